@@ -0,0 +1,13 @@
+# Results of the script run scheduled by uyuni_scheduled_action in an earlier
+# apply.
+data "uyuni_action_result" "sshd_check" {
+  action_id    = uyuni_scheduled_action.sshd_check.action_id
+  output_limit = 1024
+}
+
+check "sshd_check_succeeded" {
+  assert {
+    condition     = data.uyuni_action_result.sshd_check.status == "completed"
+    error_message = "sshd check failed on ${join(", ", [for s in data.uyuni_action_result.sshd_check.systems : s.system_name if s.status == "failed"])}"
+  }
+}
@@ -0,0 +1,12 @@
+# A key managed by another team
+data "uyuni_activation_key" "web" {
+  key = "sles15-sp6-web"
+}
+
+resource "uyuni_activation_key" "web_staging" {
+  key                  = "sles15-sp6-web-staging"
+  description          = "Staging copy of ${data.uyuni_activation_key.web.id}"
+  base_channel_label   = data.uyuni_activation_key.web.base_channel_label
+  child_channel_labels = data.uyuni_activation_key.web.child_channel_labels
+  entitlements         = data.uyuni_activation_key.web.entitlements
+}
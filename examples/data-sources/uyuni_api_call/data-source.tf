@@ -0,0 +1,8 @@
+data "uyuni_api_call" "network" {
+  path        = "system/getNetwork?sid=1000010000"
+  result_path = "$.hostname"
+}
+
+output "hostname" {
+  value = jsondecode(data.uyuni_api_call.network.result)
+}
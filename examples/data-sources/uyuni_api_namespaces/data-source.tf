@@ -0,0 +1,13 @@
+data "uyuni_api_namespaces" "server" {}
+
+# Only target systems by search on servers offering system.search
+resource "uyuni_recurring_highstate" "db" {
+  count = contains(data.uyuni_api_namespaces.server.calls, "system.search.hostname") ? 1 : 0
+
+  name = "weekly-highstate"
+  cron = "0 0 3 ? * SUN"
+
+  target {
+    search = "db"
+  }
+}
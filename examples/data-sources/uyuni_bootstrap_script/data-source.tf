@@ -0,0 +1,18 @@
+data "uyuni_bootstrap_script" "sles15" {
+  activation_key = "1-sles15-sp6"
+  proxy          = "proxy.example.com"
+}
+
+# Register the VM on first boot.
+output "user_data" {
+  value = <<-EOT
+    #cloud-config
+    write_files:
+      - path: /root/bootstrap.sh
+        permissions: "0700"
+        encoding: b64
+        content: ${base64encode(data.uyuni_bootstrap_script.sles15.content)}
+    runcmd:
+      - /root/bootstrap.sh
+  EOT
+}
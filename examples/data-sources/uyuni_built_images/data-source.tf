@@ -0,0 +1,32 @@
+data "uyuni_built_images" "web" {
+  profile_label = "web-frontend"
+}
+
+resource "kubernetes_deployment" "web" {
+  metadata {
+    name = "web-frontend"
+  }
+
+  spec {
+    selector {
+      match_labels = {
+        app = "web-frontend"
+      }
+    }
+
+    template {
+      metadata {
+        labels = {
+          app = "web-frontend"
+        }
+      }
+
+      spec {
+        container {
+          name  = "web"
+          image = data.uyuni_built_images.web.images[0].reference
+        }
+      }
+    }
+  }
+}
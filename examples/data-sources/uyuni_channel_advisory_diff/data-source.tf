@@ -0,0 +1,13 @@
+data "uyuni_channel_advisory_diff" "prod" {
+  upstream_channel_label   = "sles15-sp6-updates"
+  downstream_channel_label = "prod-sles15-sp6-updates"
+}
+
+# Promote the security advisories production is missing.
+resource "uyuni_errata_clone" "security" {
+  parent_channel_label = "prod-sles15-sp6-pool"
+  advisories = [
+    for erratum in data.uyuni_channel_advisory_diff.prod.missing : erratum.advisory_name
+    if erratum.type == "Security Advisory"
+  ]
+}
@@ -0,0 +1,14 @@
+data "uyuni_channel_errata" "updates" {
+  channel_label = "sles15-sp6-updates"
+  start_date    = "2024-08-01"
+  end_date      = "2024-09-01"
+}
+
+# Clone the critical and important security advisories of August.
+resource "uyuni_errata_clone" "august" {
+  parent_channel_label = "prod-sles15-sp6-pool"
+  advisories = [
+    for erratum in data.uyuni_channel_errata.updates.errata : erratum.advisory_name
+    if contains(["critical", "important"], coalesce(erratum.severity, "none"))
+  ]
+}
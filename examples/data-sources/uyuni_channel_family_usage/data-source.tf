@@ -0,0 +1,10 @@
+data "uyuni_channel_family_usage" "all" {}
+
+# Organizations which use up a channel family, to move systems to
+# organizations with subscriptions left.
+output "exhausted_channel_families" {
+  value = {
+    for family in data.uyuni_channel_family_usage.all.channel_families :
+    "${family.org_name}/${family.name}" => family.used if family.free == 0 && family.allocated > 0
+  }
+}
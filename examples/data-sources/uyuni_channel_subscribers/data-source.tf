@@ -0,0 +1,10 @@
+data "uyuni_channel_subscribers" "legacy" {
+  channel_label = "sles15-sp4-updates-x86_64"
+}
+
+check "legacy_channel_unused" {
+  assert {
+    condition     = length(data.uyuni_channel_subscribers.legacy.system_ids) == 0
+    error_message = "Systems still use the channel: ${join(", ", data.uyuni_channel_subscribers.legacy.systems[*].name)}"
+  }
+}
@@ -0,0 +1,9 @@
+# Vendor update channels of SLES 15 SP6
+data "uyuni_channels" "sp6_updates" {
+  label_regex   = "sles15-sp6-updates"
+  provider_name = "SUSE"
+}
+
+output "sp6_update_channels" {
+  value = data.uyuni_channels.sp6_updates.labels
+}
@@ -0,0 +1,12 @@
+data "uyuni_config_channel_export" "base" {
+  channel = "base"
+}
+
+# Files whose content on the server differs from the sources held in git,
+# e.g. because they were edited in the web UI.
+output "unmanaged_edits" {
+  value = [
+    for file, checksum in data.uyuni_config_channel_export.base.checksums : file
+    if !fileexists("${path.module}/files${file}") || filesha256("${path.module}/files${file}") != checksum
+  ]
+}
@@ -0,0 +1,15 @@
+data "uyuni_config_file_content" "motd" {
+  channel = "base"
+  path    = "/etc/motd"
+}
+
+output "cloud_init" {
+  value = yamlencode({
+    write_files = [{
+      path        = data.uyuni_config_file_content.motd.path
+      encoding    = "b64"
+      content     = data.uyuni_config_file_content.motd.content_base64
+      permissions = "0${data.uyuni_config_file_content.motd.permissions}"
+    }]
+  })
+}
@@ -0,0 +1,16 @@
+data "uyuni_contact_methods" "all" {}
+
+variable "contact_method" {
+  type    = string
+  default = "ssh-push"
+
+  validation {
+    condition     = contains(data.uyuni_contact_methods.all.contact_methods, var.contact_method)
+    error_message = "Unknown contact method."
+  }
+}
+
+resource "uyuni_activation_key" "dmz" {
+  key            = "dmz"
+  contact_method = var.contact_method
+}
@@ -0,0 +1,3 @@
+data "uyuni_crypto_keys" "gpg" {
+  type = "GPG"
+}
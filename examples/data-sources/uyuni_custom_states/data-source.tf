@@ -0,0 +1,12 @@
+data "uyuni_custom_states" "all" {}
+
+locals {
+  required_states = ["hardening", "auditd"]
+}
+
+check "custom_states_exist" {
+  assert {
+    condition     = alltrue([for label in local.required_states : contains(data.uyuni_custom_states.all.labels, label)])
+    error_message = "Missing custom states: ${join(", ", setsubtract(local.required_states, data.uyuni_custom_states.all.labels))}"
+  }
+}
@@ -0,0 +1,28 @@
+data "uyuni_entitlement_usage" "retail" {
+  org_id = 2
+}
+
+locals {
+  free_monitoring = one([
+    for entitlement in data.uyuni_entitlement_usage.retail.entitlements :
+    entitlement.free if entitlement.label == "monitoring_entitled"
+  ])
+}
+
+variable "branch_servers" {
+  type = number
+}
+
+# Refuse to hand out a key for more monitored servers than entitlements left
+resource "uyuni_activation_key" "branch" {
+  key          = "branch"
+  entitlements = ["monitoring_entitled"]
+  usage_limit  = var.branch_servers
+
+  lifecycle {
+    precondition {
+      condition     = var.branch_servers <= local.free_monitoring
+      error_message = "Not enough monitoring entitlements left for the branch servers."
+    }
+  }
+}
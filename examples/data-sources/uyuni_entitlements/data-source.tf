@@ -0,0 +1,18 @@
+data "uyuni_entitlements" "all" {}
+
+variable "entitlements" {
+  type    = set(string)
+  default = ["monitoring_entitled"]
+}
+
+resource "uyuni_activation_key" "monitored" {
+  key          = "monitored"
+  entitlements = var.entitlements
+
+  lifecycle {
+    precondition {
+      condition     = length(setsubtract(var.entitlements, data.uyuni_entitlements.all.labels)) == 0
+      error_message = "The server does not offer all of the entitlements."
+    }
+  }
+}
@@ -0,0 +1,11 @@
+data "uyuni_erratum" "openssl" {
+  advisory_name = "SUSE-2024-2930"
+}
+
+output "change_ticket" {
+  value = <<-EOT
+    ${data.uyuni_erratum.openssl.synopsis} (${coalesce(data.uyuni_erratum.openssl.severity, "no severity")})
+    CVEs: ${join(", ", sort(data.uyuni_erratum.openssl.cves))}
+    Packages: ${join(", ", data.uyuni_erratum.openssl.packages[*].nevra)}
+  EOT
+}
@@ -0,0 +1,31 @@
+# Formula layouts are read from a copy of the formula metadata of the
+# server, e.g. synced with rsync before the run.
+data "uyuni_formula_catalog" "all" {
+  metadata_dirs = [
+    "${path.module}/formula_metadata/custom",
+    "${path.module}/formula_metadata/packaged",
+  ]
+}
+
+variable "formulas" {
+  type    = set(string)
+  default = ["locale", "prometheus-exporters"]
+}
+
+locals {
+  unknown_formulas = setsubtract(var.formulas, data.uyuni_formula_catalog.all.names)
+
+  # Pillar keys each formula expects, from its form layout.
+  formula_keys = {
+    for formula in data.uyuni_formula_catalog.all.formulas :
+    formula.name => keys(yamldecode(formula.layout))
+    if formula.layout != null
+  }
+}
+
+check "formulas_installed" {
+  assert {
+    condition     = length(local.unknown_formulas) == 0
+    error_message = "Formulas not installed on the server: ${join(", ", local.unknown_formulas)}"
+  }
+}
@@ -0,0 +1,12 @@
+data "uyuni_group_errata_compliance" "prod" {
+  group_name   = "prod"
+  max_age_days = 14
+  severities   = ["critical", "important"]
+
+  lifecycle {
+    postcondition {
+      condition     = self.compliant
+      error_message = "Systems ${join(", ", self.non_compliant_system_ids)} have overdue security advisories."
+    }
+  }
+}
@@ -0,0 +1,3 @@
+data "uyuni_group_patch_status" "example" {
+  group_names = ["test", "prod"]
+}
@@ -0,0 +1,31 @@
+provider "uyuni" {
+  host     = "hub.example.com"
+  username = "admin"
+  password = var.hub_password
+
+  servers = {
+    eu = {
+      host     = "peripheral-eu.example.com"
+      username = "admin"
+      password = var.peripheral_passwords["eu"]
+    }
+    us = {
+      host     = "peripheral-us.example.com"
+      username = "admin"
+      password = var.peripheral_passwords["us"]
+    }
+  }
+}
+
+# Systems of all peripherals, skipping those which cannot be reached.
+data "uyuni_hub_systems" "estate" {
+  include_hub        = false
+  ignore_unreachable = true
+}
+
+output "outdated_systems" {
+  value = [
+    for s in data.uyuni_hub_systems.estate.systems : "${s.server_alias}/${s.name}"
+    if s.outdated_pkg_count > 0
+  ]
+}
@@ -0,0 +1,9 @@
+data "uyuni_kickstart_file" "web" {
+  profile_label = "sles15-sp6-web"
+}
+
+# Serve the rendered profile from the PXE server
+resource "local_sensitive_file" "autoyast" {
+  content  = data.uyuni_kickstart_file.web.content
+  filename = "/srv/tftpboot/autoyast/sles15-sp6-web.xml"
+}
@@ -0,0 +1,18 @@
+# Wait for the rebuild of web01 before applying its highstate.
+data "uyuni_kickstart_session_status" "web01" {
+  system_ids = [uyuni_system_reprovision.web01.system_id]
+  since      = uyuni_system_reprovision.web01.earliest_occurrence
+  wait       = true
+
+  timeouts {
+    read = "2h"
+  }
+}
+
+resource "uyuni_scheduled_action" "highstate" {
+  type = "highstate"
+
+  target {
+    system_ids = [for system in data.uyuni_kickstart_session_status.web01.systems : system.system_id if system.state == "completed"]
+  }
+}
@@ -0,0 +1,11 @@
+data "uyuni_minion_pillar" "web01" {
+  system_id = 1000010001
+}
+
+locals {
+  pillar = jsondecode(data.uyuni_minion_pillar.web01.pillar)
+}
+
+output "node_exporter_address" {
+  value = try(local.pillar.exporters.node_exporter.address, null)
+}
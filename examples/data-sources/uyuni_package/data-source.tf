@@ -0,0 +1,6 @@
+data "uyuni_package" "openssl" {
+  name    = "openssl-3"
+  version = "3.1.4"
+  release = "150600.5.10.1"
+  arch    = "x86_64"
+}
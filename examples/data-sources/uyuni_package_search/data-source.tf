@@ -0,0 +1,8 @@
+data "uyuni_package_search" "openssl" {
+  query = "name:openssl-3 AND arch:x86_64"
+}
+
+resource "uyuni_channel_packages" "curated" {
+  channel_label = "curated-sles15-sp6"
+  package_ids   = data.uyuni_package_search.openssl.ids
+}
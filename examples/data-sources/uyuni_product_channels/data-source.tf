@@ -0,0 +1,19 @@
+data "uyuni_product_channels" "sles15sp6" {
+  name    = "SUSE Linux Enterprise Server"
+  version = "15 SP6"
+  arch    = "x86_64"
+}
+
+resource "uyuni_activation_key" "sles15sp6" {
+  key                  = "sles15sp6"
+  description          = "SLES 15 SP6"
+  base_channel_label   = data.uyuni_product_channels.sles15sp6.base_channel_label
+  child_channel_labels = data.uyuni_product_channels.sles15sp6.child_channel_labels
+
+  lifecycle {
+    precondition {
+      condition     = length(data.uyuni_product_channels.sles15sp6.missing_channel_labels) == 0
+      error_message = "Channels not synced yet: ${join(", ", data.uyuni_product_channels.sles15sp6.missing_channel_labels)}"
+    }
+  }
+}
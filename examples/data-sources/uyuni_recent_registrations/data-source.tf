@@ -0,0 +1,18 @@
+data "uyuni_recent_registrations" "today" {
+  max_age_hours = 24
+}
+
+# Apply the highstate on the systems registered today
+resource "uyuni_scheduled_action" "onboarding" {
+  target {
+    system_ids = data.uyuni_recent_registrations.today.system_ids
+  }
+
+  script = <<-EOT
+    salt-call state.apply
+  EOT
+
+  triggers = {
+    systems = join(",", sort(data.uyuni_recent_registrations.today.system_ids))
+  }
+}
@@ -0,0 +1,8 @@
+data "uyuni_ssh_push_keys" "dmz" {
+  proxy = "proxy-dmz.example.com"
+}
+
+# Authorize the proxy in the cloud-init of new systems behind it
+output "ssh_authorized_key" {
+  value = data.uyuni_ssh_push_keys.dmz.authorized_key
+}
@@ -0,0 +1,13 @@
+data "uyuni_system_count_by_channel" "all" {}
+
+output "systems_per_product" {
+  value = data.uyuni_system_count_by_channel.all.counts
+}
+
+# Warn before the SLES subscriptions run out
+check "sles_subscriptions" {
+  assert {
+    condition     = lookup(data.uyuni_system_count_by_channel.all.counts, "sle-product-sles15-sp6-pool-x86_64", 0) <= 50
+    error_message = "More SLES 15 SP6 systems than subscriptions."
+  }
+}
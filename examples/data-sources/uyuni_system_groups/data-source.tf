@@ -0,0 +1,11 @@
+# Store groups like B042
+data "uyuni_system_groups" "stores" {
+  name_regex = "^B[0-9]+$"
+}
+
+output "empty_stores" {
+  value = [
+    for group in data.uyuni_system_groups.stores.groups : group.name
+    if group.system_count == 0
+  ]
+}
@@ -0,0 +1,12 @@
+data "uyuni_system_os_info" "web01" {
+  system_id = 1000010000
+}
+
+# Pick the formula for the package manager of the system
+locals {
+  package_manager = {
+    suse   = "zypper"
+    redhat = "dnf"
+    debian = "apt"
+  }[data.uyuni_system_os_info.web01.os_family]
+}
@@ -0,0 +1,7 @@
+data "uyuni_system_snapshots" "web01" {
+  system_id = 1000010001
+}
+
+output "latest_snapshot_id" {
+  value = data.uyuni_system_snapshots.web01.snapshots[0].id
+}
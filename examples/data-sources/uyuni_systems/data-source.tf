@@ -0,0 +1,23 @@
+data "uyuni_systems" "web" {
+  name_regex = "^web"
+  group_name = "production"
+}
+
+# Web servers of production still on SLES 15 SP5
+output "sp5_web_servers" {
+  value = [
+    for system in data.uyuni_systems.web.systems : system.name
+    if system.base_channel_label == "sle-product-sles15-sp5-pool-x86_64"
+  ]
+}
+
+data "uyuni_systems" "all" {
+  include_os_info = true
+}
+
+# Systems by OS family, whatever distribution they run
+output "systems_by_os_family" {
+  value = {
+    for system in data.uyuni_systems.all.systems : coalesce(system.os_family, "unknown") => system.name...
+  }
+}
@@ -0,0 +1,19 @@
+variable "reviewed_logins" {
+  type    = set(string)
+  default = ["jdoe", "ops"]
+}
+
+data "uyuni_user_permissions" "review" {
+  for_each = var.reviewed_logins
+  login    = each.value
+}
+
+output "access_review" {
+  value = {
+    for login, permissions in data.uyuni_user_permissions.review : login => {
+      roles               = permissions.roles
+      administered_groups = permissions.administered_groups
+      managed_channels    = [for channel in permissions.channels : channel.label if channel.manageable]
+    }
+  }
+}
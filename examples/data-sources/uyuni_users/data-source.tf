@@ -0,0 +1,12 @@
+data "uyuni_users" "all" {
+  include_roles = true
+  parallelism   = 16
+}
+
+output "disabled_users" {
+  value = [for user in data.uyuni_users.all.user : user.login if !user.enabled]
+}
+
+output "org_admins" {
+  value = [for user in data.uyuni_users.all.user : user.login if contains(user.roles, "org_admin")]
+}
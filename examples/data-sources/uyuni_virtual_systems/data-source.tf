@@ -0,0 +1,9 @@
+data "uyuni_virtual_systems" "all" {}
+
+# Hosts of the database servers, e.g. to check that no two of them share one.
+output "db_hosts" {
+  value = {
+    for guest in data.uyuni_virtual_systems.all.guests : guest.name => guest.host_name
+    if startswith(coalesce(guest.name, ""), "db")
+  }
+}
@@ -0,0 +1,25 @@
+# Patch the production systems and reboot them in the weekend window,
+# each month again
+resource "uyuni_action_chain" "patch_window" {
+  label = "patch-${formatdate("YYYY-MM", var.patch_date)}"
+
+  target {
+    group_names = ["production"]
+  }
+
+  steps = [
+    { type = "errata" },
+    { type = "reboot" },
+  ]
+
+  not_before = var.patch_date
+
+  timeouts {
+    create = "4h"
+  }
+}
+
+variable "patch_date" {
+  description = "Start of the patch window, e.g. 2030-01-05T22:00:00Z."
+  type        = string
+}
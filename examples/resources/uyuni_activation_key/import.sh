@@ -0,0 +1,2 @@
+# Import a key by its full key, prefixed with the organization ID.
+terraform import uyuni_activation_key.web 1-web
@@ -0,0 +1,41 @@
+# Web servers registering with this key are managed via SSH push and
+# monitored, no further setup needed after registration
+resource "uyuni_activation_key" "web" {
+  key                = "web"
+  description        = "SLES 15 SP6 web servers"
+  base_channel_label = "sle-product-sles15-sp6-pool-x86_64"
+  contact_method     = "ssh-push"
+  entitlements       = ["monitoring_entitled"]
+
+  child_channel_labels = [
+    "sle-module-basesystem15-sp6-pool-x86_64",
+    "sle-module-basesystem15-sp6-updates-x86_64",
+  ]
+
+  packages = [
+    { name = "golang-github-prometheus-node_exporter" },
+  ]
+}
+
+# Fallback for systems registering without a key
+resource "uyuni_activation_key" "default" {
+  key               = "default"
+  universal_default = true
+  usage_limit       = 50
+}
+
+# Key generated by the server for bootstrapping branch servers. Bump the
+# trigger to rotate it after a leak, everything referencing the id gets the
+# new key in the same apply.
+resource "uyuni_activation_key" "branch" {
+  description = "Branch servers"
+
+  rotation_triggers = {
+    rotated = "2026-10-16"
+  }
+}
+
+output "branch_activation_key" {
+  value     = uyuni_activation_key.branch.id
+  sensitive = true
+}
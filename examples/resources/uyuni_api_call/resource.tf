@@ -0,0 +1,28 @@
+# A system group managed through raw API calls, with its ID being the group name.
+resource "uyuni_api_call" "web_group" {
+  create {
+    path   = "systemgroup/create"
+    method = "POST"
+    body   = jsonencode({
+      name        = "web servers"
+      description = "Web servers"
+    })
+  }
+
+  read {
+    path = "systemgroup/getDetails?systemGroupName={id}"
+  }
+
+  delete {
+    path   = "systemgroup/delete"
+    method = "POST"
+    body   = jsonencode({ systemGroupName = "{id}" })
+  }
+
+  id_path     = "$.name"
+  result_path = "$.system_count"
+}
+
+output "web_group_systems" {
+  value = jsondecode(uyuni_api_call.web_group.result)
+}
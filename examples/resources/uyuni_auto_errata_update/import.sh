@@ -0,0 +1,4 @@
+# The setting is imported by "system:" followed by the system ID, or "group:"
+# followed by the group name.
+terraform import uyuni_auto_errata_update.db01 system:1000010002
+terraform import uyuni_auto_errata_update.test_fleet group:test
@@ -0,0 +1,10 @@
+# Low-risk systems apply errata as soon as they are published.
+resource "uyuni_auto_errata_update" "test_fleet" {
+  group_name = "test"
+}
+
+# A single system stays under manual patching.
+resource "uyuni_auto_errata_update" "db01" {
+  system_id = 1000010002
+  enabled   = false
+}
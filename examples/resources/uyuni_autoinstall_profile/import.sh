@@ -0,0 +1,2 @@
+# Import a profile by its label.
+terraform import uyuni_autoinstall_profile.web sles15-sp6-web
@@ -0,0 +1,20 @@
+# AutoYaST profile templated in the repository, uploaded verbatim
+resource "uyuni_autoinstall_profile" "web" {
+  label      = "sles15-sp6-web"
+  tree_label = "sles15-sp6-x86_64"
+  content = templatefile("${path.module}/autoyast.xml.tftpl", {
+    hostname_prefix = "web"
+  })
+}
+
+# AutoYaST profile passing per-profile cloud-init user-data to the installed
+# system, referenced as $user_data in the profile.
+resource "uyuni_autoinstall_profile" "db" {
+  label      = "sles15-sp6-db"
+  tree_label = "sles15-sp6-x86_64"
+  content    = file("${path.module}/autoyast-cloud-init.xml")
+
+  variables = {
+    user_data = file("${path.module}/db-user-data.yaml")
+  }
+}
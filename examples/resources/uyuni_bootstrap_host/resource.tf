@@ -0,0 +1,24 @@
+# Register a VM created by another provider in the same apply
+resource "uyuni_bootstrap_host" "web01" {
+  host           = aws_instance.web01.private_ip
+  system_name    = "web01.example.com"
+  user           = "ec2-user"
+  private_key    = file("~/.ssh/bootstrap")
+  activation_key = uyuni_activation_key.sles15sp6.id
+
+  timeouts {
+    create = "30m"
+  }
+}
+
+# Accept the key of a minion configured by cloud-init. The database server
+# is only deleted in its maintenance window.
+resource "uyuni_bootstrap_host" "db01" {
+  host                          = "db01.example.com"
+  accept_pending_minion         = true
+  destroy_in_maintenance_window = true
+}
+
+resource "uyuni_system_refresh" "web01" {
+  system_id = uyuni_bootstrap_host.web01.system_id
+}
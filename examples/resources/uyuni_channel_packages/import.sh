@@ -0,0 +1,2 @@
+# Import all packages of a channel by its label.
+terraform import uyuni_channel_packages.curated curated-sles15-sp6
@@ -0,0 +1,11 @@
+data "uyuni_package" "openssl" {
+  name    = "openssl-3"
+  version = "3.1.4"
+  release = "150600.5.10.1"
+  arch    = "x86_64"
+}
+
+resource "uyuni_channel_packages" "curated" {
+  channel_label = "curated-sles15-sp6"
+  package_ids   = [data.uyuni_package.openssl.id]
+}
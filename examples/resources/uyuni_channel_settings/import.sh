@@ -0,0 +1,2 @@
+# Import the settings of a channel by its label.
+terraform import uyuni_channel_settings.internal_tools internal-tools-sles15sp6-x86_64
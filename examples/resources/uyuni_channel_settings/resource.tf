@@ -0,0 +1,7 @@
+# Move a populated custom channel to stronger repository checksums without
+# recreating it
+resource "uyuni_channel_settings" "internal_tools" {
+  channel_label = "internal-tools-sles15sp6-x86_64"
+  checksum_type = "sha512"
+  summary       = "Internal tools for SLES 15 SP6"
+}
@@ -0,0 +1,6 @@
+# Advance the frozen clone to the first of the current month.
+resource "uyuni_channel_sync" "frozen" {
+  channel_label        = "frozen-sles15-sp6-updates-x86_64"
+  source_channel_label = "sle-module-basesystem15-sp6-updates-x86_64"
+  cutoff_date          = formatdate("YYYY-MM-01", plantimestamp())
+}
@@ -0,0 +1,17 @@
+# Development copy of SLES 15 SP6 in its original state, brought up to the
+# first of the month by cloning errata
+resource "uyuni_channel_tree" "dev" {
+  source_label   = "sle-product-sles15-sp6-pool-x86_64"
+  prefix         = "dev-"
+  original_state = true
+  on_destroy     = "orphan"
+}
+
+resource "uyuni_errata_clone" "dev" {
+  parent_channel_label = uyuni_channel_tree.dev.id
+  end_date             = "2024-09-01"
+}
+
+output "dev_updates_channel" {
+  value = uyuni_channel_tree.dev.labels["sle-module-basesystem15-sp6-updates-x86_64"]
+}
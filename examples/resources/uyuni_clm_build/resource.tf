@@ -0,0 +1,16 @@
+# Build the project into dev whenever its sources or filters change.
+resource "uyuni_clm_build" "sles15_sp6" {
+  project_label = uyuni_clm_project.sles15_sp6.label
+  message       = "Built by Terraform"
+
+  triggers = {
+    sources = join(",", [uyuni_clm_source.pool.id, uyuni_clm_source.updates.id])
+    filters = join(",", uyuni_clm_project.sles15_sp6.filter_ids)
+  }
+
+  timeouts {
+    create = "2h"
+  }
+
+  depends_on = [uyuni_clm_environment.dev]
+}
@@ -0,0 +1,2 @@
+# Environments are imported by the project label and their label, separated by a colon.
+terraform import uyuni_clm_environment.test sles15-sp6:test
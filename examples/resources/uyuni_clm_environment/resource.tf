@@ -0,0 +1,19 @@
+resource "uyuni_clm_environment" "dev" {
+  project_label = uyuni_clm_project.sles15_sp6.label
+  label         = "dev"
+  name          = "Development"
+}
+
+resource "uyuni_clm_environment" "test" {
+  project_label     = uyuni_clm_project.sles15_sp6.label
+  label             = "test"
+  name              = "Test"
+  predecessor_label = uyuni_clm_environment.dev.label
+}
+
+resource "uyuni_clm_environment" "prod" {
+  project_label     = uyuni_clm_project.sles15_sp6.label
+  label             = "prod"
+  name              = "Production"
+  predecessor_label = uyuni_clm_environment.test.label
+}
@@ -0,0 +1,2 @@
+# Filters are imported by their ID.
+terraform import uyuni_clm_filter.no_kernel_rt 12
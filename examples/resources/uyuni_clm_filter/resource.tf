@@ -0,0 +1,18 @@
+resource "uyuni_clm_filter" "no_kernel_rt" {
+  name        = "no-kernel-rt"
+  rule        = "deny"
+  entity_type = "package"
+  matcher     = "contains"
+  field       = "name"
+  value       = "kernel-rt"
+}
+
+# Freeze errata at the start of the quarter.
+resource "uyuni_clm_filter" "errata_until_q3" {
+  name        = "errata-until-2026-07-01"
+  rule        = "deny"
+  entity_type = "erratum"
+  matcher     = "greatereq"
+  field       = "issue_date"
+  value       = "2026-07-01T00:00:00Z"
+}
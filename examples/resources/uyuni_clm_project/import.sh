@@ -0,0 +1,2 @@
+# Projects are imported by their label.
+terraform import uyuni_clm_project.sles15_sp6 sles15-sp6
@@ -0,0 +1,15 @@
+resource "uyuni_clm_filter" "no_kernel_rt" {
+  name        = "no-kernel-rt"
+  rule        = "deny"
+  entity_type = "package"
+  matcher     = "contains"
+  field       = "name"
+  value       = "kernel-rt"
+}
+
+resource "uyuni_clm_project" "sles15_sp6" {
+  label       = "sles15-sp6"
+  name        = "SLES 15 SP6"
+  description = "Patches for SLES 15 SP6, promoted from dev to test to prod"
+  filter_ids  = [uyuni_clm_filter.no_kernel_rt.filter_id]
+}
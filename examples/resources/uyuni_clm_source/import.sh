@@ -0,0 +1,2 @@
+# Sources are imported by the project label and the channel label, separated by a colon.
+terraform import uyuni_clm_source.pool sles15-sp6:sle-product-sles15-sp6-pool-x86_64
@@ -0,0 +1,9 @@
+resource "uyuni_clm_source" "pool" {
+  project_label = uyuni_clm_project.sles15_sp6.label
+  channel_label = "sle-product-sles15-sp6-pool-x86_64"
+}
+
+resource "uyuni_clm_source" "updates" {
+  project_label = uyuni_clm_project.sles15_sp6.label
+  channel_label = "sle-product-sles15-sp6-updates-x86_64"
+}
@@ -0,0 +1,2 @@
+# Channels are imported by their label.
+terraform import uyuni_config_channel.motd motd
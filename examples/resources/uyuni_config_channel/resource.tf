@@ -0,0 +1,13 @@
+# Channel of files deployed as they are
+resource "uyuni_config_channel" "motd" {
+  label       = "motd"
+  name        = "Message of the day"
+  description = "Login banners of all hosts"
+}
+
+# Channel of Salt states, applied from its /init.sls
+resource "uyuni_config_channel" "sshd" {
+  label = "sshd"
+  name  = "SSH daemon"
+  type  = "state"
+}
@@ -0,0 +1,2 @@
+# Files are imported by the channel label and their path, separated by a colon.
+terraform import uyuni_config_file.motd motd:/etc/motd
@@ -0,0 +1,43 @@
+# Text file with a macro the server replaces when deploying it
+resource "uyuni_config_file" "motd" {
+  channel = uyuni_config_channel.motd.label
+  path    = "/etc/motd"
+  content = "Welcome to {| rhn.system.hostname |}\n"
+}
+
+# Salt state kept in the repository
+resource "uyuni_config_file" "sshd_init" {
+  channel = uyuni_config_channel.sshd.label
+  path    = "/init.sls"
+  content = file("${path.module}/states/sshd/init.sls")
+}
+
+resource "uyuni_config_file" "sshd_config" {
+  channel     = uyuni_config_channel.sshd.label
+  path        = "/sshd_config"
+  content     = templatefile("${path.module}/states/sshd/sshd_config.tftpl", { permit_root_login = "no" })
+  permissions = "600"
+}
+
+# Binary file
+resource "uyuni_config_file" "logo" {
+  channel        = uyuni_config_channel.motd.label
+  path           = "/usr/share/pixmaps/company-logo.png"
+  content_base64 = filebase64("${path.module}/company-logo.png")
+}
+
+# Keytab uploaded from a local file, kept out of the state
+resource "uyuni_config_file" "keytab" {
+  channel     = uyuni_config_channel.kerberos.label
+  path        = "/etc/krb5.keytab"
+  source_file = "${path.module}/secrets/krb5.keytab"
+  permissions = "600"
+}
+
+# File rolled back to revision 3, keeping only the last 10 revisions
+resource "uyuni_config_file" "issue" {
+  channel         = uyuni_config_channel.motd.label
+  path            = "/etc/issue"
+  pinned_revision = 3
+  keep_revisions  = 10
+}
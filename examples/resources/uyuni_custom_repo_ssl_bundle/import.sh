@@ -0,0 +1,2 @@
+# Import a bundle by its name, the keys are read from the server.
+terraform import uyuni_custom_repo_ssl_bundle.rhel_cdn rhel-cdn
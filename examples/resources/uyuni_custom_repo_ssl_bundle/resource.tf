@@ -0,0 +1,15 @@
+# Entitlement certificate of a RHEL subscription
+resource "uyuni_custom_repo_ssl_bundle" "rhel_cdn" {
+  name        = "rhel-cdn"
+  ca_cert     = file("${path.module}/redhat-uep.pem")
+  client_cert = file("${path.module}/entitlement.pem")
+  client_key  = file("${path.module}/entitlement-key.pem")
+}
+
+resource "uyuni_repository" "rhel9_baseos" {
+  label                = "rhel9-baseos-x86_64"
+  url                  = "https://cdn.redhat.com/content/dist/rhel9/9/x86_64/baseos/os/"
+  ssl_ca_cert_name     = uyuni_custom_repo_ssl_bundle.rhel_cdn.ca_cert_name
+  ssl_client_cert_name = uyuni_custom_repo_ssl_bundle.rhel_cdn.client_cert_name
+  ssl_client_key_name  = uyuni_custom_repo_ssl_bundle.rhel_cdn.client_key_name
+}
@@ -0,0 +1,2 @@
+# The policy is imported by the name of the group.
+terraform import uyuni_custom_values_policy.web web
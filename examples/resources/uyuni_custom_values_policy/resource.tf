@@ -0,0 +1,22 @@
+# Every web server must name its owner and cost center. New members and
+# values changed by hand show as a change and are fixed on apply.
+resource "uyuni_custom_values_policy" "web" {
+  group_name = "web"
+  values = {
+    owner       = "team-web"
+    cost_center = "4711"
+  }
+}
+
+# Only report database servers without a backup class.
+resource "uyuni_custom_values_policy" "db" {
+  group_name = "db"
+  values = {
+    backup_class = "gold"
+  }
+  enforce = false
+}
+
+output "db_without_backup_class" {
+  value = uyuni_custom_values_policy.db.non_compliant_system_ids
+}
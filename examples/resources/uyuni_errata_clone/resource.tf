@@ -0,0 +1,14 @@
+# Monthly patch import into the production tree
+resource "uyuni_errata_clone" "prod" {
+  parent_channel_label = "prod-sles15-sp6-pool-x86_64"
+  end_date             = "2025-02-01"
+}
+
+# Urgent fixes ahead of the next import
+resource "uyuni_errata_clone" "prod_hotfixes" {
+  parent_channel_label = "prod-sles15-sp6-pool-x86_64"
+  advisories = [
+    "SUSE-2025-0301",
+    "SUSE-2025-0317",
+  ]
+}
@@ -0,0 +1,2 @@
+# Import a key by its description.
+terraform import uyuni_gpg_key.custom_channels custom-channels
@@ -0,0 +1,8 @@
+# Trust the key signing the custom channels on new installations and on the
+# web servers already registered
+resource "uyuni_gpg_key" "custom_channels" {
+  description          = "custom-channels"
+  content              = file("${path.module}/custom-channels.asc")
+  autoinstall_profiles = [uyuni_autoinstall_profile.web.label]
+  system_ids           = [1000010001, 1000010002]
+}
@@ -0,0 +1,2 @@
+# All configuration channels of a group are imported by the group name.
+terraform import uyuni_group_config_channels.web web
@@ -0,0 +1,6 @@
+# Baseline states for all web servers, systems of the group can override
+# them with channels assigned to the system.
+resource "uyuni_group_config_channels" "web" {
+  group_name     = "web"
+  channel_labels = ["hardening", "motd"]
+}
@@ -0,0 +1,2 @@
+# Channel selections are imported by peripheral FQDN.
+terraform import uyuni_hub_peripheral_channels.example uyuni-emea.example.com
@@ -0,0 +1,7 @@
+resource "uyuni_hub_peripheral_channels" "emea" {
+  peripheral_fqdn = "uyuni-emea.example.com"
+  channel_labels = [
+    "sle-product-sles15-sp6-pool-x86_64",
+    "sle-product-sles15-sp6-updates-x86_64",
+  ]
+}
@@ -0,0 +1,19 @@
+# Delete systems which have not checked in for 90 days once a week, except
+# for production systems, which may be switched off for longer.
+resource "time_rotating" "weekly" {
+  rotation_days = 7
+}
+
+resource "uyuni_inactive_systems_cleanup" "stale" {
+  inactive_days    = 90
+  protected_groups = ["production"]
+  max_systems      = 50
+
+  triggers = {
+    week = time_rotating.weekly.id
+  }
+}
+
+output "deleted_systems" {
+  value = uyuni_inactive_systems_cleanup.stale.system_ids
+}
@@ -0,0 +1,2 @@
+# Trusts are imported by both organization IDs, separated by a colon.
+terraform import uyuni_org_trust.tenant_a_tenant_b 2:3
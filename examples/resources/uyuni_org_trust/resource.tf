@@ -0,0 +1,4 @@
+resource "uyuni_org_trust" "tenant_a_tenant_b" {
+  org_id         = uyuni_organization.tenant_a.org_id
+  trusted_org_id = uyuni_organization.tenant_b.org_id
+}
@@ -0,0 +1,2 @@
+# Organizations are imported by their ID.
+terraform import uyuni_organization.tenant_a 2
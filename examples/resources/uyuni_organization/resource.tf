@@ -0,0 +1,40 @@
+variable "tenant_a_admin_password" {
+  type      = string
+  sensitive = true
+}
+
+resource "uyuni_organization" "tenant_a" {
+  name             = "Tenant A"
+  admin_login      = "tenant-a-admin"
+  admin_password   = var.tenant_a_admin_password
+  admin_first_name = "Tenant"
+  admin_last_name  = "Admin"
+  admin_email      = "uyuni-admin@tenant-a.example.com"
+
+  system_entitlements = {
+    monitoring_entitled = 10
+  }
+}
+
+# Objects of the organization are managed as its administrator.
+resource "uyuni_system_group" "tenant_a_web" {
+  name        = "web"
+  description = "Web servers of tenant A"
+
+  org {
+    username = uyuni_organization.tenant_a.admin_login
+    password = var.tenant_a_admin_password
+  }
+}
+
+# Tenant B is handed over to its administrator, who sets their own password
+# through the password reset of the login page.
+resource "uyuni_organization" "tenant_b" {
+  name             = "Tenant B"
+  admin_login      = "tenant-b-admin"
+  admin_password   = "initial-password"
+  admin_handover   = true
+  admin_first_name = "Tenant"
+  admin_last_name  = "Admin"
+  admin_email      = "uyuni-admin@tenant-b.example.com"
+}
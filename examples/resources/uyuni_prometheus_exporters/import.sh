@@ -0,0 +1,4 @@
+# Exporters are imported by "system:" followed by the system ID, or "group:"
+# followed by the group name.
+terraform import uyuni_prometheus_exporters.db01 system:1000010002
+terraform import uyuni_prometheus_exporters.web group:web
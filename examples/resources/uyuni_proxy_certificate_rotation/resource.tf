@@ -0,0 +1,26 @@
+# Generate a new proxy certificate every 300 days, well ahead of its expiry.
+resource "time_rotating" "proxy_certificate" {
+  rotation_days = 300
+}
+
+resource "uyuni_proxy_certificate_rotation" "example" {
+  proxy_name  = "proxy.example.com"
+  server      = "uyuni.example.com"
+  email       = "admin@example.com"
+  ca_cert     = file("certs/root-ca.pem")
+  ca_key      = file("certs/root-ca.key")
+  ca_password = var.ca_password
+  cnames      = ["proxy"]
+  country     = "DE"
+  org         = "Example"
+
+  rotation_triggers = {
+    rotation = time_rotating.proxy_certificate.id
+  }
+}
+
+# Hand the new bundle over to the proxy deployment.
+resource "local_sensitive_file" "proxy_config" {
+  filename       = "proxy-config.tar.gz"
+  content_base64 = uyuni_proxy_certificate_rotation.example.config
+}
@@ -0,0 +1,14 @@
+resource "uyuni_proxy_config" "example" {
+  proxy_name = "proxy.example.com"
+  server     = "uyuni.example.com"
+  email      = "admin@example.com"
+  root_ca    = file("certs/root-ca.pem")
+  proxy_cert = file("certs/proxy.pem")
+  proxy_key  = file("certs/proxy.key")
+}
+
+# Hand the bundle over to the proxy deployment.
+resource "local_sensitive_file" "proxy_config" {
+  filename       = "proxy-config.tar.gz"
+  content_base64 = uyuni_proxy_config.example.config
+}
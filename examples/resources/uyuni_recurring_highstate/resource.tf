@@ -0,0 +1,25 @@
+# Nightly highstate of the web servers, except the canary
+resource "uyuni_recurring_highstate" "web" {
+  name            = "nightly-highstate"
+  cron            = "0 0 2 ? * *"
+  group_name      = "web"
+  exclude_systems = [1000010001]
+}
+
+# Daily dry run on the canary
+resource "uyuni_recurring_highstate" "canary" {
+  name       = "daily-highstate-test"
+  cron       = "0 0 8 ? * MON-FRI"
+  test       = true
+  system_ids = [1000010001]
+}
+
+# Weekly highstate of the database servers, found by hostname
+resource "uyuni_recurring_highstate" "db" {
+  name = "weekly-highstate"
+  cron = "0 0 3 ? * SUN"
+
+  target {
+    search = "db"
+  }
+}
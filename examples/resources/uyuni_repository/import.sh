@@ -0,0 +1,2 @@
+# Repositories are imported by their label.
+terraform import uyuni_repository.tools internal-tools-sles15
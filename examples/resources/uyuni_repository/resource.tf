@@ -0,0 +1,12 @@
+resource "uyuni_repository" "tools" {
+  label            = "internal-tools-sles15"
+  url              = "https://repo.example.com/tools/sles15/"
+  ssl_ca_cert_name = "internal-ca"
+}
+
+resource "uyuni_repository" "tools_debian" {
+  label               = "internal-tools-debian12"
+  url                 = "https://repo.example.com/tools/debian12/"
+  type                = "deb"
+  has_signed_metadata = true
+}
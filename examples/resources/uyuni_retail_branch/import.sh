@@ -0,0 +1,3 @@
+# Branches are imported by branch ID, optionally followed by the branch server system ID.
+terraform import uyuni_retail_branch.example B042
+terraform import uyuni_retail_branch.example B042:1000010042
@@ -0,0 +1,25 @@
+resource "uyuni_retail_branch" "store_042" {
+  branch_id        = "B042"
+  description      = "Store 042, Hamburg"
+  branch_server_id = 1000010042
+
+  minion_id_naming  = "HWAddress"
+  disable_id_prefix = false
+
+  download_server    = "branchserver.b042.example.com"
+  default_boot_image = "POS_Image_JeOS7"
+
+  # Keep the branch group of a store in operation.
+  deletion_protection = true
+}
+
+resource "uyuni_retail_branch" "store_043" {
+  branch_id        = "B043"
+  branch_server_id = 1000010043
+
+  # Applying the formulas to a busy branch server can take a while.
+  timeouts {
+    create = "30m"
+    update = "30m"
+  }
+}
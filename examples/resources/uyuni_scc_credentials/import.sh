@@ -0,0 +1,2 @@
+# Credentials are imported by username, the next apply stores the configured password.
+terraform import uyuni_scc_credentials.main SCC_0123456789abcdef
@@ -0,0 +1,16 @@
+# Organization credentials from the SUSE Customer Center, needed before
+# products can be added to a fresh server
+resource "uyuni_scc_credentials" "main" {
+  username = var.scc_username
+  password = var.scc_password
+  primary  = true
+}
+
+variable "scc_username" {
+  type = string
+}
+
+variable "scc_password" {
+  type      = string
+  sensitive = true
+}
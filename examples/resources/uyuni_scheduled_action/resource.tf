@@ -0,0 +1,39 @@
+# Restart the web server on all members of the group whenever its
+# configuration changes
+resource "uyuni_scheduled_action" "restart_nginx" {
+  target {
+    group_names = ["web"]
+  }
+
+  script = <<-EOT
+    set -e
+    nginx -t
+    systemctl restart nginx
+  EOT
+  interpreter = "/bin/bash"
+  timeout     = 120
+
+  triggers = {
+    config = sha256(file("${path.module}/nginx.conf"))
+  }
+}
+
+output "restart_output" {
+  value = { for result in uyuni_scheduled_action.restart_nginx.results : result.system_id => result.output }
+}
+
+# Apply the security advisory in the next maintenance window of the
+# systems and wait until it is applied
+resource "uyuni_scheduled_action" "openssl_fix" {
+  target {
+    group_names = ["web"]
+  }
+
+  type                        = "errata"
+  advisories                  = ["SUSE-SU-2024:1234-1"]
+  respect_maintenance_windows = true
+
+  timeouts {
+    create = "24h"
+  }
+}
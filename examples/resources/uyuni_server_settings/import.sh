@@ -0,0 +1,2 @@
+# The settings are imported by the organization ID.
+terraform import uyuni_server_settings.default 1
@@ -0,0 +1,14 @@
+# Download updates ahead of maintenance windows, other settings of the
+# organization are left as they are.
+resource "uyuni_server_settings" "default" {
+  org_id                  = 1
+  content_staging_enabled = true
+}
+
+# Stage package downloads alike in all tenant organizations
+resource "uyuni_server_settings" "tenants" {
+  for_each = uyuni_organization.tenant
+
+  org_id                  = each.value.org_id
+  content_staging_enabled = true
+}
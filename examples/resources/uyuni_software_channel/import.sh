@@ -0,0 +1,2 @@
+# Channels are imported by their label.
+terraform import uyuni_software_channel.tools internal-tools-sles15-sp6-x86_64
@@ -0,0 +1,17 @@
+# Internal tools as a child of the SLES 15 SP6 base channel, synced from
+# the repository of the ops team.
+resource "uyuni_software_channel" "tools" {
+  label                = "internal-tools-sles15-sp6-x86_64"
+  name                 = "Internal tools for SLES 15 SP6"
+  summary              = "Tools of the ops team"
+  arch_label           = "channel-x86_64"
+  parent_channel_label = "sle-product-sles15-sp6-pool-x86_64"
+  gpg_key_url          = "https://repo.example.com/tools/key.asc"
+  gpg_key_id           = "4A1B2C3D"
+  repository_labels    = [uyuni_repository.tools.label]
+}
+
+resource "uyuni_repository" "tools" {
+  label = "internal-tools-sles15"
+  url   = "https://repo.example.com/tools/sles15/"
+}
@@ -0,0 +1,11 @@
+# Upload the support data of a failing database server to its support
+# case, as the first step of the incident runbook.
+resource "uyuni_supportdata" "incident" {
+  system_id   = 1000010001
+  case_number = "01234567"
+  upload_geo  = "EU"
+
+  triggers = {
+    incident = "INC-4711"
+  }
+}
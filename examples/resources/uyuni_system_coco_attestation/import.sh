@@ -0,0 +1,2 @@
+# Attestation settings are imported by system ID.
+terraform import uyuni_system_coco_attestation.example 1000010000
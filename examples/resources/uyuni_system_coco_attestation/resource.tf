@@ -0,0 +1,5 @@
+resource "uyuni_system_coco_attestation" "example" {
+  system_id        = 1000010000
+  environment_type = "KVM_AMD_EPYC_GENOA"
+  attest_on_boot   = true
+}
@@ -0,0 +1,2 @@
+# Import all custom values of a system by its ID.
+terraform import uyuni_system_custom_values.web01 1000010001
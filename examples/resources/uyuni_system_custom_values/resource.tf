@@ -0,0 +1,14 @@
+provider "uyuni" {
+  default_custom_values = {
+    owner       = "platform-team"
+    cost_center = "4711"
+  }
+}
+
+# Owner and cost center come from the provider, the role is set here
+resource "uyuni_system_custom_values" "web01" {
+  system_id = 1000010001
+  values = {
+    role = "web"
+  }
+}
@@ -0,0 +1,2 @@
+# System groups are imported by their name.
+terraform import uyuni_system_group.stores stores
@@ -0,0 +1,17 @@
+data "uyuni_systems" "stores" {
+  name_regex = "^store-\\d+\\.example\\.com$"
+}
+
+# Group the store minions registered so far, administered by the store
+# operations team.
+resource "uyuni_system_group" "stores" {
+  name           = "stores"
+  description    = "Store servers"
+  administrators = ["store-ops"]
+  system_ids     = data.uyuni_systems.stores.system_ids
+
+  metadata = {
+    owner       = "store-ops"
+    cost_center = "4711"
+  }
+}
@@ -0,0 +1,5 @@
+resource "uyuni_system_org_migration" "tenant_a" {
+  from_org_id = 1
+  to_org_id   = 2
+  system_ids  = [1000010001, 1000010002]
+}
@@ -0,0 +1,14 @@
+resource "uyuni_system_refresh" "web01" {
+  system_id = 1000010001
+
+  timeouts {
+    create = "15m"
+  }
+}
+
+# Read the relevant errata only after the refresh finished
+data "uyuni_group_patch_status" "web" {
+  group_names = ["web"]
+
+  depends_on = [uyuni_system_refresh.web01]
+}
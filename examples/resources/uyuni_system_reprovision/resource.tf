@@ -0,0 +1,15 @@
+# Rebuild the system with the web profile in its next maintenance window,
+# again whenever the profile content changes.
+resource "uyuni_system_reprovision" "web01" {
+  system_id                   = 1000010001
+  profile_name                = uyuni_autoinstall_profile.web.label
+  respect_maintenance_windows = true
+
+  triggers = {
+    profile = sha256(uyuni_autoinstall_profile.web.content)
+  }
+
+  timeouts {
+    create = "24h"
+  }
+}
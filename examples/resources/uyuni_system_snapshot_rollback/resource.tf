@@ -0,0 +1,15 @@
+resource "uyuni_system_snapshot_tag" "configured" {
+  system_id = 1000010001
+  name      = "configured"
+}
+
+# Roll the system back to the configured state, again whenever the
+# incident number changes.
+resource "uyuni_system_snapshot_rollback" "recover" {
+  system_id   = uyuni_system_snapshot_tag.configured.system_id
+  snapshot_id = uyuni_system_snapshot_tag.configured.snapshot_id
+
+  triggers = {
+    incident = "INC-1234"
+  }
+}
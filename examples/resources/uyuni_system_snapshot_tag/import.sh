@@ -0,0 +1,2 @@
+# Snapshot tags are imported by system ID and tag name.
+terraform import uyuni_system_snapshot_tag.configured 1000010001:configured
@@ -0,0 +1,5 @@
+# Tag the snapshot taken after the system was configured.
+resource "uyuni_system_snapshot_tag" "configured" {
+  system_id = 1000010001
+  name      = "configured"
+}
@@ -0,0 +1,23 @@
+# Move the first migration wave to SLES 15 SP6, ten systems at a time.
+resource "uyuni_systems_channel_change" "wave1" {
+  target {
+    group_names = ["migration-wave-1"]
+  }
+
+  base_channel_label = "sles15-sp6-pool-x86_64"
+  parallelism        = 10
+
+  child_channel_labels = [
+    "sles15-sp6-updates-x86_64",
+    "sle-manager-tools15-pool-x86_64-sp6",
+    "sle-manager-tools15-updates-x86_64-sp6",
+  ]
+
+  timeouts {
+    create = "2h"
+  }
+}
+
+output "wave1_failed_systems" {
+  value = uyuni_systems_channel_change.wave1.failed_system_ids
+}
@@ -0,0 +1,40 @@
+# After the monthly patch run, reboot the database servers needing it
+# first, then the web servers, two at a time and only in their
+# maintenance windows.
+variable "chat_webhook_url" {
+  type      = string
+  sensitive = true
+}
+
+resource "uyuni_systems_reboot" "patch_day" {
+  target {
+    group_names = ["db", "web"]
+  }
+
+  max_parallel                = 2
+  respect_maintenance_windows = true
+
+  triggers = {
+    patch_run = "2026-10"
+  }
+
+  # Tell the ops channel once all systems are back.
+  notify {
+    url = var.chat_webhook_url
+    template = jsonencode({
+      text = "Patch run 2026-10: {{ len .SystemIDs }} systems rebooted"
+    })
+  }
+
+  timeouts {
+    create = "24h"
+  }
+}
+
+output "reboot_progress" {
+  value = {
+    status   = uyuni_systems_reboot.patch_day.status
+    rebooted = uyuni_systems_reboot.patch_day.rebooted_system_ids
+    failed   = uyuni_systems_reboot.patch_day.failed_system_ids
+  }
+}
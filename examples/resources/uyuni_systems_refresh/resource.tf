@@ -0,0 +1,10 @@
+# Refresh the inventory of all web servers before the quarterly audit.
+resource "uyuni_systems_refresh" "audit" {
+  target {
+    group_names = ["web"]
+  }
+
+  triggers = {
+    audit = "2026-Q4"
+  }
+}
@@ -0,0 +1,2 @@
+# Users are imported by login.
+terraform import uyuni_user.example jdoe
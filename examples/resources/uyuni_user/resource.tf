@@ -0,0 +1,43 @@
+resource "uyuni_user" "example" {
+  login     = "jdoe"
+  password  = "change-me"
+  firstname = "Jane"
+  lastname  = "Doe"
+  email     = "jdoe@example.com"
+
+  # Hand the autoinstall profiles of the user over when it leaves.
+  reassign_to = "admin"
+}
+
+resource "uyuni_user" "ldap" {
+  login     = "asmith"
+  firstname = "Alex"
+  lastname  = "Smith"
+  email     = "asmith@example.com"
+  use_pam   = true
+}
+
+# A service account syncing channels, which needs no email per advisory
+resource "uyuni_user" "channel_sync" {
+  login               = "svc-channel-sync"
+  password            = "change-me"
+  firstname           = "Channel"
+  lastname            = "Sync"
+  email               = "ops@example.com"
+  errata_notification = false
+  roles               = ["channel_admin"]
+}
+
+# Create the user in the organization of another administrator.
+resource "uyuni_user" "tenant" {
+  login     = "bmiller"
+  password  = "change-me"
+  firstname = "Blake"
+  lastname  = "Miller"
+  email     = "bmiller@tenant.example.com"
+
+  org {
+    username = "tenant-admin"
+    password = "tenant-secret"
+  }
+}
@@ -0,0 +1,2 @@
+# Users are imported by a comma-separated list of their logins.
+terraform import uyuni_users.team jdoe,jsmith
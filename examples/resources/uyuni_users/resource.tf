@@ -0,0 +1,13 @@
+variable "team" {
+  type = map(object({
+    firstname = string
+    lastname  = string
+    email     = string
+  }))
+}
+
+resource "uyuni_users" "team" {
+  users = {
+    for login, user in var.team : login => merge(user, { use_pam = true })
+  }
+}
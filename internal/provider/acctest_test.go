@@ -0,0 +1,262 @@
+package provider
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+
+	"terraform-provider-uyuni/internal/testing/uyunimock"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/uyuni-project/uyuni-tools/shared/api"
+)
+
+// Acceptance tests run against the server named by UYUNI_TEST_HOST. With
+// UYUNI_TEST_CONTAINER=1, or TF_ACC_UYUNI_REAL=1, a server container is
+// installed with mgradm for the duration of the test run instead, and removed again afterwards unless
+// UYUNI_TEST_KEEP_SERVER is set. UYUNI_TEST_IMAGE overrides the server image.
+//
+// Without either, UYUNI_TEST_MOCK=1 runs the tests against the mock API of
+// internal/testing/uyunimock, which keeps users, system groups, configuration
+// channels and software channels in memory. Tests of other objects call
+// testAccRealServerPreCheck and are skipped then.
+//
+// Tests needing infrastructure that cannot be created on the fly, like
+// registered systems, are skipped unless the corresponding UYUNI_TEST_*
+// variable points to it.
+
+const (
+	testAccDefaultUsername = "admin"
+	testAccDefaultPassword = "tfacc-Admin-123"
+
+	// testAccServerStartTimeout bounds the installation of the server container.
+	testAccServerStartTimeout = 45 * time.Minute
+)
+
+// testAccMock is the mock API the acceptance tests run against, if any.
+var testAccMock *uyunimock.Server
+
+func TestMain(m *testing.M) {
+	os.Exit(testAccMain(m))
+}
+
+func testAccMain(m *testing.M) int {
+	if os.Getenv(resource.EnvTfAcc) == "" {
+		return m.Run()
+	}
+
+	username := testAccEnvOr("UYUNI_TEST_USERNAME", testAccDefaultUsername)
+	password := testAccEnvOr("UYUNI_TEST_PASSWORD", testAccDefaultPassword)
+	host := os.Getenv("UYUNI_TEST_HOST")
+
+	if host == "" && (os.Getenv("UYUNI_TEST_CONTAINER") == "1" || os.Getenv("TF_ACC_UYUNI_REAL") == "1") {
+		var err error
+		host, err = testAccStartServer(username, password)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Could not start the Uyuni server container: %s\n", err)
+			return 1
+		}
+		if os.Getenv("UYUNI_TEST_KEEP_SERVER") == "" {
+			defer testAccStopServer()
+		}
+		// The container uses a self-signed certificate.
+		os.Setenv("UYUNI_INSECURE", "true")
+	}
+
+	if host == "" && os.Getenv("UYUNI_TEST_MOCK") == "1" {
+		testAccMock = uyunimock.New(username, password)
+		defer testAccMock.Close()
+		host = testAccMock.Host()
+		// The mock uses a self-signed certificate.
+		os.Setenv("UYUNI_INSECURE", "true")
+	}
+
+	if host != "" {
+		// The provider reads its connection settings from the environment.
+		os.Setenv("UYUNI_HOST", host)
+		os.Setenv("UYUNI_USERNAME", username)
+		os.Setenv("UYUNI_PASSWORD", password)
+	}
+	return m.Run()
+}
+
+// testAccStartServer installs a server container on this host and returns its FQDN.
+func testAccStartServer(username, password string) (string, error) {
+	out, err := exec.Command("hostname", "-f").Output()
+	if err != nil {
+		return "", fmt.Errorf("could not determine the FQDN of this host: %w", err)
+	}
+	fqdn := strings.TrimSpace(string(out))
+
+	args := []string{
+		"install", "podman",
+		"--admin-login", username,
+		"--admin-password", password,
+		"--email", "tfacc@example.com",
+		"--emailfrom", "tfacc@example.com",
+		"--organization", "Terraform Acceptance",
+		"--ssl-password", password,
+	}
+	if image := os.Getenv("UYUNI_TEST_IMAGE"); image != "" {
+		args = append(args, "--image", image)
+	}
+	args = append(args, fqdn)
+
+	ctx, cancel := context.WithTimeout(context.Background(), testAccServerStartTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "mgradm", args...)
+	cmd.Stdout = os.Stderr
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("mgradm install failed: %w", err)
+	}
+	return fqdn, testAccWaitForServer(ctx, fqdn)
+}
+
+// testAccWaitForServer polls the API until the server answers.
+func testAccWaitForServer(ctx context.Context, host string) error {
+	client := &http.Client{
+		Timeout: 10 * time.Second,
+		Transport: &http.Transport{
+			// The container uses a self-signed certificate.
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+		},
+	}
+	for {
+		res, err := client.Get("https://" + host + "/rhn/manager/api/api/getVersion")
+		if err == nil {
+			res.Body.Close()
+			if res.StatusCode == http.StatusOK {
+				return nil
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("the server did not become ready: %w", ctx.Err())
+		case <-time.After(10 * time.Second):
+		}
+	}
+}
+
+// testAccStopServer removes the server container and its volumes.
+func testAccStopServer() {
+	cmd := exec.Command("mgradm", "uninstall", "--force", "--purge-volumes")
+	cmd.Stdout = os.Stderr
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		fmt.Fprintf(os.Stderr, "Could not remove the Uyuni server container: %s\n", err)
+	}
+}
+
+// testAccUyuniPreCheck skips tests against the provider when no server is configured.
+func testAccUyuniPreCheck(t *testing.T) {
+	if os.Getenv("UYUNI_HOST") == "" {
+		t.Skip("UYUNI_TEST_HOST, UYUNI_TEST_CONTAINER=1 or UYUNI_TEST_MOCK=1 must be set to run acceptance tests against a Uyuni server")
+	}
+}
+
+// testAccRealServerPreCheck skips tests of objects the mock API does not
+// keep when running against it.
+func testAccRealServerPreCheck(t *testing.T) {
+	testAccUyuniPreCheck(t)
+	if testAccMock != nil {
+		t.Skip("the mock API does not implement the calls of this test, UYUNI_TEST_HOST or UYUNI_TEST_CONTAINER=1 must be set")
+	}
+}
+
+// testAccSkipUnlessEnv skips the test if the environment variable name is unset.
+func testAccSkipUnlessEnv(t *testing.T, name, purpose string) {
+	if os.Getenv(name) == "" {
+		t.Skipf("%s must point to %s", name, purpose)
+	}
+}
+
+func testAccEnvOr(name, fallback string) string {
+	if value := os.Getenv(name); value != "" {
+		return value
+	}
+	return fallback
+}
+
+// testAccClient returns an API client for seeding and checking server objects.
+func testAccClient(t *testing.T) *uyuniClient {
+	client, err := newUyuniClient(context.Background(), &api.ConnectionDetails{
+		Server:   os.Getenv("UYUNI_HOST"),
+		User:     os.Getenv("UYUNI_USERNAME"),
+		Password: os.Getenv("UYUNI_PASSWORD"),
+		Insecure: true,
+	})
+	if err != nil {
+		t.Fatalf("could not log in to the Uyuni server: %s", err)
+	}
+	return client
+}
+
+// testAccSeed creates a server object needed by a test through the API, and
+// removes it again once the test finished.
+func testAccSeed(t *testing.T, path string, data map[string]interface{}, cleanupPath string, cleanupData map[string]interface{}) {
+	client := testAccClient(t)
+	if _, err := apiPost[interface{}](context.Background(), client, path, data); err != nil {
+		t.Fatalf("could not seed %s: %s", path, err)
+	}
+	t.Cleanup(func() {
+		if _, err := apiPost[interface{}](context.Background(), client, cleanupPath, cleanupData); err != nil {
+			t.Errorf("could not clean up after %s: %s", path, err)
+		}
+	})
+}
+
+// testAccCheckGone returns a CheckDestroy function verifying that path no
+// longer finds the object.
+func testAccCheckGone(t *testing.T, path string) resource.TestCheckFunc {
+	return func(_ *terraform.State) error {
+		_, err := apiGet[interface{}](context.Background(), testAccClient(t), path)
+		if err == nil {
+			return fmt.Errorf("%s still finds the object", path)
+		}
+		if !isNotFoundError(err) {
+			return err
+		}
+		return nil
+	}
+}
+
+// testAccSeedSystemGroup creates the system group name for the test.
+func testAccSeedSystemGroup(t *testing.T, name string) {
+	testAccSeed(t, "systemgroup/create", map[string]interface{}{
+		"name":        name,
+		"description": "Group of the acceptance tests",
+	}, "systemgroup/delete", map[string]interface{}{"systemGroupName": name})
+}
+
+// testAccSeedChannel creates the empty x86_64 base channel label for the
+// test.
+func testAccSeedChannel(t *testing.T, label string) {
+	testAccSeed(t, "channel/software/create", map[string]interface{}{
+		"label":       label,
+		"name":        label,
+		"summary":     "Channel of the acceptance tests",
+		"archLabel":   "channel-x86_64",
+		"parentLabel": "",
+	}, "channel/software/delete", map[string]interface{}{"channelLabel": label})
+}
+
+// testAccAttribute returns an ImportStateIdFunc returning the attribute of
+// the resource name, for objects imported by an ID the server assigns.
+func testAccAttribute(name, attribute string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return "", fmt.Errorf("%s not found in the state", name)
+		}
+		return rs.Primary.Attributes[attribute], nil
+	}
+}
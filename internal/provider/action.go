@@ -0,0 +1,296 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"terraform-provider-uyuni/internal/uyuni"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// actionPollInterval is how often waitForAction checks whether an action
+// finished. It is a variable so that tests do not have to wait.
+var actionPollInterval = 10 * time.Second
+
+// Statuses of actions as exposed by the resources scheduling them.
+const (
+	actionStatusPending   = "pending"
+	actionStatusCompleted = "completed"
+	actionStatusFailed    = "failed"
+)
+
+// actionFailedError is returned when an action failed on a system, as
+// opposed to errors which leave it unknown whether it finished.
+type actionFailedError struct {
+	actionID int64
+	sid      int64
+	message  string
+}
+
+func (e *actionFailedError) Error() string {
+	return fmt.Sprintf("action %d failed on system %d: %s", e.actionID, e.sid, e.message)
+}
+
+// actionSystemStatus returns the entry of the system in the list of systems
+// of the action, nil if it is not listed.
+func actionSystemStatus(ctx context.Context, client *uyuniClient, list string, actionID, sid int64) (*uyuni.ActionSystem, error) {
+	systems, err := apiGet[[]uyuni.ActionSystem](ctx, client, fmt.Sprintf("schedule/%s?actionId=%d", list, actionID))
+	if err != nil {
+		return nil, err
+	}
+	for i, system := range systems.Result {
+		if int64(system.ServerID) == sid {
+			return &systems.Result[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// waitForAction waits until the action finished on the system. It returns an
+// error if the action failed, or if ctx is done first.
+func waitForAction(ctx context.Context, client *uyuniClient, actionID, sid int64) error {
+	for {
+		// The status changes without writes, which would refresh the cache.
+		client.cache.invalidate()
+		done, err := actionDone(ctx, client, actionID, sid)
+		if ctx.Err() != nil {
+			return fmt.Errorf("action %d did not finish on system %d: %w", actionID, sid, ctx.Err())
+		}
+		if err != nil || done {
+			return err
+		}
+
+		tflog.Debug(ctx, fmt.Sprintf("Waiting for action %d on system %d", actionID, sid))
+		select {
+		case <-ctx.Done():
+		case <-time.After(actionPollInterval):
+		}
+	}
+}
+
+// actionDone reports whether the action completed on the system. It returns
+// an error if the action failed.
+func actionDone(ctx context.Context, client *uyuniClient, actionID, sid int64) (bool, error) {
+	failed, err := actionSystemStatus(ctx, client, "listFailedSystems", actionID, sid)
+	if err != nil {
+		return false, err
+	}
+	if failed != nil {
+		return false, &actionFailedError{actionID: actionID, sid: sid, message: failed.Message}
+	}
+	completed, err := actionSystemStatus(ctx, client, "listCompletedSystems", actionID, sid)
+	if err != nil {
+		return false, err
+	}
+	return completed != nil, nil
+}
+
+// scriptRun is a script run as scheduled by system.scheduleScriptRun.
+type scriptRun struct {
+	username  string
+	groupname string
+	// timeout is the number of seconds the script may run.
+	timeout int64
+	script  string
+	// earliest is the date the script may run at, immediately if zero.
+	earliest time.Time
+}
+
+// scheduleScriptRun schedules the script to run on the systems and returns
+// the action id.
+func scheduleScriptRun(ctx context.Context, client *uyuniClient, sids []int64, run scriptRun) (int64, error) {
+	earliest := run.earliest
+	if earliest.IsZero() {
+		earliest = time.Now()
+	}
+	actionID, err := apiPost[int64](ctx, client, "system/scheduleScriptRun", map[string]interface{}{
+		"sids":               sids,
+		"username":           run.username,
+		"groupname":          run.groupname,
+		"timeout":            run.timeout,
+		"script":             run.script,
+		"earliestOccurrence": apiDate(earliest),
+	})
+	if err != nil {
+		return 0, err
+	}
+	return actionID.Result, nil
+}
+
+// waitForSystems waits until the action finished on all systems.
+func waitForSystems(ctx context.Context, client *uyuniClient, actionID int64, sids []int64) error {
+	for _, sid := range sids {
+		if err := waitForAction(ctx, client, actionID, sid); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// waitedActionStatus returns the status of an action after waiting for it
+// returned err. Actions which did not finish in time are pending.
+func waitedActionStatus(err error) string {
+	var failed *actionFailedError
+	switch {
+	case err == nil:
+		return actionStatusCompleted
+	case errors.As(err, &failed):
+		return actionStatusFailed
+	default:
+		return actionStatusPending
+	}
+}
+
+// actionStatus returns the status of the action over all systems it was
+// scheduled for: failed if it failed on any, pending while it is queued or
+// running on any, and completed otherwise.
+func actionStatus(ctx context.Context, client *uyuniClient, actionID int64) (string, error) {
+	failed, err := apiGet[[]uyuni.ActionSystem](ctx, client, fmt.Sprintf("schedule/listFailedSystems?actionId=%d", actionID))
+	if err != nil {
+		return "", err
+	}
+	if len(failed.Result) > 0 {
+		return actionStatusFailed, nil
+	}
+	inProgress, err := actionInProgress(ctx, client, actionID)
+	if err != nil {
+		return "", err
+	}
+	if inProgress {
+		return actionStatusPending, nil
+	}
+	return actionStatusCompleted, nil
+}
+
+// actionInProgress reports whether the action is still queued or running on
+// any system.
+func actionInProgress(ctx context.Context, client *uyuniClient, actionID int64) (bool, error) {
+	systems, err := apiGet[[]uyuni.ActionSystem](ctx, client, fmt.Sprintf("schedule/listInProgressSystems?actionId=%d", actionID))
+	if err != nil {
+		return false, err
+	}
+	return len(systems.Result) > 0, nil
+}
+
+// actionsStatus returns the status over the actions as combinedActionStatus
+// does. Actions deleted from the history are skipped.
+func actionsStatus(ctx context.Context, client *uyuniClient, actionIDs []int64) (string, error) {
+	statuses := make([]string, 0, len(actionIDs))
+	for _, actionID := range actionIDs {
+		status, err := actionStatus(ctx, client, actionID)
+		if err != nil {
+			if isNotFoundError(err) {
+				continue
+			}
+			return "", fmt.Errorf("could not read the status of action %d: %w", actionID, err)
+		}
+		statuses = append(statuses, status)
+	}
+	return combinedActionStatus(statuses), nil
+}
+
+// waitForActions waits until none of the actions is queued or running on any
+// system and returns their status as actionsStatus does. Unlike
+// waitForSystems it does not need to know the systems of each action. It
+// returns an error if ctx is done first.
+func waitForActions(ctx context.Context, client *uyuniClient, actionIDs []int64) (string, error) {
+	for {
+		// The status changes without writes, which would refresh the cache.
+		client.cache.invalidate()
+		status, err := actionsStatus(ctx, client, actionIDs)
+		if ctx.Err() != nil {
+			return actionStatusPending, fmt.Errorf("actions %v did not finish: %w", actionIDs, ctx.Err())
+		}
+		if err != nil {
+			return actionStatusPending, err
+		}
+		if status != actionStatusPending {
+			return status, nil
+		}
+
+		tflog.Debug(ctx, fmt.Sprintf("Waiting for actions %v", actionIDs))
+		select {
+		case <-ctx.Done():
+		case <-time.After(actionPollInterval):
+		}
+	}
+}
+
+// relevantErrata returns the IDs of the errata relevant to the system, in
+// ascending order. Unless advisories is nil, only the errata with those
+// advisory names are returned.
+func relevantErrata(ctx context.Context, client *uyuniClient, sid int64, advisories []string) ([]int64, error) {
+	errata, err := apiGet[[]uyuni.Erratum](ctx, client, fmt.Sprintf("system/getRelevantErrata?sid=%d", sid))
+	if err != nil {
+		return nil, fmt.Errorf("could not list the relevant errata of system %d: %w", sid, err)
+	}
+	ids := []int64{}
+	for _, erratum := range errata.Result {
+		if advisories == nil || slices.Contains(advisories, erratum.AdvisoryName) {
+			ids = append(ids, int64(erratum.ID))
+		}
+	}
+	slices.Sort(ids)
+	return ids, nil
+}
+
+// cancelOnDestroyAttribute is the schema of the cancel_on_destroy attribute of
+// resources scheduling actions.
+func cancelOnDestroyAttribute() schema.BoolAttribute {
+	return schema.BoolAttribute{
+		Description: "Cancel actions which are still queued or running when the resource is destroyed. Defaults to true.",
+		Optional:    true,
+		Computed:    true,
+		Default:     booldefault.StaticBool(true),
+	}
+}
+
+// cancelPendingActions cancels those of the actions which are still queued or
+// running on any system. Actions deleted from the history are skipped.
+func cancelPendingActions(ctx context.Context, client *uyuniClient, actionIDs []int64) error {
+	pending := []int64{}
+	for _, actionID := range actionIDs {
+		inProgress, err := actionInProgress(ctx, client, actionID)
+		if err != nil {
+			if isNotFoundError(err) {
+				continue
+			}
+			return fmt.Errorf("could not read the status of action %d: %w", actionID, err)
+		}
+		if inProgress {
+			pending = append(pending, actionID)
+		}
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+	if _, err := apiPost[int](ctx, client, "schedule/cancelActions", map[string]interface{}{"actionIds": pending}); err != nil {
+		return fmt.Errorf("could not cancel actions %v: %w", pending, err)
+	}
+	tflog.Info(ctx, fmt.Sprintf("Canceled pending actions %v", pending))
+	return nil
+}
+
+// refreshActionStatus returns the current status of the action if the given
+// status is pending, and the given status otherwise. Actions deleted from the
+// history keep their last known status.
+func refreshActionStatus(ctx context.Context, client *uyuniClient, actionID types.Int64, status types.String) (types.String, error) {
+	if actionID.IsNull() || status.ValueString() != actionStatusPending {
+		return status, nil
+	}
+	current, err := actionStatus(ctx, client, actionID.ValueInt64())
+	if err != nil {
+		if isNotFoundError(err) {
+			return status, nil
+		}
+		return status, fmt.Errorf("could not read the status of action %d: %w", actionID.ValueInt64(), err)
+	}
+	return types.StringValue(current), nil
+}
@@ -0,0 +1,513 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"terraform-provider-uyuni/internal/uyuni"
+	"terraform-provider-uyuni/internal/validators"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &actionChainResource{}
+	_ resource.ResourceWithConfigure      = &actionChainResource{}
+	_ resource.ResourceWithValidateConfig = &actionChainResource{}
+)
+
+// Types of the steps of uyuni_action_chain.
+const (
+	actionChainErrata = "errata"
+	actionChainReboot = "reboot"
+)
+
+// NewActionChainResource is a helper function to simplify the provider implementation.
+func NewActionChainResource() resource.Resource {
+	return &actionChainResource{}
+}
+
+// actionChainResource is the resource implementation.
+type actionChainResource struct {
+	client *uyuniClient
+}
+
+// actionChainResourceModel maps the resource schema data.
+type actionChainResourceModel struct {
+	ID                        types.String           `tfsdk:"id"`
+	Label                     types.String           `tfsdk:"label"`
+	Steps                     []actionChainStepModel `tfsdk:"steps"`
+	Target                    *targetModel           `tfsdk:"target"`
+	NotBefore                 types.String           `tfsdk:"not_before"`
+	RespectMaintenanceWindows types.Bool             `tfsdk:"respect_maintenance_windows"`
+	Wait                      types.Bool             `tfsdk:"wait"`
+	Triggers                  types.Map              `tfsdk:"triggers"`
+	EarliestOccurrence        types.String           `tfsdk:"earliest_occurrence"`
+	SystemIDs                 types.Set              `tfsdk:"system_ids"`
+	ActionIDs                 types.Set              `tfsdk:"action_ids"`
+	Status                    types.String           `tfsdk:"status"`
+	CancelOnDestroy           types.Bool             `tfsdk:"cancel_on_destroy"`
+	Notify                    *notifyModel           `tfsdk:"notify"`
+	ServerAlias               types.String           `tfsdk:"server_alias"`
+	Timeouts                  timeouts.Value         `tfsdk:"timeouts"`
+}
+
+// actionChainStepModel maps a step of the chain.
+type actionChainStepModel struct {
+	Type       types.String `tfsdk:"type"`
+	Advisories types.Set    `tfsdk:"advisories"`
+}
+
+// Metadata returns the resource type name.
+func (r *actionChainResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_action_chain"
+}
+
+// Schema defines the schema for the resource.
+func (r *actionChainResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Schedules an action chain on systems when created, e.g. applying the relevant errata and rebooting " +
+			"in a patch window. The steps run in order and a step only runs once the previous one completed. " +
+			"Change triggers to schedule the chain again. Destroying the resource does not undo what the chain did. " +
+			"Use uyuni_scheduled_action for a single action.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Label of the chain.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"label": schema.StringAttribute{
+				Description: "Label of the chain, unique among the chains not scheduled yet.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"steps": schema.ListNestedAttribute{
+				Description: "Steps of the chain, in order. Each step is added for every system it applies to.",
+				Required:    true,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.RequiresReplace(),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"type": schema.StringAttribute{
+							Description: "Type of the step: `errata` applies the relevant errata, systems without any skip the step, " +
+								"and `reboot` reboots the systems.",
+							Required: true,
+							Validators: []validator.String{
+								stringvalidator.OneOf(actionChainErrata, actionChainReboot),
+							},
+						},
+						"advisories": schema.SetAttribute{
+							Description: "Advisory names of the errata to apply, of those relevant to each system. " +
+								"Only for errata, all relevant errata are applied if not set.",
+							ElementType: types.StringType,
+							Optional:    true,
+							Validators: []validator.Set{
+								setvalidator.SizeAtLeast(1),
+							},
+						},
+					},
+				},
+			},
+			"not_before": schema.StringAttribute{
+				Description: "Earliest date the chain may run at, in RFC 3339 format, e.g. `2030-01-01T02:00:00Z`. " +
+					"Defaults to the time of apply.",
+				Optional: true,
+				Validators: []validator.String{
+					validators.Timestamp(),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"respect_maintenance_windows": schema.BoolAttribute{
+				Description: "Schedule the chain for the next maintenance window of the systems from not_before on, " +
+					"unless a window is open. All targeted systems having a maintenance schedule must share it. " +
+					"With wait, the create timeout must last until the window. Defaults to false.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"wait": schema.BoolAttribute{
+				Description: "Wait until the chain finished on all systems. Defaults to true.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"triggers": schema.MapAttribute{
+				Description: "Arbitrary values which schedule the chain again when they change.",
+				ElementType: types.StringType,
+				Optional:    true,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"cancel_on_destroy": cancelOnDestroyAttribute(),
+			"earliest_occurrence": schema.StringAttribute{
+				Description: "Date the chain was scheduled for, in RFC 3339 format.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"system_ids": schema.SetAttribute{
+				Description: "IDs of the targeted systems, as resolved from the target on apply.",
+				ElementType: types.Int64Type,
+				Computed:    true,
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"action_ids": schema.SetAttribute{
+				Description: "IDs of the actions of all steps and systems. Empty if no step applied to any system.",
+				ElementType: types.Int64Type,
+				Computed:    true,
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"status": schema.StringAttribute{
+				Description: "Status of the chain over all systems: `failed` if an action failed on any, " +
+					"`pending` while one is queued or running on any, and `completed` otherwise. Pending statuses are refreshed.",
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"server_alias": serverAliasAttribute(),
+		},
+		Blocks: map[string]schema.Block{
+			"target": requiredTargetBlock("Systems running the chain."),
+			"notify": notifyBlock(),
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+			}),
+		},
+	}
+}
+
+// ValidateConfig refuses advisories for steps other than errata.
+func (r *actionChainResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var steps []actionChainStepModel
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("steps"), &steps)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for i, step := range steps {
+		if step.Type.IsUnknown() || step.Type.ValueString() == actionChainErrata || step.Advisories.IsNull() {
+			continue
+		}
+		resp.Diagnostics.AddAttributeError(
+			path.Root("steps").AtListIndex(i).AtName("advisories"),
+			"Conflicting Attribute Configuration",
+			fmt.Sprintf("Attribute advisories cannot be set for steps of type %q.", step.Type.ValueString()),
+		)
+	}
+}
+
+// addSteps adds the steps for every system to the chain and returns the IDs
+// of the actions added.
+func (m *actionChainResourceModel) addSteps(ctx context.Context, client *uyuniClient, sids []int64) ([]int64, error) {
+	label := m.Label.ValueString()
+	actionIDs := []int64{}
+	for i, step := range m.Steps {
+		var advisories []string
+		if !step.Advisories.IsNull() {
+			var err error
+			if advisories, err = stringSet(ctx, step.Advisories); err != nil {
+				return nil, err
+			}
+		}
+		for _, sid := range sids {
+			var (
+				added *uyuni.Response[int64]
+				err   error
+			)
+			switch step.Type.ValueString() {
+			case actionChainErrata:
+				var errataIDs []int64
+				if errataIDs, err = relevantErrata(ctx, client, sid, advisories); err != nil {
+					return nil, err
+				}
+				if len(errataIDs) == 0 {
+					tflog.Debug(ctx, fmt.Sprintf("Skipping step %d on system %d without relevant errata", i+1, sid))
+					continue
+				}
+				added, err = apiPost[int64](ctx, client, "actionchain/addErrataUpdate", map[string]interface{}{
+					"sid":        sid,
+					"errataIds":  errataIDs,
+					"chainLabel": label,
+				})
+			case actionChainReboot:
+				added, err = apiPost[int64](ctx, client, "actionchain/addSystemReboot", map[string]interface{}{
+					"sid":        sid,
+					"chainLabel": label,
+				})
+			}
+			if err != nil {
+				return nil, fmt.Errorf("could not add step %d for system %d: %w", i+1, sid, err)
+			}
+			if added != nil {
+				actionIDs = append(actionIDs, added.Result)
+			}
+		}
+	}
+	return actionIDs, nil
+}
+
+// deleteActionChain deletes the chain, which is only possible before it is
+// scheduled.
+func deleteActionChain(ctx context.Context, client *uyuniClient, label string) {
+	if _, err := apiPost[int](ctx, client, "actionchain/deleteChain", map[string]interface{}{"chainLabel": label}); err != nil {
+		tflog.Warn(ctx, fmt.Sprintf("Could not delete action chain %s: %s", label, err))
+	}
+}
+
+// Create creates and schedules the chain and waits for it.
+func (r *actionChainResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan actionChainResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := resourceClient(ctx, r.client, plan.ServerAlias, nil, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	label := plan.Label.ValueString()
+	sids, err := plan.Target.resolve(ctx, client)
+	if err != nil {
+		resp.Diagnostics.AddError("Error scheduling action chain", "Could not resolve the target: "+err.Error())
+		return
+	}
+
+	earliest := time.Now()
+	if !plan.NotBefore.IsNull() {
+		if earliest, err = time.Parse(time.RFC3339, plan.NotBefore.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("not_before"), "Error scheduling action chain", err.Error())
+			return
+		}
+	}
+	if plan.RespectMaintenanceWindows.ValueBool() {
+		earliest, err = maintenanceWindowStart(ctx, client, sids, earliest)
+		if err != nil {
+			resp.Diagnostics.AddError("Error scheduling action chain", "Could not find the next maintenance window: "+err.Error())
+			return
+		}
+		tflog.Info(ctx, "Scheduling action chain for the maintenance window", map[string]interface{}{"earliest": earliest.Format(time.RFC3339)})
+	}
+
+	if _, err := apiPost[int64](ctx, client, "actionchain/createChain", map[string]interface{}{"chainLabel": label}); err != nil {
+		resp.Diagnostics.AddError(
+			"Error scheduling action chain",
+			fmt.Sprintf("Could not create action chain %s: %s", label, err),
+		)
+		return
+	}
+	actionIDs, err := plan.addSteps(ctx, client, sids)
+	if err == nil && len(actionIDs) > 0 {
+		_, err = apiPost[int](ctx, client, "actionchain/scheduleChain", map[string]interface{}{
+			"chainLabel": label,
+			"date":       apiDate(earliest),
+		})
+	}
+	if err != nil || len(actionIDs) == 0 {
+		// Uyuni deletes chains once they are scheduled, others would be
+		// left behind and block the label.
+		deleteActionChain(ctx, client, label)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error scheduling action chain",
+			fmt.Sprintf("Could not schedule action chain %s: %s", label, err),
+		)
+		return
+	}
+
+	sort.Slice(actionIDs, func(i, j int) bool { return actionIDs[i] < actionIDs[j] })
+	plan.ID = types.StringValue(label)
+	plan.EarliestOccurrence = types.StringValue(apiDate(earliest))
+	plan.SystemIDs, diags = types.SetValueFrom(ctx, types.Int64Type, sids)
+	resp.Diagnostics.Append(diags...)
+	plan.ActionIDs, diags = types.SetValueFrom(ctx, types.Int64Type, actionIDs)
+	resp.Diagnostics.Append(diags...)
+	plan.Status = types.StringValue(actionStatusPending)
+
+	switch {
+	case len(actionIDs) == 0:
+		tflog.Info(ctx, fmt.Sprintf("No step of action chain %s applies to the %d systems", label, len(sids)))
+		plan.Status = types.StringValue(actionStatusCompleted)
+	case plan.Wait.ValueBool():
+		status, err := waitForActions(ctx, client, actionIDs)
+		plan.Status = types.StringValue(status)
+		switch {
+		case err != nil:
+			resp.Diagnostics.AddError(
+				"Error running action chain",
+				fmt.Sprintf("Could not wait for action chain %s: %s", label, err),
+			)
+		case status == actionStatusFailed:
+			resp.Diagnostics.AddError(
+				"Error running action chain",
+				fmt.Sprintf("Action chain %s failed on at least one system, see actions %v.", label, actionIDs),
+			)
+		default:
+			tflog.Info(ctx, fmt.Sprintf("Ran action chain %s on %d systems", label, len(sids)))
+		}
+	}
+	plan.Notify.send(ctx, notification{
+		Resource:  "uyuni_action_chain",
+		ID:        label,
+		Status:    plan.Status.ValueString(),
+		ActionIDs: actionIDs,
+		SystemIDs: sids,
+	}, &resp.Diagnostics)
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read keeps the state, Uyuni deletes chains once they are scheduled. Only a
+// pending status is refreshed.
+func (r *actionChainResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state actionChainResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := resourceClient(ctx, r.client, state.ServerAlias, nil, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	if state.Status.ValueString() == actionStatusPending {
+		actionIDs, err := int64Set(ctx, state.ActionIDs)
+		var status string
+		if err == nil {
+			status, err = actionsStatus(ctx, client, actionIDs)
+		}
+		if err != nil {
+			resp.Diagnostics.AddError("Error Reading Uyuni action chain", err.Error())
+			return
+		}
+		state.Status = types.StringValue(status)
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update only changes cancel_on_destroy and timeouts, all other changes
+// schedule the chain again.
+func (r *actionChainResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan actionChainResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete cancels the actions of the chain which are still pending, unless
+// cancel_on_destroy is false. What the chain did on the systems is not
+// undone.
+func (r *actionChainResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state actionChainResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := resourceClient(ctx, r.client, state.ServerAlias, nil, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	if state.CancelOnDestroy.ValueBool() && state.Status.ValueString() == actionStatusPending {
+		actionIDs, err := int64Set(ctx, state.ActionIDs)
+		if err == nil {
+			err = cancelPendingActions(ctx, client, actionIDs)
+		}
+		if err != nil {
+			resp.Diagnostics.AddError("Error Deleting Uyuni action chain", err.Error())
+			return
+		}
+	}
+	tflog.Info(ctx, "Removing action chain from state, the systems are not changed")
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *actionChainResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*uyuniClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *uyuniClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
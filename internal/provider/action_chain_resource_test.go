@@ -0,0 +1,163 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	acctest "github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestActionChainSchedulesStepsInOrder(t *testing.T) {
+	ctx := context.Background()
+	actionPollInterval = time.Millisecond
+	t.Cleanup(func() { actionPollInterval = 10 * time.Second })
+
+	var (
+		added     []string
+		scheduled map[string]interface{}
+		nextID    int64 = 800
+	)
+	r := NewActionChainResource()
+	testConfigure(t, r, testAPIClient(t, func(w http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/system/getRelevantErrata":
+			if req.URL.Query().Get("sid") == "1000010002" {
+				_, _ = w.Write([]byte(`{"success": true, "result": []}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"success": true, "result": [
+				{"id": 12, "advisory_name": "SUSE-SU-2024:0002-1"},
+				{"id": 11, "advisory_name": "SUSE-SU-2024:0001-1"}
+			]}`))
+		case "/actionchain/createChain":
+			_, _ = w.Write([]byte(`{"success": true, "result": 1}`))
+		case "/actionchain/addErrataUpdate", "/actionchain/addSystemReboot":
+			var body map[string]interface{}
+			_ = json.NewDecoder(req.Body).Decode(&body)
+			added = append(added, fmt.Sprintf("%s %v %v", req.URL.Path, body["sid"], body["errataIds"]))
+			nextID++
+			_, _ = fmt.Fprintf(w, `{"success": true, "result": %d}`, nextID)
+		case "/actionchain/scheduleChain":
+			_ = json.NewDecoder(req.Body).Decode(&scheduled)
+			_, _ = w.Write([]byte(`{"success": true, "result": 1}`))
+		default:
+			_, _ = w.Write([]byte(`{"success": true, "result": []}`))
+		}
+	}))
+
+	sids, _ := types.SetValueFrom(ctx, types.Int64Type, []int64{1000010002, 1000010001})
+	planned := testState(t, r, map[string]interface{}{
+		"label": "patch-window",
+		"steps": []actionChainStepModel{
+			{Type: types.StringValue(actionChainErrata), Advisories: types.SetNull(types.StringType)},
+			{Type: types.StringValue(actionChainReboot), Advisories: types.SetNull(types.StringType)},
+		},
+		"target": &targetModel{
+			SystemIDs:  sids,
+			GroupNames: types.SetNull(types.StringType),
+			Search:     types.StringNull(),
+			SearchBy:   types.StringNull(),
+		},
+		"not_before": "2030-01-01T02:00:00Z",
+		"wait":       true,
+	})
+	resp := &resource.CreateResponse{State: planned}
+	r.Create(ctx, resource.CreateRequest{Plan: tfsdk.Plan{Schema: planned.Schema, Raw: planned.Raw}}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+
+	expected := "[/actionchain/addErrataUpdate 1.000010001e+09 [11 12] " +
+		"/actionchain/addSystemReboot 1.000010001e+09 <nil> " +
+		"/actionchain/addSystemReboot 1.000010002e+09 <nil>]"
+	if fmt.Sprint(added) != expected {
+		t.Errorf("expected the errata of the system with relevant errata before the reboots, got %v", added)
+	}
+	if scheduled["chainLabel"] != "patch-window" || scheduled["date"] != "2030-01-01T02:00:00Z" {
+		t.Errorf("unexpected schedule %v", scheduled)
+	}
+
+	var state actionChainResourceModel
+	resp.State.Get(ctx, &state)
+	if state.ID.ValueString() != "patch-window" || state.Status.ValueString() != actionStatusCompleted || len(state.ActionIDs.Elements()) != 3 {
+		t.Errorf("unexpected chain %s with status %s and actions %s", state.ID, state.Status, state.ActionIDs)
+	}
+}
+
+func TestActionChainDeletesChainItCouldNotSchedule(t *testing.T) {
+	ctx := context.Background()
+	var requests []string
+	r := NewActionChainResource()
+	testConfigure(t, r, testAPIClient(t, func(w http.ResponseWriter, req *http.Request) {
+		requests = append(requests, req.URL.Path)
+		switch req.URL.Path {
+		case "/actionchain/addSystemReboot":
+			_, _ = w.Write([]byte(`{"success": false, "message": "No such system"}`))
+		default:
+			_, _ = w.Write([]byte(`{"success": true, "result": 1}`))
+		}
+	}))
+
+	sids, _ := types.SetValueFrom(ctx, types.Int64Type, []int64{1000010001})
+	planned := testState(t, r, map[string]interface{}{
+		"label": "reboot",
+		"steps": []actionChainStepModel{
+			{Type: types.StringValue(actionChainReboot), Advisories: types.SetNull(types.StringType)},
+		},
+		"target": &targetModel{
+			SystemIDs:  sids,
+			GroupNames: types.SetNull(types.StringType),
+			Search:     types.StringNull(),
+			SearchBy:   types.StringNull(),
+		},
+		"wait": true,
+	})
+	resp := &resource.CreateResponse{State: planned}
+	r.Create(ctx, resource.CreateRequest{Plan: tfsdk.Plan{Schema: planned.Schema, Raw: planned.Raw}}, resp)
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected an error")
+	}
+	if fmt.Sprint(requests) != "[/actionchain/createChain /actionchain/addSystemReboot /actionchain/deleteChain]" {
+		t.Errorf("expected the chain to be deleted, got requests %v", requests)
+	}
+}
+
+func TestAccActionChainResource(t *testing.T) {
+	minion := os.Getenv("UYUNI_TEST_MINION_ID")
+	acctest.Test(t, acctest.TestCase{
+		PreCheck: func() {
+			testAccRealServerPreCheck(t)
+			testAccSkipUnlessEnv(t, "UYUNI_TEST_MINION_ID", "a registered Salt minion")
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []acctest.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "uyuni_action_chain" "test" {
+  label = "tfacc-chain"
+
+  target {
+    system_ids = [%s]
+  }
+
+  steps = [
+    { type = "errata" },
+  ]
+}
+`, minion),
+				Check: acctest.ComposeAggregateTestCheckFunc(
+					acctest.TestCheckTypeSetElemAttr("uyuni_action_chain.test", "system_ids.*", minion),
+					acctest.TestCheckResourceAttr("uyuni_action_chain.test", "status", "completed"),
+				),
+			},
+		},
+	})
+}
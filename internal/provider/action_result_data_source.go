@@ -0,0 +1,247 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"terraform-provider-uyuni/internal/uyuni"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// defaultOutputLimit is the number of characters of the output of a script
+// kept per system when output_limit is not set.
+const defaultOutputLimit = 4096
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &ActionResultDataSource{}
+	_ datasource.DataSourceWithConfigure = &ActionResultDataSource{}
+)
+
+// ActionResultDataSourceModel maps the data source schema data.
+type ActionResultDataSourceModel struct {
+	ActionID    types.Int64               `tfsdk:"action_id"`
+	OutputLimit types.Int64               `tfsdk:"output_limit"`
+	Status      types.String              `tfsdk:"status"`
+	Systems     []actionSystemResultModel `tfsdk:"systems"`
+}
+
+// actionSystemResultModel maps the result of an action on a system.
+type actionSystemResultModel struct {
+	SystemID   types.Int64  `tfsdk:"system_id"`
+	SystemName types.String `tfsdk:"system_name"`
+	Status     types.String `tfsdk:"status"`
+	Message    types.String `tfsdk:"message"`
+	Timestamp  types.String `tfsdk:"timestamp"`
+	ReturnCode types.Int64  `tfsdk:"return_code"`
+	Output     types.String `tfsdk:"output"`
+}
+
+// NewActionResultDataSource is a helper function to simplify the provider implementation.
+func NewActionResultDataSource() datasource.DataSource {
+	return &ActionResultDataSource{}
+}
+
+// ActionResultDataSource is the data source implementation.
+type ActionResultDataSource struct {
+	client *uyuniClient
+}
+
+// Metadata returns the data source type name.
+func (d *ActionResultDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_action_result"
+}
+
+// Schema defines the schema for the data source.
+func (d *ActionResultDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Reads the results of an action on each system it was scheduled for, e.g. of an action scheduled " +
+			"in an earlier apply, for post-apply checks and reporting.",
+		Attributes: map[string]schema.Attribute{
+			"action_id": schema.Int64Attribute{
+				Description: "ID of the action.",
+				Required:    true,
+			},
+			"output_limit": schema.Int64Attribute{
+				Description: "Number of characters of the output of a script kept per system, counted from its end. " +
+					"Defaults to 4096.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"status": schema.StringAttribute{
+				Description: "Status of the action over all systems: `failed` if it failed on any, `pending` while it is " +
+					"queued or running on any, and `completed` otherwise.",
+				Computed: true,
+			},
+			"systems": schema.ListNestedAttribute{
+				Description: "Results by system, ordered by system ID.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"system_id": schema.Int64Attribute{
+							Description: "ID of the system.",
+							Computed:    true,
+						},
+						"system_name": schema.StringAttribute{
+							Description: "Name of the system.",
+							Computed:    true,
+						},
+						"status": schema.StringAttribute{
+							Description: "Status of the action on the system: `completed`, `failed` or `pending`.",
+							Computed:    true,
+						},
+						"message": schema.StringAttribute{
+							Description: "Result message reported by the system, e.g. why the action failed.",
+							Computed:    true,
+						},
+						"timestamp": schema.StringAttribute{
+							Description: "Date the status last changed, in RFC 3339 format.",
+							Computed:    true,
+						},
+						"return_code": schema.Int64Attribute{
+							Description: "Exit code of the script. Null for other actions and scripts which did not run yet.",
+							Computed:    true,
+						},
+						"output": schema.StringAttribute{
+							Description: "End of the output of the script, limited to output_limit characters. " +
+								"Null for other actions and scripts which did not run yet.",
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *ActionResultDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state ActionResultDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	actionID := state.ActionID.ValueInt64()
+	limit := int64(defaultOutputLimit)
+	if !state.OutputLimit.IsNull() {
+		limit = state.OutputLimit.ValueInt64()
+	}
+
+	results := map[int]*actionSystemResultModel{}
+	state.Status = types.StringValue(actionStatusCompleted)
+	for _, list := range []struct {
+		method string
+		status string
+	}{
+		{"listCompletedSystems", actionStatusCompleted},
+		{"listInProgressSystems", actionStatusPending},
+		{"listFailedSystems", actionStatusFailed},
+	} {
+		systems, err := apiGet[[]uyuni.ActionSystem](ctx, d.client, fmt.Sprintf("schedule/%s?actionId=%d", list.method, actionID))
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Read Uyuni action result",
+				fmt.Sprintf("Could not list the systems of action %d: %s", actionID, err),
+			)
+			return
+		}
+		for _, system := range systems.Result {
+			results[system.ServerID] = &actionSystemResultModel{
+				SystemID:   types.Int64Value(int64(system.ServerID)),
+				SystemName: types.StringValue(system.ServerName),
+				Status:     types.StringValue(list.status),
+				Message:    nonEmptyString(system.Message),
+				Timestamp:  timestampValue(ctx, system.Timestamp),
+				ReturnCode: types.Int64Null(),
+				Output:     types.StringNull(),
+			}
+		}
+		// Failed systems are listed last, so that they decide the status.
+		if len(systems.Result) > 0 && list.status != actionStatusCompleted {
+			state.Status = types.StringValue(list.status)
+		}
+	}
+
+	scripts, err := scriptResults(ctx, d.client, actionID)
+	var fault *uyuni.Fault
+	switch {
+	case errors.As(err, &fault):
+		// The call fails for actions other than script runs, whose systems
+		// have no return code or output.
+		tflog.Debug(ctx, fmt.Sprintf("Action %d has no script results", actionID), map[string]interface{}{"error": err.Error()})
+	case err != nil:
+		resp.Diagnostics.AddError(
+			"Unable to Read Uyuni action result",
+			fmt.Sprintf("Could not read the script results of action %d: %s", actionID, err),
+		)
+		return
+	}
+	for _, script := range scripts {
+		result, ok := results[int(script.SystemID.ValueInt64())]
+		if !ok {
+			continue
+		}
+		result.ReturnCode = script.ReturnCode
+		result.Output = types.StringValue(outputExcerpt(script.Output.ValueString(), limit))
+	}
+
+	ids := make([]int, 0, len(results))
+	for id := range results {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	state.Systems = make([]actionSystemResultModel, 0, len(ids))
+	for _, id := range ids {
+		state.Systems = append(state.Systems, *results[id])
+	}
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// outputExcerpt returns the last limit characters of output, where failing
+// commands usually report why.
+func outputExcerpt(output string, limit int64) string {
+	runes := []rune(output)
+	if int64(len(runes)) <= limit {
+		return output
+	}
+	return string(runes[int64(len(runes))-limit:])
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *ActionResultDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*uyuniClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *uyuniClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
@@ -0,0 +1,110 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestActionResultDataSource(t *testing.T) {
+	client := testAPIClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("actionId") != "42" {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		switch r.URL.Path {
+		case "/schedule/listCompletedSystems":
+			_, _ = w.Write([]byte(`{"success": true, "result": [
+				{"server_id": 1000010001, "server_name": "web01.example.com", "timestamp": "2025-02-03T09:41:30Z", "message": "Script executed"}]}`))
+		case "/schedule/listFailedSystems":
+			_, _ = w.Write([]byte(`{"success": true, "result": [
+				{"server_id": 1000010000, "server_name": "db01.example.com", "message": "Script failed"}]}`))
+		case "/schedule/listInProgressSystems":
+			_, _ = w.Write([]byte(`{"success": true, "result": []}`))
+		case "/system/getScriptResults":
+			_, _ = w.Write([]byte(`{"success": true, "result": [
+				{"serverId": 1000010001, "returnCode": 0, "output": "sshd: active\n"},
+				{"serverId": 1000010000, "returnCode": 3, "output": "starting\nsshd: inactive\n"}]}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL)
+		}
+	})
+
+	resp := testDataSourceRead(t, NewActionResultDataSource(), client, map[string]tftypes.Value{
+		"action_id":    tftypes.NewValue(tftypes.Number, 42),
+		"output_limit": tftypes.NewValue(tftypes.Number, 15),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+	var model ActionResultDataSourceModel
+	resp.State.Get(context.Background(), &model)
+	if model.Status.ValueString() != actionStatusFailed || len(model.Systems) != 2 {
+		t.Fatalf("unexpected result %v", model)
+	}
+	failed, completed := model.Systems[0], model.Systems[1]
+	if failed.Status.ValueString() != actionStatusFailed || failed.ReturnCode.ValueInt64() != 3 || failed.Output.ValueString() != "sshd: inactive\n" {
+		t.Errorf("unexpected failed system %v", failed)
+	}
+	if completed.Status.ValueString() != actionStatusCompleted || completed.Timestamp.ValueString() != "2025-02-03T09:41:30Z" {
+		t.Errorf("unexpected completed system %v", completed)
+	}
+}
+
+func TestActionResultDataSourceWithoutScript(t *testing.T) {
+	client := testAPIClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/schedule/listInProgressSystems":
+			_, _ = w.Write([]byte(`{"success": true, "result": [{"server_id": 1000010001, "server_name": "web01.example.com"}]}`))
+		case "/system/getScriptResults":
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(`{"success": false, "message": "Invalid action type"}`))
+		default:
+			_, _ = w.Write([]byte(`{"success": true, "result": []}`))
+		}
+	})
+
+	resp := testDataSourceRead(t, NewActionResultDataSource(), client, map[string]tftypes.Value{
+		"action_id": tftypes.NewValue(tftypes.Number, 42),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+	var model ActionResultDataSourceModel
+	resp.State.Get(context.Background(), &model)
+	if model.Status.ValueString() != actionStatusPending || len(model.Systems) != 1 || !model.Systems[0].Output.IsNull() {
+		t.Errorf("unexpected result %v", model)
+	}
+}
+
+func TestAccActionResultDataSource(t *testing.T) {
+	minion := os.Getenv("UYUNI_TEST_MINION_ID")
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccRealServerPreCheck(t)
+			testAccSkipUnlessEnv(t, "UYUNI_TEST_MINION_ID", "a registered Salt minion")
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "uyuni_system_refresh" "test" {
+  system_id = %s
+}
+
+data "uyuni_action_result" "test" {
+  action_id = uyuni_system_refresh.test.package_refresh_action_id
+}
+`, minion),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.uyuni_action_result.test", "status", "completed"),
+					resource.TestCheckResourceAttr("data.uyuni_action_result.test", "systems.0.system_id", minion),
+				),
+			},
+		},
+	})
+}
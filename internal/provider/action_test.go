@@ -0,0 +1,87 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testActionServer completes action 10 on system 1 after the given number of
+// polls and fails action 11.
+func testActionServer(t *testing.T, polls int) *uyuniClient {
+	actionPollInterval = time.Millisecond
+	t.Cleanup(func() { actionPollInterval = 10 * time.Second })
+
+	client := testAPIClient(t, func(w http.ResponseWriter, r *http.Request) {
+		actionID := r.URL.Query().Get("actionId")
+		switch {
+		case r.URL.Path == "/schedule/listFailedSystems" && actionID == "11":
+			_, _ = w.Write([]byte(`{"success": true, "result": [{"server_id": 1, "message": "Minion is down"}]}`))
+		case r.URL.Path == "/schedule/listInProgressSystems" && actionID == "10":
+			if polls > 0 {
+				polls--
+				_, _ = w.Write([]byte(`{"success": true, "result": [{"server_id": 1}]}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"success": true, "result": []}`))
+		case r.URL.Path == "/schedule/listCompletedSystems" && actionID == "10":
+			if polls > 0 {
+				polls--
+				_, _ = w.Write([]byte(`{"success": true, "result": [{"server_id": 2}]}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"success": true, "result": [{"server_id": 2}, {"server_id": 1}]}`))
+		default:
+			_, _ = w.Write([]byte(`{"success": true, "result": []}`))
+		}
+	})
+	// Polls must not be answered from the cache.
+	client.cache = newReadCache(readCacheTTL)
+	return client
+}
+
+func TestWaitForActionPollsUntilCompleted(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := waitForAction(ctx, testActionServer(t, 2), 10, 1); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestWaitForActionsPollsUntilCompleted(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	status, err := waitForActions(ctx, testActionServer(t, 2), []int64{10})
+	if err != nil || status != actionStatusCompleted {
+		t.Fatalf("expected the actions to complete, got %s, %v", status, err)
+	}
+}
+
+func TestWaitForActionFails(t *testing.T) {
+	err := waitForAction(context.Background(), testActionServer(t, 0), 11, 1)
+	if err == nil || !strings.Contains(err.Error(), "Minion is down") {
+		t.Errorf("expected the failure message, got %v", err)
+	}
+}
+
+func TestWaitForActionTimesOut(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	err := waitForAction(ctx, testActionServer(t, 0), 12, 1)
+	if err == nil || !strings.Contains(err.Error(), "did not finish") {
+		t.Errorf("expected a timeout, got %v", err)
+	}
+}
+
+func TestWaitedActionStatus(t *testing.T) {
+	client := testActionServer(t, 0)
+	for actionID, want := range map[int64]string{10: actionStatusCompleted, 11: actionStatusFailed, 12: actionStatusPending} {
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		if status := waitedActionStatus(waitForAction(ctx, client, actionID, 1)); status != want {
+			t.Errorf("expected action %d to be %s, got %s", actionID, want, status)
+		}
+		cancel()
+	}
+}
@@ -0,0 +1,263 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"terraform-provider-uyuni/internal/uyuni"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &ActivationKeyDataSource{}
+	_ datasource.DataSourceWithConfigure = &ActivationKeyDataSource{}
+)
+
+// ActivationKeyDataSourceModel maps the data source schema data.
+type ActivationKeyDataSourceModel struct {
+	Key                types.String `tfsdk:"key"`
+	KeyRegex           types.String `tfsdk:"key_regex"`
+	ID                 types.String `tfsdk:"id"`
+	Description        types.String `tfsdk:"description"`
+	BaseChannelLabel   types.String `tfsdk:"base_channel_label"`
+	ChildChannelLabels types.Set    `tfsdk:"child_channel_labels"`
+	UsageLimit         types.Int64  `tfsdk:"usage_limit"`
+	UniversalDefault   types.Bool   `tfsdk:"universal_default"`
+	Disabled           types.Bool   `tfsdk:"disabled"`
+	ContactMethod      types.String `tfsdk:"contact_method"`
+	Entitlements       types.Set    `tfsdk:"entitlements"`
+	SystemGroupIDs     types.Set    `tfsdk:"system_group_ids"`
+	Packages           types.Set    `tfsdk:"packages"`
+}
+
+// NewActivationKeyDataSource is a helper function to simplify the provider implementation.
+func NewActivationKeyDataSource() datasource.DataSource {
+	return &ActivationKeyDataSource{}
+}
+
+// ActivationKeyDataSource is the data source implementation.
+type ActivationKeyDataSource struct {
+	client *uyuniClient
+}
+
+// Metadata returns the data source type name.
+func (d *ActivationKeyDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_activation_key"
+}
+
+// Schema defines the schema for the data source.
+func (d *ActivationKeyDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Looks up an activation key of the organization of the provider user, e.g. one managed outside " +
+			"of Terraform, to reference it without hardcoding it.",
+		Attributes: map[string]schema.Attribute{
+			"key": schema.StringAttribute{
+				Description: "Key to look up, with or without the organization prefix.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("key_regex")),
+				},
+			},
+			"key_regex": schema.StringAttribute{
+				Description: "Regular expression in RE2 syntax matching the full key, e.g. `-web$`. Exactly one key has to match.",
+				Optional:    true,
+			},
+			"id": schema.StringAttribute{
+				Description: "Full key, prefixed with the organization ID, e.g. `1-web`.",
+				Computed:    true,
+			},
+			"description": schema.StringAttribute{
+				Description: "Description of the key.",
+				Computed:    true,
+			},
+			"base_channel_label": schema.StringAttribute{
+				Description: "Label of the base channel of registering systems, null for the default base channel of each system.",
+				Computed:    true,
+			},
+			"child_channel_labels": schema.SetAttribute{
+				Description: "Labels of the child channels of registering systems.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"usage_limit": schema.Int64Attribute{
+				Description: "Number of systems which may register with the key, null if unlimited.",
+				Computed:    true,
+			},
+			"universal_default": schema.BoolAttribute{
+				Description: "Whether the key is the default key of the organization.",
+				Computed:    true,
+			},
+			"disabled": schema.BoolAttribute{
+				Description: "Whether the key is disabled.",
+				Computed:    true,
+			},
+			"contact_method": schema.StringAttribute{
+				Description: "How the server contacts registering systems.",
+				Computed:    true,
+			},
+			"entitlements": schema.SetAttribute{
+				Description: "Add-on entitlements of registering systems.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"system_group_ids": schema.SetAttribute{
+				Description: "IDs of the system groups registering systems join.",
+				ElementType: types.Int64Type,
+				Computed:    true,
+			},
+			"packages": schema.SetNestedAttribute{
+				Description: "Packages installed on registering systems.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Description: "Name of the package.",
+							Computed:    true,
+						},
+						"arch": schema.StringAttribute{
+							Description: "Architecture of the package, null for any.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// matchActivationKey returns the only key of keys matching keyRegex, or the
+// key of the model if keyRegex is nil.
+func (m *ActivationKeyDataSourceModel) matchActivationKey(keys []uyuni.ActivationKey, keyRegex *regexp.Regexp) (*uyuni.ActivationKey, error) {
+	var match func(key string) bool
+	if keyRegex != nil {
+		match = keyRegex.MatchString
+	} else {
+		wanted := m.Key.ValueString()
+		match = func(key string) bool {
+			_, name, _ := strings.Cut(key, "-")
+			return key == wanted || name == wanted
+		}
+	}
+
+	var matches []string
+	var found *uyuni.ActivationKey
+	for i, key := range keys {
+		if match(key.Key) {
+			matches = append(matches, key.Key)
+			found = &keys[i]
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("no activation key visible to the user matches")
+	case 1:
+		return found, nil
+	}
+	sort.Strings(matches)
+	return nil, fmt.Errorf("%d activation keys match: %s", len(matches), strings.Join(matches, ", "))
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *ActivationKeyDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state ActivationKeyDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	keyRegex, ok := compileRegexFilter(state.KeyRegex, "key_regex", &resp.Diagnostics)
+	if !ok {
+		return
+	}
+
+	keys, err := apiGet[[]uyuni.ActivationKey](ctx, d.client, "activationkey/listActivationKeys")
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Uyuni activation key",
+			"Could not list activation keys: "+err.Error(),
+		)
+		return
+	}
+	key, err := state.matchActivationKey(keys.Result, keyRegex)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to Read Uyuni activation key", err.Error())
+		return
+	}
+
+	state.ID = types.StringValue(key.Key)
+	state.Description = types.StringValue(key.Description)
+	state.BaseChannelLabel = types.StringNull()
+	if key.BaseChannelLabel != "" && key.BaseChannelLabel != noBaseChannel {
+		state.BaseChannelLabel = types.StringValue(key.BaseChannelLabel)
+	}
+	state.UsageLimit = types.Int64Null()
+	if key.UsageLimit > 0 {
+		state.UsageLimit = types.Int64Value(int64(key.UsageLimit))
+	}
+	state.UniversalDefault = types.BoolValue(key.UniversalDefault)
+	state.Disabled = types.BoolValue(key.Disabled)
+	state.ContactMethod = types.StringValue(key.ContactMethod)
+
+	state.ChildChannelLabels, diags = types.SetValueFrom(ctx, types.StringType, append([]string{}, key.ChildChannelLabels...))
+	resp.Diagnostics.Append(diags...)
+	state.Entitlements, diags = types.SetValueFrom(ctx, types.StringType, append([]string{}, key.Entitlements...))
+	resp.Diagnostics.Append(diags...)
+	groupIDs := make([]int64, 0, len(key.ServerGroupIDs))
+	for _, id := range key.ServerGroupIDs {
+		groupIDs = append(groupIDs, int64(id))
+	}
+	state.SystemGroupIDs, diags = types.SetValueFrom(ctx, types.Int64Type, groupIDs)
+	resp.Diagnostics.Append(diags...)
+	packages := make([]activationKeyPackageModel, 0, len(key.Packages))
+	for _, pkg := range key.Packages {
+		arch := types.StringNull()
+		if pkg.Arch != "" {
+			arch = types.StringValue(pkg.Arch)
+		}
+		packages = append(packages, activationKeyPackageModel{Name: types.StringValue(pkg.Name), Arch: arch})
+	}
+	state.Packages, diags = types.SetValueFrom(ctx, activationKeyPackageType, packages)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *ActivationKeyDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*uyuniClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *uyuniClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
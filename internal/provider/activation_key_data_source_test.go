@@ -0,0 +1,68 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestActivationKeyDataSourceLooksUpKeyWithoutPrefix(t *testing.T) {
+	resp := testDataSourceRead(t, NewActivationKeyDataSource(), fixtureClient(t, "2024.08"), map[string]tftypes.Value{
+		"key": tftypes.NewValue(tftypes.String, "sles15-sp6-web"),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+	var state ActivationKeyDataSourceModel
+	resp.State.Get(context.Background(), &state)
+	if state.ID.ValueString() != "1-sles15-sp6-web" || state.ContactMethod.ValueString() != "ssh-push" || !state.UsageLimit.IsNull() {
+		t.Errorf("unexpected key %v", state)
+	}
+	if len(state.Entitlements.Elements()) != 1 || len(state.ChildChannelLabels.Elements()) != 2 {
+		t.Errorf("unexpected entitlements %s or child channels %s", state.Entitlements, state.ChildChannelLabels)
+	}
+}
+
+func TestActivationKeyDataSourceRefusesAmbiguousRegex(t *testing.T) {
+	resp := testDataSourceRead(t, NewActivationKeyDataSource(), fixtureClient(t, "2024.08"), map[string]tftypes.Value{
+		"key_regex": tftypes.NewValue(tftypes.String, "^1-"),
+	})
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected an error")
+	}
+	if detail := resp.Diagnostics.Errors()[0].Detail(); !strings.Contains(detail, "1-build-hosts, 1-sles15-sp6-web") {
+		t.Errorf("expected the matching keys, got %s", detail)
+	}
+}
+
+func TestAccActivationKeyDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccRealServerPreCheck(t)
+			testAccSeed(t, "activationkey/create", map[string]interface{}{
+				"key":              "tfacc-ds-key",
+				"description":      "Key of the acceptance tests",
+				"baseChannelLabel": "",
+				"entitlements":     []string{},
+				"universalDefault": false,
+			}, "activationkey/delete", map[string]interface{}{"key": "1-tfacc-ds-key"})
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `data "uyuni_activation_key" "test" { key = "tfacc-ds-key" }`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.uyuni_activation_key.test", "id", "1-tfacc-ds-key"),
+					resource.TestCheckResourceAttr("data.uyuni_activation_key.test", "description", "Key of the acceptance tests"),
+				),
+			},
+			{
+				Config: `data "uyuni_activation_key" "test" { key_regex = "-tfacc-ds-key$" }`,
+				Check:  resource.TestCheckResourceAttr("data.uyuni_activation_key.test", "id", "1-tfacc-ds-key"),
+			},
+		},
+	})
+}
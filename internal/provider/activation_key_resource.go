@@ -0,0 +1,797 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"terraform-provider-uyuni/internal/uyuni"
+	"terraform-provider-uyuni/internal/validators"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                 = &activationKeyResource{}
+	_ resource.ResourceWithConfigure    = &activationKeyResource{}
+	_ resource.ResourceWithImportState  = &activationKeyResource{}
+	_ resource.ResourceWithUpgradeState = &activationKeyResource{}
+	_ resource.ResourceWithModifyPlan   = &activationKeyResource{}
+)
+
+// Contact methods of activation keys.
+const (
+	contactMethodDefault       = "default"
+	contactMethodSSHPush       = "ssh-push"
+	contactMethodSSHPushTunnel = "ssh-push-tunnel"
+)
+
+// contactMethods are the contact methods the server accepts, also listed by
+// the uyuni_contact_methods data source.
+var contactMethods = []string{contactMethodDefault, contactMethodSSHPush, contactMethodSSHPushTunnel}
+
+// noBaseChannel is the base channel label activationkey.getDetails returns
+// for keys using the default base channel of the registering system.
+const noBaseChannel = "none"
+
+// NewActivationKeyResource is a helper function to simplify the provider implementation.
+func NewActivationKeyResource() resource.Resource {
+	return &activationKeyResource{}
+}
+
+// activationKeyResource is the resource implementation.
+type activationKeyResource struct {
+	client *uyuniClient
+}
+
+// activationKeyResourceModel maps the resource schema data.
+type activationKeyResourceModel struct {
+	ID                 types.String   `tfsdk:"id"`
+	Key                types.String   `tfsdk:"key"`
+	Description        types.String   `tfsdk:"description"`
+	BaseChannelLabel   types.String   `tfsdk:"base_channel_label"`
+	ChildChannelLabels types.Set      `tfsdk:"child_channel_labels"`
+	UsageLimit         types.Int64    `tfsdk:"usage_limit"`
+	UniversalDefault   types.Bool     `tfsdk:"universal_default"`
+	ContactMethod      types.String   `tfsdk:"contact_method"`
+	Entitlements       types.Set      `tfsdk:"entitlements"`
+	Packages           types.Set      `tfsdk:"packages"`
+	RotationTriggers   types.Map      `tfsdk:"rotation_triggers"`
+	AdoptExisting      types.Bool     `tfsdk:"adopt_existing"`
+	ServerAlias        types.String   `tfsdk:"server_alias"`
+	Org                *orgModel      `tfsdk:"org"`
+	Timeouts           timeouts.Value `tfsdk:"timeouts"`
+}
+
+// activationKeyPackageModel maps a package of an activation key.
+type activationKeyPackageModel struct {
+	Name types.String `tfsdk:"name"`
+	Arch types.String `tfsdk:"arch"`
+}
+
+// activationKeyPackageType is the type of the packages of activation keys.
+var activationKeyPackageType = types.ObjectType{AttrTypes: map[string]attr.Type{
+	"name": types.StringType,
+	"arch": types.StringType,
+}}
+
+// activationKeyStateMigrations upgrade states of prior schema versions.
+var activationKeyStateMigrations = stateMigrations{
+	// Version 0 could not adopt existing keys.
+	migrateSetAttribute("adopt_existing", false),
+}
+
+// Metadata returns the resource type name.
+func (r *activationKeyResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_activation_key"
+}
+
+// Schema defines the schema for the resource.
+func (r *activationKeyResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Version: activationKeyStateMigrations.version(),
+		Description: "Manages an activation key, which determines how systems registering with it are set up: " +
+			"their channels, add-on entitlements, packages and how the server contacts them.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Full key, prefixed with the organization ID by the server, e.g. `1-web`.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"key": schema.StringAttribute{
+				Description: "Key without the organization prefix, which the server adds. Omit it to let the server " +
+					"generate a random key, which is required to rotate the key.",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"description": schema.StringAttribute{
+				Description: "Description of the key.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(""),
+			},
+			"base_channel_label": schema.StringAttribute{
+				Description: "Label of the base channel of registering systems. Omit it to use the default base channel of each system.",
+				Optional:    true,
+				Validators: []validator.String{
+					validators.ChannelLabel(),
+				},
+			},
+			"child_channel_labels": schema.SetAttribute{
+				Description: "Labels of the child channels of the base channel to subscribe registering systems to.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(validators.ChannelLabel()),
+				},
+			},
+			"usage_limit": schema.Int64Attribute{
+				Description: "Number of systems which can register with the key. Omit it for an unlimited key.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"universal_default": schema.BoolAttribute{
+				Description: "Use the key for systems registering without a key. Only one key per organization can be the universal default. Defaults to false.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"contact_method": schema.StringAttribute{
+				Description: "How the server contacts registered systems: `default`, `ssh-push` or `ssh-push-tunnel`. Defaults to `default`.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(contactMethodDefault),
+				Validators: []validator.String{
+					stringvalidator.OneOf(contactMethods...),
+				},
+			},
+			"entitlements": schema.SetAttribute{
+				Description: "Add-on entitlements of registering systems, e.g. `monitoring_entitled` or `container_build_host`. " +
+					"Entitlements the server does not offer are refused when planning, if the provider user may list them.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"packages": schema.SetNestedAttribute{
+				Description: "Packages to install on registering systems.",
+				Optional:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Description: "Name of the package, e.g. `golang-github-prometheus-node_exporter`.",
+							Required:    true,
+						},
+						"arch": schema.StringAttribute{
+							Description: "Architecture of the package, e.g. `x86_64`. Omit it for the architecture of each system.",
+							Optional:    true,
+						},
+					},
+				},
+			},
+			"rotation_triggers": schema.MapAttribute{
+				Description: "Arbitrary values which rotate the key when they change, e.g. after it leaked. The key is cloned " +
+					"with a new random key, which keeps the server groups and configuration channels assigned to it outside of " +
+					"Terraform, and the old key is deleted. Everything referencing id gets the new key in the same apply, but resources " +
+					"which are replaced when their key changes, like uyuni_bootstrap_host, are replaced as well. " +
+					"Conflicts with key.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators: []validator.Map{
+					mapvalidator.ConflictsWith(path.MatchRoot("key")),
+				},
+			},
+			"adopt_existing": adoptExistingAttribute(),
+			"server_alias":   serverAliasAttribute(),
+		},
+		Blocks: map[string]schema.Block{
+			"org": orgBlock(),
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+// entitlements returns the entitlements of the model.
+func (m *activationKeyResourceModel) entitlements(ctx context.Context) ([]string, error) {
+	entitlements := []string{}
+	if m.Entitlements.IsNull() {
+		return entitlements, nil
+	}
+	if diags := m.Entitlements.ElementsAs(ctx, &entitlements, false); diags.HasError() {
+		return nil, fmt.Errorf("invalid entitlements")
+	}
+	return entitlements, nil
+}
+
+// childChannelLabels returns the child channels of the model.
+func (m *activationKeyResourceModel) childChannelLabels(ctx context.Context) ([]string, error) {
+	return stringSet(ctx, m.ChildChannelLabels)
+}
+
+// packages returns the packages of the model.
+func (m *activationKeyResourceModel) packages(ctx context.Context) ([]uyuni.KeyPackage, error) {
+	packages := []uyuni.KeyPackage{}
+	if m.Packages.IsNull() {
+		return packages, nil
+	}
+	var models []activationKeyPackageModel
+	if diags := m.Packages.ElementsAs(ctx, &models, false); diags.HasError() {
+		return nil, fmt.Errorf("invalid packages")
+	}
+	for _, pkg := range models {
+		packages = append(packages, uyuni.KeyPackage{Name: pkg.Name.ValueString(), Arch: pkg.Arch.ValueString()})
+	}
+	return packages, nil
+}
+
+// details returns the details of the model for activationkey.setDetails.
+func (m *activationKeyResourceModel) details() map[string]interface{} {
+	details := map[string]interface{}{
+		"description":        m.Description.ValueString(),
+		"base_channel_label": m.BaseChannelLabel.ValueString(),
+		"universal_default":  m.UniversalDefault.ValueBool(),
+		"contact_method":     m.ContactMethod.ValueString(),
+	}
+	if m.UsageLimit.IsNull() {
+		details["unlimited_usage_limit"] = true
+	} else {
+		details["usage_limit"] = m.UsageLimit.ValueInt64()
+	}
+	return details
+}
+
+// read sets the model from the key on the server.
+func (m *activationKeyResourceModel) read(ctx context.Context, key *uyuni.ActivationKey) error {
+	m.ID = types.StringValue(key.Key)
+	m.Description = types.StringValue(key.Description)
+	m.BaseChannelLabel = types.StringNull()
+	if key.BaseChannelLabel != "" && key.BaseChannelLabel != noBaseChannel {
+		m.BaseChannelLabel = types.StringValue(key.BaseChannelLabel)
+	}
+	m.UsageLimit = types.Int64Null()
+	if key.UsageLimit > 0 {
+		m.UsageLimit = types.Int64Value(int64(key.UsageLimit))
+	}
+	m.UniversalDefault = types.BoolValue(key.UniversalDefault)
+	m.ContactMethod = types.StringValue(key.ContactMethod)
+
+	// Keep an unset attribute unset while the key has no entitlements.
+	if len(key.Entitlements) > 0 || !m.Entitlements.IsNull() {
+		entitlements, diags := types.SetValueFrom(ctx, types.StringType, append([]string{}, key.Entitlements...))
+		if diags.HasError() {
+			return fmt.Errorf("could not set entitlements")
+		}
+		m.Entitlements = entitlements
+	}
+	if len(key.ChildChannelLabels) > 0 || !m.ChildChannelLabels.IsNull() {
+		children, diags := types.SetValueFrom(ctx, types.StringType, append([]string{}, key.ChildChannelLabels...))
+		if diags.HasError() {
+			return fmt.Errorf("could not set child channels")
+		}
+		m.ChildChannelLabels = children
+	}
+	if len(key.Packages) > 0 || !m.Packages.IsNull() {
+		packages := make([]activationKeyPackageModel, 0, len(key.Packages))
+		for _, pkg := range key.Packages {
+			arch := types.StringNull()
+			if pkg.Arch != "" {
+				arch = types.StringValue(pkg.Arch)
+			}
+			packages = append(packages, activationKeyPackageModel{Name: types.StringValue(pkg.Name), Arch: arch})
+		}
+		set, diags := types.SetValueFrom(ctx, activationKeyPackageType, packages)
+		if diags.HasError() {
+			return fmt.Errorf("could not set packages")
+		}
+		m.Packages = set
+	}
+	return nil
+}
+
+// getActivationKey returns the details of the key.
+func getActivationKey(ctx context.Context, client *uyuniClient, key string) (*uyuni.ActivationKey, error) {
+	details, err := apiGet[uyuni.ActivationKey](ctx, client, "activationkey/getDetails?key="+url.QueryEscape(key))
+	if err != nil {
+		return nil, err
+	}
+	return &details.Result, nil
+}
+
+// adoptActivationKey updates the existing key of the plan, which the server
+// prefixes with the organization ID, to the plan.
+func adoptActivationKey(ctx context.Context, client *uyuniClient, plan *activationKeyResourceModel, entitlements []string) error {
+	keys, err := apiGet[[]uyuni.ActivationKey](ctx, client, "activationkey/listActivationKeys")
+	if err != nil {
+		return err
+	}
+	var existing *uyuni.ActivationKey
+	for i, key := range keys.Result {
+		if _, name, _ := strings.Cut(key.Key, "-"); name == plan.Key.ValueString() {
+			existing = &keys.Result[i]
+			break
+		}
+	}
+	if existing == nil {
+		return fmt.Errorf("the key is not visible to the user")
+	}
+
+	_, err = apiPost[int](ctx, client, "activationkey/setDetails", map[string]interface{}{
+		"key":     existing.Key,
+		"details": plan.details(),
+	})
+	if err != nil {
+		return err
+	}
+	if err := changeEntitlements(ctx, client, existing.Key, existing.Entitlements, entitlements); err != nil {
+		return err
+	}
+	if err := plan.changeContents(ctx, client, existing); err != nil {
+		return err
+	}
+	plan.ID = types.StringValue(existing.Key)
+	return nil
+}
+
+// ModifyPlan plans a new key when the rotation triggers change, and refuses
+// entitlements the server does not offer.
+func (r *activationKeyResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to plan on destroy.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan activationKeyResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !req.State.Raw.IsNull() {
+		var state activationKeyResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if !plan.RotationTriggers.Equal(state.RotationTriggers) && !plan.ID.IsUnknown() {
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("id"), types.StringUnknown())...)
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("key"), types.StringUnknown())...)
+		}
+	}
+
+	// Entitlements are checked once the provider is configured.
+	if r.client == nil || plan.Entitlements.IsUnknown() || plan.ServerAlias.IsUnknown() {
+		return
+	}
+
+	// Unknown aliases are reported by the apply.
+	client, err := r.client.forServer(ctx, plan.ServerAlias.ValueString())
+	if err != nil {
+		return
+	}
+	entitlements, err := plan.entitlements(ctx)
+	if err == nil {
+		err = checkEntitlements(ctx, client, entitlements)
+	}
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("entitlements"), "Unsupported Entitlement", err.Error())
+	}
+}
+
+// Create a new resource.
+func (r *activationKeyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan activationKeyResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	client := resourceClient(ctx, r.client, plan.ServerAlias, plan.Org, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	entitlements, err := plan.entitlements(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Error creating activation key", err.Error())
+		return
+	}
+	data := map[string]interface{}{
+		"key":              plan.Key.ValueString(),
+		"description":      plan.Description.ValueString(),
+		"baseChannelLabel": plan.BaseChannelLabel.ValueString(),
+		"entitlements":     entitlements,
+		"universalDefault": plan.UniversalDefault.ValueBool(),
+	}
+	if !plan.UsageLimit.IsNull() {
+		data["usageLimit"] = plan.UsageLimit.ValueInt64()
+	}
+	key, err := apiPost[string](ctx, client, "activationkey/create", data)
+	if err != nil && plan.AdoptExisting.ValueBool() && isAlreadyExistsError(err) {
+		tflog.Info(ctx, "Activation key "+plan.Key.ValueString()+" already exists, adopting it")
+		if err := adoptActivationKey(ctx, client, &plan, entitlements); err != nil {
+			resp.Diagnostics.AddError(
+				"Error creating activation key",
+				"Could not adopt activation key "+plan.Key.ValueString()+": "+err.Error(),
+			)
+			return
+		}
+
+		// Set state to fully populated data
+		diags = resp.State.Set(ctx, plan)
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating activation key",
+			"Could not create activation key "+plan.Key.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+	plan.ID = types.StringValue(key.Result)
+	_, name, _ := strings.Cut(key.Result, "-")
+	plan.Key = types.StringValue(name)
+
+	// The contact method can only be set after creating the key.
+	if plan.ContactMethod.ValueString() != contactMethodDefault {
+		_, err = apiPost[int](ctx, client, "activationkey/setDetails", map[string]interface{}{
+			"key":     key.Result,
+			"details": map[string]interface{}{"contact_method": plan.ContactMethod.ValueString()},
+		})
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error creating activation key",
+				"Could not set the contact method of activation key "+key.Result+": "+err.Error(),
+			)
+			// Fall through to track the key, which Terraform taints.
+		}
+	}
+	// Child channels and packages can only be added after creating the key.
+	if err := plan.changeContents(ctx, client, &uyuni.ActivationKey{Key: key.Result}); err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating activation key",
+			"Could not set the child channels and packages of activation key "+key.Result+": "+err.Error(),
+		)
+		// Fall through to track the key, which Terraform taints.
+	}
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read resource information.
+func (r *activationKeyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Get current state
+	var state activationKeyResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := resourceClient(ctx, r.client, state.ServerAlias, state.Org, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	key, err := getActivationKey(ctx, client, state.ID.ValueString())
+	if err != nil {
+		if handleNotFound(ctx, resp, err, "Activation key "+state.ID.ValueString()) {
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Reading Uyuni activation key",
+			"Could not read activation key "+state.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+	if err := state.read(ctx, key); err != nil {
+		resp.Diagnostics.AddError("Error Reading Uyuni activation key", err.Error())
+		return
+	}
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *activationKeyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Retrieve values from plan and state
+	var plan, state activationKeyResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	client := resourceClient(ctx, r.client, plan.ServerAlias, plan.Org, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	if !plan.RotationTriggers.Equal(state.RotationTriggers) {
+		r.rotate(ctx, client, &plan, &state, resp)
+		return
+	}
+
+	key := state.ID.ValueString()
+	if err := plan.update(ctx, client, key, &state); err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating activation key",
+			"Could not update activation key "+key+": "+err.Error(),
+		)
+		return
+	}
+
+	plan.ID = state.ID
+	plan.Key = state.Key
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// rotate replaces the key of the state by a clone with a new random key
+// updated to the plan, and deletes the old key.
+func (r *activationKeyResource) rotate(ctx context.Context, client *uyuniClient, plan, state *activationKeyResourceModel, resp *resource.UpdateResponse) {
+	old := state.ID.ValueString()
+	tflog.Info(ctx, "Rotating activation key "+old)
+	clone, err := apiPost[string](ctx, client, "activationkey/clone", map[string]interface{}{
+		"key":              old,
+		"cloneDescription": plan.Description.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error rotating activation key",
+			"Could not clone activation key "+old+": "+err.Error(),
+		)
+		return
+	}
+
+	if err := plan.update(ctx, client, clone.Result, state); err != nil {
+		// Keep the old key rather than tracking a half configured one.
+		if _, err := apiPost[int](ctx, client, "activationkey/delete", map[string]interface{}{"key": clone.Result}); err != nil {
+			tflog.Warn(ctx, "Could not delete the clone "+clone.Result+" of activation key "+old+": "+err.Error())
+		}
+		resp.Diagnostics.AddError(
+			"Error rotating activation key",
+			"Could not update the clone of activation key "+old+": "+err.Error(),
+		)
+		return
+	}
+
+	plan.ID = types.StringValue(clone.Result)
+	_, name, _ := strings.Cut(clone.Result, "-")
+	plan.Key = types.StringValue(name)
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+
+	_, err = apiPost[int](ctx, client, "activationkey/delete", map[string]interface{}{"key": old})
+	if err != nil && !isNotFoundError(err) {
+		resp.Diagnostics.AddError(
+			"Error rotating activation key",
+			fmt.Sprintf("Rotated activation key %s to %s but could not delete the old key, delete it manually: %s", old, clone.Result, err),
+		)
+	}
+}
+
+// update updates the key on the server, whose entitlements are those of
+// the state, to the model.
+func (m *activationKeyResourceModel) update(ctx context.Context, client *uyuniClient, key string, state *activationKeyResourceModel) error {
+	_, err := apiPost[int](ctx, client, "activationkey/setDetails", map[string]interface{}{
+		"key":     key,
+		"details": m.details(),
+	})
+	if err != nil {
+		return err
+	}
+
+	current, err := state.entitlements(ctx)
+	if err != nil {
+		return err
+	}
+	wanted, err := m.entitlements(ctx)
+	if err != nil {
+		return err
+	}
+	if err := changeEntitlements(ctx, client, key, current, wanted); err != nil {
+		return fmt.Errorf("could not update the entitlements: %w", err)
+	}
+
+	// Compare with the server, changing the base channel unsubscribes from
+	// the child channels of the previous one.
+	existing, err := getActivationKey(ctx, client, key)
+	if err == nil {
+		err = m.changeContents(ctx, client, existing)
+	}
+	if err != nil {
+		return fmt.Errorf("could not update the child channels and packages: %w", err)
+	}
+	return nil
+}
+
+// changeEntitlements adds and removes entitlements of the key.
+func changeEntitlements(ctx context.Context, client *uyuniClient, key string, current, wanted []string) error {
+	return changeKeyList(ctx, client, key, "Entitlements", "entitlements", current, wanted)
+}
+
+// changeContents adds and removes the child channels and packages of the
+// key, whose current contents are those of existing, to match the model.
+func (m *activationKeyResourceModel) changeContents(ctx context.Context, client *uyuniClient, existing *uyuni.ActivationKey) error {
+	children, err := m.childChannelLabels(ctx)
+	if err != nil {
+		return err
+	}
+	if err := changeKeyList(ctx, client, existing.Key, "ChildChannels", "childChannelLabels", existing.ChildChannelLabels, children); err != nil {
+		return err
+	}
+	packages, err := m.packages(ctx)
+	if err != nil {
+		return err
+	}
+	return changeKeyList(ctx, client, existing.Key, "Packages", "packages", existing.Packages, packages)
+}
+
+// changeKeyList adds and removes items of a list of the key with the
+// activationkey.add<list> and activationkey.remove<list> calls, which take
+// the items as param.
+func changeKeyList[T comparable](ctx context.Context, client *uyuniClient, key, list, param string, current, wanted []T) error {
+	add, remove := setDiff(current, wanted)
+	if len(add) > 0 {
+		if _, err := apiPost[int](ctx, client, "activationkey/add"+list, map[string]interface{}{
+			"key": key,
+			param: add,
+		}); err != nil {
+			return fmt.Errorf("could not add %s: %w", joinItems(add), err)
+		}
+	}
+	if len(remove) > 0 {
+		if _, err := apiPost[int](ctx, client, "activationkey/remove"+list, map[string]interface{}{
+			"key": key,
+			param: remove,
+		}); err != nil {
+			return fmt.Errorf("could not remove %s: %w", joinItems(remove), err)
+		}
+	}
+	return nil
+}
+
+// joinItems joins the items for messages.
+func joinItems[T any](items []T) string {
+	names := make([]string, 0, len(items))
+	for _, item := range items {
+		names = append(names, fmt.Sprint(item))
+	}
+	return strings.Join(names, ", ")
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *activationKeyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Retrieve values from state
+	var state activationKeyResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	client := resourceClient(ctx, r.client, state.ServerAlias, state.Org, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	_, err := apiPost[int](ctx, client, "activationkey/delete", map[string]interface{}{
+		"key": state.ID.ValueString(),
+	})
+	if err != nil && !isNotFoundError(err) {
+		resp.Diagnostics.AddError(
+			"Error Deleting Uyuni activation key",
+			"Could not delete activation key "+state.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+}
+
+// ImportState imports a key by its full key, e.g. "1-web".
+func (r *activationKeyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	_, key, ok := strings.Cut(req.ID, "-")
+	if !ok || key == "" {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected the full key prefixed with the organization ID, e.g. 1-web, got %q.", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("key"), key)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("adopt_existing"), false)...)
+}
+
+// UpgradeState upgrades states of prior schema versions.
+func (r *activationKeyResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return activationKeyStateMigrations.upgraders()
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *activationKeyResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*uyuniClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *uyuniClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
@@ -0,0 +1,350 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	acctest "github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestActivationKeySetsContactMethodAfterCreate(t *testing.T) {
+	ctx := context.Background()
+	var paths []string
+	var details map[string]interface{}
+	r := NewActivationKeyResource()
+	testConfigure(t, r, testAPIClient(t, func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		var body struct {
+			Details map[string]interface{} `json:"details"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		switch r.URL.Path {
+		case "/activationkey/create":
+			_, _ = w.Write([]byte(`{"success": true, "result": "1-web"}`))
+		case "/activationkey/setDetails":
+			details = body.Details
+			_, _ = w.Write([]byte(`{"success": true, "result": 1}`))
+		default:
+			_, _ = w.Write([]byte(`{"success": false, "message": "unexpected request ` + r.URL.String() + `"}`))
+		}
+	}))
+
+	planned := testState(t, r, map[string]interface{}{
+		"key":               "web",
+		"description":       "",
+		"universal_default": false,
+		"contact_method":    contactMethodSSHPush,
+	})
+	resp := &resource.CreateResponse{State: planned}
+	r.Create(ctx, resource.CreateRequest{Plan: tfsdk.Plan{Schema: planned.Schema, Raw: planned.Raw}}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+	if want := []string{"/activationkey/create", "/activationkey/setDetails"}; !reflect.DeepEqual(paths, want) {
+		t.Errorf("expected requests %v, got %v", want, paths)
+	}
+	if details["contact_method"] != contactMethodSSHPush {
+		t.Errorf("expected contact method %s, got %v", contactMethodSSHPush, details)
+	}
+	var state activationKeyResourceModel
+	resp.State.Get(ctx, &state)
+	if state.ID.ValueString() != "1-web" {
+		t.Errorf("expected id 1-web, got %s", state.ID)
+	}
+}
+
+func TestActivationKeyReadsDefaults(t *testing.T) {
+	ctx := context.Background()
+	r := NewActivationKeyResource()
+	client := testAPIClient(t, func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"success": true, "result": {"key": "1-web", "description": "Web", "usage_limit": 0,
+			"base_channel_label": "none", "entitlements": [], "universal_default": true, "contact_method": "default"}}`))
+	})
+
+	resp := testRead(t, r, client, map[string]interface{}{"id": "1-web", "key": "web"})
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+	var model activationKeyResourceModel
+	resp.State.Get(ctx, &model)
+	if !model.BaseChannelLabel.IsNull() || !model.UsageLimit.IsNull() || !model.Entitlements.IsNull() {
+		t.Errorf("expected unset base channel, usage limit and entitlements, got %s, %s, %s",
+			model.BaseChannelLabel, model.UsageLimit, model.Entitlements)
+	}
+	if !model.UniversalDefault.Equal(types.BoolValue(true)) || model.ContactMethod.ValueString() != contactMethodDefault {
+		t.Errorf("unexpected universal default %s or contact method %s", model.UniversalDefault, model.ContactMethod)
+	}
+}
+
+func TestActivationKeyCreateAdoptsExistingKey(t *testing.T) {
+	ctx := context.Background()
+	var paths []string
+	r := NewActivationKeyResource()
+	testConfigure(t, r, testAPIClient(t, func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		switch r.URL.Path {
+		case "/activationkey/create":
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(`{"success": false, "message": "Activation key 2-web already exists"}`))
+		case "/activationkey/listActivationKeys":
+			_, _ = w.Write([]byte(`{"success": true, "result": [
+				{"key": "2-db", "entitlements": []},
+				{"key": "2-web", "entitlements": ["monitoring_entitled"]}
+			]}`))
+		default:
+			_, _ = w.Write([]byte(`{"success": true, "result": 1}`))
+		}
+	}))
+
+	planned := testState(t, r, map[string]interface{}{
+		"key":               "web",
+		"description":       "",
+		"universal_default": false,
+		"contact_method":    contactMethodDefault,
+		"adopt_existing":    true,
+	})
+	resp := &resource.CreateResponse{State: planned}
+	r.Create(ctx, resource.CreateRequest{Plan: tfsdk.Plan{Schema: planned.Schema, Raw: planned.Raw}}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+	want := []string{"/activationkey/create", "/activationkey/listActivationKeys", "/activationkey/setDetails", "/activationkey/removeEntitlements"}
+	if !reflect.DeepEqual(paths, want) {
+		t.Errorf("expected requests %v, got %v", want, paths)
+	}
+	var state activationKeyResourceModel
+	resp.State.Get(ctx, &state)
+	if state.ID.ValueString() != "2-web" {
+		t.Errorf("expected id 2-web, got %s", state.ID)
+	}
+}
+
+func TestActivationKeyUpdateResubscribesChildChannels(t *testing.T) {
+	ctx := context.Background()
+	calls := map[string]interface{}{}
+	r := NewActivationKeyResource()
+	testConfigure(t, r, testAPIClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		switch r.URL.Path {
+		case "/activationkey/setDetails":
+			_, _ = w.Write([]byte(`{"success": true, "result": 1}`))
+		case "/activationkey/getDetails":
+			// The server dropped the child channel of the previous base
+			// channel.
+			_, _ = w.Write([]byte(`{"success": true, "result": {"key": "1-web", "description": "", "usage_limit": 0,
+				"base_channel_label": "sles15-sp6-pool-x86_64", "child_channel_labels": [], "entitlements": [],
+				"packages": [{"name": "vim"}], "universal_default": false, "contact_method": "default"}}`))
+		case "/activationkey/addChildChannels":
+			calls["children"] = body["childChannelLabels"]
+			_, _ = w.Write([]byte(`{"success": true, "result": 1}`))
+		case "/activationkey/addPackages":
+			calls["added"] = body["packages"]
+			_, _ = w.Write([]byte(`{"success": true, "result": 1}`))
+		case "/activationkey/removePackages":
+			calls["removed"] = body["packages"]
+			_, _ = w.Write([]byte(`{"success": true, "result": 1}`))
+		default:
+			_, _ = w.Write([]byte(`{"success": false, "message": "unexpected request ` + r.URL.String() + `"}`))
+		}
+	}))
+
+	attributes := map[string]interface{}{
+		"id":                   "1-web",
+		"key":                  "web",
+		"description":          "",
+		"base_channel_label":   "sles15-sp5-pool-x86_64",
+		"child_channel_labels": []string{"sles15-sp5-updates-x86_64"},
+		"universal_default":    false,
+		"contact_method":       contactMethodDefault,
+		"packages": []activationKeyPackageModel{
+			{Name: types.StringValue("vim"), Arch: types.StringNull()},
+		},
+	}
+	state := testState(t, r, attributes)
+	attributes["base_channel_label"] = "sles15-sp6-pool-x86_64"
+	attributes["child_channel_labels"] = []string{"sles15-sp6-updates-x86_64"}
+	attributes["packages"] = []activationKeyPackageModel{
+		{Name: types.StringValue("golang-github-prometheus-node_exporter"), Arch: types.StringValue("x86_64")},
+	}
+	planned := testState(t, r, attributes)
+	resp := &resource.UpdateResponse{State: state}
+	r.Update(ctx, resource.UpdateRequest{Plan: tfsdk.Plan{Schema: planned.Schema, Raw: planned.Raw}, State: state}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+	want := map[string]interface{}{
+		"children": []interface{}{"sles15-sp6-updates-x86_64"},
+		"added":    []interface{}{map[string]interface{}{"name": "golang-github-prometheus-node_exporter", "arch": "x86_64"}},
+		"removed":  []interface{}{map[string]interface{}{"name": "vim"}},
+	}
+	if !reflect.DeepEqual(calls, want) {
+		t.Errorf("expected calls %v, got %v", want, calls)
+	}
+}
+
+func TestActivationKeyRefusesEntitlementsNotOffered(t *testing.T) {
+	ctx := context.Background()
+	unlisted := testAPIClient(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"success": false, "message": "Either the user doesn't have the SAT_ADMIN role"}`))
+	})
+
+	for _, tc := range []struct {
+		client       *uyuniClient
+		entitlements []string
+		refused      bool
+	}{
+		{fixtureClient(t, "2024.08"), []string{"monitoring_entitled"}, false},
+		{fixtureClient(t, "2024.08"), []string{"monitoring_entitled", "container_build_host"}, true},
+		{unlisted, []string{"container_build_host"}, false},
+	} {
+		r := NewActivationKeyResource()
+		testConfigure(t, r, tc.client)
+		planned := testState(t, r, map[string]interface{}{
+			"key":          "web",
+			"description":  "",
+			"entitlements": tc.entitlements,
+		})
+		resp := &resource.ModifyPlanResponse{Plan: tfsdk.Plan{Schema: planned.Schema, Raw: planned.Raw}}
+		r.(resource.ResourceWithModifyPlan).ModifyPlan(ctx, resource.ModifyPlanRequest{
+			Plan: tfsdk.Plan{Schema: planned.Schema, Raw: planned.Raw},
+		}, resp)
+		if resp.Diagnostics.HasError() != tc.refused {
+			t.Errorf("%v: expected refused to be %t, got %v", tc.entitlements, tc.refused, resp.Diagnostics)
+		}
+	}
+}
+
+func TestActivationKeyPlansRotation(t *testing.T) {
+	ctx := context.Background()
+	r := NewActivationKeyResource()
+	attributes := map[string]interface{}{
+		"id":                "1-3f9ac2",
+		"key":               "3f9ac2",
+		"description":       "",
+		"rotation_triggers": map[string]string{"leaked": "2026-10-01"},
+	}
+	state := testState(t, r, attributes)
+	attributes["rotation_triggers"] = map[string]string{"leaked": "2026-10-16"}
+	planned := testState(t, r, attributes)
+
+	resp := &resource.ModifyPlanResponse{Plan: tfsdk.Plan{Schema: planned.Schema, Raw: planned.Raw}}
+	r.(resource.ResourceWithModifyPlan).ModifyPlan(ctx, resource.ModifyPlanRequest{
+		Plan:  tfsdk.Plan{Schema: planned.Schema, Raw: planned.Raw},
+		State: state,
+	}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+	var plan activationKeyResourceModel
+	resp.Plan.Get(ctx, &plan)
+	if !plan.ID.IsUnknown() || !plan.Key.IsUnknown() {
+		t.Errorf("expected a new key to be planned, got %s and %s", plan.ID, plan.Key)
+	}
+}
+
+func TestActivationKeyRotationClonesKey(t *testing.T) {
+	ctx := context.Background()
+	var deleted []string
+	r := NewActivationKeyResource()
+	testConfigure(t, r, testAPIClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		switch r.URL.Path {
+		case "/activationkey/clone":
+			if body["key"] != "1-3f9ac2" {
+				t.Errorf("expected the old key to be cloned, got %v", body)
+			}
+			_, _ = w.Write([]byte(`{"success": true, "result": "1-b71e04"}`))
+		case "/activationkey/setDetails":
+			if body["key"] != "1-b71e04" {
+				t.Errorf("expected the clone to be updated, got %v", body)
+			}
+			_, _ = w.Write([]byte(`{"success": true, "result": 1}`))
+		case "/activationkey/getDetails":
+			_, _ = w.Write([]byte(`{"success": true, "result": {"key": "1-b71e04", "description": "Web servers", "usage_limit": 0,
+				"base_channel_label": "none", "child_channel_labels": [], "entitlements": [], "packages": [],
+				"universal_default": false, "contact_method": "default"}}`))
+		case "/activationkey/delete":
+			deleted = append(deleted, body["key"].(string))
+			_, _ = w.Write([]byte(`{"success": true, "result": 1}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL)
+		}
+	}))
+
+	attributes := map[string]interface{}{
+		"id":                "1-3f9ac2",
+		"key":               "3f9ac2",
+		"description":       "Web servers",
+		"universal_default": false,
+		"contact_method":    contactMethodDefault,
+		"rotation_triggers": map[string]string{"leaked": "2026-10-01"},
+	}
+	state := testState(t, r, attributes)
+	attributes["id"] = types.StringUnknown()
+	attributes["key"] = types.StringUnknown()
+	attributes["rotation_triggers"] = map[string]string{"leaked": "2026-10-16"}
+	planned := testState(t, r, attributes)
+	resp := &resource.UpdateResponse{State: state}
+	r.Update(ctx, resource.UpdateRequest{Plan: tfsdk.Plan{Schema: planned.Schema, Raw: planned.Raw}, State: state}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+	var rotated activationKeyResourceModel
+	resp.State.Get(ctx, &rotated)
+	if rotated.ID.ValueString() != "1-b71e04" || rotated.Key.ValueString() != "b71e04" {
+		t.Errorf("expected the clone in the state, got %s and %s", rotated.ID, rotated.Key)
+	}
+	if !reflect.DeepEqual(deleted, []string{"1-3f9ac2"}) {
+		t.Errorf("expected the old key to be deleted, got %v", deleted)
+	}
+}
+
+func TestAccActivationKeyResource(t *testing.T) {
+	acctest.Test(t, acctest.TestCase{
+		PreCheck:                 func() { testAccRealServerPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckGone(t, "activationkey/getDetails?key=1-tfacc-key"),
+		Steps: []acctest.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccActivationKeyResourceConfig("Created by the acceptance tests"),
+				Check: acctest.ComposeAggregateTestCheckFunc(
+					acctest.TestCheckResourceAttr("uyuni_activation_key.test", "id", "1-tfacc-key"),
+					acctest.TestCheckResourceAttr("uyuni_activation_key.test", "usage_limit", "5"),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: testAccActivationKeyResourceConfig("Updated by the acceptance tests"),
+				Check:  acctest.TestCheckResourceAttr("uyuni_activation_key.test", "description", "Updated by the acceptance tests"),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "uyuni_activation_key.test",
+				ImportState:       true,
+				ImportStateId:     "1-tfacc-key",
+				ImportStateVerify: true,
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccActivationKeyResourceConfig(description string) string {
+	return fmt.Sprintf(`
+resource "uyuni_activation_key" "test" {
+  key         = "tfacc-key"
+  description = %q
+  usage_limit = 5
+}
+`, description)
+}
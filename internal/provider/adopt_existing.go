@@ -0,0 +1,50 @@
+package provider
+
+import (
+	"errors"
+	"strings"
+
+	"terraform-provider-uyuni/internal/uyuni"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+)
+
+// alreadyExistsMessages are the fault messages the API uses when creating an
+// object that exists, like "Login already in use" or
+// "Activation key 1-web already exists".
+var alreadyExistsMessages = []string{
+	"already exists",
+	"already in use",
+	"already taken",
+}
+
+// isAlreadyExistsError reports whether an API error means the object to
+// create already exists.
+func isAlreadyExistsError(err error) bool {
+	var fault *uyuni.Fault
+	if !errors.As(err, &fault) {
+		return false
+	}
+	msg := strings.ToLower(fault.Message)
+	for _, exists := range alreadyExistsMessages {
+		if strings.Contains(msg, exists) {
+			return true
+		}
+	}
+	return false
+}
+
+// adoptExistingAttribute is the schema of the adopt_existing attribute of
+// resources which can take over objects created outside Terraform, e.g.
+// when moving many hand-made objects under management without importing
+// each of them.
+func adoptExistingAttribute() schema.BoolAttribute {
+	return schema.BoolAttribute{
+		Description: "Adopt the object instead of failing when it already exists on the server, " +
+			"updating it to the configuration. Defaults to false.",
+		Optional: true,
+		Computed: true,
+		Default:  booldefault.StaticBool(false),
+	}
+}
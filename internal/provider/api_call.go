@@ -0,0 +1,200 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// apiCallIDPlaceholder is replaced with the ID of a uyuni_api_call resource in
+// the paths and bodies of its read and delete calls.
+const apiCallIDPlaceholder = "{id}"
+
+// apiCallModel maps a call of the uyuni_api_call resource.
+type apiCallModel struct {
+	Path   types.String `tfsdk:"path"`
+	Method types.String `tfsdk:"method"`
+	Body   types.String `tfsdk:"body"`
+}
+
+// apiCallMethods are the methods of API calls, the API only accepts GET and
+// POST.
+var apiCallMethods = []string{http.MethodGet, http.MethodPost}
+
+// callAPI sends an API call and returns its raw result. POST calls without a
+// body send an empty object.
+func callAPI(ctx context.Context, client *uyuniClient, method, path, body string) (json.RawMessage, error) {
+	var data []byte
+	if body != "" {
+		if !json.Valid([]byte(body)) {
+			return nil, errors.New("the body is not valid JSON")
+		}
+		data = []byte(body)
+	} else if method == http.MethodPost {
+		data = []byte("{}")
+	}
+	response, err := apiRequest[json.RawMessage](ctx, client, method, path, data)
+	if err != nil {
+		return nil, err
+	}
+	if len(response.Result) == 0 {
+		return json.RawMessage("null"), nil
+	}
+	return response.Result, nil
+}
+
+// send sends the call with the ID substituted for its placeholder. The ID is
+// query escaped in the path and inserted verbatim in the body.
+func (c *apiCallModel) send(ctx context.Context, client *uyuniClient, id string) (json.RawMessage, error) {
+	path := strings.ReplaceAll(c.Path.ValueString(), apiCallIDPlaceholder, url.QueryEscape(id))
+	body := strings.ReplaceAll(c.Body.ValueString(), apiCallIDPlaceholder, id)
+	method := http.MethodGet
+	if !c.Method.IsNull() && !c.Method.IsUnknown() {
+		method = c.Method.ValueString()
+	}
+	return callAPI(ctx, client, method, path, body)
+}
+
+// jsonPathStep is a step of a JSONPath: a member name or an array index.
+type jsonPathStep struct {
+	name    string
+	index   int
+	isIndex bool
+}
+
+// parseJSONPath parses the subset of JSONPath the uyuni_api_call resource and
+// data source support: the root $ followed by members as .name or ['name'] and
+// array indexes as [N].
+func parseJSONPath(expr string) ([]jsonPathStep, error) {
+	if !strings.HasPrefix(expr, "$") {
+		return nil, errors.New("the path does not start with $")
+	}
+	var steps []jsonPathStep
+	rest := expr[1:]
+	for rest != "" {
+		switch {
+		case rest[0] == '.':
+			end := strings.IndexAny(rest[1:], ".[")
+			if end < 0 {
+				end = len(rest) - 1
+			}
+			name := rest[1 : end+1]
+			if name == "" || name == "*" {
+				return nil, fmt.Errorf("invalid member at %q", rest)
+			}
+			steps = append(steps, jsonPathStep{name: name})
+			rest = rest[end+1:]
+		case strings.HasPrefix(rest, "['") || strings.HasPrefix(rest, `["`):
+			quote := rest[1:2]
+			end := strings.Index(rest[2:], quote+"]")
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated member at %q", rest)
+			}
+			steps = append(steps, jsonPathStep{name: rest[2 : end+2]})
+			rest = rest[end+4:]
+		case rest[0] == '[':
+			end := strings.Index(rest, "]")
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated index at %q", rest)
+			}
+			index, err := strconv.Atoi(rest[1:end])
+			if err != nil || index < 0 {
+				return nil, fmt.Errorf("invalid index at %q", rest)
+			}
+			steps = append(steps, jsonPathStep{index: index, isIndex: true})
+			rest = rest[end+1:]
+		default:
+			return nil, fmt.Errorf("unexpected %q", rest)
+		}
+	}
+	return steps, nil
+}
+
+// extractJSONPath returns the JSON encoding of the value expr selects in data.
+func extractJSONPath(data json.RawMessage, expr string) (json.RawMessage, error) {
+	steps, err := parseJSONPath(expr)
+	if err != nil {
+		return nil, err
+	}
+	value, at := data, "$"
+	for _, step := range steps {
+		if step.isIndex {
+			var items []json.RawMessage
+			if json.Unmarshal(value, &items) != nil {
+				return nil, fmt.Errorf("%s is not an array", at)
+			}
+			if step.index >= len(items) {
+				return nil, fmt.Errorf("%s has %d items, index %d is out of range", at, len(items), step.index)
+			}
+			value = items[step.index]
+			at = fmt.Sprintf("%s[%d]", at, step.index)
+			continue
+		}
+		var fields map[string]json.RawMessage
+		if json.Unmarshal(value, &fields) != nil || fields == nil {
+			return nil, fmt.Errorf("%s is not an object", at)
+		}
+		field, ok := fields[step.name]
+		if !ok {
+			return nil, fmt.Errorf("%s has no member %q", at, step.name)
+		}
+		value = field
+		at = fmt.Sprintf("%s[%q]", at, step.name)
+	}
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, value); err != nil {
+		return nil, err
+	}
+	return compact.Bytes(), nil
+}
+
+// jsonScalar returns the string or number data encodes as a string.
+func jsonScalar(data json.RawMessage) (string, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return "", err
+	}
+	switch value := value.(type) {
+	case string:
+		return value, nil
+	case json.Number:
+		return value.String(), nil
+	default:
+		return "", fmt.Errorf("%s is not a string or number", data)
+	}
+}
+
+// jsonPathValidator ensures that the value is a JSONPath of the supported
+// subset.
+type jsonPathValidator struct{}
+
+// Description implements validator.Describer.
+func (v jsonPathValidator) Description(_ context.Context) string {
+	return "must be a JSONPath of members and array indexes, e.g. $[0].id"
+}
+
+// MarkdownDescription implements validator.Describer.
+func (v jsonPathValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateString implements validator.String.
+func (v jsonPathValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	if _, err := parseJSONPath(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid JSONPath", err.Error())
+	}
+}
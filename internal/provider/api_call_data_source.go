@@ -0,0 +1,153 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"terraform-provider-uyuni/internal/validators"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &APICallDataSource{}
+	_ datasource.DataSourceWithConfigure = &APICallDataSource{}
+)
+
+// APICallDataSourceModel maps the data source schema data.
+type APICallDataSourceModel struct {
+	Path       types.String `tfsdk:"path"`
+	Method     types.String `tfsdk:"method"`
+	Body       types.String `tfsdk:"body"`
+	ResultPath types.String `tfsdk:"result_path"`
+	Result     types.String `tfsdk:"result"`
+}
+
+// NewAPICallDataSource is a helper function to simplify the provider implementation.
+func NewAPICallDataSource() datasource.DataSource {
+	return &APICallDataSource{}
+}
+
+// APICallDataSource is the data source implementation.
+type APICallDataSource struct {
+	client *uyuniClient
+}
+
+// Metadata returns the data source type name.
+func (d *APICallDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_api_call"
+}
+
+// Schema defines the schema for the data source.
+func (d *APICallDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Reads the result of an arbitrary API call, for data the provider has no data source for yet. " +
+			"The call runs on every refresh, so it should only read. Use uyuni_api_call resources for calls " +
+			"changing the server.",
+		Attributes: map[string]schema.Attribute{
+			"path": schema.StringAttribute{
+				Description: "API call as namespace/method relative to the API root, optionally followed by a query, " +
+					"e.g. `system/getDetails?sid=1000010000`.",
+				Required: true,
+				Validators: []validator.String{
+					validators.APIEndpoint(),
+				},
+			},
+			"method": schema.StringAttribute{
+				Description: "HTTP method of the call: `GET` or `POST`. Defaults to `GET`.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(apiCallMethods...),
+				},
+			},
+			"body": schema.StringAttribute{
+				Description: "JSON body of the call, e.g. built with jsonencode.",
+				Optional:    true,
+				Sensitive:   true,
+			},
+			"result_path": schema.StringAttribute{
+				Description: "JSONPath selecting the result in the result of the call, e.g. `$.hostname`. Members " +
+					"are selected with `.name` or `['name']` and array items with `[N]`. Defaults to `$`, the " +
+					"whole result.",
+				Optional: true,
+				Validators: []validator.String{
+					jsonPathValidator{},
+				},
+			},
+			"result": schema.StringAttribute{
+				Description: "JSON encoding of the value result_path selects, decode it with jsondecode.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *APICallDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state APICallDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	method := http.MethodGet
+	if !state.Method.IsNull() {
+		method = state.Method.ValueString()
+	}
+	data, err := callAPI(ctx, d.client, method, state.Path.ValueString(), state.Body.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Uyuni API call",
+			fmt.Sprintf("Could not call %s: %s", state.Path.ValueString(), err),
+		)
+		return
+	}
+	resultPath := "$"
+	if !state.ResultPath.IsNull() {
+		resultPath = state.ResultPath.ValueString()
+	}
+	result, err := extractJSONPath(data, resultPath)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Uyuni API call",
+			fmt.Sprintf("Could not select the result of %s: %s", state.Path.ValueString(), err),
+		)
+		return
+	}
+	state.Result = types.StringValue(string(result))
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *APICallDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*uyuniClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *uyuniClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
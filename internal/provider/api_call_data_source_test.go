@@ -0,0 +1,55 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAPICallDataSourceSelectsResult(t *testing.T) {
+	ctx := context.Background()
+	client := testAPIClient(t, func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet || req.URL.Path != "/system/getNetwork" || req.URL.Query().Get("sid") != "1000010000" {
+			t.Errorf("unexpected request %s %s", req.Method, req.URL)
+		}
+		_, _ = w.Write([]byte(`{"success": true, "result": {"ip": "192.0.2.10", "hostname": "web01.example.com"}}`))
+	})
+
+	resp := testDataSourceRead(t, NewAPICallDataSource(), client, map[string]tftypes.Value{
+		"path":        tftypes.NewValue(tftypes.String, "system/getNetwork?sid=1000010000"),
+		"result_path": tftypes.NewValue(tftypes.String, "$.hostname"),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+
+	var state APICallDataSourceModel
+	resp.State.Get(ctx, &state)
+	if state.Result.ValueString() != `"web01.example.com"` {
+		t.Errorf("unexpected result %s", state.Result)
+	}
+}
+
+func TestAccAPICallDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccUyuniPreCheck(t)
+			testAccSeedSystemGroup(t, "tfacc-ds-api-call")
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "uyuni_api_call" "test" {
+  path        = "systemgroup/getDetails?systemGroupName=tfacc-ds-api-call"
+  result_path = "$.description"
+}
+`,
+				Check: resource.TestCheckResourceAttr("data.uyuni_api_call.test", "result", `"Group of the acceptance tests"`),
+			},
+		},
+	})
+}
@@ -0,0 +1,352 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"terraform-provider-uyuni/internal/validators"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/objectvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource              = &apiCallResource{}
+	_ resource.ResourceWithConfigure = &apiCallResource{}
+)
+
+// NewAPICallResource is a helper function to simplify the provider implementation.
+func NewAPICallResource() resource.Resource {
+	return &apiCallResource{}
+}
+
+// apiCallResource is the resource implementation.
+type apiCallResource struct {
+	client *uyuniClient
+}
+
+// apiCallResourceModel maps the resource schema data.
+type apiCallResourceModel struct {
+	ID           types.String  `tfsdk:"id"`
+	Create       *apiCallModel `tfsdk:"create"`
+	Read         *apiCallModel `tfsdk:"read"`
+	Delete       *apiCallModel `tfsdk:"delete"`
+	IDPath       types.String  `tfsdk:"id_path"`
+	ResultPath   types.String  `tfsdk:"result_path"`
+	Triggers     types.Map     `tfsdk:"triggers"`
+	CreateResult types.String  `tfsdk:"create_result"`
+	Result       types.String  `tfsdk:"result"`
+	ServerAlias  types.String  `tfsdk:"server_alias"`
+	Org          *orgModel     `tfsdk:"org"`
+}
+
+// Metadata returns the resource type name.
+func (r *apiCallResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_api_call"
+}
+
+// apiCallBlock is the schema of a call of the resource.
+func apiCallBlock(description string) schema.SingleNestedBlock {
+	return schema.SingleNestedBlock{
+		Description: description,
+		Attributes: map[string]schema.Attribute{
+			"path": schema.StringAttribute{
+				Description: "API call as namespace/method relative to the API root, optionally followed by a query, " +
+					"e.g. `systemgroup/getDetails?systemGroupName=web`.",
+				Required: true,
+				Validators: []validator.String{
+					validators.APIEndpoint(),
+				},
+			},
+			"method": schema.StringAttribute{
+				Description: "HTTP method of the call: `GET` or `POST`. Defaults to `GET`.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(apiCallMethods...),
+				},
+			},
+			"body": schema.StringAttribute{
+				Description: "JSON body of the call, e.g. built with jsonencode. POST calls without one send an empty object.",
+				Optional:    true,
+				Sensitive:   true,
+			},
+		},
+	}
+}
+
+// Schema defines the schema for the resource.
+func (r *apiCallResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	create := apiCallBlock("Call creating the object. Changing it creates a new object.")
+	create.Validators = []validator.Object{
+		objectvalidator.IsRequired(),
+	}
+	create.PlanModifiers = []planmodifier.Object{
+		objectplanmodifier.RequiresReplace(),
+	}
+
+	resp.Schema = schema.Schema{
+		Description: "Manages an object through arbitrary API calls, for objects the provider has no resource for " +
+			"yet. The create call runs on create, the read call on refresh and the delete call on destroy. " +
+			"`{id}` in the path and body of the read and delete calls is replaced with the ID of the object, " +
+			"query escaped in the path and verbatim in the body. The calls are not checked beyond their syntax, " +
+			"prefer a dedicated resource where one exists.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "ID of the object, selected from the result of the create call with id_path.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"id_path": schema.StringAttribute{
+				Description: "JSONPath selecting the ID in the result of the create call, e.g. `$.id`. It must " +
+					"select a string or number. Defaults to the path of the create call.",
+				Optional: true,
+				Validators: []validator.String{
+					jsonPathValidator{},
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"result_path": schema.StringAttribute{
+				Description: "JSONPath selecting the result in the result of the read call, or of the create call " +
+					"without one. Members are selected with `.name` or `['name']` and array items with `[N]`. " +
+					"Defaults to `$`, the whole result.",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString("$"),
+				Validators: []validator.String{
+					jsonPathValidator{},
+				},
+			},
+			"triggers": schema.MapAttribute{
+				Description: "Arbitrary values that create a new object when changed.",
+				ElementType: types.StringType,
+				Optional:    true,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"create_result": schema.StringAttribute{
+				Description: "JSON encoding of the result of the create call.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"result": schema.StringAttribute{
+				Description: "JSON encoding of the value result_path selects, decode it with jsondecode.",
+				Computed:    true,
+			},
+			"server_alias": serverAliasAttribute(),
+		},
+		Blocks: map[string]schema.Block{
+			"create": create,
+			"read": apiCallBlock("Call reading the object on refresh. If the server reports the object as missing, " +
+				"it is created again. Without it, the result is selected from the create result."),
+			"delete": apiCallBlock("Call deleting the object on destroy. Without it, destroying the resource only " +
+				"removes it from state."),
+			"org": orgBlock(),
+		},
+	}
+}
+
+// refresh sets the result from the read call, or from the create result
+// without one.
+func (m *apiCallResourceModel) refresh(ctx context.Context, client *uyuniClient) error {
+	data := json.RawMessage(m.CreateResult.ValueString())
+	if m.Read != nil {
+		var err error
+		if data, err = m.Read.send(ctx, client, m.ID.ValueString()); err != nil {
+			return err
+		}
+	}
+	resultPath := "$"
+	if !m.ResultPath.IsNull() {
+		resultPath = m.ResultPath.ValueString()
+	}
+	result, err := extractJSONPath(data, resultPath)
+	if err != nil {
+		return fmt.Errorf("could not select the result: %w", err)
+	}
+	m.Result = types.StringValue(string(result))
+	return nil
+}
+
+// Create a new resource.
+func (r *apiCallResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan apiCallResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := resourceClient(ctx, r.client, plan.ServerAlias, plan.Org, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	created, err := plan.Create.send(ctx, client, "")
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error calling Uyuni API",
+			fmt.Sprintf("Could not call %s: %s", plan.Create.Path.ValueString(), err),
+		)
+		return
+	}
+	plan.CreateResult = types.StringValue(string(created))
+
+	id := plan.Create.Path.ValueString()
+	if !plan.IDPath.IsNull() {
+		selected, err := extractJSONPath(created, plan.IDPath.ValueString())
+		if err == nil {
+			id, err = jsonScalar(selected)
+		}
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error calling Uyuni API",
+				fmt.Sprintf("Could not select the ID in the result of %s: %s", plan.Create.Path.ValueString(), err),
+			)
+			return
+		}
+	}
+	plan.ID = types.StringValue(id)
+
+	if err := plan.refresh(ctx, client); err != nil {
+		// The object exists, keep it in state so that it can be destroyed.
+		resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+		resp.Diagnostics.AddError(
+			"Error reading Uyuni API call result",
+			fmt.Sprintf("Could not read object %s: %s", id, err),
+		)
+		return
+	}
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read refreshes the result with the read call.
+func (r *apiCallResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Get current state
+	var state apiCallResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := resourceClient(ctx, r.client, state.ServerAlias, state.Org, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	if err := state.refresh(ctx, client); err != nil {
+		if handleNotFound(ctx, resp, err, "Object "+state.ID.ValueString()) {
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Reading Uyuni API call result",
+			fmt.Sprintf("Could not read object %s: %s", state.ID.ValueString(), err),
+		)
+		return
+	}
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update changes the read and delete calls and refreshes the result, the
+// object itself is left alone.
+func (r *apiCallResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Retrieve values from plan
+	var plan, state apiCallResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := resourceClient(ctx, r.client, plan.ServerAlias, plan.Org, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	plan.ID = state.ID
+	plan.CreateResult = state.CreateResult
+	if err := plan.refresh(ctx, client); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Uyuni API call result",
+			fmt.Sprintf("Could not read object %s: %s", plan.ID.ValueString(), err),
+		)
+		return
+	}
+
+	diags := resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete deletes the object with the delete call.
+func (r *apiCallResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state apiCallResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if state.Delete == nil {
+		tflog.Info(ctx, "Removing object "+state.ID.ValueString()+" from state, it has no delete call")
+		return
+	}
+
+	client := resourceClient(ctx, r.client, state.ServerAlias, state.Org, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	if _, err := state.Delete.send(ctx, client, state.ID.ValueString()); err != nil && !isNotFoundError(err) {
+		resp.Diagnostics.AddError(
+			"Error Deleting Uyuni API call object",
+			fmt.Sprintf("Could not delete object %s: %s", state.ID.ValueString(), err),
+		)
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *apiCallResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*uyuniClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *uyuniClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
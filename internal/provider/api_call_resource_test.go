@@ -0,0 +1,164 @@
+package provider
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	acctest "github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAPICallManagesObject(t *testing.T) {
+	ctx := context.Background()
+	var deleted string
+	r := NewAPICallResource()
+	testConfigure(t, r, testAPIClient(t, func(w http.ResponseWriter, req *http.Request) {
+		switch req.Method + " " + req.URL.Path {
+		case "POST /systemgroup/create":
+			body, _ := io.ReadAll(req.Body)
+			if string(body) != `{"name": "web servers", "description": "Web servers"}` {
+				t.Errorf("unexpected body %s", body)
+			}
+			_, _ = w.Write([]byte(`{"success": true, "result": {"id": 42, "name": "web servers", "system_count": 0}}`))
+		case "GET /systemgroup/getDetails":
+			if name := req.URL.Query().Get("systemGroupName"); name != "web servers" {
+				t.Errorf("unexpected group %q", name)
+			}
+			_, _ = w.Write([]byte(`{"success": true, "result": {"id": 42, "name": "web servers", "system_count": 3}}`))
+		case "POST /systemgroup/delete":
+			body, _ := io.ReadAll(req.Body)
+			deleted = string(body)
+			_, _ = w.Write([]byte(`{"success": true, "result": 1}`))
+		default:
+			t.Errorf("unexpected request %s %s", req.Method, req.URL)
+		}
+	}))
+
+	planned := testState(t, r, map[string]interface{}{
+		"create": &apiCallModel{
+			Path:   types.StringValue("systemgroup/create"),
+			Method: types.StringValue(http.MethodPost),
+			Body:   types.StringValue(`{"name": "web servers", "description": "Web servers"}`),
+		},
+		"read": &apiCallModel{
+			Path:   types.StringValue("systemgroup/getDetails?systemGroupName={id}"),
+			Method: types.StringNull(),
+			Body:   types.StringNull(),
+		},
+		"delete": &apiCallModel{
+			Path:   types.StringValue("systemgroup/delete"),
+			Method: types.StringValue(http.MethodPost),
+			Body:   types.StringValue(`{"systemGroupName": "{id}"}`),
+		},
+		"id_path":     "$.name",
+		"result_path": "$.system_count",
+	})
+	resp := &resource.CreateResponse{State: planned}
+	r.Create(ctx, resource.CreateRequest{Plan: tfsdk.Plan{Schema: planned.Schema, Raw: planned.Raw}}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+
+	var state apiCallResourceModel
+	resp.State.Get(ctx, &state)
+	if state.ID.ValueString() != "web servers" {
+		t.Errorf("expected ID web servers, got %s", state.ID)
+	}
+	if state.CreateResult.ValueString() != `{"id": 42, "name": "web servers", "system_count": 0}` {
+		t.Errorf("unexpected create result %s", state.CreateResult)
+	}
+	if state.Result.ValueString() != "3" {
+		t.Errorf("expected the result of the read call, got %s", state.Result)
+	}
+
+	deleteResp := &resource.DeleteResponse{State: resp.State}
+	r.Delete(ctx, resource.DeleteRequest{State: resp.State}, deleteResp)
+	if deleteResp.Diagnostics.HasError() {
+		t.Fatal(deleteResp.Diagnostics)
+	}
+	if deleted != `{"systemGroupName": "web servers"}` {
+		t.Errorf("unexpected delete body %s", deleted)
+	}
+}
+
+func TestAPICallWithoutReadKeepsCreateResult(t *testing.T) {
+	ctx := context.Background()
+	r := NewAPICallResource()
+	testConfigure(t, r, testAPIClient(t, func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/kickstart/keys/create" {
+			t.Errorf("unexpected request %s %s", req.Method, req.URL)
+		}
+		_, _ = w.Write([]byte(`{"success": true, "result": 1}`))
+	}))
+
+	planned := testState(t, r, map[string]interface{}{
+		"create": &apiCallModel{
+			Path:   types.StringValue("kickstart/keys/create"),
+			Method: types.StringValue(http.MethodPost),
+			Body:   types.StringNull(),
+		},
+		"result_path": "$",
+	})
+	resp := &resource.CreateResponse{State: planned}
+	r.Create(ctx, resource.CreateRequest{Plan: tfsdk.Plan{Schema: planned.Schema, Raw: planned.Raw}}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+	var state apiCallResourceModel
+	resp.State.Get(ctx, &state)
+	if state.ID.ValueString() != "kickstart/keys/create" || state.Result.ValueString() != "1" {
+		t.Errorf("unexpected state %s, %s", state.ID, state.Result)
+	}
+
+	// Refreshing without a read call sends no request.
+	readResp := &resource.ReadResponse{State: resp.State}
+	r.Read(ctx, resource.ReadRequest{State: resp.State}, readResp)
+	if readResp.Diagnostics.HasError() || readResp.State.Raw.IsNull() {
+		t.Fatalf("unexpected read result: %v", readResp.Diagnostics)
+	}
+}
+
+func TestAccAPICallResource(t *testing.T) {
+	acctest.Test(t, acctest.TestCase{
+		PreCheck:                 func() { testAccUyuniPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckGone(t, "systemgroup/getDetails?systemGroupName=tfacc-api-call"),
+		Steps: []acctest.TestStep{
+			{
+				Config: `
+resource "uyuni_api_call" "test" {
+  create {
+    path   = "systemgroup/create"
+    method = "POST"
+    body = jsonencode({
+      name        = "tfacc-api-call"
+      description = "Created by the acceptance tests"
+    })
+  }
+
+  read {
+    path = "systemgroup/getDetails?systemGroupName={id}"
+  }
+
+  delete {
+    path   = "systemgroup/delete"
+    method = "POST"
+    body   = jsonencode({ systemGroupName = "{id}" })
+  }
+
+  id_path     = "$.name"
+  result_path = "$.description"
+}
+`,
+				Check: acctest.ComposeAggregateTestCheckFunc(
+					acctest.TestCheckResourceAttr("uyuni_api_call.test", "id", "tfacc-api-call"),
+					acctest.TestCheckResourceAttr("uyuni_api_call.test", "result", `"Created by the acceptance tests"`),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,54 @@
+package provider
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestExtractJSONPath(t *testing.T) {
+	data := json.RawMessage(`{"id": 1000010000, "name": "web01", "net": {"ips": ["192.0.2.10", "192.0.2.11"]}, "a.b": true}`)
+	tests := map[string]struct {
+		want    string
+		wantErr bool
+	}{
+		"$":             {want: `{"id":1000010000,"name":"web01","net":{"ips":["192.0.2.10","192.0.2.11"]},"a.b":true}`},
+		"$.id":          {want: `1000010000`},
+		"$.net.ips[1]":  {want: `"192.0.2.11"`},
+		"$['a.b']":      {want: `true`},
+		`$["net"].ips`:  {want: `["192.0.2.10","192.0.2.11"]`},
+		"$.net.ips[2]":  {wantErr: true},
+		"$.missing":     {wantErr: true},
+		"$.name[0]":     {wantErr: true},
+		"$.id.value":    {wantErr: true},
+		"name":          {wantErr: true},
+		"$.":            {wantErr: true},
+		"$.net.ips[*]":  {wantErr: true},
+		"$['unclosed":   {wantErr: true},
+		"$.net..ips[0]": {wantErr: true},
+	}
+	for expr, tc := range tests {
+		got, err := extractJSONPath(data, expr)
+		if tc.wantErr {
+			if err == nil {
+				t.Errorf("%s: expected an error, got %s", expr, got)
+			}
+			continue
+		}
+		if err != nil || string(got) != tc.want {
+			t.Errorf("%s: expected %s, got %s, %v", expr, tc.want, got, err)
+		}
+	}
+}
+
+func TestJSONScalar(t *testing.T) {
+	for data, want := range map[string]string{`"web"`: "web", `1000010000`: "1000010000", `12345678901234567890`: "12345678901234567890"} {
+		if got, err := jsonScalar(json.RawMessage(data)); err != nil || got != want {
+			t.Errorf("%s: expected %s, got %s, %v", data, want, got, err)
+		}
+	}
+	for _, data := range []string{`{"id": 1}`, `[1]`, `true`, `null`} {
+		if _, err := jsonScalar(json.RawMessage(data)); err == nil {
+			t.Errorf("%s: expected an error", data)
+		}
+	}
+}
@@ -0,0 +1,204 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"terraform-provider-uyuni/internal/uyuni"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	// defaultRequestTimeout limits API requests whose context carries no deadline,
+	// matching the timeout of the clients created by api.Init.
+	defaultRequestTimeout = time.Minute
+
+	// defaultOperationTimeout is used for create, update and delete operations
+	// when no timeouts block is configured.
+	defaultOperationTimeout = 20 * time.Minute
+)
+
+// apiError is a failed API call. It names the call and the HTTP status, so
+// that diagnostics rendering it are enough to troubleshoot. Faults reported by
+// the server remain available through errors.As.
+type apiError struct {
+	Method string
+	Path   string
+	// StatusCode is the HTTP status of the response, zero if none was received.
+	StatusCode int
+	Err        error
+}
+
+func (e *apiError) Error() string {
+	msg := e.Err.Error()
+	var fault *uyuni.Fault
+	if errors.As(e.Err, &fault) && fault.Message == "" {
+		msg = "no fault message"
+	}
+	if e.StatusCode == 0 {
+		return fmt.Sprintf("%s %s: %s", e.Method, e.Path, msg)
+	}
+	return fmt.Sprintf("%s %s returned HTTP %d: %s", e.Method, e.Path, e.StatusCode, msg)
+}
+
+func (e *apiError) Unwrap() error {
+	return e.Err
+}
+
+// apiGet sends a GET request to the Uyuni API. Unlike api.Get the request is
+// bound to ctx, so operation timeouts and cancellation abort it.
+func apiGet[T interface{}](ctx context.Context, client *uyuniClient, path string) (*uyuni.Response[T], error) {
+	return apiRequest[T](ctx, client, http.MethodGet, path, nil)
+}
+
+// apiPost sends a POST request with a JSON body to the Uyuni API, bound to ctx.
+func apiPost[T interface{}](ctx context.Context, client *uyuniClient, path string, data map[string]interface{}) (*uyuni.Response[T], error) {
+	jsonData, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	return apiRequest[T](ctx, client, http.MethodPost, path, jsonData)
+}
+
+// apiRequest sends a request to the Uyuni API and decodes the response strictly,
+// logging a warning for every field that does not match the model. Requests
+// failing transiently or because the server is busy are retried with
+// exponential backoff.
+func apiRequest[T interface{}](ctx context.Context, client *uyuniClient, method, path string, body []byte) (*uyuni.Response[T], error) {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, defaultRequestTimeout)
+		defer cancel()
+	}
+
+	response, err := apiAttempt[T](ctx, client, method, path, body)
+	busy, transient := 0, 0
+	for err != nil {
+		var delay time.Duration
+		switch {
+		case retriesBusy(method, path) && isBusyError(err):
+			// Writes racing with a repository synchronization are retried
+			// until it finished or ctx is done.
+			delay = busyRetryDelay(busy)
+			busy++
+			tflog.Warn(ctx, "Uyuni server busy synchronizing repositories, retrying", map[string]interface{}{
+				"path":  path,
+				"error": err.Error(),
+				"delay": delay.String(),
+			})
+		case transient < client.retries && isTransientError(method, err):
+			delay = transientRetryDelay(transient)
+			transient++
+			tflog.Warn(ctx, "Uyuni API request failed transiently, retrying", map[string]interface{}{
+				"path":  path,
+				"error": err.Error(),
+				"delay": delay.String(),
+				"retry": transient,
+			})
+		default:
+			return nil, err
+		}
+		select {
+		case <-ctx.Done():
+			return nil, err
+		case <-time.After(delay):
+		}
+		response, err = apiAttempt[T](ctx, client, method, path, body)
+	}
+	return response, nil
+}
+
+// apiAttempt sends a request once and decodes the response.
+func apiAttempt[T interface{}](ctx context.Context, client *uyuniClient, method, path string, body []byte) (*uyuni.Response[T], error) {
+	data, status, err := client.call(ctx, method, path, body)
+	if err != nil {
+		return nil, &apiError{Method: method, Path: path, StatusCode: status, Err: err}
+	}
+
+	response, warnings, err := uyuni.Decode[T](data)
+	if err != nil {
+		return nil, &apiError{Method: method, Path: path, StatusCode: status, Err: err}
+	}
+	for _, warning := range warnings {
+		tflog.Warn(ctx, "Unexpected Uyuni API response: "+warning, map[string]interface{}{"path": path})
+	}
+	return response, nil
+}
+
+// call sends a request and returns the body of a successful response and the
+// HTTP status, which is zero if no response was received. GET responses are
+// served from the read cache when possible, while any other request
+// invalidates it. Calls the server is known not to offer fail without a
+// request, and calls to an overloaded server wait for the pacer.
+func (c *uyuniClient) call(ctx context.Context, method, path string, body []byte) ([]byte, int, error) {
+	if err := c.checkFeature(path); err != nil {
+		return nil, 0, err
+	}
+
+	var generation uint64
+	if method == http.MethodGet {
+		cached, current, ok := c.cache.get(path)
+		if ok {
+			ctx = withLogSubsystem(ctx, logSubsystemClient)
+			tflog.SubsystemTrace(ctx, logSubsystemClient, "Serving Uyuni API response from cache", map[string]interface{}{"path": path})
+			return cached, http.StatusOK, nil
+		}
+		generation = current
+	} else {
+		// Invalidate before and after the write, so that no read running
+		// concurrently caches the state from before it.
+		c.cache.invalidate()
+		defer c.cache.invalidate()
+	}
+
+	if err := c.pacer.acquire(ctx); err != nil {
+		return nil, 0, err
+	}
+	start := time.Now()
+	data, status, err := c.fetch(ctx, method, path, body)
+	end := time.Now()
+	c.pacer.release(ctx, pacingEndpoint(method, path), status, end.Sub(start))
+	c.logAPICall(ctx, method, path, len(body), len(data), status, end.Sub(start), err)
+	c.tracer.record(ctx, c.baseURL, method, path, status, start, end, err)
+	c.audit.record(ctx, c.baseURL, c.username, method, path, body, status, err)
+	if err != nil {
+		return nil, status, err
+	}
+	if method == http.MethodGet {
+		c.cache.put(path, data, generation)
+	}
+	return data, status, nil
+}
+
+// fetch sends a request to the server and returns the body of a successful
+// response and the HTTP status, which is zero if no response was received.
+func (c *uyuniClient) fetch(ctx context.Context, method, path string, body []byte) ([]byte, int, error) {
+	res, err := c.do(ctx, method, path, body)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusBadRequest {
+		fault := &uyuni.Fault{StatusCode: res.StatusCode}
+		var errResponse struct {
+			Message string
+		}
+		if err := json.NewDecoder(res.Body).Decode(&errResponse); err == nil {
+			fault.Message = errResponse.Message
+		}
+		return nil, res.StatusCode, fault
+	}
+
+	data, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, res.StatusCode, err
+	}
+	return data, res.StatusCode, nil
+}
@@ -0,0 +1,141 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"terraform-provider-uyuni/internal/uyuni"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+)
+
+func testAPIClient(t *testing.T, handler http.HandlerFunc) *uyuniClient {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return &uyuniClient{baseURL: server.URL, httpClient: server.Client()}
+}
+
+func TestAPIRequestDecodesResult(t *testing.T) {
+	client := testAPIClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/user/getDetails" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		_, _ = w.Write([]byte(`{"success": true, "result": {"email": "jdoe@example.com"}}`))
+	})
+
+	user, err := apiGet[struct{ Email string }](context.Background(), client, "user/getDetails?login=jdoe")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if user.Result.Email != "jdoe@example.com" {
+		t.Errorf("got %q", user.Result.Email)
+	}
+}
+
+func TestAPIRequestReturnsServerMessage(t *testing.T) {
+	client := testAPIClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(`{"success": false, "message": "No such user: jdoe"}`))
+	})
+
+	_, err := apiPost[int](context.Background(), client, "user/delete", map[string]interface{}{"login": "jdoe"})
+	if err == nil || err.Error() != "POST user/delete returned HTTP 500: No such user: jdoe" {
+		t.Errorf("got %v", err)
+	}
+	var fault *uyuni.Fault
+	if !errors.As(err, &fault) || fault.Message != "No such user: jdoe" {
+		t.Errorf("expected the fault to be wrapped, got %#v", err)
+	}
+}
+
+func TestAPIRequestErrors(t *testing.T) {
+	tests := map[string]struct {
+		status int
+		body   string
+		want   string
+	}{
+		"fault in successful response": {
+			status: http.StatusOK,
+			body:   `{"success": false, "message": "Invalid channel label"}`,
+			want:   "GET channel/software/getDetails returned HTTP 200: Invalid channel label",
+		},
+		"fault without message": {
+			status: http.StatusBadGateway,
+			body:   `<html>Bad Gateway</html>`,
+			want:   "GET channel/software/getDetails returned HTTP 502: no fault message",
+		},
+		"malformed response": {
+			status: http.StatusOK,
+			body:   `<html>`,
+			want:   "GET channel/software/getDetails returned HTTP 200: ",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := testAPIClient(t, func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(test.status)
+				_, _ = w.Write([]byte(test.body))
+			})
+
+			_, err := apiGet[int](context.Background(), client, "channel/software/getDetails")
+			if err == nil || !strings.HasPrefix(err.Error(), test.want) {
+				t.Errorf("got %v, want %q", err, test.want)
+			}
+		})
+	}
+}
+
+func TestAPIRequestTransportError(t *testing.T) {
+	client := testAPIClient(t, func(w http.ResponseWriter, r *http.Request) {})
+	client.baseURL = "http://127.0.0.1:0"
+
+	_, err := apiGet[int](context.Background(), client, "user/listUsers")
+	var apiErr *apiError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != 0 {
+		t.Fatalf("got %#v", err)
+	}
+	if !strings.HasPrefix(err.Error(), "GET user/listUsers: ") {
+		t.Errorf("got %v", err)
+	}
+}
+
+func TestAPIRequestHonorsContext(t *testing.T) {
+	client := testAPIClient(t, func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := apiGet[int](ctx, client, "channel/software/clone")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected deadline exceeded, got %v", err)
+	}
+}
+
+func TestCreateHonorsTimeoutsBlock(t *testing.T) {
+	ctx := context.Background()
+	r := NewSystemGroupResource()
+	testConfigure(t, r, testAPIClient(t, func(w http.ResponseWriter, r *http.Request) {
+		// The server only notices the client going away once the body is read.
+		_, _ = io.Copy(io.Discard, r.Body)
+		<-r.Context().Done()
+	}))
+
+	planned := testState(t, r, map[string]interface{}{"name": "stores"})
+	if diags := planned.SetAttribute(ctx, path.Root("timeouts").AtName("create"), "10ms"); diags.HasError() {
+		t.Fatal(diags)
+	}
+	resp := &resource.CreateResponse{State: planned}
+	r.Create(ctx, resource.CreateRequest{Plan: tfsdk.Plan{Schema: planned.Schema, Raw: planned.Raw}}, resp)
+	if !resp.Diagnostics.HasError() || !strings.Contains(resp.Diagnostics[0].Detail(), context.DeadlineExceeded.Error()) {
+		t.Errorf("expected the create timeout to cancel the call, got %v", resp.Diagnostics)
+	}
+}
@@ -0,0 +1,130 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"terraform-provider-uyuni/internal/uyuni"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &APINamespacesDataSource{}
+	_ datasource.DataSourceWithConfigure = &APINamespacesDataSource{}
+)
+
+// APINamespacesDataSourceModel maps the data source schema data.
+type APINamespacesDataSourceModel struct {
+	Namespaces types.Set `tfsdk:"namespaces"`
+	Calls      types.Set `tfsdk:"calls"`
+}
+
+// NewAPINamespacesDataSource is a helper function to simplify the provider implementation.
+func NewAPINamespacesDataSource() datasource.DataSource {
+	return &APINamespacesDataSource{}
+}
+
+// APINamespacesDataSource is the data source implementation.
+type APINamespacesDataSource struct {
+	client *uyuniClient
+}
+
+// Metadata returns the data source type name.
+func (d *APINamespacesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_api_namespaces"
+}
+
+// Schema defines the schema for the data source.
+func (d *APINamespacesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the API namespaces and methods the server offers, e.g. to only use features the connected server supports: " +
+			"`contains(data.uyuni_api_namespaces.this.calls, \"system.search.hostname\")`.",
+		Attributes: map[string]schema.Attribute{
+			"namespaces": schema.SetAttribute{
+				Description: "Names of the namespaces, e.g. `system.search`.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"calls": schema.SetAttribute{
+				Description: "Methods qualified by their namespace, e.g. `system.search.hostname`. " +
+					"Methods taking different parameters are only listed once.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *APINamespacesDataSource) Read(ctx context.Context, _ datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state APINamespacesDataSourceModel
+
+	namespaces, err := apiGet[map[string]string](ctx, d.client, "api/getApiNamespaces")
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to Read Uyuni API namespaces", err.Error())
+		return
+	}
+	callList, err := apiGet[map[string]map[string]uyuni.APICall](ctx, d.client, "api/getApiCallList")
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to Read Uyuni API calls", err.Error())
+		return
+	}
+
+	names := make([]string, 0, len(namespaces.Result))
+	for namespace := range namespaces.Result {
+		names = append(names, namespace)
+	}
+	// The call list has an entry for each signature of overloaded methods.
+	calls := []string{}
+	seen := map[string]bool{}
+	for namespace, methods := range callList.Result {
+		for _, method := range methods {
+			call := namespace + "." + method.Name
+			if !seen[call] {
+				seen[call] = true
+				calls = append(calls, call)
+			}
+		}
+	}
+
+	namespaceSet, diags := types.SetValueFrom(ctx, types.StringType, names)
+	state.Namespaces = namespaceSet
+	resp.Diagnostics.Append(diags...)
+	state.Calls, diags = types.SetValueFrom(ctx, types.StringType, calls)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *APINamespacesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*uyuniClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *uyuniClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
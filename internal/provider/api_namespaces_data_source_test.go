@@ -0,0 +1,84 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAPINamespacesDataSource(t *testing.T) {
+	ctx := context.Background()
+	client := testAPIClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/getApiNamespaces":
+			_, _ = w.Write([]byte(`{"success": true, "result": {"system": "SystemHandler", "system.search": "SystemSearchHandler"}}`))
+		case "/api/getApiCallList":
+			_, _ = w.Write([]byte(`{"success": true, "result": {
+				"system.search": {
+					"hostname(string sessionKey, string searchTerm)": {"name": "hostname", "parameters": ["string", "string"]}
+				},
+				"system": {
+					"getDetails(string sessionKey, int sid)": {"name": "getDetails", "parameters": ["string", "int"]},
+					"getDetails(string sessionKey, string name)": {"name": "getDetails", "parameters": ["string", "string"]}
+				}
+			}}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL)
+		}
+	})
+
+	d := NewAPINamespacesDataSource()
+	d.(datasource.DataSourceWithConfigure).Configure(ctx, datasource.ConfigureRequest{ProviderData: client}, &datasource.ConfigureResponse{})
+	var schemaResp datasource.SchemaResponse
+	d.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	values := map[string]tftypes.Value{}
+	for name, attributeType := range objectType.AttributeTypes {
+		values[name] = tftypes.NewValue(attributeType, nil)
+	}
+	config := tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, values)}
+	resp := &datasource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: config.Raw}}
+	d.Read(ctx, datasource.ReadRequest{Config: config}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+
+	var state APINamespacesDataSourceModel
+	resp.State.Get(ctx, &state)
+	var namespaces, calls []string
+	state.Namespaces.ElementsAs(ctx, &namespaces, false)
+	state.Calls.ElementsAs(ctx, &calls, false)
+	sort.Strings(namespaces)
+	sort.Strings(calls)
+	if want := []string{"system", "system.search"}; !reflect.DeepEqual(namespaces, want) {
+		t.Errorf("expected namespaces %v, got %v", want, namespaces)
+	}
+	// The overloaded system.getDetails is listed once.
+	if want := []string{"system.getDetails", "system.search.hostname"}; !reflect.DeepEqual(calls, want) {
+		t.Errorf("expected calls %v, got %v", want, calls)
+	}
+}
+
+func TestAccAPINamespacesDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccRealServerPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `data "uyuni_api_namespaces" "all" {}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckTypeSetElemAttr("data.uyuni_api_namespaces.all", "namespaces.*", "systemgroup"),
+					resource.TestCheckTypeSetElemAttr("data.uyuni_api_namespaces.all", "calls.*", "systemgroup/create"),
+				),
+			},
+		},
+	})
+}
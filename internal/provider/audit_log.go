@@ -0,0 +1,290 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	// auditSendTimeout bounds sending a record to the webhook.
+	auditSendTimeout = 10 * time.Second
+
+	// auditMaxValueLength is the length above which string parameters are
+	// only recorded with their size, e.g. file contents and certificates.
+	auditMaxValueLength = 256
+)
+
+// auditRedacted matches the names of parameters whose values are never
+// recorded, e.g. password, proxyKey, contents and script. The key of
+// activation keys is recorded.
+var auditRedacted = regexp.MustCompile(`(?i:password|passphrase|secret|token|private)|^(script|contents?)$|[a-z](Key|Contents?|Script)$`)
+
+// auditLogModel maps the audit_log attribute of the provider.
+type auditLogModel struct {
+	File           types.String `tfsdk:"file"`
+	WebhookURL     types.String `tfsdk:"webhook_url"`
+	WebhookHeaders types.Map    `tfsdk:"webhook_headers"`
+	RunID          types.String `tfsdk:"run_id"`
+}
+
+// auditLog records the mutating API calls of the provider to a JSON lines
+// file and a webhook, as evidence of the changes made by Terraform.
+type auditLog struct {
+	file       string
+	webhookURL string
+	headers    map[string]string
+	runID      string
+	httpClient *http.Client
+
+	// mu keeps records in order and lines whole.
+	mu sync.Mutex
+}
+
+// auditRecord is a line of the audit log.
+type auditRecord struct {
+	Time       string                 `json:"time"`
+	RunID      string                 `json:"run_id"`
+	Server     string                 `json:"server"`
+	Requester  string                 `json:"requester"`
+	Method     string                 `json:"method"`
+	Endpoint   string                 `json:"endpoint"`
+	Parameters map[string]interface{} `json:"parameters,omitempty"`
+	Status     int                    `json:"status,omitempty"`
+	Error      string                 `json:"error,omitempty"`
+}
+
+// auditLogAttribute is the schema of the audit_log attribute of the
+// provider.
+func auditLogAttribute() schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		Description: "Records every API call of the provider other than reads, e.g. as change management evidence. Each " +
+			"record holds the time, the run ID, the server, the user making the call, the endpoint, the parameters and " +
+			"the outcome. Passwords, keys, contents and scripts are left out and long values are only recorded with " +
+			"their size. Failing to record a call is logged as a warning and does not fail the call.",
+		Optional: true,
+		Attributes: map[string]schema.Attribute{
+			"file": schema.StringAttribute{
+				Description: "Path of a file the records are appended to, one JSON object per line.",
+				Optional:    true,
+			},
+			"webhook_url": schema.StringAttribute{
+				Description: "URL each record is posted to as a JSON object.",
+				Optional:    true,
+			},
+			"webhook_headers": schema.MapAttribute{
+				Description: "HTTP headers sent to the webhook, e.g. for authentication.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Sensitive:   true,
+			},
+			"run_id": schema.StringAttribute{
+				Description: "ID of the Terraform run recorded with each call. Defaults to the TFC_RUN_ID environment " +
+					"variable set by HCP Terraform and Terraform Enterprise, and to a random ID per provider instance " +
+					"otherwise.",
+				Optional: true,
+			},
+		},
+	}
+}
+
+// newAuditLog returns the audit log of the audit_log attribute, nil if it is
+// not set.
+func newAuditLog(ctx context.Context, value types.Object) (*auditLog, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if value.IsNull() {
+		return nil, diags
+	}
+	var model auditLogModel
+	diags.Append(value.As(ctx, &model, basetypes.ObjectAsOptions{})...)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	a := &auditLog{
+		file:       model.File.ValueString(),
+		webhookURL: model.WebhookURL.ValueString(),
+		headers:    map[string]string{},
+		runID:      model.RunID.ValueString(),
+		httpClient: &http.Client{Timeout: auditSendTimeout},
+	}
+	if a.file == "" && a.webhookURL == "" {
+		diags.AddAttributeError(
+			path.Root("audit_log"),
+			"Invalid Audit Log",
+			"The audit log needs a file, a webhook_url or both.",
+		)
+		return nil, diags
+	}
+	if a.file != "" {
+		// Fail early rather than on the first change.
+		f, err := os.OpenFile(a.file, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+		if err != nil {
+			diags.AddAttributeError(path.Root("audit_log").AtName("file"), "Invalid Audit Log", "The provider cannot write the audit log: "+err.Error())
+			return nil, diags
+		}
+		f.Close()
+	}
+	if a.webhookURL != "" {
+		u, err := url.Parse(a.webhookURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			diags.AddAttributeError(
+				path.Root("audit_log").AtName("webhook_url"),
+				"Invalid Audit Log",
+				fmt.Sprintf("%q is not an http or https URL.", a.webhookURL),
+			)
+			return nil, diags
+		}
+	}
+	if !model.WebhookHeaders.IsNull() {
+		diags.Append(model.WebhookHeaders.ElementsAs(ctx, &a.headers, false)...)
+	}
+	if a.runID == "" {
+		a.runID = os.Getenv("TFC_RUN_ID")
+	}
+	if a.runID == "" {
+		a.runID = randomHex(8)
+	}
+	return a, diags
+}
+
+// auditParameters returns the parameters of a call from the query of its
+// path and its JSON body, without secrets and long values.
+func auditParameters(path string, body []byte) map[string]interface{} {
+	params := map[string]interface{}{}
+	if _, query, ok := strings.Cut(path, "?"); ok {
+		values, _ := url.ParseQuery(query)
+		for name := range values {
+			params[name] = values.Get(name)
+		}
+	}
+	var decoded map[string]interface{}
+	if len(body) > 0 && json.Unmarshal(body, &decoded) == nil {
+		for name, value := range decoded {
+			params[name] = value
+		}
+	}
+	for name, value := range params {
+		params[name] = auditValue(name, value)
+	}
+	if len(params) == 0 {
+		return nil
+	}
+	return params
+}
+
+// auditValue returns the value of the named parameter as recorded.
+func auditValue(name string, value interface{}) interface{} {
+	if auditRedacted.MatchString(name) {
+		return "(redacted)"
+	}
+	switch v := value.(type) {
+	case string:
+		if len(v) > auditMaxValueLength {
+			return fmt.Sprintf("(%d bytes)", len(v))
+		}
+	case map[string]interface{}:
+		for key, nested := range v {
+			v[key] = auditValue(key, nested)
+		}
+	case []interface{}:
+		for i, nested := range v {
+			v[i] = auditValue(name, nested)
+		}
+	}
+	return value
+}
+
+// record records a call of the client to the server at baseURL. Reads are
+// not recorded. It is a no-op on a nil audit log, so clients without one
+// need no checks.
+func (a *auditLog) record(ctx context.Context, baseURL, requester, method, path string, body []byte, status int, callErr error) {
+	if a == nil || method == http.MethodGet {
+		return
+	}
+	server := baseURL
+	if u, err := url.Parse(baseURL); err == nil {
+		server = u.Hostname()
+	}
+	endpoint, _, _ := strings.Cut(path, "?")
+	record := auditRecord{
+		Time:       time.Now().UTC().Format(time.RFC3339Nano),
+		RunID:      a.runID,
+		Server:     server,
+		Requester:  requester,
+		Method:     method,
+		Endpoint:   endpoint,
+		Parameters: auditParameters(path, body),
+		Status:     status,
+	}
+	if callErr != nil {
+		record.Error = callErr.Error()
+	}
+	data, err := json.Marshal(record)
+	if err != nil {
+		tflog.Warn(ctx, "Unable to record the API call in the audit log", map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.file != "" {
+		if err := a.append(data); err != nil {
+			tflog.Warn(ctx, "Unable to write the audit log", map[string]interface{}{"error": err.Error(), "endpoint": endpoint})
+		}
+	}
+	if a.webhookURL != "" {
+		if err := a.send(data); err != nil {
+			tflog.Warn(ctx, "Unable to send the audit log to the webhook", map[string]interface{}{"error": err.Error(), "endpoint": endpoint})
+		}
+	}
+}
+
+// append appends a record to the file.
+func (a *auditLog) append(data []byte) error {
+	f, err := os.OpenFile(a.file, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// send posts a record to the webhook.
+func (a *auditLog) send(data []byte) error {
+	req, err := http.NewRequest(http.MethodPost, a.webhookURL, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for name, value := range a.headers {
+		req.Header.Set(name, value)
+	}
+	res, err := a.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("webhook returned HTTP %d", res.StatusCode)
+	}
+	return nil
+}
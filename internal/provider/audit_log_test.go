@@ -0,0 +1,109 @@
+package provider
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestAuditLogRecordsMutatingCalls(t *testing.T) {
+	ctx := context.Background()
+	var webhook []auditRecord
+	hook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			t.Errorf("unexpected webhook headers %v", r.Header)
+		}
+		var record auditRecord
+		if err := json.NewDecoder(r.Body).Decode(&record); err != nil {
+			t.Error(err)
+		}
+		webhook = append(webhook, record)
+	}))
+	defer hook.Close()
+
+	file := filepath.Join(t.TempDir(), "audit.jsonl")
+	value := types.ObjectValueMust(
+		map[string]attr.Type{
+			"file":            types.StringType,
+			"webhook_url":     types.StringType,
+			"webhook_headers": types.MapType{ElemType: types.StringType},
+			"run_id":          types.StringType,
+		},
+		map[string]attr.Value{
+			"file":        types.StringValue(file),
+			"webhook_url": types.StringValue(hook.URL),
+			"webhook_headers": types.MapValueMust(types.StringType, map[string]attr.Value{
+				"Authorization": types.StringValue("Bearer token"),
+			}),
+			"run_id": types.StringValue("run-42"),
+		},
+	)
+	audit, diags := newAuditLog(ctx, value)
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+
+	client := testAPIClient(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"success": true, "result": 1}`))
+	})
+	client.username = "admin"
+	client.audit = audit
+	if _, err := apiGet[int](ctx, client, "user/getDetails?login=jdoe"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := apiPost[int](ctx, client, "user/create", map[string]interface{}{
+		"login":       "jdoe",
+		"password":    "hunter2",
+		"description": "Operator",
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := apiPost[int](ctx, client, "configchannel/createOrUpdatePath", map[string]interface{}{
+		"channelLabel": "web",
+		"data":         map[string]interface{}{"contents": "secret config", "owner": "root"},
+		"ca":           strings.Repeat("x", 1000),
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := os.Open(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	var records []auditRecord
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var record auditRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			t.Fatal(err)
+		}
+		records = append(records, record)
+	}
+	if len(records) != 2 || len(webhook) != 2 {
+		t.Fatalf("expected the two writes in the file and the webhook, got %v and %v", records, webhook)
+	}
+	create, update := records[0], records[1]
+	if create.Endpoint != "user/create" || create.RunID != "run-42" || create.Requester != "admin" || create.Server != "127.0.0.1" || create.Status != http.StatusOK {
+		t.Errorf("unexpected record %v", create)
+	}
+	if create.Parameters["password"] != "(redacted)" || create.Parameters["login"] != "jdoe" || create.Parameters["description"] != "Operator" {
+		t.Errorf("expected the password to be left out, got %v", create.Parameters)
+	}
+	data := update.Parameters["data"].(map[string]interface{})
+	if data["contents"] != "(redacted)" || data["owner"] != "root" || update.Parameters["ca"] != "(1000 bytes)" {
+		t.Errorf("expected contents and long values to be left out, got %v", update.Parameters)
+	}
+	if webhook[1].Endpoint != "configchannel/createOrUpdatePath" {
+		t.Errorf("unexpected webhook records %v", webhook)
+	}
+}
@@ -0,0 +1,447 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+
+	"terraform-provider-uyuni/internal/uyuni"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &autoErrataUpdateResource{}
+	_ resource.ResourceWithConfigure   = &autoErrataUpdateResource{}
+	_ resource.ResourceWithImportState = &autoErrataUpdateResource{}
+)
+
+// NewAutoErrataUpdateResource is a helper function to simplify the provider implementation.
+func NewAutoErrataUpdateResource() resource.Resource {
+	return &autoErrataUpdateResource{}
+}
+
+// autoErrataUpdateResource is the resource implementation.
+type autoErrataUpdateResource struct {
+	client *uyuniClient
+}
+
+// autoErrataUpdateResourceModel maps the resource schema data.
+type autoErrataUpdateResourceModel struct {
+	ID                         types.String   `tfsdk:"id"`
+	SystemID                   types.Int64    `tfsdk:"system_id"`
+	GroupName                  types.String   `tfsdk:"group_name"`
+	Enabled                    types.Bool     `tfsdk:"enabled"`
+	SystemIDs                  types.Set      `tfsdk:"system_ids"`
+	OnDestroy                  types.String   `tfsdk:"on_destroy"`
+	DestroyInMaintenanceWindow types.Bool     `tfsdk:"destroy_in_maintenance_window"`
+	ServerAlias                types.String   `tfsdk:"server_alias"`
+	Org                        *orgModel      `tfsdk:"org"`
+	Timeouts                   timeouts.Value `tfsdk:"timeouts"`
+}
+
+// Metadata returns the resource type name.
+func (r *autoErrataUpdateResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_auto_errata_update"
+}
+
+// Schema defines the schema for the resource.
+func (r *autoErrataUpdateResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Sets whether a system, or every member of a system group, applies errata automatically. " +
+			"The server schedules relevant errata as soon as they are published, and right away when enabled. " +
+			"Group members which joined or changed the setting since the last apply show as a change.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "\"system:\" followed by the system ID, or \"group:\" followed by the group name.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"system_id": schema.Int64Attribute{
+				Description: "ID of the system. Exactly one of system_id and group_name must be set.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+				Validators: []validator.Int64{
+					int64validator.ExactlyOneOf(path.MatchRoot("group_name")),
+				},
+			},
+			"group_name": schema.StringAttribute{
+				Description: "Name of the system group whose members are set.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"enabled": schema.BoolAttribute{
+				Description: "Whether errata are applied automatically. Defaults to true.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+			},
+			"system_ids": schema.SetAttribute{
+				Description: "IDs of the systems set, the members of the group at the last apply or refresh.",
+				ElementType: types.Int64Type,
+				Computed:    true,
+			},
+			"on_destroy":                    onDestroyAttribute("disables automatic errata updates of the systems"),
+			"destroy_in_maintenance_window": destroyInMaintenanceWindowAttribute(),
+			"server_alias":                  serverAliasAttribute(),
+		},
+		Blocks: map[string]schema.Block{
+			"org": orgBlock(),
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+// systems returns the IDs of the system or of the members of the group.
+func (m *autoErrataUpdateResourceModel) systems(ctx context.Context, client *uyuniClient) ([]string, error) {
+	if !m.SystemID.IsNull() {
+		return []string{strconv.FormatInt(m.SystemID.ValueInt64(), 10)}, nil
+	}
+	systems, err := apiGet[[]uyuni.ShortSystem](ctx, client, "systemgroup/listSystemsMinimal?systemGroupName="+url.QueryEscape(m.GroupName.ValueString()))
+	if err != nil {
+		return nil, fmt.Errorf("could not list systems of group %s: %w", m.GroupName.ValueString(), err)
+	}
+	sids := make([]string, 0, len(systems.Result))
+	for _, system := range systems.Result {
+		sids = append(sids, strconv.Itoa(system.ID))
+	}
+	return sids, nil
+}
+
+// setSystemIDs sets system_ids from the IDs of the systems.
+func (m *autoErrataUpdateResourceModel) setSystemIDs(ctx context.Context, sids []string) error {
+	ids := make([]int64, 0, len(sids))
+	for _, sid := range sids {
+		id, err := strconv.ParseInt(sid, 10, 64)
+		if err != nil {
+			return err
+		}
+		ids = append(ids, id)
+	}
+	value, diags := types.SetValueFrom(ctx, types.Int64Type, ids)
+	if diags.HasError() {
+		return fmt.Errorf("could not convert system IDs: %v", diags)
+	}
+	m.SystemIDs = value
+	return nil
+}
+
+// setAutoErrataUpdate sets auto_errata_update of the systems.
+func setAutoErrataUpdate(ctx context.Context, client *uyuniClient, sids []string, enabled bool) error {
+	tflog.Info(ctx, fmt.Sprintf("Setting auto_errata_update of %d systems to %t", len(sids), enabled))
+	errs := runBatch(sids, func(sid string) error {
+		id, err := strconv.ParseInt(sid, 10, 64)
+		if err != nil {
+			return err
+		}
+		_, err = apiPost[int](ctx, client, "system/setDetails", map[string]interface{}{
+			"sid":     id,
+			"details": map[string]interface{}{"auto_errata_update": enabled},
+		})
+		return err
+	})
+	for _, sid := range sids {
+		if err := errs[sid]; err != nil {
+			return fmt.Errorf("could not update system %s: %w", sid, err)
+		}
+	}
+	return nil
+}
+
+// autoErrataUpdates returns auto_update of the systems by ID.
+func autoErrataUpdates(ctx context.Context, client *uyuniClient, sids []string) (map[string]bool, error) {
+	var mu sync.Mutex
+	updates := map[string]bool{}
+	errs := runBatch(sids, func(sid string) error {
+		details, err := apiGet[uyuni.SystemDetails](ctx, client, "system/getDetails?sid="+sid)
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		updates[sid] = details.Result.AutoUpdate
+		mu.Unlock()
+		return nil
+	})
+	for _, sid := range sids {
+		if err := errs[sid]; err != nil {
+			return nil, fmt.Errorf("could not read system %s: %w", sid, err)
+		}
+	}
+	return updates, nil
+}
+
+// apply sets auto_errata_update of the systems of the plan.
+func (r *autoErrataUpdateResource) apply(ctx context.Context, client *uyuniClient, plan *autoErrataUpdateResourceModel) error {
+	sids, err := plan.systems(ctx, client)
+	if err != nil {
+		return err
+	}
+	if err := setAutoErrataUpdate(ctx, client, sids, plan.Enabled.ValueBool()); err != nil {
+		return err
+	}
+
+	if !plan.SystemID.IsNull() {
+		plan.ID = types.StringValue(fmt.Sprintf("system%s%d", importIDSeparator, plan.SystemID.ValueInt64()))
+	} else {
+		plan.ID = types.StringValue("group" + importIDSeparator + plan.GroupName.ValueString())
+	}
+	return plan.setSystemIDs(ctx, sids)
+}
+
+// Create a new resource.
+func (r *autoErrataUpdateResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan autoErrataUpdateResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	client := resourceClient(ctx, r.client, plan.ServerAlias, plan.Org, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	if err := r.apply(ctx, client, &plan); err != nil {
+		resp.Diagnostics.AddError(
+			"Error setting automatic errata updates",
+			"Could not set automatic errata updates: "+err.Error(),
+		)
+		return
+	}
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read resource information. enabled only keeps its value while every
+// system has it, so that diverging systems show as a change.
+func (r *autoErrataUpdateResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Get current state
+	var state autoErrataUpdateResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := resourceClient(ctx, r.client, state.ServerAlias, state.Org, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	sids, err := state.systems(ctx, client)
+	var updates map[string]bool
+	if err == nil {
+		updates, err = autoErrataUpdates(ctx, client, sids)
+	}
+	if err != nil {
+		if handleNotFound(ctx, resp, err, "Target "+state.ID.ValueString()) {
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Reading Uyuni automatic errata updates",
+			"Could not read automatic errata updates: "+err.Error(),
+		)
+		return
+	}
+
+	if state.Enabled.IsNull() {
+		// Imported targets are enabled when all of their systems are.
+		enabled := len(sids) > 0
+		for _, update := range updates {
+			enabled = enabled && update
+		}
+		state.Enabled = types.BoolValue(enabled)
+	} else {
+		for _, update := range updates {
+			if update != state.Enabled.ValueBool() {
+				state.Enabled = types.BoolValue(update)
+				break
+			}
+		}
+	}
+	if err := state.setSystemIDs(ctx, sids); err != nil {
+		resp.Diagnostics.AddError("Error Reading Uyuni automatic errata updates", err.Error())
+		return
+	}
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *autoErrataUpdateResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Retrieve values from plan
+	var plan autoErrataUpdateResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	client := resourceClient(ctx, r.client, plan.ServerAlias, plan.Org, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	if err := r.apply(ctx, client, &plan); err != nil {
+		resp.Diagnostics.AddError(
+			"Error setting automatic errata updates",
+			"Could not set automatic errata updates: "+err.Error(),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete disables automatic errata updates of the current systems.
+func (r *autoErrataUpdateResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Retrieve values from state
+	var state autoErrataUpdateResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if orphaned(state.OnDestroy) || !state.Enabled.ValueBool() {
+		tflog.Info(ctx, "Removing automatic errata updates of "+state.ID.ValueString()+" from state, the systems keep their setting")
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	client := resourceClient(ctx, r.client, state.ServerAlias, state.Org, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	ids, err := int64Set(ctx, state.SystemIDs)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Deleting Uyuni automatic errata updates", err.Error())
+		return
+	}
+	if outsideMaintenanceWindow(ctx, client, state.DestroyInMaintenanceWindow, ids, "Automatic errata updates of "+state.ID.ValueString(), &resp.Diagnostics) {
+		return
+	}
+
+	sids, err := state.systems(ctx, client)
+	if err == nil {
+		err = setAutoErrataUpdate(ctx, client, sids, false)
+	}
+	if err != nil && !isNotFoundError(err) {
+		resp.Diagnostics.AddError(
+			"Error Deleting Uyuni automatic errata updates",
+			"Could not disable automatic errata updates: "+err.Error(),
+		)
+		return
+	}
+}
+
+// ImportState imports the setting of a system by "system:<system_id>" or of a
+// group by "group:<group_name>".
+func (r *autoErrataUpdateResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	kind, value, _ := strings.Cut(req.ID, importIDSeparator)
+	switch {
+	case kind == "system" && value != "":
+		sid, err := parseImportInt64("system_id", value)
+		if err != nil {
+			resp.Diagnostics.AddError("Invalid Import ID", err.Error())
+			return
+		}
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("system_id"), sid)...)
+	case kind == "group" && value != "":
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("group_name"), value)...)
+	default:
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("expected import ID in the format \"system:<system_id>\" or \"group:<group_name>\", got: %q", req.ID),
+		)
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("on_destroy"), onDestroyDelete)...)
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *autoErrataUpdateResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*uyuniClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *uyuniClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
@@ -0,0 +1,136 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	acctest "github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+// testAutoErrataUpdateServer serves the group web with the systems 1 and 2,
+// of which only 1 applies errata automatically.
+func testAutoErrataUpdateServer(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case "/systemgroup/listSystemsMinimal":
+		_, _ = w.Write([]byte(`{"success": true, "result": [
+			{"id": 1, "name": "web01", "last_checkin": "2025-01-14T10:02:11Z", "created": "2024-11-02T08:45:37Z"},
+			{"id": 2, "name": "web02", "last_checkin": "2025-01-14T10:02:11Z", "created": "2024-11-02T08:45:37Z"}
+		]}`))
+	case "/system/getDetails":
+		autoUpdate := r.URL.Query().Get("sid") == "1"
+		_, _ = fmt.Fprintf(w, `{"success": true, "result": {"id": %s, "auto_update": %t}}`, r.URL.Query().Get("sid"), autoUpdate)
+	default:
+		_, _ = w.Write([]byte(`{"success": true, "result": 1}`))
+	}
+}
+
+func TestAutoErrataUpdateReadDetectsDivergingSystems(t *testing.T) {
+	ctx := context.Background()
+	for name, tt := range map[string]struct {
+		attrs map[string]interface{}
+		want  bool
+	}{
+		"group":          {map[string]interface{}{"id": "group:web", "group_name": "web", "enabled": true}, false},
+		"system":         {map[string]interface{}{"id": "system:1", "system_id": int64(1), "enabled": true}, true},
+		"imported group": {map[string]interface{}{"id": "group:web", "group_name": "web"}, false},
+	} {
+		t.Run(name, func(t *testing.T) {
+			resp := testRead(t, NewAutoErrataUpdateResource(), testAPIClient(t, testAutoErrataUpdateServer), tt.attrs)
+			if resp.Diagnostics.HasError() {
+				t.Fatal(resp.Diagnostics)
+			}
+			var state autoErrataUpdateResourceModel
+			resp.State.Get(ctx, &state)
+			if state.Enabled.ValueBool() != tt.want {
+				t.Errorf("expected enabled %t, got %s", tt.want, state.Enabled)
+			}
+		})
+	}
+}
+
+func TestAutoErrataUpdateCreateSetsGroupMembers(t *testing.T) {
+	ctx := context.Background()
+	var mu sync.Mutex
+	var updated []string
+	client := testAPIClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/system/setDetails" {
+			var body map[string]interface{}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			mu.Lock()
+			updated = append(updated, fmt.Sprint(body["sid"], body["details"]))
+			mu.Unlock()
+		}
+		testAutoErrataUpdateServer(w, r)
+	})
+	r := NewAutoErrataUpdateResource()
+	testConfigure(t, r, client)
+
+	planned := testState(t, r, map[string]interface{}{
+		"group_name": "web",
+		"enabled":    true,
+		"on_destroy": onDestroyDelete,
+	})
+	resp := &resource.CreateResponse{State: planned}
+	r.Create(ctx, resource.CreateRequest{Plan: tfsdk.Plan{Schema: planned.Schema, Raw: planned.Raw}}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+	sort.Strings(updated)
+	if want := "[1 map[auto_errata_update:true] 2 map[auto_errata_update:true]]"; fmt.Sprint(updated) != want {
+		t.Errorf("expected %s, got %v", want, updated)
+	}
+
+	var state autoErrataUpdateResourceModel
+	resp.State.Get(ctx, &state)
+	if state.ID.ValueString() != "group:web" || len(state.SystemIDs.Elements()) != 2 {
+		t.Errorf("unexpected state %v", state)
+	}
+}
+
+func TestAccAutoErrataUpdateResource(t *testing.T) {
+	acctest.Test(t, acctest.TestCase{
+		PreCheck: func() {
+			testAccRealServerPreCheck(t)
+			testAccSeedSystemGroup(t, "tfacc-auto-errata")
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []acctest.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccAutoErrataUpdateResourceConfig(true),
+				Check: acctest.ComposeAggregateTestCheckFunc(
+					acctest.TestCheckResourceAttr("uyuni_auto_errata_update.test", "id", "group:tfacc-auto-errata"),
+					acctest.TestCheckResourceAttr("uyuni_auto_errata_update.test", "system_ids.#", "0"),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: testAccAutoErrataUpdateResourceConfig(false),
+				Check:  acctest.TestCheckResourceAttr("uyuni_auto_errata_update.test", "enabled", "false"),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "uyuni_auto_errata_update.test",
+				ImportState:       true,
+				ImportStateId:     "group:tfacc-auto-errata",
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccAutoErrataUpdateResourceConfig(enabled bool) string {
+	return fmt.Sprintf(`
+resource "uyuni_auto_errata_update" "test" {
+  group_name = "tfacc-auto-errata"
+  enabled    = %t
+}
+`, enabled)
+}
@@ -0,0 +1,384 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &autoinstallProfileResource{}
+	_ resource.ResourceWithConfigure   = &autoinstallProfileResource{}
+	_ resource.ResourceWithImportState = &autoinstallProfileResource{}
+)
+
+// NewAutoinstallProfileResource is a helper function to simplify the provider implementation.
+func NewAutoinstallProfileResource() resource.Resource {
+	return &autoinstallProfileResource{}
+}
+
+// autoinstallProfileResource is the resource implementation.
+type autoinstallProfileResource struct {
+	client *uyuniClient
+}
+
+// autoinstallProfileResourceModel maps the resource schema data.
+type autoinstallProfileResourceModel struct {
+	ID                 types.String `tfsdk:"id"`
+	Label              types.String `tfsdk:"label"`
+	TreeLabel          types.String `tfsdk:"tree_label"`
+	VirtualizationType types.String `tfsdk:"virtualization_type"`
+	Content            types.String `tfsdk:"content"`
+	ContentSHA256      types.String `tfsdk:"content_sha256"`
+	Variables          types.Map    `tfsdk:"variables"`
+	ServerAlias        types.String `tfsdk:"server_alias"`
+	Org                *orgModel    `tfsdk:"org"`
+}
+
+// Metadata returns the resource type name.
+func (r *autoinstallProfileResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_autoinstall_profile"
+}
+
+// Schema defines the schema for the resource.
+func (r *autoinstallProfileResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages an autoinstall profile uploaded as raw file, i.e. a kickstart, AutoYaST or cloud-init file " +
+			"templated outside Uyuni, whose content the server keeps verbatim. " +
+			"The API offers no cloud-init or Ignition profile types, so such files are uploaded as raw profiles, too. " +
+			"The API cannot change the content of an existing raw profile, so changing any attribute but variables imports the profile again. " +
+			"Changes made on the server are detected by the checksum of the content and reverted on the next apply.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Label of the profile.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"label": schema.StringAttribute{
+				Description: "Label of the profile.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"tree_label": schema.StringAttribute{
+				Description: "Label of the autoinstallable distribution the profile installs.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"virtualization_type": schema.StringAttribute{
+				Description: "Virtualization type of the profile: `none`, `qemu`, `para_host`, `xenpv` or `xenfv`. Defaults to `none`.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString("none"),
+				Validators: []validator.String{
+					stringvalidator.OneOf("none", "qemu", "para_host", "xenpv", "xenfv"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"content": schema.StringAttribute{
+				Description: "Full content of the file, e.g. rendered with `templatefile()`.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"content_sha256": schema.StringAttribute{
+				Description: "SHA256 checksum of the content as stored on the server.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"variables": schema.MapAttribute{
+				Description: "Variables the server substitutes when rendering the profile for a system, e.g. per-profile " +
+					"cloud-init meta-data or user-data referenced as `$user_data` in the content. Unset leaves the variables alone.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"server_alias": serverAliasAttribute(),
+		},
+		Blocks: map[string]schema.Block{
+			"org": orgBlock(),
+		},
+	}
+}
+
+// contentSHA256 returns the hex encoded SHA256 checksum of the content.
+func contentSHA256(content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(sum[:])
+}
+
+// downloadAutoinstallProfile returns the content of the profile as uploaded.
+// Unlike profile.downloadRenderedKickstart, it does not expand Cobbler
+// snippets, which would otherwise show up as changes.
+func downloadAutoinstallProfile(ctx context.Context, client *uyuniClient, label string) (string, error) {
+	content, err := apiGet[string](ctx, client, "kickstart/profile/downloadKickstart?ksLabel="+url.QueryEscape(label))
+	if err != nil {
+		return "", err
+	}
+	return content.Result, nil
+}
+
+// setProfileVariables sets the variables of the profile, unless they are left
+// alone.
+func (m *autoinstallProfileResourceModel) setProfileVariables(ctx context.Context, client *uyuniClient) error {
+	if m.Variables.IsNull() {
+		return nil
+	}
+	variables := map[string]string{}
+	if diags := m.Variables.ElementsAs(ctx, &variables, false); diags.HasError() {
+		return fmt.Errorf("could not read variables: %v", diags)
+	}
+	_, err := apiPost[int](ctx, client, "kickstart/profile/setVariables", map[string]interface{}{
+		"ksLabel":   m.Label.ValueString(),
+		"variables": variables,
+	})
+	return err
+}
+
+// Create imports the profile.
+func (r *autoinstallProfileResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan autoinstallProfileResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := resourceClient(ctx, r.client, plan.ServerAlias, plan.Org, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	label := plan.Label.ValueString()
+	_, err := apiPost[int](ctx, client, "kickstart/importRawFile", map[string]interface{}{
+		"profileLabel":           label,
+		"virtualizationType":     plan.VirtualizationType.ValueString(),
+		"kickstartableTreeLabel": plan.TreeLabel.ValueString(),
+		"kickstartFileContents":  plan.Content.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating autoinstall profile",
+			"Could not import autoinstall profile "+label+": "+err.Error(),
+		)
+		return
+	}
+	plan.ID = types.StringValue(label)
+
+	// The server may normalize the content, e.g. line endings, so the
+	// checksum is taken from what it stored.
+	content, err := downloadAutoinstallProfile(ctx, client, label)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating autoinstall profile",
+			"Could not download autoinstall profile "+label+": "+err.Error(),
+		)
+		// Fall through to track the profile, which Terraform taints.
+		content = plan.Content.ValueString()
+	}
+	plan.ContentSHA256 = types.StringValue(contentSHA256(content))
+
+	if err := plan.setProfileVariables(ctx, client); err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating autoinstall profile",
+			"Could not set the variables of autoinstall profile "+label+": "+err.Error(),
+		)
+		// Fall through to track the profile, which Terraform taints.
+	}
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read resource information.
+func (r *autoinstallProfileResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Get current state
+	var state autoinstallProfileResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := resourceClient(ctx, r.client, state.ServerAlias, state.Org, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	label := state.ID.ValueString()
+	content, err := downloadAutoinstallProfile(ctx, client, label)
+	if err != nil {
+		if handleNotFound(ctx, resp, err, "Autoinstall profile "+label) {
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Reading Uyuni autoinstall profile",
+			"Could not download autoinstall profile "+label+": "+err.Error(),
+		)
+		return
+	}
+	tree, err := apiGet[string](ctx, client, "kickstart/profile/getKickstartTree?kickstartLabel="+url.QueryEscape(label))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Uyuni autoinstall profile",
+			"Could not read the distribution of autoinstall profile "+label+": "+err.Error(),
+		)
+		return
+	}
+	state.TreeLabel = types.StringValue(tree.Result)
+	virtualization, err := apiGet[string](ctx, client, "kickstart/profile/getVirtualizationType?kickstartLabel="+url.QueryEscape(label))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Uyuni autoinstall profile",
+			"Could not read the virtualization type of autoinstall profile "+label+": "+err.Error(),
+		)
+		return
+	}
+	state.VirtualizationType = types.StringValue(virtualization.Result)
+
+	// Variables are only refreshed where they are managed.
+	if !state.Variables.IsNull() {
+		variables, err := apiGet[map[string]interface{}](ctx, client, "kickstart/profile/getVariables?ksLabel="+url.QueryEscape(label))
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Reading Uyuni autoinstall profile",
+				"Could not read the variables of autoinstall profile "+label+": "+err.Error(),
+			)
+			return
+		}
+		values := make(map[string]string, len(variables.Result))
+		for name, value := range variables.Result {
+			values[name] = fmt.Sprint(value)
+		}
+		state.Variables, diags = types.MapValueFrom(ctx, types.StringType, values)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// Keep the configured content while the stored one is unchanged, so
+	// that normalization by the server does not show up as a change.
+	if checksum := contentSHA256(content); checksum != state.ContentSHA256.ValueString() {
+		state.Content = types.StringValue(content)
+		state.ContentSHA256 = types.StringValue(checksum)
+	}
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update sets the variables, all other changes import the profile again.
+func (r *autoinstallProfileResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Retrieve values from plan
+	var plan autoinstallProfileResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := resourceClient(ctx, r.client, plan.ServerAlias, plan.Org, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	if err := plan.setProfileVariables(ctx, client); err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating autoinstall profile",
+			"Could not set the variables of autoinstall profile "+plan.Label.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *autoinstallProfileResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Retrieve values from state
+	var state autoinstallProfileResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := resourceClient(ctx, r.client, state.ServerAlias, state.Org, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	_, err := apiPost[int](ctx, client, "kickstart/deleteProfile", map[string]interface{}{
+		"ksLabel": state.ID.ValueString(),
+	})
+	if err != nil && !isNotFoundError(err) {
+		resp.Diagnostics.AddError(
+			"Error Deleting Uyuni autoinstall profile",
+			"Could not delete autoinstall profile "+state.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+}
+
+// ImportState imports a profile by its label.
+func (r *autoinstallProfileResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("label"), req.ID)...)
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *autoinstallProfileResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*uyuniClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *uyuniClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
@@ -0,0 +1,177 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	acctest "github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAutoinstallProfileDetectsChangedContent(t *testing.T) {
+	ctx := context.Background()
+	// The server stores the content with Windows line endings.
+	stored := "install\r\nreboot\r\n"
+	r := NewAutoinstallProfileResource()
+	client := testAPIClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/kickstart/importRawFile":
+			_, _ = w.Write([]byte(`{"success": true, "result": 1}`))
+		case "/kickstart/profile/downloadKickstart":
+			content, _ := json.Marshal(stored)
+			_, _ = w.Write([]byte(`{"success": true, "result": ` + string(content) + `}`))
+		case "/kickstart/profile/getKickstartTree":
+			_, _ = w.Write([]byte(`{"success": true, "result": "sles15-sp6"}`))
+		case "/kickstart/profile/getVirtualizationType":
+			_, _ = w.Write([]byte(`{"success": true, "result": "none"}`))
+		default:
+			_, _ = w.Write([]byte(`{"success": false, "message": "unexpected request ` + r.URL.String() + `"}`))
+		}
+	})
+	testConfigure(t, r, client)
+
+	planned := testState(t, r, map[string]interface{}{
+		"label":               "web",
+		"tree_label":          "sles15-sp6",
+		"virtualization_type": "none",
+		"content":             "install\nreboot\n",
+	})
+	createResp := &resource.CreateResponse{State: planned}
+	r.Create(ctx, resource.CreateRequest{Plan: tfsdk.Plan{Schema: planned.Schema, Raw: planned.Raw}}, createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatal(createResp.Diagnostics)
+	}
+
+	// The normalized content is no change.
+	readResp := &resource.ReadResponse{State: createResp.State}
+	r.Read(ctx, resource.ReadRequest{State: createResp.State}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatal(readResp.Diagnostics)
+	}
+	var state autoinstallProfileResourceModel
+	readResp.State.Get(ctx, &state)
+	if state.Content.ValueString() != "install\nreboot\n" {
+		t.Errorf("expected the configured content, got %q", state.Content.ValueString())
+	}
+
+	// Content changed on the server is read back.
+	stored = "install\r\npoweroff\r\n"
+	r.Read(ctx, resource.ReadRequest{State: readResp.State}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatal(readResp.Diagnostics)
+	}
+	readResp.State.Get(ctx, &state)
+	if state.Content.ValueString() != stored || state.ContentSHA256.ValueString() != contentSHA256(stored) {
+		t.Errorf("expected the changed content, got %q with checksum %s", state.Content.ValueString(), state.ContentSHA256)
+	}
+}
+
+func TestAutoinstallProfileUpdateSetsVariables(t *testing.T) {
+	ctx := context.Background()
+	var set map[string]interface{}
+	r := NewAutoinstallProfileResource()
+	client := testAPIClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/kickstart/profile/setVariables":
+			_ = json.NewDecoder(r.Body).Decode(&set)
+			_, _ = w.Write([]byte(`{"success": true, "result": 1}`))
+		case "/kickstart/profile/getVariables":
+			_, _ = w.Write([]byte(`{"success": true, "result": {"user_data": "#cloud-config\n", "ssh_port": 2222}}`))
+		case "/kickstart/profile/downloadKickstart":
+			_, _ = w.Write([]byte(`{"success": true, "result": "install\n"}`))
+		case "/kickstart/profile/getKickstartTree":
+			_, _ = w.Write([]byte(`{"success": true, "result": "sles15-sp6"}`))
+		case "/kickstart/profile/getVirtualizationType":
+			_, _ = w.Write([]byte(`{"success": true, "result": "none"}`))
+		default:
+			_, _ = w.Write([]byte(`{"success": false, "message": "unexpected request ` + r.URL.String() + `"}`))
+		}
+	})
+	testConfigure(t, r, client)
+
+	attributes := map[string]interface{}{
+		"id":         "web",
+		"label":      "web",
+		"tree_label": "sles15-sp6",
+		"content":    "install\n",
+		"variables":  map[string]string{"user_data": "#cloud-config\n"},
+	}
+	state := testState(t, r, attributes)
+	attributes["variables"] = map[string]string{"user_data": "#cloud-config\n", "ssh_port": "2222"}
+	planned := testState(t, r, attributes)
+	resp := &resource.UpdateResponse{State: planned}
+	r.Update(ctx, resource.UpdateRequest{Plan: tfsdk.Plan{Schema: planned.Schema, Raw: planned.Raw}, State: state}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+	if set["ksLabel"] != "web" || len(set["variables"].(map[string]interface{})) != 2 {
+		t.Errorf("unexpected request %v", set)
+	}
+
+	// Numbers set outside of Terraform are read back as strings.
+	readResp := &resource.ReadResponse{State: resp.State}
+	r.Read(ctx, resource.ReadRequest{State: resp.State}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatal(readResp.Diagnostics)
+	}
+	var read autoinstallProfileResourceModel
+	readResp.State.Get(ctx, &read)
+	variables := map[string]string{}
+	read.Variables.ElementsAs(ctx, &variables, false)
+	if variables["ssh_port"] != "2222" || variables["user_data"] != "#cloud-config\n" {
+		t.Errorf("unexpected variables %v", variables)
+	}
+}
+
+func TestAccAutoinstallProfileResource(t *testing.T) {
+	tree := os.Getenv("UYUNI_TEST_AUTOINSTALL_TREE")
+	acctest.Test(t, acctest.TestCase{
+		PreCheck: func() {
+			testAccRealServerPreCheck(t)
+			testAccSkipUnlessEnv(t, "UYUNI_TEST_AUTOINSTALL_TREE", "an autoinstallable distribution")
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckGone(t, "kickstart/profile/getKickstartTree?kickstartLabel=tfacc-profile"),
+		Steps: []acctest.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccAutoinstallProfileResourceConfig(tree, "tfacc-created"),
+				Check:  acctest.TestCheckResourceAttrSet("uyuni_autoinstall_profile.test", "content_sha256"),
+			},
+			// Update and Read testing
+			{
+				Config: testAccAutoinstallProfileResourceConfig(tree, "tfacc-updated"),
+				Check:  acctest.TestCheckResourceAttr("uyuni_autoinstall_profile.test", "label", "tfacc-profile"),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "uyuni_autoinstall_profile.test",
+				ImportState:       true,
+				ImportStateId:     "tfacc-profile",
+				ImportStateVerify: true,
+				// The server returns the rendered profile.
+				ImportStateVerifyIgnore: []string{"content"},
+			},
+		},
+	})
+}
+
+func testAccAutoinstallProfileResourceConfig(tree, hostname string) string {
+	return fmt.Sprintf(`
+resource "uyuni_autoinstall_profile" "test" {
+  label      = "tfacc-profile"
+  tree_label = %q
+  content    = <<-EOT
+    <?xml version="1.0"?>
+    <profile xmlns="http://www.suse.com/1.0/yast2ns" xmlns:config="http://www.suse.com/1.0/configns">
+      <networking><dns><hostname>%s</hostname></dns></networking>
+    </profile>
+  EOT
+}
+`, tree, hostname)
+}
@@ -0,0 +1,86 @@
+package provider
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// batchConcurrency bounds the API calls a batch runs at the same time, so
+// that resources managing hundreds of objects do not overload the server.
+const batchConcurrency = 8
+
+// parallelismAttribute returns the schema of the parallelism of data sources
+// reading details of what they list, one call per item.
+func parallelismAttribute(items string) schema.Int64Attribute {
+	return schema.Int64Attribute{
+		Description: fmt.Sprintf("Number of %s whose details are read at the same time. The provider still sends at most "+
+			"max_concurrent_requests calls to a server at once. Defaults to %d.", items, batchConcurrency),
+		Optional: true,
+		Validators: []validator.Int64{
+			int64validator.Between(1, 64),
+		},
+	}
+}
+
+// batchLimit returns the configured parallelism, batchConcurrency if unset.
+func batchLimit(parallelism types.Int64) int {
+	if parallelism.IsNull() || parallelism.IsUnknown() {
+		return batchConcurrency
+	}
+	return int(parallelism.ValueInt64())
+}
+
+// runBatch calls fn for every key, batchConcurrency keys at a time, and
+// returns the errors by key. Keys are started in sorted order so that runs
+// are reproducible.
+func runBatch(keys []string, fn func(key string) error) map[string]error {
+	return runBatchLimit(keys, batchConcurrency, fn)
+}
+
+// runBatchLimit is runBatch with limit keys at a time instead.
+func runBatchLimit(keys []string, limit int, fn func(key string) error) map[string]error {
+	sorted := append([]string(nil), keys...)
+	sort.Strings(sorted)
+
+	var (
+		mu     sync.Mutex
+		wg     sync.WaitGroup
+		errs   = map[string]error{}
+		tokens = make(chan struct{}, max(limit, 1))
+	)
+	for _, key := range sorted {
+		wg.Add(1)
+		tokens <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-tokens }()
+			if err := fn(key); err != nil {
+				mu.Lock()
+				errs[key] = err
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	return errs
+}
+
+// removeInChunks calls remove with at most size items at a time, so that
+// large removals make progress that survives failures. On failure it
+// returns the items that were not removed yet together with the error.
+func removeInChunks[T any](items []T, size int, remove func(chunk []T) error) ([]T, error) {
+	for len(items) > 0 {
+		n := min(len(items), max(size, 1))
+		if err := remove(items[:n]); err != nil {
+			return items, err
+		}
+		items = items[n:]
+	}
+	return nil, nil
+}
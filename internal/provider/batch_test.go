@@ -0,0 +1,37 @@
+package provider
+
+import (
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRunBatchLimitBoundsConcurrency(t *testing.T) {
+	var running, peak atomic.Int32
+	var keys []string
+	for i := 0; i < 20; i++ {
+		keys = append(keys, fmt.Sprint(i))
+	}
+	errs := runBatchLimit(keys, 3, func(key string) error {
+		n := running.Add(1)
+		defer running.Add(-1)
+		for {
+			old := peak.Load()
+			if n <= old || peak.CompareAndSwap(old, n) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond)
+		if key == "7" {
+			return fmt.Errorf("failed")
+		}
+		return nil
+	})
+	if peak.Load() > 3 {
+		t.Errorf("expected at most 3 calls at the same time, got %d", peak.Load())
+	}
+	if len(errs) != 1 || errs["7"] == nil {
+		t.Errorf("expected the error of key 7, got %v", errs)
+	}
+}
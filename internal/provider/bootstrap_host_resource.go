@@ -0,0 +1,449 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"terraform-provider-uyuni/internal/uyuni"
+	"terraform-provider-uyuni/internal/validators"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// systemPollInterval is how often the bootstrap waits for the system to be
+// registered. It is a variable so that tests do not have to wait.
+var systemPollInterval = 10 * time.Second
+
+// cleanupTypes are the ways system.deleteSystem cleans up the system.
+var cleanupTypes = []string{"FAIL_ON_CLEANUP_ERR", "NO_CLEANUP", "FORCE_DELETE"}
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                     = &bootstrapHostResource{}
+	_ resource.ResourceWithConfigure        = &bootstrapHostResource{}
+	_ resource.ResourceWithConfigValidators = &bootstrapHostResource{}
+)
+
+// NewBootstrapHostResource is a helper function to simplify the provider implementation.
+func NewBootstrapHostResource() resource.Resource {
+	return &bootstrapHostResource{}
+}
+
+// bootstrapHostResource is the resource implementation.
+type bootstrapHostResource struct {
+	client *uyuniClient
+}
+
+// bootstrapHostResourceModel maps the resource schema data.
+type bootstrapHostResourceModel struct {
+	ID                         types.String   `tfsdk:"id"`
+	Host                       types.String   `tfsdk:"host"`
+	SSHPort                    types.Int64    `tfsdk:"ssh_port"`
+	User                       types.String   `tfsdk:"user"`
+	Password                   types.String   `tfsdk:"password"`
+	PrivateKey                 types.String   `tfsdk:"private_key"`
+	PrivateKeyPassword         types.String   `tfsdk:"private_key_password"`
+	AcceptPendingMinion        types.Bool     `tfsdk:"accept_pending_minion"`
+	ActivationKey              types.String   `tfsdk:"activation_key"`
+	ProxyID                    types.Int64    `tfsdk:"proxy_id"`
+	SaltSSH                    types.Bool     `tfsdk:"salt_ssh"`
+	SystemName                 types.String   `tfsdk:"system_name"`
+	CleanupType                types.String   `tfsdk:"cleanup_type"`
+	SystemID                   types.Int64    `tfsdk:"system_id"`
+	DestroyInMaintenanceWindow types.Bool     `tfsdk:"destroy_in_maintenance_window"`
+	ServerAlias                types.String   `tfsdk:"server_alias"`
+	Timeouts                   timeouts.Value `tfsdk:"timeouts"`
+}
+
+// Metadata returns the resource type name.
+func (r *bootstrapHostResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_bootstrap_host"
+}
+
+// Schema defines the schema for the resource.
+func (r *bootstrapHostResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Registers a host as Salt minion over SSH, e.g. right after it was provisioned by another provider, " +
+			"and waits until the system appears. Alternatively accepts the pending Salt key of a minion which was " +
+			"configured otherwise, e.g. by cloud-init. Destroying the resource deletes the system. All changes except " +
+			"cleanup_type register the host again.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "ID of the system.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"host": schema.StringAttribute{
+				Description: "Hostname or IP address of the host, or the minion id with accept_pending_minion.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"ssh_port": schema.Int64Attribute{
+				Description: "SSH port of the host. Defaults to 22.",
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(22),
+				Validators: []validator.Int64{
+					int64validator.Between(1, 65535),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"user": schema.StringAttribute{
+				Description: "SSH user on the host. Defaults to `root`.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString("root"),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"password": schema.StringAttribute{
+				Description: "SSH password of the user. Conflicts with private_key.",
+				Optional:    true,
+				Sensitive:   true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"private_key": schema.StringAttribute{
+				Description: "SSH private key of the user in PEM format. Conflicts with password.",
+				Optional:    true,
+				Sensitive:   true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"private_key_password": schema.StringAttribute{
+				Description: "Passphrase of private_key.",
+				Optional:    true,
+				Sensitive:   true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"accept_pending_minion": schema.BoolAttribute{
+				Description: "Accept the pending Salt key of the minion host instead of connecting over SSH. " +
+					"Conflicts with the SSH attributes, activation_key and proxy_id. Defaults to false.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"activation_key": schema.StringAttribute{
+				Description: "Activation key to register the system with, e.g. `1-sles15sp6`.",
+				Optional:    true,
+				Sensitive:   true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"proxy_id": schema.Int64Attribute{
+				Description: "ID of the proxy system to connect the minion through.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"salt_ssh": schema.BoolAttribute{
+				Description: "Manage the system agentless over Salt SSH. Defaults to false.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"system_name": schema.StringAttribute{
+				Description: "Profile name the system registers with, usually its FQDN. Set it when host is an IP address. " +
+					"Defaults to host.",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"cleanup_type": schema.StringAttribute{
+				Description: "How to clean up the host when the system is deleted, `FAIL_ON_CLEANUP_ERR`, `NO_CLEANUP` or " +
+					"`FORCE_DELETE`, which deletes the system even if the host cannot be cleaned up, e.g. because it " +
+					"was destroyed first. Defaults to `FORCE_DELETE`.",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString("FORCE_DELETE"),
+				Validators: []validator.String{
+					stringvalidator.OneOf(cleanupTypes...),
+				},
+			},
+			"system_id": schema.Int64Attribute{
+				Description: "ID of the registered system.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"destroy_in_maintenance_window": destroyInMaintenanceWindowAttribute(),
+			"server_alias":                  serverAliasAttribute(),
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+			}),
+		},
+	}
+}
+
+// ConfigValidators returns the validators checking attributes against each other.
+func (r *bootstrapHostResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.Conflicting(
+			path.MatchRoot("password"),
+			path.MatchRoot("private_key"),
+		),
+		resourcevalidator.AtLeastOneOf(
+			path.MatchRoot("password"),
+			path.MatchRoot("private_key"),
+			path.MatchRoot("accept_pending_minion"),
+		),
+		validators.AlsoRequires("private_key", "private_key_password"),
+		validators.ConflictsWhenTrue("accept_pending_minion", "password", "private_key", "activation_key", "proxy_id"),
+	}
+}
+
+// bootstrap registers the host over SSH, or accepts its pending Salt key.
+func (m *bootstrapHostResourceModel) bootstrap(ctx context.Context, client *uyuniClient) error {
+	if m.AcceptPendingMinion.ValueBool() {
+		_, err := apiPost[int](ctx, client, "saltkey/accept", map[string]interface{}{
+			"minionId": m.Host.ValueString(),
+		})
+		return err
+	}
+
+	endpoint := "system/bootstrap"
+	body := map[string]interface{}{
+		"host":          m.Host.ValueString(),
+		"sshPort":       m.SSHPort.ValueInt64(),
+		"sshUser":       m.User.ValueString(),
+		"activationKey": m.ActivationKey.ValueString(),
+		"saltSSH":       m.SaltSSH.ValueBool(),
+	}
+	if m.PrivateKey.IsNull() {
+		body["sshPassword"] = m.Password.ValueString()
+	} else {
+		endpoint = "system/bootstrapWithPrivateSshKey"
+		body["sshPrivKey"] = m.PrivateKey.ValueString()
+		body["sshPrivKeyPass"] = m.PrivateKeyPassword.ValueString()
+	}
+	if !m.ProxyID.IsNull() {
+		body["proxyId"] = m.ProxyID.ValueInt64()
+	}
+	_, err := apiPost[int](ctx, client, endpoint, body)
+	return err
+}
+
+// waitForSystem waits until a system with the profile name is registered and
+// returns its id, the most recent one if several systems have the name. It
+// returns an error if ctx is done first.
+func waitForSystem(ctx context.Context, client *uyuniClient, name string) (int64, error) {
+	for {
+		// Registrations involve no writes of ours, which would refresh the
+		// cache.
+		client.cache.invalidate()
+		systems, err := apiGet[[]uyuni.SystemSummary](ctx, client, "system/getId?name="+url.QueryEscape(name))
+		if ctx.Err() != nil {
+			return 0, fmt.Errorf("system %s was not registered in time", name)
+		}
+		if err != nil {
+			return 0, err
+		}
+		var sid int64
+		for _, system := range systems.Result {
+			sid = max(sid, int64(system.ID))
+		}
+		if sid != 0 {
+			return sid, nil
+		}
+		tflog.Debug(ctx, "Waiting for system "+name+" to be registered")
+		select {
+		case <-ctx.Done():
+			return 0, fmt.Errorf("system %s was not registered in time", name)
+		case <-time.After(systemPollInterval):
+		}
+	}
+}
+
+// Create registers the host and waits for the system.
+func (r *bootstrapHostResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan bootstrapHostResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := resourceClient(ctx, r.client, plan.ServerAlias, nil, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	host := plan.Host.ValueString()
+	if plan.SystemName.IsUnknown() {
+		plan.SystemName = plan.Host
+	}
+	if err := plan.bootstrap(ctx, client); err != nil {
+		resp.Diagnostics.AddError(
+			"Error bootstrapping host",
+			"Could not bootstrap host "+host+": "+err.Error(),
+		)
+		return
+	}
+	sid, err := waitForSystem(ctx, client, plan.SystemName.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error bootstrapping host",
+			"Could not find the system of host "+host+": "+err.Error(),
+		)
+		return
+	}
+	tflog.Info(ctx, fmt.Sprintf("Registered host %s as system %d", host, sid))
+
+	plan.SystemID = types.Int64Value(sid)
+	plan.ID = types.StringValue(fmt.Sprint(sid))
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read removes the resource from state when the system was deleted.
+func (r *bootstrapHostResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state bootstrapHostResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := resourceClient(ctx, r.client, state.ServerAlias, nil, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	sid := fmt.Sprint(state.SystemID.ValueInt64())
+	if _, err := apiGet[uyuni.SystemDetails](ctx, client, "system/getDetails?sid="+sid); err != nil {
+		if handleNotFound(ctx, resp, err, "System "+sid) {
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Reading Uyuni system",
+			"Could not read system "+sid+": "+err.Error(),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update only changes cleanup_type and timeouts, all other changes register
+// the host again.
+func (r *bootstrapHostResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan bootstrapHostResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete deletes the system, cleaning up the host as configured.
+func (r *bootstrapHostResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state bootstrapHostResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := resourceClient(ctx, r.client, state.ServerAlias, nil, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	sid := state.SystemID.ValueInt64()
+	if outsideMaintenanceWindow(ctx, client, state.DestroyInMaintenanceWindow, []int64{sid}, fmt.Sprintf("System %d", sid), &resp.Diagnostics) {
+		return
+	}
+	_, err := apiPost[int](ctx, client, "system/deleteSystem", map[string]interface{}{
+		"sid":         sid,
+		"cleanupType": state.CleanupType.ValueString(),
+	})
+	if err != nil && !isNotFoundError(err) {
+		resp.Diagnostics.AddError(
+			"Error Deleting Uyuni system",
+			fmt.Sprintf("Could not delete system %d: %s", sid, err),
+		)
+		return
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *bootstrapHostResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*uyuniClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *uyuniClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
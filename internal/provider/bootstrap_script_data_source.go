@@ -0,0 +1,181 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &BootstrapScriptDataSource{}
+	_ datasource.DataSourceWithConfigure = &BootstrapScriptDataSource{}
+)
+
+var activationKeysLine = regexp.MustCompile(`(?m)^ACTIVATION_KEYS=.*$`)
+
+// BootstrapScriptDataSourceModel maps the data source schema data.
+type BootstrapScriptDataSourceModel struct {
+	ActivationKey types.String `tfsdk:"activation_key"`
+	Proxy         types.String `tfsdk:"proxy"`
+	ScriptName    types.String `tfsdk:"script_name"`
+	URL           types.String `tfsdk:"url"`
+	Content       types.String `tfsdk:"content"`
+}
+
+// NewBootstrapScriptDataSource is a helper function to simplify the provider implementation.
+func NewBootstrapScriptDataSource() datasource.DataSource {
+	return &BootstrapScriptDataSource{}
+}
+
+// BootstrapScriptDataSource is the data source implementation.
+type BootstrapScriptDataSource struct {
+	client *uyuniClient
+}
+
+// Metadata returns the data source type name.
+func (d *BootstrapScriptDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_bootstrap_script"
+}
+
+// Schema defines the schema for the data source.
+func (d *BootstrapScriptDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Renders the bootstrap script published by the server or a proxy for a given activation key, e.g. to embed it in cloud-init user data.",
+		Attributes: map[string]schema.Attribute{
+			"activation_key": schema.StringAttribute{
+				Description: "Activation key written into the script. Keys with add-on entitlements the server does not offer are refused.",
+				Required:    true,
+			},
+			"proxy": schema.StringAttribute{
+				Description: "FQDN of the proxy to fetch the script from. The server is used when omitted.",
+				Optional:    true,
+			},
+			"script_name": schema.StringAttribute{
+				Description: "Name of the script below /pub/bootstrap. Defaults to bootstrap.sh.",
+				Optional:    true,
+			},
+			"url": schema.StringAttribute{
+				Description: "URL the script was downloaded from.",
+				Computed:    true,
+			},
+			"content": schema.StringAttribute{
+				Description: "Script content with the activation key filled in.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *BootstrapScriptDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state BootstrapScriptDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Systems registering with add-ons the server does not offer fail.
+	if err := checkActivationKeyEntitlements(ctx, d.client, state.ActivationKey.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("activation_key"), "Unsupported Entitlement", err.Error())
+		return
+	}
+
+	server, err := url.Parse(d.client.baseURL)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to determine Uyuni server", err.Error())
+		return
+	}
+	host := server.Host
+	if !state.Proxy.IsNull() {
+		host = state.Proxy.ValueString()
+	}
+	scriptName := "bootstrap.sh"
+	if !state.ScriptName.IsNull() {
+		scriptName = state.ScriptName.ValueString()
+	}
+	scriptURL := fmt.Sprintf("https://%s/pub/bootstrap/%s", host, scriptName)
+
+	// The script is a static file, it is not served through the API.
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, scriptURL, nil)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to Read bootstrap script", err.Error())
+		return
+	}
+	httpResp, err := d.client.httpClient.Do(httpReq)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to Read bootstrap script", "Could not download "+scriptURL+": "+err.Error())
+		return
+	}
+	defer httpResp.Body.Close()
+	if httpResp.StatusCode != http.StatusOK {
+		resp.Diagnostics.AddError(
+			"Unable to Read bootstrap script",
+			fmt.Sprintf("Downloading %s returned HTTP %d. Check that the script was generated with mgr-bootstrap.", scriptURL, httpResp.StatusCode),
+		)
+		return
+	}
+	body, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to Read bootstrap script", err.Error())
+		return
+	}
+
+	content, ok := renderBootstrapScript(string(body), state.ActivationKey.ValueString())
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unable to render bootstrap script",
+			scriptURL+" has no ACTIVATION_KEYS line, it does not look like a bootstrap script.",
+		)
+		return
+	}
+
+	state.URL = types.StringValue(scriptURL)
+	state.Content = types.StringValue(content)
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *BootstrapScriptDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*uyuniClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *uyuniClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+// renderBootstrapScript fills the activation key into a bootstrap script.
+// It returns false if the script has no ACTIVATION_KEYS line.
+func renderBootstrapScript(script, activationKey string) (string, bool) {
+	if !activationKeysLine.MatchString(script) {
+		return "", false
+	}
+	return activationKeysLine.ReplaceAllLiteralString(script, "ACTIVATION_KEYS="+activationKey), true
+}
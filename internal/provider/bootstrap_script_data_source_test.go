@@ -0,0 +1,40 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccBootstrapScriptDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccRealServerPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `data "uyuni_bootstrap_script" "test" { activation_key = "1-tfacc" }`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.uyuni_bootstrap_script.test", "url"),
+					resource.TestMatchResourceAttr("data.uyuni_bootstrap_script.test", "content", regexp.MustCompile("(?m)^ACTIVATION_KEYS=1-tfacc$")),
+				),
+			},
+		},
+	})
+}
+
+func TestRenderBootstrapScript(t *testing.T) {
+	script := "#!/bin/bash\nACTIVATION_KEYS=\nORG_GPG_KEY=\n"
+
+	got, ok := renderBootstrapScript(script, "1-sles15")
+	if !ok {
+		t.Fatal("expected the script to be rendered")
+	}
+	if want := "#!/bin/bash\nACTIVATION_KEYS=1-sles15\nORG_GPG_KEY=\n"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	if _, ok := renderBootstrapScript("<html>Not Found</html>", "1-sles15"); ok {
+		t.Error("expected a page without ACTIVATION_KEYS to be rejected")
+	}
+}
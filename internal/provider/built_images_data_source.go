@@ -0,0 +1,213 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"terraform-provider-uyuni/internal/uyuni"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &BuiltImagesDataSource{}
+	_ datasource.DataSourceWithConfigure = &BuiltImagesDataSource{}
+)
+
+// BuiltImagesDataSourceModel maps the data source schema data.
+type BuiltImagesDataSourceModel struct {
+	ProfileLabel types.String      `tfsdk:"profile_label"`
+	RegistryPath types.String      `tfsdk:"registry_path"`
+	Images       []builtImageModel `tfsdk:"images"`
+}
+
+// builtImageModel maps an image built for a profile.
+type builtImageModel struct {
+	ID        types.Int64  `tfsdk:"id"`
+	Tag       types.String `tfsdk:"tag"`
+	Revision  types.Int64  `tfsdk:"revision"`
+	Arch      types.String `tfsdk:"arch"`
+	Digest    types.String `tfsdk:"digest"`
+	Reference types.String `tfsdk:"reference"`
+	Obsolete  types.Bool   `tfsdk:"obsolete"`
+}
+
+// NewBuiltImagesDataSource is a helper function to simplify the provider implementation.
+func NewBuiltImagesDataSource() datasource.DataSource {
+	return &BuiltImagesDataSource{}
+}
+
+// BuiltImagesDataSource is the data source implementation.
+type BuiltImagesDataSource struct {
+	client *uyuniClient
+}
+
+// Metadata returns the data source type name.
+func (d *BuiltImagesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_built_images"
+}
+
+// Schema defines the schema for the data source.
+func (d *BuiltImagesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the container images built for an image profile with their registry references, " +
+			"e.g. to deploy exactly the image Uyuni built with a Kubernetes or Helm provider. " +
+			"Images whose build failed and images imported from other registries are left out.",
+		Attributes: map[string]schema.Attribute{
+			"profile_label": schema.StringAttribute{
+				Description: "Label of the image profile.",
+				Required:    true,
+			},
+			"registry_path": schema.StringAttribute{
+				Description: "Path of the images in the registry of the image store of the profile, " +
+					"e.g. `registry.example.com:5000/uyuni/web-frontend`.",
+				Computed: true,
+			},
+			"images": schema.ListNestedAttribute{
+				Description: "Built images, the latest revision first.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.Int64Attribute{
+							Description: "ID of the image.",
+							Computed:    true,
+						},
+						"tag": schema.StringAttribute{
+							Description: "Tag of the image, the version of the build, e.g. `latest`.",
+							Computed:    true,
+						},
+						"revision": schema.Int64Attribute{
+							Description: "Revision of the image, counting the builds of the tag.",
+							Computed:    true,
+						},
+						"arch": schema.StringAttribute{
+							Description: "Architecture of the image.",
+							Computed:    true,
+						},
+						"digest": schema.StringAttribute{
+							Description: "Digest of the image, `sha256:` followed by the hash. Null until the image was inspected.",
+							Computed:    true,
+						},
+						"reference": schema.StringAttribute{
+							Description: "Reference to pull the image by: the registry path with the digest, " +
+								"or with the tag until the image was inspected.",
+							Computed: true,
+						},
+						"obsolete": schema.BoolAttribute{
+							Description: "Whether a later build replaced the image.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// registryPath returns the path of images named name in the registry of the
+// image store, without scheme.
+func registryPath(store uyuni.ImageStore, name string) string {
+	uri := store.URI
+	if _, rest, found := strings.Cut(uri, "://"); found {
+		uri = rest
+	}
+	return strings.TrimSuffix(uri, "/") + "/" + name
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *BuiltImagesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state BuiltImagesDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	label := state.ProfileLabel.ValueString()
+	profile, err := apiGet[uyuni.ImageProfile](ctx, d.client, "image/profile/getDetails?label="+url.QueryEscape(label))
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to Read Uyuni image profile", "Could not read image profile "+label+": "+err.Error())
+		return
+	}
+	store, err := apiGet[uyuni.ImageStore](ctx, d.client, "image/store/getDetails?label="+url.QueryEscape(profile.Result.ImageStore))
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to Read Uyuni image store", "Could not read image store "+profile.Result.ImageStore+": "+err.Error())
+		return
+	}
+	images, err := apiGet[[]uyuni.ImageInfo](ctx, d.client, "image/listImages")
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to Read Uyuni images", err.Error())
+		return
+	}
+
+	// Images of a profile are named by its label and pushed to its store.
+	built := []uyuni.ImageInfo{}
+	for _, image := range images.Result {
+		if image.Name != label || image.StoreLabel != store.Result.Label || image.External {
+			continue
+		}
+		if image.BuildStatus != "" && image.BuildStatus != "completed" {
+			continue
+		}
+		built = append(built, image)
+	}
+	sort.Slice(built, func(i, j int) bool {
+		if built[i].Revision != built[j].Revision {
+			return built[i].Revision > built[j].Revision
+		}
+		return built[i].ID > built[j].ID
+	})
+
+	path := registryPath(store.Result, label)
+	state.RegistryPath = types.StringValue(path)
+	state.Images = make([]builtImageModel, 0, len(built))
+	for _, image := range built {
+		reference := path + ":" + image.Version
+		if image.Checksum != "" {
+			reference = path + "@" + image.Checksum
+		}
+		state.Images = append(state.Images, builtImageModel{
+			ID:        types.Int64Value(int64(image.ID)),
+			Tag:       types.StringValue(image.Version),
+			Revision:  types.Int64Value(int64(image.Revision)),
+			Arch:      types.StringValue(image.Arch),
+			Digest:    nonEmptyString(image.Checksum),
+			Reference: types.StringValue(reference),
+			Obsolete:  types.BoolValue(image.Obsolete),
+		})
+	}
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *BuiltImagesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*uyuniClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *uyuniClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
@@ -0,0 +1,103 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+
+	"terraform-provider-uyuni/internal/uyuni"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestRegistryPath(t *testing.T) {
+	for uri, want := range map[string]string{
+		"registry.example.com:5000":        "registry.example.com:5000/web",
+		"https://registry.example.com/ns/": "registry.example.com/ns/web",
+		"registry.example.com:5000/uyuni/": "registry.example.com:5000/uyuni/web",
+	} {
+		if got := registryPath(uyuni.ImageStore{URI: uri}, "web"); got != want {
+			t.Errorf("%s: got %s, want %s", uri, got, want)
+		}
+	}
+}
+
+func TestBuiltImagesDataSource(t *testing.T) {
+	ctx := context.Background()
+	client := testAPIClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/image/profile/getDetails":
+			_, _ = w.Write([]byte(`{"success": true, "result": {"label": "web", "imagetype": "dockerfile", "imagestore": "registry", "path": "https://git.example.com/web.git"}}`))
+		case "/image/store/getDetails":
+			_, _ = w.Write([]byte(`{"success": true, "result": {"label": "registry", "uri": "registry.example.com:5000", "storetype": "registry"}}`))
+		case "/image/listImages":
+			_, _ = w.Write([]byte(`{"success": true, "result": [
+				{"id": 7, "name": "web", "version": "latest", "revision": 1, "arch": "x86_64", "external": false, "storeLabel": "registry", "checksum": "sha256:aaa", "obsolete": true, "buildStatus": "completed"},
+				{"id": 9, "name": "web", "version": "latest", "revision": 2, "arch": "x86_64", "external": false, "storeLabel": "registry", "buildStatus": "completed"},
+				{"id": 10, "name": "web", "version": "latest", "revision": 3, "arch": "x86_64", "external": false, "storeLabel": "registry", "buildStatus": "failed"},
+				{"id": 11, "name": "web", "version": "upstream", "revision": 1, "arch": "x86_64", "external": true, "storeLabel": "registry"},
+				{"id": 12, "name": "db", "version": "latest", "revision": 1, "arch": "x86_64", "external": false, "storeLabel": "registry"}
+			]}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL)
+		}
+	})
+
+	d := NewBuiltImagesDataSource()
+	d.(datasource.DataSourceWithConfigure).Configure(ctx, datasource.ConfigureRequest{ProviderData: client}, &datasource.ConfigureResponse{})
+	var schemaResp datasource.SchemaResponse
+	d.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	values := map[string]tftypes.Value{}
+	for name, attributeType := range objectType.AttributeTypes {
+		values[name] = tftypes.NewValue(attributeType, nil)
+	}
+	values["profile_label"] = tftypes.NewValue(tftypes.String, "web")
+	config := tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, values)}
+	resp := &datasource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: config.Raw}}
+	d.Read(ctx, datasource.ReadRequest{Config: config}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+
+	var state BuiltImagesDataSourceModel
+	resp.State.Get(ctx, &state)
+	if state.RegistryPath.ValueString() != "registry.example.com:5000/web" {
+		t.Errorf("got registry path %s", state.RegistryPath)
+	}
+	if len(state.Images) != 2 {
+		t.Fatalf("expected the 2 built images, got %v", state.Images)
+	}
+	if latest := state.Images[0]; latest.ID.ValueInt64() != 9 || latest.Reference.ValueString() != "registry.example.com:5000/web:latest" || !latest.Digest.IsNull() {
+		t.Errorf("expected the uninspected revision 2 first, referenced by tag, got %v", latest)
+	}
+	if previous := state.Images[1]; previous.Reference.ValueString() != "registry.example.com:5000/web@sha256:aaa" || !previous.Obsolete.ValueBool() {
+		t.Errorf("expected the inspected revision 1 referenced by digest, got %v", previous)
+	}
+}
+
+func TestAccBuiltImagesDataSource(t *testing.T) {
+	profile := os.Getenv("UYUNI_TEST_IMAGE_PROFILE")
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccRealServerPreCheck(t)
+			testAccSkipUnlessEnv(t, "UYUNI_TEST_IMAGE_PROFILE", "an image profile with a built image")
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`data "uyuni_built_images" "test" { profile_label = %q }`, profile),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.uyuni_built_images.test", "images.0.id"),
+					resource.TestCheckResourceAttrSet("data.uyuni_built_images.test", "images.0.reference"),
+				),
+			},
+		},
+	})
+}
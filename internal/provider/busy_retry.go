@@ -0,0 +1,84 @@
+package provider
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"terraform-provider-uyuni/internal/uyuni"
+)
+
+// busyRetryInterval is the delay before the first retry of a write the server
+// rejected as busy. It doubles with every retry up to busyRetryMaxInterval.
+// Both are variables so that tests do not have to wait.
+var (
+	busyRetryInterval    = 15 * time.Second
+	busyRetryMaxInterval = 2 * time.Minute
+)
+
+// busyRetryNamespaces are the API namespaces whose writes conflict with
+// repository synchronizations.
+var busyRetryNamespaces = []string{
+	"channel/",
+	"errata/",
+}
+
+// busyMessages are the fault messages of writes racing with a repository
+// synchronization, like "Channel sles15-sp5-updates is currently being
+// synced" or "could not obtain lock on row in relation rhnchannel".
+var busyMessages = []string{
+	"being synced",
+	"being synchronized",
+	"sync in progress",
+	"currently syncing",
+	"could not obtain lock",
+	"lock timeout",
+	"deadlock detected",
+}
+
+// retriesBusy reports whether a request is retried when the server rejects it
+// as busy. Reads are served regardless of synchronizations.
+func retriesBusy(method, path string) bool {
+	if method == http.MethodGet {
+		return false
+	}
+	for _, namespace := range busyRetryNamespaces {
+		if strings.HasPrefix(path, namespace) {
+			return true
+		}
+	}
+	return false
+}
+
+// isBusyError reports whether an API error means the server could not
+// process the request because a repository synchronization holds the
+// channel, so that the request succeeds once it finished.
+func isBusyError(err error) bool {
+	var fault *uyuni.Fault
+	if !errors.As(err, &fault) {
+		return false
+	}
+	if fault.StatusCode == http.StatusServiceUnavailable {
+		return true
+	}
+	msg := strings.ToLower(fault.Message)
+	for _, busy := range busyMessages {
+		if strings.Contains(msg, busy) {
+			return true
+		}
+	}
+	return false
+}
+
+// busyRetryDelay returns the delay before the given retry, counted from zero.
+func busyRetryDelay(retry int) time.Duration {
+	delay := busyRetryInterval
+	for i := 0; i < retry && delay < busyRetryMaxInterval; i++ {
+		delay *= 2
+	}
+	if delay > busyRetryMaxInterval {
+		return busyRetryMaxInterval
+	}
+	return delay
+}
@@ -0,0 +1,116 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"terraform-provider-uyuni/internal/uyuni"
+)
+
+func testBusyRetry(t *testing.T) {
+	interval, maxInterval := busyRetryInterval, busyRetryMaxInterval
+	busyRetryInterval, busyRetryMaxInterval = time.Millisecond, 4*time.Millisecond
+	t.Cleanup(func() { busyRetryInterval, busyRetryMaxInterval = interval, maxInterval })
+}
+
+func TestIsBusyError(t *testing.T) {
+	tests := map[string]struct {
+		err  error
+		want bool
+	}{
+		"being synced":        {&apiError{Err: &uyuni.Fault{StatusCode: 500, Message: "Channel sles15-sp5-updates is currently being synced"}}, true},
+		"row lock":            {&uyuni.Fault{Message: "ERROR: could not obtain lock on row in relation \"rhnchannel\""}, true},
+		"service unavailable": {&uyuni.Fault{StatusCode: http.StatusServiceUnavailable}, true},
+		"other fault":         {&uyuni.Fault{StatusCode: 500, Message: "Invalid channel label"}, false},
+		"transport error":     {errors.New("connection refused"), false},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := isBusyError(tt.err); got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBusyRetryDelay(t *testing.T) {
+	testBusyRetry(t)
+	want := []time.Duration{time.Millisecond, 2 * time.Millisecond, 4 * time.Millisecond, 4 * time.Millisecond}
+	for retry, delay := range want {
+		if got := busyRetryDelay(retry); got != delay {
+			t.Errorf("retry %d: got %s, want %s", retry, got, delay)
+		}
+	}
+}
+
+func TestAPIRequestRetriesBusyWrites(t *testing.T) {
+	testBusyRetry(t)
+	calls := 0
+	client := testAPIClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls < 3 {
+			_, _ = w.Write([]byte(`{"success": false, "message": "Channel test-channel is currently being synced"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"success": true, "result": 1}`))
+	})
+
+	result, err := apiPost[int](context.Background(), client, "channel/software/mergePackages", map[string]interface{}{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Result != 1 || calls != 3 {
+		t.Errorf("got result %d after %d calls", result.Result, calls)
+	}
+}
+
+func TestAPIRequestDoesNotRetryOtherFaults(t *testing.T) {
+	testBusyRetry(t)
+	tests := map[string]struct {
+		method string
+		path   string
+		body   string
+	}{
+		"other namespace": {http.MethodPost, "system/deleteSystem", `{"success": false, "message": "currently being synced"}`},
+		"read":            {http.MethodGet, "channel/software/getDetails?channelLabel=test", `{"success": false, "message": "currently being synced"}`},
+		"other fault":     {http.MethodPost, "channel/software/create", `{"success": false, "message": "Invalid channel label"}`},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			calls := 0
+			client := testAPIClient(t, func(w http.ResponseWriter, r *http.Request) {
+				calls++
+				_, _ = w.Write([]byte(tt.body))
+			})
+			if _, err := apiRequest[int](context.Background(), client, tt.method, tt.path, nil); err == nil {
+				t.Fatal("expected an error")
+			}
+			if calls != 1 {
+				t.Errorf("got %d calls", calls)
+			}
+		})
+	}
+}
+
+func TestAPIRequestStopsRetryingAtDeadline(t *testing.T) {
+	testBusyRetry(t)
+	var calls atomic.Int32
+	client := testAPIClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	// The deadline ends either a retry or its delay, depending on timing.
+	if _, err := apiPost[int](ctx, client, "errata/publish", map[string]interface{}{}); err == nil {
+		t.Fatal("expected an error")
+	}
+	if calls.Load() < 2 {
+		t.Errorf("expected retries, got %d calls", calls.Load())
+	}
+}
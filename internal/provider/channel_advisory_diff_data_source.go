@@ -0,0 +1,174 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"terraform-provider-uyuni/internal/uyuni"
+	"terraform-provider-uyuni/internal/validators"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &ChannelAdvisoryDiffDataSource{}
+	_ datasource.DataSourceWithConfigure = &ChannelAdvisoryDiffDataSource{}
+)
+
+// clonedAdvisoryName matches the names of advisories cloned by the server,
+// which prefixes the original name with CL-, or CM-, CN- and so on if that
+// name is taken.
+var clonedAdvisoryName = regexp.MustCompile(`^C[L-Z]-(.+)$`)
+
+// ChannelAdvisoryDiffDataSourceModel maps the data source schema data.
+type ChannelAdvisoryDiffDataSourceModel struct {
+	UpstreamChannelLabel   types.String          `tfsdk:"upstream_channel_label"`
+	DownstreamChannelLabel types.String          `tfsdk:"downstream_channel_label"`
+	MissingAdvisoryNames   types.Set             `tfsdk:"missing_advisory_names"`
+	Missing                []channelErratumModel `tfsdk:"missing"`
+}
+
+// NewChannelAdvisoryDiffDataSource is a helper function to simplify the provider implementation.
+func NewChannelAdvisoryDiffDataSource() datasource.DataSource {
+	return &ChannelAdvisoryDiffDataSource{}
+}
+
+// ChannelAdvisoryDiffDataSource is the data source implementation.
+type ChannelAdvisoryDiffDataSource struct {
+	client *uyuniClient
+}
+
+// Metadata returns the data source type name.
+func (d *ChannelAdvisoryDiffDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_channel_advisory_diff"
+}
+
+// Schema defines the schema for the data source.
+func (d *ChannelAdvisoryDiffDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the errata of an upstream channel missing in a downstream channel, e.g. of a vendor channel " +
+			"missing in its production clone, to decide what to promote or to feed uyuni_errata_clone and content " +
+			"lifecycle filters. Errata cloned by the server with a `CL-` prefix count as present.",
+		Attributes: map[string]schema.Attribute{
+			"upstream_channel_label": schema.StringAttribute{
+				Description: "Label of the channel the errata come from, e.g. the vendor channel.",
+				Required:    true,
+				Validators: []validator.String{
+					validators.ChannelLabel(),
+				},
+			},
+			"downstream_channel_label": schema.StringAttribute{
+				Description: "Label of the channel the errata are promoted to, e.g. a clone of the upstream channel.",
+				Required:    true,
+				Validators: []validator.String{
+					validators.ChannelLabel(),
+				},
+			},
+			"missing_advisory_names": schema.SetAttribute{
+				Description: "Names of the advisories missing in the downstream channel.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"missing": schema.ListNestedAttribute{
+				Description:  "Errata missing in the downstream channel, ordered by issue date and advisory name.",
+				Computed:     true,
+				NestedObject: channelErratumAttributes(),
+			},
+		},
+	}
+}
+
+// missingErrata returns the errata of upstream missing in downstream.
+func missingErrata(upstream, downstream []uyuni.Erratum) []uyuni.Erratum {
+	present := map[string]bool{}
+	for _, erratum := range downstream {
+		present[erratum.AdvisoryName] = true
+		if match := clonedAdvisoryName.FindStringSubmatch(erratum.AdvisoryName); match != nil {
+			present[match[1]] = true
+		}
+	}
+	missing := []uyuni.Erratum{}
+	for _, erratum := range upstream {
+		if !present[erratum.AdvisoryName] {
+			missing = append(missing, erratum)
+		}
+	}
+	return missing
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *ChannelAdvisoryDiffDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state ChannelAdvisoryDiffDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	errata := map[string][]uyuni.Erratum{}
+	for _, label := range []string{state.UpstreamChannelLabel.ValueString(), state.DownstreamChannelLabel.ValueString()} {
+		channelErrata, err := listChannelErrata(ctx, d.client, label, nil, nil)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Read Uyuni channel advisory diff",
+				"Could not list errata of channel "+label+": "+err.Error(),
+			)
+			return
+		}
+		errata[label] = channelErrata
+	}
+
+	missing := missingErrata(errata[state.UpstreamChannelLabel.ValueString()], errata[state.DownstreamChannelLabel.ValueString()])
+	severities, err := listErrataSeverities(ctx, d.client, missing)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Uyuni channel advisory diff",
+			"Could not read the severities of the missing errata: "+err.Error(),
+		)
+		return
+	}
+
+	names := make([]string, 0, len(missing))
+	for _, erratum := range missing {
+		names = append(names, erratum.AdvisoryName)
+	}
+	state.Missing = channelErratumModels(ctx, missing, severities)
+	state.MissingAdvisoryNames, diags = types.SetValueFrom(ctx, types.StringType, names)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *ChannelAdvisoryDiffDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*uyuniClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *uyuniClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
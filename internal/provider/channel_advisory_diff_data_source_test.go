@@ -0,0 +1,97 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestChannelAdvisoryDiffDataSource(t *testing.T) {
+	ctx := context.Background()
+	client := testAPIClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/channel/software/listErrata":
+			switch r.URL.Query().Get("channelLabel") {
+			case "sles15-sp6-updates":
+				_, _ = w.Write([]byte(`{"success": true, "result": [
+					{"id": 4725, "date": "2024-08-22", "advisory_synopsis": "Recommended update for systemd",
+					 "advisory_type": "Bug Fix Advisory", "advisory_status": "final", "advisory_name": "SUSE-2024-2951"},
+					{"id": 4711, "date": "2024-08-20", "advisory_synopsis": "Security update for openssl-3",
+					 "advisory_type": "Security Advisory", "advisory_status": "final", "advisory_name": "SUSE-2024-2930"},
+					{"id": 4700, "date": "2024-08-01", "advisory_synopsis": "Security update for curl",
+					 "advisory_type": "Security Advisory", "advisory_status": "final", "advisory_name": "SUSE-2024-2801"},
+					{"id": 4690, "date": "2024-07-30", "advisory_synopsis": "Recommended update for zypper",
+					 "advisory_type": "Bug Fix Advisory", "advisory_status": "final", "advisory_name": "SUSE-2024-2790"}
+				]}`))
+			case "prod-sles15-sp6-updates":
+				_, _ = w.Write([]byte(`{"success": true, "result": [
+					{"id": 4700, "date": "2024-08-01", "advisory_synopsis": "Security update for curl",
+					 "advisory_type": "Security Advisory", "advisory_status": "final", "advisory_name": "SUSE-2024-2801"},
+					{"id": 5690, "date": "2024-07-30", "advisory_synopsis": "Recommended update for zypper",
+					 "advisory_type": "Bug Fix Advisory", "advisory_status": "final", "advisory_name": "CL-SUSE-2024-2790"}
+				]}`))
+			default:
+				t.Errorf("unexpected request %s", r.URL)
+			}
+		case "/errata/getDetails":
+			if r.URL.Query().Get("advisoryName") != "SUSE-2024-2930" {
+				t.Errorf("unexpected request %s", r.URL)
+			}
+			_, _ = w.Write([]byte(`{"success": true, "result": {"severity": "important"}}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL)
+		}
+	})
+
+	resp := testDataSourceRead(t, NewChannelAdvisoryDiffDataSource(), client, map[string]tftypes.Value{
+		"upstream_channel_label":   tftypes.NewValue(tftypes.String, "sles15-sp6-updates"),
+		"downstream_channel_label": tftypes.NewValue(tftypes.String, "prod-sles15-sp6-updates"),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+
+	var state ChannelAdvisoryDiffDataSourceModel
+	resp.State.Get(ctx, &state)
+	if len(state.MissingAdvisoryNames.Elements()) != 2 {
+		t.Errorf("expected 2 missing advisories, got %v", state.MissingAdvisoryNames)
+	}
+	if len(state.Missing) != 2 || state.Missing[0].AdvisoryName.ValueString() != "SUSE-2024-2930" ||
+		state.Missing[1].AdvisoryName.ValueString() != "SUSE-2024-2951" {
+		t.Fatalf("expected the missing errata ordered by issue date, got %v", state.Missing)
+	}
+	if state.Missing[0].Severity.ValueString() != "important" {
+		t.Errorf("expected the severity of the security advisory, got %v", state.Missing[0])
+	}
+}
+
+func TestAccChannelAdvisoryDiffDataSource(t *testing.T) {
+	source := os.Getenv("UYUNI_TEST_SOURCE_CHANNEL")
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccRealServerPreCheck(t)
+			testAccSkipUnlessEnv(t, "UYUNI_TEST_SOURCE_CHANNEL", "a synchronized base channel")
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				// A channel misses no advisory of itself.
+				Config: fmt.Sprintf(`
+data "uyuni_channel_advisory_diff" "test" {
+  upstream_channel_label   = %[1]q
+  downstream_channel_label = %[1]q
+}
+`, source),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.uyuni_channel_advisory_diff.test", "missing_advisory_names.#", "0"),
+					resource.TestCheckResourceAttr("data.uyuni_channel_advisory_diff.test", "missing.#", "0"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,284 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"terraform-provider-uyuni/internal/uyuni"
+	"terraform-provider-uyuni/internal/validators"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &ChannelErrataDataSource{}
+	_ datasource.DataSourceWithConfigure = &ChannelErrataDataSource{}
+)
+
+// ChannelErrataDataSourceModel maps the data source schema data.
+type ChannelErrataDataSourceModel struct {
+	ChannelLabel  types.String          `tfsdk:"channel_label"`
+	StartDate     types.String          `tfsdk:"start_date"`
+	EndDate       types.String          `tfsdk:"end_date"`
+	AdvisoryNames types.Set             `tfsdk:"advisory_names"`
+	Errata        []channelErratumModel `tfsdk:"errata"`
+}
+
+// channelErratumModel maps an erratum of the channel.
+type channelErratumModel struct {
+	AdvisoryName types.String `tfsdk:"advisory_name"`
+	ID           types.Int64  `tfsdk:"id"`
+	Synopsis     types.String `tfsdk:"synopsis"`
+	Type         types.String `tfsdk:"type"`
+	Severity     types.String `tfsdk:"severity"`
+	Status       types.String `tfsdk:"status"`
+	IssueDate    types.String `tfsdk:"issue_date"`
+	UpdateDate   types.String `tfsdk:"update_date"`
+}
+
+// NewChannelErrataDataSource is a helper function to simplify the provider implementation.
+func NewChannelErrataDataSource() datasource.DataSource {
+	return &ChannelErrataDataSource{}
+}
+
+// ChannelErrataDataSource is the data source implementation.
+type ChannelErrataDataSource struct {
+	client *uyuniClient
+}
+
+// Metadata returns the data source type name.
+func (d *ChannelErrataDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_channel_errata"
+}
+
+// Schema defines the schema for the data source.
+func (d *ChannelErrataDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the errata of a software channel, e.g. to select the advisories an uyuni_errata_clone " +
+			"clones by issue date or severity.",
+		Attributes: map[string]schema.Attribute{
+			"channel_label": schema.StringAttribute{
+				Description: "Label of the channel.",
+				Required:    true,
+				Validators: []validator.String{
+					validators.ChannelLabel(),
+				},
+			},
+			"start_date": schema.StringAttribute{
+				Description: "Only errata modified on or after this date (YYYY-MM-DD, UTC) are listed.",
+				Optional:    true,
+				Validators: []validator.String{
+					validators.Date(),
+				},
+			},
+			"end_date": schema.StringAttribute{
+				Description: "Only errata modified before this date (YYYY-MM-DD, UTC) are listed.",
+				Optional:    true,
+				Validators: []validator.String{
+					validators.Date(),
+				},
+			},
+			"advisory_names": schema.SetAttribute{
+				Description: "Names of the listed advisories.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"errata": schema.ListNestedAttribute{
+				Description:  "Errata of the channel, ordered by issue date and advisory name.",
+				Computed:     true,
+				NestedObject: channelErratumAttributes(),
+			},
+		},
+	}
+}
+
+// channelErratumAttributes is the schema of an erratum of a channel.
+func channelErratumAttributes() schema.NestedAttributeObject {
+	return schema.NestedAttributeObject{
+		Attributes: map[string]schema.Attribute{
+			"advisory_name": schema.StringAttribute{
+				Description: "Name of the advisory, e.g. `SUSE-2024-2930`.",
+				Computed:    true,
+			},
+			"id": schema.Int64Attribute{
+				Description: "ID of the advisory.",
+				Computed:    true,
+			},
+			"synopsis": schema.StringAttribute{
+				Description: "Synopsis of the advisory.",
+				Computed:    true,
+			},
+			"type": schema.StringAttribute{
+				Description: "Type of the advisory: `Security Advisory`, `Bug Fix Advisory` or `Product Enhancement Advisory`.",
+				Computed:    true,
+			},
+			"severity": schema.StringAttribute{
+				Description: "Severity of a security advisory, e.g. `critical` or `moderate`. Null if the advisory has none.",
+				Computed:    true,
+			},
+			"status": schema.StringAttribute{
+				Description: "Status of the advisory, e.g. `final` or `retracted`.",
+				Computed:    true,
+			},
+			"issue_date": schema.StringAttribute{
+				Description: "Date the advisory was issued, in RFC 3339 format.",
+				Computed:    true,
+			},
+			"update_date": schema.StringAttribute{
+				Description: "Date the advisory was last updated, in RFC 3339 format.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// listErrataSeverities returns the severity by advisory name of the security
+// advisories, which the channel listing does not include.
+func listErrataSeverities(ctx context.Context, client *uyuniClient, errata []uyuni.Erratum) (map[string]string, error) {
+	var advisories []string
+	for _, erratum := range errata {
+		if erratum.AdvisoryType == advisoryTypeSecurity {
+			advisories = append(advisories, erratum.AdvisoryName)
+		}
+	}
+
+	var mu sync.Mutex
+	severities := map[string]string{}
+	errs := runBatch(advisories, func(advisory string) error {
+		details, err := apiGet[uyuni.ErratumDetails](ctx, client, "errata/getDetails?advisoryName="+url.QueryEscape(advisory))
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		severities[advisory] = details.Result.Severity
+		mu.Unlock()
+		return nil
+	})
+	if len(errs) > 0 {
+		failed := make([]string, 0, len(errs))
+		for advisory, err := range errs {
+			failed = append(failed, fmt.Sprintf("advisory %s: %s", advisory, err))
+		}
+		sort.Strings(failed)
+		return nil, fmt.Errorf("could not read %s", strings.Join(failed, "; "))
+	}
+	return severities, nil
+}
+
+// channelErratumModels returns the models of the errata, ordered by issue
+// date and advisory name.
+func channelErratumModels(ctx context.Context, errata []uyuni.Erratum, severities map[string]string) []channelErratumModel {
+	models := make([]channelErratumModel, 0, len(errata))
+	for _, erratum := range errata {
+		severity := types.StringNull()
+		if severities[erratum.AdvisoryName] != "" {
+			severity = types.StringValue(severities[erratum.AdvisoryName])
+		}
+		models = append(models, channelErratumModel{
+			AdvisoryName: types.StringValue(erratum.AdvisoryName),
+			ID:           types.Int64Value(int64(erratum.ID)),
+			Synopsis:     types.StringValue(erratum.AdvisorySynopsis),
+			Type:         types.StringValue(erratum.AdvisoryType),
+			Severity:     severity,
+			Status:       types.StringValue(erratum.AdvisoryStatus),
+			IssueDate:    timestampValue(ctx, erratum.Date),
+			UpdateDate:   timestampValue(ctx, erratum.UpdateDate),
+		})
+	}
+	// RFC 3339 dates in UTC sort in time order.
+	sort.SliceStable(models, func(i, j int) bool {
+		a, b := models[i], models[j]
+		if a.IssueDate.ValueString() != b.IssueDate.ValueString() {
+			return a.IssueDate.ValueString() < b.IssueDate.ValueString()
+		}
+		return a.AdvisoryName.ValueString() < b.AdvisoryName.ValueString()
+	})
+	return models
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *ChannelErrataDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state ChannelErrataDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	start, err := optionalDate(state.StartDate)
+	var end *time.Time
+	if err == nil {
+		end, err = optionalDate(state.EndDate)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to Read Uyuni channel errata", err.Error())
+		return
+	}
+
+	label := state.ChannelLabel.ValueString()
+	errata, err := listChannelErrata(ctx, d.client, label, start, end)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Uyuni channel errata",
+			"Could not list errata of channel "+label+": "+err.Error(),
+		)
+		return
+	}
+	severities, err := listErrataSeverities(ctx, d.client, errata)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Uyuni channel errata",
+			"Could not read the severities of the errata of channel "+label+": "+err.Error(),
+		)
+		return
+	}
+
+	names := make([]string, 0, len(errata))
+	for _, erratum := range errata {
+		names = append(names, erratum.AdvisoryName)
+	}
+	state.Errata = channelErratumModels(ctx, errata, severities)
+
+	state.AdvisoryNames, diags = types.SetValueFrom(ctx, types.StringType, names)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *ChannelErrataDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*uyuniClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *uyuniClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
@@ -0,0 +1,91 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestChannelErrataDataSource(t *testing.T) {
+	ctx := context.Background()
+	client := testAPIClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/channel/software/listErrata":
+			query := r.URL.Query()
+			if query.Get("channelLabel") != "sles15-sp6-updates" || query.Get("startDate") != "2024-08-01T00:00:00Z" || query.Get("endDate") == "" {
+				t.Errorf("unexpected request %s", r.URL)
+			}
+			_, _ = w.Write([]byte(`{"success": true, "result": [
+				{"id": 4725, "date": "2024-08-22", "advisory_synopsis": "Recommended update for systemd",
+				 "advisory_type": "Bug Fix Advisory", "advisory_status": "final", "advisory_name": "SUSE-2024-2951"},
+				{"id": 4711, "date": "2024-08-20", "advisory_synopsis": "Security update for openssl-3",
+				 "advisory_type": "Security Advisory", "advisory_status": "final", "advisory_name": "SUSE-2024-2930"}
+			]}`))
+		case "/errata/getDetails":
+			if r.URL.Query().Get("advisoryName") != "SUSE-2024-2930" {
+				t.Errorf("unexpected request %s", r.URL)
+			}
+			_, _ = w.Write([]byte(`{"success": true, "result": {"severity": "important"}}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL)
+		}
+	})
+
+	d := NewChannelErrataDataSource()
+	d.(datasource.DataSourceWithConfigure).Configure(ctx, datasource.ConfigureRequest{ProviderData: client}, &datasource.ConfigureResponse{})
+	var schemaResp datasource.SchemaResponse
+	d.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	values := map[string]tftypes.Value{}
+	for name, attributeType := range objectType.AttributeTypes {
+		values[name] = tftypes.NewValue(attributeType, nil)
+	}
+	values["channel_label"] = tftypes.NewValue(tftypes.String, "sles15-sp6-updates")
+	values["start_date"] = tftypes.NewValue(tftypes.String, "2024-08-01")
+	config := tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, values)}
+	resp := &datasource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: config.Raw}}
+	d.Read(ctx, datasource.ReadRequest{Config: config}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+
+	var state ChannelErrataDataSourceModel
+	resp.State.Get(ctx, &state)
+	if len(state.AdvisoryNames.Elements()) != 2 {
+		t.Errorf("expected 2 advisory names, got %v", state.AdvisoryNames)
+	}
+	if len(state.Errata) != 2 || state.Errata[0].AdvisoryName.ValueString() != "SUSE-2024-2930" || state.Errata[0].IssueDate.ValueString() != "2024-08-20T00:00:00Z" {
+		t.Fatalf("expected the errata ordered by issue date, got %v", state.Errata)
+	}
+	if state.Errata[0].Severity.ValueString() != "important" || !state.Errata[1].Severity.IsNull() {
+		t.Errorf("expected only the security advisory to have a severity, got %v", state.Errata)
+	}
+}
+
+func TestAccChannelErrataDataSource(t *testing.T) {
+	source := os.Getenv("UYUNI_TEST_SOURCE_CHANNEL")
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccRealServerPreCheck(t)
+			testAccSkipUnlessEnv(t, "UYUNI_TEST_SOURCE_CHANNEL", "a synchronized base channel")
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`data "uyuni_channel_errata" "test" { channel_label = %q }`, source),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.uyuni_channel_errata.test", "errata.0.advisory_name"),
+					resource.TestCheckResourceAttrPair("data.uyuni_channel_errata.test", "advisory_names.#", "data.uyuni_channel_errata.test", "errata.#"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,178 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"terraform-provider-uyuni/internal/uyuni"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &ChannelFamilyUsageDataSource{}
+	_ datasource.DataSourceWithConfigure = &ChannelFamilyUsageDataSource{}
+)
+
+// ChannelFamilyUsageDataSourceModel maps the data source schema data.
+type ChannelFamilyUsageDataSourceModel struct {
+	OrgID                  types.Int64               `tfsdk:"org_id"`
+	IgnorePermissionErrors types.Bool                `tfsdk:"ignore_permission_errors"`
+	ChannelFamilies        []channelFamilyUsageModel `tfsdk:"channel_families"`
+}
+
+// channelFamilyUsageModel maps the usage of a channel family by an
+// organization.
+type channelFamilyUsageModel struct {
+	OrgID       types.Int64  `tfsdk:"org_id"`
+	OrgName     types.String `tfsdk:"org_name"`
+	Label       types.String `tfsdk:"label"`
+	Name        types.String `tfsdk:"name"`
+	Allocated   types.Int64  `tfsdk:"allocated"`
+	Unallocated types.Int64  `tfsdk:"unallocated"`
+	Used        types.Int64  `tfsdk:"used"`
+	Free        types.Int64  `tfsdk:"free"`
+}
+
+// NewChannelFamilyUsageDataSource is a helper function to simplify the provider implementation.
+func NewChannelFamilyUsageDataSource() datasource.DataSource {
+	return &ChannelFamilyUsageDataSource{}
+}
+
+// ChannelFamilyUsageDataSource is the data source implementation.
+type ChannelFamilyUsageDataSource struct {
+	client *uyuniClient
+}
+
+// Metadata returns the data source type name.
+func (d *ChannelFamilyUsageDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_channel_family_usage"
+}
+
+// Schema defines the schema for the data source.
+func (d *ChannelFamilyUsageDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists how many subscriptions of each channel family, i.e. of the products synchronized from the " +
+			"SUSE Customer Center, each organization uses and has left, e.g. to rebalance systems between organizations. " +
+			"Use uyuni_entitlement_usage for system entitlements. Requires the `satellite_admin` role.",
+		Attributes: map[string]schema.Attribute{
+			"org_id": schema.Int64Attribute{
+				Description: "ID of the organization. All organizations are listed if omitted.",
+				Optional:    true,
+			},
+			"ignore_permission_errors": ignorePermissionErrorsAttribute(roleSatelliteAdmin),
+			"channel_families": schema.ListNestedAttribute{
+				Description: "Usage of each channel family by each organization, ordered by organization ID and label.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"org_id": schema.Int64Attribute{
+							Description: "ID of the organization.",
+							Computed:    true,
+						},
+						"org_name": schema.StringAttribute{
+							Description: "Name of the organization.",
+							Computed:    true,
+						},
+						"label": schema.StringAttribute{
+							Description: "Label of the channel family, e.g. `7261`.",
+							Computed:    true,
+						},
+						"name": schema.StringAttribute{
+							Description: "Name of the channel family, e.g. `SUSE Linux Enterprise Server`.",
+							Computed:    true,
+						},
+						"allocated": schema.Int64Attribute{
+							Description: "Number of subscriptions allocated to the organization.",
+							Computed:    true,
+						},
+						"unallocated": schema.Int64Attribute{
+							Description: "Number of subscriptions not allocated to any organization.",
+							Computed:    true,
+						},
+						"used": schema.Int64Attribute{
+							Description: "Number of systems of the organization subscribed to channels of the family.",
+							Computed:    true,
+						},
+						"free": schema.Int64Attribute{
+							Description: "Number of allocated subscriptions the organization does not use.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *ChannelFamilyUsageDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state ChannelFamilyUsageDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	selected, ok := selectOrgs(ctx, d.client, state.OrgID, state.IgnorePermissionErrors, &resp.Diagnostics)
+	if !ok {
+		return
+	}
+
+	state.ChannelFamilies = []channelFamilyUsageModel{}
+	for _, org := range selected {
+		usage, err := apiGet[[]uyuni.EntitlementUsage](ctx, d.client, fmt.Sprintf("org/listSoftwareEntitlementsForOrg?orgId=%d", org.ID))
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Read Uyuni channel family usage",
+				fmt.Sprintf("Could not list the channel families of organization %s: %s", org.Name, err),
+			)
+			return
+		}
+		sort.Slice(usage.Result, func(i, j int) bool { return usage.Result[i].Label < usage.Result[j].Label })
+		for _, family := range usage.Result {
+			state.ChannelFamilies = append(state.ChannelFamilies, channelFamilyUsageModel{
+				OrgID:       types.Int64Value(int64(org.ID)),
+				OrgName:     types.StringValue(org.Name),
+				Label:       types.StringValue(family.Label),
+				Name:        types.StringValue(family.Name),
+				Allocated:   types.Int64Value(int64(family.Allocated)),
+				Unallocated: types.Int64Value(int64(family.Unallocated)),
+				Used:        types.Int64Value(int64(family.Used)),
+				Free:        types.Int64Value(int64(family.Free)),
+			})
+		}
+	}
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *ChannelFamilyUsageDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*uyuniClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *uyuniClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
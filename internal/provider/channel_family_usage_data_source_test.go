@@ -0,0 +1,54 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestChannelFamilyUsageDataSourceListsAllOrgs(t *testing.T) {
+	client := testAPIClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/org/listOrgs":
+			_, _ = w.Write([]byte(`{"success": true, "result": [{"id": 2, "name": "Retail"}, {"id": 1, "name": "SUSE"}]}`))
+		case "/org/listSoftwareEntitlementsForOrg":
+			if r.URL.Query().Get("orgId") == "1" {
+				_, _ = w.Write([]byte(`{"success": true, "result": [{"label": "7261", "name": "SUSE Linux Enterprise Server", "allocated": 100, "free": 0, "used": 100}]}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"success": true, "result": [{"label": "7261", "name": "SUSE Linux Enterprise Server", "allocated": 20, "free": 15, "used": 5}]}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL)
+		}
+	})
+
+	resp := testDataSourceRead(t, NewChannelFamilyUsageDataSource(), client, map[string]tftypes.Value{})
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+	var state ChannelFamilyUsageDataSourceModel
+	resp.State.Get(context.Background(), &state)
+	if len(state.ChannelFamilies) != 2 {
+		t.Fatalf("expected a channel family per organization, got %v", state.ChannelFamilies)
+	}
+	first, second := state.ChannelFamilies[0], state.ChannelFamilies[1]
+	if first.OrgName.ValueString() != "SUSE" || first.Free.ValueInt64() != 0 || second.OrgName.ValueString() != "Retail" || second.Free.ValueInt64() != 15 {
+		t.Errorf("expected the usage ordered by organization ID, got %v", state.ChannelFamilies)
+	}
+}
+
+func TestAccChannelFamilyUsageDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccRealServerPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `data "uyuni_channel_family_usage" "test" { org_id = 1 }`,
+				Check:  resource.TestCheckResourceAttrSet("data.uyuni_channel_family_usage.test", "channel_families.#"),
+			},
+		},
+	})
+}
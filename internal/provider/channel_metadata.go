@@ -0,0 +1,45 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// regenerateMetadataAttribute returns the schema of the regenerate_metadata
+// attribute of resources changing the packages of channels.
+func regenerateMetadataAttribute() schema.BoolAttribute {
+	return schema.BoolAttribute{
+		Description: "Regenerate the repository metadata and the errata cache of the channel after its packages changed, " +
+			"so clients see consistent repodata right after the apply instead of after the next Taskomatic run. " +
+			"Defaults to false.",
+		Optional: true,
+		Computed: true,
+		Default:  booldefault.StaticBool(false),
+	}
+}
+
+// regenerateChannelMetadata regenerates the errata cache and the repository
+// metadata of the channel if regenerate is true.
+func regenerateChannelMetadata(ctx context.Context, client *uyuniClient, regenerate types.Bool, label string) error {
+	if !regenerate.ValueBool() {
+		return nil
+	}
+	tflog.Info(ctx, "Regenerating metadata of channel "+label)
+	if _, err := apiPost[int](ctx, client, "channel/software/regenerateNeededCache", map[string]interface{}{
+		"channelLabel": label,
+	}); err != nil {
+		return fmt.Errorf("could not regenerate the errata cache: %w", err)
+	}
+	if _, err := apiPost[int](ctx, client, "channel/software/regenerateYumCache", map[string]interface{}{
+		"channelLabel": label,
+		"force":        false,
+	}); err != nil {
+		return fmt.Errorf("could not regenerate the repository metadata: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,425 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+
+	"terraform-provider-uyuni/internal/uyuni"
+	"terraform-provider-uyuni/internal/validators"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &channelPackagesResource{}
+	_ resource.ResourceWithConfigure   = &channelPackagesResource{}
+	_ resource.ResourceWithImportState = &channelPackagesResource{}
+)
+
+// NewChannelPackagesResource is a helper function to simplify the provider implementation.
+func NewChannelPackagesResource() resource.Resource {
+	return &channelPackagesResource{}
+}
+
+// channelPackagesResource is the resource implementation.
+type channelPackagesResource struct {
+	client *uyuniClient
+}
+
+// channelPackagesResourceModel maps the resource schema data.
+type channelPackagesResourceModel struct {
+	ID                 types.String   `tfsdk:"id"`
+	ChannelLabel       types.String   `tfsdk:"channel_label"`
+	PackageIDs         types.Set      `tfsdk:"package_ids"`
+	RegenerateMetadata types.Bool     `tfsdk:"regenerate_metadata"`
+	OnDestroy          types.String   `tfsdk:"on_destroy"`
+	ServerAlias        types.String   `tfsdk:"server_alias"`
+	Org                *orgModel      `tfsdk:"org"`
+	Timeouts           timeouts.Value `tfsdk:"timeouts"`
+}
+
+// Metadata returns the resource type name.
+func (r *channelPackagesResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_channel_packages"
+}
+
+// Schema defines the schema for the resource.
+func (r *channelPackagesResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages packages of a custom software channel. " +
+			"Packages of the channel that are not listed are left alone, so several resources can curate the same channel. " +
+			"Use the uyuni_package data source to look up the ids of packages. " +
+			"Destroying removes the packages in chunks, a destroy that fails keeps the packages still in the channel in the state " +
+			"and the next destroy resumes with them.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Label of the channel.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"channel_label": schema.StringAttribute{
+				Description: "Label of the custom channel.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validators.ChannelLabel(),
+				},
+			},
+			"package_ids": schema.SetAttribute{
+				Description: "IDs of the packages in the channel.",
+				ElementType: types.Int64Type,
+				Required:    true,
+			},
+			"regenerate_metadata": regenerateMetadataAttribute(),
+			"on_destroy":          onDestroyAttribute("removes the listed packages from the channel"),
+			"server_alias":        serverAliasAttribute(),
+		},
+		Blocks: map[string]schema.Block{
+			"org": orgBlock(),
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+// channelPackagesChunkSize bounds the packages removed from a channel by a
+// single call when destroying, so that an interrupted destroy resumes with
+// the packages that are still in the channel.
+var channelPackagesChunkSize = 1000
+
+// listChannelPackages returns the ids of the packages in the channel.
+func listChannelPackages(ctx context.Context, client *uyuniClient, label string) ([]int64, error) {
+	packages, err := apiGet[[]uyuni.Package](ctx, client, "channel/software/listAllPackages?channelLabel="+url.QueryEscape(label))
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]int64, 0, len(packages.Result))
+	for _, pkg := range packages.Result {
+		ids = append(ids, int64(pkg.ID))
+	}
+	return ids, nil
+}
+
+// changeChannelPackages adds packages to and removes packages from the channel.
+func changeChannelPackages(ctx context.Context, client *uyuniClient, label string, add, remove []int64) error {
+	if len(add) > 0 {
+		_, err := apiPost[int](ctx, client, "channel/software/addPackages", map[string]interface{}{
+			"channelLabel": label,
+			"packageIds":   add,
+		})
+		if err != nil {
+			return fmt.Errorf("could not add packages %v: %w", add, err)
+		}
+	}
+	if len(remove) > 0 {
+		_, err := apiPost[int](ctx, client, "channel/software/removePackages", map[string]interface{}{
+			"channelLabel": label,
+			"packageIds":   remove,
+		})
+		if err != nil {
+			return fmt.Errorf("could not remove packages %v: %w", remove, err)
+		}
+	}
+	return nil
+}
+
+// Create a new resource.
+func (r *channelPackagesResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan channelPackagesResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	client := resourceClient(ctx, r.client, plan.ServerAlias, plan.Org, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	var wanted []int64
+	resp.Diagnostics.Append(plan.PackageIDs.ElementsAs(ctx, &wanted, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	label := plan.ChannelLabel.ValueString()
+	current, err := listChannelPackages(ctx, client, label)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error adding channel packages",
+			"Could not list packages of channel "+label+": "+err.Error(),
+		)
+		return
+	}
+
+	// Packages already in the channel are adopted, others stay untouched.
+	add, _ := setDiff(current, wanted)
+	tflog.Info(ctx, fmt.Sprintf("Channel %s: adding %d packages", label, len(add)))
+	if err := changeChannelPackages(ctx, client, label, add, nil); err != nil {
+		resp.Diagnostics.AddError(
+			"Error adding channel packages",
+			"Could not add packages to channel "+label+": "+err.Error(),
+		)
+		return
+	}
+	if len(add) > 0 {
+		if err := regenerateChannelMetadata(ctx, client, plan.RegenerateMetadata, label); err != nil {
+			resp.Diagnostics.AddError(
+				"Error adding channel packages",
+				"Could not regenerate metadata of channel "+label+": "+err.Error(),
+			)
+			return
+		}
+	}
+
+	plan.ID = plan.ChannelLabel
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read resource information.
+func (r *channelPackagesResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Get current state
+	var state channelPackagesResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := resourceClient(ctx, r.client, state.ServerAlias, state.Org, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	label := state.ChannelLabel.ValueString()
+	current, err := listChannelPackages(ctx, client, label)
+	if err != nil {
+		if handleNotFound(ctx, resp, err, "Channel "+label) {
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Reading Uyuni channel packages",
+			"Could not list packages of channel "+label+": "+err.Error(),
+		)
+		return
+	}
+
+	// An imported channel manages all of its packages, otherwise only the
+	// managed packages that are still in the channel are kept.
+	ids := current
+	if !state.PackageIDs.IsNull() {
+		var managed []int64
+		resp.Diagnostics.Append(state.PackageIDs.ElementsAs(ctx, &managed, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		inChannel := map[int64]bool{}
+		for _, id := range current {
+			inChannel[id] = true
+		}
+		ids = []int64{}
+		for _, id := range managed {
+			if inChannel[id] {
+				ids = append(ids, id)
+			}
+		}
+	}
+
+	packageIDs, diags := types.SetValueFrom(ctx, types.Int64Type, ids)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	state.PackageIDs = packageIDs
+
+	state.ID = state.ChannelLabel
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *channelPackagesResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Retrieve values from plan and state
+	var plan, state channelPackagesResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	client := resourceClient(ctx, r.client, plan.ServerAlias, plan.Org, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	var wanted, previous []int64
+	resp.Diagnostics.Append(plan.PackageIDs.ElementsAs(ctx, &wanted, false)...)
+	resp.Diagnostics.Append(state.PackageIDs.ElementsAs(ctx, &previous, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	label := plan.ChannelLabel.ValueString()
+	add, remove := setDiff(previous, wanted)
+	tflog.Info(ctx, fmt.Sprintf("Channel %s: adding %d and removing %d packages", label, len(add), len(remove)))
+	if err := changeChannelPackages(ctx, client, label, add, remove); err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating channel packages",
+			"Could not update packages of channel "+label+": "+err.Error(),
+		)
+		return
+	}
+	if len(add) > 0 || len(remove) > 0 {
+		if err := regenerateChannelMetadata(ctx, client, plan.RegenerateMetadata, label); err != nil {
+			resp.Diagnostics.AddError(
+				"Error updating channel packages",
+				"Could not regenerate metadata of channel "+label+": "+err.Error(),
+			)
+			return
+		}
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete removes the managed packages from the channel.
+func (r *channelPackagesResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Retrieve values from state
+	var state channelPackagesResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	label := state.ChannelLabel.ValueString()
+	if orphaned(state.OnDestroy) {
+		tflog.Info(ctx, "Removing channel packages from state, the packages stay in channel "+label)
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	client := resourceClient(ctx, r.client, state.ServerAlias, state.Org, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	var ids []int64
+	resp.Diagnostics.Append(state.PackageIDs.ElementsAs(ctx, &ids, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	remaining, err := removeInChunks(ids, channelPackagesChunkSize, func(chunk []int64) error {
+		return changeChannelPackages(ctx, client, label, nil, chunk)
+	})
+	if err != nil {
+		// Keep only the packages still in the channel, so that destroying
+		// again resumes where this attempt stopped.
+		if len(remaining) < len(ids) {
+			resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("package_ids"), remaining)...)
+		}
+		resp.Diagnostics.AddError(
+			"Error Deleting Uyuni channel packages",
+			fmt.Sprintf("Could not remove packages from channel %s, %d of %d packages are left for the next destroy: %s",
+				label, len(remaining), len(ids), err),
+		)
+		return
+	}
+	if len(ids) > 0 {
+		if err := regenerateChannelMetadata(ctx, client, state.RegenerateMetadata, label); err != nil {
+			resp.Diagnostics.AddError(
+				"Error Deleting Uyuni channel packages",
+				"Could not regenerate metadata of channel "+label+": "+err.Error(),
+			)
+			return
+		}
+	}
+}
+
+// ImportState imports all packages of a channel by its label.
+func (r *channelPackagesResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("channel_label"), req, resp)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("on_destroy"), onDestroyDelete)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("regenerate_metadata"), false)...)
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *channelPackagesResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*uyuniClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *uyuniClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
@@ -0,0 +1,265 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	acctest "github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccChannelPackagesResource(t *testing.T) {
+	packageID := os.Getenv("UYUNI_TEST_PACKAGE_ID")
+	acctest.Test(t, acctest.TestCase{
+		PreCheck: func() {
+			testAccRealServerPreCheck(t)
+			testAccSkipUnlessEnv(t, "UYUNI_TEST_PACKAGE_ID", "an x86_64 package synchronized to the server")
+			testAccSeedChannel(t, "tfacc-packages")
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []acctest.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "uyuni_channel_packages" "test" {
+  channel_label = "tfacc-packages"
+  package_ids   = [%s]
+}
+`, packageID),
+				Check: acctest.ComposeAggregateTestCheckFunc(
+					acctest.TestCheckResourceAttr("uyuni_channel_packages.test", "id", "tfacc-packages"),
+					acctest.TestCheckTypeSetElemAttr("uyuni_channel_packages.test", "package_ids.*", packageID),
+				),
+			},
+			{
+				ResourceName:      "uyuni_channel_packages.test",
+				ImportState:       true,
+				ImportStateId:     "tfacc-packages",
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+// testChannelPackagesServer serves a channel holding the packages 1, 2 and 3.
+func testChannelPackagesServer(w http.ResponseWriter, r *http.Request) {
+	_, _ = w.Write([]byte(`{"success": true, "result": [
+		{"id": 1, "name": "a", "version": "1", "release": "1", "epoch": "", "arch_label": "noarch"},
+		{"id": 2, "name": "b", "version": "1", "release": "1", "epoch": "", "arch_label": "noarch"},
+		{"id": 3, "name": "c", "version": "1", "release": "1", "epoch": "", "arch_label": "noarch"}
+	]}`))
+}
+
+func testPackageIDs(t *testing.T, ids types.Set) []int64 {
+	var values []int64
+	if diags := ids.ElementsAs(context.Background(), &values, false); diags.HasError() {
+		t.Fatal(diags)
+	}
+	sort.Slice(values, func(i, j int) bool { return values[i] < values[j] })
+	return values
+}
+
+func TestChannelPackagesReadKeepsManagedPackages(t *testing.T) {
+	ctx := context.Background()
+	ids, _ := types.SetValueFrom(ctx, types.Int64Type, []int64{2, 4})
+
+	resp := testRead(t, NewChannelPackagesResource(), testAPIClient(t, testChannelPackagesServer), map[string]interface{}{
+		"id":            "custom",
+		"channel_label": "custom",
+		"package_ids":   ids,
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+
+	var state channelPackagesResourceModel
+	resp.State.Get(ctx, &state)
+	if got := testPackageIDs(t, state.PackageIDs); fmt.Sprint(got) != "[2]" {
+		t.Errorf("expected only the managed package still in the channel, got %v", got)
+	}
+}
+
+func TestChannelPackagesImportAdoptsAllPackages(t *testing.T) {
+	ctx := context.Background()
+
+	resp := testRead(t, NewChannelPackagesResource(), testAPIClient(t, testChannelPackagesServer), map[string]interface{}{
+		"channel_label": "custom",
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+
+	var state channelPackagesResourceModel
+	resp.State.Get(ctx, &state)
+	got := testPackageIDs(t, state.PackageIDs)
+	if fmt.Sprint(got) != "[1 2 3]" {
+		t.Errorf("expected all packages of the channel, got %v", got)
+	}
+	if state.ID.ValueString() != "custom" {
+		t.Errorf("got id %s", state.ID)
+	}
+}
+
+func TestChannelPackagesCreateAddsMissingPackages(t *testing.T) {
+	ctx := context.Background()
+	var added []int64
+	client := testAPIClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/channel/software/addPackages" {
+			var body struct {
+				PackageIDs []int64 `json:"packageIds"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			added = body.PackageIDs
+			_, _ = w.Write([]byte(`{"success": true, "result": 1}`))
+			return
+		}
+		testChannelPackagesServer(w, r)
+	})
+	r := NewChannelPackagesResource()
+	testConfigure(t, r, client)
+
+	ids, _ := types.SetValueFrom(ctx, types.Int64Type, []int64{3, 4})
+	planned := testState(t, r, map[string]interface{}{
+		"channel_label": "custom",
+		"package_ids":   ids,
+	})
+	resp := &resource.CreateResponse{State: planned}
+	r.Create(ctx, resource.CreateRequest{Plan: tfsdk.Plan{Schema: planned.Schema, Raw: planned.Raw}}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+	if fmt.Sprint(added) != "[4]" {
+		t.Errorf("expected only the missing package to be added, got %v", added)
+	}
+}
+
+func TestChannelPackagesOrphanKeepsPackages(t *testing.T) {
+	ctx := context.Background()
+	r := NewChannelPackagesResource()
+	testConfigure(t, r, testAPIClient(t, func(_ http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s", r.URL)
+	}))
+
+	ids, _ := types.SetValueFrom(ctx, types.Int64Type, []int64{1, 2})
+	state := testState(t, r, map[string]interface{}{
+		"id":            "custom",
+		"channel_label": "custom",
+		"package_ids":   ids,
+		"on_destroy":    onDestroyOrphan,
+	})
+	resp := &resource.DeleteResponse{State: state}
+	r.Delete(ctx, resource.DeleteRequest{State: state}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+}
+
+func TestChannelPackagesUpdateRegeneratesMetadata(t *testing.T) {
+	ctx := context.Background()
+	var calls []string
+	client := testAPIClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.URL.Path)
+		_, _ = w.Write([]byte(`{"success": true, "result": 1}`))
+	})
+	r := NewChannelPackagesResource()
+	testConfigure(t, r, client)
+
+	previous, _ := types.SetValueFrom(ctx, types.Int64Type, []int64{1, 2})
+	wanted, _ := types.SetValueFrom(ctx, types.Int64Type, []int64{2, 3})
+	state := testState(t, r, map[string]interface{}{
+		"id":                  "custom",
+		"channel_label":       "custom",
+		"package_ids":         previous,
+		"regenerate_metadata": true,
+	})
+	planned := testState(t, r, map[string]interface{}{
+		"id":                  "custom",
+		"channel_label":       "custom",
+		"package_ids":         wanted,
+		"regenerate_metadata": true,
+	})
+	resp := &resource.UpdateResponse{State: planned}
+	r.Update(ctx, resource.UpdateRequest{Plan: tfsdk.Plan{Schema: planned.Schema, Raw: planned.Raw}, State: state}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+	want := []string{
+		"/channel/software/addPackages",
+		"/channel/software/removePackages",
+		"/channel/software/regenerateNeededCache",
+		"/channel/software/regenerateYumCache",
+	}
+	if fmt.Sprint(calls) != fmt.Sprint(want) {
+		t.Errorf("expected %v, got %v", want, calls)
+	}
+
+	// Unchanged packages leave the metadata alone.
+	calls = nil
+	resp = &resource.UpdateResponse{State: planned}
+	r.Update(ctx, resource.UpdateRequest{Plan: tfsdk.Plan{Schema: planned.Schema, Raw: planned.Raw}, State: planned}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+	if len(calls) != 0 {
+		t.Errorf("expected no requests, got %v", calls)
+	}
+}
+
+func TestChannelPackagesDeleteResumesAfterFailure(t *testing.T) {
+	ctx := context.Background()
+	defer func(size int) { channelPackagesChunkSize = size }(channelPackagesChunkSize)
+	channelPackagesChunkSize = 2
+
+	var removed [][]int64
+	failing := true
+	client := testAPIClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			PackageIDs []int64 `json:"packageIds"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		if failing && len(removed) == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		removed = append(removed, body.PackageIDs)
+		_, _ = w.Write([]byte(`{"success": true, "result": 1}`))
+	})
+	r := NewChannelPackagesResource()
+	testConfigure(t, r, client)
+
+	ids, _ := types.SetValueFrom(ctx, types.Int64Type, []int64{5, 1, 4, 2, 3})
+	state := testState(t, r, map[string]interface{}{
+		"id":            "custom",
+		"channel_label": "custom",
+		"package_ids":   ids,
+	})
+	resp := &resource.DeleteResponse{State: state}
+	r.Delete(ctx, resource.DeleteRequest{State: state}, resp)
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected an error")
+	}
+	var remaining []int64
+	resp.State.GetAttribute(ctx, path.Root("package_ids"), &remaining)
+	sort.Slice(remaining, func(i, j int) bool { return remaining[i] < remaining[j] })
+	if fmt.Sprint(remaining) != "[3 4 5]" {
+		t.Errorf("expected the packages left in the channel in the state, got %v", remaining)
+	}
+
+	// Destroying again only removes the remaining packages.
+	failing = false
+	resp = &resource.DeleteResponse{State: resp.State}
+	r.Delete(ctx, resource.DeleteRequest{State: resp.State}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+	if fmt.Sprint(removed) != "[[1 2] [3 4] [5]]" {
+		t.Errorf("expected the packages to be removed in chunks once, got %v", removed)
+	}
+}
@@ -0,0 +1,339 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+
+	"terraform-provider-uyuni/internal/uyuni"
+	"terraform-provider-uyuni/internal/validators"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &channelSettingsResource{}
+	_ resource.ResourceWithConfigure   = &channelSettingsResource{}
+	_ resource.ResourceWithImportState = &channelSettingsResource{}
+	_ resource.ResourceWithModifyPlan  = &channelSettingsResource{}
+)
+
+// channelChecksumTypes maps the checksum types of channels to the release
+// which introduced them, nil for the types all supported releases offer.
+var channelChecksumTypes = map[string]*apiFeature{
+	"sha1":   nil,
+	"sha256": nil,
+	"sha384": {name: "The sha384 channel checksum type", uyuni: "2024.08", suseManager: "5.0"},
+	"sha512": {name: "The sha512 channel checksum type", uyuni: "2024.08", suseManager: "5.0"},
+}
+
+// NewChannelSettingsResource is a helper function to simplify the provider implementation.
+func NewChannelSettingsResource() resource.Resource {
+	return &channelSettingsResource{}
+}
+
+// channelSettingsResource is the resource implementation.
+type channelSettingsResource struct {
+	client *uyuniClient
+}
+
+// channelSettingsResourceModel maps the resource schema data.
+type channelSettingsResourceModel struct {
+	ID           types.String `tfsdk:"id"`
+	ChannelLabel types.String `tfsdk:"channel_label"`
+	ChecksumType types.String `tfsdk:"checksum_type"`
+	Summary      types.String `tfsdk:"summary"`
+	ArchLabel    types.String `tfsdk:"arch_label"`
+	ServerAlias  types.String `tfsdk:"server_alias"`
+	Org          *orgModel    `tfsdk:"org"`
+}
+
+// Metadata returns the resource type name.
+func (r *channelSettingsResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_channel_settings"
+}
+
+// Schema defines the schema for the resource.
+func (r *channelSettingsResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	checksumTypes := make([]string, 0, len(channelChecksumTypes))
+	for checksumType := range channelChecksumTypes {
+		checksumTypes = append(checksumTypes, checksumType)
+	}
+	sort.Strings(checksumTypes)
+	resp.Schema = schema.Schema{
+		Description: "Changes the checksum type and the summary of an existing custom channel in place, " +
+			"so populated channels need not be recreated. Changing the checksum type regenerates the repository metadata. " +
+			"Attributes left unset keep their current values, and destroying the resource leaves the channel as it is.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Label of the channel.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"channel_label": schema.StringAttribute{
+				Description: "Label of the custom channel.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validators.ChannelLabel(),
+				},
+			},
+			"checksum_type": schema.StringAttribute{
+				Description: "Checksum type of the repository metadata: `sha1`, `sha256`, or since Uyuni 2024.08 " +
+					"and SUSE Manager 5.0 `sha384` and `sha512`.",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf(checksumTypes...),
+				},
+			},
+			"summary": schema.StringAttribute{
+				Description: "Summary of the channel.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"arch_label": schema.StringAttribute{
+				Description: "Architecture of the channel, e.g. `channel-x86_64`. It cannot be changed in place.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"server_alias": serverAliasAttribute(),
+		},
+		Blocks: map[string]schema.Block{
+			"org": orgBlock(),
+		},
+	}
+}
+
+// checkChecksumType returns an error if the server is too old for the
+// checksum type. Nothing is refused while the server version is unknown.
+func checkChecksumType(client *uyuniClient, checksumType string) error {
+	feature := channelChecksumTypes[checksumType]
+	if feature == nil || client.version == nil || client.version.atLeast(feature.minimum(client.version)) {
+		return nil
+	}
+	return &unsupportedFeatureError{feature: *feature, version: client.version}
+}
+
+// ModifyPlan refuses checksum types the server does not offer yet.
+func (r *channelSettingsResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to plan on destroy or before the provider is configured.
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	var plan channelSettingsResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() || plan.ChecksumType.IsNull() || plan.ChecksumType.IsUnknown() || plan.ServerAlias.IsUnknown() {
+		return
+	}
+
+	// Unknown aliases are reported by the apply.
+	client, err := r.client.forServer(ctx, plan.ServerAlias.ValueString())
+	if err != nil {
+		return
+	}
+	if err := checkChecksumType(client, plan.ChecksumType.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("checksum_type"), "Unsupported Checksum Type", err.Error())
+	}
+}
+
+// setFromChannel sets the computed attributes from the details of the channel.
+func (m *channelSettingsResourceModel) setFromChannel(channel *uyuni.Channel) {
+	m.ID = m.ChannelLabel
+	m.ChecksumType = types.StringValue(channel.ChecksumLabel)
+	m.Summary = types.StringValue(channel.Summary)
+	m.ArchLabel = types.StringValue(channel.ArchLabel)
+}
+
+// apply changes the settings of the channel which differ from the plan.
+// Changing the checksum type regenerates the repository metadata, as clients
+// cannot use metadata of the old type after the switch.
+func (m *channelSettingsResourceModel) apply(ctx context.Context, client *uyuniClient) error {
+	label := m.ChannelLabel.ValueString()
+	current, err := apiGet[uyuni.Channel](ctx, client, "channel/software/getDetails?channelLabel="+url.QueryEscape(label))
+	if err != nil {
+		return fmt.Errorf("could not read channel %s: %w", label, err)
+	}
+
+	details := map[string]interface{}{}
+	checksumChanged := !m.ChecksumType.IsUnknown() && !m.ChecksumType.IsNull() && m.ChecksumType.ValueString() != current.Result.ChecksumLabel
+	if checksumChanged {
+		details["checksum_label"] = m.ChecksumType.ValueString()
+	}
+	if !m.Summary.IsUnknown() && !m.Summary.IsNull() && m.Summary.ValueString() != current.Result.Summary {
+		details["summary"] = m.Summary.ValueString()
+	}
+	if len(details) > 0 {
+		if _, err := apiPost[int](ctx, client, "channel/software/setDetails", map[string]interface{}{
+			"channelLabel": label,
+			"details":      details,
+		}); err != nil {
+			return fmt.Errorf("could not change channel %s: %w", label, err)
+		}
+	}
+	if checksumChanged {
+		tflog.Info(ctx, fmt.Sprintf("Regenerating metadata of channel %s with checksum type %s", label, m.ChecksumType.ValueString()))
+		if _, err := apiPost[int](ctx, client, "channel/software/regenerateYumCache", map[string]interface{}{
+			"channelLabel": label,
+			"force":        true,
+		}); err != nil {
+			return fmt.Errorf("could not regenerate the repository metadata of channel %s: %w", label, err)
+		}
+	}
+
+	changed, err := apiGet[uyuni.Channel](ctx, client, "channel/software/getDetails?channelLabel="+url.QueryEscape(label))
+	if err != nil {
+		return fmt.Errorf("could not read channel %s after changing it: %w", label, err)
+	}
+	m.setFromChannel(&changed.Result)
+	return nil
+}
+
+// Create a new resource.
+func (r *channelSettingsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan channelSettingsResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := resourceClient(ctx, r.client, plan.ServerAlias, plan.Org, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	if err := plan.apply(ctx, client); err != nil {
+		resp.Diagnostics.AddError("Error changing channel settings", err.Error())
+		return
+	}
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read resource information.
+func (r *channelSettingsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Get current state
+	var state channelSettingsResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := resourceClient(ctx, r.client, state.ServerAlias, state.Org, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	label := state.ChannelLabel.ValueString()
+	channel, err := apiGet[uyuni.Channel](ctx, client, "channel/software/getDetails?channelLabel="+url.QueryEscape(label))
+	if err != nil {
+		if handleNotFound(ctx, resp, err, "Channel "+label) {
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Reading Uyuni channel settings",
+			"Could not read channel "+label+": "+err.Error(),
+		)
+		return
+	}
+	state.setFromChannel(&channel.Result)
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update changes the settings in place.
+func (r *channelSettingsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Retrieve values from plan
+	var plan channelSettingsResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := resourceClient(ctx, r.client, plan.ServerAlias, plan.Org, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	if err := plan.apply(ctx, client); err != nil {
+		resp.Diagnostics.AddError("Error changing channel settings", err.Error())
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete leaves the channel with its current settings.
+func (r *channelSettingsResource) Delete(ctx context.Context, _ resource.DeleteRequest, _ *resource.DeleteResponse) {
+	tflog.Info(ctx, "Removing channel settings from state, the channel keeps its current settings")
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *channelSettingsResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*uyuniClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *uyuniClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// ImportState imports the settings of a channel by its label.
+func (r *channelSettingsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("channel_label"), req, resp)
+}
@@ -0,0 +1,126 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	acctest "github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestChannelSettingsResourceChangesChecksumInPlace(t *testing.T) {
+	ctx := context.Background()
+	checksum, summary := "sha1", "Old summary"
+	var requests []string
+	r := NewChannelSettingsResource()
+	testConfigure(t, r, testAPIClient(t, func(w http.ResponseWriter, req *http.Request) {
+		var body map[string]interface{}
+		_ = json.NewDecoder(req.Body).Decode(&body)
+		switch req.URL.Path {
+		case "/channel/software/getDetails":
+			_, _ = fmt.Fprintf(w, `{"success": true, "result": {"label": "custom", "arch_label": "channel-x86_64", "checksum_label": %q, "summary": %q}}`, checksum, summary)
+			return
+		case "/channel/software/setDetails":
+			details := body["details"].(map[string]interface{})
+			checksum = details["checksum_label"].(string)
+			requests = append(requests, req.URL.Path+" "+fmt.Sprint(details))
+		default:
+			requests = append(requests, req.URL.Path+" "+fmt.Sprint(body["force"]))
+		}
+		_, _ = w.Write([]byte(`{"success": true, "result": 1}`))
+	}))
+
+	planned := testState(t, r, map[string]interface{}{
+		"channel_label": "custom",
+		"checksum_type": "sha256",
+		"summary":       "Old summary",
+	})
+	resp := &resource.CreateResponse{State: planned}
+	r.Create(ctx, resource.CreateRequest{Plan: tfsdk.Plan{Schema: planned.Schema, Raw: planned.Raw}}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+	want := "[/channel/software/setDetails map[checksum_label:sha256] /channel/software/regenerateYumCache true]"
+	if fmt.Sprint(requests) != want {
+		t.Errorf("expected %s, got %v", want, requests)
+	}
+	var state channelSettingsResourceModel
+	resp.State.Get(ctx, &state)
+	if state.ID.ValueString() != "custom" || state.ChecksumType.ValueString() != "sha256" || state.ArchLabel.ValueString() != "channel-x86_64" {
+		t.Errorf("unexpected state %v", state)
+	}
+}
+
+func TestChannelSettingsResourceRefusesChecksumOfNewerReleases(t *testing.T) {
+	ctx := context.Background()
+	client := testAPIClient(t, func(w http.ResponseWriter, req *http.Request) {
+		t.Errorf("unexpected request %s", req.URL)
+	})
+	client.version, _ = parseServerVersion("2024.05")
+	r := NewChannelSettingsResource()
+	testConfigure(t, r, client)
+
+	for checksum, refused := range map[string]bool{"sha256": false, "sha512": true} {
+		planned := testState(t, r, map[string]interface{}{
+			"channel_label": "custom",
+			"checksum_type": checksum,
+		})
+		resp := &resource.ModifyPlanResponse{Plan: tfsdk.Plan{Schema: planned.Schema, Raw: planned.Raw}}
+		r.(resource.ResourceWithModifyPlan).ModifyPlan(ctx, resource.ModifyPlanRequest{
+			Plan: tfsdk.Plan{Schema: planned.Schema, Raw: planned.Raw},
+		}, resp)
+		if resp.Diagnostics.HasError() != refused {
+			t.Errorf("%s: expected refused to be %t, got %v", checksum, refused, resp.Diagnostics)
+		}
+	}
+}
+
+func TestAccChannelSettingsResource(t *testing.T) {
+	acctest.Test(t, acctest.TestCase{
+		PreCheck: func() {
+			testAccUyuniPreCheck(t)
+			testAccSeedChannel(t, "tfacc-settings")
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []acctest.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccChannelSettingsResourceConfig("sha256", "Created by the acceptance tests"),
+				Check: acctest.ComposeAggregateTestCheckFunc(
+					acctest.TestCheckResourceAttr("uyuni_channel_settings.test", "checksum_type", "sha256"),
+					acctest.TestCheckResourceAttr("uyuni_channel_settings.test", "arch_label", "channel-x86_64"),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: testAccChannelSettingsResourceConfig("sha512", "Updated by the acceptance tests"),
+				Check: acctest.ComposeAggregateTestCheckFunc(
+					acctest.TestCheckResourceAttr("uyuni_channel_settings.test", "checksum_type", "sha512"),
+					acctest.TestCheckResourceAttr("uyuni_channel_settings.test", "summary", "Updated by the acceptance tests"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:                         "uyuni_channel_settings.test",
+				ImportState:                          true,
+				ImportStateId:                        "tfacc-settings",
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "channel_label",
+			},
+		},
+	})
+}
+
+func testAccChannelSettingsResourceConfig(checksumType, summary string) string {
+	return fmt.Sprintf(`
+resource "uyuni_channel_settings" "test" {
+  channel_label = "tfacc-settings"
+  checksum_type = %q
+  summary       = %q
+}
+`, checksumType, summary)
+}
@@ -0,0 +1,158 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+
+	"terraform-provider-uyuni/internal/uyuni"
+	"terraform-provider-uyuni/internal/validators"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &ChannelSubscribersDataSource{}
+	_ datasource.DataSourceWithConfigure = &ChannelSubscribersDataSource{}
+)
+
+// ChannelSubscribersDataSourceModel maps the data source schema data.
+type ChannelSubscribersDataSourceModel struct {
+	ChannelLabel types.String            `tfsdk:"channel_label"`
+	SystemIDs    types.Set               `tfsdk:"system_ids"`
+	Systems      []subscribedSystemModel `tfsdk:"systems"`
+}
+
+// subscribedSystemModel maps a system subscribed to a channel.
+type subscribedSystemModel struct {
+	ID   types.Int64  `tfsdk:"id"`
+	Name types.String `tfsdk:"name"`
+}
+
+// NewChannelSubscribersDataSource is a helper function to simplify the provider implementation.
+func NewChannelSubscribersDataSource() datasource.DataSource {
+	return &ChannelSubscribersDataSource{}
+}
+
+// ChannelSubscribersDataSource is the data source implementation.
+type ChannelSubscribersDataSource struct {
+	client *uyuniClient
+}
+
+// Metadata returns the data source type name.
+func (d *ChannelSubscribersDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_channel_subscribers"
+}
+
+// Schema defines the schema for the data source.
+func (d *ChannelSubscribersDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the systems subscribed to a software channel, e.g. to check the impact of deleting " +
+			"or migrating the channel before applying.",
+		Attributes: map[string]schema.Attribute{
+			"channel_label": schema.StringAttribute{
+				Description: "Label of the channel.",
+				Required:    true,
+				Validators: []validator.String{
+					validators.ChannelLabel(),
+				},
+			},
+			"system_ids": schema.SetAttribute{
+				Description: "IDs of the subscribed systems.",
+				ElementType: types.Int64Type,
+				Computed:    true,
+			},
+			"systems": schema.ListNestedAttribute{
+				Description: "Subscribed systems ordered by name.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.Int64Attribute{
+							Description: "ID of the system.",
+							Computed:    true,
+						},
+						"name": schema.StringAttribute{
+							Description: "Name of the system.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *ChannelSubscribersDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state ChannelSubscribersDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	label := state.ChannelLabel.ValueString()
+	systems, err := apiGet[[]uyuni.SubscribedSystem](ctx, d.client, "channel/software/listSubscribedSystems?channelLabel="+url.QueryEscape(label))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Uyuni channel subscribers",
+			"Could not list systems subscribed to channel "+label+": "+err.Error(),
+		)
+		return
+	}
+	sort.Slice(systems.Result, func(i, j int) bool {
+		a, b := systems.Result[i], systems.Result[j]
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		return a.ID < b.ID
+	})
+
+	ids := make([]int64, 0, len(systems.Result))
+	state.Systems = make([]subscribedSystemModel, 0, len(systems.Result))
+	for _, system := range systems.Result {
+		ids = append(ids, int64(system.ID))
+		state.Systems = append(state.Systems, subscribedSystemModel{
+			ID:   types.Int64Value(int64(system.ID)),
+			Name: types.StringValue(system.Name),
+		})
+	}
+	state.SystemIDs, diags = types.SetValueFrom(ctx, types.Int64Type, ids)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *ChannelSubscribersDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*uyuniClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *uyuniClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
@@ -0,0 +1,78 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestChannelSubscribersDataSource(t *testing.T) {
+	ctx := context.Background()
+	client := testAPIClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/channel/software/listSubscribedSystems" || r.URL.Query().Get("channelLabel") != "sles15-sp6-updates" {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		_, _ = w.Write([]byte(`{"success": true, "result": [
+			{"id": 1000010001, "name": "web01.example.com"},
+			{"id": 1000010004, "name": "db01.example.com"}
+		]}`))
+	})
+
+	d := NewChannelSubscribersDataSource()
+	d.(datasource.DataSourceWithConfigure).Configure(ctx, datasource.ConfigureRequest{ProviderData: client}, &datasource.ConfigureResponse{})
+	var schemaResp datasource.SchemaResponse
+	d.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	values := map[string]tftypes.Value{}
+	for name, attributeType := range objectType.AttributeTypes {
+		values[name] = tftypes.NewValue(attributeType, nil)
+	}
+	values["channel_label"] = tftypes.NewValue(tftypes.String, "sles15-sp6-updates")
+	config := tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, values)}
+	resp := &datasource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: config.Raw}}
+	d.Read(ctx, datasource.ReadRequest{Config: config}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+
+	var state ChannelSubscribersDataSourceModel
+	resp.State.Get(ctx, &state)
+	if len(state.SystemIDs.Elements()) != 2 {
+		t.Errorf("expected 2 system ids, got %v", state.SystemIDs)
+	}
+	if len(state.Systems) != 2 || state.Systems[0].Name.ValueString() != "db01.example.com" || state.Systems[0].ID.ValueInt64() != 1000010004 {
+		t.Errorf("expected the systems ordered by name, got %v", state.Systems)
+	}
+}
+
+func TestAccChannelSubscribersDataSource(t *testing.T) {
+	minion := os.Getenv("UYUNI_TEST_MINION_ID")
+	channel := os.Getenv("UYUNI_TEST_MINION_BASE_CHANNEL")
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccRealServerPreCheck(t)
+			testAccSkipUnlessEnv(t, "UYUNI_TEST_MINION_ID", "a registered Salt minion")
+			testAccSkipUnlessEnv(t, "UYUNI_TEST_MINION_BASE_CHANNEL", "the base channel of the minion")
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`data "uyuni_channel_subscribers" "test" { channel_label = %q }`, channel),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckTypeSetElemAttr("data.uyuni_channel_subscribers.test", "system_ids.*", minion),
+					resource.TestCheckTypeSetElemNestedAttrs("data.uyuni_channel_subscribers.test", "systems.*", map[string]string{
+						"id": minion,
+					}),
+				),
+			},
+		},
+	})
+}
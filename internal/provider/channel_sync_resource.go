@@ -0,0 +1,389 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"terraform-provider-uyuni/internal/uyuni"
+	"terraform-provider-uyuni/internal/validators"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource              = &channelSyncResource{}
+	_ resource.ResourceWithConfigure = &channelSyncResource{}
+)
+
+// NewChannelSyncResource is a helper function to simplify the provider implementation.
+func NewChannelSyncResource() resource.Resource {
+	return &channelSyncResource{}
+}
+
+// channelSyncResource is the resource implementation.
+type channelSyncResource struct {
+	client *uyuniClient
+}
+
+// channelSyncResourceModel maps the resource schema data.
+type channelSyncResourceModel struct {
+	ID                 types.String   `tfsdk:"id"`
+	ChannelLabel       types.String   `tfsdk:"channel_label"`
+	SourceChannelLabel types.String   `tfsdk:"source_channel_label"`
+	CutoffDate         types.String   `tfsdk:"cutoff_date"`
+	InSync             types.Bool     `tfsdk:"in_sync"`
+	RegenerateMetadata types.Bool     `tfsdk:"regenerate_metadata"`
+	ServerAlias        types.String   `tfsdk:"server_alias"`
+	Org                *orgModel      `tfsdk:"org"`
+	Timeouts           timeouts.Value `tfsdk:"timeouts"`
+}
+
+// Metadata returns the resource type name.
+func (r *channelSyncResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_channel_sync"
+}
+
+// Schema defines the schema for the resource.
+func (r *channelSyncResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Keeps a cloned channel synchronized with its original up to a cutoff date. " +
+			"Errata and packages of the original published before the cutoff date are merged into the clone, " +
+			"so advancing the date, e.g. monthly, advances a frozen clone. " +
+			"Merged content is never removed, neither when the date moves back nor when the resource is destroyed.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Label of the cloned channel.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"channel_label": schema.StringAttribute{
+				Description: "Label of the cloned channel to synchronize.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validators.ChannelLabel(),
+				},
+			},
+			"source_channel_label": schema.StringAttribute{
+				Description: "Label of the original channel to merge from.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validators.ChannelLabel(),
+				},
+			},
+			"cutoff_date": schema.StringAttribute{
+				Description: "Content of the original published before this date (YYYY-MM-DD, UTC) is merged into the clone.",
+				Required:    true,
+				Validators: []validator.String{
+					validators.Date(),
+				},
+			},
+			"in_sync": schema.BoolAttribute{
+				Description: "Whether the clone holds all packages the original published before the cutoff date. " +
+					"It is false when the original received packages dated before the cutoff date after the last merge, " +
+					"in which case the next apply merges them.",
+				Computed: true,
+				Default:  booldefault.StaticBool(true),
+			},
+			"regenerate_metadata": regenerateMetadataAttribute(),
+			"server_alias":        serverAliasAttribute(),
+		},
+		Blocks: map[string]schema.Block{
+			"org": orgBlock(),
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+			}),
+		},
+	}
+}
+
+// channelSyncEpoch is the start of the merged date range, which has no
+// lower bound.
+var channelSyncEpoch = time.Unix(0, 0).UTC()
+
+// apiDate formats a date for API parameters.
+func apiDate(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
+
+// cutoffDate parses the cutoff_date attribute.
+func (m *channelSyncResourceModel) cutoffDate() (time.Time, error) {
+	return time.Parse(validators.DateLayout, m.CutoffDate.ValueString())
+}
+
+// listChannelPackagesBefore returns the ids of the packages of the channel
+// last modified before the cutoff date.
+func listChannelPackagesBefore(ctx context.Context, client *uyuniClient, label string, cutoff time.Time) ([]int64, error) {
+	query := url.Values{
+		"channelLabel": {label},
+		"startDate":    {apiDate(channelSyncEpoch)},
+		"endDate":      {apiDate(cutoff)},
+	}
+	packages, err := apiGet[[]uyuni.Package](ctx, client, "channel/software/listAllPackages?"+query.Encode())
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]int64, 0, len(packages.Result))
+	for _, pkg := range packages.Result {
+		ids = append(ids, int64(pkg.ID))
+	}
+	return ids, nil
+}
+
+// missingChannelPackages returns the ids of the packages of the source
+// channel last modified before the cutoff date which the clone lacks.
+func missingChannelPackages(ctx context.Context, client *uyuniClient, source, clone string, cutoff time.Time) ([]int64, error) {
+	wanted, err := listChannelPackagesBefore(ctx, client, source, cutoff)
+	if err != nil {
+		return nil, fmt.Errorf("could not list packages of channel %s: %w", source, err)
+	}
+	current, err := listChannelPackages(ctx, client, clone)
+	if err != nil {
+		return nil, fmt.Errorf("could not list packages of channel %s: %w", clone, err)
+	}
+	missing, _ := setDiff(current, wanted)
+	return missing, nil
+}
+
+// syncChannel merges the errata and packages the source channel published
+// before the cutoff date into the clone. channel/software/mergePackages
+// cannot be limited to a date range, so the packages are added by id
+// instead.
+func syncChannel(ctx context.Context, client *uyuniClient, source, clone string, cutoff time.Time) error {
+	errata, err := apiPost[[]uyuni.Erratum](ctx, client, "channel/software/mergeErrata", map[string]interface{}{
+		"mergeFromLabel": source,
+		"mergeToLabel":   clone,
+		"startDate":      apiDate(channelSyncEpoch),
+		"endDate":        apiDate(cutoff),
+	})
+	if err != nil {
+		return fmt.Errorf("could not merge errata: %w", err)
+	}
+
+	missing, err := missingChannelPackages(ctx, client, source, clone, cutoff)
+	if err != nil {
+		return err
+	}
+	tflog.Info(ctx, fmt.Sprintf("Channel %s: merged %d errata and adding %d packages from %s", clone, len(errata.Result), len(missing), source))
+	return changeChannelPackages(ctx, client, clone, missing, nil)
+}
+
+// Create a new resource.
+func (r *channelSyncResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan channelSyncResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	client := resourceClient(ctx, r.client, plan.ServerAlias, plan.Org, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	cutoff, err := plan.cutoffDate()
+	if err != nil {
+		resp.Diagnostics.AddError("Error synchronizing channel", "Invalid cutoff date: "+err.Error())
+		return
+	}
+	clone := plan.ChannelLabel.ValueString()
+	if err := syncChannel(ctx, client, plan.SourceChannelLabel.ValueString(), clone, cutoff); err != nil {
+		resp.Diagnostics.AddError(
+			"Error synchronizing channel",
+			"Could not synchronize channel "+clone+": "+err.Error(),
+		)
+		return
+	}
+	if err := regenerateChannelMetadata(ctx, client, plan.RegenerateMetadata, clone); err != nil {
+		resp.Diagnostics.AddError(
+			"Error synchronizing channel",
+			"Could not regenerate metadata of channel "+clone+": "+err.Error(),
+		)
+		return
+	}
+
+	plan.ID = plan.ChannelLabel
+	plan.InSync = types.BoolValue(true)
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read resource information.
+func (r *channelSyncResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Get current state
+	var state channelSyncResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := resourceClient(ctx, r.client, state.ServerAlias, state.Org, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	cutoff, err := state.cutoffDate()
+	if err != nil {
+		resp.Diagnostics.AddError("Error Reading Uyuni channel sync", "Invalid cutoff date in state: "+err.Error())
+		return
+	}
+	clone, source := state.ChannelLabel.ValueString(), state.SourceChannelLabel.ValueString()
+	current, err := listChannelPackages(ctx, client, clone)
+	if err != nil {
+		if handleNotFound(ctx, resp, err, "Channel "+clone) {
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Reading Uyuni channel sync",
+			"Could not list packages of channel "+clone+": "+err.Error(),
+		)
+		return
+	}
+	wanted, err := listChannelPackagesBefore(ctx, client, source, cutoff)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Uyuni channel sync",
+			"Could not list packages of channel "+source+": "+err.Error(),
+		)
+		return
+	}
+	missing, _ := setDiff(current, wanted)
+	if len(missing) > 0 {
+		tflog.Info(ctx, fmt.Sprintf("Channel %s lacks %d packages of %s", clone, len(missing), source))
+	}
+	state.InSync = types.BoolValue(len(missing) == 0)
+
+	state.ID = state.ChannelLabel
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update merges the content up to the new cutoff date.
+func (r *channelSyncResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Retrieve values from plan and state
+	var plan, state channelSyncResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	client := resourceClient(ctx, r.client, plan.ServerAlias, plan.Org, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	cutoff, err := plan.cutoffDate()
+	if err != nil {
+		resp.Diagnostics.AddError("Error synchronizing channel", "Invalid cutoff date: "+err.Error())
+		return
+	}
+	if previous, err := state.cutoffDate(); err == nil && cutoff.Before(previous) {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("cutoff_date"),
+			"Cutoff Date Moved Back",
+			"Content merged up to "+state.CutoffDate.ValueString()+" stays in the channel. "+
+				"Recreate the clone to drop it.",
+		)
+	}
+
+	clone := plan.ChannelLabel.ValueString()
+	if err := syncChannel(ctx, client, plan.SourceChannelLabel.ValueString(), clone, cutoff); err != nil {
+		resp.Diagnostics.AddError(
+			"Error synchronizing channel",
+			"Could not synchronize channel "+clone+": "+err.Error(),
+		)
+		return
+	}
+	if err := regenerateChannelMetadata(ctx, client, plan.RegenerateMetadata, clone); err != nil {
+		resp.Diagnostics.AddError(
+			"Error synchronizing channel",
+			"Could not regenerate metadata of channel "+clone+": "+err.Error(),
+		)
+		return
+	}
+
+	plan.InSync = types.BoolValue(true)
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete stops synchronizing the channel. The merged content stays in the
+// clone.
+func (r *channelSyncResource) Delete(ctx context.Context, _ resource.DeleteRequest, _ *resource.DeleteResponse) {
+	tflog.Info(ctx, "Removing channel sync from state, merged content stays in the channel")
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *channelSyncResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*uyuniClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *uyuniClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
@@ -0,0 +1,148 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	acctest "github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccChannelSyncResource(t *testing.T) {
+	source := os.Getenv("UYUNI_TEST_SOURCE_CHANNEL")
+	acctest.Test(t, acctest.TestCase{
+		PreCheck: func() {
+			testAccRealServerPreCheck(t)
+			testAccSkipUnlessEnv(t, "UYUNI_TEST_SOURCE_CHANNEL", "a synchronized x86_64 channel without parent")
+			testAccSeed(t, "channel/software/clone", map[string]interface{}{
+				"original_label": source,
+				"channel": map[string]interface{}{
+					"label":   "tfacc-sync",
+					"name":    "tfacc-sync",
+					"summary": "Clone of the acceptance tests",
+				},
+				"original_state": true,
+			}, "channel/software/delete", map[string]interface{}{"channelLabel": "tfacc-sync"})
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []acctest.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "uyuni_channel_sync" "test" {
+  channel_label        = "tfacc-sync"
+  source_channel_label = %q
+  cutoff_date          = "2024-01-01"
+}
+`, source),
+				Check: acctest.ComposeAggregateTestCheckFunc(
+					acctest.TestCheckResourceAttr("uyuni_channel_sync.test", "id", "tfacc-sync"),
+					acctest.TestCheckResourceAttr("uyuni_channel_sync.test", "in_sync", "true"),
+				),
+			},
+		},
+	})
+}
+
+// testChannelSyncServer serves the packages 1, 2 and 3 as dated before the
+// cutoff in the source channel, and the packages 1 and 4 in the clone. It
+// records the packages added to the clone.
+type testChannelSyncServer struct {
+	merged bool
+	added  []int64
+}
+
+func (s *testChannelSyncServer) handler(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case r.URL.Path == "/channel/software/mergeErrata":
+		s.merged = true
+		_, _ = w.Write([]byte(`{"success": true, "result": [{"id": 7, "advisory_name": "CL-SUSE-2023-1"}]}`))
+	case r.URL.Path == "/channel/software/addPackages":
+		var body struct {
+			PackageIDs []int64 `json:"packageIds"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		s.added = body.PackageIDs
+		_, _ = w.Write([]byte(`{"success": true, "result": 1}`))
+	case r.URL.Query().Get("channelLabel") == "source" && r.URL.Query().Get("endDate") == "2024-01-01T00:00:00Z":
+		testChannelPackagesServer(w, r)
+	case r.URL.Query().Get("channelLabel") == "clone":
+		_, _ = w.Write([]byte(`{"success": true, "result": [
+			{"id": 1, "name": "a", "version": "1", "release": "1", "epoch": "", "arch_label": "noarch"},
+			{"id": 4, "name": "d", "version": "1", "release": "1", "epoch": "", "arch_label": "noarch"}
+		]}`))
+	default:
+		_, _ = w.Write([]byte(`{"success": false, "message": "unexpected request ` + r.URL.String() + `"}`))
+	}
+}
+
+func testChannelSyncAttributes() map[string]interface{} {
+	return map[string]interface{}{
+		"id":                   "clone",
+		"channel_label":        "clone",
+		"source_channel_label": "source",
+		"cutoff_date":          "2024-01-01",
+		"in_sync":              true,
+	}
+}
+
+func TestChannelSyncCreateMergesUpToCutoff(t *testing.T) {
+	ctx := context.Background()
+	server := &testChannelSyncServer{}
+	r := NewChannelSyncResource()
+	testConfigure(t, r, testAPIClient(t, server.handler))
+
+	planned := testState(t, r, testChannelSyncAttributes())
+	resp := &resource.CreateResponse{State: planned}
+	r.Create(ctx, resource.CreateRequest{Plan: tfsdk.Plan{Schema: planned.Schema, Raw: planned.Raw}}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+	if !server.merged || fmt.Sprint(server.added) != "[2 3]" {
+		t.Errorf("expected errata to be merged and packages 2 and 3 to be added, got %v, %v", server.merged, server.added)
+	}
+}
+
+func TestChannelSyncReadDetectsMissingPackages(t *testing.T) {
+	ctx := context.Background()
+	server := &testChannelSyncServer{}
+
+	resp := testRead(t, NewChannelSyncResource(), testAPIClient(t, server.handler), testChannelSyncAttributes())
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+
+	var state channelSyncResourceModel
+	resp.State.Get(ctx, &state)
+	if state.InSync.ValueBool() {
+		t.Error("expected the clone to be out of sync")
+	}
+	if server.merged || server.added != nil {
+		t.Error("read must not change the clone")
+	}
+}
+
+func TestChannelSyncUpdateWarnsWhenCutoffMovesBack(t *testing.T) {
+	ctx := context.Background()
+	server := &testChannelSyncServer{}
+	r := NewChannelSyncResource()
+	testConfigure(t, r, testAPIClient(t, server.handler))
+
+	attributes := testChannelSyncAttributes()
+	attributes["cutoff_date"] = "2024-02-01"
+	prior := testState(t, r, attributes)
+	planned := testState(t, r, testChannelSyncAttributes())
+
+	resp := &resource.UpdateResponse{State: prior}
+	r.Update(ctx, resource.UpdateRequest{Plan: tfsdk.Plan{Schema: planned.Schema, Raw: planned.Raw}, State: prior}, resp)
+	if resp.Diagnostics.HasError() || resp.Diagnostics.WarningsCount() != 1 {
+		t.Fatalf("expected a single warning, got %v", resp.Diagnostics)
+	}
+	if !server.merged {
+		t.Error("expected errata to be merged")
+	}
+}
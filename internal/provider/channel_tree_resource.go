@@ -0,0 +1,497 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sync"
+
+	"terraform-provider-uyuni/internal/uyuni"
+	"terraform-provider-uyuni/internal/validators"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource              = &channelTreeResource{}
+	_ resource.ResourceWithConfigure = &channelTreeResource{}
+)
+
+// NewChannelTreeResource is a helper function to simplify the provider implementation.
+func NewChannelTreeResource() resource.Resource {
+	return &channelTreeResource{}
+}
+
+// channelTreeResource is the resource implementation.
+type channelTreeResource struct {
+	client *uyuniClient
+}
+
+// channelTreeResourceModel maps the resource schema data.
+type channelTreeResourceModel struct {
+	ID            types.String   `tfsdk:"id"`
+	SourceLabel   types.String   `tfsdk:"source_label"`
+	Prefix        types.String   `tfsdk:"prefix"`
+	OriginalState types.Bool     `tfsdk:"original_state"`
+	Children      types.Set      `tfsdk:"children"`
+	Labels        types.Map      `tfsdk:"labels"`
+	InSync        types.Bool     `tfsdk:"in_sync"`
+	OnDestroy     types.String   `tfsdk:"on_destroy"`
+	ServerAlias   types.String   `tfsdk:"server_alias"`
+	Org           *orgModel      `tfsdk:"org"`
+	Timeouts      timeouts.Value `tfsdk:"timeouts"`
+}
+
+// Metadata returns the resource type name.
+func (r *channelTreeResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_channel_tree"
+}
+
+// Schema defines the schema for the resource.
+func (r *channelTreeResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Clones a base channel together with its child channels, like spacewalk-clone-by-date does. " +
+			"Clones are labeled and named after their originals with a prefix, e.g. `dev-`, and keep the parent/child relations. " +
+			"Use uyuni_errata_clone or uyuni_channel_sync on the cloned tree to bring it up to a date.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Label of the cloned base channel.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"source_label": schema.StringAttribute{
+				Description: "Label of the base channel to clone.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validators.ChannelLabel(),
+				},
+			},
+			"prefix": schema.StringAttribute{
+				Description: "Prefix of the labels and names of the clones, e.g. `dev-`.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"original_state": schema.BoolAttribute{
+				Description: "Clone the channels in their original state, i.e. without the errata and packages released since. Defaults to false.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"children": schema.SetAttribute{
+				Description: "Labels of the child channels of the source to clone. Defaults to all of them when the tree is created. " +
+					"Clones of children removed from the set are handled according to on_destroy.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"labels": schema.MapAttribute{
+				Description: "Label of each clone by the label of its original.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"in_sync": schema.BoolAttribute{
+				Description: "Whether all clones exist. It is false when a cloned child was deleted outside Terraform, " +
+					"in which case the next apply clones it again.",
+				Computed: true,
+				Default:  booldefault.StaticBool(true),
+			},
+			"on_destroy":   onDestroyAttribute("deletes the cloned channels"),
+			"server_alias": serverAliasAttribute(),
+		},
+		Blocks: map[string]schema.Block{
+			"org": orgBlock(),
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+// cloneChannel clones the original channel, as a child of parent unless it
+// is empty.
+func cloneChannel(ctx context.Context, client *uyuniClient, original uyuni.Channel, prefix, parent string, originalState bool) error {
+	details := map[string]interface{}{
+		"name":    prefix + original.Name,
+		"label":   prefix + original.Label,
+		"summary": original.Summary,
+	}
+	if parent != "" {
+		details["parent_label"] = parent
+	}
+	_, err := apiPost[int](ctx, client, "channel/software/clone", map[string]interface{}{
+		"channelLabel":  original.Label,
+		"details":       details,
+		"originalState": originalState,
+	})
+	return err
+}
+
+// deleteChannel deletes the channel. Channels already gone are no error.
+func deleteChannel(ctx context.Context, client *uyuniClient, label string) error {
+	_, err := apiPost[int](ctx, client, "channel/software/delete", map[string]interface{}{
+		"channelLabel": label,
+	})
+	if err != nil && !isNotFoundError(err) {
+		return err
+	}
+	return nil
+}
+
+// apply clones the source and the wanted children missing in clones, and
+// deletes or orphans the clones of children no longer wanted. clones maps
+// original to clone labels and is updated as channels are cloned and deleted,
+// so that it holds the existing clones even if some calls fail.
+func (m *channelTreeResourceModel) apply(ctx context.Context, client *uyuniClient, clones map[string]string) error {
+	source := m.SourceLabel.ValueString()
+	prefix := m.Prefix.ValueString()
+	base := prefix + source
+	details, err := apiGet[uyuni.Channel](ctx, client, "channel/software/getDetails?channelLabel="+url.QueryEscape(source))
+	if err != nil {
+		return fmt.Errorf("could not read channel %s: %w", source, err)
+	}
+	children, err := apiGet[[]uyuni.Channel](ctx, client, "channel/software/listChildren?channelLabel="+url.QueryEscape(source))
+	if err != nil {
+		return fmt.Errorf("could not list children of channel %s: %w", source, err)
+	}
+	originals := map[string]uyuni.Channel{}
+	all := make([]string, 0, len(children.Result))
+	for _, child := range children.Result {
+		originals[child.Label] = child
+		all = append(all, child.Label)
+	}
+
+	wanted := all
+	if m.Children.IsUnknown() {
+		set, diags := types.SetValueFrom(ctx, types.StringType, all)
+		if diags.HasError() {
+			return fmt.Errorf("could not set children")
+		}
+		m.Children = set
+	} else if diags := m.Children.ElementsAs(ctx, &wanted, false); diags.HasError() {
+		return fmt.Errorf("invalid children")
+	}
+	for _, label := range wanted {
+		if _, ok := originals[label]; !ok {
+			return fmt.Errorf("channel %s is no child of %s", label, source)
+		}
+	}
+
+	if _, ok := clones[source]; !ok {
+		if err := cloneChannel(ctx, client, details.Result, prefix, "", m.OriginalState.ValueBool()); err != nil {
+			return fmt.Errorf("could not clone %s: %w", source, err)
+		}
+		clones[source] = base
+		tflog.Info(ctx, "Cloned channel "+source+" to "+base)
+	}
+
+	var mu sync.Mutex
+	missing := []string{}
+	for _, label := range wanted {
+		if _, ok := clones[label]; !ok {
+			missing = append(missing, label)
+		}
+	}
+	errs := runBatch(missing, func(label string) error {
+		if err := cloneChannel(ctx, client, originals[label], prefix, base, m.OriginalState.ValueBool()); err != nil {
+			return err
+		}
+		mu.Lock()
+		clones[label] = prefix + label
+		mu.Unlock()
+		return nil
+	})
+	if err := batchError(errs); err != nil {
+		return fmt.Errorf("could not clone %w", err)
+	}
+
+	keep := map[string]bool{source: true}
+	for _, label := range wanted {
+		keep[label] = true
+	}
+	removed := []string{}
+	for original := range clones {
+		if !keep[original] {
+			removed = append(removed, original)
+		}
+	}
+	errs = runBatch(removed, func(original string) error {
+		mu.Lock()
+		clone := clones[original]
+		mu.Unlock()
+		if !orphaned(m.OnDestroy) {
+			if err := deleteChannel(ctx, client, clone); err != nil {
+				return err
+			}
+		}
+		mu.Lock()
+		delete(clones, original)
+		mu.Unlock()
+		return nil
+	})
+	if err := batchError(errs); err != nil {
+		return fmt.Errorf("could not delete clones of %w", err)
+	}
+	return nil
+}
+
+// setLabels sets the computed attributes from the clones.
+func (m *channelTreeResourceModel) setLabels(ctx context.Context, clones map[string]string) error {
+	m.ID = types.StringValue(m.Prefix.ValueString() + m.SourceLabel.ValueString())
+	labels, diags := types.MapValueFrom(ctx, types.StringType, clones)
+	if diags.HasError() {
+		return fmt.Errorf("could not set labels")
+	}
+	m.Labels = labels
+	m.InSync = types.BoolValue(true)
+	return nil
+}
+
+// Create a new resource.
+func (r *channelTreeResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan channelTreeResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	client := resourceClient(ctx, r.client, plan.ServerAlias, plan.Org, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	clones := map[string]string{}
+	if err := plan.apply(ctx, client, clones); err != nil {
+		resp.Diagnostics.AddError(
+			"Error cloning channel tree",
+			"Could not clone the tree of channel "+plan.SourceLabel.ValueString()+": "+err.Error(),
+		)
+		if len(clones) == 0 {
+			return
+		}
+		// Fall through to track the clones made, which Terraform taints.
+		if plan.Children.IsUnknown() {
+			plan.Children = types.SetNull(types.StringType)
+		}
+	}
+	if err := plan.setLabels(ctx, clones); err != nil {
+		resp.Diagnostics.AddError("Error cloning channel tree", err.Error())
+		return
+	}
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read resource information.
+func (r *channelTreeResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Get current state
+	var state channelTreeResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := resourceClient(ctx, r.client, state.ServerAlias, state.Org, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	base := state.ID.ValueString()
+	tree, err := listClonedTree(ctx, client, base)
+	if err != nil {
+		if handleNotFound(ctx, resp, err, "Channel "+base) {
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Reading Uyuni channel tree",
+			"Could not read the tree of channel "+base+": "+err.Error(),
+		)
+		return
+	}
+
+	var clones map[string]string
+	resp.Diagnostics.Append(state.Labels.ElementsAs(ctx, &clones, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	existing := map[string]string{}
+	for original, clone := range clones {
+		if tree[clone] == original {
+			existing[original] = clone
+		} else {
+			tflog.Warn(ctx, "Clone "+clone+" of channel "+original+" no longer exists")
+		}
+	}
+	state.Labels, diags = types.MapValueFrom(ctx, types.StringType, existing)
+	resp.Diagnostics.Append(diags...)
+	state.InSync = types.BoolValue(len(existing) == len(clones))
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update clones missing children and deletes clones of children no longer
+// wanted.
+func (r *channelTreeResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Retrieve values from plan and state
+	var plan, state channelTreeResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	client := resourceClient(ctx, r.client, plan.ServerAlias, plan.Org, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	var clones map[string]string
+	resp.Diagnostics.Append(state.Labels.ElementsAs(ctx, &clones, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if err := plan.apply(ctx, client, clones); err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating channel tree",
+			"Could not update the tree of channel "+plan.SourceLabel.ValueString()+": "+err.Error(),
+		)
+		// Keep the clones made and the attributes of the prior state, so
+		// that the next apply retries.
+		plan.Children = state.Children
+		plan.OnDestroy = state.OnDestroy
+	}
+	if err := plan.setLabels(ctx, clones); err != nil {
+		resp.Diagnostics.AddError("Error updating channel tree", err.Error())
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete deletes the clones, children first, unless they are orphaned.
+func (r *channelTreeResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Retrieve values from state
+	var state channelTreeResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	base := state.ID.ValueString()
+	if orphaned(state.OnDestroy) {
+		tflog.Info(ctx, "Removing channel tree from state, the clones of "+base+" stay on the server")
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	client := resourceClient(ctx, r.client, state.ServerAlias, state.Org, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	var clones map[string]string
+	resp.Diagnostics.Append(state.Labels.ElementsAs(ctx, &clones, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	children := []string{}
+	for _, clone := range clones {
+		if clone != base {
+			children = append(children, clone)
+		}
+	}
+	err := batchError(runBatch(children, func(clone string) error {
+		return deleteChannel(ctx, client, clone)
+	}))
+	if err == nil {
+		err = deleteChannel(ctx, client, base)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Deleting Uyuni channel tree",
+			"Could not delete the clones of channel tree "+base+": "+err.Error(),
+		)
+		return
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *channelTreeResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*uyuniClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *uyuniClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
@@ -0,0 +1,126 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"reflect"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	acctest "github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+// testChannelTreeServer serves the base channel sles with the children pool
+// and updates, and records the clones made and the channels deleted.
+type testChannelTreeServer struct {
+	mu      sync.Mutex
+	cloned  map[string]string
+	deleted []string
+}
+
+func (s *testChannelTreeServer) handler(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var body struct {
+		ChannelLabel string            `json:"channelLabel"`
+		Details      map[string]string `json:"details"`
+	}
+	_ = json.NewDecoder(r.Body).Decode(&body)
+	switch r.URL.Path {
+	case "/channel/software/getDetails":
+		_, _ = w.Write([]byte(`{"success": true, "result": {"label": "sles", "name": "SLES", "summary": "SLES"}}`))
+	case "/channel/software/listChildren":
+		_, _ = w.Write([]byte(`{"success": true, "result": [
+			{"label": "pool", "name": "Pool", "summary": "Pool"},
+			{"label": "updates", "name": "Updates", "summary": "Updates"}
+		]}`))
+	case "/channel/software/clone":
+		s.cloned[body.Details["label"]] = body.Details["parent_label"]
+		_, _ = w.Write([]byte(`{"success": true, "result": 1}`))
+	case "/channel/software/delete":
+		s.deleted = append(s.deleted, body.ChannelLabel)
+		_, _ = w.Write([]byte(`{"success": true, "result": 1}`))
+	default:
+		_, _ = w.Write([]byte(`{"success": false, "message": "unexpected request ` + r.URL.String() + `"}`))
+	}
+}
+
+func TestChannelTreeClonesAllChildren(t *testing.T) {
+	ctx := context.Background()
+	server := &testChannelTreeServer{cloned: map[string]string{}}
+	r := NewChannelTreeResource()
+	testConfigure(t, r, testAPIClient(t, server.handler))
+
+	planned := testState(t, r, map[string]interface{}{
+		"source_label":   "sles",
+		"prefix":         "dev-",
+		"original_state": false,
+		"in_sync":        true,
+		"on_destroy":     onDestroyDelete,
+		// Children default to all children of the source.
+		"children": types.SetUnknown(types.StringType),
+	})
+	createResp := &resource.CreateResponse{State: planned}
+	r.Create(ctx, resource.CreateRequest{Plan: tfsdk.Plan{Schema: planned.Schema, Raw: planned.Raw}}, createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatal(createResp.Diagnostics)
+	}
+	want := map[string]string{"dev-sles": "", "dev-pool": "dev-sles", "dev-updates": "dev-sles"}
+	if !reflect.DeepEqual(server.cloned, want) {
+		t.Errorf("expected clones %v, got %v", want, server.cloned)
+	}
+
+	var state channelTreeResourceModel
+	createResp.State.Get(ctx, &state)
+	var labels map[string]string
+	state.Labels.ElementsAs(ctx, &labels, false)
+	if labels["updates"] != "dev-updates" || state.ID.ValueString() != "dev-sles" {
+		t.Errorf("unexpected labels %v of %s", labels, state.ID)
+	}
+
+	r.Delete(ctx, resource.DeleteRequest{State: createResp.State}, &resource.DeleteResponse{})
+	if len(server.deleted) != 3 || server.deleted[2] != "dev-sles" {
+		t.Fatalf("expected the base channel to be deleted after its children, got %v", server.deleted)
+	}
+	sort.Strings(server.deleted[:2])
+	if !reflect.DeepEqual(server.deleted[:2], []string{"dev-pool", "dev-updates"}) {
+		t.Errorf("expected the children to be deleted, got %v", server.deleted)
+	}
+}
+
+func TestAccChannelTreeResource(t *testing.T) {
+	source := os.Getenv("UYUNI_TEST_SOURCE_CHANNEL")
+	acctest.Test(t, acctest.TestCase{
+		PreCheck: func() {
+			testAccRealServerPreCheck(t)
+			testAccSkipUnlessEnv(t, "UYUNI_TEST_SOURCE_CHANNEL", "a synchronized x86_64 channel without parent")
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckGone(t, "channel/software/getDetails?channelLabel=tfacc-tree-"+source),
+		Steps: []acctest.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "uyuni_channel_tree" "test" {
+  source_label   = %q
+  prefix         = "tfacc-tree-"
+  original_state = true
+  children       = []
+}
+`, source),
+				Check: acctest.ComposeAggregateTestCheckFunc(
+					acctest.TestCheckResourceAttr("uyuni_channel_tree.test", "id", "tfacc-tree-"+source),
+					acctest.TestCheckResourceAttr("uyuni_channel_tree.test", "labels."+source, "tfacc-tree-"+source),
+					acctest.TestCheckResourceAttr("uyuni_channel_tree.test", "in_sync", "true"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,224 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+
+	"terraform-provider-uyuni/internal/uyuni"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &ChannelsDataSource{}
+	_ datasource.DataSourceWithConfigure = &ChannelsDataSource{}
+)
+
+// ChannelsDataSourceModel maps the data source schema data.
+type ChannelsDataSourceModel struct {
+	LabelRegex   types.String   `tfsdk:"label_regex"`
+	NameRegex    types.String   `tfsdk:"name_regex"`
+	ProviderName types.String   `tfsdk:"provider_name"`
+	Labels       types.Set      `tfsdk:"labels"`
+	Channels     []channelModel `tfsdk:"channels"`
+}
+
+// channelModel maps a software channel.
+type channelModel struct {
+	ID           types.Int64  `tfsdk:"id"`
+	Label        types.String `tfsdk:"label"`
+	Name         types.String `tfsdk:"name"`
+	ProviderName types.String `tfsdk:"provider_name"`
+	ArchName     types.String `tfsdk:"arch_name"`
+	Packages     types.Int64  `tfsdk:"packages"`
+	Systems      types.Int64  `tfsdk:"systems"`
+}
+
+// NewChannelsDataSource is a helper function to simplify the provider implementation.
+func NewChannelsDataSource() datasource.DataSource {
+	return &ChannelsDataSource{}
+}
+
+// ChannelsDataSource is the data source implementation.
+type ChannelsDataSource struct {
+	client *uyuniClient
+}
+
+// Metadata returns the data source type name.
+func (d *ChannelsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_channels"
+}
+
+// Schema defines the schema for the data source.
+func (d *ChannelsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the software channels the provider user can see, e.g. to reference channels managed " +
+			"outside of Terraform without hardcoding their labels.",
+		Attributes: map[string]schema.Attribute{
+			"label_regex": schema.StringAttribute{
+				Description: "Only list channels whose label matches this regular expression, in RE2 syntax.",
+				Optional:    true,
+			},
+			"name_regex": schema.StringAttribute{
+				Description: "Only list channels whose name matches this regular expression, in RE2 syntax.",
+				Optional:    true,
+			},
+			"provider_name": schema.StringAttribute{
+				Description: "Only list channels provided by this organization, e.g. `SUSE` for vendor channels " +
+					"or the name of the organization of the provider user for its own channels.",
+				Optional: true,
+			},
+			"labels": schema.SetAttribute{
+				Description: "Labels of the channels.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"channels": schema.ListNestedAttribute{
+				Description: "Channels, ordered by label.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.Int64Attribute{
+							Description: "ID of the channel.",
+							Computed:    true,
+						},
+						"label": schema.StringAttribute{
+							Description: "Label of the channel.",
+							Computed:    true,
+						},
+						"name": schema.StringAttribute{
+							Description: "Name of the channel.",
+							Computed:    true,
+						},
+						"provider_name": schema.StringAttribute{
+							Description: "Name of the organization providing the channel.",
+							Computed:    true,
+						},
+						"arch_name": schema.StringAttribute{
+							Description: "Architecture of the channel, e.g. `x86_64`.",
+							Computed:    true,
+						},
+						"packages": schema.Int64Attribute{
+							Description: "Number of packages in the channel.",
+							Computed:    true,
+						},
+						"systems": schema.Int64Attribute{
+							Description: "Number of systems subscribed to the channel.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// compileRegexFilter compiles the regular expression of the filter
+// attribute, nil if it is not set. Invalid expressions are reported on the
+// attribute.
+func compileRegexFilter(value types.String, attribute string, diags *diag.Diagnostics) (*regexp.Regexp, bool) {
+	if value.IsNull() {
+		return nil, true
+	}
+	re, err := regexp.Compile(value.ValueString())
+	if err != nil {
+		diags.AddAttributeError(path.Root(attribute), "Invalid Regular Expression", err.Error())
+		return nil, false
+	}
+	return re, true
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *ChannelsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state ChannelsDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	labelRegex, ok := compileRegexFilter(state.LabelRegex, "label_regex", &resp.Diagnostics)
+	if !ok {
+		return
+	}
+	nameRegex, ok := compileRegexFilter(state.NameRegex, "name_regex", &resp.Diagnostics)
+	if !ok {
+		return
+	}
+
+	channels, err := apiGet[[]uyuni.OrgChannel](ctx, d.client, "channel/listAllChannels")
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Uyuni channels",
+			"Could not list channels: "+err.Error(),
+		)
+		return
+	}
+
+	sort.Slice(channels.Result, func(i, j int) bool {
+		return channels.Result[i].Label < channels.Result[j].Label
+	})
+	labels := []string{}
+	state.Channels = []channelModel{}
+	for _, channel := range channels.Result {
+		if labelRegex != nil && !labelRegex.MatchString(channel.Label) {
+			continue
+		}
+		if nameRegex != nil && !nameRegex.MatchString(channel.Name) {
+			continue
+		}
+		if !state.ProviderName.IsNull() && channel.ProviderName != state.ProviderName.ValueString() {
+			continue
+		}
+		labels = append(labels, channel.Label)
+		state.Channels = append(state.Channels, channelModel{
+			ID:           types.Int64Value(int64(channel.ID)),
+			Label:        types.StringValue(channel.Label),
+			Name:         types.StringValue(channel.Name),
+			ProviderName: types.StringValue(channel.ProviderName),
+			ArchName:     types.StringValue(channel.ArchName),
+			Packages:     types.Int64Value(int64(channel.Packages)),
+			Systems:      types.Int64Value(int64(channel.Systems)),
+		})
+	}
+	state.Labels, diags = types.SetValueFrom(ctx, types.StringType, labels)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *ChannelsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*uyuniClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *uyuniClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
@@ -0,0 +1,56 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestChannelsDataSourceFiltersByLabelAndProvider(t *testing.T) {
+	resp := testDataSourceRead(t, NewChannelsDataSource(), fixtureClient(t, "2024.08"), map[string]tftypes.Value{
+		"label_regex":   tftypes.NewValue(tftypes.String, "-updates-"),
+		"provider_name": tftypes.NewValue(tftypes.String, "SUSE"),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+	var state ChannelsDataSourceModel
+	resp.State.Get(context.Background(), &state)
+	if len(state.Channels) != 1 || state.Channels[0].Label.ValueString() != "sle-product-sles15-sp6-updates-x86_64" {
+		t.Fatalf("expected the SUSE updates channel, got %v", state.Channels)
+	}
+	if state.Channels[0].ID.ValueInt64() != 102 || state.Channels[0].Systems.ValueInt64() != 12 {
+		t.Errorf("unexpected channel %v", state.Channels[0])
+	}
+}
+
+func TestChannelsDataSourceRefusesInvalidRegex(t *testing.T) {
+	resp := testDataSourceRead(t, NewChannelsDataSource(), fixtureClient(t, "2024.08"), map[string]tftypes.Value{
+		"name_regex": tftypes.NewValue(tftypes.String, "("),
+	})
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected an error")
+	}
+}
+
+func TestAccChannelsDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccUyuniPreCheck(t)
+			testAccSeedChannel(t, "tfacc-ds-channels")
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `data "uyuni_channels" "test" { label_regex = "^tfacc-ds-channels$" }`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.uyuni_channels.test", "labels.#", "1"),
+					resource.TestCheckTypeSetElemAttr("data.uyuni_channels.test", "labels.*", "tfacc-ds-channels"),
+					resource.TestCheckResourceAttr("data.uyuni_channels.test", "channels.0.name", "tfacc-ds-channels"),
+				),
+			},
+		},
+	})
+}
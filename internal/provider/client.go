@@ -0,0 +1,237 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/uyuni-project/uyuni-tools/shared/api"
+)
+
+// sessionCookieName is the cookie holding the API session.
+const sessionCookieName = "pxt-session-cookie"
+
+// uyuniClient is the API client shared by all resources and data sources.
+// Terraform runs their operations concurrently, so unlike api.HTTPClient the
+// session cookie is guarded by a lock, and an expired session is renewed by a
+// single login no matter how many requests noticed it.
+type uyuniClient struct {
+	baseURL    string
+	httpClient *http.Client
+	username   string
+	password   string
+
+	mu      sync.RWMutex
+	session *http.Cookie
+
+	// cache holds GET responses, nil disables caching.
+	cache *readCache
+
+	// pacer paces the calls to the server, shared by the clients of its
+	// organizations. Nil disables pacing.
+	pacer *pacer
+
+	// version is the server version detected at Configure, nil if unknown.
+	version *serverVersion
+
+	// defaultCustomValues are the default_custom_values of the provider,
+	// which system resources merge with their own.
+	defaultCustomValues map[string]string
+
+	// scriptPolicy is the script_policy of the provider, nil if it is not
+	// set.
+	scriptPolicy *scriptPolicy
+
+	// orgs holds the clients of the users of org blocks by login.
+	orgsMu sync.Mutex
+	orgs   map[string]*uyuniClient
+
+	// debug enables the logging of every API call.
+	debug bool
+
+	// retries is how often a request failing transiently is retried.
+	retries int
+
+	// tracer sends a span per API call, nil if tracing is not configured.
+	tracer *otlpExporter
+
+	// audit records the mutating API calls, nil if audit_log is not
+	// configured.
+	audit *auditLog
+
+	// servers holds the servers of the servers attribute of the provider,
+	// nil if it is not set.
+	servers *serverRegistry
+}
+
+// newUyuniClient creates a client for the server in conn and logs in.
+func newUyuniClient(ctx context.Context, conn *api.ConnectionDetails) (*uyuniClient, error) {
+	// Without a user api.Init only sets up the transport, the login is done
+	// here so it can be repeated when the session expires. The CA
+	// certificate is added here, api.Init only reads files and exits on
+	// errors.
+	client, err := api.Init(&api.ConnectionDetails{
+		Server:   conn.Server,
+		Insecure: conn.Insecure,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if conn.CAcert != "" {
+		pool, err := loadCACert(conn.CAcert)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CA certificate: %w", err)
+		}
+		client.Client.Transport.(*http.Transport).TLSClientConfig.RootCAs = pool
+	}
+	// Requests are bounded by the contexts of the individual operations, so
+	// that long running calls are not cut off by a global client timeout.
+	client.Client.Timeout = 0
+
+	c := &uyuniClient{
+		baseURL:    client.BaseURL,
+		httpClient: client.Client,
+		username:   conn.User,
+		password:   conn.Password,
+		cache:      newReadCache(readCacheTTL),
+		pacer:      newPacer(),
+		retries:    defaultRetries,
+	}
+	if err := c.login(ctx, nil); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// cookie returns the current session cookie.
+func (c *uyuniClient) cookie() *http.Cookie {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.session
+}
+
+// login opens a new session replacing the expired one. Requests failing
+// concurrently all pass the same expired cookie, so only the first of them
+// logs in and the others reuse its session.
+func (c *uyuniClient) login(ctx context.Context, expired *http.Cookie) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.session != expired {
+		return nil
+	}
+
+	data, err := json.Marshal(map[string]string{
+		"login":    c.username,
+		"password": c.password,
+	})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf("%s/auth/login", c.baseURL), bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	req.Header.Set("Accept", "application/json; charset=utf-8")
+
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	var response struct {
+		Success  bool   `json:"success"`
+		Messages string `json:"messages"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return fmt.Errorf("unexpected login response: %w", err)
+	}
+	if !response.Success {
+		if response.Messages != "" {
+			return errors.New(response.Messages)
+		}
+		return errors.New("login failed")
+	}
+
+	for _, cookie := range res.Cookies() {
+		if cookie.Name == sessionCookieName && cookie.MaxAge > 0 {
+			c.session = cookie
+			return nil
+		}
+	}
+	return errors.New("auth cookie not found in login response")
+}
+
+// sessionExpiredMessages are the fault messages of requests whose session
+// the server no longer knows, which some versions answer with status 500
+// instead of 401.
+var sessionExpiredMessages = []string{
+	"session expired",
+	"could not find session",
+	"invalid session",
+}
+
+// do sends a request with the session cookie. If the server rejects the
+// session, the client logs in again and retries the request once.
+func (c *uyuniClient) do(ctx context.Context, method, path string, body []byte) (*http.Response, error) {
+	cookie := c.cookie()
+	res, err := c.send(ctx, method, path, body, cookie)
+	if err != nil || !sessionExpired(res) {
+		return res, err
+	}
+	res.Body.Close()
+
+	ctx = withLogSubsystem(ctx, logSubsystemClient)
+	tflog.SubsystemDebug(ctx, logSubsystemClient, "Uyuni API session expired, logging in again")
+	if err := c.login(ctx, cookie); err != nil {
+		return nil, fmt.Errorf("could not renew the API session: %w", err)
+	}
+	return c.send(ctx, method, path, body, c.cookie())
+}
+
+// sessionExpired reports whether the server rejected the session of the
+// response. The body of failed responses is read to find the fault message
+// and replaced, so that it can be read again.
+func sessionExpired(res *http.Response) bool {
+	if res.StatusCode == http.StatusUnauthorized {
+		return true
+	}
+	if res.StatusCode < http.StatusBadRequest {
+		return false
+	}
+	data, err := io.ReadAll(res.Body)
+	res.Body.Close()
+	res.Body = io.NopCloser(bytes.NewReader(data))
+	if err != nil {
+		return false
+	}
+	msg := strings.ToLower(string(data))
+	for _, expired := range sessionExpiredMessages {
+		if strings.Contains(msg, expired) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *uyuniClient) send(ctx context.Context, method, path string, body []byte, cookie *http.Cookie) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, fmt.Sprintf("%s/%s", c.baseURL, path), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	req.Header.Set("Accept", "application/json; charset=utf-8")
+	if cookie != nil {
+		req.AddCookie(cookie)
+	}
+	return c.httpClient.Do(req)
+}
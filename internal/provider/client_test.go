@@ -0,0 +1,166 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/uyuni-project/uyuni-tools/shared/api"
+)
+
+// testSessionServer imitates the session handling of the API: every login
+// opens a new session, and expire invalidates the current one.
+type testSessionServer struct {
+	*httptest.Server
+
+	logins  atomic.Int32
+	mu      sync.Mutex
+	session string
+}
+
+func newTestSessionServer(t *testing.T) *testSessionServer {
+	s := &testSessionServer{}
+	s.Server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/auth/login") {
+			s.login(w)
+			return
+		}
+		cookie, err := r.Cookie(sessionCookieName)
+		if err != nil || !s.valid(cookie.Value) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`{"success": true, "result": 1}`))
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *testSessionServer) login(w http.ResponseWriter) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.session = fmt.Sprintf("session-%d", s.logins.Add(1))
+	http.SetCookie(w, &http.Cookie{Name: sessionCookieName, Value: s.session, MaxAge: 3600})
+	_, _ = w.Write([]byte(`{"success": true}`))
+}
+
+func (s *testSessionServer) valid(session string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return session == s.session
+}
+
+func (s *testSessionServer) expire() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.session = ""
+}
+
+// client logs in to the test server, which answers below any API root path.
+func (s *testSessionServer) client(t *testing.T) *uyuniClient {
+	server, err := url.Parse(s.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, err := newUyuniClient(context.Background(), &api.ConnectionDetails{
+		Server:   server.Host,
+		User:     "admin",
+		Password: "secret",
+		Insecure: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+func TestClientConcurrentRequests(t *testing.T) {
+	server := newTestSessionServer(t)
+	client := server.client(t)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := apiGet[int](context.Background(), client, "api/getVersion"); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if logins := server.logins.Load(); logins != 1 {
+		t.Errorf("expected a single login, got %d", logins)
+	}
+}
+
+func TestClientRenewsExpiredSessionOnce(t *testing.T) {
+	server := newTestSessionServer(t)
+	client := server.client(t)
+	server.expire()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := apiGet[int](context.Background(), client, "api/getVersion"); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if logins := server.logins.Load(); logins != 2 {
+		t.Errorf("expected the expired session to be renewed by one login, got %d logins", logins)
+	}
+}
+
+func TestClientLoginFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"success": false, "messages": "Either the password or username is incorrect."}`))
+	}))
+	defer server.Close()
+
+	client := &uyuniClient{baseURL: server.URL, httpClient: server.Client()}
+	err := client.login(context.Background(), nil)
+	if err == nil || err.Error() != "Either the password or username is incorrect." {
+		t.Errorf("got %v", err)
+	}
+}
+
+func TestClientRenewsSessionOnExpiredFault(t *testing.T) {
+	var logins atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/auth/login") {
+			http.SetCookie(w, &http.Cookie{Name: sessionCookieName, Value: fmt.Sprintf("session-%d", logins.Add(1)), MaxAge: 3600})
+			_, _ = w.Write([]byte(`{"success": true}`))
+			return
+		}
+		if cookie, err := r.Cookie(sessionCookieName); err != nil || cookie.Value != "session-2" {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(`{"success": false, "message": "Could not find session with id 1x2"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"success": true, "result": 1}`))
+	}))
+	defer server.Close()
+
+	client := &uyuniClient{baseURL: server.URL, httpClient: server.Client()}
+	if err := client.login(context.Background(), nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := apiGet[int](context.Background(), client, "api/getVersion"); err != nil {
+		t.Fatal(err)
+	}
+	if logins := logins.Load(); logins != 2 {
+		t.Errorf("expected the expired session to be renewed, got %d logins", logins)
+	}
+}
@@ -0,0 +1,301 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"terraform-provider-uyuni/internal/uyuni"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource              = &clmBuildResource{}
+	_ resource.ResourceWithConfigure = &clmBuildResource{}
+)
+
+// clmBuildPollInterval is how often a build is checked for completion. It is
+// a variable so that tests do not have to wait.
+var clmBuildPollInterval = 10 * time.Second
+
+// Statuses of content lifecycle environments while and after they are built.
+const (
+	clmStatusBuilding           = "building"
+	clmStatusGeneratingRepodata = "generating_repodata"
+	clmStatusFailed             = "failed"
+)
+
+// NewCLMBuildResource is a helper function to simplify the provider implementation.
+func NewCLMBuildResource() resource.Resource {
+	return &clmBuildResource{}
+}
+
+// clmBuildResource is the resource implementation.
+type clmBuildResource struct {
+	client *uyuniClient
+}
+
+// clmBuildResourceModel maps the resource schema data.
+type clmBuildResourceModel struct {
+	ID               types.String   `tfsdk:"id"`
+	ProjectLabel     types.String   `tfsdk:"project_label"`
+	Message          types.String   `tfsdk:"message"`
+	Wait             types.Bool     `tfsdk:"wait"`
+	Triggers         types.Map      `tfsdk:"triggers"`
+	EnvironmentLabel types.String   `tfsdk:"environment_label"`
+	Version          types.Int64    `tfsdk:"version"`
+	Status           types.String   `tfsdk:"status"`
+	ServerAlias      types.String   `tfsdk:"server_alias"`
+	Org              *orgModel      `tfsdk:"org"`
+	Timeouts         timeouts.Value `tfsdk:"timeouts"`
+}
+
+// Metadata returns the resource type name.
+func (r *clmBuildResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_clm_build"
+}
+
+// Schema defines the schema for the resource.
+func (r *clmBuildResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Builds a content lifecycle management project into its first environment when created, and waits for " +
+			"the build. Change triggers, e.g. to the IDs of the sources and filters of the project, to build it again.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Project label and version built, separated by a colon.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"project_label": schema.StringAttribute{
+				Description: "Label of the project.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"message": schema.StringAttribute{
+				Description: "Message describing the build in the history of the project.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(""),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"wait": schema.BoolAttribute{
+				Description: "Wait until the build finished. Defaults to true.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"triggers": schema.MapAttribute{
+				Description: "Arbitrary values which build the project again when they change.",
+				ElementType: types.StringType,
+				Optional:    true,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"environment_label": schema.StringAttribute{
+				Description: "Label of the first environment of the project, which the build went to.",
+				Computed:    true,
+			},
+			"version": schema.Int64Attribute{
+				Description: "Version of the project in the first environment after the build.",
+				Computed:    true,
+			},
+			"status": schema.StringAttribute{
+				Description: "Status of the first environment after the build, `building` or `generating_repodata` if not waited for.",
+				Computed:    true,
+			},
+			"server_alias": serverAliasAttribute(),
+		},
+		Blocks: map[string]schema.Block{
+			"org": orgBlock(),
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+			}),
+		},
+	}
+}
+
+// clmBuildDone reports whether the environment finished building. It returns
+// an error if the build failed.
+func clmBuildDone(environment *uyuni.ContentEnvironment) (bool, error) {
+	switch environment.Status {
+	case clmStatusBuilding, clmStatusGeneratingRepodata:
+		return false, nil
+	case clmStatusFailed:
+		return false, fmt.Errorf("building version %d of environment %s failed", environment.Version, environment.Label)
+	}
+	return true, nil
+}
+
+// waitForCLMBuild waits until the environment of the project finished
+// building and returns it, or ctx is done first.
+func waitForCLMBuild(ctx context.Context, client *uyuniClient, project, label string) (*uyuni.ContentEnvironment, error) {
+	for {
+		// The status changes without writes, which would refresh the cache.
+		client.cache.invalidate()
+		environment, err := lookupEnvironment(ctx, client, project, label)
+		if ctx.Err() != nil {
+			return nil, fmt.Errorf("environment %s did not finish building: %w", label, ctx.Err())
+		}
+		if err != nil {
+			return nil, err
+		}
+		done, err := clmBuildDone(environment)
+		if err != nil || done {
+			return environment, err
+		}
+
+		tflog.Debug(ctx, fmt.Sprintf("Waiting for environment %s of content lifecycle project %s, status %s", label, project, environment.Status))
+		select {
+		case <-ctx.Done():
+		case <-time.After(clmBuildPollInterval):
+		}
+	}
+}
+
+// Create builds the project and waits for the build.
+func (r *clmBuildResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan clmBuildResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := resourceClient(ctx, r.client, plan.ServerAlias, plan.Org, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	label := plan.ProjectLabel.ValueString()
+	project, err := apiGet[uyuni.ContentProject](ctx, client, "contentmanagement/lookupProject?projectLabel="+url.QueryEscape(label))
+	if err == nil && project.Result.FirstEnvironment == "" {
+		err = fmt.Errorf("the project has no environment to build into")
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error building content lifecycle project",
+			"Could not build content lifecycle project "+label+": "+err.Error(),
+		)
+		return
+	}
+	envLabel := project.Result.FirstEnvironment
+
+	tflog.Info(ctx, "About to build content lifecycle project "+label)
+	_, err = apiPost[int](ctx, client, "contentmanagement/buildProject", map[string]interface{}{
+		"projectLabel": label,
+		"message":      plan.Message.ValueString(),
+	})
+	var environment *uyuni.ContentEnvironment
+	if err == nil {
+		if plan.Wait.ValueBool() {
+			environment, err = waitForCLMBuild(ctx, client, label, envLabel)
+		} else {
+			environment, err = lookupEnvironment(ctx, client, label, envLabel)
+		}
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error building content lifecycle project",
+			"Could not build content lifecycle project "+label+": "+err.Error(),
+		)
+		return
+	}
+	tflog.Info(ctx, fmt.Sprintf("Built version %d of content lifecycle project %s", environment.Version, label))
+
+	plan.ID = types.StringValue(fmt.Sprintf("%s%s%d", label, importIDSeparator, environment.Version))
+	plan.EnvironmentLabel = types.StringValue(envLabel)
+	plan.Version = types.Int64Value(int64(environment.Version))
+	plan.Status = types.StringValue(environment.Status)
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read keeps the state, a build has no lasting object on the server apart
+// from the channels of the environments, which later builds replace.
+func (r *clmBuildResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state clmBuildResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update only changes timeouts, all other changes build the project again.
+func (r *clmBuildResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan clmBuildResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete removes the build from state. The built channels stay.
+func (r *clmBuildResource) Delete(ctx context.Context, _ resource.DeleteRequest, _ *resource.DeleteResponse) {
+	tflog.Info(ctx, "Removing content lifecycle build from state, the built channels are not changed")
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *clmBuildResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*uyuniClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *uyuniClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
@@ -0,0 +1,123 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	acctest "github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestCLMBuildWaitsForFirstEnvironment(t *testing.T) {
+	clmBuildPollInterval = time.Millisecond
+	t.Cleanup(func() { clmBuildPollInterval = 10 * time.Second })
+
+	for _, test := range []struct {
+		statuses []string
+		err      string
+	}{
+		{statuses: []string{"building", "generating_repodata", "built"}},
+		{statuses: []string{"building", "failed"}, err: "building version 5 of environment dev failed"},
+	} {
+		t.Run(test.statuses[len(test.statuses)-1], func(t *testing.T) {
+			ctx := context.Background()
+			built := false
+			lookups := 0
+			r := NewCLMBuildResource()
+			testConfigure(t, r, testAPIClient(t, func(w http.ResponseWriter, req *http.Request) {
+				switch req.URL.Path {
+				case "/contentmanagement/lookupProject":
+					_, _ = w.Write([]byte(`{"success": true, "result": {"id": 3, "label": "sles15-sp6", "firstEnvironment": "dev"}}`))
+				case "/contentmanagement/buildProject":
+					built = true
+					_, _ = w.Write([]byte(`{"success": true, "result": 1}`))
+				case "/contentmanagement/lookupEnvironment":
+					status := test.statuses[min(lookups, len(test.statuses)-1)]
+					lookups++
+					_, _ = w.Write([]byte(`{"success": true, "result": {"id": 6, "label": "dev", "version": 5, "status": "` + status + `"}}`))
+				}
+			}))
+
+			planned := testState(t, r, map[string]interface{}{
+				"project_label": "sles15-sp6",
+				"message":       "",
+				"wait":          true,
+			})
+			resp := &resource.CreateResponse{State: planned}
+			r.Create(ctx, resource.CreateRequest{Plan: tfsdk.Plan{Schema: planned.Schema, Raw: planned.Raw}}, resp)
+			if !built || lookups != len(test.statuses) {
+				t.Errorf("expected a build and %d lookups, got %t and %d", len(test.statuses), built, lookups)
+			}
+			if test.err != "" {
+				if !resp.Diagnostics.HasError() || !strings.Contains(resp.Diagnostics[0].Detail(), test.err) {
+					t.Errorf("expected %q, got %v", test.err, resp.Diagnostics)
+				}
+				return
+			}
+			if resp.Diagnostics.HasError() {
+				t.Fatal(resp.Diagnostics)
+			}
+			var state clmBuildResourceModel
+			resp.State.Get(ctx, &state)
+			if state.ID.ValueString() != "sles15-sp6:5" || state.EnvironmentLabel.ValueString() != "dev" || state.Status.ValueString() != "built" {
+				t.Errorf("unexpected state %v", state)
+			}
+		})
+	}
+}
+
+func TestAccCLMBuildResource(t *testing.T) {
+	source := os.Getenv("UYUNI_TEST_SOURCE_CHANNEL")
+	acctest.Test(t, acctest.TestCase{
+		PreCheck: func() {
+			testAccRealServerPreCheck(t)
+			testAccSkipUnlessEnv(t, "UYUNI_TEST_SOURCE_CHANNEL", "a synchronized x86_64 channel without parent")
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []acctest.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "uyuni_clm_project" "test" {
+  label               = "tfacc-clm-build"
+  name                = "tfacc-clm-build"
+  deletion_protection = false
+}
+
+resource "uyuni_clm_source" "test" {
+  project_label = uyuni_clm_project.test.label
+  channel_label = %q
+}
+
+resource "uyuni_clm_environment" "test" {
+  project_label = uyuni_clm_project.test.label
+  label         = "dev"
+  name          = "Development"
+}
+
+resource "uyuni_clm_build" "test" {
+  project_label = uyuni_clm_project.test.label
+  message       = "Built by the acceptance tests"
+
+  triggers = {
+    source = uyuni_clm_source.test.id
+  }
+
+  depends_on = [uyuni_clm_environment.test]
+}
+`, source),
+				Check: acctest.ComposeAggregateTestCheckFunc(
+					acctest.TestCheckResourceAttr("uyuni_clm_build.test", "id", "tfacc-clm-build:1"),
+					acctest.TestCheckResourceAttr("uyuni_clm_build.test", "environment_label", "dev"),
+					acctest.TestCheckResourceAttr("uyuni_clm_build.test", "version", "1"),
+					acctest.TestCheckResourceAttr("uyuni_clm_build.test", "status", "built"),
+				),
+			},
+		},
+	})
+}
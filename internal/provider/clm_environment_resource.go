@@ -0,0 +1,359 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"terraform-provider-uyuni/internal/uyuni"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &clmEnvironmentResource{}
+	_ resource.ResourceWithConfigure   = &clmEnvironmentResource{}
+	_ resource.ResourceWithImportState = &clmEnvironmentResource{}
+)
+
+// NewCLMEnvironmentResource is a helper function to simplify the provider implementation.
+func NewCLMEnvironmentResource() resource.Resource {
+	return &clmEnvironmentResource{}
+}
+
+// clmEnvironmentResource is the resource implementation.
+type clmEnvironmentResource struct {
+	client *uyuniClient
+}
+
+// clmEnvironmentResourceModel maps the resource schema data.
+type clmEnvironmentResourceModel struct {
+	ID               types.String   `tfsdk:"id"`
+	ProjectLabel     types.String   `tfsdk:"project_label"`
+	Label            types.String   `tfsdk:"label"`
+	PredecessorLabel types.String   `tfsdk:"predecessor_label"`
+	Name             types.String   `tfsdk:"name"`
+	Description      types.String   `tfsdk:"description"`
+	EnvironmentID    types.Int64    `tfsdk:"environment_id"`
+	Version          types.Int64    `tfsdk:"version"`
+	Status           types.String   `tfsdk:"status"`
+	ServerAlias      types.String   `tfsdk:"server_alias"`
+	Org              *orgModel      `tfsdk:"org"`
+	Timeouts         timeouts.Value `tfsdk:"timeouts"`
+}
+
+// Metadata returns the resource type name.
+func (r *clmEnvironmentResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_clm_environment"
+}
+
+// Schema defines the schema for the resource.
+func (r *clmEnvironmentResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages an environment of a content lifecycle management project, e.g. dev, test or prod. Builds " +
+			"go to the first environment and are promoted from each environment to the next. Removing an environment " +
+			"removes the channels built into it.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Project label and environment label, separated by a colon.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"project_label": schema.StringAttribute{
+				Description: "Label of the project.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"label": schema.StringAttribute{
+				Description: "Label of the environment, which is part of the labels of the channels built into it.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"predecessor_label": schema.StringAttribute{
+				Description: "Label of the environment content is promoted from into this one. Unset for the first environment. " +
+					"Reference the predecessor resource so that environments are created in order.",
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "Name of the environment.",
+				Required:    true,
+			},
+			"description": schema.StringAttribute{
+				Description: "Description of the environment.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(""),
+			},
+			"environment_id": schema.Int64Attribute{
+				Description: "ID of the environment.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"version": schema.Int64Attribute{
+				Description: "Version of the project built or promoted into the environment, 0 if none yet.",
+				Computed:    true,
+			},
+			"status": schema.StringAttribute{
+				Description: "Status of the environment, e.g. `new`, `building`, `generating_repodata` or `built`.",
+				Computed:    true,
+			},
+			"server_alias": serverAliasAttribute(),
+		},
+		Blocks: map[string]schema.Block{
+			"org": orgBlock(),
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+// setFromEnvironment sets the computed attributes from the environment.
+func (m *clmEnvironmentResourceModel) setFromEnvironment(environment *uyuni.ContentEnvironment) {
+	m.ID = types.StringValue(m.ProjectLabel.ValueString() + importIDSeparator + m.Label.ValueString())
+	m.EnvironmentID = types.Int64Value(int64(environment.ID))
+	m.Version = types.Int64Value(int64(environment.Version))
+	m.Status = types.StringValue(environment.Status)
+}
+
+// lookupEnvironment returns the environment of the project.
+func lookupEnvironment(ctx context.Context, client *uyuniClient, project, label string) (*uyuni.ContentEnvironment, error) {
+	environment, err := apiGet[uyuni.ContentEnvironment](ctx, client, "contentmanagement/lookupEnvironment?projectLabel="+
+		url.QueryEscape(project)+"&envLabel="+url.QueryEscape(label))
+	if err != nil {
+		return nil, err
+	}
+	return &environment.Result, nil
+}
+
+// Create a new resource.
+func (r *clmEnvironmentResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan clmEnvironmentResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	client := resourceClient(ctx, r.client, plan.ServerAlias, plan.Org, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	project, label := plan.ProjectLabel.ValueString(), plan.Label.ValueString()
+	tflog.Info(ctx, "About to create environment "+label+" of content lifecycle project "+project)
+
+	environment, err := apiPost[uyuni.ContentEnvironment](ctx, client, "contentmanagement/createEnvironment", map[string]interface{}{
+		"projectLabel":     project,
+		"predecessorLabel": plan.PredecessorLabel.ValueString(),
+		"envLabel":         label,
+		"name":             plan.Name.ValueString(),
+		"description":      plan.Description.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating content lifecycle environment",
+			"Could not create environment "+label+" of content lifecycle project "+project+": "+err.Error(),
+		)
+		return
+	}
+	plan.setFromEnvironment(&environment.Result)
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read resource information.
+func (r *clmEnvironmentResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Get current state
+	var state clmEnvironmentResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := resourceClient(ctx, r.client, state.ServerAlias, state.Org, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	project, label := state.ProjectLabel.ValueString(), state.Label.ValueString()
+	environment, err := lookupEnvironment(ctx, client, project, label)
+	if err != nil {
+		if handleNotFound(ctx, resp, err, "Environment "+label+" of content lifecycle project "+project) {
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Reading Uyuni content lifecycle environment",
+			"Could not read environment "+label+" of content lifecycle project "+project+": "+err.Error(),
+		)
+		return
+	}
+	state.Name = types.StringValue(environment.Name)
+	state.Description = types.StringValue(environment.Description)
+	state.PredecessorLabel = nonEmptyString(environment.PreviousEnvironmentLabel)
+	state.setFromEnvironment(environment)
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *clmEnvironmentResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Retrieve values from plan
+	var plan clmEnvironmentResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	client := resourceClient(ctx, r.client, plan.ServerAlias, plan.Org, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	project, label := plan.ProjectLabel.ValueString(), plan.Label.ValueString()
+	environment, err := apiPost[uyuni.ContentEnvironment](ctx, client, "contentmanagement/updateEnvironment", map[string]interface{}{
+		"projectLabel": project,
+		"envLabel":     label,
+		"props": map[string]interface{}{
+			"name":        plan.Name.ValueString(),
+			"description": plan.Description.ValueString(),
+		},
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating content lifecycle environment",
+			"Could not update environment "+label+" of content lifecycle project "+project+": "+err.Error(),
+		)
+		return
+	}
+	plan.setFromEnvironment(&environment.Result)
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete removes the environment with the channels built into it.
+func (r *clmEnvironmentResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Retrieve values from state
+	var state clmEnvironmentResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	client := resourceClient(ctx, r.client, state.ServerAlias, state.Org, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	project, label := state.ProjectLabel.ValueString(), state.Label.ValueString()
+	_, err := apiPost[int](ctx, client, "contentmanagement/removeEnvironment", map[string]interface{}{
+		"projectLabel": project,
+		"envLabel":     label,
+	})
+	if err != nil && !isNotFoundError(err) {
+		resp.Diagnostics.AddError(
+			"Error Deleting Uyuni content lifecycle environment",
+			"Could not delete environment "+label+" of content lifecycle project "+project+": "+err.Error(),
+		)
+		return
+	}
+}
+
+// ImportState imports an environment by the project label and its label,
+// e.g. "sles15-sp6:test".
+func (r *clmEnvironmentResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts, err := parseImportID(req.ID, "project_label", "label")
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Import ID", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project_label"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("label"), parts[1])...)
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *clmEnvironmentResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*uyuniClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *uyuniClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
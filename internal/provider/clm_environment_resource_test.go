@@ -0,0 +1,106 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	acctest "github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestCLMEnvironmentFirstHasNoPredecessor(t *testing.T) {
+	ctx := context.Background()
+	var created map[string]interface{}
+	r := NewCLMEnvironmentResource()
+	client := testAPIClient(t, func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == "/contentmanagement/createEnvironment" {
+			_ = json.NewDecoder(req.Body).Decode(&created)
+		}
+		_, _ = w.Write([]byte(`{"success": true, "result": {"id": 6, "label": "dev", "name": "Development",
+			"version": 0, "status": "new", "contentProjectLabel": "sles15-sp6", "nextEnvironmentLabel": "test"}}`))
+	})
+	testConfigure(t, r, client)
+
+	planned := testState(t, r, map[string]interface{}{
+		"project_label": "sles15-sp6",
+		"label":         "dev",
+		"name":          "Development",
+		"description":   "",
+	})
+	resp := &resource.CreateResponse{State: planned}
+	r.Create(ctx, resource.CreateRequest{Plan: tfsdk.Plan{Schema: planned.Schema, Raw: planned.Raw}}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+	if created["predecessorLabel"] != "" || created["envLabel"] != "dev" {
+		t.Errorf("unexpected request %v", created)
+	}
+
+	read := testRead(t, r, client, map[string]interface{}{"project_label": "sles15-sp6", "label": "dev"})
+	if read.Diagnostics.HasError() {
+		t.Fatal(read.Diagnostics)
+	}
+	var state clmEnvironmentResourceModel
+	read.State.Get(ctx, &state)
+	if !state.PredecessorLabel.IsNull() || state.ID.ValueString() != "sles15-sp6:dev" || state.Status.ValueString() != "new" {
+		t.Errorf("unexpected state %v", state)
+	}
+}
+
+func TestAccCLMEnvironmentResource(t *testing.T) {
+	acctest.Test(t, acctest.TestCase{
+		PreCheck:                 func() { testAccRealServerPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckGone(t, "contentmanagement/lookupEnvironment?projectLabel=tfacc-clm-env&envLabel=dev"),
+		Steps: []acctest.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccCLMEnvironmentResourceConfig("Development"),
+				Check: acctest.ComposeAggregateTestCheckFunc(
+					acctest.TestCheckResourceAttr("uyuni_clm_environment.dev", "label", "dev"),
+					acctest.TestCheckResourceAttr("uyuni_clm_environment.test", "predecessor_label", "dev"),
+					acctest.TestCheckResourceAttrSet("uyuni_clm_environment.dev", "environment_id"),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: testAccCLMEnvironmentResourceConfig("Development renamed"),
+				Check:  acctest.TestCheckResourceAttr("uyuni_clm_environment.dev", "name", "Development renamed"),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "uyuni_clm_environment.test",
+				ImportState:       true,
+				ImportStateId:     "tfacc-clm-env:test",
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCLMEnvironmentResourceConfig(name string) string {
+	return fmt.Sprintf(`
+resource "uyuni_clm_project" "test" {
+  label               = "tfacc-clm-env"
+  name                = "tfacc-clm-env"
+  deletion_protection = false
+}
+
+resource "uyuni_clm_environment" "dev" {
+  project_label = uyuni_clm_project.test.label
+  label         = "dev"
+  name          = %q
+}
+
+resource "uyuni_clm_environment" "test" {
+  project_label     = uyuni_clm_project.test.label
+  label             = "test"
+  name              = "Test"
+  predecessor_label = uyuni_clm_environment.dev.label
+}
+`, name)
+}
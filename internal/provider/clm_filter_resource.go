@@ -0,0 +1,343 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"terraform-provider-uyuni/internal/uyuni"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &clmFilterResource{}
+	_ resource.ResourceWithConfigure   = &clmFilterResource{}
+	_ resource.ResourceWithImportState = &clmFilterResource{}
+)
+
+// NewCLMFilterResource is a helper function to simplify the provider implementation.
+func NewCLMFilterResource() resource.Resource {
+	return &clmFilterResource{}
+}
+
+// clmFilterResource is the resource implementation.
+type clmFilterResource struct {
+	client *uyuniClient
+}
+
+// clmFilterResourceModel maps the resource schema data.
+type clmFilterResourceModel struct {
+	ID          types.String   `tfsdk:"id"`
+	FilterID    types.Int64    `tfsdk:"filter_id"`
+	Name        types.String   `tfsdk:"name"`
+	Rule        types.String   `tfsdk:"rule"`
+	EntityType  types.String   `tfsdk:"entity_type"`
+	Matcher     types.String   `tfsdk:"matcher"`
+	Field       types.String   `tfsdk:"field"`
+	Value       types.String   `tfsdk:"value"`
+	ServerAlias types.String   `tfsdk:"server_alias"`
+	Org         *orgModel      `tfsdk:"org"`
+	Timeouts    timeouts.Value `tfsdk:"timeouts"`
+}
+
+// Metadata returns the resource type name.
+func (r *clmFilterResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_clm_filter"
+}
+
+// Schema defines the schema for the resource.
+func (r *clmFilterResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a content lifecycle management filter, which allows or denies packages, errata or modules " +
+			"when projects are built. Attach it to projects with the filter_ids of uyuni_clm_project.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "ID of the filter.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"filter_id": schema.Int64Attribute{
+				Description: "ID of the filter.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "Name of the filter.",
+				Required:    true,
+			},
+			"rule": schema.StringAttribute{
+				Description: "Whether matching content is `allow`ed or `deny`ed.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("allow", "deny"),
+				},
+			},
+			"entity_type": schema.StringAttribute{
+				Description: "Type of content the filter matches, e.g. `package`, `erratum`, `module` or `ptf`.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"matcher": schema.StringAttribute{
+				Description: "How the field is compared with the value, e.g. `contains`, `equals`, `matches` or `greatereq`.",
+				Required:    true,
+			},
+			"field": schema.StringAttribute{
+				Description: "Field of the content the value is compared with, e.g. `name`, `nevr`, `advisory_type` or `issue_date`.",
+				Required:    true,
+			},
+			"value": schema.StringAttribute{
+				Description: "Value the field is compared with.",
+				Required:    true,
+			},
+			"server_alias": serverAliasAttribute(),
+		},
+		Blocks: map[string]schema.Block{
+			"org": orgBlock(),
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+// criteria returns the criteria of the filter as the API expects them.
+func (m *clmFilterResourceModel) criteria() map[string]interface{} {
+	return map[string]interface{}{
+		"matcher": m.Matcher.ValueString(),
+		"field":   m.Field.ValueString(),
+		"value":   m.Value.ValueString(),
+	}
+}
+
+// setFromFilter sets the model from the filter.
+func (m *clmFilterResourceModel) setFromFilter(filter *uyuni.ContentFilter) {
+	m.ID = types.StringValue(strconv.Itoa(filter.ID))
+	m.FilterID = types.Int64Value(int64(filter.ID))
+	m.Name = types.StringValue(filter.Name)
+	m.Rule = types.StringValue(filter.Rule)
+	m.EntityType = types.StringValue(filter.EntityType)
+	m.Matcher = types.StringValue(filter.Criteria.Matcher)
+	m.Field = types.StringValue(filter.Criteria.Field)
+	m.Value = types.StringValue(filter.Criteria.Value)
+}
+
+// Create a new resource.
+func (r *clmFilterResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan clmFilterResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	client := resourceClient(ctx, r.client, plan.ServerAlias, plan.Org, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	name := plan.Name.ValueString()
+	tflog.Info(ctx, "About to create content lifecycle filter "+name)
+
+	filter, err := apiPost[uyuni.ContentFilter](ctx, client, "contentmanagement/createFilter", map[string]interface{}{
+		"name":       name,
+		"rule":       plan.Rule.ValueString(),
+		"entityType": plan.EntityType.ValueString(),
+		"criteria":   plan.criteria(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating content lifecycle filter",
+			"Could not create content lifecycle filter "+name+": "+err.Error(),
+		)
+		return
+	}
+	plan.ID = types.StringValue(strconv.Itoa(filter.Result.ID))
+	plan.FilterID = types.Int64Value(int64(filter.Result.ID))
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read resource information.
+func (r *clmFilterResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Get current state
+	var state clmFilterResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := resourceClient(ctx, r.client, state.ServerAlias, state.Org, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	id := state.FilterID.ValueInt64()
+	filter, err := apiGet[uyuni.ContentFilter](ctx, client, fmt.Sprintf("contentmanagement/lookupFilterById?filterId=%d", id))
+	if err != nil {
+		if handleNotFound(ctx, resp, err, fmt.Sprintf("Content lifecycle filter %d", id)) {
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Reading Uyuni content lifecycle filter",
+			fmt.Sprintf("Could not read content lifecycle filter %d: %s", id, err.Error()),
+		)
+		return
+	}
+	state.setFromFilter(&filter.Result)
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *clmFilterResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Retrieve values from plan
+	var plan clmFilterResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	client := resourceClient(ctx, r.client, plan.ServerAlias, plan.Org, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	id := plan.FilterID.ValueInt64()
+	_, err := apiPost[uyuni.ContentFilter](ctx, client, "contentmanagement/updateFilter", map[string]interface{}{
+		"filterId": id,
+		"name":     plan.Name.ValueString(),
+		"rule":     plan.Rule.ValueString(),
+		"criteria": plan.criteria(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating content lifecycle filter",
+			fmt.Sprintf("Could not update content lifecycle filter %d: %s", id, err.Error()),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete removes the filter. The server refuses to remove filters which are
+// still attached to projects.
+func (r *clmFilterResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Retrieve values from state
+	var state clmFilterResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	client := resourceClient(ctx, r.client, state.ServerAlias, state.Org, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	id := state.FilterID.ValueInt64()
+	_, err := apiPost[int](ctx, client, "contentmanagement/removeFilter", map[string]interface{}{
+		"filterId": id,
+	})
+	if err != nil && !isNotFoundError(err) {
+		resp.Diagnostics.AddError(
+			"Error Deleting Uyuni content lifecycle filter",
+			fmt.Sprintf("Could not delete content lifecycle filter %d: %s", id, err.Error()),
+		)
+		return
+	}
+}
+
+// ImportState imports a filter by its ID.
+func (r *clmFilterResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id, err := parseImportInt64("filter_id", req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Import ID", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("filter_id"), id)...)
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *clmFilterResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*uyuniClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *uyuniClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
@@ -0,0 +1,88 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	acctest "github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestCLMFilterCreateSendsCriteria(t *testing.T) {
+	ctx := context.Background()
+	var created map[string]interface{}
+	r := NewCLMFilterResource()
+	testConfigure(t, r, testAPIClient(t, func(w http.ResponseWriter, req *http.Request) {
+		_ = json.NewDecoder(req.Body).Decode(&created)
+		_, _ = w.Write([]byte(`{"success": true, "result": {"id": 12, "name": "no-kernel-rt"}}`))
+	}))
+
+	planned := testState(t, r, map[string]interface{}{
+		"name":        "no-kernel-rt",
+		"rule":        "deny",
+		"entity_type": "package",
+		"matcher":     "contains",
+		"field":       "name",
+		"value":       "kernel-rt",
+	})
+	resp := &resource.CreateResponse{State: planned}
+	r.Create(ctx, resource.CreateRequest{Plan: tfsdk.Plan{Schema: planned.Schema, Raw: planned.Raw}}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+	if want := "map[field:name matcher:contains value:kernel-rt]"; fmt.Sprint(created["criteria"]) != want || created["entityType"] != "package" {
+		t.Errorf("unexpected request %v", created)
+	}
+
+	var state clmFilterResourceModel
+	resp.State.Get(ctx, &state)
+	if state.ID.ValueString() != "12" || state.FilterID.ValueInt64() != 12 {
+		t.Errorf("unexpected state %v", state)
+	}
+}
+
+func TestAccCLMFilterResource(t *testing.T) {
+	acctest.Test(t, acctest.TestCase{
+		PreCheck:                 func() { testAccRealServerPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []acctest.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccCLMFilterResourceConfig("kernel-rt"),
+				Check: acctest.ComposeAggregateTestCheckFunc(
+					acctest.TestCheckResourceAttr("uyuni_clm_filter.test", "rule", "deny"),
+					acctest.TestCheckResourceAttrSet("uyuni_clm_filter.test", "filter_id"),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: testAccCLMFilterResourceConfig("kernel-debug"),
+				Check:  acctest.TestCheckResourceAttr("uyuni_clm_filter.test", "value", "kernel-debug"),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "uyuni_clm_filter.test",
+				ImportState:       true,
+				ImportStateIdFunc: testAccAttribute("uyuni_clm_filter.test", "filter_id"),
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCLMFilterResourceConfig(value string) string {
+	return fmt.Sprintf(`
+resource "uyuni_clm_filter" "test" {
+  name        = "tfacc-filter"
+  rule        = "deny"
+  entity_type = "package"
+  matcher     = "contains"
+  field       = "name"
+  value       = %q
+}
+`, value)
+}
@@ -0,0 +1,420 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+
+	"terraform-provider-uyuni/internal/uyuni"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &clmProjectResource{}
+	_ resource.ResourceWithConfigure   = &clmProjectResource{}
+	_ resource.ResourceWithImportState = &clmProjectResource{}
+)
+
+// contentStateDetached is the state of sources and filters detached from a
+// project, which the project keeps until its next build.
+const contentStateDetached = "DETACHED"
+
+// NewCLMProjectResource is a helper function to simplify the provider implementation.
+func NewCLMProjectResource() resource.Resource {
+	return &clmProjectResource{}
+}
+
+// clmProjectResource is the resource implementation.
+type clmProjectResource struct {
+	client *uyuniClient
+}
+
+// clmProjectResourceModel maps the resource schema data.
+type clmProjectResourceModel struct {
+	ID                    types.String   `tfsdk:"id"`
+	Label                 types.String   `tfsdk:"label"`
+	Name                  types.String   `tfsdk:"name"`
+	Description           types.String   `tfsdk:"description"`
+	FilterIDs             types.Set      `tfsdk:"filter_ids"`
+	ProjectID             types.Int64    `tfsdk:"project_id"`
+	FirstEnvironmentLabel types.String   `tfsdk:"first_environment_label"`
+	LastBuildDate         types.String   `tfsdk:"last_build_date"`
+	DeletionProtection    types.Bool     `tfsdk:"deletion_protection"`
+	ServerAlias           types.String   `tfsdk:"server_alias"`
+	Org                   *orgModel      `tfsdk:"org"`
+	Timeouts              timeouts.Value `tfsdk:"timeouts"`
+}
+
+// Metadata returns the resource type name.
+func (r *clmProjectResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_clm_project"
+}
+
+// Schema defines the schema for the resource.
+func (r *clmProjectResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a content lifecycle management project, which builds its sources through its filters into " +
+			"the channels of its environments. Sources and environments are managed by uyuni_clm_source and " +
+			"uyuni_clm_environment, builds are triggered by uyuni_clm_build.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Label of the project.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"label": schema.StringAttribute{
+				Description: "Label of the project, which prefixes the labels of the channels it builds.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "Name of the project.",
+				Required:    true,
+			},
+			"description": schema.StringAttribute{
+				Description: "Description of the project.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(""),
+			},
+			"filter_ids": schema.SetAttribute{
+				Description: "IDs of the uyuni_clm_filter filters the project builds its sources through, other filters are " +
+					"detached. Changes take effect with the next build. Unset leaves the filters alone.",
+				ElementType: types.Int64Type,
+				Optional:    true,
+			},
+			"project_id": schema.Int64Attribute{
+				Description: "ID of the project.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"first_environment_label": schema.StringAttribute{
+				Description: "Label of the first environment, which builds go to. Null while the project has no environment.",
+				Computed:    true,
+			},
+			"last_build_date": schema.StringAttribute{
+				Description: "Date the project was last built, in RFC 3339 format. Null if it was never built.",
+				Computed:    true,
+			},
+			"deletion_protection": deletionProtectionAttribute(true),
+			"server_alias":        serverAliasAttribute(),
+		},
+		Blocks: map[string]schema.Block{
+			"org": orgBlock(),
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+// setFromProject sets the computed attributes from the project.
+func (m *clmProjectResourceModel) setFromProject(ctx context.Context, project *uyuni.ContentProject) {
+	m.ID = m.Label
+	m.ProjectID = types.Int64Value(int64(project.ID))
+	m.FirstEnvironmentLabel = nonEmptyString(project.FirstEnvironment)
+	m.LastBuildDate = timestampValue(ctx, project.LastBuildDate)
+}
+
+// listProjectFilters returns the IDs of the filters attached to the project,
+// without those detached until its next build.
+func listProjectFilters(ctx context.Context, client *uyuniClient, project string) ([]int64, error) {
+	filters, err := apiGet[[]uyuni.ContentProjectFilter](ctx, client, "contentmanagement/listProjectFilters?projectLabel="+url.QueryEscape(project))
+	if err != nil {
+		return nil, err
+	}
+	ids := []int64{}
+	for _, filter := range filters.Result {
+		if filter.State != contentStateDetached {
+			ids = append(ids, int64(filter.Filter.ID))
+		}
+	}
+	return ids, nil
+}
+
+// changeFilters attaches and detaches filters so that the project has those
+// of the model, unless they are left alone.
+func (m *clmProjectResourceModel) changeFilters(ctx context.Context, client *uyuniClient) error {
+	if m.FilterIDs.IsNull() {
+		return nil
+	}
+	project := m.Label.ValueString()
+	wanted, err := int64Set(ctx, m.FilterIDs)
+	if err != nil {
+		return err
+	}
+	current, err := listProjectFilters(ctx, client, project)
+	if err != nil {
+		return fmt.Errorf("could not list filters: %w", err)
+	}
+	add, remove := setDiff(current, wanted)
+	sort.Slice(add, func(i, j int) bool { return add[i] < add[j] })
+	sort.Slice(remove, func(i, j int) bool { return remove[i] < remove[j] })
+	for _, change := range []struct {
+		ids      []int64
+		endpoint string
+	}{{add, "contentmanagement/attachFilter"}, {remove, "contentmanagement/detachFilter"}} {
+		for _, id := range change.ids {
+			if _, err := apiPost[any](ctx, client, change.endpoint, map[string]interface{}{
+				"projectLabel": project,
+				"filterId":     id,
+			}); err != nil {
+				return fmt.Errorf("could not change filter %d: %w", id, err)
+			}
+			tflog.Info(ctx, fmt.Sprintf("Called %s with filter %d for project %s", change.endpoint, id, project))
+		}
+	}
+	return nil
+}
+
+// Create a new resource.
+func (r *clmProjectResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan clmProjectResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	client := resourceClient(ctx, r.client, plan.ServerAlias, plan.Org, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	label := plan.Label.ValueString()
+	tflog.Info(ctx, "About to create content lifecycle project "+label)
+
+	project, err := apiPost[uyuni.ContentProject](ctx, client, "contentmanagement/createProject", map[string]interface{}{
+		"projectLabel": label,
+		"name":         plan.Name.ValueString(),
+		"description":  plan.Description.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating content lifecycle project",
+			"Could not create content lifecycle project: "+err.Error(),
+		)
+		return
+	}
+	plan.setFromProject(ctx, &project.Result)
+
+	if err := plan.changeFilters(ctx, client); err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating content lifecycle project",
+			"Could not attach the filters of content lifecycle project "+label+": "+err.Error(),
+		)
+		// Fall through to track the project, which Terraform taints.
+	}
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read resource information.
+func (r *clmProjectResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Get current state
+	var state clmProjectResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := resourceClient(ctx, r.client, state.ServerAlias, state.Org, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	label := state.Label.ValueString()
+	project, err := apiGet[uyuni.ContentProject](ctx, client, "contentmanagement/lookupProject?projectLabel="+url.QueryEscape(label))
+	if err != nil {
+		if handleNotFound(ctx, resp, err, "Content lifecycle project "+label) {
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Reading Uyuni content lifecycle project",
+			"Could not read content lifecycle project "+label+": "+err.Error(),
+		)
+		return
+	}
+	state.Name = types.StringValue(project.Result.Name)
+	state.Description = types.StringValue(project.Result.Description)
+	state.setFromProject(ctx, &project.Result)
+
+	// Filters are only refreshed where they are managed.
+	if !state.FilterIDs.IsNull() {
+		ids, err := listProjectFilters(ctx, client, label)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Reading Uyuni content lifecycle project",
+				"Could not list filters of content lifecycle project "+label+": "+err.Error(),
+			)
+			return
+		}
+		state.FilterIDs, diags = types.SetValueFrom(ctx, types.Int64Type, ids)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *clmProjectResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Retrieve values from plan
+	var plan clmProjectResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	client := resourceClient(ctx, r.client, plan.ServerAlias, plan.Org, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	label := plan.Label.ValueString()
+	project, err := apiPost[uyuni.ContentProject](ctx, client, "contentmanagement/updateProject", map[string]interface{}{
+		"projectLabel": label,
+		"props": map[string]interface{}{
+			"name":        plan.Name.ValueString(),
+			"description": plan.Description.ValueString(),
+		},
+	})
+	if err == nil {
+		plan.setFromProject(ctx, &project.Result)
+		err = plan.changeFilters(ctx, client)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating content lifecycle project",
+			"Could not update content lifecycle project "+label+": "+err.Error(),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete removes the project with its environments. The channels it built
+// are removed with the environments.
+func (r *clmProjectResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Retrieve values from state
+	var state clmProjectResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	label := state.Label.ValueString()
+	if deletionProtected(state.DeletionProtection, "Content lifecycle project "+label, &resp.Diagnostics) {
+		return
+	}
+
+	client := resourceClient(ctx, r.client, state.ServerAlias, state.Org, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	_, err := apiPost[int](ctx, client, "contentmanagement/removeProject", map[string]interface{}{
+		"projectLabel": label,
+	})
+	if err != nil && !isNotFoundError(err) {
+		resp.Diagnostics.AddError(
+			"Error Deleting Uyuni content lifecycle project",
+			"Could not delete content lifecycle project "+label+": "+err.Error(),
+		)
+		return
+	}
+}
+
+// ImportState imports a project by its label. Its filters stay unmanaged
+// until they are configured, and it is protected against deletion.
+func (r *clmProjectResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("label"), req, resp)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("deletion_protection"), true)...)
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *clmProjectResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*uyuniClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *uyuniClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
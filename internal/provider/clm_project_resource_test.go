@@ -0,0 +1,114 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	acctest "github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestCLMProjectUpdateAttachesAndDetachesFilters(t *testing.T) {
+	ctx := context.Background()
+	var changes []string
+	r := NewCLMProjectResource()
+	testConfigure(t, r, testAPIClient(t, func(w http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/contentmanagement/listProjectFilters":
+			// Filter 13 was detached before and stays until the next build.
+			_, _ = w.Write([]byte(`{"success": true, "result": [
+				{"contentProjectLabel": "sles15-sp6", "state": "BUILT", "filter": {"id": 12}},
+				{"contentProjectLabel": "sles15-sp6", "state": "DETACHED", "filter": {"id": 13}}]}`))
+		case "/contentmanagement/attachFilter", "/contentmanagement/detachFilter":
+			var body map[string]interface{}
+			_ = json.NewDecoder(req.Body).Decode(&body)
+			changes = append(changes, fmt.Sprint(req.URL.Path, " ", body["filterId"]))
+			_, _ = w.Write([]byte(`{"success": true, "result": 1}`))
+		default:
+			_, _ = w.Write([]byte(`{"success": true, "result": {"id": 3, "label": "sles15-sp6", "name": "SLES 15 SP6", "firstEnvironment": "dev"}}`))
+		}
+	}))
+
+	attributes := map[string]interface{}{
+		"label":      "sles15-sp6",
+		"name":       "SLES 15 SP6",
+		"filter_ids": []int64{12},
+	}
+	state := testState(t, r, attributes)
+	attributes["filter_ids"] = []int64{13, 14}
+	planned := testState(t, r, attributes)
+	resp := &resource.UpdateResponse{State: planned}
+	r.Update(ctx, resource.UpdateRequest{Plan: tfsdk.Plan{Schema: planned.Schema, Raw: planned.Raw}, State: state}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+	want := "[/contentmanagement/attachFilter 13 /contentmanagement/attachFilter 14 /contentmanagement/detachFilter 12]"
+	if fmt.Sprint(changes) != want {
+		t.Errorf("expected %s, got %v", want, changes)
+	}
+
+	var updated clmProjectResourceModel
+	resp.State.Get(ctx, &updated)
+	if updated.ID.ValueString() != "sles15-sp6" || updated.FirstEnvironmentLabel.ValueString() != "dev" || !updated.LastBuildDate.IsNull() {
+		t.Errorf("unexpected state %v", updated)
+	}
+}
+
+func TestAccCLMProjectResource(t *testing.T) {
+	acctest.Test(t, acctest.TestCase{
+		PreCheck:                 func() { testAccRealServerPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckGone(t, "contentmanagement/lookupProject?projectLabel=tfacc-clm"),
+		Steps: []acctest.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccCLMProjectResourceConfig("Created by the acceptance tests"),
+				Check: acctest.ComposeAggregateTestCheckFunc(
+					acctest.TestCheckResourceAttr("uyuni_clm_project.test", "label", "tfacc-clm"),
+					acctest.TestCheckResourceAttr("uyuni_clm_project.test", "filter_ids.#", "1"),
+					acctest.TestCheckResourceAttrSet("uyuni_clm_project.test", "project_id"),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: testAccCLMProjectResourceConfig("Updated by the acceptance tests"),
+				Check:  acctest.TestCheckResourceAttr("uyuni_clm_project.test", "description", "Updated by the acceptance tests"),
+			},
+			// ImportState testing
+			{
+				ResourceName:                         "uyuni_clm_project.test",
+				ImportState:                          true,
+				ImportStateId:                        "tfacc-clm",
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "label",
+				// Imported projects are protected against deletion.
+				ImportStateVerifyIgnore: []string{"deletion_protection"},
+			},
+		},
+	})
+}
+
+func testAccCLMProjectResourceConfig(description string) string {
+	return fmt.Sprintf(`
+resource "uyuni_clm_filter" "test" {
+  name        = "tfacc-clm-project"
+  rule        = "deny"
+  entity_type = "package"
+  matcher     = "contains"
+  field       = "name"
+  value       = "kernel-rt"
+}
+
+resource "uyuni_clm_project" "test" {
+  label               = "tfacc-clm"
+  name                = "tfacc-clm"
+  description         = %q
+  filter_ids          = [uyuni_clm_filter.test.filter_id]
+  deletion_protection = false
+}
+`, description)
+}
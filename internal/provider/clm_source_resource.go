@@ -0,0 +1,277 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"terraform-provider-uyuni/internal/uyuni"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &clmSourceResource{}
+	_ resource.ResourceWithConfigure   = &clmSourceResource{}
+	_ resource.ResourceWithImportState = &clmSourceResource{}
+)
+
+// clmSourceTypeSoftware is the type of sources which are software channels.
+const clmSourceTypeSoftware = "software"
+
+// NewCLMSourceResource is a helper function to simplify the provider implementation.
+func NewCLMSourceResource() resource.Resource {
+	return &clmSourceResource{}
+}
+
+// clmSourceResource is the resource implementation.
+type clmSourceResource struct {
+	client *uyuniClient
+}
+
+// clmSourceResourceModel maps the resource schema data.
+type clmSourceResourceModel struct {
+	ID           types.String   `tfsdk:"id"`
+	ProjectLabel types.String   `tfsdk:"project_label"`
+	ChannelLabel types.String   `tfsdk:"channel_label"`
+	State        types.String   `tfsdk:"state"`
+	ServerAlias  types.String   `tfsdk:"server_alias"`
+	Org          *orgModel      `tfsdk:"org"`
+	Timeouts     timeouts.Value `tfsdk:"timeouts"`
+}
+
+// Metadata returns the resource type name.
+func (r *clmSourceResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_clm_source"
+}
+
+// Schema defines the schema for the resource.
+func (r *clmSourceResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Attaches a software channel to a content lifecycle management project as a source of its builds. " +
+			"Attaching and detaching take effect with the next build of the project.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Project label and channel label, separated by a colon.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"project_label": schema.StringAttribute{
+				Description: "Label of the project.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"channel_label": schema.StringAttribute{
+				Description: "Label of the software channel.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"state": schema.StringAttribute{
+				Description: "State of the source, `ATTACHED` until the project is built and `BUILT` afterwards.",
+				Computed:    true,
+			},
+			"server_alias": serverAliasAttribute(),
+		},
+		Blocks: map[string]schema.Block{
+			"org": orgBlock(),
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+// Create a new resource.
+func (r *clmSourceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan clmSourceResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	client := resourceClient(ctx, r.client, plan.ServerAlias, plan.Org, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	project, channel := plan.ProjectLabel.ValueString(), plan.ChannelLabel.ValueString()
+	tflog.Info(ctx, "About to attach channel "+channel+" to content lifecycle project "+project)
+
+	source, err := apiPost[uyuni.ContentSource](ctx, client, "contentmanagement/attachSource", map[string]interface{}{
+		"projectLabel": project,
+		"sourceType":   clmSourceTypeSoftware,
+		"sourceLabel":  channel,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error attaching content lifecycle source",
+			"Could not attach channel "+channel+" to content lifecycle project "+project+": "+err.Error(),
+		)
+		return
+	}
+	plan.ID = types.StringValue(project + importIDSeparator + channel)
+	plan.State = types.StringValue(source.Result.State)
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read resource information. A source detached outside of Terraform stays
+// with the project until its next build and is treated as gone.
+func (r *clmSourceResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Get current state
+	var state clmSourceResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := resourceClient(ctx, r.client, state.ServerAlias, state.Org, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	project, channel := state.ProjectLabel.ValueString(), state.ChannelLabel.ValueString()
+	source, err := apiGet[uyuni.ContentSource](ctx, client, "contentmanagement/lookupSource?projectLabel="+url.QueryEscape(project)+
+		"&sourceType="+clmSourceTypeSoftware+"&sourceLabel="+url.QueryEscape(channel))
+	if err != nil {
+		if handleNotFound(ctx, resp, err, "Source "+channel+" of content lifecycle project "+project) {
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Reading Uyuni content lifecycle source",
+			"Could not read source "+channel+" of content lifecycle project "+project+": "+err.Error(),
+		)
+		return
+	}
+	if source.Result.State == contentStateDetached {
+		tflog.Warn(ctx, "Source "+channel+" of content lifecycle project "+project+" was detached, removing it from state")
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	state.ID = types.StringValue(project + importIDSeparator + channel)
+	state.State = types.StringValue(source.Result.State)
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update only stores the plan, all attributes require replacement.
+func (r *clmSourceResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan clmSourceResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete detaches the source. The channels built from it stay in the
+// environments until the next build.
+func (r *clmSourceResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Retrieve values from state
+	var state clmSourceResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	client := resourceClient(ctx, r.client, state.ServerAlias, state.Org, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	project, channel := state.ProjectLabel.ValueString(), state.ChannelLabel.ValueString()
+	_, err := apiPost[int](ctx, client, "contentmanagement/detachSource", map[string]interface{}{
+		"projectLabel": project,
+		"sourceType":   clmSourceTypeSoftware,
+		"sourceLabel":  channel,
+	})
+	if err != nil && !isNotFoundError(err) {
+		resp.Diagnostics.AddError(
+			"Error detaching content lifecycle source",
+			"Could not detach channel "+channel+" from content lifecycle project "+project+": "+err.Error(),
+		)
+		return
+	}
+}
+
+// ImportState imports a source by the project label and the channel label,
+// e.g. "sles15-sp6:sle-product-sles15-sp6-pool-x86_64".
+func (r *clmSourceResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts, err := parseImportID(req.ID, "project_label", "channel_label")
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Import ID", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project_label"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("channel_label"), parts[1])...)
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *clmSourceResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*uyuniClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *uyuniClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
@@ -0,0 +1,70 @@
+package provider
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+
+	acctest "github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestCLMSourceReadRemovesDetachedSource(t *testing.T) {
+	client := testAPIClient(t, func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Query().Get("sourceType") != "software" {
+			t.Errorf("unexpected query %s", req.URL.RawQuery)
+		}
+		_, _ = w.Write([]byte(`{"success": true, "result": {"contentProjectLabel": "sles15-sp6", "type": "software",
+			"state": "DETACHED", "channelLabel": "sle-product-sles15-sp6-pool-x86_64"}}`))
+	})
+
+	resp := testRead(t, NewCLMSourceResource(), client, map[string]interface{}{
+		"project_label": "sles15-sp6",
+		"channel_label": "sle-product-sles15-sp6-pool-x86_64",
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+	if !resp.State.Raw.IsNull() {
+		t.Error("expected the detached source to be removed from state")
+	}
+}
+
+func TestAccCLMSourceResource(t *testing.T) {
+	source := os.Getenv("UYUNI_TEST_SOURCE_CHANNEL")
+	acctest.Test(t, acctest.TestCase{
+		PreCheck: func() {
+			testAccRealServerPreCheck(t)
+			testAccSkipUnlessEnv(t, "UYUNI_TEST_SOURCE_CHANNEL", "a synchronized x86_64 channel without parent")
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []acctest.TestStep{
+			// Create and Read testing
+			{
+				Config: fmt.Sprintf(`
+resource "uyuni_clm_project" "test" {
+  label               = "tfacc-clm-source"
+  name                = "tfacc-clm-source"
+  deletion_protection = false
+}
+
+resource "uyuni_clm_source" "test" {
+  project_label = uyuni_clm_project.test.label
+  channel_label = %q
+}
+`, source),
+				Check: acctest.ComposeAggregateTestCheckFunc(
+					acctest.TestCheckResourceAttr("uyuni_clm_source.test", "id", "tfacc-clm-source:"+source),
+					acctest.TestCheckResourceAttrSet("uyuni_clm_source.test", "state"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "uyuni_clm_source.test",
+				ImportState:       true,
+				ImportStateId:     "tfacc-clm-source:" + source,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
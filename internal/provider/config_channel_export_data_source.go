@@ -0,0 +1,245 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+
+	"terraform-provider-uyuni/internal/uyuni"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &ConfigChannelExportDataSource{}
+	_ datasource.DataSourceWithConfigure = &ConfigChannelExportDataSource{}
+)
+
+// ConfigChannelExportDataSourceModel maps the data source schema data.
+type ConfigChannelExportDataSourceModel struct {
+	Channel   types.String             `tfsdk:"channel"`
+	Checksums types.Map                `tfsdk:"checksums"`
+	Files     []configChannelFileModel `tfsdk:"files"`
+}
+
+// configChannelFileModel maps the exported file schema data.
+type configChannelFileModel struct {
+	Path        types.String `tfsdk:"path"`
+	Type        types.String `tfsdk:"type"`
+	Revision    types.Int64  `tfsdk:"revision"`
+	Binary      types.Bool   `tfsdk:"binary"`
+	TargetPath  types.String `tfsdk:"target_path"`
+	Owner       types.String `tfsdk:"owner"`
+	Group       types.String `tfsdk:"group"`
+	Permissions types.String `tfsdk:"permissions"`
+	SELinuxCtx  types.String `tfsdk:"selinux_ctx"`
+	SHA256      types.String `tfsdk:"sha256"`
+	Modified    types.String `tfsdk:"modified"`
+}
+
+// NewConfigChannelExportDataSource is a helper function to simplify the provider implementation.
+func NewConfigChannelExportDataSource() datasource.DataSource {
+	return &ConfigChannelExportDataSource{}
+}
+
+// ConfigChannelExportDataSource is the data source implementation.
+type ConfigChannelExportDataSource struct {
+	client *uyuniClient
+}
+
+// Metadata returns the data source type name.
+func (d *ConfigChannelExportDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_config_channel_export"
+}
+
+// Schema defines the schema for the data source.
+func (d *ConfigChannelExportDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Exports the metadata and checksums of the latest revisions of all files of a configuration channel, " +
+			"e.g. to compare them with the sources held in git and detect files edited in the web UI.",
+		Attributes: map[string]schema.Attribute{
+			"channel": schema.StringAttribute{
+				Description: "Label of the configuration channel.",
+				Required:    true,
+			},
+			"checksums": schema.MapAttribute{
+				Description: "SHA-256 checksums of the contents of the files by path. Directories are omitted.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"files": schema.ListNestedAttribute{
+				Description: "Files of the channel in their latest revision, ordered by path.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"path": schema.StringAttribute{
+							Description: "Path of the file, e.g. `/etc/motd`.",
+							Computed:    true,
+						},
+						"type": schema.StringAttribute{
+							Description: "Type of the file: `file`, `directory`, `symlink` or `sls`.",
+							Computed:    true,
+						},
+						"revision": schema.Int64Attribute{
+							Description: "Latest revision of the file.",
+							Computed:    true,
+						},
+						"binary": schema.BoolAttribute{
+							Description: "Whether the file is binary.",
+							Computed:    true,
+						},
+						"target_path": schema.StringAttribute{
+							Description: "Target of the symbolic link. Null for other types.",
+							Computed:    true,
+						},
+						"owner": schema.StringAttribute{
+							Description: "Owner of the file when deployed. Null for Salt states.",
+							Computed:    true,
+						},
+						"group": schema.StringAttribute{
+							Description: "Group of the file when deployed. Null for Salt states.",
+							Computed:    true,
+						},
+						"permissions": schema.StringAttribute{
+							Description: "Octal permissions of the file when deployed, e.g. `644`. Null for Salt states.",
+							Computed:    true,
+						},
+						"selinux_ctx": schema.StringAttribute{
+							Description: "SELinux context of the file when deployed.",
+							Computed:    true,
+						},
+						"sha256": schema.StringAttribute{
+							Description: "SHA-256 checksum of the content. Null for directories.",
+							Computed:    true,
+						},
+						"modified": schema.StringAttribute{
+							Description: "Date the revision was created, in RFC 3339 format.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *ConfigChannelExportDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state ConfigChannelExportDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	label := state.Channel.ValueString()
+	files, err := apiGet[[]uyuni.ConfigFile](ctx, d.client, "configchannel/listFiles?channelLabel="+url.QueryEscape(label))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Uyuni configuration channel",
+			"Could not list the files of configuration channel "+label+": "+err.Error(),
+		)
+		return
+	}
+	paths := make([]string, 0, len(files.Result))
+	for _, file := range files.Result {
+		paths = append(paths, file.Path)
+	}
+	sort.Strings(paths)
+
+	var mu sync.Mutex
+	revisions := map[string]*uyuni.ConfigRevision{}
+	errs := runBatch(paths, func(path string) error {
+		latest, err := fileRevision(ctx, d.client, label, path, 0)
+		if err != nil {
+			return err
+		}
+		if latest == nil {
+			return fmt.Errorf("the file has no revision")
+		}
+		mu.Lock()
+		revisions[path] = latest
+		mu.Unlock()
+		return nil
+	})
+	if len(errs) > 0 {
+		failed := make([]string, 0, len(errs))
+		for path, err := range errs {
+			failed = append(failed, fmt.Sprintf("%s: %s", path, err))
+		}
+		sort.Strings(failed)
+		resp.Diagnostics.AddError(
+			"Unable to Read Uyuni configuration channel",
+			"Could not read the files of configuration channel "+label+": "+strings.Join(failed, "; "),
+		)
+		return
+	}
+
+	checksums := map[string]string{}
+	state.Files = make([]configChannelFileModel, 0, len(paths))
+	for _, path := range paths {
+		revision := revisions[path]
+		file := configChannelFileModel{
+			Path:        types.StringValue(path),
+			Type:        types.StringValue(revision.Type),
+			Revision:    types.Int64Value(int64(revision.Revision)),
+			Binary:      types.BoolValue(revision.Binary),
+			TargetPath:  nonEmptyString(revision.TargetPath),
+			Owner:       nonEmptyString(revision.Owner),
+			Group:       nonEmptyString(revision.Group),
+			Permissions: nonEmptyString(revision.PermissionsMode),
+			SELinuxCtx:  nonEmptyString(revision.SELinuxCtx),
+			SHA256:      types.StringNull(),
+			Modified:    timestampValue(ctx, revision.Modified),
+		}
+		if revision.Type != "directory" {
+			checksum, err := revisionSHA256(*revision)
+			if err != nil {
+				resp.Diagnostics.AddError("Unable to Read Uyuni configuration channel", err.Error())
+				return
+			}
+			file.SHA256 = types.StringValue(checksum)
+			checksums[path] = checksum
+		}
+		state.Files = append(state.Files, file)
+	}
+	state.Checksums, diags = types.MapValueFrom(ctx, types.StringType, checksums)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *ConfigChannelExportDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*uyuniClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *uyuniClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
@@ -0,0 +1,68 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestConfigChannelExportListsLatestRevisions(t *testing.T) {
+	client := testAPIClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("filePath") {
+		case "":
+			_, _ = w.Write([]byte(`{"success": true, "result": [
+				{"type": "file", "path": "/etc/motd"},
+				{"type": "directory", "path": "/etc/profile.d"}]}`))
+		case "/etc/motd":
+			_, _ = w.Write([]byte(`{"success": true, "result": [
+				{"type": "file", "path": "/etc/motd", "contents": "Welcome\n", "revision": 1, "owner": "root", "permissions_mode": "644"},
+				{"type": "file", "path": "/etc/motd", "contents": "V2VsY29tZSB0byBwcm9kCg==", "contents_enc64": true, "revision": 2,
+				 "modified": "2025-01-14T10:02:11Z", "owner": "root", "permissions_mode": "644"}]}`))
+		default:
+			_, _ = w.Write([]byte(`{"success": true, "result": [{"type": "directory", "path": "/etc/profile.d", "revision": 1}]}`))
+		}
+	})
+
+	resp := testDataSourceRead(t, NewConfigChannelExportDataSource(), client, map[string]tftypes.Value{
+		"channel": tftypes.NewValue(tftypes.String, "base"),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+	var model ConfigChannelExportDataSourceModel
+	resp.State.Get(context.Background(), &model)
+	if len(model.Files) != 2 || model.Files[0].Revision.ValueInt64() != 2 || !model.Files[1].SHA256.IsNull() {
+		t.Fatalf("unexpected files %v", model.Files)
+	}
+	// The checksum of "Welcome to prod\n".
+	want := "964183cef48f6bb76f94a08a8d26b2116b0e5ace3af0ef9731abd31384e8f253"
+	checksums := model.Checksums.Elements()
+	if len(checksums) != 1 || checksums["/etc/motd"].String() != `"`+want+`"` {
+		t.Errorf("unexpected checksums %v", checksums)
+	}
+}
+
+func TestAccConfigChannelExportDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccRealServerPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccConfigFileResourceConfig("Exported by the acceptance tests\n") + `
+data "uyuni_config_channel_export" "test" {
+  channel = uyuni_config_file.test.channel
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.uyuni_config_channel_export.test", "files.#", "1"),
+					resource.TestCheckResourceAttr("data.uyuni_config_channel_export.test", "files.0.path", "/etc/motd"),
+					resource.TestCheckResourceAttr("data.uyuni_config_channel_export.test", "files.0.permissions", "644"),
+					resource.TestCheckResourceAttrPair("data.uyuni_config_channel_export.test", "checksums./etc/motd", "uyuni_config_file.test", "sha256"),
+				),
+			},
+		},
+	})
+}
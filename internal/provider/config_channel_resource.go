@@ -0,0 +1,340 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"terraform-provider-uyuni/internal/uyuni"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &configChannelResource{}
+	_ resource.ResourceWithConfigure   = &configChannelResource{}
+	_ resource.ResourceWithImportState = &configChannelResource{}
+)
+
+// Types of configuration channels: channels of files deployed as they are,
+// and channels of Salt states.
+const (
+	configChannelTypeNormal = "normal"
+	configChannelTypeState  = "state"
+)
+
+// NewConfigChannelResource is a helper function to simplify the provider implementation.
+func NewConfigChannelResource() resource.Resource {
+	return &configChannelResource{}
+}
+
+// configChannelResource is the resource implementation.
+type configChannelResource struct {
+	client *uyuniClient
+}
+
+// configChannelResourceModel maps the resource schema data.
+type configChannelResourceModel struct {
+	ID                 types.String   `tfsdk:"id"`
+	Label              types.String   `tfsdk:"label"`
+	Name               types.String   `tfsdk:"name"`
+	Description        types.String   `tfsdk:"description"`
+	Type               types.String   `tfsdk:"type"`
+	ChannelID          types.Int64    `tfsdk:"channel_id"`
+	DeletionProtection types.Bool     `tfsdk:"deletion_protection"`
+	ServerAlias        types.String   `tfsdk:"server_alias"`
+	Org                *orgModel      `tfsdk:"org"`
+	Timeouts           timeouts.Value `tfsdk:"timeouts"`
+}
+
+// Metadata returns the resource type name.
+func (r *configChannelResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_config_channel"
+}
+
+// Schema defines the schema for the resource.
+func (r *configChannelResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a global configuration channel, whose files are managed by uyuni_config_file. " +
+			"Channels of type `state` hold Salt states, applied from their `/init.sls`.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Label of the channel.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"label": schema.StringAttribute{
+				Description: "Label of the channel.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "Name of the channel.",
+				Required:    true,
+			},
+			"description": schema.StringAttribute{
+				Description: "Description of the channel.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(""),
+			},
+			"type": schema.StringAttribute{
+				Description: "Type of the channel: `normal` for files deployed as they are, or `state` for Salt states. " +
+					"Defaults to `normal`.",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(configChannelTypeNormal),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf(configChannelTypeNormal, configChannelTypeState),
+				},
+			},
+			"channel_id": schema.Int64Attribute{
+				Description: "ID of the channel.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"deletion_protection": deletionProtectionAttribute(true),
+			"server_alias":        serverAliasAttribute(),
+		},
+		Blocks: map[string]schema.Block{
+			"org": orgBlock(),
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+// setFromChannel sets the attributes from the channel.
+func (m *configChannelResourceModel) setFromChannel(channel *uyuni.ConfigChannel) {
+	m.ID = types.StringValue(channel.Label)
+	m.Label = types.StringValue(channel.Label)
+	m.Name = types.StringValue(channel.Name)
+	m.Description = types.StringValue(channel.Description)
+	m.Type = types.StringValue(channel.ConfigChannelType.Label)
+	m.ChannelID = types.Int64Value(int64(channel.ID))
+}
+
+// Create a new resource.
+func (r *configChannelResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan configChannelResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	client := resourceClient(ctx, r.client, plan.ServerAlias, plan.Org, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	label := plan.Label.ValueString()
+	tflog.Info(ctx, "About to create configuration channel "+label)
+
+	channel, err := apiPost[uyuni.ConfigChannel](ctx, client, "configchannel/create", map[string]interface{}{
+		"channelLabel":       label,
+		"channelName":        plan.Name.ValueString(),
+		"channelDescription": plan.Description.ValueString(),
+		"channelType":        plan.Type.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating configuration channel",
+			"Could not create configuration channel: "+err.Error(),
+		)
+		return
+	}
+	plan.setFromChannel(&channel.Result)
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read resource information.
+func (r *configChannelResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Get current state
+	var state configChannelResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := resourceClient(ctx, r.client, state.ServerAlias, state.Org, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	label := state.Label.ValueString()
+	channel, err := apiGet[uyuni.ConfigChannel](ctx, client, "configchannel/getDetails?label="+url.QueryEscape(label))
+	if err != nil {
+		if handleNotFound(ctx, resp, err, "Configuration channel "+label) {
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Reading Uyuni configuration channel",
+			"Could not read configuration channel "+label+": "+err.Error(),
+		)
+		return
+	}
+	state.setFromChannel(&channel.Result)
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *configChannelResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Retrieve values from plan
+	var plan configChannelResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	client := resourceClient(ctx, r.client, plan.ServerAlias, plan.Org, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	label := plan.Label.ValueString()
+	channel, err := apiPost[uyuni.ConfigChannel](ctx, client, "configchannel/update", map[string]interface{}{
+		"channelLabel": label,
+		"channelName":  plan.Name.ValueString(),
+		"description":  plan.Description.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating configuration channel",
+			"Could not update configuration channel "+label+": "+err.Error(),
+		)
+		return
+	}
+	plan.setFromChannel(&channel.Result)
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete removes the channel with all its files and their revisions, and
+// unsubscribes the systems from it.
+func (r *configChannelResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Retrieve values from state
+	var state configChannelResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	label := state.Label.ValueString()
+	if deletionProtected(state.DeletionProtection, "Configuration channel "+label, &resp.Diagnostics) {
+		return
+	}
+
+	client := resourceClient(ctx, r.client, state.ServerAlias, state.Org, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	_, err := apiPost[int](ctx, client, "configchannel/deleteChannels", map[string]interface{}{
+		"labels": []string{label},
+	})
+	if err != nil && !isNotFoundError(err) {
+		resp.Diagnostics.AddError(
+			"Error Deleting Uyuni configuration channel",
+			"Could not delete configuration channel "+label+": "+err.Error(),
+		)
+		return
+	}
+}
+
+// ImportState imports a channel by its label. It is protected against
+// deletion.
+func (r *configChannelResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("label"), req, resp)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("deletion_protection"), true)...)
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *configChannelResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*uyuniClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *uyuniClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
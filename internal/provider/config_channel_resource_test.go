@@ -0,0 +1,94 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	acctest "github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestConfigChannelCreate(t *testing.T) {
+	ctx := context.Background()
+	var body map[string]interface{}
+	r := NewConfigChannelResource()
+	testConfigure(t, r, testAPIClient(t, func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/configchannel/create" {
+			t.Errorf("unexpected request %s", req.URL)
+		}
+		_ = json.NewDecoder(req.Body).Decode(&body)
+		_, _ = w.Write([]byte(`{"success": true, "result": {"id": 7, "label": "sshd", "name": "SSH daemon", "description": "",
+			"configChannelType": {"id": 4, "label": "state", "name": "State Channel"}}}`))
+	}))
+
+	planned := testState(t, r, map[string]interface{}{
+		"label":               "sshd",
+		"name":                "SSH daemon",
+		"description":         "",
+		"type":                "state",
+		"deletion_protection": true,
+	})
+	resp := &resource.CreateResponse{State: planned}
+	r.Create(ctx, resource.CreateRequest{Plan: tfsdk.Plan{Schema: planned.Schema, Raw: planned.Raw}}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+	if body["channelLabel"] != "sshd" || body["channelName"] != "SSH daemon" || body["channelType"] != "state" {
+		t.Errorf("unexpected request body %v", body)
+	}
+
+	var state configChannelResourceModel
+	resp.State.Get(ctx, &state)
+	if state.ID.ValueString() != "sshd" || state.ChannelID.ValueInt64() != 7 || state.Type.ValueString() != "state" {
+		t.Errorf("unexpected state %v", state)
+	}
+}
+
+func TestAccConfigChannelResource(t *testing.T) {
+	acctest.Test(t, acctest.TestCase{
+		PreCheck:                 func() { testAccUyuniPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckGone(t, "configchannel/getDetails?label=tfacc-config"),
+		Steps: []acctest.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccConfigChannelResourceConfig("Terraform acceptance"),
+				Check: acctest.ComposeAggregateTestCheckFunc(
+					acctest.TestCheckResourceAttr("uyuni_config_channel.test", "label", "tfacc-config"),
+					acctest.TestCheckResourceAttr("uyuni_config_channel.test", "type", "normal"),
+					acctest.TestCheckResourceAttrSet("uyuni_config_channel.test", "channel_id"),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: testAccConfigChannelResourceConfig("Terraform acceptance renamed"),
+				Check:  acctest.TestCheckResourceAttr("uyuni_config_channel.test", "name", "Terraform acceptance renamed"),
+			},
+			// ImportState testing
+			{
+				ResourceName:                         "uyuni_config_channel.test",
+				ImportState:                          true,
+				ImportStateId:                        "tfacc-config",
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "label",
+				// Imported channels are protected against deletion.
+				ImportStateVerifyIgnore: []string{"deletion_protection"},
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccConfigChannelResourceConfig(name string) string {
+	return fmt.Sprintf(`
+resource "uyuni_config_channel" "test" {
+  label               = "tfacc-config"
+  name                = %q
+  deletion_protection = false
+}
+`, name)
+}
@@ -0,0 +1,213 @@
+package provider
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &ConfigFileContentDataSource{}
+	_ datasource.DataSourceWithConfigure = &ConfigFileContentDataSource{}
+)
+
+// ConfigFileContentDataSourceModel maps the data source schema data.
+type ConfigFileContentDataSourceModel struct {
+	Channel       types.String `tfsdk:"channel"`
+	Path          types.String `tfsdk:"path"`
+	Revision      types.Int64  `tfsdk:"revision"`
+	Type          types.String `tfsdk:"type"`
+	Binary        types.Bool   `tfsdk:"binary"`
+	Content       types.String `tfsdk:"content"`
+	ContentBase64 types.String `tfsdk:"content_base64"`
+	TargetPath    types.String `tfsdk:"target_path"`
+	Owner         types.String `tfsdk:"owner"`
+	Group         types.String `tfsdk:"group"`
+	Permissions   types.String `tfsdk:"permissions"`
+	SELinuxCtx    types.String `tfsdk:"selinux_ctx"`
+	SHA256        types.String `tfsdk:"sha256"`
+	Modified      types.String `tfsdk:"modified"`
+}
+
+// NewConfigFileContentDataSource is a helper function to simplify the provider implementation.
+func NewConfigFileContentDataSource() datasource.DataSource {
+	return &ConfigFileContentDataSource{}
+}
+
+// ConfigFileContentDataSource is the data source implementation.
+type ConfigFileContentDataSource struct {
+	client *uyuniClient
+}
+
+// Metadata returns the data source type name.
+func (d *ConfigFileContentDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_config_file_content"
+}
+
+// Schema defines the schema for the data source.
+func (d *ConfigFileContentDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Reads a file of a configuration channel, e.g. to template centrally managed configuration " +
+			"into cloud-init.",
+		Attributes: map[string]schema.Attribute{
+			"channel": schema.StringAttribute{
+				Description: "Label of the configuration channel.",
+				Required:    true,
+			},
+			"path": schema.StringAttribute{
+				Description: "Path of the file, e.g. `/etc/motd`.",
+				Required:    true,
+			},
+			"revision": schema.Int64Attribute{
+				Description: "Revision of the file to read. Defaults to the latest revision.",
+				Optional:    true,
+				Computed:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"type": schema.StringAttribute{
+				Description: "Type of the file: `file`, `directory`, `symlink` or `sls`.",
+				Computed:    true,
+			},
+			"binary": schema.BoolAttribute{
+				Description: "Whether the file is binary.",
+				Computed:    true,
+			},
+			"content": schema.StringAttribute{
+				Description: "Content of the file. Null for binary files, use `content_base64` instead.",
+				Computed:    true,
+			},
+			"content_base64": schema.StringAttribute{
+				Description: "Content of the file, base64 encoded.",
+				Computed:    true,
+			},
+			"target_path": schema.StringAttribute{
+				Description: "Target of the symbolic link. Null for other types.",
+				Computed:    true,
+			},
+			"owner": schema.StringAttribute{
+				Description: "Owner of the file when deployed. Null for Salt states.",
+				Computed:    true,
+			},
+			"group": schema.StringAttribute{
+				Description: "Group of the file when deployed. Null for Salt states.",
+				Computed:    true,
+			},
+			"permissions": schema.StringAttribute{
+				Description: "Octal permissions of the file when deployed, e.g. `644`. Null for Salt states.",
+				Computed:    true,
+			},
+			"selinux_ctx": schema.StringAttribute{
+				Description: "SELinux context of the file when deployed.",
+				Computed:    true,
+			},
+			"sha256": schema.StringAttribute{
+				Description: "SHA-256 checksum of the content.",
+				Computed:    true,
+			},
+			"modified": schema.StringAttribute{
+				Description: "Date the revision was created, in RFC 3339 format.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *ConfigFileContentDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state ConfigFileContentDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	revision, err := fileRevision(ctx, d.client, state.Channel.ValueString(), state.Path.ValueString(), int(state.Revision.ValueInt64()))
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to Read Uyuni configuration file", err.Error())
+		return
+	}
+	if revision == nil {
+		detail := fmt.Sprintf("Configuration channel %s has no file %s", state.Channel.ValueString(), state.Path.ValueString())
+		if !state.Revision.IsNull() {
+			detail += fmt.Sprintf(" with revision %d", state.Revision.ValueInt64())
+		}
+		resp.Diagnostics.AddError("Uyuni configuration file not found", detail+".")
+		return
+	}
+
+	content := []byte(revision.Contents)
+	if revision.ContentsEnc64 {
+		content, err = base64.StdEncoding.DecodeString(revision.Contents)
+		if err != nil {
+			resp.Diagnostics.AddError("Unable to Read Uyuni configuration file", fmt.Sprintf("could not decode %s: %s", revision.Path, err))
+			return
+		}
+	}
+	checksum, err := revisionSHA256(*revision)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to Read Uyuni configuration file", err.Error())
+		return
+	}
+
+	state.Revision = types.Int64Value(int64(revision.Revision))
+	state.Type = types.StringValue(revision.Type)
+	state.Binary = types.BoolValue(revision.Binary)
+	state.Content = types.StringValue(string(content))
+	if revision.Binary {
+		state.Content = types.StringNull()
+	}
+	state.ContentBase64 = types.StringValue(base64.StdEncoding.EncodeToString(content))
+	state.TargetPath = nonEmptyString(revision.TargetPath)
+	state.Owner = nonEmptyString(revision.Owner)
+	state.Group = nonEmptyString(revision.Group)
+	state.Permissions = nonEmptyString(revision.PermissionsMode)
+	state.SELinuxCtx = nonEmptyString(revision.SELinuxCtx)
+	state.SHA256 = types.StringValue(checksum)
+	state.Modified = timestampValue(ctx, revision.Modified)
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// nonEmptyString converts an API string into a string attribute, null when
+// the API omitted it.
+func nonEmptyString(value string) types.String {
+	if value == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(value)
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *ConfigFileContentDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*uyuniClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *uyuniClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
@@ -0,0 +1,141 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func readConfigFileContent(t *testing.T, attrs map[string]tftypes.Value) (ConfigFileContentDataSourceModel, diag.Diagnostics) {
+	ctx := context.Background()
+	client := testAPIClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/configchannel/getFileRevisions" || r.URL.Query().Get("channelLabel") != "base" {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		switch r.URL.Query().Get("filePath") {
+		case "/etc/motd":
+			_, _ = w.Write([]byte(`{"success": true, "result": [
+				{"type": "file", "path": "/etc/motd", "channel": "base", "contents": "Welcome\n", "contents_enc64": false, "revision": 1,
+				 "creation": "2024-11-02T08:45:37Z", "modified": "2024-11-02T08:45:37Z", "owner": "root", "group": "root",
+				 "permissions": 644, "permissions_mode": "644", "binary": false},
+				{"type": "file", "path": "/etc/motd", "channel": "base", "contents": "V2VsY29tZSB0byBwcm9kCg==", "contents_enc64": true, "revision": 2,
+				 "creation": "2025-01-14T10:02:11Z", "modified": "2025-01-14T10:02:11Z", "owner": "root", "group": "root",
+				 "permissions": 644, "permissions_mode": "644", "binary": false}
+			]}`))
+		case "/etc/krb5.keytab":
+			_, _ = w.Write([]byte(`{"success": true, "result": [
+				{"type": "file", "path": "/etc/krb5.keytab", "channel": "base", "contents": "BQIAAA==", "contents_enc64": true, "revision": 1,
+				 "creation": "2025-01-14T10:02:11Z", "modified": "2025-01-14T10:02:11Z", "owner": "root", "group": "root",
+				 "permissions": 600, "permissions_mode": "600", "binary": true}
+			]}`))
+		default:
+			_, _ = w.Write([]byte(`{"success": false, "message": "No such file"}`))
+		}
+	})
+
+	d := NewConfigFileContentDataSource()
+	d.(datasource.DataSourceWithConfigure).Configure(ctx, datasource.ConfigureRequest{ProviderData: client}, &datasource.ConfigureResponse{})
+	var schemaResp datasource.SchemaResponse
+	d.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	values := map[string]tftypes.Value{}
+	for name, attributeType := range objectType.AttributeTypes {
+		values[name] = tftypes.NewValue(attributeType, nil)
+	}
+	for name, value := range attrs {
+		values[name] = value
+	}
+	config := tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, values)}
+	resp := &datasource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: config.Raw}}
+	d.Read(ctx, datasource.ReadRequest{Config: config}, resp)
+
+	var state ConfigFileContentDataSourceModel
+	if !resp.Diagnostics.HasError() {
+		resp.State.Get(ctx, &state)
+	}
+	return state, resp.Diagnostics
+}
+
+func TestConfigFileContentDataSource(t *testing.T) {
+	state, diags := readConfigFileContent(t, map[string]tftypes.Value{
+		"channel": tftypes.NewValue(tftypes.String, "base"),
+		"path":    tftypes.NewValue(tftypes.String, "/etc/motd"),
+	})
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+	if state.Revision.ValueInt64() != 2 || state.Content.ValueString() != "Welcome to prod\n" {
+		t.Errorf("expected the decoded latest revision, got revision %d with %q", state.Revision.ValueInt64(), state.Content.ValueString())
+	}
+	if state.Permissions.ValueString() != "644" || state.Owner.ValueString() != "root" || !state.TargetPath.IsNull() {
+		t.Errorf("unexpected metadata %v", state)
+	}
+	if state.SHA256.ValueString() == "" || state.Modified.ValueString() != "2025-01-14T10:02:11Z" {
+		t.Errorf("unexpected checksum %q or modification date %q", state.SHA256.ValueString(), state.Modified.ValueString())
+	}
+}
+
+func TestConfigFileContentDataSourceRevision(t *testing.T) {
+	state, diags := readConfigFileContent(t, map[string]tftypes.Value{
+		"channel":  tftypes.NewValue(tftypes.String, "base"),
+		"path":     tftypes.NewValue(tftypes.String, "/etc/motd"),
+		"revision": tftypes.NewValue(tftypes.Number, 1),
+	})
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+	if state.Content.ValueString() != "Welcome\n" || state.ContentBase64.ValueString() != "V2VsY29tZQo=" {
+		t.Errorf("expected revision 1, got %q", state.Content.ValueString())
+	}
+
+	_, diags = readConfigFileContent(t, map[string]tftypes.Value{
+		"channel":  tftypes.NewValue(tftypes.String, "base"),
+		"path":     tftypes.NewValue(tftypes.String, "/etc/motd"),
+		"revision": tftypes.NewValue(tftypes.Number, 3),
+	})
+	if !diags.HasError() {
+		t.Error("expected an error for a missing revision")
+	}
+}
+
+func TestConfigFileContentDataSourceBinary(t *testing.T) {
+	state, diags := readConfigFileContent(t, map[string]tftypes.Value{
+		"channel": tftypes.NewValue(tftypes.String, "base"),
+		"path":    tftypes.NewValue(tftypes.String, "/etc/krb5.keytab"),
+	})
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+	if !state.Binary.ValueBool() || !state.Content.IsNull() || state.ContentBase64.ValueString() != "BQIAAA==" {
+		t.Errorf("expected only base64 content for binary files, got %v", state)
+	}
+}
+
+func TestAccConfigFileContentDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccRealServerPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccConfigFileResourceConfig("Read by the acceptance tests\n") + `
+data "uyuni_config_file_content" "test" {
+  channel = uyuni_config_file.test.channel
+  path    = uyuni_config_file.test.path
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.uyuni_config_file_content.test", "content", "Read by the acceptance tests\n"),
+					resource.TestCheckResourceAttr("data.uyuni_config_file_content.test", "revision", "1"),
+					resource.TestCheckResourceAttr("data.uyuni_config_file_content.test", "binary", "false"),
+				),
+			},
+		},
+	})
+}
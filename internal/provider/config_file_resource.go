@@ -0,0 +1,701 @@
+package provider
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"terraform-provider-uyuni/internal/uyuni"
+	"terraform-provider-uyuni/internal/validators"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                     = &configFileResource{}
+	_ resource.ResourceWithConfigure        = &configFileResource{}
+	_ resource.ResourceWithImportState      = &configFileResource{}
+	_ resource.ResourceWithConfigValidators = &configFileResource{}
+	_ resource.ResourceWithModifyPlan       = &configFileResource{}
+)
+
+// configFileTypeDirectory is the type of directories in configuration
+// channels.
+const configFileTypeDirectory = "directory"
+
+// NewConfigFileResource is a helper function to simplify the provider implementation.
+func NewConfigFileResource() resource.Resource {
+	return &configFileResource{}
+}
+
+// configFileResource is the resource implementation.
+type configFileResource struct {
+	client *uyuniClient
+}
+
+// configFileResourceModel maps the resource schema data.
+type configFileResourceModel struct {
+	ID                  types.String   `tfsdk:"id"`
+	Channel             types.String   `tfsdk:"channel"`
+	Path                types.String   `tfsdk:"path"`
+	Directory           types.Bool     `tfsdk:"directory"`
+	Content             types.String   `tfsdk:"content"`
+	ContentBase64       types.String   `tfsdk:"content_base64"`
+	SourceFile          types.String   `tfsdk:"source_file"`
+	Binary              types.Bool     `tfsdk:"binary"`
+	Owner               types.String   `tfsdk:"owner"`
+	Group               types.String   `tfsdk:"group"`
+	Permissions         types.String   `tfsdk:"permissions"`
+	SELinuxCtx          types.String   `tfsdk:"selinux_ctx"`
+	MacroStartDelimiter types.String   `tfsdk:"macro_start_delimiter"`
+	MacroEndDelimiter   types.String   `tfsdk:"macro_end_delimiter"`
+	PinnedRevision      types.Int64    `tfsdk:"pinned_revision"`
+	KeepRevisions       types.Int64    `tfsdk:"keep_revisions"`
+	Revision            types.Int64    `tfsdk:"revision"`
+	SHA256              types.String   `tfsdk:"sha256"`
+	ServerAlias         types.String   `tfsdk:"server_alias"`
+	Org                 *orgModel      `tfsdk:"org"`
+	Timeouts            timeouts.Value `tfsdk:"timeouts"`
+}
+
+// Metadata returns the resource type name.
+func (r *configFileResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_config_file"
+}
+
+// Schema defines the schema for the resource.
+func (r *configFileResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	// Attributes the server defaults when they are not set.
+	serverDefault := func(description string) schema.StringAttribute {
+		return schema.StringAttribute{
+			Description: description,
+			Optional:    true,
+			Computed:    true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		}
+	}
+	permissions := serverDefault("Octal permissions of the file when deployed, e.g. `600`. Defaults to `644` for files and `755` for directories.")
+	permissions.Validators = []validator.String{
+		stringvalidator.RegexMatches(regexp.MustCompile(`^[0-7]{3,4}$`), "must be octal permissions, e.g. 644"),
+	}
+
+	resp.Schema = schema.Schema{
+		Description: "Manages a file or directory in a configuration channel, e.g. a Salt state in a channel of type `state`. " +
+			"Every change creates a new revision of the file. Changes made outside of Terraform, e.g. in the web UI, show up " +
+			"as a difference of the content in the plan.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Channel label and path, separated by a colon.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"channel": schema.StringAttribute{
+				Description: "Label of the configuration channel.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"path": schema.StringAttribute{
+				Description: "Absolute path of the file, e.g. `/etc/motd`, or `/init.sls` for the Salt state of a state channel.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^/\S+$`), "must be an absolute path"),
+				},
+			},
+			"directory": schema.BoolAttribute{
+				Description: "Whether the path is a directory rather than a file. Defaults to false.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"content": schema.StringAttribute{
+				Description: "Content of a text file, e.g. read with `file()` or rendered with `templatefile()`. It may contain " +
+					"macros between the macro delimiters, which the server replaces when deploying the file.",
+				Optional: true,
+			},
+			"content_base64": schema.StringAttribute{
+				Description: "Content of a binary file, base64 encoded, e.g. read with `filebase64()`.",
+				Optional:    true,
+			},
+			"source_file": schema.StringAttribute{
+				Description: "Path of a local file to upload instead of content, e.g. a certificate or a keytab. The file is " +
+					"read on every plan and its content is not kept in the state; changes of the local file and of the file " +
+					"on the server show up as a difference of sha256.",
+				Optional: true,
+			},
+			"binary": schema.BoolAttribute{
+				Description: "Whether the file is stored as a binary file, which the server deploys as it is without replacing " +
+					"macros. Defaults to true for content_base64, to whether source_file is not UTF-8 text for source_file, " +
+					"and to false otherwise.",
+				Optional: true,
+				Computed: true,
+			},
+			"owner":                 serverDefault("Owner of the file when deployed. Defaults to `root`."),
+			"group":                 serverDefault("Group of the file when deployed. Defaults to `root`."),
+			"permissions":           permissions,
+			"selinux_ctx":           serverDefault("SELinux context of the file when deployed."),
+			"macro_start_delimiter": serverDefault("Delimiter starting the macros in the content of a text file. Defaults to `{|`."),
+			"macro_end_delimiter":   serverDefault("Delimiter ending the macros in the content of a text file. Defaults to `|}`."),
+			"pinned_revision": schema.Int64Attribute{
+				Description: "Revision to roll the file back to instead of setting its content, e.g. to undo a change. Its " +
+					"content is written as a new revision whenever the latest revision has other content, which shows up " +
+					"as a change of pinned_revision to the latest revision. Ownership and permissions remain set by their " +
+					"attributes.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"keep_revisions": schema.Int64Attribute{
+				Description: "Number of latest revisions to keep, older ones except pinned_revision are deleted whenever " +
+					"the file is written. Defaults to keeping all revisions.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"revision": schema.Int64Attribute{
+				Description: "Latest revision of the file.",
+				Computed:    true,
+			},
+			"sha256": schema.StringAttribute{
+				Description: "SHA-256 checksum of the content. Null for directories.",
+				Computed:    true,
+			},
+			"server_alias": serverAliasAttribute(),
+		},
+		Blocks: map[string]schema.Block{
+			"org": orgBlock(),
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+// ConfigValidators returns the validators checking attributes against each other.
+func (r *configFileResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.Conflicting(
+			path.MatchRoot("content"),
+			path.MatchRoot("content_base64"),
+			path.MatchRoot("source_file"),
+			path.MatchRoot("pinned_revision"),
+		),
+		resourcevalidator.Conflicting(
+			path.MatchRoot("binary"),
+			path.MatchRoot("pinned_revision"),
+		),
+		validators.ConflictsWhenTrue("directory", "content", "content_base64", "source_file", "binary", "pinned_revision"),
+	}
+}
+
+// contents returns the content of the model, read from source_file if it is
+// set.
+func (m *configFileResourceModel) contents() ([]byte, error) {
+	switch {
+	case !m.SourceFile.IsNull():
+		content, err := os.ReadFile(expandHome(m.SourceFile.ValueString()))
+		if err != nil {
+			return nil, fmt.Errorf("could not read source_file: %w", err)
+		}
+		return content, nil
+	case !m.ContentBase64.IsNull():
+		content, err := base64.StdEncoding.DecodeString(m.ContentBase64.ValueString())
+		if err != nil {
+			return nil, fmt.Errorf("content_base64 is not base64 encoded: %w", err)
+		}
+		return content, nil
+	}
+	return []byte(m.Content.ValueString()), nil
+}
+
+// binary returns whether the file with the given content is stored as binary,
+// as planned or by default.
+func (m *configFileResourceModel) binary(content []byte) bool {
+	if !m.Binary.IsNull() && !m.Binary.IsUnknown() {
+		return m.Binary.ValueBool()
+	}
+	switch {
+	case m.Directory.ValueBool():
+		return false
+	case !m.ContentBase64.IsNull():
+		return true
+	case !m.SourceFile.IsNull():
+		return !utf8.Valid(content)
+	}
+	return false
+}
+
+// plannedContents returns the content to write and whether it is binary,
+// taken from pinned_revision if it is set.
+func (m *configFileResourceModel) plannedContents(ctx context.Context, client *uyuniClient) ([]byte, bool, error) {
+	if m.PinnedRevision.IsNull() {
+		content, err := m.contents()
+		return content, m.binary(content), err
+	}
+	revision, err := fileRevision(ctx, client, m.Channel.ValueString(), m.Path.ValueString(), int(m.PinnedRevision.ValueInt64()))
+	if err != nil {
+		return nil, false, err
+	}
+	if revision == nil {
+		return nil, false, fmt.Errorf("there is no revision %d to pin", m.PinnedRevision.ValueInt64())
+	}
+	content, err := revisionContents(*revision)
+	return content, revision.Binary, err
+}
+
+// pathInfo returns the path info of configchannel.createOrUpdatePath for the
+// model with the given content. Attributes left to the server are omitted.
+func (m *configFileResourceModel) pathInfo(content []byte, binary bool) map[string]interface{} {
+	info := map[string]interface{}{}
+	if !m.Directory.ValueBool() {
+		// Content not given as text, e.g. read from a file, is encoded, as
+		// it may contain characters the JSON of the API does not keep.
+		if binary || m.Content.IsNull() {
+			info["contents"] = base64.StdEncoding.EncodeToString(content)
+			info["contents_enc64"] = true
+		} else {
+			info["contents"] = string(content)
+		}
+		if binary {
+			info["binary"] = true
+		}
+	}
+	for key, value := range map[string]types.String{
+		"owner":                 m.Owner,
+		"group":                 m.Group,
+		"permissions":           m.Permissions,
+		"selinux_ctx":           m.SELinuxCtx,
+		"macro-start-delimiter": m.MacroStartDelimiter,
+		"macro-end-delimiter":   m.MacroEndDelimiter,
+	} {
+		if !value.IsNull() && !value.IsUnknown() {
+			info[key] = value.ValueString()
+		}
+	}
+	return info
+}
+
+// setFromRevision sets the attributes of the file from the revision, without
+// its content.
+func (m *configFileResourceModel) setFromRevision(revision *uyuni.ConfigRevision) {
+	m.ID = types.StringValue(m.Channel.ValueString() + importIDSeparator + m.Path.ValueString())
+	m.Directory = types.BoolValue(revision.Type == configFileTypeDirectory)
+	m.Owner = types.StringValue(revision.Owner)
+	m.Group = types.StringValue(revision.Group)
+	m.Permissions = types.StringValue(revision.PermissionsMode)
+	m.SELinuxCtx = types.StringValue(revision.SELinuxCtx)
+	m.MacroStartDelimiter = types.StringValue(revision.MacroStartDelim)
+	m.MacroEndDelimiter = types.StringValue(revision.MacroEndDelim)
+	m.Binary = types.BoolValue(revision.Binary)
+	m.Revision = types.Int64Value(int64(revision.Revision))
+}
+
+// write creates or updates the file from the model, which creates a new
+// revision, and prunes the old revisions.
+func (m *configFileResourceModel) write(ctx context.Context, client *uyuniClient) error {
+	content, binary, err := m.plannedContents(ctx, client)
+	if err != nil {
+		return err
+	}
+	revision, err := apiPost[uyuni.ConfigRevision](ctx, client, "configchannel/createOrUpdatePath", map[string]interface{}{
+		"configChannelLabel": m.Channel.ValueString(),
+		"path":               m.Path.ValueString(),
+		"isDir":              m.Directory.ValueBool(),
+		"pathInfo":           m.pathInfo(content, binary),
+	})
+	if err != nil {
+		return err
+	}
+	// The checksum and the binary flag are taken from the planned content,
+	// as the content in the response may differ in encoding.
+	m.setFromRevision(&revision.Result)
+	m.Binary = types.BoolValue(binary)
+	m.SHA256 = types.StringNull()
+	if !m.Directory.ValueBool() {
+		m.SHA256 = types.StringValue(contentSHA256(string(content)))
+	}
+	tflog.Info(ctx, fmt.Sprintf("Wrote revision %d of %s in configuration channel %s", revision.Result.Revision, m.Path.ValueString(), m.Channel.ValueString()))
+	return m.prune(ctx, client)
+}
+
+// prune deletes the revisions beyond keep_revisions, except pinned_revision.
+func (m *configFileResourceModel) prune(ctx context.Context, client *uyuniClient) error {
+	if m.KeepRevisions.IsNull() {
+		return nil
+	}
+	label, filePath := m.Channel.ValueString(), m.Path.ValueString()
+	revisions, err := fileRevisions(ctx, client, label, filePath)
+	if err != nil {
+		return fmt.Errorf("could not list revisions: %w", err)
+	}
+	numbers := make([]int, 0, len(revisions))
+	for _, revision := range revisions {
+		numbers = append(numbers, revision.Revision)
+	}
+	sort.Ints(numbers)
+
+	keep := len(numbers) - int(m.KeepRevisions.ValueInt64())
+	var pruned []int
+	for i, number := range numbers {
+		if i < keep && int64(number) != m.PinnedRevision.ValueInt64() {
+			pruned = append(pruned, number)
+		}
+	}
+	if len(pruned) == 0 {
+		return nil
+	}
+	_, err = apiPost[int](ctx, client, "configchannel/deleteFileRevisions", map[string]interface{}{
+		"channelLabel": label,
+		"filePath":     filePath,
+		"revisions":    pruned,
+	})
+	if err != nil {
+		return fmt.Errorf("could not delete revisions %v: %w", pruned, err)
+	}
+	tflog.Info(ctx, fmt.Sprintf("Deleted revisions %v of %s in configuration channel %s", pruned, filePath, label))
+	return nil
+}
+
+// rewrites reports whether applying the model to the file in state writes
+// a new revision, rather than only pruning old ones.
+func (m *configFileResourceModel) rewrites(state *configFileResourceModel) bool {
+	for _, pair := range [][2]attr.Value{
+		{m.Content, state.Content},
+		{m.ContentBase64, state.ContentBase64},
+		{m.SourceFile, state.SourceFile},
+		{m.PinnedRevision, state.PinnedRevision},
+		{m.Binary, state.Binary},
+		{m.Owner, state.Owner},
+		{m.Group, state.Group},
+		{m.Permissions, state.Permissions},
+		{m.SELinuxCtx, state.SELinuxCtx},
+		{m.MacroStartDelimiter, state.MacroStartDelimiter},
+		{m.MacroEndDelimiter, state.MacroEndDelimiter},
+	} {
+		if !pair[0].Equal(pair[1]) {
+			return true
+		}
+	}
+	// The checksum of source_file is planned.
+	return !m.SHA256.IsUnknown() && !m.SHA256.Equal(state.SHA256)
+}
+
+// Create a new resource.
+func (r *configFileResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan configFileResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	client := resourceClient(ctx, r.client, plan.ServerAlias, plan.Org, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	if err := plan.write(ctx, client); err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating configuration file",
+			fmt.Sprintf("Could not create %s in configuration channel %s: %s", plan.Path.ValueString(), plan.Channel.ValueString(), err),
+		)
+		return
+	}
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read resource information. The content is refreshed from the latest
+// revision, so that changes made outside of Terraform show up in the plan.
+func (r *configFileResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Get current state
+	var state configFileResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := resourceClient(ctx, r.client, state.ServerAlias, state.Org, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	label, filePath := state.Channel.ValueString(), state.Path.ValueString()
+	revisions, err := fileRevisions(ctx, client, label, filePath)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Uyuni configuration file",
+			fmt.Sprintf("Could not read %s in configuration channel %s: %s", filePath, label, err),
+		)
+		return
+	}
+	revision := findRevision(revisions, 0)
+	if revision == nil {
+		tflog.Warn(ctx, fmt.Sprintf("Configuration channel %s no longer has %s, removing it from state", label, filePath))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	state.setFromRevision(revision)
+
+	state.SHA256 = types.StringNull()
+	if revision.Type != configFileTypeDirectory {
+		content, err := revisionContents(*revision)
+		if err != nil {
+			resp.Diagnostics.AddError("Error Reading Uyuni configuration file", err.Error())
+			return
+		}
+		checksum, err := revisionSHA256(*revision)
+		if err != nil {
+			resp.Diagnostics.AddError("Error Reading Uyuni configuration file", err.Error())
+			return
+		}
+		state.SHA256 = types.StringValue(checksum)
+		// A latest revision with other content than the pinned one, or a
+		// pinned revision deleted meanwhile, shows up as a change of
+		// pinned_revision.
+		if !state.PinnedRevision.IsNull() {
+			pinned := findRevision(revisions, int(state.PinnedRevision.ValueInt64()))
+			if pinned == nil {
+				state.PinnedRevision = types.Int64Value(int64(revision.Revision))
+			} else if pinnedChecksum, err := revisionSHA256(*pinned); err != nil || pinnedChecksum != checksum {
+				state.PinnedRevision = types.Int64Value(int64(revision.Revision))
+			}
+		}
+		// Empty files keep a null content, as they are created without, and
+		// files uploaded from source_file or pinned to a revision keep theirs
+		// out of the state.
+		switch {
+		case !state.SourceFile.IsNull() || !state.PinnedRevision.IsNull():
+		case revision.Binary || !state.ContentBase64.IsNull():
+			state.ContentBase64 = types.StringValue(base64.StdEncoding.EncodeToString(content))
+			state.Content = types.StringNull()
+		case len(content) > 0 || !state.Content.IsNull():
+			state.Content = types.StringValue(string(content))
+		}
+	}
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// ModifyPlan plans the checksum of source_file, so that changes of the local
+// file and of the file on the server both show up in the plan, and the binary
+// flag when it is not configured.
+func (r *configFileResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to plan on destroy.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan configFileResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	var binary types.Bool
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("binary"), &binary)...)
+	if resp.Diagnostics.HasError() || plan.SourceFile.IsUnknown() || plan.Directory.IsUnknown() {
+		return
+	}
+
+	var content []byte
+	if !plan.SourceFile.IsNull() {
+		var err error
+		if content, err = plan.contents(); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("source_file"), "Invalid Source File", err.Error())
+			return
+		}
+		plan.SHA256 = types.StringValue(contentSHA256(string(content)))
+	}
+	// Without configuration the flag follows the content rather than the
+	// state, so that a file changed on the server is uploaded again. A
+	// pinned revision keeps its flag.
+	switch {
+	case !plan.PinnedRevision.IsNull():
+		var state configFileResourceModel
+		plan.Binary = types.BoolUnknown()
+		if !req.State.Raw.IsNull() {
+			resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+			if plan.PinnedRevision.Equal(state.PinnedRevision) {
+				plan.Binary = state.Binary
+			}
+		}
+	case binary.IsNull():
+		plan.Binary = types.BoolNull()
+		plan.Binary = types.BoolValue(plan.binary(content))
+	}
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
+// Update writes a new revision of the file. Changing only keep_revisions
+// prunes the revisions without writing a new one.
+func (r *configFileResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Retrieve values from plan
+	var plan, state configFileResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	client := resourceClient(ctx, r.client, plan.ServerAlias, plan.Org, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	var err error
+	if plan.rewrites(&state) {
+		err = plan.write(ctx, client)
+	} else {
+		plan.ID, plan.Revision, plan.SHA256 = state.ID, state.Revision, state.SHA256
+		err = plan.prune(ctx, client)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating configuration file",
+			fmt.Sprintf("Could not update %s in configuration channel %s: %s", plan.Path.ValueString(), plan.Channel.ValueString(), err),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete removes the file with all its revisions.
+func (r *configFileResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Retrieve values from state
+	var state configFileResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	client := resourceClient(ctx, r.client, state.ServerAlias, state.Org, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	label, filePath := state.Channel.ValueString(), state.Path.ValueString()
+	_, err := apiPost[int](ctx, client, "configchannel/deleteFiles", map[string]interface{}{
+		"configChannelLabel": label,
+		"filePaths":          []string{filePath},
+	})
+	if err != nil && !isNotFoundError(err) {
+		resp.Diagnostics.AddError(
+			"Error Deleting Uyuni configuration file",
+			fmt.Sprintf("Could not delete %s in configuration channel %s: %s", filePath, label, err),
+		)
+		return
+	}
+}
+
+// ImportState imports a file by the channel label and its path, e.g.
+// "motd:/etc/motd". Only the first colon separates them, as paths may
+// contain colons.
+func (r *configFileResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	label, filePath, ok := strings.Cut(req.ID, importIDSeparator)
+	if !ok || label == "" || filePath == "" {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("expected import ID in the format %q, got: %q", "channel"+importIDSeparator+"path", req.ID),
+		)
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("channel"), label)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("path"), filePath)...)
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *configFileResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*uyuniClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *uyuniClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
@@ -0,0 +1,445 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	acctest "github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestConfigFileCreateSendsPathInfo(t *testing.T) {
+	ctx := context.Background()
+	var body struct {
+		ConfigChannelLabel string                 `json:"configChannelLabel"`
+		Path               string                 `json:"path"`
+		IsDir              bool                   `json:"isDir"`
+		PathInfo           map[string]interface{} `json:"pathInfo"`
+	}
+	r := NewConfigFileResource()
+	testConfigure(t, r, testAPIClient(t, func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/configchannel/createOrUpdatePath" {
+			t.Errorf("unexpected request %s", req.URL)
+		}
+		_ = json.NewDecoder(req.Body).Decode(&body)
+		_, _ = w.Write([]byte(`{"success": true, "result": {"type": "file", "path": "/etc/motd", "channel": "motd", "revision": 3,
+			"owner": "root", "group": "root", "permissions": 420, "permissions_mode": "644", "binary": false,
+			"macro-start-delimiter": "{|", "macro-end-delimiter": "|}"}}`))
+	}))
+
+	planned := testState(t, r, map[string]interface{}{
+		"channel":   "motd",
+		"path":      "/etc/motd",
+		"directory": false,
+		"content":   "Welcome to {| rhn.system.hostname |}\n",
+		"owner":     "root",
+	})
+	resp := &resource.CreateResponse{State: planned}
+	r.Create(ctx, resource.CreateRequest{Plan: tfsdk.Plan{Schema: planned.Schema, Raw: planned.Raw}}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+	if body.ConfigChannelLabel != "motd" || body.Path != "/etc/motd" || body.IsDir {
+		t.Errorf("unexpected request body %+v", body)
+	}
+	// Attributes left to the server are not sent.
+	if body.PathInfo["contents"] != "Welcome to {| rhn.system.hostname |}\n" || body.PathInfo["owner"] != "root" || len(body.PathInfo) != 2 {
+		t.Errorf("unexpected path info %v", body.PathInfo)
+	}
+
+	var state configFileResourceModel
+	resp.State.Get(ctx, &state)
+	if state.ID.ValueString() != "motd:/etc/motd" || state.Revision.ValueInt64() != 3 || state.Permissions.ValueString() != "644" ||
+		state.MacroStartDelimiter.ValueString() != "{|" {
+		t.Errorf("unexpected state %v", state)
+	}
+	// The checksum of "Welcome to {| rhn.system.hostname |}\n".
+	if state.SHA256.ValueString() != "6bc0ec23ae580e34de4192d0fd91aab1900fa8e8404eeaad03710793ffcf79f0" {
+		t.Errorf("unexpected checksum %s", state.SHA256.ValueString())
+	}
+}
+
+func TestConfigFileReadDetectsChangedContent(t *testing.T) {
+	client := testAPIClient(t, func(w http.ResponseWriter, req *http.Request) {
+		// Revision 4 was edited in the web UI.
+		_, _ = w.Write([]byte(`{"success": true, "result": [
+			{"type": "file", "path": "/etc/motd", "contents": "Welcome\n", "revision": 3, "owner": "root", "permissions_mode": "644"},
+			{"type": "file", "path": "/etc/motd", "contents": "SGFja2VkCg==", "contents_enc64": true, "revision": 4,
+			 "owner": "root", "group": "root", "permissions_mode": "600"}]}`))
+	})
+
+	resp := testRead(t, NewConfigFileResource(), client, map[string]interface{}{
+		"channel":   "motd",
+		"path":      "/etc/motd",
+		"directory": false,
+		"content":   "Welcome\n",
+		"revision":  3,
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+	var state configFileResourceModel
+	resp.State.Get(context.Background(), &state)
+	if state.Content.ValueString() != "Hacked\n" || state.Revision.ValueInt64() != 4 || state.Permissions.ValueString() != "600" {
+		t.Errorf("expected the edited revision, got %v", state)
+	}
+}
+
+func TestConfigFileReadRemovesDeletedFile(t *testing.T) {
+	client := testAPIClient(t, func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(`{"success": false, "message": "No such file: /etc/motd"}`))
+	})
+
+	resp := testRead(t, NewConfigFileResource(), client, map[string]interface{}{
+		"channel": "motd",
+		"path":    "/etc/motd",
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+	if !resp.State.Raw.IsNull() {
+		t.Error("expected the file to be removed from state")
+	}
+}
+
+func TestConfigFileImportState(t *testing.T) {
+	ctx := context.Background()
+	r := NewConfigFileResource()
+	state := testState(t, r, nil)
+	resp := &resource.ImportStateResponse{State: state}
+	r.(resource.ResourceWithImportState).ImportState(ctx, resource.ImportStateRequest{ID: "motd:/etc/issue:net"}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+	var model configFileResourceModel
+	resp.State.Get(ctx, &model)
+	if model.Channel.ValueString() != "motd" || model.Path.ValueString() != "/etc/issue:net" {
+		t.Errorf("unexpected import %v", model)
+	}
+}
+
+func TestConfigFilePlansSourceFile(t *testing.T) {
+	ctx := context.Background()
+	source := filepath.Join(t.TempDir(), "krb5.keytab")
+	if err := os.WriteFile(source, []byte{0x05, 0x02, 0x00, 0xff}, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	r := NewConfigFileResource()
+	// The state holds the checksum of the file on the server.
+	state := testState(t, r, map[string]interface{}{
+		"channel":     "kerberos",
+		"path":        "/etc/krb5.keytab",
+		"directory":   false,
+		"source_file": source,
+		"sha256":      "b5bb9d8014a0f9b1d61e21e796d78dccdf1352f23cd32812f4850b878ae4944c",
+	})
+	planned := testState(t, r, map[string]interface{}{
+		"channel":     "kerberos",
+		"path":        "/etc/krb5.keytab",
+		"directory":   false,
+		"source_file": source,
+		"sha256":      "b5bb9d8014a0f9b1d61e21e796d78dccdf1352f23cd32812f4850b878ae4944c",
+	})
+
+	resp := &resource.ModifyPlanResponse{Plan: tfsdk.Plan{Schema: planned.Schema, Raw: planned.Raw}}
+	r.(resource.ResourceWithModifyPlan).ModifyPlan(ctx, resource.ModifyPlanRequest{
+		Config: tfsdk.Config{Schema: planned.Schema, Raw: planned.Raw},
+		Plan:   tfsdk.Plan{Schema: planned.Schema, Raw: planned.Raw},
+		State:  state,
+	}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+	var plan configFileResourceModel
+	resp.Plan.Get(ctx, &plan)
+	// The checksum of the local file differs, so the file is uploaded again,
+	// as binary since it is not UTF-8 text.
+	if plan.SHA256.ValueString() != contentSHA256("\x05\x02\x00\xff") || !plan.Binary.ValueBool() {
+		t.Errorf("unexpected plan %v", plan)
+	}
+
+	if err := os.Remove(source); err != nil {
+		t.Fatal(err)
+	}
+	resp = &resource.ModifyPlanResponse{Plan: tfsdk.Plan{Schema: planned.Schema, Raw: planned.Raw}}
+	r.(resource.ResourceWithModifyPlan).ModifyPlan(ctx, resource.ModifyPlanRequest{
+		Config: tfsdk.Config{Schema: planned.Schema, Raw: planned.Raw},
+		Plan:   tfsdk.Plan{Schema: planned.Schema, Raw: planned.Raw},
+		State:  state,
+	}, resp)
+	if !resp.Diagnostics.HasError() {
+		t.Error("expected a missing source_file to be reported")
+	}
+}
+
+func TestConfigFileUploadsSourceFile(t *testing.T) {
+	ctx := context.Background()
+	source := filepath.Join(t.TempDir(), "ca.pem")
+	if err := os.WriteFile(source, []byte("-----BEGIN CERTIFICATE-----\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	var pathInfo map[string]interface{}
+	r := NewConfigFileResource()
+	testConfigure(t, r, testAPIClient(t, func(w http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/configchannel/createOrUpdatePath":
+			var body struct {
+				PathInfo map[string]interface{} `json:"pathInfo"`
+			}
+			_ = json.NewDecoder(req.Body).Decode(&body)
+			pathInfo = body.PathInfo
+			_, _ = w.Write([]byte(`{"success": true, "result": {"type": "file", "path": "/etc/pki/ca.pem", "revision": 1,
+				"owner": "root", "group": "root", "permissions_mode": "644", "binary": false}}`))
+		case "/configchannel/getFileRevisions":
+			_, _ = w.Write([]byte(`{"success": true, "result": [{"type": "file", "path": "/etc/pki/ca.pem",
+				"contents": "LS0tLS1CRUdJTiBDRVJUSUZJQ0FURS0tLS0tCg==", "contents_enc64": true, "revision": 1,
+				"owner": "root", "group": "root", "permissions_mode": "644", "binary": false}]}`))
+		default:
+			t.Errorf("unexpected request %s", req.URL)
+		}
+	}))
+
+	planned := testState(t, r, map[string]interface{}{
+		"channel":     "pki",
+		"path":        "/etc/pki/ca.pem",
+		"directory":   false,
+		"source_file": source,
+	})
+	resp := &resource.CreateResponse{State: planned}
+	r.Create(ctx, resource.CreateRequest{Plan: tfsdk.Plan{Schema: planned.Schema, Raw: planned.Raw}}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+	if pathInfo["contents"] != "LS0tLS1CRUdJTiBDRVJUSUZJQ0FURS0tLS0tCg==" || pathInfo["contents_enc64"] != true || pathInfo["binary"] != nil {
+		t.Errorf("unexpected path info %v", pathInfo)
+	}
+
+	// The content read back stays out of the state.
+	readResp := &resource.ReadResponse{State: resp.State}
+	r.Read(ctx, resource.ReadRequest{State: resp.State}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatal(readResp.Diagnostics)
+	}
+	var state configFileResourceModel
+	readResp.State.Get(ctx, &state)
+	if !state.Content.IsNull() || !state.ContentBase64.IsNull() || state.Binary.ValueBool() ||
+		state.SHA256.ValueString() != contentSHA256("-----BEGIN CERTIFICATE-----\n") {
+		t.Errorf("unexpected state %v", state)
+	}
+}
+
+// testRevisions answers getFileRevisions with revision 3 and the edited
+// revision 4 of /etc/motd.
+const testRevisions = `{"success": true, "result": [
+	{"type": "file", "path": "/etc/motd", "contents": "Welcome\n", "revision": 3, "owner": "root", "permissions_mode": "644"},
+	{"type": "file", "path": "/etc/motd", "contents": "SGFja2VkCg==", "contents_enc64": true, "revision": 4,
+	 "owner": "root", "group": "root", "permissions_mode": "600"}]}`
+
+func TestConfigFileWritesPinnedRevision(t *testing.T) {
+	ctx := context.Background()
+	var pathInfo map[string]interface{}
+	r := NewConfigFileResource()
+	testConfigure(t, r, testAPIClient(t, func(w http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/configchannel/getFileRevisions":
+			_, _ = w.Write([]byte(testRevisions))
+		case "/configchannel/createOrUpdatePath":
+			var body struct {
+				PathInfo map[string]interface{} `json:"pathInfo"`
+			}
+			_ = json.NewDecoder(req.Body).Decode(&body)
+			pathInfo = body.PathInfo
+			_, _ = w.Write([]byte(`{"success": true, "result": {"type": "file", "path": "/etc/motd", "revision": 5,
+				"owner": "root", "group": "root", "permissions_mode": "644", "binary": false}}`))
+		default:
+			t.Errorf("unexpected request %s", req.URL)
+		}
+	}))
+
+	attributes := map[string]interface{}{
+		"id":              "motd:/etc/motd",
+		"channel":         "motd",
+		"path":            "/etc/motd",
+		"directory":       false,
+		"pinned_revision": 4,
+		"binary":          false,
+		"revision":        4,
+	}
+	state := testState(t, r, attributes)
+	attributes["pinned_revision"] = 3
+	planned := testState(t, r, attributes)
+	resp := &resource.UpdateResponse{State: state}
+	r.Update(ctx, resource.UpdateRequest{Plan: tfsdk.Plan{Schema: planned.Schema, Raw: planned.Raw}, State: state}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+	// The content of revision 3 is written as revision 5.
+	if pathInfo["contents"] != "V2VsY29tZQo=" || pathInfo["contents_enc64"] != true {
+		t.Errorf("unexpected path info %v", pathInfo)
+	}
+	var model configFileResourceModel
+	resp.State.Get(ctx, &model)
+	if model.Revision.ValueInt64() != 5 || model.PinnedRevision.ValueInt64() != 3 || model.SHA256.ValueString() != contentSHA256("Welcome\n") {
+		t.Errorf("unexpected state %v", model)
+	}
+}
+
+func TestConfigFileReadDetectsChangedPinnedRevision(t *testing.T) {
+	client := testAPIClient(t, func(w http.ResponseWriter, req *http.Request) {
+		_, _ = w.Write([]byte(testRevisions))
+	})
+
+	resp := testRead(t, NewConfigFileResource(), client, map[string]interface{}{
+		"channel":         "motd",
+		"path":            "/etc/motd",
+		"directory":       false,
+		"pinned_revision": 3,
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+	var state configFileResourceModel
+	resp.State.Get(context.Background(), &state)
+	// Revision 4 has other content than the pinned revision 3.
+	if state.PinnedRevision.ValueInt64() != 4 || !state.Content.IsNull() || !state.ContentBase64.IsNull() {
+		t.Errorf("expected the change to show up in pinned_revision, got %v", state)
+	}
+}
+
+func TestConfigFilePrunesRevisions(t *testing.T) {
+	ctx := context.Background()
+	var pruned []int
+	r := NewConfigFileResource()
+	testConfigure(t, r, testAPIClient(t, func(w http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/configchannel/getFileRevisions":
+			_, _ = w.Write([]byte(`{"success": true, "result": [
+				{"type": "file", "path": "/etc/motd", "revision": 1}, {"type": "file", "path": "/etc/motd", "revision": 2},
+				{"type": "file", "path": "/etc/motd", "revision": 3}, {"type": "file", "path": "/etc/motd", "revision": 4}]}`))
+		case "/configchannel/deleteFileRevisions":
+			var body struct {
+				Revisions []int `json:"revisions"`
+			}
+			_ = json.NewDecoder(req.Body).Decode(&body)
+			pruned = body.Revisions
+			_, _ = w.Write([]byte(`{"success": true, "result": 1}`))
+		default:
+			// Changing keep_revisions does not write a new revision.
+			t.Errorf("unexpected request %s", req.URL)
+		}
+	}))
+
+	attributes := map[string]interface{}{
+		"id":        "motd:/etc/motd",
+		"channel":   "motd",
+		"path":      "/etc/motd",
+		"directory": false,
+		"content":   "Welcome\n",
+		"binary":    false,
+		"revision":  4,
+		"sha256":    contentSHA256("Welcome\n"),
+	}
+	state := testState(t, r, attributes)
+	// The plan leaves the computed attributes unknown.
+	attributes["keep_revisions"] = 2
+	attributes["revision"] = types.Int64Unknown()
+	attributes["sha256"] = types.StringUnknown()
+	planned := testState(t, r, attributes)
+	resp := &resource.UpdateResponse{State: state}
+	r.Update(ctx, resource.UpdateRequest{Plan: tfsdk.Plan{Schema: planned.Schema, Raw: planned.Raw}, State: state}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+	if len(pruned) != 2 || pruned[0] != 1 || pruned[1] != 2 {
+		t.Errorf("expected revisions 1 and 2 to be deleted, got %v", pruned)
+	}
+	var model configFileResourceModel
+	resp.State.Get(ctx, &model)
+	if model.Revision.ValueInt64() != 4 || model.SHA256.ValueString() != contentSHA256("Welcome\n") {
+		t.Errorf("unexpected state %v", model)
+	}
+}
+
+func TestAccConfigFileResource(t *testing.T) {
+	acctest.Test(t, acctest.TestCase{
+		PreCheck:                 func() { testAccRealServerPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []acctest.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccConfigFileResourceConfig("Created by the acceptance tests\n"),
+				Check: acctest.ComposeAggregateTestCheckFunc(
+					acctest.TestCheckResourceAttr("uyuni_config_file.test", "id", "tfacc-files:/etc/motd"),
+					acctest.TestCheckResourceAttr("uyuni_config_file.test", "revision", "1"),
+					acctest.TestCheckResourceAttrSet("uyuni_config_file.test", "sha256"),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: testAccConfigFileResourceConfig("Updated by the acceptance tests\n"),
+				Check: acctest.ComposeAggregateTestCheckFunc(
+					acctest.TestCheckResourceAttr("uyuni_config_file.test", "content", "Updated by the acceptance tests\n"),
+					acctest.TestCheckResourceAttr("uyuni_config_file.test", "revision", "2"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "uyuni_config_file.test",
+				ImportState:       true,
+				ImportStateId:     "tfacc-files:/etc/motd",
+				ImportStateVerify: true,
+			},
+			// Rollback testing
+			{
+				Config: testAccConfigFilePinnedConfig(1, 2),
+				Check: acctest.ComposeAggregateTestCheckFunc(
+					acctest.TestCheckResourceAttr("uyuni_config_file.test", "revision", "3"),
+					acctest.TestCheckResourceAttr("uyuni_config_file.test", "sha256", contentSHA256("Created by the acceptance tests\n")),
+				),
+			},
+		},
+	})
+}
+
+func testAccConfigFileResourceConfig(content string) string {
+	return fmt.Sprintf(`
+resource "uyuni_config_channel" "test" {
+  label               = "tfacc-files"
+  name                = "tfacc-files"
+  deletion_protection = false
+}
+
+resource "uyuni_config_file" "test" {
+  channel     = uyuni_config_channel.test.label
+  path        = "/etc/motd"
+  content     = %q
+  permissions = "644"
+}
+`, content)
+}
+
+func testAccConfigFilePinnedConfig(revision, keep int) string {
+	return fmt.Sprintf(`
+resource "uyuni_config_channel" "test" {
+  label               = "tfacc-files"
+  name                = "tfacc-files"
+  deletion_protection = false
+}
+
+resource "uyuni_config_file" "test" {
+  channel         = uyuni_config_channel.test.label
+  path            = "/etc/motd"
+  pinned_revision = %d
+  keep_revisions  = %d
+  permissions     = "644"
+}
+`, revision, keep)
+}
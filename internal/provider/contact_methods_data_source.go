@@ -0,0 +1,67 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource = &ContactMethodsDataSource{}
+)
+
+// ContactMethodsDataSourceModel maps the data source schema data.
+type ContactMethodsDataSourceModel struct {
+	ContactMethods types.List `tfsdk:"contact_methods"`
+}
+
+// NewContactMethodsDataSource is a helper function to simplify the provider implementation.
+func NewContactMethodsDataSource() datasource.DataSource {
+	return &ContactMethodsDataSource{}
+}
+
+// ContactMethodsDataSource is the data source implementation.
+type ContactMethodsDataSource struct{}
+
+// Metadata returns the data source type name.
+func (d *ContactMethodsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_contact_methods"
+}
+
+// Schema defines the schema for the data source.
+func (d *ContactMethodsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the contact methods systems can be registered with, e.g. to validate variables " +
+			"feeding the contact_method of activation keys. The API offers no call listing them, so these are " +
+			"the methods the provider accepts.",
+		Attributes: map[string]schema.Attribute{
+			"contact_methods": schema.ListAttribute{
+				Description: "Contact methods, starting with the default one: `default`, `ssh-push` and `ssh-push-tunnel`.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *ContactMethodsDataSource) Read(ctx context.Context, _ datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state ContactMethodsDataSourceModel
+
+	methods, diags := types.ListValueFrom(ctx, types.StringType, contactMethods)
+	state.ContactMethods = methods
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
@@ -0,0 +1,460 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"terraform-provider-uyuni/internal/uyuni"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// fixturesDir holds the recorded responses of each server version, see its
+// README.
+var fixturesDir = filepath.Join("..", "uyuni", "testdata")
+
+// fixtureClient returns a client of a fake server answering each call with
+// the recorded response of the version, and a fault for endpoints without
+// one. The version of the client is detected as with real servers.
+func fixtureClient(t *testing.T, version string) *uyuniClient {
+	client := testAPIClient(t, func(w http.ResponseWriter, r *http.Request) {
+		endpoint := strings.ReplaceAll(strings.TrimPrefix(r.URL.Path, "/"), "/", ".")
+		data, err := os.ReadFile(filepath.Join(fixturesDir, version, endpoint+".json"))
+		if err != nil {
+			_, _ = w.Write([]byte(`{"success": false, "message": "no recorded response for ` + endpoint + `"}`))
+			return
+		}
+		_, _ = w.Write(data)
+	})
+	client.detectVersion(context.Background())
+	if client.version == nil {
+		t.Fatalf("could not detect version %s", version)
+	}
+	return client
+}
+
+// testDataSourceRead reads d configured with the attributes, all others null.
+func testDataSourceRead(t *testing.T, d datasource.DataSource, client *uyuniClient, attributes map[string]tftypes.Value) *datasource.ReadResponse {
+	ctx := context.Background()
+	d.(datasource.DataSourceWithConfigure).Configure(ctx, datasource.ConfigureRequest{ProviderData: client}, &datasource.ConfigureResponse{})
+	var schemaResp datasource.SchemaResponse
+	d.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	values := map[string]tftypes.Value{}
+	for name, attributeType := range objectType.AttributeTypes {
+		values[name] = tftypes.NewValue(attributeType, nil)
+	}
+	for name, value := range attributes {
+		values[name] = value
+	}
+	config := tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, values)}
+	resp := &datasource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: config.Raw}}
+	d.Read(ctx, datasource.ReadRequest{Config: config}, resp)
+	return resp
+}
+
+// contracts run resources and data sources against the recorded responses
+// of a server version.
+var contracts = map[string]func(t *testing.T, client *uyuniClient){
+	"user": func(t *testing.T, client *uyuniClient) {
+		ctx := context.Background()
+		r := NewUserResource()
+		testConfigure(t, r, client)
+		state := testState(t, r, map[string]interface{}{"id": "jdoe", "login": "jdoe"})
+		resp := &resource.ReadResponse{State: state}
+		r.Read(ctx, resource.ReadRequest{State: state}, resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatal(resp.Diagnostics)
+		}
+		var model userResourceModel
+		resp.State.Get(ctx, &model)
+		if model.FirstName.ValueString() == "" || model.Email.ValueString() == "" || model.CreatedDate.IsNull() {
+			t.Errorf("expected the details of the user, got %v", model)
+		}
+	},
+	"recent registrations": func(t *testing.T, client *uyuniClient) {
+		resp := testDataSourceRead(t, NewRecentRegistrationsDataSource(), client, map[string]tftypes.Value{
+			"max_age_hours": tftypes.NewValue(tftypes.Number, 1000000),
+		})
+		if resp.Diagnostics.HasError() {
+			t.Fatal(resp.Diagnostics)
+		}
+		var model RecentRegistrationsDataSourceModel
+		resp.State.Get(context.Background(), &model)
+		if len(model.Systems) == 0 {
+			t.Error("expected the recorded systems")
+		}
+	},
+	"system count by channel": func(t *testing.T, client *uyuniClient) {
+		resp := testDataSourceRead(t, NewSystemCountByChannelDataSource(), client, nil)
+		if resp.Diagnostics.HasError() {
+			t.Fatal(resp.Diagnostics)
+		}
+		var model SystemCountByChannelDataSourceModel
+		resp.State.Get(context.Background(), &model)
+		if model.TotalSystems.ValueInt64() == 0 {
+			t.Error("expected the recorded systems to be counted")
+		}
+	},
+	"formula catalog": func(t *testing.T, client *uyuniClient) {
+		resp := testDataSourceRead(t, NewFormulaCatalogDataSource(), client, nil)
+		if resp.Diagnostics.HasError() {
+			t.Fatal(resp.Diagnostics)
+		}
+		var model FormulaCatalogDataSourceModel
+		resp.State.Get(context.Background(), &model)
+		if len(model.Formulas) == 0 {
+			t.Error("expected the recorded formulas")
+		}
+	},
+	"systems": func(t *testing.T, client *uyuniClient) {
+		resp := testDataSourceRead(t, NewSystemsDataSource(), client, nil)
+		if resp.Diagnostics.HasError() {
+			t.Fatal(resp.Diagnostics)
+		}
+		var model SystemsDataSourceModel
+		resp.State.Get(context.Background(), &model)
+		if len(model.Systems) == 0 {
+			t.Error("expected the recorded systems")
+		}
+	},
+	"system group": func(t *testing.T, client *uyuniClient) {
+		resp := testRead(t, NewSystemGroupResource(), client, map[string]interface{}{
+			"name":           "B042",
+			"administrators": []string{},
+		})
+		if resp.Diagnostics.HasError() {
+			t.Fatal(resp.Diagnostics)
+		}
+		var state systemGroupResourceModel
+		resp.State.Get(context.Background(), &state)
+		if state.GroupID.IsNull() || len(state.Administrators.Elements()) == 0 {
+			t.Errorf("expected the recorded group with its administrators, got %v", state)
+		}
+	},
+	"software channel": func(t *testing.T, client *uyuniClient) {
+		resp := testRead(t, NewSoftwareChannelResource(), client, map[string]interface{}{
+			"label":             "prod-sles15-sp6-pool-x86_64",
+			"repository_labels": []string{},
+		})
+		if resp.Diagnostics.HasError() {
+			t.Fatal(resp.Diagnostics)
+		}
+		var state softwareChannelResourceModel
+		resp.State.Get(context.Background(), &state)
+		if state.ArchLabel.ValueString() == "" || state.ChecksumType.ValueString() == "" {
+			t.Errorf("expected the recorded channel, got %v", state)
+		}
+	},
+	"repository": func(t *testing.T, client *uyuniClient) {
+		resp := testRead(t, NewRepositoryResource(), client, map[string]interface{}{"label": "internal-tools"})
+		if resp.Diagnostics.HasError() {
+			t.Fatal(resp.Diagnostics)
+		}
+		var state repositoryResourceModel
+		resp.State.Get(context.Background(), &state)
+		if state.URL.ValueString() == "" || state.SSLCACert.ValueString() != "internal-ca" {
+			t.Errorf("expected the recorded repository, got %v", state)
+		}
+	},
+	"activation key data source": func(t *testing.T, client *uyuniClient) {
+		resp := testDataSourceRead(t, NewActivationKeyDataSource(), client, map[string]tftypes.Value{
+			"key": tftypes.NewValue(tftypes.String, "sles15-sp6-web"),
+		})
+		if resp.Diagnostics.HasError() {
+			t.Fatal(resp.Diagnostics)
+		}
+	},
+	"channels": func(t *testing.T, client *uyuniClient) {
+		resp := testDataSourceRead(t, NewChannelsDataSource(), client, nil)
+		if resp.Diagnostics.HasError() {
+			t.Fatal(resp.Diagnostics)
+		}
+		var model ChannelsDataSourceModel
+		resp.State.Get(context.Background(), &model)
+		if len(model.Channels) == 0 {
+			t.Error("expected the recorded channels")
+		}
+	},
+	"system groups": func(t *testing.T, client *uyuniClient) {
+		resp := testDataSourceRead(t, NewSystemGroupsDataSource(), client, nil)
+		if resp.Diagnostics.HasError() {
+			t.Fatal(resp.Diagnostics)
+		}
+		var model SystemGroupsDataSourceModel
+		resp.State.Get(context.Background(), &model)
+		if len(model.Groups) == 0 {
+			t.Error("expected the recorded groups")
+		}
+	},
+	"clm project": func(t *testing.T, client *uyuniClient) {
+		resp := testRead(t, NewCLMProjectResource(), client, map[string]interface{}{
+			"label":      "sles15-sp6",
+			"filter_ids": []int64{},
+		})
+		if resp.Diagnostics.HasError() {
+			t.Fatal(resp.Diagnostics)
+		}
+		var state clmProjectResourceModel
+		resp.State.Get(context.Background(), &state)
+		if state.FirstEnvironmentLabel.ValueString() != "dev" || len(state.FilterIDs.Elements()) != 1 {
+			t.Errorf("expected the recorded project, got %v", state)
+		}
+	},
+	"clm environment": func(t *testing.T, client *uyuniClient) {
+		resp := testRead(t, NewCLMEnvironmentResource(), client, map[string]interface{}{
+			"project_label": "sles15-sp6",
+			"label":         "test",
+		})
+		if resp.Diagnostics.HasError() {
+			t.Fatal(resp.Diagnostics)
+		}
+		var state clmEnvironmentResourceModel
+		resp.State.Get(context.Background(), &state)
+		if state.PredecessorLabel.ValueString() != "dev" || state.Version.ValueInt64() == 0 {
+			t.Errorf("expected the recorded environment, got %v", state)
+		}
+	},
+	"clm source": func(t *testing.T, client *uyuniClient) {
+		resp := testRead(t, NewCLMSourceResource(), client, map[string]interface{}{
+			"project_label": "sles15-sp6",
+			"channel_label": "sle-product-sles15-sp6-pool-x86_64",
+		})
+		if resp.Diagnostics.HasError() {
+			t.Fatal(resp.Diagnostics)
+		}
+		var state clmSourceResourceModel
+		resp.State.Get(context.Background(), &state)
+		if state.State.ValueString() == "" {
+			t.Errorf("expected the recorded source, got %v", state)
+		}
+	},
+	"clm filter": func(t *testing.T, client *uyuniClient) {
+		resp := testRead(t, NewCLMFilterResource(), client, map[string]interface{}{"filter_id": 12})
+		if resp.Diagnostics.HasError() {
+			t.Fatal(resp.Diagnostics)
+		}
+		var state clmFilterResourceModel
+		resp.State.Get(context.Background(), &state)
+		if state.Rule.ValueString() != "deny" || state.Value.ValueString() != "kernel-rt" {
+			t.Errorf("expected the recorded filter, got %v", state)
+		}
+	},
+	"config channel export": func(t *testing.T, client *uyuniClient) {
+		resp := testDataSourceRead(t, NewConfigChannelExportDataSource(), client, map[string]tftypes.Value{
+			"channel": tftypes.NewValue(tftypes.String, "hardening"),
+		})
+		if resp.Diagnostics.HasError() {
+			t.Fatal(resp.Diagnostics)
+		}
+		var model ConfigChannelExportDataSourceModel
+		resp.State.Get(context.Background(), &model)
+		if len(model.Files) != 2 || len(model.Checksums.Elements()) != 2 {
+			t.Errorf("expected the recorded files, got %v", model)
+		}
+	},
+	"organization": func(t *testing.T, client *uyuniClient) {
+		resp := testRead(t, NewOrganizationResource(), client, map[string]interface{}{
+			"org_id":              2,
+			"system_entitlements": map[string]int64{"monitoring_entitled": 0},
+		})
+		if resp.Diagnostics.HasError() {
+			t.Fatal(resp.Diagnostics)
+		}
+		var state organizationResourceModel
+		resp.State.Get(context.Background(), &state)
+		if state.Name.ValueString() == "" || state.SystemEntitlements.Elements()["monitoring_entitled"].String() == "0" {
+			t.Errorf("expected the recorded organization, got %v", state)
+		}
+	},
+	"org trust": func(t *testing.T, client *uyuniClient) {
+		resp := testRead(t, NewOrgTrustResource(), client, map[string]interface{}{"org_id": 1, "trusted_org_id": 2})
+		if resp.Diagnostics.HasError() {
+			t.Fatal(resp.Diagnostics)
+		}
+		if resp.State.Raw.IsNull() {
+			t.Error("expected the recorded trust")
+		}
+	},
+	"action result": func(t *testing.T, client *uyuniClient) {
+		resp := testDataSourceRead(t, NewActionResultDataSource(), client, map[string]tftypes.Value{
+			"action_id": tftypes.NewValue(tftypes.Number, 42),
+		})
+		if resp.Diagnostics.HasError() {
+			t.Fatal(resp.Diagnostics)
+		}
+		var model ActionResultDataSourceModel
+		resp.State.Get(context.Background(), &model)
+		if len(model.Systems) == 0 || model.Status.IsNull() {
+			t.Errorf("expected the recorded systems, got %v", model)
+		}
+	},
+	"hub systems": func(t *testing.T, client *uyuniClient) {
+		resp := testDataSourceRead(t, NewHubSystemsDataSource(), client, nil)
+		if resp.Diagnostics.HasError() {
+			t.Fatal(resp.Diagnostics)
+		}
+		var model HubSystemsDataSourceModel
+		resp.State.Get(context.Background(), &model)
+		if len(model.Systems) == 0 {
+			t.Errorf("expected the recorded systems, got %v", model)
+		}
+	},
+	"config channel": func(t *testing.T, client *uyuniClient) {
+		resp := testRead(t, NewConfigChannelResource(), client, map[string]interface{}{"label": "hardening"})
+		if resp.Diagnostics.HasError() {
+			t.Fatal(resp.Diagnostics)
+		}
+		var state configChannelResourceModel
+		resp.State.Get(context.Background(), &state)
+		if state.Name.ValueString() == "" || state.Type.ValueString() != configChannelTypeState {
+			t.Errorf("expected the recorded channel, got %v", state)
+		}
+	},
+	"config file": func(t *testing.T, client *uyuniClient) {
+		resp := testRead(t, NewConfigFileResource(), client, map[string]interface{}{"channel": "hardening", "path": "/init.sls"})
+		if resp.Diagnostics.HasError() {
+			t.Fatal(resp.Diagnostics)
+		}
+		var state configFileResourceModel
+		resp.State.Get(context.Background(), &state)
+		if state.Content.IsNull() || state.Revision.ValueInt64() == 0 || state.SHA256.IsNull() {
+			t.Errorf("expected the recorded file, got %v", state)
+		}
+	},
+	"product channels": func(t *testing.T, client *uyuniClient) {
+		resp := testDataSourceRead(t, NewProductChannelsDataSource(), client, map[string]tftypes.Value{
+			"name":    tftypes.NewValue(tftypes.String, "SUSE Linux Enterprise Server"),
+			"version": tftypes.NewValue(tftypes.String, "15 SP6"),
+			"arch":    tftypes.NewValue(tftypes.String, "x86_64"),
+		})
+		if resp.Diagnostics.HasError() {
+			t.Fatal(resp.Diagnostics)
+		}
+		var model ProductChannelsDataSourceModel
+		resp.State.Get(context.Background(), &model)
+		if model.BaseChannelLabel.ValueString() != "sle-product-sles15-sp6-pool-x86_64" {
+			t.Errorf("expected the recorded base channel, got %v", model)
+		}
+	},
+	"bootstrap host": func(t *testing.T, client *uyuniClient) {
+		resp := testRead(t, NewBootstrapHostResource(), client, map[string]interface{}{"system_id": int64(1000010001)})
+		if resp.Diagnostics.HasError() || resp.State.Raw.IsNull() {
+			t.Fatalf("expected the recorded system to be kept, got %v", resp.Diagnostics)
+		}
+	},
+	"scc credentials": func(t *testing.T, client *uyuniClient) {
+		resp := testRead(t, NewSCCCredentialsResource(), client, map[string]interface{}{"username": "SCC_0123456789abcdef", "password": "secret"})
+		if resp.Diagnostics.HasError() {
+			t.Fatal(resp.Diagnostics)
+		}
+		var model sccCredentialsResourceModel
+		resp.State.Get(context.Background(), &model)
+		if !model.Primary.ValueBool() {
+			t.Errorf("expected the recorded credentials to be primary, got %v", model)
+		}
+	},
+	"system os info": func(t *testing.T, client *uyuniClient) {
+		resp := testDataSourceRead(t, NewSystemOSInfoDataSource(), client, map[string]tftypes.Value{
+			"system_id": tftypes.NewValue(tftypes.Number, 1000010000),
+		})
+		if resp.Diagnostics.HasError() {
+			t.Fatal(resp.Diagnostics)
+		}
+		var model SystemOSInfoDataSourceModel
+		resp.State.Get(context.Background(), &model)
+		if model.OSFamily.ValueString() != "suse" || model.OSVersion.ValueString() != "15.6" {
+			t.Errorf("expected the recorded base product, got %v", model)
+		}
+	},
+	"inactive systems": func(t *testing.T, client *uyuniClient) {
+		stale, protected, err := inactiveSystems(context.Background(), client, 30, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(stale) != 2 || stale[0] != 1000010003 || len(protected) != 0 {
+			t.Errorf("unexpected inactive systems %v, protected %v", stale, protected)
+		}
+	},
+	"channel family usage": func(t *testing.T, client *uyuniClient) {
+		resp := testDataSourceRead(t, NewChannelFamilyUsageDataSource(), client, map[string]tftypes.Value{
+			"org_id": tftypes.NewValue(tftypes.Number, 1),
+		})
+		if resp.Diagnostics.HasError() {
+			t.Fatal(resp.Diagnostics)
+		}
+		var state ChannelFamilyUsageDataSourceModel
+		resp.State.Get(context.Background(), &state)
+		if len(state.ChannelFamilies) != 2 || state.ChannelFamilies[0].Label.ValueString() != "7261" || state.ChannelFamilies[0].Used.ValueInt64() != 106 {
+			t.Errorf("unexpected channel families %v", state.ChannelFamilies)
+		}
+	},
+	"kickstart session status": func(t *testing.T, client *uyuniClient) {
+		resp := testDataSourceRead(t, NewKickstartSessionStatusDataSource(), client, map[string]tftypes.Value{
+			"system_ids": tftypes.NewValue(tftypes.Set{ElementType: tftypes.Number}, []tftypes.Value{
+				tftypes.NewValue(tftypes.Number, 1000010000),
+			}),
+		})
+		if resp.Diagnostics.HasError() {
+			t.Fatal(resp.Diagnostics)
+		}
+		var state KickstartSessionStatusDataSourceModel
+		resp.State.Get(context.Background(), &state)
+		if len(state.Systems) != 1 || state.Systems[0].ActionID.ValueInt64() != 915 || state.Systems[0].State.ValueString() != actionStatusCompleted {
+			t.Errorf("unexpected kickstart sessions %v", state.Systems)
+		}
+	},
+	"virtual systems": func(t *testing.T, client *uyuniClient) {
+		resp := testDataSourceRead(t, NewVirtualSystemsDataSource(), client, nil)
+		if resp.Diagnostics.HasError() {
+			t.Fatal(resp.Diagnostics)
+		}
+		var state VirtualSystemsDataSourceModel
+		resp.State.Get(context.Background(), &state)
+		if len(state.Hosts) != 1 || len(state.Guests) != 2 || state.Guests[1].SystemID.ValueInt64() != 1000010000 {
+			t.Errorf("unexpected virtual systems %v, %v", state.Hosts, state.Guests)
+		}
+	},
+	"confidential computing": func(t *testing.T, client *uyuniClient) {
+		// Versions offering the feature have a recorded response, older
+		// ones refuse the call without sending it.
+		path := "system/getCoCoAttestationConfig?sid=1000010000"
+		_, err := apiGet[uyuni.CocoAttestationConfig](context.Background(), client, path)
+		var unsupported *unsupportedFeatureError
+		if client.checkFeature(path) == nil {
+			if err != nil {
+				t.Fatal(err)
+			}
+		} else if !errors.As(err, &unsupported) {
+			t.Errorf("expected %s to refuse the call, got %v", client.version, err)
+		}
+	},
+}
+
+func TestContracts(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join(fixturesDir, "*", "api.systemVersion.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) == 0 {
+		t.Fatal("no fixtures found")
+	}
+
+	for _, file := range files {
+		version := filepath.Base(filepath.Dir(file))
+		for name, contract := range contracts {
+			t.Run(version+"/"+name, func(t *testing.T) {
+				contract(t, fixtureClient(t, version))
+			})
+		}
+	}
+}
@@ -0,0 +1,152 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"terraform-provider-uyuni/internal/uyuni"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &CryptoKeysDataSource{}
+	_ datasource.DataSourceWithConfigure = &CryptoKeysDataSource{}
+)
+
+// CryptoKeysDataSourceModel maps the data source schema data.
+type CryptoKeysDataSourceModel struct {
+	Type types.String     `tfsdk:"type"`
+	Keys []cryptoKeyModel `tfsdk:"key"`
+}
+
+// cryptoKeyModel maps crypto key schema data.
+type cryptoKeyModel struct {
+	Description types.String `tfsdk:"description"`
+	Type        types.String `tfsdk:"type"`
+	Content     types.String `tfsdk:"content"`
+}
+
+// NewCryptoKeysDataSource is a helper function to simplify the provider implementation.
+func NewCryptoKeysDataSource() datasource.DataSource {
+	return &CryptoKeysDataSource{}
+}
+
+// CryptoKeysDataSource is the data source implementation.
+type CryptoKeysDataSource struct {
+	client *uyuniClient
+}
+
+// Metadata returns the data source type name.
+func (d *CryptoKeysDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_crypto_keys"
+}
+
+// Schema defines the schema for the data source.
+func (d *CryptoKeysDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the GPG and SSL keys stored in the organization, e.g. to reference them from autoinstallation profiles by description.",
+		Attributes: map[string]schema.Attribute{
+			"type": schema.StringAttribute{
+				Description: "Only return keys of this type (GPG or SSL).",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("GPG", "SSL"),
+				},
+			},
+			"key": schema.ListNestedAttribute{
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"description": schema.StringAttribute{
+							Computed: true,
+						},
+						"type": schema.StringAttribute{
+							Computed: true,
+						},
+						"content": schema.StringAttribute{
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *CryptoKeysDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state CryptoKeysDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// read keys from API
+	keys, err := apiGet[[]uyuni.CryptoKey](ctx, d.client, "kickstart/keys/listAllKeys")
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Uyuni crypto keys",
+			err.Error(),
+		)
+		return
+	}
+
+	state.Keys = nil
+	for _, this_key := range keys.Result {
+		if !state.Type.IsNull() && this_key.Type != state.Type.ValueString() {
+			continue
+		}
+
+		// The list call does not include the key material.
+		details, err := apiGet[uyuni.CryptoKey](ctx, d.client, "kickstart/keys/getDetails?description="+url.QueryEscape(this_key.Description))
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Read Uyuni crypto key",
+				"Could not read key "+this_key.Description+": "+err.Error(),
+			)
+			return
+		}
+
+		state.Keys = append(state.Keys, cryptoKeyModel{
+			Description: types.StringValue(this_key.Description),
+			Type:        types.StringValue(this_key.Type),
+			Content:     types.StringValue(details.Result.Content),
+		})
+	}
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *CryptoKeysDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*uyuniClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *uyuniClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
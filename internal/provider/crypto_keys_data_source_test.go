@@ -0,0 +1,32 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccCryptoKeysDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccRealServerPreCheck(t)
+			testAccSeed(t, "kickstart/keys/create", map[string]interface{}{
+				"description": "tfacc-key",
+				"type":        "GPG",
+				"content":     "-----BEGIN PGP PUBLIC KEY BLOCK-----\ntfacc\n-----END PGP PUBLIC KEY BLOCK-----\n",
+			}, "kickstart/keys/delete", map[string]interface{}{
+				"description": "tfacc-key",
+			})
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `data "uyuni_crypto_keys" "gpg" { type = "GPG" }`,
+				Check: resource.TestCheckTypeSetElemNestedAttrs("data.uyuni_crypto_keys.gpg", "key.*", map[string]string{
+					"description": "tfacc-key",
+					"type":        "GPG",
+				}),
+			},
+		},
+	})
+}
@@ -0,0 +1,382 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"terraform-provider-uyuni/internal/uyuni"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                     = &customRepoSSLBundleResource{}
+	_ resource.ResourceWithConfigure        = &customRepoSSLBundleResource{}
+	_ resource.ResourceWithConfigValidators = &customRepoSSLBundleResource{}
+	_ resource.ResourceWithImportState      = &customRepoSSLBundleResource{}
+)
+
+// sslKeyType is the type of SSL keys in the kickstart.keys namespace.
+const sslKeyType = "SSL"
+
+// Suffixes of the descriptions of the crypto keys of a bundle.
+const (
+	sslCACertSuffix     = "-ca"
+	sslClientCertSuffix = "-cert"
+	sslClientKeySuffix  = "-key"
+)
+
+// NewCustomRepoSSLBundleResource is a helper function to simplify the provider implementation.
+func NewCustomRepoSSLBundleResource() resource.Resource {
+	return &customRepoSSLBundleResource{}
+}
+
+// customRepoSSLBundleResource is the resource implementation.
+type customRepoSSLBundleResource struct {
+	client *uyuniClient
+}
+
+// customRepoSSLBundleResourceModel maps the resource schema data.
+type customRepoSSLBundleResourceModel struct {
+	ID             types.String `tfsdk:"id"`
+	Name           types.String `tfsdk:"name"`
+	CACert         types.String `tfsdk:"ca_cert"`
+	ClientCert     types.String `tfsdk:"client_cert"`
+	ClientKey      types.String `tfsdk:"client_key"`
+	CACertName     types.String `tfsdk:"ca_cert_name"`
+	ClientCertName types.String `tfsdk:"client_cert_name"`
+	ClientKeyName  types.String `tfsdk:"client_key_name"`
+	ServerAlias    types.String `tfsdk:"server_alias"`
+	Org            *orgModel    `tfsdk:"org"`
+}
+
+// Metadata returns the resource type name.
+func (r *customRepoSSLBundleResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_custom_repo_ssl_bundle"
+}
+
+// pemAttribute returns the schema of an attribute holding a PEM block.
+func pemAttribute(description, block string, required, sensitive bool) schema.StringAttribute {
+	return schema.StringAttribute{
+		Description: description,
+		Required:    required,
+		Optional:    !required,
+		Sensitive:   sensitive,
+		Validators: []validator.String{
+			stringvalidator.RegexMatches(
+				regexp.MustCompile(`^\s*-----BEGIN `+block),
+				"must be PEM encoded",
+			),
+		},
+	}
+}
+
+// Schema defines the schema for the resource.
+func (r *customRepoSSLBundleResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages the SSL crypto keys authenticating custom repositories, e.g. the entitlement certificate " +
+			"of the RHEL CDN or of Ubuntu Pro: the CA certificate and optionally a client certificate and key. " +
+			"Pass the names of the keys to the ssl_ca_cert_name, ssl_client_cert_name and ssl_client_key_name " +
+			"of uyuni_repository.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Name of the bundle.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "Name of the bundle. The keys are named after it with the suffixes `-ca`, `-cert` and `-key`.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"ca_cert":     pemAttribute("PEM encoded CA certificate of the repository.", "CERTIFICATE", true, false),
+			"client_cert": pemAttribute("PEM encoded client certificate for the repository.", "CERTIFICATE", false, false),
+			"client_key":  pemAttribute("PEM encoded private key of the client certificate.", "", false, true),
+			"ca_cert_name": schema.StringAttribute{
+				Description: "Name of the CA certificate key.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"client_cert_name": schema.StringAttribute{
+				Description: "Name of the client certificate key, null without client certificate.",
+				Computed:    true,
+			},
+			"client_key_name": schema.StringAttribute{
+				Description: "Name of the client key, null without client certificate.",
+				Computed:    true,
+			},
+			"server_alias": serverAliasAttribute(),
+		},
+		Blocks: map[string]schema.Block{
+			"org": orgBlock(),
+		},
+	}
+}
+
+// ConfigValidators returns the validators checking attributes against each other.
+func (r *customRepoSSLBundleResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.RequiredTogether(
+			path.MatchRoot("client_cert"),
+			path.MatchRoot("client_key"),
+		),
+	}
+}
+
+// sslBundleKey is a crypto key of a bundle.
+type sslBundleKey struct {
+	suffix  string
+	content *types.String
+	name    *types.String
+}
+
+// keys returns the crypto keys of the bundle.
+func (m *customRepoSSLBundleResourceModel) keys() []sslBundleKey {
+	return []sslBundleKey{
+		{sslCACertSuffix, &m.CACert, &m.CACertName},
+		{sslClientCertSuffix, &m.ClientCert, &m.ClientCertName},
+		{sslClientKeySuffix, &m.ClientKey, &m.ClientKeyName},
+	}
+}
+
+// setNames sets the names of the keys which have content.
+func (m *customRepoSSLBundleResourceModel) setNames() {
+	m.ID = m.Name
+	for _, key := range m.keys() {
+		*key.name = types.StringNull()
+		if !key.content.IsNull() {
+			*key.name = types.StringValue(m.Name.ValueString() + key.suffix)
+		}
+	}
+}
+
+// writeSSLKeys creates, updates and deletes the keys of the bundle so that
+// they match the plan, given the keys in state.
+func writeSSLKeys(ctx context.Context, client *uyuniClient, plan, state *customRepoSSLBundleResourceModel) error {
+	var current []sslBundleKey
+	if state != nil {
+		current = state.keys()
+	}
+	for i, key := range plan.keys() {
+		description := plan.Name.ValueString() + key.suffix
+		exists := current != nil && !current[i].content.IsNull()
+		var err error
+		switch {
+		case key.content.IsNull() && exists:
+			_, err = apiPost[int](ctx, client, "kickstart/keys/delete", map[string]interface{}{
+				"description": description,
+			})
+			if isNotFoundError(err) {
+				err = nil
+			}
+		case key.content.IsNull(), exists && key.content.Equal(*current[i].content):
+			continue
+		default:
+			endpoint := "kickstart/keys/create"
+			if exists {
+				endpoint = "kickstart/keys/update"
+			}
+			_, err = apiPost[int](ctx, client, endpoint, map[string]interface{}{
+				"description": description,
+				"type":        sslKeyType,
+				"content":     key.content.ValueString(),
+			})
+		}
+		if err != nil {
+			return fmt.Errorf("could not write key %s: %w", description, err)
+		}
+	}
+	return nil
+}
+
+// Create a new resource.
+func (r *customRepoSSLBundleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan customRepoSSLBundleResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := resourceClient(ctx, r.client, plan.ServerAlias, plan.Org, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	if err := writeSSLKeys(ctx, client, &plan, nil); err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating SSL bundle",
+			"Could not create SSL bundle "+plan.Name.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+	plan.setNames()
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read resource information.
+func (r *customRepoSSLBundleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Get current state
+	var state customRepoSSLBundleResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := resourceClient(ctx, r.client, state.ServerAlias, state.Org, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	// Imported bundles read all keys, others only those they manage.
+	imported := state.CACert.IsNull()
+	name := state.ID.ValueString()
+	state.Name = state.ID
+	for i, key := range state.keys() {
+		if !imported && key.content.IsNull() {
+			continue
+		}
+		description := name + key.suffix
+		details, err := apiGet[uyuni.CryptoKey](ctx, client, "kickstart/keys/getDetails?description="+url.QueryEscape(description))
+		if err != nil {
+			// The bundle is gone with its CA certificate, client
+			// certificates deleted on the server show as a change.
+			if i == 0 && handleNotFound(ctx, resp, err, "SSL bundle "+name) {
+				return
+			}
+			if i > 0 && isNotFoundError(err) {
+				*key.content = types.StringNull()
+				continue
+			}
+			resp.Diagnostics.AddError(
+				"Error Reading Uyuni SSL bundle",
+				"Could not read key "+description+": "+err.Error(),
+			)
+			return
+		}
+		// The server may strip surrounding whitespace.
+		if strings.TrimSpace(details.Result.Content) != strings.TrimSpace(key.content.ValueString()) {
+			*key.content = types.StringValue(details.Result.Content)
+		}
+	}
+	state.setNames()
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *customRepoSSLBundleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Retrieve values from plan and state
+	var plan, state customRepoSSLBundleResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := resourceClient(ctx, r.client, plan.ServerAlias, plan.Org, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	if err := writeSSLKeys(ctx, client, &plan, &state); err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating SSL bundle",
+			"Could not update SSL bundle "+plan.Name.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+	plan.setNames()
+
+	diags := resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *customRepoSSLBundleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Retrieve values from state
+	var state customRepoSSLBundleResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := resourceClient(ctx, r.client, state.ServerAlias, state.Org, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	// Client certificates first, the CA certificate marks the bundle.
+	keys := state.keys()
+	for i := len(keys) - 1; i >= 0; i-- {
+		description := state.ID.ValueString() + keys[i].suffix
+		if keys[i].content.IsNull() {
+			continue
+		}
+		_, err := apiPost[int](ctx, client, "kickstart/keys/delete", map[string]interface{}{
+			"description": description,
+		})
+		if err != nil && !isNotFoundError(err) {
+			resp.Diagnostics.AddError(
+				"Error Deleting Uyuni SSL bundle",
+				"Could not delete key "+description+": "+err.Error(),
+			)
+			return
+		}
+	}
+}
+
+// ImportState imports a bundle by its name.
+func (r *customRepoSSLBundleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *customRepoSSLBundleResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*uyuniClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *uyuniClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
@@ -0,0 +1,283 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+
+	"terraform-provider-uyuni/internal/uyuni"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &CustomStatesDataSource{}
+	_ datasource.DataSourceWithConfigure = &CustomStatesDataSource{}
+)
+
+const (
+	// stateChannelType is the type of the configuration channels holding
+	// custom Salt states.
+	stateChannelType = "state"
+	// stateInitFile is the file Salt applies for a state channel.
+	stateInitFile = "/init.sls"
+)
+
+// CustomStatesDataSourceModel maps the data source schema data.
+type CustomStatesDataSourceModel struct {
+	Labels types.Set          `tfsdk:"labels"`
+	States []customStateModel `tfsdk:"states"`
+}
+
+// customStateModel maps the custom state schema data.
+type customStateModel struct {
+	Label         types.String `tfsdk:"label"`
+	Name          types.String `tfsdk:"name"`
+	Description   types.String `tfsdk:"description"`
+	InitSLSSHA256 types.String `tfsdk:"init_sls_sha256"`
+	Revision      types.Int64  `tfsdk:"revision"`
+	Modified      types.String `tfsdk:"modified"`
+}
+
+// NewCustomStatesDataSource is a helper function to simplify the provider implementation.
+func NewCustomStatesDataSource() datasource.DataSource {
+	return &CustomStatesDataSource{}
+}
+
+// CustomStatesDataSource is the data source implementation.
+type CustomStatesDataSource struct {
+	client *uyuniClient
+}
+
+// Metadata returns the data source type name.
+func (d *CustomStatesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_custom_states"
+}
+
+// Schema defines the schema for the data source.
+func (d *CustomStatesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the custom Salt states, i.e. the configuration channels of type state, " +
+			"e.g. to check that the states a resource references exist before it is applied.",
+		Attributes: map[string]schema.Attribute{
+			"labels": schema.SetAttribute{
+				Description: "Labels of all custom states.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"states": schema.ListNestedAttribute{
+				Description: "Custom states, ordered by label.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"label": schema.StringAttribute{
+							Description: "Label of the state, which systems apply it by.",
+							Computed:    true,
+						},
+						"name": schema.StringAttribute{
+							Description: "Name of the state.",
+							Computed:    true,
+						},
+						"description": schema.StringAttribute{
+							Description: "Description of the state.",
+							Computed:    true,
+						},
+						"init_sls_sha256": schema.StringAttribute{
+							Description: "SHA256 checksum of the latest revision of init.sls, null if the state has none.",
+							Computed:    true,
+						},
+						"revision": schema.Int64Attribute{
+							Description: "Latest revision of init.sls, null if the state has none.",
+							Computed:    true,
+						},
+						"modified": schema.StringAttribute{
+							Description: "Date the latest revision of init.sls was created, in RFC 3339 format. Null if the state has none.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// revisionSHA256 returns the checksum of the revision. Servers which do not
+// return it get it computed from the contents.
+func revisionSHA256(revision uyuni.ConfigRevision) (string, error) {
+	if revision.SHA256 != "" {
+		return revision.SHA256, nil
+	}
+	contents, err := revisionContents(revision)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(contents)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// revisionContents returns the decoded contents of a revision.
+func revisionContents(revision uyuni.ConfigRevision) ([]byte, error) {
+	if !revision.ContentsEnc64 {
+		return []byte(revision.Contents), nil
+	}
+	contents, err := base64.StdEncoding.DecodeString(revision.Contents)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode %s: %w", revision.Path, err)
+	}
+	return contents, nil
+}
+
+// fileRevisions returns the revisions of a file in a configuration channel,
+// or nil if the channel has no such file.
+func fileRevisions(ctx context.Context, client *uyuniClient, label, path string) ([]uyuni.ConfigRevision, error) {
+	query := url.Values{"channelLabel": {label}, "filePath": {path}}
+	revisions, err := apiGet[[]uyuni.ConfigRevision](ctx, client, "configchannel/getFileRevisions?"+query.Encode())
+	if err != nil {
+		if isNotFoundError(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return revisions.Result, nil
+}
+
+// fileRevision returns the given revision of a file in a configuration
+// channel, or its latest revision if revision is 0. It returns nil if the
+// channel has no such file or revision.
+func fileRevision(ctx context.Context, client *uyuniClient, label, path string, revision int) (*uyuni.ConfigRevision, error) {
+	revisions, err := fileRevisions(ctx, client, label, path)
+	if err != nil {
+		return nil, err
+	}
+	return findRevision(revisions, revision), nil
+}
+
+// findRevision returns the given revision of revisions, or the latest if
+// revision is 0, nil if there is none.
+func findRevision(revisions []uyuni.ConfigRevision, revision int) *uyuni.ConfigRevision {
+	var found *uyuni.ConfigRevision
+	for i, candidate := range revisions {
+		if revision != 0 {
+			if candidate.Revision == revision {
+				return &revisions[i]
+			}
+			continue
+		}
+		if found == nil || candidate.Revision > found.Revision {
+			found = &revisions[i]
+		}
+	}
+	return found
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *CustomStatesDataSource) Read(ctx context.Context, _ datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state CustomStatesDataSourceModel
+
+	channels, err := apiGet[[]uyuni.ConfigChannel](ctx, d.client, "configchannel/listGlobals")
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Uyuni custom states",
+			err.Error(),
+		)
+		return
+	}
+	stateChannels := map[string]uyuni.ConfigChannel{}
+	labels := []string{}
+	for _, channel := range channels.Result {
+		if channel.ConfigChannelType.Label == stateChannelType {
+			stateChannels[channel.Label] = channel
+			labels = append(labels, channel.Label)
+		}
+	}
+	sort.Strings(labels)
+
+	var mu sync.Mutex
+	inits := map[string]*uyuni.ConfigRevision{}
+	errs := runBatch(labels, func(label string) error {
+		latest, err := fileRevision(ctx, d.client, label, stateInitFile, 0)
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		inits[label] = latest
+		mu.Unlock()
+		return nil
+	})
+	if len(errs) > 0 {
+		failed := make([]string, 0, len(errs))
+		for label, err := range errs {
+			failed = append(failed, fmt.Sprintf("%s: %s", label, err))
+		}
+		sort.Strings(failed)
+		resp.Diagnostics.AddError(
+			"Unable to Read Uyuni custom states",
+			"Could not read init.sls of "+strings.Join(failed, "; "),
+		)
+		return
+	}
+
+	state.States = make([]customStateModel, 0, len(labels))
+	for _, label := range labels {
+		channel := stateChannels[label]
+		custom := customStateModel{
+			Label:         types.StringValue(channel.Label),
+			Name:          types.StringValue(channel.Name),
+			Description:   types.StringValue(channel.Description),
+			InitSLSSHA256: types.StringNull(),
+			Revision:      types.Int64Null(),
+			Modified:      types.StringNull(),
+		}
+		if initSLS := inits[label]; initSLS != nil {
+			checksum, err := revisionSHA256(*initSLS)
+			if err != nil {
+				resp.Diagnostics.AddError("Unable to Read Uyuni custom states", err.Error())
+				return
+			}
+			custom.InitSLSSHA256 = types.StringValue(checksum)
+			custom.Revision = types.Int64Value(int64(initSLS.Revision))
+			custom.Modified = timestampValue(ctx, initSLS.Creation)
+		}
+		state.States = append(state.States, custom)
+	}
+	labelSet, diags := types.SetValueFrom(ctx, types.StringType, labels)
+	state.Labels = labelSet
+	resp.Diagnostics.Append(diags...)
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *CustomStatesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*uyuniClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *uyuniClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
@@ -0,0 +1,87 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestCustomStatesDataSource(t *testing.T) {
+	ctx := context.Background()
+	client := testAPIClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/configchannel/listGlobals":
+			_, _ = w.Write([]byte(`{"success": true, "result": [
+				{"id": 3, "label": "hardening", "name": "Hardening", "configChannelType": {"label": "state"}},
+				{"id": 4, "label": "empty", "name": "Empty", "configChannelType": {"label": "state"}},
+				{"id": 5, "label": "motd", "name": "Message of the day", "configChannelType": {"label": "normal"}}
+			]}`))
+		case r.URL.Path == "/configchannel/getFileRevisions" && r.URL.Query().Get("channelLabel") == "hardening":
+			_, _ = w.Write([]byte(`{"success": true, "result": [
+				{"path": "/init.sls", "revision": 1, "contents": "include:\n  - .sshd\n", "creation": "2024-11-02T08:45:37Z"},
+				{"path": "/init.sls", "revision": 2, "contents": "aW5jbHVkZToK", "contents_enc64": true, "creation": "2025-01-14T10:02:11Z"}
+			]}`))
+		case r.URL.Path == "/configchannel/getFileRevisions":
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(`{"success": false, "message": "No such file: /init.sls"}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL)
+		}
+	})
+
+	d := NewCustomStatesDataSource()
+	d.(datasource.DataSourceWithConfigure).Configure(ctx, datasource.ConfigureRequest{ProviderData: client}, &datasource.ConfigureResponse{})
+	var schemaResp datasource.SchemaResponse
+	d.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+
+	raw := tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)
+	resp := &datasource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: raw}}
+	d.Read(ctx, datasource.ReadRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: raw}}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+
+	var state CustomStatesDataSourceModel
+	resp.State.Get(ctx, &state)
+	if len(state.States) != 2 || len(state.Labels.Elements()) != 2 {
+		t.Fatalf("expected the two state channels, got %+v", state.States)
+	}
+	empty, hardening := state.States[0], state.States[1]
+	if !empty.InitSLSSHA256.IsNull() || !empty.Revision.IsNull() {
+		t.Errorf("expected no init.sls for empty, got %+v", empty)
+	}
+	// sha256 of "include:\n", the decoded latest revision
+	if hardening.Revision.ValueInt64() != 2 || hardening.InitSLSSHA256.ValueString() != "e6244accdd79f57a7964bb58465f338fa21e94550e90b500405894bf380d86cd" {
+		t.Errorf("unexpected init.sls of hardening %+v", hardening)
+	}
+}
+
+func TestAccCustomStatesDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccRealServerPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				// Configuration channels of the state type are the custom states.
+				Config: `
+resource "uyuni_config_channel" "test" {
+  label               = "tfacc-ds-state"
+  name                = "tfacc-ds-state"
+  type                = "state"
+  deletion_protection = false
+}
+
+data "uyuni_custom_states" "all" {
+  depends_on = [uyuni_config_channel.test]
+}
+`,
+				Check: resource.TestCheckTypeSetElemAttr("data.uyuni_custom_states.all", "labels.*", "tfacc-ds-state"),
+			},
+		},
+	})
+}
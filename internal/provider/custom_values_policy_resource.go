@@ -0,0 +1,420 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"sync"
+
+	"terraform-provider-uyuni/internal/uyuni"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &customValuesPolicyResource{}
+	_ resource.ResourceWithConfigure   = &customValuesPolicyResource{}
+	_ resource.ResourceWithImportState = &customValuesPolicyResource{}
+	_ resource.ResourceWithModifyPlan  = &customValuesPolicyResource{}
+)
+
+// NewCustomValuesPolicyResource is a helper function to simplify the provider implementation.
+func NewCustomValuesPolicyResource() resource.Resource {
+	return &customValuesPolicyResource{}
+}
+
+// customValuesPolicyResource is the resource implementation.
+type customValuesPolicyResource struct {
+	client *uyuniClient
+}
+
+// customValuesPolicyResourceModel maps the resource schema data.
+type customValuesPolicyResourceModel struct {
+	ID                    types.String   `tfsdk:"id"`
+	GroupName             types.String   `tfsdk:"group_name"`
+	Values                types.Map      `tfsdk:"values"`
+	Enforce               types.Bool     `tfsdk:"enforce"`
+	SystemIDs             types.Set      `tfsdk:"system_ids"`
+	NonCompliantSystemIDs types.Set      `tfsdk:"non_compliant_system_ids"`
+	ServerAlias           types.String   `tfsdk:"server_alias"`
+	Org                   *orgModel      `tfsdk:"org"`
+	Timeouts              timeouts.Value `tfsdk:"timeouts"`
+}
+
+// Metadata returns the resource type name.
+func (r *customValuesPolicyResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_custom_values_policy"
+}
+
+// Schema defines the schema for the resource.
+func (r *customValuesPolicyResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Requires every member of a system group to have custom values. Members missing a value or " +
+			"having another one are reported in non_compliant_system_ids and, with enforce, show as a change and " +
+			"are fixed on apply. The keys must exist on the server. Custom values of other keys are left alone, and " +
+			"destroying the resource leaves the values on the systems.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Name of the group.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"group_name": schema.StringAttribute{
+				Description: "Name of the system group whose members must have the values.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"values": schema.MapAttribute{
+				Description: "Required custom values by key.",
+				ElementType: types.StringType,
+				Required:    true,
+				Validators: []validator.Map{
+					mapvalidator.SizeAtLeast(1),
+				},
+			},
+			"enforce": schema.BoolAttribute{
+				Description: "Set the values on non-compliant members on apply. Without it, they are only reported. " +
+					"Defaults to true.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(true),
+			},
+			"system_ids": schema.SetAttribute{
+				Description: "IDs of the members of the group at the last apply or refresh.",
+				ElementType: types.Int64Type,
+				Computed:    true,
+			},
+			"non_compliant_system_ids": schema.SetAttribute{
+				Description: "IDs of the members missing a value or having another one. Empty after an apply with " +
+					"enforce.",
+				ElementType: types.Int64Type,
+				Computed:    true,
+			},
+			"server_alias": serverAliasAttribute(),
+		},
+		Blocks: map[string]schema.Block{
+			"org": orgBlock(),
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+			}),
+		},
+	}
+}
+
+// ModifyPlan plans no non-compliant systems with enforce, so that systems
+// found non-compliant on refresh show as a change. Without enforce they are
+// only known after apply if the values change.
+func (r *customValuesPolicyResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to plan on destroy.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan customValuesPolicyResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	nonCompliant := types.SetUnknown(types.Int64Type)
+	switch {
+	case plan.Enforce.ValueBool():
+		nonCompliant = types.SetValueMust(types.Int64Type, nil)
+	case !req.State.Raw.IsNull():
+		var state customValuesPolicyResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if state.Values.Equal(plan.Values) {
+			nonCompliant = state.NonCompliantSystemIDs
+		}
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("non_compliant_system_ids"), nonCompliant)...)
+}
+
+// groupCustomValues returns the custom values of the members of the group by
+// system ID.
+func groupCustomValues(ctx context.Context, client *uyuniClient, group string) (map[string]map[string]string, error) {
+	systems, err := apiGet[[]uyuni.ShortSystem](ctx, client, "systemgroup/listSystemsMinimal?systemGroupName="+url.QueryEscape(group))
+	if err != nil {
+		return nil, err
+	}
+	sids := make([]string, 0, len(systems.Result))
+	for _, system := range systems.Result {
+		sids = append(sids, strconv.Itoa(system.ID))
+	}
+
+	var mu sync.Mutex
+	values := map[string]map[string]string{}
+	errs := runBatch(sids, func(sid string) error {
+		current, err := apiGet[map[string]string](ctx, client, "system/getCustomValues?sid="+sid)
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		values[sid] = current.Result
+		mu.Unlock()
+		return nil
+	})
+	if err := batchError(errs); err != nil {
+		return nil, fmt.Errorf("could not read custom values of systems %s", err)
+	}
+	return values, nil
+}
+
+// nonCompliantSystems returns the IDs of the systems missing a required value
+// or having another one, sorted.
+func nonCompliantSystems(current map[string]map[string]string, required map[string]string) []string {
+	sids := []string{}
+	for sid, values := range current {
+		for key, value := range required {
+			if actual, ok := values[key]; !ok || actual != value {
+				sids = append(sids, sid)
+				break
+			}
+		}
+	}
+	sort.Strings(sids)
+	return sids
+}
+
+// int64SetValue returns a set of the IDs.
+func int64SetValue(ctx context.Context, ids []string) (types.Set, error) {
+	values := make([]int64, 0, len(ids))
+	for _, id := range ids {
+		value, err := strconv.ParseInt(id, 10, 64)
+		if err != nil {
+			return types.Set{}, err
+		}
+		values = append(values, value)
+	}
+	set, diags := types.SetValueFrom(ctx, types.Int64Type, values)
+	if diags.HasError() {
+		return types.Set{}, fmt.Errorf("could not convert system IDs: %v", diags)
+	}
+	return set, nil
+}
+
+// refresh reads the members of the group and sets system_ids and
+// non_compliant_system_ids. It returns the non-compliant systems.
+func (m *customValuesPolicyResourceModel) refresh(ctx context.Context, client *uyuniClient) ([]string, error) {
+	current, err := groupCustomValues(ctx, client, m.GroupName.ValueString())
+	if err != nil {
+		return nil, err
+	}
+	required, err := stringMap(ctx, m.Values)
+	if err != nil {
+		return nil, err
+	}
+
+	sids := make([]string, 0, len(current))
+	for sid := range current {
+		sids = append(sids, sid)
+	}
+	nonCompliant := nonCompliantSystems(current, required)
+	if m.SystemIDs, err = int64SetValue(ctx, sids); err != nil {
+		return nil, err
+	}
+	if m.NonCompliantSystemIDs, err = int64SetValue(ctx, nonCompliant); err != nil {
+		return nil, err
+	}
+	return nonCompliant, nil
+}
+
+// apply reads the members of the group and, with enforce, sets the values on
+// the non-compliant ones.
+func (r *customValuesPolicyResource) apply(ctx context.Context, client *uyuniClient, plan *customValuesPolicyResourceModel) error {
+	nonCompliant, err := plan.refresh(ctx, client)
+	if err != nil {
+		return err
+	}
+	plan.ID = plan.GroupName
+	if len(nonCompliant) == 0 {
+		return nil
+	}
+	if !plan.Enforce.ValueBool() {
+		tflog.Warn(ctx, fmt.Sprintf("Systems %v of group %s do not have the required custom values", nonCompliant, plan.GroupName.ValueString()))
+		return nil
+	}
+
+	required, err := stringMap(ctx, plan.Values)
+	if err != nil {
+		return err
+	}
+	tflog.Info(ctx, fmt.Sprintf("Setting the required custom values of %d systems of group %s", len(nonCompliant), plan.GroupName.ValueString()))
+	errs := runBatch(nonCompliant, func(sid string) error {
+		id, err := strconv.ParseInt(sid, 10, 64)
+		if err != nil {
+			return err
+		}
+		return setCustomValues(ctx, client, id, required, nil)
+	})
+	if err := batchError(errs); err != nil {
+		return fmt.Errorf("could not fix systems %s", err)
+	}
+	plan.NonCompliantSystemIDs = types.SetValueMust(types.Int64Type, nil)
+	return nil
+}
+
+// Create a new resource.
+func (r *customValuesPolicyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan customValuesPolicyResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	client := resourceClient(ctx, r.client, plan.ServerAlias, plan.Org, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	if err := r.apply(ctx, client, &plan); err != nil {
+		resp.Diagnostics.AddError(
+			"Error enforcing custom values",
+			fmt.Sprintf("Could not enforce the custom values of group %s: %s", plan.GroupName.ValueString(), err),
+		)
+		return
+	}
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read refreshes the members of the group and their compliance.
+func (r *customValuesPolicyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Get current state
+	var state customValuesPolicyResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := resourceClient(ctx, r.client, state.ServerAlias, state.Org, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	if _, err := state.refresh(ctx, client); err != nil {
+		if handleNotFound(ctx, resp, err, "System group "+state.GroupName.ValueString()) {
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Reading Uyuni custom values policy",
+			fmt.Sprintf("Could not read the custom values of group %s: %s", state.GroupName.ValueString(), err),
+		)
+		return
+	}
+	state.ID = state.GroupName
+	if state.Enforce.IsNull() {
+		state.Enforce = types.BoolValue(true)
+	}
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *customValuesPolicyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Retrieve values from plan
+	var plan customValuesPolicyResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	client := resourceClient(ctx, r.client, plan.ServerAlias, plan.Org, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	if err := r.apply(ctx, client, &plan); err != nil {
+		resp.Diagnostics.AddError(
+			"Error enforcing custom values",
+			fmt.Sprintf("Could not enforce the custom values of group %s: %s", plan.GroupName.ValueString(), err),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete removes the policy from state, the systems keep their values.
+func (r *customValuesPolicyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state customValuesPolicyResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Info(ctx, "Removing the custom values policy of group "+state.GroupName.ValueString()+" from state, the systems keep their values")
+}
+
+// ImportState imports the policy of a group by its name. The values have to
+// be configured, until then every member is compliant.
+func (r *customValuesPolicyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("group_name"), req, resp)
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *customValuesPolicyResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*uyuniClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *uyuniClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
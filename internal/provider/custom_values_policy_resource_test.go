@@ -0,0 +1,124 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	acctest "github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestCustomValuesPolicyEnforcesValues(t *testing.T) {
+	ctx := context.Background()
+	for _, enforce := range []bool{true, false} {
+		var fixed []float64
+		r := NewCustomValuesPolicyResource()
+		testConfigure(t, r, testAPIClient(t, func(w http.ResponseWriter, req *http.Request) {
+			switch req.URL.Path {
+			case "/systemgroup/listSystemsMinimal":
+				_, _ = w.Write([]byte(`{"success": true, "result": [{"id": 1000010001}, {"id": 1000010002}, {"id": 1000010003}]}`))
+			case "/system/getCustomValues":
+				switch req.URL.Query().Get("sid") {
+				case "1000010001":
+					_, _ = w.Write([]byte(`{"success": true, "result": {"cost_center": "4711", "owner": "web", "rack": "A1"}}`))
+				case "1000010002":
+					_, _ = w.Write([]byte(`{"success": true, "result": {"cost_center": "4712", "owner": "web"}}`))
+				default:
+					_, _ = w.Write([]byte(`{"success": true, "result": {}}`))
+				}
+			case "/system/setCustomValues":
+				if !enforce {
+					t.Errorf("unexpected request %s without enforce", req.URL)
+				}
+				var body map[string]interface{}
+				_ = json.NewDecoder(req.Body).Decode(&body)
+				if values := body["values"].(map[string]interface{}); len(values) != 2 || values["cost_center"] != "4711" {
+					t.Errorf("unexpected values %v", values)
+				}
+				fixed = append(fixed, body["sid"].(float64))
+				_, _ = w.Write([]byte(`{"success": true, "result": 1}`))
+			default:
+				t.Errorf("unexpected request %s", req.URL)
+			}
+		}))
+
+		planned := testState(t, r, map[string]interface{}{
+			"group_name": "web",
+			"values":     map[string]string{"cost_center": "4711", "owner": "web"},
+			"enforce":    enforce,
+		})
+		resp := &resource.CreateResponse{State: planned}
+		r.Create(ctx, resource.CreateRequest{Plan: tfsdk.Plan{Schema: planned.Schema, Raw: planned.Raw}}, resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("enforce = %v: %v", enforce, resp.Diagnostics)
+		}
+
+		var state customValuesPolicyResourceModel
+		resp.State.Get(ctx, &state)
+		nonCompliant, _ := int64Set(ctx, state.NonCompliantSystemIDs)
+		members, _ := int64Set(ctx, state.SystemIDs)
+		if len(members) != 3 {
+			t.Errorf("enforce = %v: expected 3 members, got %v", enforce, members)
+		}
+		if enforce && (len(nonCompliant) != 0 || len(fixed) != 2) {
+			t.Errorf("expected systems 1000010002 and 1000010003 to be fixed, got %v, %v", fixed, nonCompliant)
+		}
+		if !enforce && len(nonCompliant) != 2 {
+			t.Errorf("expected systems 1000010002 and 1000010003 to be reported, got %v", nonCompliant)
+		}
+	}
+}
+
+func TestAccCustomValuesPolicyResource(t *testing.T) {
+	acctest.Test(t, acctest.TestCase{
+		PreCheck: func() {
+			testAccRealServerPreCheck(t)
+			testAccSeedSystemGroup(t, "tfacc-policy")
+			testAccSeed(t, "system/custominfo/createKey", map[string]interface{}{
+				"keyLabel":       "tfacc_owner",
+				"keyDescription": "Key of the acceptance tests",
+			}, "system/custominfo/deleteKey", map[string]interface{}{"keyLabel": "tfacc_owner"})
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []acctest.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccCustomValuesPolicyResourceConfig("team-a"),
+				Check: acctest.ComposeAggregateTestCheckFunc(
+					acctest.TestCheckResourceAttr("uyuni_custom_values_policy.test", "system_ids.#", "0"),
+					acctest.TestCheckResourceAttr("uyuni_custom_values_policy.test", "non_compliant_system_ids.#", "0"),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: testAccCustomValuesPolicyResourceConfig("team-b"),
+				Check:  acctest.TestCheckResourceAttr("uyuni_custom_values_policy.test", "values.tfacc_owner", "team-b"),
+			},
+			// ImportState testing
+			{
+				ResourceName:                         "uyuni_custom_values_policy.test",
+				ImportState:                          true,
+				ImportStateId:                        "tfacc-policy",
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "group_name",
+				// The values are only known from the configuration.
+				ImportStateVerifyIgnore: []string{"values"},
+			},
+		},
+	})
+}
+
+func testAccCustomValuesPolicyResourceConfig(owner string) string {
+	return fmt.Sprintf(`
+resource "uyuni_custom_values_policy" "test" {
+  group_name = "tfacc-policy"
+  values = {
+    tfacc_owner = %q
+  }
+}
+`, owner)
+}
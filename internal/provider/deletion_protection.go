@@ -0,0 +1,66 @@
+package provider
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// deletionProtectionAttribute is the schema of the deletion_protection
+// attribute of resources whose Delete removes objects that are hard to
+// recreate. New resources enable it by default, resources that existed before
+// the attribute disable it to keep destroying them working.
+func deletionProtectionAttribute(enabled bool) schema.BoolAttribute {
+	return schema.BoolAttribute{
+		Description: "Prevent Terraform from deleting the object. It has to be set to false and applied before the resource can be destroyed.",
+		Optional:    true,
+		Computed:    true,
+		Default:     booldefault.StaticBool(enabled),
+	}
+}
+
+// deletionProtected reports an error and returns true if the deletion
+// protection of the resource is enabled.
+func deletionProtected(protection types.Bool, object string, diags *diag.Diagnostics) bool {
+	if !protection.ValueBool() {
+		return false
+	}
+	diags.AddError(
+		"Deletion Protection Enabled",
+		object+" is protected against deletion. Set deletion_protection to false and apply the change before destroying it.",
+	)
+	return true
+}
+
+// destroyInMaintenanceWindowAttribute is the schema of the
+// destroy_in_maintenance_window attribute of resources whose Delete changes
+// systems.
+func destroyInMaintenanceWindowAttribute() schema.BoolAttribute {
+	return schema.BoolAttribute{
+		Description: "Only destroy the resource while a maintenance window of the systems is open. The systems must " +
+			"share a maintenance schedule, destroying it outside of its windows fails. Defaults to false.",
+		Optional: true,
+	}
+}
+
+// outsideMaintenanceWindow reports an error and returns true if the resource
+// may only be destroyed in a maintenance window of the systems and none is
+// open.
+func outsideMaintenanceWindow(ctx context.Context, client *uyuniClient, restricted types.Bool, sids []int64, object string, diags *diag.Diagnostics) bool {
+	if !restricted.ValueBool() {
+		return false
+	}
+	if err := maintenanceWindowOpen(ctx, client, sids, time.Now()); err != nil {
+		diags.AddError(
+			"Outside of Maintenance Window",
+			object+" may only be destroyed in a maintenance window: "+err.Error()+". Destroy it in the next window or "+
+				"set destroy_in_maintenance_window to false and apply the change first.",
+		)
+		return true
+	}
+	return false
+}
@@ -0,0 +1,68 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+)
+
+func TestDeletionProtection(t *testing.T) {
+	for protected, wantCalls := range map[bool]int{true: 0, false: 1} {
+		calls := 0
+		client := testAPIClient(t, func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			_, _ = w.Write([]byte(`{"success": true, "result": 1}`))
+		})
+
+		r := NewRetailBranchResource()
+		testConfigure(t, r, client)
+		state := testState(t, r, map[string]interface{}{
+			"branch_id":           "B001",
+			"deletion_protection": protected,
+		})
+
+		var resp resource.DeleteResponse
+		r.Delete(context.Background(), resource.DeleteRequest{State: state}, &resp)
+
+		if resp.Diagnostics.HasError() != protected {
+			t.Errorf("deletion_protection = %v: got diagnostics %v", protected, resp.Diagnostics)
+		}
+		if calls != wantCalls {
+			t.Errorf("deletion_protection = %v: expected %d API calls, got %d", protected, wantCalls, calls)
+		}
+	}
+}
+
+func TestDestroyInMaintenanceWindow(t *testing.T) {
+	for restricted, wantDeleted := range map[bool]bool{true: false, false: true} {
+		deleted := false
+		client := testAPIClient(t, func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/maintenance/listScheduleNames":
+				_, _ = w.Write([]byte(`{"success": true, "result": []}`))
+			case "/system/deleteSystem":
+				deleted = true
+				_, _ = w.Write([]byte(`{"success": true, "result": 1}`))
+			default:
+				t.Errorf("unexpected request %s", r.URL)
+			}
+		})
+
+		r := NewBootstrapHostResource()
+		testConfigure(t, r, client)
+		state := testState(t, r, map[string]interface{}{
+			"system_id":                     1000010001,
+			"cleanup_type":                  "NO_CLEANUP",
+			"destroy_in_maintenance_window": restricted,
+		})
+
+		var resp resource.DeleteResponse
+		r.Delete(context.Background(), resource.DeleteRequest{State: state}, &resp)
+
+		if resp.Diagnostics.HasError() == wantDeleted || deleted != wantDeleted {
+			t.Errorf("destroy_in_maintenance_window = %v: deleted %v, got diagnostics %v", restricted, deleted, resp.Diagnostics)
+		}
+	}
+}
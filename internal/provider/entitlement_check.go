@@ -0,0 +1,73 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"slices"
+	"sort"
+	"strings"
+
+	"terraform-provider-uyuni/internal/uyuni"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// checkEntitlements returns an error naming the entitlements the server does
+// not offer, e.g. add-ons of SUSE Manager on an Uyuni server, so they are
+// refused when planning instead of failing the apply. Nothing is refused if
+// the offered entitlements cannot be listed, which only satellite
+// administrators may do.
+func checkEntitlements(ctx context.Context, client *uyuniClient, entitlements []string) error {
+	if len(entitlements) == 0 {
+		return nil
+	}
+	offered, err := apiGet[[]uyuni.EntitlementUsage](ctx, client, "org/listSystemEntitlements")
+	if err != nil {
+		tflog.Debug(ctx, "Not checking entitlements, the offered entitlements could not be listed", map[string]interface{}{"error": err.Error()})
+		return nil
+	}
+	labels := make([]string, 0, len(offered.Result))
+	for _, entitlement := range offered.Result {
+		labels = append(labels, entitlement.Label)
+	}
+	sort.Strings(labels)
+
+	var unsupported []string
+	for _, entitlement := range entitlements {
+		if !slices.Contains(labels, entitlement) {
+			unsupported = append(unsupported, entitlement)
+		}
+	}
+	if len(unsupported) == 0 {
+		return nil
+	}
+	sort.Strings(unsupported)
+	server := "the server"
+	if client.version != nil {
+		server = client.version.String()
+	}
+	return fmt.Errorf("%s does not offer the entitlements %s, it offers %s",
+		server, strings.Join(unsupported, ", "), strings.Join(labels, ", "))
+}
+
+// checkActivationKeyEntitlements checks the entitlements of the
+// comma-separated activation keys with checkEntitlements. Keys which do not
+// exist yet, e.g. as they are created by the same apply, are not checked.
+func checkActivationKeyEntitlements(ctx context.Context, client *uyuniClient, keys string) error {
+	for _, key := range strings.Split(keys, ",") {
+		key = strings.TrimSpace(key)
+		if key == "" {
+			continue
+		}
+		details, err := apiGet[uyuni.ActivationKey](ctx, client, "activationkey/getDetails?key="+url.QueryEscape(key))
+		if err != nil {
+			tflog.Debug(ctx, "Not checking the entitlements of activation key "+key, map[string]interface{}{"error": err.Error()})
+			continue
+		}
+		if err := checkEntitlements(ctx, client, details.Result.Entitlements); err != nil {
+			return fmt.Errorf("activation key %s: %w", key, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,207 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"terraform-provider-uyuni/internal/uyuni"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &EntitlementUsageDataSource{}
+	_ datasource.DataSourceWithConfigure = &EntitlementUsageDataSource{}
+)
+
+// EntitlementUsageDataSourceModel maps the data source schema data.
+type EntitlementUsageDataSourceModel struct {
+	OrgID                  types.Int64             `tfsdk:"org_id"`
+	IgnorePermissionErrors types.Bool              `tfsdk:"ignore_permission_errors"`
+	Entitlements           []entitlementUsageModel `tfsdk:"entitlements"`
+}
+
+// entitlementUsageModel maps the usage of an entitlement by an organization.
+type entitlementUsageModel struct {
+	OrgID       types.Int64  `tfsdk:"org_id"`
+	OrgName     types.String `tfsdk:"org_name"`
+	Label       types.String `tfsdk:"label"`
+	Name        types.String `tfsdk:"name"`
+	Allocated   types.Int64  `tfsdk:"allocated"`
+	Unallocated types.Int64  `tfsdk:"unallocated"`
+	Used        types.Int64  `tfsdk:"used"`
+	Free        types.Int64  `tfsdk:"free"`
+}
+
+// NewEntitlementUsageDataSource is a helper function to simplify the provider implementation.
+func NewEntitlementUsageDataSource() datasource.DataSource {
+	return &EntitlementUsageDataSource{}
+}
+
+// EntitlementUsageDataSource is the data source implementation.
+type EntitlementUsageDataSource struct {
+	client *uyuniClient
+}
+
+// Metadata returns the data source type name.
+func (d *EntitlementUsageDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_entitlement_usage"
+}
+
+// Schema defines the schema for the data source.
+func (d *EntitlementUsageDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists how many system entitlements each organization uses and has left, e.g. to fail a plan in a precondition " +
+			"when provisioning would exceed them. Requires the `satellite_admin` role.",
+		Attributes: map[string]schema.Attribute{
+			"org_id": schema.Int64Attribute{
+				Description: "ID of the organization. All organizations are listed if omitted.",
+				Optional:    true,
+			},
+			"ignore_permission_errors": ignorePermissionErrorsAttribute(roleSatelliteAdmin),
+			"entitlements": schema.ListNestedAttribute{
+				Description: "Usage of each entitlement by each organization, ordered by organization ID and label.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"org_id": schema.Int64Attribute{
+							Description: "ID of the organization.",
+							Computed:    true,
+						},
+						"org_name": schema.StringAttribute{
+							Description: "Name of the organization.",
+							Computed:    true,
+						},
+						"label": schema.StringAttribute{
+							Description: "Label of the entitlement, e.g. `monitoring_entitled`.",
+							Computed:    true,
+						},
+						"name": schema.StringAttribute{
+							Description: "Name of the entitlement.",
+							Computed:    true,
+						},
+						"allocated": schema.Int64Attribute{
+							Description: "Number of entitlements allocated to the organization.",
+							Computed:    true,
+						},
+						"unallocated": schema.Int64Attribute{
+							Description: "Number of entitlements not allocated to any organization.",
+							Computed:    true,
+						},
+						"used": schema.Int64Attribute{
+							Description: "Number of systems of the organization using the entitlement.",
+							Computed:    true,
+						},
+						"free": schema.Int64Attribute{
+							Description: "Number of allocated entitlements the organization does not use.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *EntitlementUsageDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state EntitlementUsageDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	selected, ok := selectOrgs(ctx, d.client, state.OrgID, state.IgnorePermissionErrors, &resp.Diagnostics)
+	if !ok {
+		return
+	}
+
+	state.Entitlements = []entitlementUsageModel{}
+	for _, org := range selected {
+		usage, err := apiGet[[]uyuni.EntitlementUsage](ctx, d.client, fmt.Sprintf("org/listSystemEntitlementsForOrg?orgId=%d", org.ID))
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Read Uyuni entitlement usage",
+				fmt.Sprintf("Could not list the entitlements of organization %s: %s", org.Name, err),
+			)
+			return
+		}
+		sort.Slice(usage.Result, func(i, j int) bool { return usage.Result[i].Label < usage.Result[j].Label })
+		for _, entitlement := range usage.Result {
+			state.Entitlements = append(state.Entitlements, entitlementUsageModel{
+				OrgID:       types.Int64Value(int64(org.ID)),
+				OrgName:     types.StringValue(org.Name),
+				Label:       types.StringValue(entitlement.Label),
+				Name:        types.StringValue(entitlement.Name),
+				Allocated:   types.Int64Value(int64(entitlement.Allocated)),
+				Unallocated: types.Int64Value(int64(entitlement.Unallocated)),
+				Used:        types.Int64Value(int64(entitlement.Used)),
+				Free:        types.Int64Value(int64(entitlement.Free)),
+			})
+		}
+	}
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// selectOrgs returns the organizations selected by orgID, all if it is null,
+// ordered by ID. It returns false after reporting an error.
+func selectOrgs(ctx context.Context, client *uyuniClient, orgID types.Int64, ignorePermissionErrors types.Bool, diags *diag.Diagnostics) ([]uyuni.Org, bool) {
+	orgs, err := apiGet[[]uyuni.Org](ctx, client, "org/listOrgs")
+	if err != nil {
+		if !handleListError(diags, "Unable to Read Uyuni organizations", "org/listOrgs", err, roleSatelliteAdmin, ignorePermissionErrors) {
+			return nil, false
+		}
+		orgs = &uyuni.Response[[]uyuni.Org]{}
+	}
+	selected := []uyuni.Org{}
+	for _, org := range orgs.Result {
+		if orgID.IsNull() || int64(org.ID) == orgID.ValueInt64() {
+			selected = append(selected, org)
+		}
+	}
+	// Organizations are only missing if they could be listed.
+	if len(selected) == 0 && !orgID.IsNull() && err == nil {
+		diags.AddAttributeError(
+			path.Root("org_id"),
+			"Organization not found",
+			fmt.Sprintf("No organization has the ID %d.", orgID.ValueInt64()),
+		)
+		return nil, false
+	}
+	sort.Slice(selected, func(i, j int) bool { return selected[i].ID < selected[j].ID })
+	return selected, true
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *EntitlementUsageDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*uyuniClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *uyuniClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
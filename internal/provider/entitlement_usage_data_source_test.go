@@ -0,0 +1,76 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestEntitlementUsageDataSource(t *testing.T) {
+	ctx := context.Background()
+	client := testAPIClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/org/listOrgs":
+			_, _ = w.Write([]byte(`{"success": true, "result": [{"id": 2, "name": "Retail"}, {"id": 1, "name": "SUSE"}]}`))
+		case "/org/listSystemEntitlementsForOrg":
+			if r.URL.Query().Get("orgId") != "2" {
+				t.Errorf("unexpected request %s", r.URL)
+			}
+			_, _ = w.Write([]byte(`{"success": true, "result": [
+				{"label": "monitoring_entitled", "name": "Monitoring", "allocated": 10, "free": 7, "used": 3},
+				{"label": "enterprise_entitled", "name": "Management", "allocated": 50, "free": 8, "used": 42}
+			]}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL)
+		}
+	})
+
+	d := NewEntitlementUsageDataSource()
+	d.(datasource.DataSourceWithConfigure).Configure(ctx, datasource.ConfigureRequest{ProviderData: client}, &datasource.ConfigureResponse{})
+	var schemaResp datasource.SchemaResponse
+	d.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	values := map[string]tftypes.Value{}
+	for name, attributeType := range objectType.AttributeTypes {
+		values[name] = tftypes.NewValue(attributeType, nil)
+	}
+	values["org_id"] = tftypes.NewValue(tftypes.Number, 2)
+	config := tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, values)}
+	resp := &datasource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: config.Raw}}
+	d.Read(ctx, datasource.ReadRequest{Config: config}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+
+	var state EntitlementUsageDataSourceModel
+	resp.State.Get(ctx, &state)
+	if len(state.Entitlements) != 2 {
+		t.Fatalf("expected the entitlements of Retail, got %v", state.Entitlements)
+	}
+	first := state.Entitlements[0]
+	if first.Label.ValueString() != "enterprise_entitled" || first.OrgName.ValueString() != "Retail" || first.Free.ValueInt64() != 8 {
+		t.Errorf("unexpected entitlement %+v", first)
+	}
+}
+
+func TestAccEntitlementUsageDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccRealServerPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `data "uyuni_entitlement_usage" "test" { org_id = 1 }`,
+				Check: resource.TestCheckTypeSetElemNestedAttrs("data.uyuni_entitlement_usage.test", "entitlements.*", map[string]string{
+					"org_id": "1",
+					"label":  "salt_entitled",
+				}),
+			},
+		},
+	})
+}
@@ -0,0 +1,133 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"terraform-provider-uyuni/internal/uyuni"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &EntitlementsDataSource{}
+	_ datasource.DataSourceWithConfigure = &EntitlementsDataSource{}
+)
+
+// EntitlementsDataSourceModel maps the data source schema data.
+type EntitlementsDataSourceModel struct {
+	Labels       types.Set          `tfsdk:"labels"`
+	Entitlements []entitlementModel `tfsdk:"entitlements"`
+}
+
+// entitlementModel maps a system entitlement the server offers.
+type entitlementModel struct {
+	Label types.String `tfsdk:"label"`
+	Name  types.String `tfsdk:"name"`
+}
+
+// NewEntitlementsDataSource is a helper function to simplify the provider implementation.
+func NewEntitlementsDataSource() datasource.DataSource {
+	return &EntitlementsDataSource{}
+}
+
+// EntitlementsDataSource is the data source implementation.
+type EntitlementsDataSource struct {
+	client *uyuniClient
+}
+
+// Metadata returns the data source type name.
+func (d *EntitlementsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_entitlements"
+}
+
+// Schema defines the schema for the data source.
+func (d *EntitlementsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the system entitlements the server offers, e.g. to validate the entitlements of activation keys " +
+			"against the connected release. Requires a server administrator.",
+		Attributes: map[string]schema.Attribute{
+			"labels": schema.SetAttribute{
+				Description: "Labels of the entitlements, e.g. `monitoring_entitled`.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"entitlements": schema.ListNestedAttribute{
+				Description: "Entitlements ordered by label.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"label": schema.StringAttribute{
+							Description: "Label of the entitlement.",
+							Computed:    true,
+						},
+						"name": schema.StringAttribute{
+							Description: "Name of the entitlement.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *EntitlementsDataSource) Read(ctx context.Context, _ datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state EntitlementsDataSourceModel
+
+	entitlements, err := apiGet[[]uyuni.EntitlementUsage](ctx, d.client, "org/listSystemEntitlements")
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to Read Uyuni entitlements", err.Error())
+		return
+	}
+	sort.Slice(entitlements.Result, func(i, j int) bool { return entitlements.Result[i].Label < entitlements.Result[j].Label })
+
+	labels := make([]string, 0, len(entitlements.Result))
+	state.Entitlements = []entitlementModel{}
+	for _, entitlement := range entitlements.Result {
+		labels = append(labels, entitlement.Label)
+		state.Entitlements = append(state.Entitlements, entitlementModel{
+			Label: types.StringValue(entitlement.Label),
+			Name:  types.StringValue(entitlement.Name),
+		})
+	}
+	labelSet, diags := types.SetValueFrom(ctx, types.StringType, labels)
+	state.Labels = labelSet
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *EntitlementsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*uyuniClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *uyuniClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
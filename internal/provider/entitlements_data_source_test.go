@@ -0,0 +1,69 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestEntitlementsDataSource(t *testing.T) {
+	ctx := context.Background()
+	client := testAPIClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/org/listSystemEntitlements" {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		_, _ = w.Write([]byte(`{"success": true, "result": [
+			{"label": "salt_entitled", "name": "Salt", "allocated": 60, "unallocated": 10, "free": 18, "used": 42},
+			{"label": "monitoring_entitled", "name": "Monitoring", "allocated": 10, "unallocated": 0, "free": 7, "used": 3}
+		]}`))
+	})
+
+	d := NewEntitlementsDataSource()
+	d.(datasource.DataSourceWithConfigure).Configure(ctx, datasource.ConfigureRequest{ProviderData: client}, &datasource.ConfigureResponse{})
+	var schemaResp datasource.SchemaResponse
+	d.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	values := map[string]tftypes.Value{}
+	for name, attributeType := range objectType.AttributeTypes {
+		values[name] = tftypes.NewValue(attributeType, nil)
+	}
+	config := tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, values)}
+	resp := &datasource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: config.Raw}}
+	d.Read(ctx, datasource.ReadRequest{Config: config}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+
+	var state EntitlementsDataSourceModel
+	resp.State.Get(ctx, &state)
+	var labels []string
+	state.Labels.ElementsAs(ctx, &labels, false)
+	sort.Strings(labels)
+	if want := []string{"monitoring_entitled", "salt_entitled"}; !reflect.DeepEqual(labels, want) {
+		t.Errorf("expected labels %v, got %v", want, labels)
+	}
+	if len(state.Entitlements) != 2 || state.Entitlements[0].Name.ValueString() != "Monitoring" {
+		t.Errorf("expected the entitlements ordered by label, got %v", state.Entitlements)
+	}
+}
+
+func TestAccEntitlementsDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccRealServerPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `data "uyuni_entitlements" "all" {}`,
+				Check:  resource.TestCheckTypeSetElemAttr("data.uyuni_entitlements.all", "labels.*", "salt_entitled"),
+			},
+		},
+	})
+}
@@ -0,0 +1,476 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"terraform-provider-uyuni/internal/uyuni"
+	"terraform-provider-uyuni/internal/validators"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                     = &errataCloneResource{}
+	_ resource.ResourceWithConfigure        = &errataCloneResource{}
+	_ resource.ResourceWithConfigValidators = &errataCloneResource{}
+)
+
+// NewErrataCloneResource is a helper function to simplify the provider implementation.
+func NewErrataCloneResource() resource.Resource {
+	return &errataCloneResource{}
+}
+
+// errataCloneResource is the resource implementation.
+type errataCloneResource struct {
+	client *uyuniClient
+}
+
+// errataCloneResourceModel maps the resource schema data.
+type errataCloneResourceModel struct {
+	ID                 types.String   `tfsdk:"id"`
+	ParentChannelLabel types.String   `tfsdk:"parent_channel_label"`
+	Advisories         types.Set      `tfsdk:"advisories"`
+	StartDate          types.String   `tfsdk:"start_date"`
+	EndDate            types.String   `tfsdk:"end_date"`
+	Channels           types.Map      `tfsdk:"channels"`
+	ServerAlias        types.String   `tfsdk:"server_alias"`
+	Org                *orgModel      `tfsdk:"org"`
+	Timeouts           timeouts.Value `tfsdk:"timeouts"`
+}
+
+// Metadata returns the resource type name.
+func (r *errataCloneResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_errata_clone"
+}
+
+// Schema defines the schema for the resource.
+func (r *errataCloneResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Clones selected errata from the original channels into a tree of cloned channels, e.g. for monthly patch imports. " +
+			"Every channel of the tree which is a clone receives the selected errata of its original, together with their packages. " +
+			"Errata are selected by advisory name, by date or both. " +
+			"Cloned errata are never removed, neither when the selection shrinks nor when the resource is destroyed.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Label of the parent channel of the cloned tree.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"parent_channel_label": schema.StringAttribute{
+				Description: "Label of the cloned parent channel. The parent and all of its children which are clones receive errata.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validators.ChannelLabel(),
+				},
+			},
+			"advisories": schema.SetAttribute{
+				Description: "Names of the advisories to clone, e.g. `SUSE-2024-2930`. " +
+					"Each channel receives those of the advisories its original contains.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"start_date": schema.StringAttribute{
+				Description: "Only errata of the originals modified on or after this date (YYYY-MM-DD, UTC) are cloned.",
+				Optional:    true,
+				Validators: []validator.String{
+					validators.Date(),
+				},
+			},
+			"end_date": schema.StringAttribute{
+				Description: "Only errata of the originals modified before this date (YYYY-MM-DD, UTC) are cloned.",
+				Optional:    true,
+				Validators: []validator.String{
+					validators.Date(),
+				},
+			},
+			"channels": schema.MapAttribute{
+				Description: "Original channel label by label of each cloned channel of the tree.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"server_alias": serverAliasAttribute(),
+		},
+		Blocks: map[string]schema.Block{
+			"org": orgBlock(),
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+			}),
+		},
+	}
+}
+
+// ConfigValidators returns the validators checking attributes against each other.
+func (r *errataCloneResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		// Without any selection all errata of the originals would be cloned.
+		resourcevalidator.AtLeastOneOf(
+			path.MatchRoot("advisories"),
+			path.MatchRoot("start_date"),
+			path.MatchRoot("end_date"),
+		),
+	}
+}
+
+// listClonedTree returns the original channel label by label of the cloned
+// parent channel and of each of its children which is a clone.
+func listClonedTree(ctx context.Context, client *uyuniClient, parent string) (map[string]string, error) {
+	details, err := apiGet[uyuni.Channel](ctx, client, "channel/software/getDetails?channelLabel="+url.QueryEscape(parent))
+	if err != nil {
+		return nil, err
+	}
+	if details.Result.CloneOriginal == "" {
+		return nil, fmt.Errorf("channel %s is not a clone", parent)
+	}
+	children, err := apiGet[[]uyuni.Channel](ctx, client, "channel/software/listChildren?channelLabel="+url.QueryEscape(parent))
+	if err != nil {
+		return nil, fmt.Errorf("could not list children of channel %s: %w", parent, err)
+	}
+
+	tree := map[string]string{parent: details.Result.CloneOriginal}
+	for _, child := range children.Result {
+		if child.CloneOriginal == "" {
+			tflog.Debug(ctx, "Channel "+child.Label+" is not a clone, skipping it")
+			continue
+		}
+		tree[child.Label] = child.CloneOriginal
+	}
+	return tree, nil
+}
+
+// errataSelection selects errata of the original channels.
+type errataSelection struct {
+	advisories map[string]bool
+	start, end *time.Time
+}
+
+// optionalDate parses an optional date attribute, nil if it is not set.
+func optionalDate(value types.String) (*time.Time, error) {
+	if value.IsNull() {
+		return nil, nil
+	}
+	t, err := time.Parse(validators.DateLayout, value.ValueString())
+	if err != nil {
+		return nil, fmt.Errorf("invalid date %s: %w", value.ValueString(), err)
+	}
+	return &t, nil
+}
+
+// selection returns the errata selection of the plan.
+func (m *errataCloneResourceModel) selection(ctx context.Context) (*errataSelection, error) {
+	s := &errataSelection{}
+	if !m.Advisories.IsNull() {
+		var advisories []string
+		if diags := m.Advisories.ElementsAs(ctx, &advisories, false); diags.HasError() {
+			return nil, fmt.Errorf("invalid advisories")
+		}
+		s.advisories = map[string]bool{}
+		for _, advisory := range advisories {
+			s.advisories[advisory] = true
+		}
+	}
+	var err error
+	if s.start, err = optionalDate(m.StartDate); err != nil {
+		return nil, err
+	}
+	if s.end, err = optionalDate(m.EndDate); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// listChannelErrata returns the errata of the channel, only those last
+// modified in the date range if start or end is set.
+func listChannelErrata(ctx context.Context, client *uyuniClient, label string, start, end *time.Time) ([]uyuni.Erratum, error) {
+	query := url.Values{"channelLabel": {label}}
+	if start != nil || end != nil {
+		from, to := channelSyncEpoch, time.Now()
+		if start != nil {
+			from = *start
+		}
+		if end != nil {
+			to = *end
+		}
+		query.Set("startDate", apiDate(from))
+		query.Set("endDate", apiDate(to))
+	}
+	errata, err := apiGet[[]uyuni.Erratum](ctx, client, "channel/software/listErrata?"+query.Encode())
+	if err != nil {
+		return nil, err
+	}
+	return errata.Result, nil
+}
+
+// listSelectedErrata returns the names of the selected errata of the channel.
+func listSelectedErrata(ctx context.Context, client *uyuniClient, label string, s *errataSelection) ([]string, error) {
+	errata, err := listChannelErrata(ctx, client, label, s.start, s.end)
+	if err != nil {
+		return nil, err
+	}
+
+	names := []string{}
+	for _, erratum := range errata {
+		if s.advisories == nil || s.advisories[erratum.AdvisoryName] {
+			names = append(names, erratum.AdvisoryName)
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// cloneErrata merges the selected errata of the originals into the clones of
+// the tree. It returns the requested advisories no original contains.
+func cloneErrata(ctx context.Context, client *uyuniClient, tree map[string]string, s *errataSelection) ([]string, error) {
+	clones := make([]string, 0, len(tree))
+	for clone := range tree {
+		clones = append(clones, clone)
+	}
+
+	var mu sync.Mutex
+	found := map[string]bool{}
+	errs := runBatch(clones, func(clone string) error {
+		original := tree[clone]
+		names, err := listSelectedErrata(ctx, client, original, s)
+		if err != nil {
+			return fmt.Errorf("could not list errata of channel %s: %w", original, err)
+		}
+		mu.Lock()
+		for _, name := range names {
+			found[name] = true
+		}
+		mu.Unlock()
+		if len(names) == 0 {
+			return nil
+		}
+
+		// mergeErrata skips errata the clone already contains.
+		merged, err := apiPost[[]uyuni.Erratum](ctx, client, "channel/software/mergeErrata", map[string]interface{}{
+			"mergeFromLabel": original,
+			"mergeToLabel":   clone,
+			"errataNames":    names,
+		})
+		if err != nil {
+			return fmt.Errorf("could not merge errata from %s: %w", original, err)
+		}
+		tflog.Info(ctx, fmt.Sprintf("Channel %s: merged %d of %d selected errata from %s", clone, len(merged.Result), len(names), original))
+		return nil
+	})
+	if len(errs) > 0 {
+		failed := make([]string, 0, len(errs))
+		for clone, err := range errs {
+			failed = append(failed, fmt.Sprintf("channel %s: %s", clone, err))
+		}
+		sort.Strings(failed)
+		return nil, fmt.Errorf("could not clone errata into %s", strings.Join(failed, "; "))
+	}
+
+	missing := []string{}
+	for advisory := range s.advisories {
+		if !found[advisory] {
+			missing = append(missing, advisory)
+		}
+	}
+	sort.Strings(missing)
+	return missing, nil
+}
+
+// cloneTree clones the errata selected by the model into its tree and sets
+// the computed attributes.
+func (m *errataCloneResourceModel) cloneTree(ctx context.Context, client *uyuniClient) (warning string, err error) {
+	s, err := m.selection(ctx)
+	if err != nil {
+		return "", err
+	}
+	parent := m.ParentChannelLabel.ValueString()
+	tree, err := listClonedTree(ctx, client, parent)
+	if err != nil {
+		return "", err
+	}
+	missing, err := cloneErrata(ctx, client, tree, s)
+	if err != nil {
+		return "", err
+	}
+	if len(missing) > 0 {
+		warning = fmt.Sprintf("No original channel of the tree of %s contains %s.", parent, strings.Join(missing, ", "))
+	}
+
+	m.ID = m.ParentChannelLabel
+	channels, diags := types.MapValueFrom(ctx, types.StringType, tree)
+	if diags.HasError() {
+		return "", fmt.Errorf("could not set channels")
+	}
+	m.Channels = channels
+	return warning, nil
+}
+
+// Create a new resource.
+func (r *errataCloneResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan errataCloneResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	client := resourceClient(ctx, r.client, plan.ServerAlias, plan.Org, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	warning, err := plan.cloneTree(ctx, client)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error cloning errata",
+			"Could not clone errata into the tree of "+plan.ParentChannelLabel.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+	if warning != "" {
+		resp.Diagnostics.AddAttributeWarning(path.Root("advisories"), "Advisories Not Found", warning)
+	}
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read resource information.
+func (r *errataCloneResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Get current state
+	var state errataCloneResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := resourceClient(ctx, r.client, state.ServerAlias, state.Org, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	parent := state.ParentChannelLabel.ValueString()
+	tree, err := listClonedTree(ctx, client, parent)
+	if err != nil {
+		if handleNotFound(ctx, resp, err, "Channel "+parent) {
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Reading Uyuni errata clone",
+			"Could not read the tree of channel "+parent+": "+err.Error(),
+		)
+		return
+	}
+	state.ID = state.ParentChannelLabel
+	state.Channels, diags = types.MapValueFrom(ctx, types.StringType, tree)
+	resp.Diagnostics.Append(diags...)
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update clones the errata of the new selection. Errata cloned before stay.
+func (r *errataCloneResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Retrieve values from plan
+	var plan errataCloneResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	client := resourceClient(ctx, r.client, plan.ServerAlias, plan.Org, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	warning, err := plan.cloneTree(ctx, client)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error cloning errata",
+			"Could not clone errata into the tree of "+plan.ParentChannelLabel.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+	if warning != "" {
+		resp.Diagnostics.AddAttributeWarning(path.Root("advisories"), "Advisories Not Found", warning)
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete removes the errata clone from state. The cloned errata stay in the
+// channels.
+func (r *errataCloneResource) Delete(ctx context.Context, _ resource.DeleteRequest, _ *resource.DeleteResponse) {
+	tflog.Info(ctx, "Removing errata clone from state, cloned errata stay in the channels")
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *errataCloneResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*uyuniClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *uyuniClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
@@ -0,0 +1,137 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	acctest "github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+// testErrataCloneServer serves the clone tree prod-pool with the cloned child
+// prod-updates and the in-house child tools, and records the errata merged
+// by clone.
+type testErrataCloneServer struct {
+	mu     sync.Mutex
+	merged map[string][]string
+}
+
+func (s *testErrataCloneServer) handler(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case r.URL.Path == "/channel/software/getDetails" && r.URL.Query().Get("channelLabel") == "prod-pool":
+		_, _ = w.Write([]byte(`{"success": true, "result": {"label": "prod-pool", "clone_original": "pool"}}`))
+	case r.URL.Path == "/channel/software/getDetails":
+		_, _ = w.Write([]byte(`{"success": true, "result": {"label": "pool", "clone_original": ""}}`))
+	case r.URL.Path == "/channel/software/listChildren":
+		_, _ = w.Write([]byte(`{"success": true, "result": [
+			{"label": "prod-updates", "parent_channel_label": "prod-pool", "clone_original": "updates"},
+			{"label": "tools", "parent_channel_label": "prod-pool", "clone_original": ""}
+		]}`))
+	case r.URL.Path == "/channel/software/listErrata" && r.URL.Query().Get("channelLabel") == "updates":
+		_, _ = w.Write([]byte(`{"success": true, "result": [
+			{"id": 1, "advisory_name": "SUSE-2024-1"},
+			{"id": 2, "advisory_name": "SUSE-2024-2"},
+			{"id": 3, "advisory_name": "SUSE-2024-3"}
+		]}`))
+	case r.URL.Path == "/channel/software/listErrata":
+		_, _ = w.Write([]byte(`{"success": true, "result": []}`))
+	case r.URL.Path == "/channel/software/mergeErrata":
+		var body struct {
+			MergeToLabel string   `json:"mergeToLabel"`
+			ErrataNames  []string `json:"errataNames"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		s.mu.Lock()
+		s.merged[body.MergeToLabel] = body.ErrataNames
+		s.mu.Unlock()
+		_, _ = w.Write([]byte(`{"success": true, "result": []}`))
+	default:
+		_, _ = w.Write([]byte(`{"success": false, "message": "unexpected request ` + r.URL.String() + `"}`))
+	}
+}
+
+func TestErrataCloneCreateMergesSelectedAdvisories(t *testing.T) {
+	ctx := context.Background()
+	server := &testErrataCloneServer{merged: map[string][]string{}}
+	r := NewErrataCloneResource()
+	testConfigure(t, r, testAPIClient(t, server.handler))
+
+	planned := testState(t, r, map[string]interface{}{
+		"parent_channel_label": "prod-pool",
+		"advisories":           []string{"SUSE-2024-1", "SUSE-2024-3", "SUSE-2024-9"},
+	})
+	resp := &resource.CreateResponse{State: planned}
+	r.Create(ctx, resource.CreateRequest{Plan: tfsdk.Plan{Schema: planned.Schema, Raw: planned.Raw}}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+
+	want := map[string][]string{"prod-updates": {"SUSE-2024-1", "SUSE-2024-3"}}
+	if !reflect.DeepEqual(server.merged, want) {
+		t.Errorf("expected merges %v, got %v", want, server.merged)
+	}
+	if len(resp.Diagnostics.Warnings()) != 1 || !strings.Contains(resp.Diagnostics.Warnings()[0].Detail(), "SUSE-2024-9") {
+		t.Errorf("expected a warning about SUSE-2024-9, got %v", resp.Diagnostics)
+	}
+
+	var state errataCloneResourceModel
+	resp.State.Get(ctx, &state)
+	channels := map[string]string{}
+	state.Channels.ElementsAs(ctx, &channels, false)
+	if !reflect.DeepEqual(channels, map[string]string{"prod-pool": "pool", "prod-updates": "updates"}) {
+		t.Errorf("unexpected channels %v", channels)
+	}
+}
+
+func TestErrataCloneRejectsOriginalTree(t *testing.T) {
+	ctx := context.Background()
+	server := &testErrataCloneServer{merged: map[string][]string{}}
+	r := NewErrataCloneResource()
+	testConfigure(t, r, testAPIClient(t, server.handler))
+
+	planned := testState(t, r, map[string]interface{}{
+		"parent_channel_label": "pool",
+		"end_date":             "2024-09-01",
+	})
+	resp := &resource.CreateResponse{State: planned}
+	r.Create(ctx, resource.CreateRequest{Plan: tfsdk.Plan{Schema: planned.Schema, Raw: planned.Raw}}, resp)
+	if !resp.Diagnostics.HasError() || !strings.Contains(resp.Diagnostics[0].Detail(), "is not a clone") {
+		t.Errorf("expected an error about the original channel, got %v", resp.Diagnostics)
+	}
+	if len(server.merged) > 0 {
+		t.Errorf("expected no merges, got %v", server.merged)
+	}
+}
+
+func TestAccErrataCloneResource(t *testing.T) {
+	channel := os.Getenv("UYUNI_TEST_CLONED_CHANNEL")
+	acctest.Test(t, acctest.TestCase{
+		PreCheck: func() {
+			testAccRealServerPreCheck(t)
+			testAccSkipUnlessEnv(t, "UYUNI_TEST_CLONED_CHANNEL", "a base channel cloned in its original state")
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []acctest.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "uyuni_errata_clone" "test" {
+  parent_channel_label = %q
+  end_date             = "2024-01-01"
+}
+`, channel),
+				Check: acctest.ComposeAggregateTestCheckFunc(
+					acctest.TestCheckResourceAttr("uyuni_errata_clone.test", "parent_channel_label", channel),
+					acctest.TestCheckResourceAttrSet("uyuni_errata_clone.test", "channels.#"),
+				),
+			},
+		},
+	})
+}
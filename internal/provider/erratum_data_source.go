@@ -0,0 +1,254 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+
+	"terraform-provider-uyuni/internal/uyuni"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &ErratumDataSource{}
+	_ datasource.DataSourceWithConfigure = &ErratumDataSource{}
+)
+
+// ErratumDataSourceModel maps the data source schema data.
+type ErratumDataSourceModel struct {
+	AdvisoryName    types.String          `tfsdk:"advisory_name"`
+	ID              types.Int64           `tfsdk:"id"`
+	Synopsis        types.String          `tfsdk:"synopsis"`
+	Type            types.String          `tfsdk:"type"`
+	Severity        types.String          `tfsdk:"severity"`
+	Status          types.String          `tfsdk:"status"`
+	IssueDate       types.String          `tfsdk:"issue_date"`
+	UpdateDate      types.String          `tfsdk:"update_date"`
+	Description     types.String          `tfsdk:"description"`
+	RebootSuggested types.Bool            `tfsdk:"reboot_suggested"`
+	CVEs            types.Set             `tfsdk:"cves"`
+	Channels        types.Set             `tfsdk:"channels"`
+	Packages        []erratumPackageModel `tfsdk:"packages"`
+}
+
+// erratumPackageModel maps the packages of an erratum.
+type erratumPackageModel struct {
+	ID       types.Int64  `tfsdk:"id"`
+	NEVRA    types.String `tfsdk:"nevra"`
+	Name     types.String `tfsdk:"name"`
+	Channels types.Set    `tfsdk:"channels"`
+}
+
+// NewErratumDataSource is a helper function to simplify the provider implementation.
+func NewErratumDataSource() datasource.DataSource {
+	return &ErratumDataSource{}
+}
+
+// ErratumDataSource is the data source implementation.
+type ErratumDataSource struct {
+	client *uyuniClient
+}
+
+// Metadata returns the data source type name.
+func (d *ErratumDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_erratum"
+}
+
+// Schema defines the schema for the data source.
+func (d *ErratumDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Looks up an advisory by name, e.g. to describe it in a change ticket.",
+		Attributes: map[string]schema.Attribute{
+			"advisory_name": schema.StringAttribute{
+				Description: "Name of the advisory, e.g. `SUSE-2024-2930`.",
+				Required:    true,
+			},
+			"id": schema.Int64Attribute{
+				Description: "ID of the advisory.",
+				Computed:    true,
+			},
+			"synopsis": schema.StringAttribute{
+				Description: "Synopsis of the advisory.",
+				Computed:    true,
+			},
+			"type": schema.StringAttribute{
+				Description: "Type of the advisory: `Security Advisory`, `Bug Fix Advisory` or `Product Enhancement Advisory`.",
+				Computed:    true,
+			},
+			"severity": schema.StringAttribute{
+				Description: "Severity of a security advisory, e.g. `critical` or `moderate`. Null if the advisory has none.",
+				Computed:    true,
+			},
+			"status": schema.StringAttribute{
+				Description: "Status of the advisory, e.g. `final` or `retracted`.",
+				Computed:    true,
+			},
+			"issue_date": schema.StringAttribute{
+				Description: "Date the advisory was issued, in RFC 3339 format.",
+				Computed:    true,
+			},
+			"update_date": schema.StringAttribute{
+				Description: "Date the advisory was last updated, in RFC 3339 format.",
+				Computed:    true,
+			},
+			"description": schema.StringAttribute{
+				Description: "Description of the advisory.",
+				Computed:    true,
+			},
+			"reboot_suggested": schema.BoolAttribute{
+				Description: "Whether systems should be rebooted after applying the advisory.",
+				Computed:    true,
+			},
+			"cves": schema.SetAttribute{
+				Description: "CVEs fixed by the advisory.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"channels": schema.SetAttribute{
+				Description: "Labels of the channels the advisory applies to.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"packages": schema.ListNestedAttribute{
+				Description: "Packages of the advisory, ordered by NEVRA.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.Int64Attribute{
+							Description: "ID of the package.",
+							Computed:    true,
+						},
+						"nevra": schema.StringAttribute{
+							Description: "Name-[epoch:]version-release.arch of the package.",
+							Computed:    true,
+						},
+						"name": schema.StringAttribute{
+							Description: "Name of the package.",
+							Computed:    true,
+						},
+						"channels": schema.SetAttribute{
+							Description: "Labels of the channels providing the package.",
+							ElementType: types.StringType,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *ErratumDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state ErratumDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	advisory := state.AdvisoryName.ValueString()
+	query := "?advisoryName=" + url.QueryEscape(advisory)
+	details, err := apiGet[uyuni.ErratumDetails](ctx, d.client, "errata/getDetails"+query)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Uyuni erratum",
+			"Could not read advisory "+advisory+": "+err.Error(),
+		)
+		return
+	}
+	cves, err := apiGet[[]string](ctx, d.client, "errata/listCves"+query)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Uyuni erratum",
+			"Could not list CVEs of advisory "+advisory+": "+err.Error(),
+		)
+		return
+	}
+	channels, err := apiGet[[]uyuni.ErratumChannel](ctx, d.client, "errata/applicableToChannels"+query)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Uyuni erratum",
+			"Could not list channels of advisory "+advisory+": "+err.Error(),
+		)
+		return
+	}
+	packages, err := apiGet[[]uyuni.ErratumPackage](ctx, d.client, "errata/listPackages"+query)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Uyuni erratum",
+			"Could not list packages of advisory "+advisory+": "+err.Error(),
+		)
+		return
+	}
+
+	state.ID = types.Int64Value(int64(details.Result.ID))
+	state.Synopsis = types.StringValue(details.Result.Synopsis)
+	state.Type = types.StringValue(details.Result.Type)
+	state.Severity = types.StringNull()
+	if details.Result.Severity != "" {
+		state.Severity = types.StringValue(details.Result.Severity)
+	}
+	state.Status = types.StringValue(details.Result.AdvisoryStatus)
+	state.IssueDate = timestampValue(ctx, details.Result.IssueDate)
+	state.UpdateDate = timestampValue(ctx, details.Result.UpdateDate)
+	state.Description = types.StringValue(details.Result.Description)
+	state.RebootSuggested = types.BoolValue(details.Result.RebootSuggested)
+
+	state.CVEs, diags = types.SetValueFrom(ctx, types.StringType, append([]string{}, cves.Result...))
+	resp.Diagnostics.Append(diags...)
+	labels := make([]string, 0, len(channels.Result))
+	for _, channel := range channels.Result {
+		labels = append(labels, channel.Label)
+	}
+	state.Channels, diags = types.SetValueFrom(ctx, types.StringType, labels)
+	resp.Diagnostics.Append(diags...)
+
+	sort.Slice(packages.Result, func(i, j int) bool {
+		return packages.Result[i].NEVRA() < packages.Result[j].NEVRA()
+	})
+	state.Packages = make([]erratumPackageModel, 0, len(packages.Result))
+	for _, pkg := range packages.Result {
+		providing, diags := types.SetValueFrom(ctx, types.StringType, append([]string{}, pkg.ProvidingChannels...))
+		resp.Diagnostics.Append(diags...)
+		state.Packages = append(state.Packages, erratumPackageModel{
+			ID:       types.Int64Value(int64(pkg.ID)),
+			NEVRA:    types.StringValue(pkg.NEVRA()),
+			Name:     types.StringValue(pkg.Name),
+			Channels: providing,
+		})
+	}
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *ErratumDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*uyuniClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *uyuniClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
@@ -0,0 +1,104 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestErratumDataSource(t *testing.T) {
+	ctx := context.Background()
+	client := testAPIClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("advisoryName") != "SUSE-2024-2930" {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		switch r.URL.Path {
+		case "/errata/getDetails":
+			_, _ = w.Write([]byte(`{"success": true, "result": {"id": 4711, "synopsis": "Security update for openssl-3",
+				"type": "Security Advisory", "advisory_status": "final", "issue_date": "2024-08-20", "update_date": "2024-08-21",
+				"reboot_suggested": false, "severity": "moderate"}}`))
+		case "/errata/listCves":
+			_, _ = w.Write([]byte(`{"success": true, "result": ["CVE-2024-6119", "CVE-2024-5535"]}`))
+		case "/errata/applicableToChannels":
+			_, _ = w.Write([]byte(`{"success": true, "result": [{"channel_id": 117, "label": "updates"}]}`))
+		case "/errata/listPackages":
+			_, _ = w.Write([]byte(`{"success": true, "result": [
+				{"id": 2, "name": "openssl-3", "epoch": "", "version": "3.1.4", "release": "5.15.1", "arch_label": "x86_64", "providing_channels": ["updates"]},
+				{"id": 1, "name": "libopenssl3", "epoch": "", "version": "3.1.4", "release": "5.15.1", "arch_label": "x86_64", "providing_channels": ["updates"]}
+			]}`))
+		default:
+			_, _ = w.Write([]byte(`{"success": false, "message": "unexpected request ` + r.URL.String() + `"}`))
+		}
+	})
+
+	d := NewErratumDataSource()
+	d.(datasource.DataSourceWithConfigure).Configure(ctx, datasource.ConfigureRequest{ProviderData: client}, &datasource.ConfigureResponse{})
+	var schemaResp datasource.SchemaResponse
+	d.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	values := map[string]tftypes.Value{}
+	for name, attributeType := range objectType.AttributeTypes {
+		values[name] = tftypes.NewValue(attributeType, nil)
+	}
+	values["advisory_name"] = tftypes.NewValue(tftypes.String, "SUSE-2024-2930")
+	config := tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, values)}
+	resp := &datasource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: config.Raw}}
+	d.Read(ctx, datasource.ReadRequest{Config: config}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+
+	var state ErratumDataSourceModel
+	resp.State.Get(ctx, &state)
+	if state.ID.ValueInt64() != 4711 || state.Severity.ValueString() != "moderate" || state.IssueDate.ValueString() != "2024-08-20T00:00:00Z" {
+		t.Errorf("unexpected details %+v", state)
+	}
+	if len(state.CVEs.Elements()) != 2 || len(state.Channels.Elements()) != 1 {
+		t.Errorf("got cves %s and channels %s", state.CVEs, state.Channels)
+	}
+	var nevras []string
+	for _, pkg := range state.Packages {
+		nevras = append(nevras, pkg.NEVRA.ValueString())
+	}
+	if want := []string{"libopenssl3-3.1.4-5.15.1.x86_64", "openssl-3-3.1.4-5.15.1.x86_64"}; !reflect.DeepEqual(nevras, want) {
+		t.Errorf("expected packages %v, got %v", want, nevras)
+	}
+}
+
+func TestAccErratumDataSource(t *testing.T) {
+	source := os.Getenv("UYUNI_TEST_SOURCE_CHANNEL")
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccRealServerPreCheck(t)
+			testAccSkipUnlessEnv(t, "UYUNI_TEST_SOURCE_CHANNEL", "a synchronized base channel")
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+data "uyuni_channel_errata" "test" {
+  channel_label = %q
+}
+
+data "uyuni_erratum" "test" {
+  advisory_name = data.uyuni_channel_errata.test.errata[0].advisory_name
+}
+`, source),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.uyuni_erratum.test", "id", "data.uyuni_channel_errata.test", "errata.0.id"),
+					resource.TestCheckResourceAttrPair("data.uyuni_erratum.test", "synopsis", "data.uyuni_channel_errata.test", "errata.0.synopsis"),
+					resource.TestCheckTypeSetElemAttr("data.uyuni_erratum.test", "channels.*", source),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,182 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &FormulaCatalogDataSource{}
+	_ datasource.DataSourceWithConfigure = &FormulaCatalogDataSource{}
+)
+
+// FormulaCatalogDataSourceModel maps the data source schema data.
+type FormulaCatalogDataSourceModel struct {
+	MetadataDirs []types.String `tfsdk:"metadata_dirs"`
+	Names        types.Set      `tfsdk:"names"`
+	Formulas     []formulaModel `tfsdk:"formulas"`
+}
+
+// formulaModel maps a formula of the catalog.
+type formulaModel struct {
+	Name     types.String `tfsdk:"name"`
+	Layout   types.String `tfsdk:"layout"`
+	Metadata types.String `tfsdk:"metadata"`
+}
+
+// NewFormulaCatalogDataSource is a helper function to simplify the provider implementation.
+func NewFormulaCatalogDataSource() datasource.DataSource {
+	return &FormulaCatalogDataSource{}
+}
+
+// FormulaCatalogDataSource is the data source implementation.
+type FormulaCatalogDataSource struct {
+	client *uyuniClient
+}
+
+// Metadata returns the data source type name.
+func (d *FormulaCatalogDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_formula_catalog"
+}
+
+// Schema defines the schema for the data source.
+func (d *FormulaCatalogDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the Salt formulas installed on the server, e.g. to validate the formulas assigned to systems and " +
+			"groups. The API does not expose the form layouts of the formulas, which define their pillar data; they are read " +
+			"from metadata_dirs where the formula metadata is available to Terraform.",
+		Attributes: map[string]schema.Attribute{
+			"metadata_dirs": schema.ListAttribute{
+				Description: "Directories holding the metadata of formulas in a directory per formula, searched in order, " +
+					"e.g. a copy of `/usr/share/susemanager/formulas/metadata` and `/srv/formula_metadata` of the server " +
+					"or checkouts of formula sources. The layouts of formulas found in none of them are null.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"names": schema.SetAttribute{
+				Description: "Names of the formulas.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"formulas": schema.ListNestedAttribute{
+				Description: "Formulas, ordered by name.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Description: "Name of the formula.",
+							Computed:    true,
+						},
+						"layout": schema.StringAttribute{
+							Description: "Form layout of the formula as YAML, i.e. its `form.yml`, which defines the pillar " +
+								"data of the formula. Decode it with yamldecode. Null if the formula is in none of metadata_dirs.",
+							Computed: true,
+						},
+						"metadata": schema.StringAttribute{
+							Description: "Metadata of the formula as YAML, i.e. its `metadata.yml` with e.g. its description " +
+								"and group. Null if the formula has none in metadata_dirs.",
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// readFormulaFile returns the content of the file of the formula in the
+// first of the directories having it, null if none has.
+func readFormulaFile(dirs []types.String, formula, file string) (types.String, error) {
+	for _, dir := range dirs {
+		data, err := os.ReadFile(filepath.Join(expandHome(dir.ValueString()), formula, file))
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return types.StringNull(), err
+		}
+		return types.StringValue(string(data)), nil
+	}
+	return types.StringNull(), nil
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *FormulaCatalogDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state FormulaCatalogDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	formulas, err := apiGet[[]string](ctx, d.client, "formula/listFormulas")
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Uyuni formula catalog",
+			"Could not list formulas: "+err.Error(),
+		)
+		return
+	}
+	sort.Strings(formulas.Result)
+
+	state.Formulas = make([]formulaModel, 0, len(formulas.Result))
+	for _, name := range formulas.Result {
+		formula := formulaModel{Name: types.StringValue(name)}
+		if formula.Layout, err = readFormulaFile(state.MetadataDirs, name, "form.yml"); err == nil {
+			formula.Metadata, err = readFormulaFile(state.MetadataDirs, name, "metadata.yml")
+		}
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("metadata_dirs"),
+				"Unable to Read Uyuni formula catalog",
+				fmt.Sprintf("Could not read the metadata of formula %s: %s", name, err),
+			)
+			return
+		}
+		state.Formulas = append(state.Formulas, formula)
+	}
+	state.Names, diags = types.SetValueFrom(ctx, types.StringType, formulas.Result)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *FormulaCatalogDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*uyuniClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *uyuniClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
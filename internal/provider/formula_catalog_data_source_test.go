@@ -0,0 +1,73 @@
+package provider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestFormulaCatalogReadsLayoutsFromMetadataDirs(t *testing.T) {
+	custom, packaged := t.TempDir(), t.TempDir()
+	for file, content := range map[string]string{
+		filepath.Join(custom, "locale", "form.yml"):       "timezone:\n  $type: group\n",
+		filepath.Join(packaged, "locale", "form.yml"):     "timezone:\n  $type: text\n",
+		filepath.Join(packaged, "locale", "metadata.yml"): "description: Settings for language, keyboard, and timezone\n",
+		filepath.Join(packaged, "prometheus", "form.yml"): "prometheus:\n  $type: group\n",
+	} {
+		if err := os.MkdirAll(filepath.Dir(file), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(file, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	resp := testDataSourceRead(t, NewFormulaCatalogDataSource(), fixtureClient(t, "2025.02"), map[string]tftypes.Value{
+		"metadata_dirs": tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, []tftypes.Value{
+			tftypes.NewValue(tftypes.String, custom),
+			tftypes.NewValue(tftypes.String, packaged),
+		}),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+	var model FormulaCatalogDataSourceModel
+	resp.State.Get(context.Background(), &model)
+	formulas := map[string]formulaModel{}
+	for _, formula := range model.Formulas {
+		formulas[formula.Name.ValueString()] = formula
+	}
+	if len(model.Names.Elements()) != len(model.Formulas) || len(formulas) != 13 {
+		t.Fatalf("expected the 13 recorded formulas, got %v", model.Names)
+	}
+	if locale := formulas["locale"]; locale.Layout.ValueString() != "timezone:\n  $type: group\n" || locale.Metadata.IsNull() {
+		t.Errorf("expected the layout of the first directory and the metadata of the second, got %v", locale)
+	}
+	if prometheus := formulas["prometheus"]; prometheus.Layout.IsNull() || !prometheus.Metadata.IsNull() {
+		t.Errorf("expected a layout without metadata, got %v", prometheus)
+	}
+	if dhcpd := formulas["dhcpd"]; !dhcpd.Layout.IsNull() {
+		t.Errorf("expected no layout, got %v", dhcpd)
+	}
+}
+
+func TestAccFormulaCatalogDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccRealServerPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `data "uyuni_formula_catalog" "all" {}`,
+				// Without metadata_dirs the layouts are unknown.
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.uyuni_formula_catalog.all", "names.#", "data.uyuni_formula_catalog.all", "formulas.#"),
+					resource.TestCheckNoResourceAttr("data.uyuni_formula_catalog.all", "formulas.0.layout"),
+				),
+			},
+		},
+	})
+}
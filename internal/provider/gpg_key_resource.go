@@ -0,0 +1,439 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"terraform-provider-uyuni/internal/uyuni"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &gpgKeyResource{}
+	_ resource.ResourceWithConfigure   = &gpgKeyResource{}
+	_ resource.ResourceWithImportState = &gpgKeyResource{}
+)
+
+// gpgKeyType is the type of GPG keys in the kickstart.keys namespace.
+const gpgKeyType = "GPG"
+
+// gpgKeyImportTimeout is the number of seconds the script importing the key
+// on a system may run.
+const gpgKeyImportTimeout = 300
+
+// NewGPGKeyResource is a helper function to simplify the provider implementation.
+func NewGPGKeyResource() resource.Resource {
+	return &gpgKeyResource{}
+}
+
+// gpgKeyResource is the resource implementation.
+type gpgKeyResource struct {
+	client *uyuniClient
+}
+
+// gpgKeyResourceModel maps the resource schema data.
+type gpgKeyResourceModel struct {
+	ID                  types.String   `tfsdk:"id"`
+	Description         types.String   `tfsdk:"description"`
+	Content             types.String   `tfsdk:"content"`
+	AutoinstallProfiles types.Set      `tfsdk:"autoinstall_profiles"`
+	SystemIDs           types.Set      `tfsdk:"system_ids"`
+	ServerAlias         types.String   `tfsdk:"server_alias"`
+	Org                 *orgModel      `tfsdk:"org"`
+	Timeouts            timeouts.Value `tfsdk:"timeouts"`
+}
+
+// Metadata returns the resource type name.
+func (r *gpgKeyResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_gpg_key"
+}
+
+// Schema defines the schema for the resource.
+func (r *gpgKeyResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Distributes a GPG public key, e.g. the signing key of custom channels, to clients: " +
+			"systems installed with the listed autoinstall profiles trust it from the start, " +
+			"and the listed systems import it into their RPM database.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Description of the key.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"description": schema.StringAttribute{
+				Description: "Description of the key, which identifies it.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"content": schema.StringAttribute{
+				Description: "ASCII armored public key.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(
+						regexp.MustCompile(`^\s*-----BEGIN PGP PUBLIC KEY BLOCK-----`),
+						"must be an ASCII armored public key",
+					),
+				},
+			},
+			"autoinstall_profiles": schema.SetAttribute{
+				Description: "Labels of the autoinstall profiles whose systems import the key during installation.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"system_ids": schema.SetAttribute{
+				Description: "IDs of RPM based systems importing the key. They import it when they are added and when the content changes, " +
+					"systems removed from the set keep it.",
+				ElementType: types.Int64Type,
+				Optional:    true,
+			},
+			"server_alias": serverAliasAttribute(),
+		},
+		Blocks: map[string]schema.Block{
+			"org": orgBlock(),
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+			}),
+		},
+	}
+}
+
+// gpgImportScript returns a script importing the key into the RPM database.
+func gpgImportScript(content string) string {
+	return "#!/bin/sh\nset -e\n" +
+		"key=$(mktemp)\n" +
+		"trap 'rm -f \"$key\"' EXIT\n" +
+		"cat > \"$key\" <<'UYUNI_GPG_KEY'\n" + strings.TrimSpace(content) + "\nUYUNI_GPG_KEY\n" +
+		"rpm --import \"$key\"\n"
+}
+
+// importGPGKey imports the key on the systems and waits until they did.
+func importGPGKey(ctx context.Context, client *uyuniClient, content string, sids []int64) error {
+	if len(sids) == 0 {
+		return nil
+	}
+	actionID, err := scheduleScriptRun(ctx, client, sids, scriptRun{
+		username:  "root",
+		groupname: "root",
+		timeout:   gpgKeyImportTimeout,
+		script:    gpgImportScript(content),
+	})
+	if err != nil {
+		return err
+	}
+	tflog.Info(ctx, fmt.Sprintf("Importing GPG key on %d systems with action %d", len(sids), actionID))
+	return waitForSystems(ctx, client, actionID, sids)
+}
+
+// changeProfileKeys adds the key to and removes it from autoinstall profiles.
+func changeProfileKeys(ctx context.Context, client *uyuniClient, description string, add, remove []string) error {
+	for _, label := range add {
+		if _, err := apiPost[int](ctx, client, "kickstart/profile/system/addKeys", map[string]interface{}{
+			"kickstartLabel": label,
+			"descriptions":   []string{description},
+		}); err != nil {
+			return fmt.Errorf("could not add the key to profile %s: %w", label, err)
+		}
+	}
+	for _, label := range remove {
+		if _, err := apiPost[int](ctx, client, "kickstart/profile/system/removeKeys", map[string]interface{}{
+			"kickstartLabel": label,
+			"descriptions":   []string{description},
+		}); err != nil && !isNotFoundError(err) {
+			return fmt.Errorf("could not remove the key from profile %s: %w", label, err)
+		}
+	}
+	return nil
+}
+
+// Create a new resource.
+func (r *gpgKeyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan gpgKeyResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	client := resourceClient(ctx, r.client, plan.ServerAlias, plan.Org, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	description := plan.Description.ValueString()
+	_, err := apiPost[int](ctx, client, "kickstart/keys/create", map[string]interface{}{
+		"description": description,
+		"type":        gpgKeyType,
+		"content":     plan.Content.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating GPG key",
+			"Could not create GPG key "+description+": "+err.Error(),
+		)
+		return
+	}
+	plan.ID = plan.Description
+
+	profiles, err := stringSet(ctx, plan.AutoinstallProfiles)
+	if err == nil {
+		err = changeProfileKeys(ctx, client, description, profiles, nil)
+	}
+	if err == nil {
+		var sids []int64
+		if sids, err = int64Set(ctx, plan.SystemIDs); err == nil {
+			err = importGPGKey(ctx, client, plan.Content.ValueString(), sids)
+		}
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating GPG key",
+			"Could not distribute GPG key "+description+": "+err.Error(),
+		)
+		// Fall through to track the key, which Terraform taints.
+	}
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read resource information.
+func (r *gpgKeyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Get current state
+	var state gpgKeyResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := resourceClient(ctx, r.client, state.ServerAlias, state.Org, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	description := state.ID.ValueString()
+	key, err := apiGet[uyuni.CryptoKey](ctx, client, "kickstart/keys/getDetails?description="+url.QueryEscape(description))
+	if err != nil {
+		if handleNotFound(ctx, resp, err, "GPG key "+description) {
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Reading Uyuni GPG key",
+			"Could not read GPG key "+description+": "+err.Error(),
+		)
+		return
+	}
+	state.Description = state.ID
+	// The server may strip surrounding whitespace.
+	if strings.TrimSpace(key.Result.Content) != strings.TrimSpace(state.Content.ValueString()) {
+		state.Content = types.StringValue(key.Result.Content)
+	}
+
+	profiles, err := stringSet(ctx, state.AutoinstallProfiles)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Reading Uyuni GPG key", err.Error())
+		return
+	}
+	if len(profiles) > 0 {
+		keyed := []string{}
+		for _, label := range profiles {
+			keys, err := apiGet[[]uyuni.CryptoKey](ctx, client, "kickstart/profile/system/listKeys?kickstartLabel="+url.QueryEscape(label))
+			if err != nil {
+				if isNotFoundError(err) {
+					continue
+				}
+				resp.Diagnostics.AddError(
+					"Error Reading Uyuni GPG key",
+					"Could not list the keys of autoinstall profile "+label+": "+err.Error(),
+				)
+				return
+			}
+			for _, k := range keys.Result {
+				if k.Description == description {
+					keyed = append(keyed, label)
+					break
+				}
+			}
+		}
+		state.AutoinstallProfiles, diags = types.SetValueFrom(ctx, types.StringType, keyed)
+		resp.Diagnostics.Append(diags...)
+	}
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *gpgKeyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Retrieve values from plan and state
+	var plan, state gpgKeyResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	client := resourceClient(ctx, r.client, plan.ServerAlias, plan.Org, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	description := state.ID.ValueString()
+	contentChanged := !plan.Content.Equal(state.Content)
+	if contentChanged {
+		_, err := apiPost[int](ctx, client, "kickstart/keys/update", map[string]interface{}{
+			"description": description,
+			"type":        gpgKeyType,
+			"content":     plan.Content.ValueString(),
+		})
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error updating GPG key",
+				"Could not update GPG key "+description+": "+err.Error(),
+			)
+			return
+		}
+	}
+
+	if err := distributeGPGKey(ctx, client, &plan, &state, contentChanged); err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating GPG key",
+			"Could not distribute GPG key "+description+": "+err.Error(),
+		)
+		return
+	}
+	plan.ID = state.ID
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// distributeGPGKey adds the key to the profiles added to the plan, removes it from
+// those removed and imports it on the systems added, or all systems if the
+// content changed.
+func distributeGPGKey(ctx context.Context, client *uyuniClient, plan, state *gpgKeyResourceModel, contentChanged bool) error {
+	current, err := stringSet(ctx, state.AutoinstallProfiles)
+	if err != nil {
+		return err
+	}
+	wanted, err := stringSet(ctx, plan.AutoinstallProfiles)
+	if err != nil {
+		return err
+	}
+	add, remove := setDiff(current, wanted)
+	if err := changeProfileKeys(ctx, client, state.ID.ValueString(), add, remove); err != nil {
+		return err
+	}
+
+	sids, err := int64Set(ctx, plan.SystemIDs)
+	if err != nil {
+		return err
+	}
+	if !contentChanged {
+		imported, err := int64Set(ctx, state.SystemIDs)
+		if err != nil {
+			return err
+		}
+		sids, _ = setDiff(imported, sids)
+	}
+	return importGPGKey(ctx, client, plan.Content.ValueString(), sids)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+// Systems which imported the key keep it.
+func (r *gpgKeyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Retrieve values from state
+	var state gpgKeyResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := resourceClient(ctx, r.client, state.ServerAlias, state.Org, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	description := state.ID.ValueString()
+	profiles, err := stringSet(ctx, state.AutoinstallProfiles)
+	if err == nil {
+		err = changeProfileKeys(ctx, client, description, nil, profiles)
+	}
+	if err == nil {
+		_, err = apiPost[int](ctx, client, "kickstart/keys/delete", map[string]interface{}{
+			"description": description,
+		})
+	}
+	if err != nil && !isNotFoundError(err) {
+		resp.Diagnostics.AddError(
+			"Error Deleting Uyuni GPG key",
+			"Could not delete GPG key "+description+": "+err.Error(),
+		)
+		return
+	}
+}
+
+// ImportState imports a key by its description.
+func (r *gpgKeyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *gpgKeyResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*uyuniClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *uyuniClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
@@ -0,0 +1,111 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	acctest "github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+const testGPGKey = "-----BEGIN PGP PUBLIC KEY BLOCK-----\n\nmQINBGZ\n-----END PGP PUBLIC KEY BLOCK-----\n"
+
+func TestGPGKeyDistributesToProfilesAndSystems(t *testing.T) {
+	ctx := context.Background()
+	actionPollInterval = time.Millisecond
+	t.Cleanup(func() { actionPollInterval = 10 * time.Second })
+
+	var requests, imported []string
+	r := NewGPGKeyResource()
+	testConfigure(t, r, testAPIClient(t, func(w http.ResponseWriter, req *http.Request) {
+		requests = append(requests, req.URL.Path)
+		var body struct {
+			Sids   []int64 `json:"sids"`
+			Script string  `json:"script"`
+		}
+		_ = json.NewDecoder(req.Body).Decode(&body)
+		switch req.URL.Path {
+		case "/system/scheduleScriptRun":
+			if !strings.Contains(body.Script, "mQINBGZ") || !strings.Contains(body.Script, "rpm --import") {
+				t.Errorf("unexpected script %s", body.Script)
+			}
+			imported = append(imported, fmt.Sprint(body.Sids))
+			_, _ = w.Write([]byte(`{"success": true, "result": 601}`))
+		case "/schedule/listCompletedSystems":
+			_, _ = w.Write([]byte(`{"success": true, "result": [{"server_id": 1000010001}, {"server_id": 1000010002}]}`))
+		case "/schedule/listFailedSystems":
+			_, _ = w.Write([]byte(`{"success": true, "result": []}`))
+		default:
+			_, _ = w.Write([]byte(`{"success": true, "result": 1}`))
+		}
+	}))
+
+	profiles, _ := types.SetValueFrom(ctx, types.StringType, []string{"sles15-sp6-web"})
+	sids, _ := types.SetValueFrom(ctx, types.Int64Type, []int64{1000010001})
+	planned := testState(t, r, map[string]interface{}{
+		"description":          "custom-channels",
+		"content":              testGPGKey,
+		"autoinstall_profiles": profiles,
+		"system_ids":           sids,
+	})
+	createResp := &resource.CreateResponse{State: planned}
+	r.Create(ctx, resource.CreateRequest{Plan: tfsdk.Plan{Schema: planned.Schema, Raw: planned.Raw}}, createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatal(createResp.Diagnostics)
+	}
+	if requests[0] != "/kickstart/keys/create" || requests[1] != "/kickstart/profile/system/addKeys" {
+		t.Errorf("expected the key to be created and added to the profile, got %v", requests)
+	}
+
+	// Only systems added import the unchanged key.
+	sids, _ = types.SetValueFrom(ctx, types.Int64Type, []int64{1000010001, 1000010002})
+	plan := createResp.State
+	plan.SetAttribute(ctx, path.Root("system_ids"), sids)
+	updateResp := &resource.UpdateResponse{State: plan}
+	r.Update(ctx, resource.UpdateRequest{Plan: tfsdk.Plan{Schema: plan.Schema, Raw: plan.Raw}, State: createResp.State}, updateResp)
+	if updateResp.Diagnostics.HasError() {
+		t.Fatal(updateResp.Diagnostics)
+	}
+	if want := "[[1000010001] [1000010002]]"; fmt.Sprint(imported) != want {
+		t.Errorf("expected imports on %s, got %v", want, imported)
+	}
+}
+
+func TestAccGPGKeyResource(t *testing.T) {
+	keyFile := os.Getenv("UYUNI_TEST_GPG_KEY_FILE")
+	acctest.Test(t, acctest.TestCase{
+		PreCheck: func() {
+			testAccRealServerPreCheck(t)
+			testAccSkipUnlessEnv(t, "UYUNI_TEST_GPG_KEY_FILE", "an ASCII armored GPG public key")
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []acctest.TestStep{
+			// Create and Read testing
+			{
+				Config: fmt.Sprintf(`
+resource "uyuni_gpg_key" "test" {
+  description = "tfacc-gpg"
+  content     = file(%q)
+}
+`, keyFile),
+				Check: acctest.TestCheckResourceAttr("uyuni_gpg_key.test", "id", "tfacc-gpg"),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "uyuni_gpg_key.test",
+				ImportState:       true,
+				ImportStateId:     "tfacc-gpg",
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
@@ -0,0 +1,347 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+
+	"terraform-provider-uyuni/internal/uyuni"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &groupConfigChannelsResource{}
+	_ resource.ResourceWithConfigure   = &groupConfigChannelsResource{}
+	_ resource.ResourceWithImportState = &groupConfigChannelsResource{}
+)
+
+// NewGroupConfigChannelsResource is a helper function to simplify the provider implementation.
+func NewGroupConfigChannelsResource() resource.Resource {
+	return &groupConfigChannelsResource{}
+}
+
+// groupConfigChannelsResource is the resource implementation.
+type groupConfigChannelsResource struct {
+	client *uyuniClient
+}
+
+// groupConfigChannelsResourceModel maps the resource schema data.
+type groupConfigChannelsResourceModel struct {
+	ID            types.String `tfsdk:"id"`
+	GroupName     types.String `tfsdk:"group_name"`
+	ChannelLabels types.Set    `tfsdk:"channel_labels"`
+	OnDestroy     types.String `tfsdk:"on_destroy"`
+	ServerAlias   types.String `tfsdk:"server_alias"`
+	Org           *orgModel    `tfsdk:"org"`
+}
+
+// Metadata returns the resource type name.
+func (r *groupConfigChannelsResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_group_config_channels"
+}
+
+// Schema defines the schema for the resource.
+func (r *groupConfigChannelsResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Assigns configuration channels, e.g. custom states, to all systems of a system group. " +
+			"Salt applies the channels of the organization first, then those of the groups and last those of the system, " +
+			"so channels assigned to a system override the baseline of its groups. " +
+			"The API does not rank the channels of a group. " +
+			"Channels of the group that are not listed are left alone. The channels are applied with the next highstate.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Name of the group.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"group_name": schema.StringAttribute{
+				Description: "Name of the system group.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"channel_labels": schema.SetAttribute{
+				Description: "Labels of the configuration channels assigned to the group.",
+				ElementType: types.StringType,
+				Required:    true,
+			},
+			"on_destroy":   onDestroyAttribute("unassigns the listed channels from the group"),
+			"server_alias": serverAliasAttribute(),
+		},
+		Blocks: map[string]schema.Block{
+			"org": orgBlock(),
+		},
+	}
+}
+
+// listGroupConfigChannels returns the labels of the configuration channels
+// assigned to the group.
+func listGroupConfigChannels(ctx context.Context, client *uyuniClient, group string) ([]string, error) {
+	channels, err := apiGet[[]uyuni.ConfigChannel](ctx, client, "systemgroup/listAssignedConfigChannels?systemGroupName="+url.QueryEscape(group))
+	if err != nil {
+		return nil, err
+	}
+	labels := make([]string, 0, len(channels.Result))
+	for _, channel := range channels.Result {
+		labels = append(labels, channel.Label)
+	}
+	return labels, nil
+}
+
+// changeGroupConfigChannels assigns channels to and unassigns channels from
+// the group.
+func changeGroupConfigChannels(ctx context.Context, client *uyuniClient, group string, add, remove []string) error {
+	sort.Strings(add)
+	sort.Strings(remove)
+	if len(add) > 0 {
+		_, err := apiPost[int](ctx, client, "systemgroup/subscribeConfigChannels", map[string]interface{}{
+			"systemGroupName":     group,
+			"configChannelLabels": add,
+		})
+		if err != nil {
+			return fmt.Errorf("could not assign channels %v: %w", add, err)
+		}
+	}
+	if len(remove) > 0 {
+		_, err := apiPost[int](ctx, client, "systemgroup/unsubscribeConfigChannels", map[string]interface{}{
+			"systemGroupName":     group,
+			"configChannelLabels": remove,
+		})
+		if err != nil {
+			return fmt.Errorf("could not unassign channels %v: %w", remove, err)
+		}
+	}
+	return nil
+}
+
+// Create a new resource.
+func (r *groupConfigChannelsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan groupConfigChannelsResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := resourceClient(ctx, r.client, plan.ServerAlias, plan.Org, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	wanted, err := stringSet(ctx, plan.ChannelLabels)
+	if err != nil {
+		resp.Diagnostics.AddError("Error assigning configuration channels", err.Error())
+		return
+	}
+
+	group := plan.GroupName.ValueString()
+	current, err := listGroupConfigChannels(ctx, client, group)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error assigning configuration channels",
+			"Could not list configuration channels of group "+group+": "+err.Error(),
+		)
+		return
+	}
+
+	// Channels already assigned are adopted, others stay untouched.
+	add, _ := setDiff(current, wanted)
+	tflog.Info(ctx, fmt.Sprintf("Group %s: assigning %d configuration channels", group, len(add)))
+	if err := changeGroupConfigChannels(ctx, client, group, add, nil); err != nil {
+		resp.Diagnostics.AddError(
+			"Error assigning configuration channels",
+			"Could not assign configuration channels to group "+group+": "+err.Error(),
+		)
+		return
+	}
+
+	plan.ID = plan.GroupName
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read resource information.
+func (r *groupConfigChannelsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Get current state
+	var state groupConfigChannelsResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := resourceClient(ctx, r.client, state.ServerAlias, state.Org, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	group := state.GroupName.ValueString()
+	current, err := listGroupConfigChannels(ctx, client, group)
+	if err != nil {
+		if handleNotFound(ctx, resp, err, "Group "+group) {
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Reading Uyuni group configuration channels",
+			"Could not list configuration channels of group "+group+": "+err.Error(),
+		)
+		return
+	}
+
+	// An imported group manages all of its channels, otherwise only the
+	// managed channels that are still assigned are kept.
+	labels := current
+	if !state.ChannelLabels.IsNull() {
+		managed, err := stringSet(ctx, state.ChannelLabels)
+		if err != nil {
+			resp.Diagnostics.AddError("Error Reading Uyuni group configuration channels", err.Error())
+			return
+		}
+		assigned := map[string]bool{}
+		for _, label := range current {
+			assigned[label] = true
+		}
+		labels = []string{}
+		for _, label := range managed {
+			if assigned[label] {
+				labels = append(labels, label)
+			}
+		}
+	}
+
+	state.ChannelLabels, diags = types.SetValueFrom(ctx, types.StringType, labels)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	state.ID = state.GroupName
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *groupConfigChannelsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Retrieve values from plan and state
+	var plan, state groupConfigChannelsResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := resourceClient(ctx, r.client, plan.ServerAlias, plan.Org, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	wanted, err := stringSet(ctx, plan.ChannelLabels)
+	var previous []string
+	if err == nil {
+		previous, err = stringSet(ctx, state.ChannelLabels)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Error updating configuration channels", err.Error())
+		return
+	}
+
+	group := plan.GroupName.ValueString()
+	add, remove := setDiff(previous, wanted)
+	tflog.Info(ctx, fmt.Sprintf("Group %s: assigning %d and unassigning %d configuration channels", group, len(add), len(remove)))
+	if err := changeGroupConfigChannels(ctx, client, group, add, remove); err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating configuration channels",
+			"Could not update configuration channels of group "+group+": "+err.Error(),
+		)
+		return
+	}
+
+	diags := resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete unassigns the managed channels from the group.
+func (r *groupConfigChannelsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Retrieve values from state
+	var state groupConfigChannelsResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	group := state.GroupName.ValueString()
+	if orphaned(state.OnDestroy) {
+		tflog.Info(ctx, "Removing group configuration channels from state, the channels stay assigned to group "+group)
+		return
+	}
+
+	client := resourceClient(ctx, r.client, state.ServerAlias, state.Org, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	labels, err := stringSet(ctx, state.ChannelLabels)
+	if err == nil {
+		err = changeGroupConfigChannels(ctx, client, group, nil, labels)
+	}
+	if err != nil && !isNotFoundError(err) {
+		resp.Diagnostics.AddError(
+			"Error Deleting Uyuni group configuration channels",
+			"Could not unassign configuration channels from group "+group+": "+err.Error(),
+		)
+		return
+	}
+}
+
+// ImportState imports all configuration channels of a group by its name.
+func (r *groupConfigChannelsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("group_name"), req, resp)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("on_destroy"), onDestroyDelete)...)
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *groupConfigChannelsResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*uyuniClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *uyuniClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
@@ -0,0 +1,133 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	acctest "github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+// testGroupConfigChannelsServer serves the group web with the channels
+// hardening and motd assigned and records the channel changes.
+func testGroupConfigChannelsServer(changes *[]string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/systemgroup/listAssignedConfigChannels":
+			_, _ = w.Write([]byte(`{"success": true, "result": [
+				{"id": 3, "orgId": 1, "label": "hardening", "name": "Hardening", "description": "",
+				 "configChannelType": {"id": 4, "label": "state", "name": "State Channel", "priority": 1}},
+				{"id": 5, "orgId": 1, "label": "motd", "name": "MOTD", "description": "",
+				 "configChannelType": {"id": 1, "label": "normal", "name": "Normal", "priority": 1}}
+			]}`))
+		default:
+			var body map[string]interface{}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			*changes = append(*changes, fmt.Sprint(r.URL.Path, body["configChannelLabels"]))
+			_, _ = w.Write([]byte(`{"success": true, "result": 1}`))
+		}
+	}
+}
+
+func TestGroupConfigChannelsReadKeepsManagedChannels(t *testing.T) {
+	ctx := context.Background()
+	for name, tt := range map[string]struct {
+		attrs map[string]interface{}
+		want  int
+	}{
+		"managed":  {map[string]interface{}{"group_name": "web", "channel_labels": []string{"hardening", "sshd"}}, 1},
+		"imported": {map[string]interface{}{"group_name": "web"}, 2},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var changes []string
+			resp := testRead(t, NewGroupConfigChannelsResource(), testAPIClient(t, testGroupConfigChannelsServer(&changes)), tt.attrs)
+			if resp.Diagnostics.HasError() {
+				t.Fatal(resp.Diagnostics)
+			}
+			var state groupConfigChannelsResourceModel
+			resp.State.Get(ctx, &state)
+			if len(state.ChannelLabels.Elements()) != tt.want {
+				t.Errorf("expected %d channels, got %s", tt.want, state.ChannelLabels)
+			}
+		})
+	}
+}
+
+func TestGroupConfigChannelsCreateAssignsMissingChannels(t *testing.T) {
+	ctx := context.Background()
+	var changes []string
+	r := NewGroupConfigChannelsResource()
+	testConfigure(t, r, testAPIClient(t, testGroupConfigChannelsServer(&changes)))
+
+	planned := testState(t, r, map[string]interface{}{
+		"group_name":     "web",
+		"channel_labels": []string{"hardening", "sshd"},
+		"on_destroy":     onDestroyDelete,
+	})
+	resp := &resource.CreateResponse{State: planned}
+	r.Create(ctx, resource.CreateRequest{Plan: tfsdk.Plan{Schema: planned.Schema, Raw: planned.Raw}}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+	if want := "[/systemgroup/subscribeConfigChannels[sshd]]"; fmt.Sprint(changes) != want {
+		t.Errorf("expected %s, got %v", want, changes)
+	}
+}
+
+func TestAccGroupConfigChannelsResource(t *testing.T) {
+	acctest.Test(t, acctest.TestCase{
+		PreCheck: func() {
+			testAccRealServerPreCheck(t)
+			testAccSeedSystemGroup(t, "tfacc-group-config")
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []acctest.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccGroupConfigChannelsResourceConfig(`[uyuni_config_channel.a.label]`),
+				Check:  acctest.TestCheckResourceAttr("uyuni_group_config_channels.test", "channel_labels.#", "1"),
+			},
+			// Update and Read testing
+			{
+				Config: testAccGroupConfigChannelsResourceConfig(`[uyuni_config_channel.a.label, uyuni_config_channel.b.label]`),
+				Check: acctest.ComposeAggregateTestCheckFunc(
+					acctest.TestCheckResourceAttr("uyuni_group_config_channels.test", "channel_labels.#", "2"),
+					acctest.TestCheckTypeSetElemAttr("uyuni_group_config_channels.test", "channel_labels.*", "tfacc-group-config-b"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:                         "uyuni_group_config_channels.test",
+				ImportState:                          true,
+				ImportStateId:                        "tfacc-group-config",
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "group_name",
+			},
+		},
+	})
+}
+
+func testAccGroupConfigChannelsResourceConfig(labels string) string {
+	return fmt.Sprintf(`
+resource "uyuni_config_channel" "a" {
+  label               = "tfacc-group-config-a"
+  name                = "tfacc-group-config-a"
+  deletion_protection = false
+}
+
+resource "uyuni_config_channel" "b" {
+  label               = "tfacc-group-config-b"
+  name                = "tfacc-group-config-b"
+  deletion_protection = false
+}
+
+resource "uyuni_group_config_channels" "test" {
+  group_name     = "tfacc-group-config"
+  channel_labels = %s
+}
+`, labels)
+}
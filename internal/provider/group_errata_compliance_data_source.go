@@ -0,0 +1,269 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"terraform-provider-uyuni/internal/uyuni"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &GroupErrataComplianceDataSource{}
+	_ datasource.DataSourceWithConfigure = &GroupErrataComplianceDataSource{}
+)
+
+// defaultComplianceSeverity is the severity checked when none are given.
+const defaultComplianceSeverity = "critical"
+
+// GroupErrataComplianceDataSourceModel maps the data source schema data.
+type GroupErrataComplianceDataSourceModel struct {
+	GroupName          types.String               `tfsdk:"group_name"`
+	MaxAgeDays         types.Int64                `tfsdk:"max_age_days"`
+	Severities         types.Set                  `tfsdk:"severities"`
+	Compliant          types.Bool                 `tfsdk:"compliant"`
+	NonCompliantSystem types.Set                  `tfsdk:"non_compliant_system_ids"`
+	Violations         []complianceViolationModel `tfsdk:"violations"`
+}
+
+// complianceViolationModel maps an overdue advisory of a system.
+type complianceViolationModel struct {
+	SystemID     types.Int64  `tfsdk:"system_id"`
+	SystemName   types.String `tfsdk:"system_name"`
+	AdvisoryName types.String `tfsdk:"advisory_name"`
+	Severity     types.String `tfsdk:"severity"`
+	IssueDate    types.String `tfsdk:"issue_date"`
+	AgeDays      types.Int64  `tfsdk:"age_days"`
+}
+
+// NewGroupErrataComplianceDataSource is a helper function to simplify the provider implementation.
+func NewGroupErrataComplianceDataSource() datasource.DataSource {
+	return &GroupErrataComplianceDataSource{}
+}
+
+// GroupErrataComplianceDataSource is the data source implementation.
+type GroupErrataComplianceDataSource struct {
+	client *uyuniClient
+}
+
+// Metadata returns the data source type name.
+func (d *GroupErrataComplianceDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_group_errata_compliance"
+}
+
+// Schema defines the schema for the data source.
+func (d *GroupErrataComplianceDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Checks whether the systems of a group have security advisories of the given severities outstanding " +
+			"for longer than allowed. Use compliant in a postcondition to stop an apply, e.g. before promoting content " +
+			"to the next environment.",
+		Attributes: map[string]schema.Attribute{
+			"group_name": schema.StringAttribute{
+				Description: "Name of the system group.",
+				Required:    true,
+			},
+			"max_age_days": schema.Int64Attribute{
+				Description: "Number of days an advisory may stay outstanding after it was issued.",
+				Required:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"severities": schema.SetAttribute{
+				Description: "Severities of the security advisories to check, e.g. `critical` and `important`. Defaults to `critical`.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"compliant": schema.BoolAttribute{
+				Description: "Whether no system of the group has an overdue advisory.",
+				Computed:    true,
+			},
+			"non_compliant_system_ids": schema.SetAttribute{
+				Description: "IDs of the systems with overdue advisories.",
+				ElementType: types.Int64Type,
+				Computed:    true,
+			},
+			"violations": schema.ListNestedAttribute{
+				Description: "Overdue advisories by system, ordered by system ID and the oldest advisory first.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"system_id": schema.Int64Attribute{
+							Description: "ID of the system.",
+							Computed:    true,
+						},
+						"system_name": schema.StringAttribute{
+							Description: "Name of the system.",
+							Computed:    true,
+						},
+						"advisory_name": schema.StringAttribute{
+							Description: "Name of the advisory, e.g. `SUSE-2024-2930`.",
+							Computed:    true,
+						},
+						"severity": schema.StringAttribute{
+							Description: "Severity of the advisory.",
+							Computed:    true,
+						},
+						"issue_date": schema.StringAttribute{
+							Description: "Date the advisory was issued, in RFC 3339 format.",
+							Computed:    true,
+						},
+						"age_days": schema.Int64Attribute{
+							Description: "Number of days since the advisory was issued.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *GroupErrataComplianceDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state GroupErrataComplianceDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	severities := map[string]bool{defaultComplianceSeverity: true}
+	if !state.Severities.IsNull() {
+		configured, err := stringSet(ctx, state.Severities)
+		if err != nil {
+			resp.Diagnostics.AddError("Unable to Read Uyuni errata compliance", err.Error())
+			return
+		}
+		severities = map[string]bool{}
+		for _, severity := range configured {
+			severities[strings.ToLower(severity)] = true
+		}
+	}
+
+	group := state.GroupName.ValueString()
+	systems, err := apiGet[[]uyuni.ShortSystem](ctx, d.client, "systemgroup/listSystemsMinimal?systemGroupName="+url.QueryEscape(group))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Uyuni errata compliance",
+			"Could not list systems of group "+group+": "+err.Error(),
+		)
+		return
+	}
+	sort.Slice(systems.Result, func(i, j int) bool { return systems.Result[i].ID < systems.Result[j].ID })
+
+	now := time.Now()
+	maxAge := time.Duration(state.MaxAgeDays.ValueInt64()) * 24 * time.Hour
+	// Advisories are shared by many systems, so read each severity once.
+	severityByAdvisory := map[string]string{}
+	nonCompliant := []int64{}
+	state.Violations = []complianceViolationModel{}
+	for _, system := range systems.Result {
+		errata, err := apiGet[[]uyuni.Erratum](ctx, d.client, fmt.Sprintf("system/getRelevantErrata?sid=%d", system.ID))
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Read Uyuni errata compliance",
+				fmt.Sprintf("Could not list relevant errata of system %d: %s", system.ID, err),
+			)
+			return
+		}
+
+		var violations []complianceViolationModel
+		for _, erratum := range errata.Result {
+			if erratum.AdvisoryType != advisoryTypeSecurity {
+				continue
+			}
+			issued, err := uyuni.ParseDate(erratum.Date)
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"Unable to Read Uyuni errata compliance",
+					fmt.Sprintf("Could not parse the issue date of advisory %s: %s", erratum.AdvisoryName, err),
+				)
+				return
+			}
+			age := now.Sub(issued)
+			if age <= maxAge {
+				continue
+			}
+
+			severity, cached := severityByAdvisory[erratum.AdvisoryName]
+			if !cached {
+				details, err := apiGet[uyuni.ErratumDetails](ctx, d.client, "errata/getDetails?advisoryName="+url.QueryEscape(erratum.AdvisoryName))
+				if err != nil {
+					resp.Diagnostics.AddError(
+						"Unable to Read Uyuni errata compliance",
+						"Could not read advisory "+erratum.AdvisoryName+": "+err.Error(),
+					)
+					return
+				}
+				severity = strings.ToLower(details.Result.Severity)
+				severityByAdvisory[erratum.AdvisoryName] = severity
+			}
+			if !severities[severity] {
+				continue
+			}
+
+			violations = append(violations, complianceViolationModel{
+				SystemID:     types.Int64Value(int64(system.ID)),
+				SystemName:   types.StringValue(system.Name),
+				AdvisoryName: types.StringValue(erratum.AdvisoryName),
+				Severity:     types.StringValue(severity),
+				IssueDate:    types.StringValue(issued.UTC().Format(time.RFC3339)),
+				AgeDays:      types.Int64Value(int64(age / (24 * time.Hour))),
+			})
+		}
+		if len(violations) == 0 {
+			continue
+		}
+		sort.SliceStable(violations, func(i, j int) bool {
+			return violations[i].AgeDays.ValueInt64() > violations[j].AgeDays.ValueInt64()
+		})
+		nonCompliant = append(nonCompliant, int64(system.ID))
+		state.Violations = append(state.Violations, violations...)
+	}
+
+	state.Compliant = types.BoolValue(len(nonCompliant) == 0)
+	state.NonCompliantSystem, diags = types.SetValueFrom(ctx, types.Int64Type, nonCompliant)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *GroupErrataComplianceDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*uyuniClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *uyuniClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
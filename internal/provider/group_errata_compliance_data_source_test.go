@@ -0,0 +1,120 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestGroupErrataComplianceDataSource(t *testing.T) {
+	ctx := context.Background()
+	recent := time.Now().AddDate(0, 0, -3).UTC().Format("2006-01-02")
+	details := 0
+	client := testAPIClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/systemgroup/listSystemsMinimal":
+			_, _ = w.Write([]byte(`{"success": true, "result": [
+				{"id": 1000010004, "name": "db01.example.com"},
+				{"id": 1000010001, "name": "web01.example.com"}
+			]}`))
+		case "/system/getRelevantErrata":
+			switch r.URL.Query().Get("sid") {
+			case "1000010001":
+				_, _ = w.Write([]byte(`{"success": true, "result": [
+					{"date": "2024-05-02", "advisory_type": "Security Advisory", "advisory_name": "SUSE-2024-2"},
+					{"date": "2024-01-10", "advisory_type": "Security Advisory", "advisory_name": "SUSE-2024-1"},
+					{"date": "2024-01-10", "advisory_type": "Bug Fix Advisory", "advisory_name": "SUSE-2024-3"},
+					{"date": "` + recent + `", "advisory_type": "Security Advisory", "advisory_name": "SUSE-2024-4"}
+				]}`))
+			default:
+				_, _ = w.Write([]byte(`{"success": true, "result": [
+					{"date": "2024-05-02", "advisory_type": "Security Advisory", "advisory_name": "SUSE-2024-5"},
+					{"date": "2024-05-02", "advisory_type": "Security Advisory", "advisory_name": "SUSE-2024-2"}
+				]}`))
+			}
+		case "/errata/getDetails":
+			details++
+			severity := "Critical"
+			if r.URL.Query().Get("advisoryName") == "SUSE-2024-5" {
+				severity = "moderate"
+			}
+			_, _ = w.Write([]byte(`{"success": true, "result": {"severity": "` + severity + `"}}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL)
+		}
+	})
+
+	d := NewGroupErrataComplianceDataSource()
+	d.(datasource.DataSourceWithConfigure).Configure(ctx, datasource.ConfigureRequest{ProviderData: client}, &datasource.ConfigureResponse{})
+	var schemaResp datasource.SchemaResponse
+	d.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	values := map[string]tftypes.Value{}
+	for name, attributeType := range objectType.AttributeTypes {
+		values[name] = tftypes.NewValue(attributeType, nil)
+	}
+	values["group_name"] = tftypes.NewValue(tftypes.String, "prod")
+	values["max_age_days"] = tftypes.NewValue(tftypes.Number, 30)
+	config := tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, values)}
+	resp := &datasource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: config.Raw}}
+	d.Read(ctx, datasource.ReadRequest{Config: config}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+
+	var state GroupErrataComplianceDataSourceModel
+	resp.State.Get(ctx, &state)
+	if state.Compliant.ValueBool() {
+		t.Error("expected the group not to be compliant")
+	}
+	if len(state.NonCompliantSystem.Elements()) != 2 {
+		t.Errorf("expected 2 non-compliant systems, got %v", state.NonCompliantSystem)
+	}
+	var advisories []string
+	for _, violation := range state.Violations {
+		advisories = append(advisories, violation.AdvisoryName.ValueString())
+	}
+	if want := "[SUSE-2024-1 SUSE-2024-2 SUSE-2024-2]"; fmt.Sprint(advisories) != want {
+		t.Errorf("expected violations %s, got %v", want, advisories)
+	}
+	if state.Violations[0].SystemID.ValueInt64() != 1000010001 || state.Violations[0].Severity.ValueString() != "critical" {
+		t.Errorf("unexpected first violation %v", state.Violations[0])
+	}
+	if details != 3 {
+		t.Errorf("expected each overdue advisory to be read once, got %d reads", details)
+	}
+}
+
+func TestAccGroupErrataComplianceDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccRealServerPreCheck(t)
+			testAccSeedSystemGroup(t, "tfacc-ds-compliance")
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				// A group without systems misses no errata.
+				Config: `
+data "uyuni_group_errata_compliance" "test" {
+  group_name   = "tfacc-ds-compliance"
+  max_age_days = 30
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.uyuni_group_errata_compliance.test", "compliant", "true"),
+					resource.TestCheckResourceAttr("data.uyuni_group_errata_compliance.test", "non_compliant_system_ids.#", "0"),
+					resource.TestCheckResourceAttr("data.uyuni_group_errata_compliance.test", "violations.#", "0"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,267 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+
+	"terraform-provider-uyuni/internal/uyuni"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &GroupPatchStatusDataSource{}
+	_ datasource.DataSourceWithConfigure = &GroupPatchStatusDataSource{}
+)
+
+// Advisory types as returned by the Uyuni errata API.
+const (
+	advisoryTypeSecurity    = "Security Advisory"
+	advisoryTypeBugfix      = "Bug Fix Advisory"
+	advisoryTypeEnhancement = "Product Enhancement Advisory"
+)
+
+// GroupPatchStatusDataSourceModel maps the data source schema data.
+type GroupPatchStatusDataSourceModel struct {
+	GroupNames []types.String          `tfsdk:"group_names"`
+	Groups     []groupPatchStatusModel `tfsdk:"group"`
+}
+
+// groupPatchStatusModel maps the per-group errata summary.
+type groupPatchStatusModel struct {
+	ID               types.Int64  `tfsdk:"id"`
+	Name             types.String `tfsdk:"name"`
+	SystemCount      types.Int64  `tfsdk:"system_count"`
+	SecurityCount    types.Int64  `tfsdk:"security_count"`
+	BugfixCount      types.Int64  `tfsdk:"bugfix_count"`
+	EnhancementCount types.Int64  `tfsdk:"enhancement_count"`
+	TotalCount       types.Int64  `tfsdk:"total_count"`
+}
+
+// countAdvisories buckets advisories, keyed by name, by their advisory type.
+func countAdvisories(advisories map[string]string) (security, bugfix, enhancement int64) {
+	for _, advisoryType := range advisories {
+		switch advisoryType {
+		case advisoryTypeSecurity:
+			security++
+		case advisoryTypeBugfix:
+			bugfix++
+		case advisoryTypeEnhancement:
+			enhancement++
+		}
+	}
+	return security, bugfix, enhancement
+}
+
+// missingGroupNames returns the requested group names that are not among the found ones, sorted.
+func missingGroupNames(wanted, found map[string]bool) []string {
+	var missing []string
+	for name := range wanted {
+		if !found[name] {
+			missing = append(missing, name)
+		}
+	}
+	sort.Strings(missing)
+	return missing
+}
+
+// NewGroupPatchStatusDataSource is a helper function to simplify the provider implementation.
+func NewGroupPatchStatusDataSource() datasource.DataSource {
+	return &GroupPatchStatusDataSource{}
+}
+
+// GroupPatchStatusDataSource is the data source implementation.
+type GroupPatchStatusDataSource struct {
+	client *uyuniClient
+}
+
+// Metadata returns the data source type name.
+func (d *GroupPatchStatusDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_group_patch_status"
+}
+
+// Schema defines the schema for the data source.
+func (d *GroupPatchStatusDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Summarizes outstanding errata per system group.",
+		Attributes: map[string]schema.Attribute{
+			"group_names": schema.ListAttribute{
+				Description: "Restrict the summary to these system groups. All groups are returned when omitted.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"group": schema.ListNestedAttribute{
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.Int64Attribute{
+							Computed: true,
+						},
+						"name": schema.StringAttribute{
+							Computed: true,
+						},
+						"system_count": schema.Int64Attribute{
+							Computed: true,
+						},
+						"security_count": schema.Int64Attribute{
+							Description: "Distinct security advisories outstanding on at least one system of the group.",
+							Computed:    true,
+						},
+						"bugfix_count": schema.Int64Attribute{
+							Description: "Distinct bug fix advisories outstanding on at least one system of the group.",
+							Computed:    true,
+						},
+						"enhancement_count": schema.Int64Attribute{
+							Description: "Distinct enhancement advisories outstanding on at least one system of the group.",
+							Computed:    true,
+						},
+						"total_count": schema.Int64Attribute{
+							Description: "Distinct advisories of any type outstanding on at least one system of the group.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *GroupPatchStatusDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state GroupPatchStatusDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// read groups from API
+	groups, err := apiGet[[]uyuni.SystemGroup](ctx, d.client, "systemgroup/listAllGroups")
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Uyuni system groups",
+			err.Error(),
+		)
+		return
+	}
+
+	wanted := map[string]bool{}
+	for _, name := range state.GroupNames {
+		wanted[name.ValueString()] = true
+	}
+
+	found := map[string]bool{}
+	for _, this_group := range groups.Result {
+		found[this_group.Name] = true
+	}
+	for _, name := range missingGroupNames(wanted, found) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("group_names"),
+			"Unknown Uyuni system group",
+			"System group "+name+" does not exist or is not visible to the configured user.",
+		)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Systems may belong to several groups, so fetch their errata only once.
+	errataBySystem := map[int][]uyuni.Erratum{}
+
+	state.Groups = nil
+	for _, this_group := range groups.Result {
+		if len(wanted) > 0 && !wanted[this_group.Name] {
+			continue
+		}
+
+		this_status := groupPatchStatusModel{
+			ID:          types.Int64Value(int64(this_group.ID)),
+			Name:        types.StringValue(this_group.Name),
+			SystemCount: types.Int64Value(int64(this_group.SystemCount)),
+		}
+		if this_group.SystemCount == 0 {
+			this_status.SecurityCount = types.Int64Value(0)
+			this_status.BugfixCount = types.Int64Value(0)
+			this_status.EnhancementCount = types.Int64Value(0)
+			this_status.TotalCount = types.Int64Value(0)
+			state.Groups = append(state.Groups, this_status)
+			continue
+		}
+
+		systems, err := apiGet[[]uyuni.ShortSystem](ctx, d.client, "systemgroup/listSystemsMinimal?systemGroupName="+url.QueryEscape(this_group.Name))
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Read Uyuni system group members",
+				"Could not list systems of group "+this_group.Name+": "+err.Error(),
+			)
+			return
+		}
+
+		// Count every advisory once per group, no matter how many systems need it.
+		advisories := map[string]string{}
+		for _, this_system := range systems.Result {
+			errata, cached := errataBySystem[this_system.ID]
+			if !cached {
+				relevant, err := apiGet[[]uyuni.Erratum](ctx, d.client, fmt.Sprintf("system/getRelevantErrata?sid=%d", this_system.ID))
+				if err != nil {
+					resp.Diagnostics.AddError(
+						"Unable to Read Uyuni system errata",
+						fmt.Sprintf("Could not list relevant errata of system %d: %s", this_system.ID, err.Error()),
+					)
+					return
+				}
+				errata = relevant.Result
+				errataBySystem[this_system.ID] = errata
+			}
+			for _, erratum := range errata {
+				advisories[erratum.AdvisoryName] = erratum.AdvisoryType
+			}
+		}
+
+		security, bugfix, enhancement := countAdvisories(advisories)
+
+		tflog.Debug(ctx, fmt.Sprintf("Group %s has %d outstanding advisories", this_group.Name, len(advisories)))
+
+		this_status.SystemCount = types.Int64Value(int64(len(systems.Result)))
+		this_status.SecurityCount = types.Int64Value(security)
+		this_status.BugfixCount = types.Int64Value(bugfix)
+		this_status.EnhancementCount = types.Int64Value(enhancement)
+		this_status.TotalCount = types.Int64Value(int64(len(advisories)))
+		state.Groups = append(state.Groups, this_status)
+	}
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *GroupPatchStatusDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*uyuniClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *uyuniClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
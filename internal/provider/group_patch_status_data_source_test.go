@@ -0,0 +1,83 @@
+package provider
+
+import (
+	"context"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccGroupPatchStatusDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccUyuniPreCheck(t)
+			testAccSeed(t, "systemgroup/create", map[string]interface{}{
+				"name":        "tfacc-group",
+				"description": "Terraform acceptance tests",
+			}, "systemgroup/delete", map[string]interface{}{
+				"systemGroupName": "tfacc-group",
+			})
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `data "uyuni_group_patch_status" "test" { group_names = ["tfacc-group"] }`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.uyuni_group_patch_status.test", "group.#", "1"),
+					resource.TestCheckResourceAttr("data.uyuni_group_patch_status.test", "group.0.name", "tfacc-group"),
+					resource.TestCheckResourceAttr("data.uyuni_group_patch_status.test", "group.0.system_count", "0"),
+					resource.TestCheckResourceAttr("data.uyuni_group_patch_status.test", "group.0.total_count", "0"),
+				),
+			},
+			{
+				Config:      `data "uyuni_group_patch_status" "test" { group_names = ["tfacc-missing"] }`,
+				ExpectError: regexp.MustCompile("Unknown Uyuni system group"),
+			},
+		},
+	})
+}
+
+func TestGroupPatchStatusDataSourceSchema(t *testing.T) {
+	var resp datasource.SchemaResponse
+	NewGroupPatchStatusDataSource().Schema(context.Background(), datasource.SchemaRequest{}, &resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected schema diagnostics: %v", resp.Diagnostics)
+	}
+	if diags := resp.Schema.ValidateImplementation(context.Background()); diags.HasError() {
+		t.Fatalf("invalid schema implementation: %v", diags)
+	}
+}
+
+func TestCountAdvisories(t *testing.T) {
+	security, bugfix, enhancement := countAdvisories(map[string]string{
+		"SUSE-2024-1": advisoryTypeSecurity,
+		"SUSE-2024-2": advisoryTypeSecurity,
+		"SUSE-2024-3": advisoryTypeBugfix,
+		"SUSE-2024-4": advisoryTypeEnhancement,
+		"SUSE-2024-5": "Unknown Advisory",
+	})
+
+	if security != 2 || bugfix != 1 || enhancement != 1 {
+		t.Errorf("got security=%d bugfix=%d enhancement=%d, want 2/1/1", security, bugfix, enhancement)
+	}
+}
+
+func TestMissingGroupNames(t *testing.T) {
+	found := map[string]bool{"prod": true, "test": true}
+
+	if missing := missingGroupNames(map[string]bool{}, found); len(missing) != 0 {
+		t.Errorf("no filter: got %v, want none", missing)
+	}
+	if missing := missingGroupNames(map[string]bool{"prod": true}, found); len(missing) != 0 {
+		t.Errorf("known group: got %v, want none", missing)
+	}
+
+	missing := missingGroupNames(map[string]bool{"prod": true, "tset": true, "qa": true}, found)
+	if want := []string{"qa", "tset"}; !reflect.DeepEqual(missing, want) {
+		t.Errorf("unknown groups: got %v, want %v", missing, want)
+	}
+}
@@ -0,0 +1,375 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"terraform-provider-uyuni/internal/validators"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                 = &hubPeripheralChannelsResource{}
+	_ resource.ResourceWithConfigure    = &hubPeripheralChannelsResource{}
+	_ resource.ResourceWithImportState  = &hubPeripheralChannelsResource{}
+	_ resource.ResourceWithUpgradeState = &hubPeripheralChannelsResource{}
+)
+
+// NewHubPeripheralChannelsResource is a helper function to simplify the provider implementation.
+func NewHubPeripheralChannelsResource() resource.Resource {
+	return &hubPeripheralChannelsResource{}
+}
+
+// hubPeripheralChannelsResource is the resource implementation.
+type hubPeripheralChannelsResource struct {
+	client *uyuniClient
+}
+
+// hubPeripheralChannelsResourceModel maps the resource schema data.
+type hubPeripheralChannelsResourceModel struct {
+	ID             types.String   `tfsdk:"id"`
+	PeripheralFQDN types.String   `tfsdk:"peripheral_fqdn"`
+	ChannelLabels  types.Set      `tfsdk:"channel_labels"`
+	ServerAlias    types.String   `tfsdk:"server_alias"`
+	Timeouts       timeouts.Value `tfsdk:"timeouts"`
+}
+
+// hubPeripheralChannelsStateMigrations upgrade states of prior schema versions.
+var hubPeripheralChannelsStateMigrations = stateMigrations{
+	// Version 0 had no id attribute.
+	migrateAddID("peripheral_fqdn"),
+}
+
+// Metadata returns the resource type name.
+func (r *hubPeripheralChannelsResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_hub_peripheral_channels"
+}
+
+// Schema defines the schema for the resource.
+func (r *hubPeripheralChannelsResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Version: hubPeripheralChannelsStateMigrations.version(),
+		Description: "Manages the channels a peripheral server synchronizes from the hub. " +
+			"The provider must be configured against the hub.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "FQDN of the peripheral.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"peripheral_fqdn": schema.StringAttribute{
+				Description: "FQDN of the peripheral server registered to the hub.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"channel_labels": schema.SetAttribute{
+				Description: "Labels of the hub channels the peripheral synchronizes.",
+				ElementType: types.StringType,
+				Required:    true,
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(validators.ChannelLabel()),
+				},
+			},
+			"server_alias": serverAliasAttribute(),
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+// UpgradeState upgrades states of prior schema versions.
+func (r *hubPeripheralChannelsResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return hubPeripheralChannelsStateMigrations.upgraders()
+}
+
+// listPeripheralChannels returns the channels the peripheral currently synchronizes.
+func listPeripheralChannels(ctx context.Context, client *uyuniClient, fqdn string) ([]string, error) {
+	channels, err := apiGet[[]string](ctx, client, "sync/hub/listPeripheralChannelsToSync?fqdn="+url.QueryEscape(fqdn))
+	if err != nil {
+		return nil, err
+	}
+	return channels.Result, nil
+}
+
+// changePeripheralChannels adds or removes channels from the synchronization of the peripheral.
+func changePeripheralChannels(ctx context.Context, client *uyuniClient, fqdn string, add, remove []string) error {
+	if len(add) > 0 {
+		_, err := apiPost[int](ctx, client, "sync/hub/addPeripheralChannelsToSync", map[string]interface{}{
+			"fqdn":          fqdn,
+			"channelLabels": add,
+		})
+		if err != nil {
+			return fmt.Errorf("could not add channels %v: %w", add, err)
+		}
+	}
+	if len(remove) > 0 {
+		_, err := apiPost[int](ctx, client, "sync/hub/removePeripheralChannelsToSync", map[string]interface{}{
+			"fqdn":          fqdn,
+			"channelLabels": remove,
+		})
+		if err != nil {
+			return fmt.Errorf("could not remove channels %v: %w", remove, err)
+		}
+	}
+	return nil
+}
+
+// Create a new resource.
+func (r *hubPeripheralChannelsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan hubPeripheralChannelsResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := resourceClient(ctx, r.client, plan.ServerAlias, nil, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	var wanted []string
+	resp.Diagnostics.Append(plan.ChannelLabels.ElementsAs(ctx, &wanted, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	fqdn := plan.PeripheralFQDN.ValueString()
+	current, err := listPeripheralChannels(ctx, client, fqdn)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error configuring peripheral channels",
+			"Could not list channels of peripheral "+fqdn+": "+err.Error(),
+		)
+		return
+	}
+
+	// The resource owns the whole selection of the peripheral.
+	add, remove := setDiff(current, wanted)
+	tflog.Info(ctx, fmt.Sprintf("Peripheral %s: adding %d and removing %d channels", fqdn, len(add), len(remove)))
+	if err := changePeripheralChannels(ctx, client, fqdn, add, remove); err != nil {
+		resp.Diagnostics.AddError(
+			"Error configuring peripheral channels",
+			"Could not configure channels of peripheral "+fqdn+": "+err.Error(),
+		)
+		return
+	}
+
+	plan.ID = plan.PeripheralFQDN
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read resource information.
+func (r *hubPeripheralChannelsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Get current state
+	var state hubPeripheralChannelsResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := resourceClient(ctx, r.client, state.ServerAlias, nil, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	fqdn := state.PeripheralFQDN.ValueString()
+	current, err := listPeripheralChannels(ctx, client, fqdn)
+	if err != nil {
+		if handleNotFound(ctx, resp, err, "Peripheral "+fqdn) {
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Reading Uyuni peripheral channels",
+			"Could not list channels of peripheral "+fqdn+": "+err.Error(),
+		)
+		return
+	}
+
+	labels, diags := types.SetValueFrom(ctx, types.StringType, current)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	state.ChannelLabels = labels
+
+	state.ID = state.PeripheralFQDN
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *hubPeripheralChannelsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Retrieve values from plan and state
+	var plan, state hubPeripheralChannelsResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := resourceClient(ctx, r.client, plan.ServerAlias, nil, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	var wanted, previous []string
+	resp.Diagnostics.Append(plan.ChannelLabels.ElementsAs(ctx, &wanted, false)...)
+	resp.Diagnostics.Append(state.ChannelLabels.ElementsAs(ctx, &previous, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	fqdn := plan.PeripheralFQDN.ValueString()
+	add, remove := setDiff(previous, wanted)
+	if err := changePeripheralChannels(ctx, client, fqdn, add, remove); err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating peripheral channels",
+			"Could not update channels of peripheral "+fqdn+": "+err.Error(),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete stops the synchronization of all managed channels.
+func (r *hubPeripheralChannelsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Retrieve values from state
+	var state hubPeripheralChannelsResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := resourceClient(ctx, r.client, state.ServerAlias, nil, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	var labels []string
+	resp.Diagnostics.Append(state.ChannelLabels.ElementsAs(ctx, &labels, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	fqdn := state.PeripheralFQDN.ValueString()
+	if err := changePeripheralChannels(ctx, client, fqdn, nil, labels); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Deleting Uyuni peripheral channels",
+			"Could not remove channels of peripheral "+fqdn+": "+err.Error(),
+		)
+		return
+	}
+}
+
+// ImportState imports the channel selection of a peripheral by its FQDN.
+func (r *hubPeripheralChannelsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("peripheral_fqdn"), req, resp)
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *hubPeripheralChannelsResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*uyuniClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *uyuniClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// setDiff returns the values to add to and remove from current to get wanted.
+func setDiff[T comparable](current, wanted []T) (add, remove []T) {
+	have := map[T]bool{}
+	for _, v := range current {
+		have[v] = true
+	}
+	want := map[T]bool{}
+	for _, v := range wanted {
+		want[v] = true
+		if !have[v] {
+			add = append(add, v)
+		}
+	}
+	for _, v := range current {
+		if !want[v] {
+			remove = append(remove, v)
+		}
+	}
+	return add, remove
+}
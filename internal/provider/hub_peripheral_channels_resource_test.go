@@ -0,0 +1,62 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccHubPeripheralChannelsResource(t *testing.T) {
+	fqdn := os.Getenv("UYUNI_TEST_PERIPHERAL_FQDN")
+	channel := os.Getenv("UYUNI_TEST_HUB_CHANNEL")
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccRealServerPreCheck(t)
+			testAccSkipUnlessEnv(t, "UYUNI_TEST_PERIPHERAL_FQDN", "a peripheral registered to the hub under test")
+			testAccSkipUnlessEnv(t, "UYUNI_TEST_HUB_CHANNEL", "a channel of the hub")
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "uyuni_hub_peripheral_channels" "test" {
+  peripheral_fqdn = %q
+  channel_labels  = [%q]
+}
+`, fqdn, channel),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("uyuni_hub_peripheral_channels.test", "id", fqdn),
+					resource.TestCheckTypeSetElemAttr("uyuni_hub_peripheral_channels.test", "channel_labels.*", channel),
+				),
+			},
+			{
+				ResourceName:      "uyuni_hub_peripheral_channels.test",
+				ImportState:       true,
+				ImportStateId:     fqdn,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestSetDiff(t *testing.T) {
+	add, remove := setDiff(
+		[]string{"sles15-sp6-pool", "sles15-sp6-updates", "old-tools"},
+		[]string{"sles15-sp6-pool", "sles15-sp6-updates", "new-tools"},
+	)
+
+	if want := []string{"new-tools"}; !reflect.DeepEqual(add, want) {
+		t.Errorf("add: got %v, want %v", add, want)
+	}
+	if want := []string{"old-tools"}; !reflect.DeepEqual(remove, want) {
+		t.Errorf("remove: got %v, want %v", remove, want)
+	}
+
+	add, remove = setDiff([]string{"a"}, []string{"a"})
+	if len(add) != 0 || len(remove) != 0 {
+		t.Errorf("unchanged set: got add=%v remove=%v", add, remove)
+	}
+}
@@ -0,0 +1,282 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"sync"
+
+	"terraform-provider-uyuni/internal/uyuni"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &HubSystemsDataSource{}
+	_ datasource.DataSourceWithConfigure = &HubSystemsDataSource{}
+)
+
+// HubSystemsDataSourceModel maps the data source schema data.
+type HubSystemsDataSourceModel struct {
+	ServerAliases      types.Set        `tfsdk:"server_aliases"`
+	IncludeHub         types.Bool       `tfsdk:"include_hub"`
+	NameRegex          types.String     `tfsdk:"name_regex"`
+	OSInfo             types.Bool       `tfsdk:"include_os_info"`
+	Parallelism        types.Int64      `tfsdk:"parallelism"`
+	IgnoreUnreachable  types.Bool       `tfsdk:"ignore_unreachable"`
+	UnreachableServers types.Set        `tfsdk:"unreachable_servers"`
+	Systems            []hubSystemModel `tfsdk:"systems"`
+}
+
+// hubSystemModel maps a system registered to a server of the hub.
+type hubSystemModel struct {
+	ServerAlias      types.String `tfsdk:"server_alias"`
+	ID               types.Int64  `tfsdk:"id"`
+	Name             types.String `tfsdk:"name"`
+	LastCheckin      types.String `tfsdk:"last_checkin"`
+	OutdatedPkgCount types.Int64  `tfsdk:"outdated_pkg_count"`
+	ExtraPkgCount    types.Int64  `tfsdk:"extra_pkg_count"`
+	osInfoModel
+}
+
+// NewHubSystemsDataSource is a helper function to simplify the provider implementation.
+func NewHubSystemsDataSource() datasource.DataSource {
+	return &HubSystemsDataSource{}
+}
+
+// HubSystemsDataSource is the data source implementation.
+type HubSystemsDataSource struct {
+	client *uyuniClient
+}
+
+// Metadata returns the data source type name.
+func (d *HubSystemsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_hub_systems"
+}
+
+// Schema defines the schema for the data source.
+func (d *HubSystemsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the systems registered to the server of the provider and to the peripheral servers of its servers " +
+			"attribute, e.g. to produce an inventory of a whole hub estate from one workspace. The API of Uyuni offers no " +
+			"estate-wide listing, so each server is asked in turn with the credentials configured for it.",
+		Attributes: map[string]schema.Attribute{
+			"server_aliases": schema.SetAttribute{
+				Description: "Aliases of the servers in the servers attribute of the provider to list systems of. " +
+					"Defaults to all of them.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"include_hub": schema.BoolAttribute{
+				Description: "Whether to list the systems of the server of the provider as well. Defaults to true.",
+				Optional:    true,
+			},
+			"name_regex": schema.StringAttribute{
+				Description: "Only list systems whose name matches this regular expression, in RE2 syntax.",
+				Optional:    true,
+			},
+			"include_os_info": schema.BoolAttribute{
+				Description: "Whether to read the normalized operating system of the systems, " +
+					"which takes one call per system. The os_ attributes are null otherwise. Defaults to false.",
+				Optional: true,
+			},
+			"parallelism": parallelismAttribute("systems of each server"),
+			"ignore_unreachable": schema.BoolAttribute{
+				Description: "Whether to skip peripheral servers which cannot be reached or fail to list their systems, " +
+					"instead of failing. They are listed in unreachable_servers. Defaults to false.",
+				Optional: true,
+			},
+			"unreachable_servers": schema.SetAttribute{
+				Description: "Aliases of the peripheral servers skipped with ignore_unreachable.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"systems": schema.ListNestedAttribute{
+				Description: "Systems, ordered by server alias and name.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: withOSInfoAttributes(map[string]schema.Attribute{
+						"server_alias": schema.StringAttribute{
+							Description: "Alias of the server the system is registered to, null for the server of the provider.",
+							Computed:    true,
+						},
+						"id": schema.Int64Attribute{
+							Description: "ID of the system on its server.",
+							Computed:    true,
+						},
+						"name": schema.StringAttribute{
+							Description: "Name of the system.",
+							Computed:    true,
+						},
+						"last_checkin": schema.StringAttribute{
+							Description: "Date the system last checked in, in RFC 3339 format.",
+							Computed:    true,
+						},
+						"outdated_pkg_count": schema.Int64Attribute{
+							Description: "Number of installed packages with updates available.",
+							Computed:    true,
+						},
+						"extra_pkg_count": schema.Int64Attribute{
+							Description: "Number of installed packages which are in no channel of the system.",
+							Computed:    true,
+						},
+					}),
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *HubSystemsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state HubSystemsDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var nameRegex *regexp.Regexp
+	if !state.NameRegex.IsNull() {
+		var err error
+		nameRegex, err = regexp.Compile(state.NameRegex.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("name_regex"), "Invalid Regular Expression", err.Error())
+			return
+		}
+	}
+
+	var aliases []string
+	if !state.ServerAliases.IsNull() {
+		resp.Diagnostics.Append(state.ServerAliases.ElementsAs(ctx, &aliases, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	} else if d.client.servers != nil {
+		aliases = d.client.servers.aliases()
+	}
+	// The server of the provider has the empty alias, as with resources.
+	if state.IncludeHub.IsNull() || state.IncludeHub.ValueBool() {
+		aliases = append(aliases, "")
+	}
+
+	var mu sync.Mutex
+	systems := map[string][]uyuni.SystemSummary{}
+	osInfos := map[string]map[int64]osInfo{}
+	errs := runBatch(aliases, func(alias string) error {
+		client, err := d.client.forServer(ctx, alias)
+		if err != nil {
+			return err
+		}
+		listed, err := apiGet[[]uyuni.SystemSummary](ctx, client, "system/listSystems")
+		if err != nil {
+			return err
+		}
+		var infos map[int64]osInfo
+		if state.OSInfo.ValueBool() {
+			var ids []int64
+			for _, system := range listed.Result {
+				if nameRegex == nil || nameRegex.MatchString(system.Name) {
+					ids = append(ids, int64(system.ID))
+				}
+			}
+			if infos, err = osInfoBySystem(ctx, client, ids, batchLimit(state.Parallelism)); err != nil {
+				return err
+			}
+		}
+		mu.Lock()
+		systems[alias] = listed.Result
+		osInfos[alias] = infos
+		mu.Unlock()
+		return nil
+	})
+
+	unreachable := []string{}
+	if len(errs) > 0 {
+		_, hubFailed := errs[""]
+		if hubFailed || !state.IgnoreUnreachable.ValueBool() {
+			if hubFailed {
+				errs["server of the provider"] = errs[""]
+				delete(errs, "")
+			}
+			resp.Diagnostics.AddError(
+				"Unable to Read Uyuni hub systems",
+				"Could not list systems: "+batchError(errs).Error(),
+			)
+			return
+		}
+		for alias, err := range errs {
+			tflog.Warn(ctx, "Skipping unreachable peripheral server "+alias, map[string]interface{}{"error": err.Error()})
+			unreachable = append(unreachable, alias)
+		}
+	}
+	state.UnreachableServers, diags = types.SetValueFrom(ctx, types.StringType, unreachable)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	sort.Strings(aliases)
+	state.Systems = []hubSystemModel{}
+	for _, alias := range aliases {
+		listed := systems[alias]
+		sort.Slice(listed, func(i, j int) bool {
+			if listed[i].Name != listed[j].Name {
+				return listed[i].Name < listed[j].Name
+			}
+			return listed[i].ID < listed[j].ID
+		})
+		for _, system := range listed {
+			if nameRegex != nil && !nameRegex.MatchString(system.Name) {
+				continue
+			}
+			var info *osInfo
+			if found, ok := osInfos[alias][int64(system.ID)]; ok {
+				info = &found
+			}
+			state.Systems = append(state.Systems, hubSystemModel{
+				ServerAlias:      nonEmptyString(alias),
+				ID:               types.Int64Value(int64(system.ID)),
+				Name:             types.StringValue(system.Name),
+				LastCheckin:      timestampValue(ctx, system.LastCheckin),
+				OutdatedPkgCount: types.Int64Value(int64(system.OutdatedPkgCount)),
+				ExtraPkgCount:    types.Int64Value(int64(system.ExtraPkgCount)),
+				osInfoModel:      info.model(),
+			})
+		}
+	}
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *HubSystemsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*uyuniClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *uyuniClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
@@ -0,0 +1,107 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/uyuni-project/uyuni-tools/shared/api"
+)
+
+// testHubClient returns a hub client whose peripherals answer with the given
+// systems, and an alias "down" which cannot be reached.
+func testHubClient(t *testing.T, peripherals map[string]string) *uyuniClient {
+	hub := testAPIClient(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"success": true, "result": [{"id": 1000010000, "name": "peripheral01.example.com"}]}`))
+	})
+	conns := map[string]api.ConnectionDetails{"down": {Server: "127.0.0.1:0"}}
+	for alias := range peripherals {
+		conns[alias] = api.ConnectionDetails{}
+	}
+	hub.servers = newServerRegistry(conns)
+	for alias, systems := range peripherals {
+		hub.servers.clients[alias] = testAPIClient(t, func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/system/listSystems" {
+				t.Errorf("unexpected request %s", r.URL)
+			}
+			_, _ = w.Write([]byte(`{"success": true, "result": ` + systems + `}`))
+		})
+	}
+	return hub
+}
+
+func TestHubSystemsDataSource(t *testing.T) {
+	client := testHubClient(t, map[string]string{
+		"eu": `[{"id": 1000010002, "name": "web02.example.com", "outdated_pkg_count": 3}, {"id": 1000010001, "name": "db01.example.com"}]`,
+		"us": `[{"id": 1000010001, "name": "web01.example.com", "last_checkin": "2025-02-03T09:41:30Z"}]`,
+	})
+
+	resp := testDataSourceRead(t, NewHubSystemsDataSource(), client, map[string]tftypes.Value{
+		"server_aliases": tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, []tftypes.Value{
+			tftypes.NewValue(tftypes.String, "eu"),
+			tftypes.NewValue(tftypes.String, "us"),
+		}),
+		"name_regex": tftypes.NewValue(tftypes.String, "^(web|db)"),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+	var model HubSystemsDataSourceModel
+	resp.State.Get(context.Background(), &model)
+	var got []string
+	for _, system := range model.Systems {
+		got = append(got, system.ServerAlias.ValueString()+"/"+system.Name.ValueString())
+	}
+	if strings.Join(got, ",") != "eu/db01.example.com,eu/web02.example.com,us/web01.example.com" {
+		t.Fatalf("expected the systems ordered by server and name, got %v", got)
+	}
+	if model.Systems[1].OutdatedPkgCount.ValueInt64() != 3 || model.Systems[2].LastCheckin.ValueString() != "2025-02-03T09:41:30Z" {
+		t.Errorf("unexpected systems %v", model.Systems)
+	}
+}
+
+func TestHubSystemsDataSourceUnreachable(t *testing.T) {
+	client := testHubClient(t, map[string]string{"eu": `[{"id": 1000010001, "name": "db01.example.com"}]`})
+
+	resp := testDataSourceRead(t, NewHubSystemsDataSource(), client, nil)
+	if !resp.Diagnostics.HasError() || !strings.Contains(resp.Diagnostics[0].Detail(), "down: could not log in") {
+		t.Fatalf("expected the unreachable server to fail the read, got %v", resp.Diagnostics)
+	}
+
+	resp = testDataSourceRead(t, NewHubSystemsDataSource(), client, map[string]tftypes.Value{
+		"ignore_unreachable": tftypes.NewValue(tftypes.Bool, true),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+	var model HubSystemsDataSourceModel
+	resp.State.Get(context.Background(), &model)
+	if len(model.Systems) != 2 || !model.Systems[0].ServerAlias.IsNull() || len(model.UnreachableServers.Elements()) != 1 {
+		t.Errorf("expected the hub and eu systems with down skipped, got %v", model)
+	}
+}
+
+func TestAccHubSystemsDataSource(t *testing.T) {
+	minion := os.Getenv("UYUNI_TEST_MINION_ID")
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccRealServerPreCheck(t)
+			testAccSkipUnlessEnv(t, "UYUNI_TEST_MINION_ID", "a registered Salt minion")
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				// Without servers the hub is the only server.
+				Config: `data "uyuni_hub_systems" "all" {}`,
+				Check: resource.TestCheckTypeSetElemNestedAttrs("data.uyuni_hub_systems.all", "systems.*", map[string]string{
+					"server_alias": "",
+					"id":           minion,
+				}),
+			},
+		},
+	})
+}
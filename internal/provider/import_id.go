@@ -0,0 +1,34 @@
+package provider
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// importIDSeparator separates the parts of composite import IDs, e.g. "B042:1000010042".
+const importIDSeparator = ":"
+
+// parseImportID splits a composite import ID into exactly len(parts) non-empty
+// values. parts names the expected components and is used in error messages.
+func parseImportID(id string, parts ...string) ([]string, error) {
+	values := strings.Split(id, importIDSeparator)
+	if len(values) != len(parts) {
+		return nil, fmt.Errorf("expected import ID in the format %q, got: %q", strings.Join(parts, importIDSeparator), id)
+	}
+	for i, value := range values {
+		if value == "" {
+			return nil, fmt.Errorf("%s must not be empty in import ID %q", parts[i], id)
+		}
+	}
+	return values, nil
+}
+
+// parseImportInt64 converts one component of an import ID into a number.
+func parseImportInt64(name, value string) (int64, error) {
+	number, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%s must be numeric, got: %q", name, value)
+	}
+	return number, nil
+}
@@ -0,0 +1,31 @@
+package provider
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseImportID(t *testing.T) {
+	values, err := parseImportID("B042:1000010042", "branch_id", "branch_server_id")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"B042", "1000010042"}; !reflect.DeepEqual(values, want) {
+		t.Errorf("got %v, want %v", values, want)
+	}
+
+	for _, id := range []string{"B042", "B042:1:2", "B042:", ":1000010042"} {
+		if _, err := parseImportID(id, "branch_id", "branch_server_id"); err == nil {
+			t.Errorf("expected %q to be rejected", id)
+		}
+	}
+}
+
+func TestParseImportInt64(t *testing.T) {
+	if got, err := parseImportInt64("system_id", "1000010000"); err != nil || got != 1000010000 {
+		t.Errorf("got %d, %v", got, err)
+	}
+	if _, err := parseImportInt64("system_id", "web01"); err == nil {
+		t.Error("expected a non-numeric value to be rejected")
+	}
+}
@@ -0,0 +1,324 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"terraform-provider-uyuni/internal/uyuni"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource              = &inactiveSystemsCleanupResource{}
+	_ resource.ResourceWithConfigure = &inactiveSystemsCleanupResource{}
+)
+
+// NewInactiveSystemsCleanupResource is a helper function to simplify the provider implementation.
+func NewInactiveSystemsCleanupResource() resource.Resource {
+	return &inactiveSystemsCleanupResource{}
+}
+
+// inactiveSystemsCleanupResource is the resource implementation.
+type inactiveSystemsCleanupResource struct {
+	client *uyuniClient
+}
+
+// inactiveSystemsCleanupResourceModel maps the resource schema data.
+type inactiveSystemsCleanupResourceModel struct {
+	ID                 types.String   `tfsdk:"id"`
+	InactiveDays       types.Int64    `tfsdk:"inactive_days"`
+	ProtectedGroups    types.Set      `tfsdk:"protected_groups"`
+	MaxSystems         types.Int64    `tfsdk:"max_systems"`
+	DryRun             types.Bool     `tfsdk:"dry_run"`
+	CleanupType        types.String   `tfsdk:"cleanup_type"`
+	Triggers           types.Map      `tfsdk:"triggers"`
+	SystemIDs          types.Set      `tfsdk:"system_ids"`
+	ProtectedSystemIDs types.Set      `tfsdk:"protected_system_ids"`
+	ServerAlias        types.String   `tfsdk:"server_alias"`
+	Timeouts           timeouts.Value `tfsdk:"timeouts"`
+}
+
+// Metadata returns the resource type name.
+func (r *inactiveSystemsCleanupResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_inactive_systems_cleanup"
+}
+
+// Schema defines the schema for the resource.
+func (r *inactiveSystemsCleanupResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Deletes the systems which have not checked in for a number of days when created, except for the systems " +
+			"of protected groups. Change triggers, e.g. with a time_rotating resource, to clean up again. " +
+			"Destroying the resource does not bring deleted systems back.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Date of the cleanup, in RFC 3339 format.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"inactive_days": schema.Int64Attribute{
+				Description: "Delete systems which have not checked in for at least this many days.",
+				Required:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"protected_groups": schema.SetAttribute{
+				Description: "Names of system groups whose systems are never deleted. The cleanup fails if a group does not exist, " +
+					"so that a misspelled name cannot expose its systems.",
+				ElementType: types.StringType,
+				Optional:    true,
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.RequiresReplace(),
+				},
+			},
+			"max_systems": schema.Int64Attribute{
+				Description: "Fail without deleting anything if more systems than this would be deleted, " +
+					"e.g. when a network outage kept many systems from checking in. Not limited when not set.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"dry_run": schema.BoolAttribute{
+				Description: "Only list the systems which would be deleted in system_ids. Defaults to false.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"cleanup_type": schema.StringAttribute{
+				Description: "How to clean up the hosts of the deleted systems, `FAIL_ON_CLEANUP_ERR`, `NO_CLEANUP` or " +
+					"`FORCE_DELETE`, which deletes the systems even if their hosts cannot be cleaned up, as is likely " +
+					"for hosts which stopped checking in. Defaults to `FORCE_DELETE`.",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString("FORCE_DELETE"),
+				Validators: []validator.String{
+					stringvalidator.OneOf(cleanupTypes...),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"triggers": schema.MapAttribute{
+				Description: "Arbitrary values which clean up the systems again when they change.",
+				ElementType: types.StringType,
+				Optional:    true,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"system_ids": schema.SetAttribute{
+				Description: "IDs of the deleted systems, or of the systems which would be deleted with dry_run.",
+				ElementType: types.Int64Type,
+				Computed:    true,
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"protected_system_ids": schema.SetAttribute{
+				Description: "IDs of the inactive systems which were kept because they belong to a protected group.",
+				ElementType: types.Int64Type,
+				Computed:    true,
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"server_alias": serverAliasAttribute(),
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+			}),
+		},
+	}
+}
+
+// inactiveSystems returns the IDs of the systems which have not checked in
+// for days, and of those among them which belong to the protected groups.
+func inactiveSystems(ctx context.Context, client *uyuniClient, days int64, protectedGroups []string) (stale, protected []int64, err error) {
+	inactive, err := apiGet[[]uyuni.SystemSummary](ctx, client, fmt.Sprintf("system/listInactiveSystems?days=%d", days))
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not list inactive systems: %w", err)
+	}
+
+	isProtected := map[int64]bool{}
+	for _, group := range protectedGroups {
+		ids, err := listGroupSystems(ctx, client, group)
+		if err != nil {
+			return nil, nil, fmt.Errorf("could not list systems of protected group %s: %w", group, err)
+		}
+		for _, id := range ids {
+			isProtected[id] = true
+		}
+	}
+
+	stale, protected = []int64{}, []int64{}
+	for _, system := range inactive.Result {
+		id := int64(system.ID)
+		if isProtected[id] {
+			protected = append(protected, id)
+		} else {
+			stale = append(stale, id)
+		}
+	}
+	sort.Slice(stale, func(i, j int) bool { return stale[i] < stale[j] })
+	sort.Slice(protected, func(i, j int) bool { return protected[i] < protected[j] })
+	return stale, protected, nil
+}
+
+// Create deletes the inactive systems which are not protected.
+func (r *inactiveSystemsCleanupResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan inactiveSystemsCleanupResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := resourceClient(ctx, r.client, plan.ServerAlias, nil, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	groups, err := stringSet(ctx, plan.ProtectedGroups)
+	if err != nil {
+		resp.Diagnostics.AddError("Error cleaning up inactive systems", err.Error())
+		return
+	}
+	days := plan.InactiveDays.ValueInt64()
+	stale, protected, err := inactiveSystems(ctx, client, days, groups)
+	if err != nil {
+		resp.Diagnostics.AddError("Error cleaning up inactive systems", err.Error())
+		return
+	}
+	if !plan.MaxSystems.IsNull() && int64(len(stale)) > plan.MaxSystems.ValueInt64() {
+		resp.Diagnostics.AddError(
+			"Error cleaning up inactive systems",
+			fmt.Sprintf("%d systems have not checked in for %d days, more than max_systems %d allows. No system was deleted: %v",
+				len(stale), days, plan.MaxSystems.ValueInt64(), stale),
+		)
+		return
+	}
+
+	if plan.DryRun.ValueBool() {
+		tflog.Info(ctx, fmt.Sprintf("Dry run: would delete %d inactive systems %v", len(stale), stale))
+	} else if len(stale) > 0 {
+		tflog.Info(ctx, fmt.Sprintf("Deleting %d inactive systems %v", len(stale), stale))
+		_, err := apiPost[int](ctx, client, "system/deleteSystems", map[string]interface{}{
+			"sids":        stale,
+			"cleanupType": plan.CleanupType.ValueString(),
+		})
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error cleaning up inactive systems",
+				fmt.Sprintf("Could not delete systems %v: %s", stale, err),
+			)
+			return
+		}
+	}
+
+	plan.ID = types.StringValue(apiDate(time.Now()))
+	plan.SystemIDs, diags = types.SetValueFrom(ctx, types.Int64Type, stale)
+	resp.Diagnostics.Append(diags...)
+	plan.ProtectedSystemIDs, diags = types.SetValueFrom(ctx, types.Int64Type, protected)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read keeps the state, deleted systems leave nothing to refresh.
+func (r *inactiveSystemsCleanupResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state inactiveSystemsCleanupResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update only changes server_alias and timeouts, all other changes clean up
+// again.
+func (r *inactiveSystemsCleanupResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan inactiveSystemsCleanupResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete removes the cleanup from state. Deleted systems stay deleted.
+func (r *inactiveSystemsCleanupResource) Delete(ctx context.Context, _ resource.DeleteRequest, _ *resource.DeleteResponse) {
+	tflog.Info(ctx, "Removing inactive systems cleanup from state, deleted systems stay deleted")
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *inactiveSystemsCleanupResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*uyuniClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *uyuniClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
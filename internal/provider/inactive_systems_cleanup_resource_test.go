@@ -0,0 +1,150 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	acctest "github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+// testInactiveSystemsServer serves the inactive systems 1, 2 and 3, of which
+// 2 is in the group production, and records the deleted systems.
+func testInactiveSystemsServer(t *testing.T, deleted *[]int64) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/system/listInactiveSystems":
+			if days := r.URL.Query().Get("days"); days != "30" {
+				t.Errorf("expected 30 days, got %s", days)
+			}
+			_, _ = w.Write([]byte(`{"success": true, "result": [{"id": 3, "name": "c"}, {"id": 1, "name": "a"}, {"id": 2, "name": "b"}]}`))
+		case "/systemgroup/listSystemsMinimal":
+			if r.URL.Query().Get("systemGroupName") != "production" {
+				_, _ = w.Write([]byte(`{"success": false, "message": "Unable to locate or access server group"}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"success": true, "result": [{"id": 2, "name": "b"}, {"id": 9, "name": "active"}]}`))
+		case "/system/deleteSystems":
+			var body struct {
+				SIDs        []int64 `json:"sids"`
+				CleanupType string  `json:"cleanupType"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			if body.CleanupType != "FORCE_DELETE" {
+				t.Errorf("unexpected cleanup type %s", body.CleanupType)
+			}
+			*deleted = append(*deleted, body.SIDs...)
+			_, _ = w.Write([]byte(`{"success": true, "result": 1}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL)
+		}
+	}
+}
+
+// testInactiveSystemsCleanup creates the cleanup configured with attributes.
+func testInactiveSystemsCleanup(t *testing.T, deleted *[]int64, attributes map[string]interface{}) *resource.CreateResponse {
+	r := NewInactiveSystemsCleanupResource()
+	testConfigure(t, r, testAPIClient(t, testInactiveSystemsServer(t, deleted)))
+	attributes["inactive_days"] = int64(30)
+	attributes["cleanup_type"] = "FORCE_DELETE"
+	planned := testState(t, r, attributes)
+	resp := &resource.CreateResponse{State: planned}
+	r.Create(context.Background(), resource.CreateRequest{Plan: tfsdk.Plan{Schema: planned.Schema, Raw: planned.Raw}}, resp)
+	return resp
+}
+
+func TestInactiveSystemsCleanupKeepsProtectedGroups(t *testing.T) {
+	ctx := context.Background()
+	groups, _ := types.SetValueFrom(ctx, types.StringType, []string{"production"})
+	var deleted []int64
+	resp := testInactiveSystemsCleanup(t, &deleted, map[string]interface{}{
+		"protected_groups": groups,
+		"dry_run":          false,
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+	if fmt.Sprint(deleted) != "[1 3]" {
+		t.Errorf("expected systems 1 and 3 to be deleted, got %v", deleted)
+	}
+	var state inactiveSystemsCleanupResourceModel
+	resp.State.Get(ctx, &state)
+	if state.SystemIDs.String() != "[1,3]" || state.ProtectedSystemIDs.String() != "[2]" {
+		t.Errorf("unexpected systems %s, protected %s", state.SystemIDs, state.ProtectedSystemIDs)
+	}
+}
+
+func TestInactiveSystemsCleanupRefusesUnknownProtectedGroup(t *testing.T) {
+	groups, _ := types.SetValueFrom(context.Background(), types.StringType, []string{"prodution"})
+	var deleted []int64
+	resp := testInactiveSystemsCleanup(t, &deleted, map[string]interface{}{
+		"protected_groups": groups,
+		"dry_run":          false,
+	})
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected an error")
+	}
+	if len(deleted) != 0 {
+		t.Errorf("expected no system to be deleted, got %v", deleted)
+	}
+}
+
+func TestInactiveSystemsCleanupLimitsDeletions(t *testing.T) {
+	var deleted []int64
+	resp := testInactiveSystemsCleanup(t, &deleted, map[string]interface{}{
+		"max_systems": int64(2),
+		"dry_run":     false,
+	})
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected an error")
+	}
+	if len(deleted) != 0 {
+		t.Errorf("expected no system to be deleted, got %v", deleted)
+	}
+}
+
+func TestInactiveSystemsCleanupDryRun(t *testing.T) {
+	ctx := context.Background()
+	var deleted []int64
+	resp := testInactiveSystemsCleanup(t, &deleted, map[string]interface{}{
+		"dry_run": true,
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+	if len(deleted) != 0 {
+		t.Errorf("expected no system to be deleted, got %v", deleted)
+	}
+	var state inactiveSystemsCleanupResourceModel
+	resp.State.Get(ctx, &state)
+	if state.SystemIDs.String() != "[1,2,3]" {
+		t.Errorf("expected all inactive systems to be listed, got %s", state.SystemIDs)
+	}
+}
+
+func TestAccInactiveSystemsCleanupResource(t *testing.T) {
+	acctest.Test(t, acctest.TestCase{
+		PreCheck:                 func() { testAccRealServerPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []acctest.TestStep{
+			// A dry run only lists the systems.
+			{
+				Config: `
+resource "uyuni_inactive_systems_cleanup" "test" {
+  inactive_days = 3650
+  dry_run       = true
+}
+`,
+				Check: acctest.ComposeAggregateTestCheckFunc(
+					acctest.TestCheckResourceAttrSet("uyuni_inactive_systems_cleanup.test", "id"),
+					acctest.TestCheckResourceAttr("uyuni_inactive_systems_cleanup.test", "system_ids.#", "0"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,112 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &KickstartFileDataSource{}
+	_ datasource.DataSourceWithConfigure = &KickstartFileDataSource{}
+)
+
+// KickstartFileDataSourceModel maps the data source schema data.
+type KickstartFileDataSourceModel struct {
+	ProfileLabel  types.String `tfsdk:"profile_label"`
+	Content       types.String `tfsdk:"content"`
+	ContentSHA256 types.String `tfsdk:"content_sha256"`
+}
+
+// NewKickstartFileDataSource is a helper function to simplify the provider implementation.
+func NewKickstartFileDataSource() datasource.DataSource {
+	return &KickstartFileDataSource{}
+}
+
+// KickstartFileDataSource is the data source implementation.
+type KickstartFileDataSource struct {
+	client *uyuniClient
+}
+
+// Metadata returns the data source type name.
+func (d *KickstartFileDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_kickstart_file"
+}
+
+// Schema defines the schema for the data source.
+func (d *KickstartFileDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Renders the autoinstallation file of a profile with its variables and snippets expanded, " +
+			"e.g. to serve it from a PXE server managed by another provider.",
+		Attributes: map[string]schema.Attribute{
+			"profile_label": schema.StringAttribute{
+				Description: "Label of the autoinstall profile.",
+				Required:    true,
+			},
+			"content": schema.StringAttribute{
+				Description: "Rendered file. It is sensitive, as it usually holds the root password hash and activation keys.",
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"content_sha256": schema.StringAttribute{
+				Description: "SHA256 checksum of the rendered file.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *KickstartFileDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state KickstartFileDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	label := state.ProfileLabel.ValueString()
+	content, err := apiGet[string](ctx, d.client, "kickstart/profile/downloadRenderedKickstart?ksLabel="+url.QueryEscape(label))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Uyuni kickstart file",
+			"Could not render autoinstall profile "+label+": "+err.Error(),
+		)
+		return
+	}
+	state.Content = types.StringValue(content.Result)
+	state.ContentSHA256 = types.StringValue(contentSHA256(content.Result))
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *KickstartFileDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*uyuniClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *uyuniClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
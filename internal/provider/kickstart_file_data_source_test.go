@@ -0,0 +1,65 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestKickstartFileDataSource(t *testing.T) {
+	ctx := context.Background()
+	client := testAPIClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/kickstart/profile/downloadRenderedKickstart" || r.URL.Query().Get("ksLabel") != "sles15-sp6-web" {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		_, _ = w.Write([]byte(`{"success": true, "result": "install\nreboot\n"}`))
+	})
+
+	d := NewKickstartFileDataSource()
+	d.(datasource.DataSourceWithConfigure).Configure(ctx, datasource.ConfigureRequest{ProviderData: client}, &datasource.ConfigureResponse{})
+	var schemaResp datasource.SchemaResponse
+	d.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	values := map[string]tftypes.Value{}
+	for name, attributeType := range objectType.AttributeTypes {
+		values[name] = tftypes.NewValue(attributeType, nil)
+	}
+	values["profile_label"] = tftypes.NewValue(tftypes.String, "sles15-sp6-web")
+	config := tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, values)}
+	resp := &datasource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: config.Raw}}
+	d.Read(ctx, datasource.ReadRequest{Config: config}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+
+	var state KickstartFileDataSourceModel
+	resp.State.Get(ctx, &state)
+	if state.Content.ValueString() != "install\nreboot\n" || state.ContentSHA256.ValueString() != contentSHA256("install\nreboot\n") {
+		t.Errorf("unexpected file %q with checksum %s", state.Content.ValueString(), state.ContentSHA256)
+	}
+}
+
+func TestAccKickstartFileDataSource(t *testing.T) {
+	profile := os.Getenv("UYUNI_TEST_AUTOINSTALL_PROFILE")
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccRealServerPreCheck(t)
+			testAccSkipUnlessEnv(t, "UYUNI_TEST_AUTOINSTALL_PROFILE", "an autoinstallation profile")
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`data "uyuni_kickstart_file" "test" { profile_label = %q }`, profile),
+				Check:  resource.TestCheckResourceAttrSet("data.uyuni_kickstart_file.test", "content"),
+			},
+		},
+	})
+}
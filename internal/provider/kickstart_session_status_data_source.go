@@ -0,0 +1,358 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"terraform-provider-uyuni/internal/uyuni"
+	"terraform-provider-uyuni/internal/validators"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &KickstartSessionStatusDataSource{}
+	_ datasource.DataSourceWithConfigure = &KickstartSessionStatusDataSource{}
+)
+
+// States of kickstart sessions besides the action statuses.
+const (
+	kickstartSessionNone       = "none"
+	kickstartSessionInstalling = "installing"
+)
+
+// kickstartActionTypes are the names of the action type of kickstarts in the
+// system history. Older servers still call them kickstarts.
+var kickstartActionTypes = []string{
+	"Initiate an auto installation",
+	"Initiate a kickstart for a system.",
+}
+
+// KickstartSessionStatusDataSourceModel maps the data source schema data.
+type KickstartSessionStatusDataSourceModel struct {
+	SystemIDs   types.Set                     `tfsdk:"system_ids"`
+	Since       types.String                  `tfsdk:"since"`
+	Wait        types.Bool                    `tfsdk:"wait"`
+	Parallelism types.Int64                   `tfsdk:"parallelism"`
+	Systems     []kickstartSessionStatusModel `tfsdk:"systems"`
+	Timeouts    timeouts.Value                `tfsdk:"timeouts"`
+}
+
+// kickstartSessionStatusModel maps the latest kickstart session of a system.
+type kickstartSessionStatusModel struct {
+	SystemID    types.Int64  `tfsdk:"system_id"`
+	State       types.String `tfsdk:"state"`
+	ActionID    types.Int64  `tfsdk:"action_id"`
+	Scheduled   types.String `tfsdk:"scheduled"`
+	PickedUp    types.String `tfsdk:"picked_up"`
+	Rebooted    types.String `tfsdk:"rebooted"`
+	LastCheckin types.String `tfsdk:"last_checkin"`
+	Message     types.String `tfsdk:"message"`
+}
+
+// NewKickstartSessionStatusDataSource is a helper function to simplify the provider implementation.
+func NewKickstartSessionStatusDataSource() datasource.DataSource {
+	return &KickstartSessionStatusDataSource{}
+}
+
+// KickstartSessionStatusDataSource is the data source implementation.
+type KickstartSessionStatusDataSource struct {
+	client *uyuniClient
+}
+
+// Metadata returns the data source type name.
+func (d *KickstartSessionStatusDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_kickstart_session_status"
+}
+
+// Schema defines the schema for the data source.
+func (d *KickstartSessionStatusDataSource) Schema(ctx context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Reads the status of the latest kickstart, i.e. autoinstallation, of systems, e.g. to wait for " +
+			"rebuilds scheduled by uyuni_system_reprovision before subscribing channels and applying the highstate. " +
+			"The API offers no kickstart sessions, the status is derived from the kickstart action in the history " +
+			"of each system and whether the system checked in since it rebooted into the installer. This requires " +
+			"the installed system to register with the reactivation key of the kickstart, as autoinstall profiles " +
+			"do by default, so that it keeps its ID and history.",
+		Attributes: map[string]schema.Attribute{
+			"system_ids": schema.SetAttribute{
+				Description: "IDs of the systems.",
+				ElementType: types.Int64Type,
+				Required:    true,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+				},
+			},
+			"since": schema.StringAttribute{
+				Description: "Only consider kickstarts scheduled from this date on, in RFC 3339 format, " +
+					"e.g. the earliest_occurrence of uyuni_system_reprovision. Systems without one are in state `none`.",
+				Optional: true,
+				Validators: []validator.String{
+					validators.Timestamp(),
+				},
+			},
+			"wait": schema.BoolAttribute{
+				Description: "Wait until no system is in state `pending` or `installing`, at most for the read timeout. " +
+					"Defaults to false.",
+				Optional: true,
+			},
+			"parallelism": parallelismAttribute("systems"),
+			"systems": schema.ListNestedAttribute{
+				Description: "Latest kickstart of each system, ordered by system ID.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"system_id": schema.Int64Attribute{
+							Description: "ID of the system.",
+							Computed:    true,
+						},
+						"state": schema.StringAttribute{
+							Description: "State of the kickstart: `none` if there is none, `pending` until the system " +
+								"rebooted into the installer, `failed` if that failed, `installing` until the installed system " +
+								"checked in and `completed` afterwards.",
+							Computed: true,
+						},
+						"action_id": schema.Int64Attribute{
+							Description: "ID of the kickstart action.",
+							Computed:    true,
+						},
+						"scheduled": schema.StringAttribute{
+							Description: "Earliest date of the kickstart, in RFC 3339 format.",
+							Computed:    true,
+						},
+						"picked_up": schema.StringAttribute{
+							Description: "Date the system picked the kickstart up, in RFC 3339 format.",
+							Computed:    true,
+						},
+						"rebooted": schema.StringAttribute{
+							Description: "Date the system rebooted into the installer, in RFC 3339 format.",
+							Computed:    true,
+						},
+						"last_checkin": schema.StringAttribute{
+							Description: "Date the system last checked in, in RFC 3339 format.",
+							Computed:    true,
+						},
+						"message": schema.StringAttribute{
+							Description: "Result message of the kickstart action.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx),
+		},
+	}
+}
+
+// latestKickstart returns the most recent kickstart action in the history
+// of the system scheduled from since on, nil if there is none.
+func latestKickstart(ctx context.Context, client *uyuniClient, sid int64, since time.Time) (*uyuni.SystemEvent, error) {
+	events, err := apiGet[[]uyuni.SystemEvent](ctx, client, fmt.Sprintf("system/listSystemEvents?sid=%d", sid))
+	if err != nil {
+		return nil, err
+	}
+	var latest *uyuni.SystemEvent
+	for i, event := range events.Result {
+		if !slices.Contains(kickstartActionTypes, event.ActionType) {
+			continue
+		}
+		if created, err := uyuni.ParseDate(event.Created); err == nil && created.Before(since) {
+			continue
+		}
+		if latest == nil || event.ID > latest.ID {
+			latest = &events.Result[i]
+		}
+	}
+	return latest, nil
+}
+
+// kickstartSession returns the status of the kickstart of a system checked
+// in last at lastCheckin.
+func kickstartSession(ctx context.Context, sid int64, event *uyuni.SystemEvent, lastCheckin string) kickstartSessionStatusModel {
+	session := kickstartSessionStatusModel{
+		SystemID:    types.Int64Value(sid),
+		State:       types.StringValue(kickstartSessionNone),
+		ActionID:    types.Int64Null(),
+		Scheduled:   types.StringNull(),
+		PickedUp:    types.StringNull(),
+		Rebooted:    types.StringNull(),
+		LastCheckin: timestampValue(ctx, lastCheckin),
+		Message:     types.StringNull(),
+	}
+	if event == nil {
+		return session
+	}
+	session.ActionID = types.Int64Value(int64(event.ID))
+	session.Scheduled = timestampValue(ctx, event.EarliestAction)
+	session.PickedUp = timestampValue(ctx, event.PickupTime)
+	session.Rebooted = timestampValue(ctx, event.CompletionTime)
+	session.Message = nonEmptyString(event.ResultMsg)
+
+	rebooted, rebootErr := uyuni.ParseDate(event.CompletionTime)
+	checkin, checkinErr := uyuni.ParseDate(lastCheckin)
+	switch {
+	case event.FailedCount > 0:
+		session.State = types.StringValue(actionStatusFailed)
+	case event.SuccessfulCount == 0 || rebootErr != nil:
+		session.State = types.StringValue(actionStatusPending)
+	case checkinErr == nil && checkin.After(rebooted):
+		session.State = types.StringValue(actionStatusCompleted)
+	default:
+		session.State = types.StringValue(kickstartSessionInstalling)
+	}
+	return session
+}
+
+// kickstartSessions returns the latest kickstart of each system, ordered by
+// system ID.
+func kickstartSessions(ctx context.Context, client *uyuniClient, sids []int64, since time.Time, limit int) ([]kickstartSessionStatusModel, error) {
+	systems, err := apiGet[[]uyuni.SystemSummary](ctx, client, "system/listSystems")
+	if err != nil {
+		return nil, fmt.Errorf("could not list systems: %w", err)
+	}
+	checkins := map[int64]string{}
+	for _, system := range systems.Result {
+		checkins[int64(system.ID)] = system.LastCheckin
+	}
+
+	keys := make([]string, 0, len(sids))
+	for _, sid := range sids {
+		if _, ok := checkins[sid]; !ok {
+			return nil, fmt.Errorf("system %d not found", sid)
+		}
+		keys = append(keys, strconv.FormatInt(sid, 10))
+	}
+	sessions := map[int64]kickstartSessionStatusModel{}
+	var mu sync.Mutex
+	errs := runBatchLimit(keys, limit, func(key string) error {
+		sid, _ := strconv.ParseInt(key, 10, 64)
+		event, err := latestKickstart(ctx, client, sid, since)
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		sessions[sid] = kickstartSession(ctx, sid, event, checkins[sid])
+		return nil
+	})
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("could not list system events: %w", batchError(errs))
+	}
+
+	sorted := slices.Clone(sids)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	result := make([]kickstartSessionStatusModel, 0, len(sorted))
+	for _, sid := range sorted {
+		result = append(result, sessions[sid])
+	}
+	return result, nil
+}
+
+// unfinishedKickstarts returns the IDs of the systems whose kickstart is
+// pending or installing.
+func unfinishedKickstarts(sessions []kickstartSessionStatusModel) []int64 {
+	var sids []int64
+	for _, session := range sessions {
+		if state := session.State.ValueString(); state == actionStatusPending || state == kickstartSessionInstalling {
+			sids = append(sids, session.SystemID.ValueInt64())
+		}
+	}
+	return sids
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *KickstartSessionStatusDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state KickstartSessionStatusDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	sids, err := int64Set(ctx, state.SystemIDs)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("system_ids"), "Unable to Read Uyuni kickstart session status", err.Error())
+		return
+	}
+	var since time.Time
+	if !state.Since.IsNull() {
+		if since, err = time.Parse(time.RFC3339, state.Since.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("since"), "Unable to Read Uyuni kickstart session status", err.Error())
+			return
+		}
+	}
+
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	for {
+		state.Systems, err = kickstartSessions(ctx, d.client, sids, since, batchLimit(state.Parallelism))
+		if err != nil {
+			resp.Diagnostics.AddError("Unable to Read Uyuni kickstart session status", err.Error())
+			return
+		}
+		unfinished := unfinishedKickstarts(state.Systems)
+		if !state.Wait.ValueBool() || len(unfinished) == 0 {
+			break
+		}
+
+		tflog.Debug(ctx, fmt.Sprintf("Waiting for the kickstarts of systems %v", unfinished))
+		select {
+		case <-ctx.Done():
+			resp.Diagnostics.AddError(
+				"Unable to Read Uyuni kickstart session status",
+				fmt.Sprintf("The kickstarts of systems %v did not finish: %s", unfinished, ctx.Err()),
+			)
+			return
+		case <-time.After(actionPollInterval):
+		}
+		// The sessions change without writes, which would refresh the
+		// cache.
+		d.client.cache.invalidate()
+	}
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *KickstartSessionStatusDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*uyuniClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *uyuniClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
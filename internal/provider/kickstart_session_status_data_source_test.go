@@ -0,0 +1,99 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestKickstartSessionStatusWaitsForCheckin(t *testing.T) {
+	actionPollInterval = time.Millisecond
+	t.Cleanup(func() { actionPollInterval = 10 * time.Second })
+
+	listed := 0
+	client := testAPIClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/system/listSystems":
+			// The reinstalled system checks in on the second poll.
+			listed++
+			checkin := "2030-01-01T01:00:00Z"
+			if listed > 1 {
+				checkin = "2030-01-01T03:00:00Z"
+			}
+			_, _ = w.Write([]byte(`{"success": true, "result": [
+				{"id": 1, "name": "web01", "last_checkin": "` + checkin + `"},
+				{"id": 2, "name": "web02", "last_checkin": "2030-01-01T03:00:00Z"}
+			]}`))
+		case "/system/listSystemEvents":
+			if r.URL.Query().Get("sid") == "2" {
+				// Only a kickstart before since.
+				_, _ = w.Write([]byte(`{"success": true, "result": [
+					{"id": 5, "action_type": "Initiate an auto installation", "created": "2029-01-01T00:00:00Z", "completion_time": "2029-01-01T01:00:00Z", "successful_count": 1}
+				]}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"success": true, "result": [
+				{"id": 7, "action_type": "Initiate an auto installation", "created": "2030-01-01T00:00:00Z", "earliest_action": "2030-01-01T00:00:00Z", "completion_time": "2030-01-01T02:00:00Z", "successful_count": 1},
+				{"id": 8, "action_type": "Package List Refresh", "created": "2030-01-01T00:30:00Z", "successful_count": 1}
+			]}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL)
+		}
+	})
+	// Polls must not be answered from the cache.
+	client.cache = newReadCache(readCacheTTL)
+
+	resp := testDataSourceRead(t, NewKickstartSessionStatusDataSource(), client, map[string]tftypes.Value{
+		"system_ids": tftypes.NewValue(tftypes.Set{ElementType: tftypes.Number}, []tftypes.Value{
+			tftypes.NewValue(tftypes.Number, 2),
+			tftypes.NewValue(tftypes.Number, 1),
+		}),
+		"since": tftypes.NewValue(tftypes.String, "2030-01-01T00:00:00Z"),
+		"wait":  tftypes.NewValue(tftypes.Bool, true),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+	if listed != 2 {
+		t.Errorf("expected to poll until the system checked in, polled %d times", listed)
+	}
+	var state KickstartSessionStatusDataSourceModel
+	resp.State.Get(context.Background(), &state)
+	if len(state.Systems) != 2 {
+		t.Fatalf("expected a session per system, got %v", state.Systems)
+	}
+	first, second := state.Systems[0], state.Systems[1]
+	if first.State.ValueString() != actionStatusCompleted || first.ActionID.ValueInt64() != 7 || first.Rebooted.ValueString() != "2030-01-01T02:00:00Z" {
+		t.Errorf("expected the kickstart of system 1 to be completed, got %v", first)
+	}
+	if second.State.ValueString() != kickstartSessionNone || !second.ActionID.IsNull() {
+		t.Errorf("expected no kickstart of system 2 since the date, got %v", second)
+	}
+}
+
+func TestAccKickstartSessionStatusDataSource(t *testing.T) {
+	minion := os.Getenv("UYUNI_TEST_MINION_ID")
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccRealServerPreCheck(t)
+			testAccSkipUnlessEnv(t, "UYUNI_TEST_MINION_ID", "a registered Salt minion")
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				// The minion is not being reinstalled.
+				Config: fmt.Sprintf(`data "uyuni_kickstart_session_status" "test" { system_ids = [%s] }`, minion),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.uyuni_kickstart_session_status.test", "systems.0.system_id", minion),
+					resource.TestCheckResourceAttr("data.uyuni_kickstart_session_status.test", "systems.0.state", "none"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,71 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Subsystems of the provider logs. Their levels can be set separately from
+// the provider with TF_LOG_PROVIDER_UYUNI_CLIENT and the like.
+const (
+	logSubsystemClient  = "client"
+	logSubsystemUser    = "user"
+	logSubsystemChannel = "channel"
+	logSubsystemSystem  = "system"
+)
+
+// logSubsystemsByNamespace maps API namespaces to the subsystem their calls
+// are logged to. Calls of other namespaces are logged to the client one.
+var logSubsystemsByNamespace = map[string]string{
+	"user":              logSubsystemUser,
+	"org":               logSubsystemUser,
+	"channel":           logSubsystemChannel,
+	"configchannel":     logSubsystemChannel,
+	"contentmanagement": logSubsystemChannel,
+	"errata":            logSubsystemChannel,
+	"system":            logSubsystemSystem,
+	"systemgroup":       logSubsystemSystem,
+	"schedule":          logSubsystemSystem,
+	"recurring":         logSubsystemSystem,
+	"actionchain":       logSubsystemSystem,
+}
+
+// logSubsystem returns the subsystem API calls of the path are logged to.
+func logSubsystem(path string) string {
+	namespace, _, _ := strings.Cut(path, "/")
+	if subsystem, ok := logSubsystemsByNamespace[namespace]; ok {
+		return subsystem
+	}
+	return logSubsystemClient
+}
+
+// withLogSubsystem returns ctx with the subsystem set up, which tflog needs
+// before logging to it.
+func withLogSubsystem(ctx context.Context, subsystem string) context.Context {
+	return tflog.NewSubsystem(ctx, subsystem, tflog.WithLevelFromEnv("TF_LOG_PROVIDER_UYUNI", strings.ToUpper(subsystem)))
+}
+
+// logAPICall logs a call sent to the server with its duration and the sizes
+// of its payloads, if the provider enables debug logging. Payloads are never
+// logged as they hold passwords and keys.
+func (c *uyuniClient) logAPICall(ctx context.Context, method, path string, requestBytes, responseBytes, status int, duration time.Duration, err error) {
+	if !c.debug {
+		return
+	}
+	subsystem := logSubsystem(path)
+	fields := map[string]interface{}{
+		"method":         method,
+		"path":           path,
+		"status":         status,
+		"duration_ms":    duration.Milliseconds(),
+		"request_bytes":  requestBytes,
+		"response_bytes": responseBytes,
+	}
+	if err != nil {
+		fields["error"] = err.Error()
+	}
+	tflog.SubsystemDebug(withLogSubsystem(ctx, subsystem), subsystem, "Uyuni API call", fields)
+}
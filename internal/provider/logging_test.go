@@ -0,0 +1,61 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+func TestLogSubsystem(t *testing.T) {
+	for path, want := range map[string]string{
+		"user/getDetails?login=admin":          logSubsystemUser,
+		"channel/software/listErrata":          logSubsystemChannel,
+		"systemgroup/listSystemsMinimal":       logSubsystemSystem,
+		"system/getRelevantErrata?sid=1000001": logSubsystemSystem,
+		"api/systemVersion":                    logSubsystemClient,
+		"systems":                              logSubsystemClient,
+	} {
+		if got := logSubsystem(path); got != want {
+			t.Errorf("%s: got %s, want %s", path, got, want)
+		}
+	}
+}
+
+func TestLogAPICall(t *testing.T) {
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+
+	client := &uyuniClient{}
+	client.logAPICall(ctx, http.MethodPost, "user/create", 64, 20, http.StatusOK, time.Second, nil)
+	if output.Len() != 0 {
+		t.Fatalf("expected no logs without debug, got %s", output.String())
+	}
+
+	client.debug = true
+	client.logAPICall(ctx, http.MethodPost, "user/create", 64, 0, http.StatusBadRequest, 1500*time.Millisecond, errors.New("Login already in use"))
+	entries, err := tflogtest.MultilineJSONDecode(&output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("expected one log entry, got %v", entries)
+	}
+	entry := entries[0]
+	for key, want := range map[string]interface{}{
+		"@module":        "provider.user",
+		"path":           "user/create",
+		"duration_ms":    float64(1500),
+		"request_bytes":  float64(64),
+		"response_bytes": float64(0),
+		"error":          "Login already in use",
+	} {
+		if entry[key] != want {
+			t.Errorf("%s: got %v, want %v", key, entry[key], want)
+		}
+	}
+}
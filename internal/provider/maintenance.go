@@ -0,0 +1,474 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"terraform-provider-uyuni/internal/uyuni"
+	"terraform-provider-uyuni/internal/validators"
+)
+
+// maintenanceOccurrenceLimit bounds the occurrences of a recurring event that
+// are examined when looking for the next maintenance window.
+const maintenanceOccurrenceLimit = 100000
+
+// maintenanceWindowStart returns the earliest date from now on at which an
+// action restricted to maintenance windows may run on the systems: now if
+// none of them has a maintenance schedule or the window is open, otherwise
+// the start of the next window. All systems with a schedule must share it.
+func maintenanceWindowStart(ctx context.Context, client *uyuniClient, sids []int64, now time.Time) (time.Time, error) {
+	name, _, err := maintenanceSchedule(ctx, client, sids)
+	if err != nil || name == "" {
+		return now, err
+	}
+	return scheduleWindowStart(ctx, client, name, now)
+}
+
+// maintenanceWindowOpen returns an error unless a maintenance window of the
+// systems is open at now. All systems must share a maintenance schedule.
+func maintenanceWindowOpen(ctx context.Context, client *uyuniClient, sids []int64, now time.Time) error {
+	name, unscheduled, err := maintenanceSchedule(ctx, client, sids)
+	if err != nil {
+		return err
+	}
+	if len(unscheduled) > 0 {
+		return fmt.Errorf("systems %v have no maintenance schedule, so they have no maintenance windows", unscheduled)
+	}
+	start, err := scheduleWindowStart(ctx, client, name, now)
+	if err != nil {
+		return err
+	}
+	if start.After(now) {
+		return fmt.Errorf("the next maintenance window of schedule %s starts at %s", name, start.Format(time.RFC3339))
+	}
+	return nil
+}
+
+// maintenanceSchedule returns the maintenance schedule shared by the systems
+// having one, empty if none has, and the systems without one.
+func maintenanceSchedule(ctx context.Context, client *uyuniClient, sids []int64) (string, []int64, error) {
+	targeted := map[int64]bool{}
+	for _, sid := range sids {
+		targeted[sid] = true
+	}
+
+	names, err := apiGet[[]string](ctx, client, "maintenance/listScheduleNames")
+	if err != nil {
+		return "", nil, fmt.Errorf("could not list maintenance schedules: %w", err)
+	}
+	sort.Strings(names.Result)
+	scheduled := map[string]int64{}
+	covered := map[int64]bool{}
+	for _, name := range names.Result {
+		systems, err := apiGet[[]int64](ctx, client, "maintenance/listSystemsWithSchedule?scheduleName="+url.QueryEscape(name))
+		if err != nil {
+			return "", nil, fmt.Errorf("could not list the systems of maintenance schedule %s: %w", name, err)
+		}
+		for _, sid := range systems.Result {
+			if !targeted[sid] {
+				continue
+			}
+			if _, ok := scheduled[name]; !ok {
+				scheduled[name] = sid
+			}
+			covered[sid] = true
+		}
+	}
+	var unscheduled []int64
+	for _, sid := range sids {
+		if !covered[sid] {
+			unscheduled = append(unscheduled, sid)
+		}
+	}
+	if len(scheduled) > 1 {
+		var systems []string
+		for name, sid := range scheduled {
+			systems = append(systems, fmt.Sprintf("system %d uses %s", sid, name))
+		}
+		sort.Strings(systems)
+		return "", nil, fmt.Errorf("the systems have different maintenance schedules (%s), target them separately", strings.Join(systems, ", "))
+	}
+	for name := range scheduled {
+		return name, unscheduled, nil
+	}
+	return "", unscheduled, nil
+}
+
+// scheduleWindowStart returns now if a window of the maintenance schedule is
+// open, otherwise the start of the next window.
+func scheduleWindowStart(ctx context.Context, client *uyuniClient, name string, now time.Time) (time.Time, error) {
+	schedule, err := apiGet[uyuni.MaintenanceSchedule](ctx, client, "maintenance/getScheduleDetails?name="+url.QueryEscape(name))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("could not read maintenance schedule %s: %w", name, err)
+	}
+	if schedule.Result.Calendar == "" {
+		return time.Time{}, fmt.Errorf("maintenance schedule %s has no calendar, so it has no maintenance windows", name)
+	}
+	calendar, err := apiGet[uyuni.MaintenanceCalendar](ctx, client, "maintenance/getCalendarDetails?label="+url.QueryEscape(schedule.Result.Calendar))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("could not read maintenance calendar %s: %w", schedule.Result.Calendar, err)
+	}
+
+	// The events of calendars shared by several schedules are assigned to
+	// them by their summary.
+	summary := ""
+	if strings.EqualFold(schedule.Result.Type, "multi") {
+		summary = name
+	}
+	start, err := nextICalWindow(calendar.Result.ICal, summary, now)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("maintenance calendar %s of schedule %s: %w", calendar.Result.Label, name, err)
+	}
+	return start, nil
+}
+
+// icalEvent is an event of a maintenance calendar. Its occurrences are the
+// maintenance windows.
+type icalEvent struct {
+	summary  string
+	start    time.Time
+	duration time.Duration
+	rrule    map[string]string
+	exdates  []time.Time
+}
+
+// nextICalWindow returns now if a window of the calendar is open, otherwise
+// the start of the next one. With a summary, only the events having it are
+// windows.
+func nextICalWindow(document, summary string, now time.Time) (time.Time, error) {
+	events, err := parseICalEvents(document)
+	if err != nil {
+		return time.Time{}, err
+	}
+	var next time.Time
+	for _, event := range events {
+		if summary != "" && event.summary != summary {
+			continue
+		}
+		start, ok, err := event.next(now)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if ok && (next.IsZero() || start.Before(next)) {
+			next = start
+		}
+	}
+	if next.IsZero() {
+		return time.Time{}, errors.New("no upcoming maintenance window")
+	}
+	if next.Before(now) {
+		return now, nil
+	}
+	return next, nil
+}
+
+// parseICalEvents returns the events of an iCalendar document.
+func parseICalEvents(document string) ([]icalEvent, error) {
+	var events []icalEvent
+	var event *icalEvent
+	var end time.Time
+	allDay := false
+	for _, line := range validators.UnfoldICal(document) {
+		name, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		name, params, _ := strings.Cut(name, ";")
+		name = strings.ToUpper(name)
+
+		switch {
+		case name == "BEGIN" && strings.EqualFold(value, "VEVENT"):
+			event, end, allDay = &icalEvent{}, time.Time{}, false
+		case event == nil:
+		case name == "END" && strings.EqualFold(value, "VEVENT"):
+			if event.start.IsZero() {
+				return nil, fmt.Errorf("event %q has no DTSTART", event.summary)
+			}
+			if !end.IsZero() {
+				event.duration = end.Sub(event.start)
+			} else if event.duration == 0 && allDay {
+				event.duration = 24 * time.Hour
+			}
+			events = append(events, *event)
+			event = nil
+		case name == "SUMMARY":
+			event.summary = value
+		case name == "DTSTART":
+			start, err := parseICalTime(params, value)
+			if err != nil {
+				return nil, err
+			}
+			event.start = start
+			allDay = len(value) == 8
+		case name == "DTEND":
+			t, err := parseICalTime(params, value)
+			if err != nil {
+				return nil, err
+			}
+			end = t
+		case name == "DURATION":
+			duration, err := parseICalDuration(value)
+			if err != nil {
+				return nil, err
+			}
+			event.duration = duration
+		case name == "RRULE":
+			event.rrule = map[string]string{}
+			for _, part := range strings.Split(value, ";") {
+				key, val, _ := strings.Cut(part, "=")
+				event.rrule[strings.ToUpper(key)] = strings.ToUpper(val)
+			}
+		case name == "EXDATE":
+			for _, date := range strings.Split(value, ",") {
+				t, err := parseICalTime(params, date)
+				if err != nil {
+					return nil, err
+				}
+				event.exdates = append(event.exdates, t)
+			}
+		}
+	}
+	return events, nil
+}
+
+// parseICalTime parses a DATE or DATE-TIME value. Values without a UTC
+// designator are in the time zone of the TZID parameter, or else in UTC.
+func parseICalTime(params, value string) (time.Time, error) {
+	location := time.UTC
+	for _, param := range strings.Split(params, ";") {
+		key, val, _ := strings.Cut(param, "=")
+		if strings.EqualFold(key, "TZID") {
+			tz, err := time.LoadLocation(strings.Trim(val, `"`))
+			if err != nil {
+				return time.Time{}, fmt.Errorf("unknown time zone %s", val)
+			}
+			location = tz
+		}
+	}
+
+	var t time.Time
+	var err error
+	switch {
+	case strings.HasSuffix(value, "Z"):
+		t, err = time.Parse("20060102T150405Z", value)
+	case len(value) == 8:
+		t, err = time.ParseInLocation("20060102", value, location)
+	default:
+		t, err = time.ParseInLocation("20060102T150405", value, location)
+	}
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q", value)
+	}
+	return t, nil
+}
+
+var icalDurationPattern = regexp.MustCompile(`^P(?:(\d+)W)?(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+)S)?)?$`)
+
+// parseICalDuration parses a positive DURATION value like PT2H30M.
+func parseICalDuration(value string) (time.Duration, error) {
+	match := icalDurationPattern.FindStringSubmatch(strings.TrimPrefix(value, "+"))
+	if match == nil || value == "P" {
+		return 0, fmt.Errorf("invalid duration %q", value)
+	}
+	units := []time.Duration{7 * 24 * time.Hour, 24 * time.Hour, time.Hour, time.Minute, time.Second}
+	var duration time.Duration
+	for i, unit := range units {
+		if match[i+1] != "" {
+			n, _ := strconv.Atoi(match[i+1])
+			duration += time.Duration(n) * unit
+		}
+	}
+	return duration, nil
+}
+
+// icalWeekdays maps the weekdays of BYDAY rules.
+var icalWeekdays = map[string]time.Weekday{
+	"SU": time.Sunday,
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+}
+
+// next returns the start of the first occurrence of the event that did not
+// end before now, and whether there is one.
+func (e icalEvent) next(now time.Time) (time.Time, bool, error) {
+	var until time.Time
+	if value := e.rrule["UNTIL"]; value != "" {
+		t, err := parseICalTime("", value)
+		if err != nil {
+			return time.Time{}, false, err
+		}
+		until = t
+	}
+	count := -1
+	if value := e.rrule["COUNT"]; value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("invalid COUNT %q", value)
+		}
+		count = n
+	}
+
+	var next time.Time
+	found := false
+	err := e.occurrences(func(start time.Time) bool {
+		if (!until.IsZero() && start.After(until)) || count == 0 {
+			return false
+		}
+		count--
+		if e.excluded(start) {
+			return true
+		}
+		if start.Add(e.duration).After(now) {
+			next, found = start, true
+			return false
+		}
+		return true
+	})
+	return next, found, err
+}
+
+// excluded reports whether the occurrence starting at start is an EXDATE.
+func (e icalEvent) excluded(start time.Time) bool {
+	for _, exdate := range e.exdates {
+		if exdate.Equal(start) {
+			return true
+		}
+	}
+	return false
+}
+
+// occurrences calls fn with the starts of the occurrences of the event in
+// order, until fn returns false or the occurrence limit is reached.
+func (e icalEvent) occurrences(fn func(time.Time) bool) error {
+	if e.rrule == nil {
+		fn(e.start)
+		return nil
+	}
+	interval := 1
+	if value := e.rrule["INTERVAL"]; value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 {
+			return fmt.Errorf("invalid INTERVAL %q", value)
+		}
+		interval = n
+	}
+	var byDay []string
+	if value := e.rrule["BYDAY"]; value != "" {
+		byDay = strings.Split(value, ",")
+	}
+
+	// period returns the occurrences of the i-th period of the rule, in order.
+	var period func(i int) ([]time.Time, error)
+	switch freq := e.rrule["FREQ"]; {
+	case freq == "DAILY":
+		period = func(i int) ([]time.Time, error) {
+			return []time.Time{e.start.AddDate(0, 0, i*interval)}, nil
+		}
+	case freq == "WEEKLY" && byDay == nil:
+		period = func(i int) ([]time.Time, error) {
+			return []time.Time{e.start.AddDate(0, 0, 7*i*interval)}, nil
+		}
+	case freq == "WEEKLY":
+		// Weeks start on Monday.
+		monday := e.start.AddDate(0, 0, -((int(e.start.Weekday()) + 6) % 7))
+		period = func(i int) ([]time.Time, error) {
+			week := monday.AddDate(0, 0, 7*i*interval)
+			var starts []time.Time
+			for _, day := range byDay {
+				weekday, ok := icalWeekdays[day]
+				if !ok {
+					return nil, fmt.Errorf("invalid BYDAY %q", day)
+				}
+				starts = append(starts, week.AddDate(0, 0, (int(weekday)+6)%7))
+			}
+			sort.Slice(starts, func(i, j int) bool { return starts[i].Before(starts[j]) })
+			return starts, nil
+		}
+	case freq == "MONTHLY" && byDay == nil:
+		period = func(i int) ([]time.Time, error) {
+			start := e.start.AddDate(0, i*interval, 0)
+			// Months lacking the day of the month have no occurrence.
+			if start.Day() != e.start.Day() {
+				return nil, nil
+			}
+			return []time.Time{start}, nil
+		}
+	case freq == "MONTHLY":
+		first := time.Date(e.start.Year(), e.start.Month(), 1, e.start.Hour(), e.start.Minute(), e.start.Second(), 0, e.start.Location())
+		period = func(i int) ([]time.Time, error) {
+			month := first.AddDate(0, i*interval, 0)
+			var starts []time.Time
+			for _, day := range byDay {
+				start, ok, err := nthWeekday(month, day)
+				if err != nil {
+					return nil, err
+				}
+				if ok {
+					starts = append(starts, start)
+				}
+			}
+			sort.Slice(starts, func(i, j int) bool { return starts[i].Before(starts[j]) })
+			return starts, nil
+		}
+	case freq == "YEARLY" && byDay == nil:
+		period = func(i int) ([]time.Time, error) {
+			return []time.Time{e.start.AddDate(i*interval, 0, 0)}, nil
+		}
+	default:
+		return fmt.Errorf("unsupported recurrence rule %q", e.rrule["FREQ"])
+	}
+
+	for i, examined := 0, 0; examined < maintenanceOccurrenceLimit; i++ {
+		starts, err := period(i)
+		if err != nil {
+			return err
+		}
+		examined++
+		for _, start := range starts {
+			if start.Before(e.start) {
+				continue
+			}
+			if !fn(start) {
+				return nil
+			}
+		}
+	}
+	return nil
+}
+
+// nthWeekday returns the occurrence of a monthly BYDAY value like 2TU or -1SU
+// in the month starting at first. Values without an ordinal are not
+// supported for monthly rules.
+func nthWeekday(first time.Time, day string) (time.Time, bool, error) {
+	if len(day) < 3 {
+		return time.Time{}, false, fmt.Errorf("invalid BYDAY %q", day)
+	}
+	weekday, ok := icalWeekdays[day[len(day)-2:]]
+	n, err := strconv.Atoi(day[:len(day)-2])
+	if !ok || err != nil || n == 0 || n > 5 || n < -5 {
+		return time.Time{}, false, fmt.Errorf("unsupported BYDAY %q", day)
+	}
+
+	var date time.Time
+	if n > 0 {
+		date = first.AddDate(0, 0, (int(weekday)-int(first.Weekday())+7)%7+7*(n-1))
+	} else {
+		last := first.AddDate(0, 1, -1)
+		date = last.AddDate(0, 0, -((int(last.Weekday())-int(weekday)+7)%7 + 7*(-n-1)))
+	}
+	if date.Month() != first.Month() {
+		return time.Time{}, false, nil
+	}
+	return date, true, nil
+}
@@ -0,0 +1,157 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNextICalWindow(t *testing.T) {
+	const calendar = "BEGIN:VCALENDAR\r\nVERSION:2.0\r\n%s\r\nEND:VCALENDAR\r\n"
+	tests := map[string]struct {
+		events  string
+		summary string
+		now     string
+		want    string
+	}{
+		"single event": {
+			events: "BEGIN:VEVENT\r\nDTSTART:20240301T220000Z\r\nDTEND:20240302T020000Z\r\nEND:VEVENT",
+			now:    "2024-02-01T00:00:00Z",
+			want:   "2024-03-01T22:00:00Z",
+		},
+		"open window": {
+			events: "BEGIN:VEVENT\r\nDTSTART:20240301T220000Z\r\nDURATION:PT4H\r\nEND:VEVENT",
+			now:    "2024-03-01T23:00:00Z",
+			want:   "2024-03-01T23:00:00Z",
+		},
+		"weekly on weekdays in a time zone": {
+			events: "BEGIN:VEVENT\r\nDTSTART;TZID=Europe/Berlin:20240101T220000\r\nDTEND;TZID=Europe/Berlin:20240101T230000\r\n" +
+				"RRULE:FREQ=WEEKLY;BYDAY=TU,TH\r\nEND:VEVENT",
+			now:  "2024-07-04T22:00:00Z",
+			want: "2024-07-09T20:00:00Z",
+		},
+		"second tuesday of the month": {
+			events: "BEGIN:VEVENT\r\nDTSTART:20240109T180000Z\r\nDTEND:20240109T200000Z\r\nRRULE:FREQ=MONTHLY;BYDAY=2TU\r\nEND:VEVENT",
+			now:    "2024-07-10T00:00:00Z",
+			want:   "2024-08-13T18:00:00Z",
+		},
+		"last sunday of the month": {
+			events: "BEGIN:VEVENT\r\nDTSTART:20240128T020000Z\r\nDTEND:20240128T040000Z\r\nRRULE:FREQ=MONTHLY;BYDAY=-1SU\r\nEND:VEVENT",
+			now:    "2024-03-01T00:00:00Z",
+			want:   "2024-03-31T02:00:00Z",
+		},
+		"excluded occurrence": {
+			events: "BEGIN:VEVENT\r\nDTSTART:20240101T020000Z\r\nDTEND:20240101T030000Z\r\nRRULE:FREQ=DAILY;INTERVAL=2\r\n" +
+				"EXDATE:20240105T020000Z\r\nEND:VEVENT",
+			now:  "2024-01-04T00:00:00Z",
+			want: "2024-01-07T02:00:00Z",
+		},
+		"earliest of several events": {
+			events: "BEGIN:VEVENT\r\nDTSTART:20240101T020000Z\r\nDTEND:20240101T030000Z\r\nRRULE:FREQ=WEEKLY\r\nEND:VEVENT\r\n" +
+				"BEGIN:VEVENT\r\nDTSTART:20240103T020000Z\r\nDTEND:20240103T030000Z\r\nRRULE:FREQ=WEEKLY\r\nEND:VEVENT",
+			now:  "2024-01-09T00:00:00Z",
+			want: "2024-01-10T02:00:00Z",
+		},
+		"events of the schedule": {
+			events: "BEGIN:VEVENT\r\nSUMMARY:web\r\nDTSTART:20240101T020000Z\r\nDTEND:20240101T030000Z\r\nRRULE:FREQ=DAILY\r\nEND:VEVENT\r\n" +
+				"BEGIN:VEVENT\r\nSUMMARY:sap\r\nDTSTART:20240106T220000Z\r\nDTEND:20240107T040000Z\r\nRRULE:FREQ=WEEKLY\r\nEND:VEVENT",
+			summary: "sap",
+			now:     "2024-01-08T00:00:00Z",
+			want:    "2024-01-13T22:00:00Z",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			now, _ := time.Parse(time.RFC3339, tt.now)
+			got, err := nextICalWindow(strings.Replace(calendar, "%s", tt.events, 1), tt.summary, now)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.UTC().Format(time.RFC3339) != tt.want {
+				t.Errorf("got %s, want %s", got.UTC().Format(time.RFC3339), tt.want)
+			}
+		})
+	}
+}
+
+func TestNextICalWindowErrors(t *testing.T) {
+	now, _ := time.Parse(time.RFC3339, "2024-07-01T00:00:00Z")
+	tests := map[string]struct {
+		events string
+		want   string
+	}{
+		"past windows": {
+			events: "BEGIN:VEVENT\r\nDTSTART:20240101T020000Z\r\nDTEND:20240101T030000Z\r\nRRULE:FREQ=DAILY;COUNT=3\r\nEND:VEVENT",
+			want:   "no upcoming maintenance window",
+		},
+		"unsupported rule": {
+			events: "BEGIN:VEVENT\r\nDTSTART:20240101T020000Z\r\nDTEND:20240101T030000Z\r\nRRULE:FREQ=HOURLY\r\nEND:VEVENT",
+			want:   "unsupported recurrence rule",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := nextICalWindow("BEGIN:VCALENDAR\r\n"+tt.events+"\r\nEND:VCALENDAR\r\n", "", now)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("expected %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestMaintenanceWindowStart(t *testing.T) {
+	ctx := context.Background()
+	now, _ := time.Parse(time.RFC3339, "2024-07-01T00:00:00Z")
+	client := testAPIClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/maintenance/listScheduleNames":
+			_, _ = w.Write([]byte(`{"success": true, "result": ["sap", "web"]}`))
+		case "/maintenance/listSystemsWithSchedule":
+			if r.URL.Query().Get("scheduleName") == "sap" {
+				_, _ = w.Write([]byte(`{"success": true, "result": [1000010001]}`))
+			} else {
+				_, _ = w.Write([]byte(`{"success": true, "result": [1000010002]}`))
+			}
+		case "/maintenance/getScheduleDetails":
+			_, _ = w.Write([]byte(`{"success": true, "result": {"id": 1, "name": "sap", "type": "multi", "calendar": "corporate"}}`))
+		case "/maintenance/getCalendarDetails":
+			_, _ = w.Write([]byte(`{"success": true, "result": {"id": 1, "label": "corporate", "ical": "BEGIN:VCALENDAR\r\n` +
+				`BEGIN:VEVENT\r\nSUMMARY:web\r\nDTSTART:20240101T020000Z\r\nDTEND:20240101T030000Z\r\nRRULE:FREQ=DAILY\r\nEND:VEVENT\r\n` +
+				`BEGIN:VEVENT\r\nSUMMARY:sap\r\nDTSTART:20240106T220000Z\r\nDTEND:20240107T040000Z\r\nRRULE:FREQ=WEEKLY\r\nEND:VEVENT\r\n` +
+				`END:VCALENDAR\r\n"}}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL)
+		}
+	})
+
+	start, err := maintenanceWindowStart(ctx, client, []int64{1000010001, 1000010003}, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if start.UTC().Format(time.RFC3339) != "2024-07-06T22:00:00Z" {
+		t.Errorf("expected the next window of the sap schedule, got %s", start)
+	}
+
+	// Systems without a schedule are not restricted.
+	start, err = maintenanceWindowStart(ctx, client, []int64{1000010003}, now)
+	if err != nil || !start.Equal(now) {
+		t.Errorf("expected now, got %s, %v", start, err)
+	}
+
+	_, err = maintenanceWindowStart(ctx, client, []int64{1000010001, 1000010002}, now)
+	if err == nil || !strings.Contains(err.Error(), "different maintenance schedules") {
+		t.Errorf("expected the schedules to conflict, got %v", err)
+	}
+	// Destroys need an open window of all systems.
+	if err := maintenanceWindowOpen(ctx, client, []int64{1000010001}, now); err == nil || !strings.Contains(err.Error(), "starts at 2024-07-06T22:00:00Z") {
+		t.Errorf("expected the window to be closed, got %v", err)
+	}
+	if err := maintenanceWindowOpen(ctx, client, []int64{1000010001}, now.Add(143*time.Hour)); err != nil {
+		t.Errorf("expected the window to be open, got %v", err)
+	}
+	if err := maintenanceWindowOpen(ctx, client, []int64{1000010001, 1000010003}, now.Add(143*time.Hour)); err == nil || !strings.Contains(err.Error(), "[1000010003] have no maintenance schedule") {
+		t.Errorf("expected systems without a schedule to be refused, got %v", err)
+	}
+}
@@ -0,0 +1,77 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/uyuni-project/uyuni-tools/shared/api"
+	"github.com/uyuni-project/uyuni-tools/shared/utils"
+)
+
+// mgrctlFlags maps the configuration of the mgrctl api commands.
+type mgrctlFlags struct {
+	api.ConnectionDetails `mapstructure:"api"`
+}
+
+// mgrctlConfigFiles returns the configuration files mgrctl reads. Later files
+// override earlier ones.
+func mgrctlConfigFiles() []string {
+	files := []string{utils.GlobalConfigFilename}
+	configHome := os.Getenv("XDG_CONFIG_HOME")
+	if configHome == "" {
+		if home, err := os.UserHomeDir(); err == nil {
+			configHome = filepath.Join(home, ".config")
+		}
+	}
+	if configHome != "" {
+		files = append(files, filepath.Join(configHome, "uyuni-tools", "config.yaml"))
+	}
+	return files
+}
+
+// readMgrctlConfig returns the API connection mgrctl uses, read with the
+// uyuni-tools configuration loader from the configuration files and the
+// UYUNI_API_* environment variables. Missing mgrctl files are skipped, the
+// extra YAML file overrides them if set.
+func readMgrctlConfig(extra string) (*api.ConnectionDetails, error) {
+	cmd := &cobra.Command{}
+	if err := api.AddAPIFlags(cmd, true); err != nil {
+		return nil, err
+	}
+	// The loader binds the local flags only.
+	cmd.Flags().AddFlagSet(cmd.PersistentFlags())
+
+	v, err := utils.ReadConfig(cmd, mgrctlConfigFiles()...)
+	if err != nil {
+		return nil, err
+	}
+	// The loader guesses the format from the extension, which files like
+	// ~/.config/uyuni/credentials lack.
+	if extra != "" {
+		v.SetConfigFile(extra)
+		v.SetConfigType("yaml")
+		if err := v.MergeInConfig(); err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", extra, err)
+		}
+	}
+	var flags mgrctlFlags
+	if err := v.Unmarshal(&flags); err != nil {
+		return nil, err
+	}
+	return &flags.ConnectionDetails, nil
+}
+
+// expandHome replaces a leading ~ of the path with the home directory.
+func expandHome(file string) string {
+	if file != "~" && !strings.HasPrefix(file, "~/") {
+		return file
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return file
+	}
+	return filepath.Join(home, file[1:])
+}
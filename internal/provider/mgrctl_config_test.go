@@ -0,0 +1,45 @@
+package provider
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestReadMgrctlConfig(t *testing.T) {
+	configHome := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", configHome)
+	t.Setenv("UYUNI_API_PASSWORD", "")
+	if err := os.MkdirAll(filepath.Join(configHome, "uyuni-tools"), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	config := "api:\n  server: uyuni.example.com\n  user: admin\n  password: secret\n"
+	if err := os.WriteFile(filepath.Join(configHome, "uyuni-tools", "config.yaml"), []byte(config), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	conn, err := readMgrctlConfig("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if conn.Server != "uyuni.example.com" || conn.User != "admin" || conn.Password != "secret" {
+		t.Errorf("unexpected connection %+v", conn)
+	}
+
+	// The extra file overrides the mgrctl configuration.
+	credentials := filepath.Join(t.TempDir(), "credentials")
+	if err := os.WriteFile(credentials, []byte("api:\n  user: terraform\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	conn, err = readMgrctlConfig(credentials)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if conn.Server != "uyuni.example.com" || conn.User != "terraform" || conn.Password != "secret" {
+		t.Errorf("unexpected connection %+v", conn)
+	}
+
+	if _, err := readMgrctlConfig(filepath.Join(configHome, "missing")); err == nil {
+		t.Error("expected an error for a missing file")
+	}
+}
@@ -0,0 +1,229 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"terraform-provider-uyuni/internal/uyuni"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &MinionPillarDataSource{}
+	_ datasource.DataSourceWithConfigure = &MinionPillarDataSource{}
+)
+
+// MinionPillarDataSourceModel maps the data source schema data.
+type MinionPillarDataSourceModel struct {
+	SystemID    types.Int64  `tfsdk:"system_id"`
+	GroupIDs    types.Set    `tfsdk:"group_ids"`
+	CustomInfo  types.Map    `tfsdk:"custom_info"`
+	FormulaData types.Map    `tfsdk:"formula_data"`
+	Pillar      types.String `tfsdk:"pillar"`
+}
+
+// NewMinionPillarDataSource is a helper function to simplify the provider implementation.
+func NewMinionPillarDataSource() datasource.DataSource {
+	return &MinionPillarDataSource{}
+}
+
+// MinionPillarDataSource is the data source implementation.
+type MinionPillarDataSource struct {
+	client *uyuniClient
+}
+
+// Metadata returns the data source type name.
+func (d *MinionPillarDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_minion_pillar"
+}
+
+// Schema defines the schema for the data source.
+func (d *MinionPillarDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Reads the pillar data Uyuni renders for a Salt minion: the data of the formulas of the system and " +
+			"its groups, its custom info values and its groups. Pillar data of the server itself, e.g. channel tokens, " +
+			"is not exposed by the API and left out.",
+		Attributes: map[string]schema.Attribute{
+			"system_id": schema.Int64Attribute{
+				Description: "ID of the system.",
+				Required:    true,
+			},
+			"group_ids": schema.SetAttribute{
+				Description: "IDs of the groups of the system, rendered as the `group_ids` pillar.",
+				ElementType: types.Int64Type,
+				Computed:    true,
+			},
+			"custom_info": schema.MapAttribute{
+				Description: "Custom info values of the system by key, rendered as the `custom_info` pillar.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"formula_data": schema.MapAttribute{
+				Description: "JSON encoded data of the formulas assigned to the system or its groups by formula, " +
+					"the group data merged with the system data.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"pillar": schema.StringAttribute{
+				Description: "JSON encoded pillar combining the above as Salt sees it, the formula data merged at the top level.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// systemFormulas returns the formulas assigned to the system or its groups,
+// sorted, and the IDs of its groups.
+func systemFormulas(ctx context.Context, client *uyuniClient, sid int64) ([]string, []int64, error) {
+	assigned := map[string]bool{}
+	formulas, err := formulaTarget{systemID: sid}.formulas(ctx, client)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not read formulas of system %d: %w", sid, err)
+	}
+	for _, formula := range formulas {
+		assigned[formula] = true
+	}
+
+	groups, err := apiGet[[]uyuni.SystemGroupMembership](ctx, client, fmt.Sprintf("system/listGroups?sid=%d", sid))
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not list groups of system %d: %w", sid, err)
+	}
+	groupIDs := []int64{}
+	for _, group := range groups.Result {
+		if group.Subscribed != 1 {
+			continue
+		}
+		groupIDs = append(groupIDs, int64(group.ID))
+		formulas, err := formulaTarget{groupID: int64(group.ID)}.formulas(ctx, client)
+		if err != nil {
+			return nil, nil, fmt.Errorf("could not read formulas of group %s: %w", group.SystemGroupName, err)
+		}
+		for _, formula := range formulas {
+			assigned[formula] = true
+		}
+	}
+
+	names := make([]string, 0, len(assigned))
+	for formula := range assigned {
+		names = append(names, formula)
+	}
+	sort.Strings(names)
+	return names, groupIDs, nil
+}
+
+// combinedFormulaData returns the data of the formula for the system, the
+// group data merged with the system data.
+func combinedFormulaData(ctx context.Context, client *uyuniClient, sid int64, formula string) (map[string]interface{}, error) {
+	data, err := apiPost[[]uyuni.FormulaData](ctx, client, "formula/getCombinedFormulaDataByServerIds", map[string]interface{}{
+		"formulaName": formula,
+		"systemIDs":   []int64{sid},
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range data.Result {
+		if int64(entry.SystemID) == sid {
+			return entry.FormulaValues, nil
+		}
+	}
+	return map[string]interface{}{}, nil
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *MinionPillarDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state MinionPillarDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	sid := state.SystemID.ValueInt64()
+	formulas, groupIDs, err := systemFormulas(ctx, d.client, sid)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to Read Uyuni minion pillar", err.Error())
+		return
+	}
+	customInfo, err := apiGet[map[string]string](ctx, d.client, fmt.Sprintf("system/getCustomValues?sid=%d", sid))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Uyuni minion pillar",
+			fmt.Sprintf("Could not read custom values of system %d: %s", sid, err),
+		)
+		return
+	}
+
+	pillar := map[string]interface{}{}
+	formulaData := map[string]string{}
+	for _, formula := range formulas {
+		values, err := combinedFormulaData(ctx, d.client, sid, formula)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Read Uyuni minion pillar",
+				fmt.Sprintf("Could not read %s formula data of system %d: %s", formula, sid, err),
+			)
+			return
+		}
+		encoded, err := json.Marshal(values)
+		if err != nil {
+			resp.Diagnostics.AddError("Unable to Read Uyuni minion pillar", err.Error())
+			return
+		}
+		formulaData[formula] = string(encoded)
+		for key, value := range values {
+			pillar[key] = value
+		}
+	}
+	pillar["custom_info"] = customInfo.Result
+	pillar["group_ids"] = groupIDs
+	encoded, err := json.Marshal(pillar)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to Read Uyuni minion pillar", err.Error())
+		return
+	}
+
+	state.Pillar = types.StringValue(string(encoded))
+	state.GroupIDs, diags = types.SetValueFrom(ctx, types.Int64Type, groupIDs)
+	resp.Diagnostics.Append(diags...)
+	state.CustomInfo, diags = types.MapValueFrom(ctx, types.StringType, customInfo.Result)
+	resp.Diagnostics.Append(diags...)
+	state.FormulaData, diags = types.MapValueFrom(ctx, types.StringType, formulaData)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *MinionPillarDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*uyuniClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *uyuniClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
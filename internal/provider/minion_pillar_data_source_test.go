@@ -0,0 +1,95 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestMinionPillarDataSource(t *testing.T) {
+	ctx := context.Background()
+	client := testAPIClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/formula/getFormulasByServerId":
+			_, _ = w.Write([]byte(`{"success": true, "result": ["locale"]}`))
+		case "/formula/getFormulasByGroupId":
+			if r.URL.Query().Get("systemGroupId") != "5" {
+				t.Errorf("expected only the formulas of group 5, got %s", r.URL)
+			}
+			_, _ = w.Write([]byte(`{"success": true, "result": ["prometheus-exporters", "locale"]}`))
+		case "/system/listGroups":
+			_, _ = w.Write([]byte(`{"success": true, "result": [
+				{"id": 5, "subscribed": 1, "system_group_name": "web", "sgid": 5},
+				{"id": 6, "subscribed": 0, "system_group_name": "B042", "sgid": 6}
+			]}`))
+		case "/system/getCustomValues":
+			_, _ = w.Write([]byte(`{"success": true, "result": {"rack": "A1"}}`))
+		case "/formula/getCombinedFormulaDataByServerIds":
+			var body struct {
+				FormulaName string  `json:"formulaName"`
+				SystemIDs   []int64 `json:"systemIDs"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			values := `{"timezone": {"name": "Europe/Berlin"}}`
+			if body.FormulaName == "prometheus-exporters" {
+				values = `{"exporters": {"node_exporter": {"enabled": true}}}`
+			}
+			_, _ = w.Write([]byte(`{"success": true, "result": [{"system_id": 1000010001, "formula_values": ` + values + `}]}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL)
+		}
+	})
+
+	d := NewMinionPillarDataSource()
+	d.(datasource.DataSourceWithConfigure).Configure(ctx, datasource.ConfigureRequest{ProviderData: client}, &datasource.ConfigureResponse{})
+	var schemaResp datasource.SchemaResponse
+	d.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	values := map[string]tftypes.Value{}
+	for name, attributeType := range objectType.AttributeTypes {
+		values[name] = tftypes.NewValue(attributeType, nil)
+	}
+	values["system_id"] = tftypes.NewValue(tftypes.Number, 1000010001)
+	config := tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, values)}
+	resp := &datasource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: config.Raw}}
+	d.Read(ctx, datasource.ReadRequest{Config: config}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+
+	var state MinionPillarDataSourceModel
+	resp.State.Get(ctx, &state)
+	if len(state.FormulaData.Elements()) != 2 || len(state.GroupIDs.Elements()) != 1 {
+		t.Errorf("expected 2 formulas and 1 group, got %v and %v", state.FormulaData, state.GroupIDs)
+	}
+	want := `{"custom_info":{"rack":"A1"},"exporters":{"node_exporter":{"enabled":true}},"group_ids":[5],"timezone":{"name":"Europe/Berlin"}}`
+	if state.Pillar.ValueString() != want {
+		t.Errorf("expected pillar %s, got %s", want, state.Pillar.ValueString())
+	}
+}
+
+func TestAccMinionPillarDataSource(t *testing.T) {
+	minion := os.Getenv("UYUNI_TEST_MINION_ID")
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccRealServerPreCheck(t)
+			testAccSkipUnlessEnv(t, "UYUNI_TEST_MINION_ID", "a registered Salt minion")
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`data "uyuni_minion_pillar" "test" { system_id = %s }`, minion),
+				Check:  resource.TestCheckResourceAttrSet("data.uyuni_minion_pillar.test", "pillar"),
+			},
+		},
+	})
+}
@@ -0,0 +1,171 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"terraform-provider-uyuni/internal/testing/uyunimock"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/uyuni-project/uyuni-tools/shared/api"
+)
+
+// testMockClient starts a mock API and returns a client logged in to it.
+func testMockClient(t *testing.T) (*uyuniClient, *uyunimock.Server) {
+	mock := uyunimock.New("admin", "secret")
+	t.Cleanup(mock.Close)
+
+	client, err := newUyuniClient(context.Background(), &api.ConnectionDetails{
+		Server:   mock.Host(),
+		User:     "admin",
+		Password: "secret",
+		Insecure: true,
+	})
+	if err != nil {
+		t.Fatalf("could not log in to the mock API: %s", err)
+	}
+	return client, mock
+}
+
+// testMockLifecycle creates, reads, updates and deletes an object of r
+// through the mock API, like the acceptance tests do through Terraform.
+func testMockLifecycle(t *testing.T, r resource.Resource, created, updated map[string]interface{}) {
+	ctx := context.Background()
+	client, _ := testMockClient(t)
+	testConfigure(t, r, client)
+
+	plan := testState(t, r, created)
+	createResp := &resource.CreateResponse{State: plan}
+	r.Create(ctx, resource.CreateRequest{
+		Config: tfsdk.Config{Schema: plan.Schema, Raw: plan.Raw},
+		Plan:   tfsdk.Plan{Schema: plan.Schema, Raw: plan.Raw},
+	}, createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("could not create: %v", createResp.Diagnostics)
+	}
+
+	readResp := &resource.ReadResponse{State: createResp.State}
+	r.Read(ctx, resource.ReadRequest{State: createResp.State}, readResp)
+	if readResp.Diagnostics.HasError() || readResp.State.Raw.IsNull() {
+		t.Fatalf("could not read the created object: %v", readResp.Diagnostics)
+	}
+
+	// The plan keeps the computed attributes of the state.
+	for name, value := range created {
+		if _, ok := updated[name]; !ok {
+			updated[name] = value
+		}
+	}
+	next := testState(t, r, updated)
+	updateResp := &resource.UpdateResponse{State: readResp.State}
+	r.Update(ctx, resource.UpdateRequest{
+		Config: tfsdk.Config{Schema: next.Schema, Raw: next.Raw},
+		Plan:   tfsdk.Plan{Schema: next.Schema, Raw: next.Raw},
+		State:  readResp.State,
+	}, updateResp)
+	if updateResp.Diagnostics.HasError() {
+		t.Fatalf("could not update: %v", updateResp.Diagnostics)
+	}
+
+	readResp = &resource.ReadResponse{State: updateResp.State}
+	r.Read(ctx, resource.ReadRequest{State: updateResp.State}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("could not read the updated object: %v", readResp.Diagnostics)
+	}
+	for name, value := range updated {
+		var got interface{}
+		switch value.(type) {
+		case string:
+			var s string
+			readResp.State.GetAttribute(ctx, path.Root(name), &s)
+			got = s
+		case bool:
+			var b bool
+			readResp.State.GetAttribute(ctx, path.Root(name), &b)
+			got = b
+		default:
+			continue
+		}
+		if got != value {
+			t.Errorf("expected %s %v, got %v", name, value, got)
+		}
+	}
+
+	deleteResp := &resource.DeleteResponse{State: readResp.State}
+	r.Delete(ctx, resource.DeleteRequest{State: readResp.State}, deleteResp)
+	if deleteResp.Diagnostics.HasError() {
+		t.Fatalf("could not delete: %v", deleteResp.Diagnostics)
+	}
+
+	// The read cache would still answer with the deleted object.
+	client.cache.invalidate()
+	readResp = &resource.ReadResponse{State: readResp.State}
+	r.Read(ctx, resource.ReadRequest{State: readResp.State}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics reading the deleted object: %v", readResp.Diagnostics)
+	}
+	if !readResp.State.Raw.IsNull() {
+		t.Error("expected the deleted object to be removed from the state")
+	}
+}
+
+func TestMockAPIUserLifecycle(t *testing.T) {
+	testMockLifecycle(t, NewUserResource(), map[string]interface{}{
+		"login":     "jdoe",
+		"password":  "Secret-123",
+		"firstname": "Jane",
+		"lastname":  "Doe",
+		"email":     "jdoe@example.com",
+	}, map[string]interface{}{
+		"email":   "jane.doe@example.com",
+		"enabled": false,
+	})
+}
+
+func TestMockAPISystemGroupLifecycle(t *testing.T) {
+	testMockLifecycle(t, NewSystemGroupResource(), map[string]interface{}{
+		"name":        "web",
+		"description": "Web servers",
+	}, map[string]interface{}{
+		"description": "All web servers",
+	})
+}
+
+func TestMockAPIConfigChannelLifecycle(t *testing.T) {
+	testMockLifecycle(t, NewConfigChannelResource(), map[string]interface{}{
+		"label":       "motd",
+		"name":        "Message of the day",
+		"description": "Sets /etc/motd",
+	}, map[string]interface{}{
+		"name": "MOTD",
+	})
+}
+
+func TestMockAPISoftwareChannelLifecycle(t *testing.T) {
+	testMockLifecycle(t, NewSoftwareChannelResource(), map[string]interface{}{
+		"label":      "tools",
+		"name":       "Tools",
+		"summary":    "Tools for all systems",
+		"arch_label": "channel-x86_64",
+	}, map[string]interface{}{
+		"summary":     "Tools for every system",
+		"description": "Built by the CI",
+	})
+}
+
+func TestMockAPIRejectsUnknownCalls(t *testing.T) {
+	client, mock := testMockClient(t)
+	_, err := apiGet[interface{}](context.Background(), client, "system/listSystems")
+	if err == nil || isNotFoundError(err) {
+		t.Fatalf("expected an error other than not found, got %v", err)
+	}
+
+	mock.Handle("GET", "system/listSystems", func(*uyunimock.Request) (interface{}, error) {
+		return []interface{}{}, nil
+	})
+	if _, err := apiGet[[]interface{}](context.Background(), client, "system/listSystems"); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,56 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"terraform-provider-uyuni/internal/uyuni"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// notFoundMessages are the fault messages the API uses for missing objects,
+// like "No such system - sid = 1000010000" or "Could not find server 1000010000".
+var notFoundMessages = []string{
+	"no such",
+	"not found",
+	"could not find",
+	"does not exist",
+	"doesn't exist",
+}
+
+// isNotFoundError reports whether an API error means the requested object
+// does not exist. Only faults reported by the server qualify: transport
+// errors like "no such host" say nothing about the object.
+func isNotFoundError(err error) bool {
+	var fault *uyuni.Fault
+	if !errors.As(err, &fault) {
+		return false
+	}
+	if fault.StatusCode == http.StatusNotFound {
+		return true
+	}
+	msg := strings.ToLower(fault.Message)
+	for _, notFound := range notFoundMessages {
+		if strings.Contains(msg, notFound) {
+			return true
+		}
+	}
+	return false
+}
+
+// handleNotFound removes a resource whose object vanished from the server
+// from the state, so that Terraform plans to create it again, and reports
+// whether it did. Read implementations return early when it does, and report
+// any other error as usual.
+func handleNotFound(ctx context.Context, resp *resource.ReadResponse, err error, object string) bool {
+	if !isNotFoundError(err) {
+		return false
+	}
+	tflog.Warn(ctx, object+" no longer exists, removing it from state", map[string]interface{}{"error": err.Error()})
+	resp.State.RemoveResource(ctx)
+	return true
+}
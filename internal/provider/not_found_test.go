@@ -0,0 +1,300 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"testing"
+
+	"terraform-provider-uyuni/internal/uyuni"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestIsNotFoundError(t *testing.T) {
+	for name, tc := range map[string]struct {
+		err  error
+		want bool
+	}{
+		"no such system":    {&uyuni.Fault{Message: "No such system - sid = 1000010000"}, true},
+		"could not find":    {&uyuni.Fault{StatusCode: 500, Message: "Could not find server 1000010000"}, true},
+		"HTTP 404":          {&uyuni.Fault{StatusCode: http.StatusNotFound}, true},
+		"wrapped":           {fmt.Errorf("could not list channels: %w", &uyuni.Fault{Message: "No such peripheral"}), true},
+		"other fault":       {&uyuni.Fault{Message: "Permission denied"}, false},
+		"unknown host":      {&net.DNSError{Err: "no such host", Name: "uyuni.example.com"}, false},
+		"transport message": {errors.New("dial tcp: lookup uyuni.example.com: no such host"), false},
+	} {
+		if got := isNotFoundError(tc.err); got != tc.want {
+			t.Errorf("%s: isNotFoundError(%q) = %v, want %v", name, tc.err, got, tc.want)
+		}
+	}
+}
+
+// vanishedObjects are the resources reading remote objects, with the state
+// attributes pointing to one.
+var vanishedObjects = map[string]struct {
+	resource func() resource.Resource
+	state    map[string]interface{}
+}{
+	"user": {NewUserResource, map[string]interface{}{
+		"id":    "jdoe",
+		"login": "jdoe",
+	}},
+	"scc_credentials": {NewSCCCredentialsResource, map[string]interface{}{
+		"id":       "SCC_0123456789abcdef",
+		"username": "SCC_0123456789abcdef",
+		"password": "secret",
+	}},
+	"system_coco_attestation": {NewSystemCocoAttestationResource, map[string]interface{}{
+		"id":        "1000010000",
+		"system_id": int64(1000010000),
+	}},
+	"retail_branch": {NewRetailBranchResource, map[string]interface{}{
+		"id":        "B001",
+		"branch_id": "B001",
+	}},
+	"hub_peripheral_channels": {NewHubPeripheralChannelsResource, map[string]interface{}{
+		"id":              "peripheral.example.com",
+		"peripheral_fqdn": "peripheral.example.com",
+	}},
+	"activation_key": {NewActivationKeyResource, map[string]interface{}{
+		"id":  "1-web",
+		"key": "web",
+	}},
+	"auto_errata_update": {NewAutoErrataUpdateResource, map[string]interface{}{
+		"id":        "1000010000",
+		"system_id": int64(1000010000),
+	}},
+	"autoinstall_profile": {NewAutoinstallProfileResource, map[string]interface{}{
+		"id":    "sles15sp6",
+		"label": "sles15sp6",
+	}},
+	"bootstrap_host": {NewBootstrapHostResource, map[string]interface{}{
+		"id":        "1000010000",
+		"system_id": int64(1000010000),
+	}},
+	"channel_settings": {NewChannelSettingsResource, map[string]interface{}{
+		"id":            "sles15-sp6-pool-x86_64",
+		"channel_label": "sles15-sp6-pool-x86_64",
+	}},
+	"channel_packages": {NewChannelPackagesResource, map[string]interface{}{
+		"id":            "dev-sles15-sp6-pool-x86_64",
+		"channel_label": "dev-sles15-sp6-pool-x86_64",
+	}},
+	"channel_sync": {NewChannelSyncResource, map[string]interface{}{
+		"id":                   "dev-sles15-sp6-pool-x86_64",
+		"channel_label":        "dev-sles15-sp6-pool-x86_64",
+		"source_channel_label": "sles15-sp6-pool-x86_64",
+		"cutoff_date":          "2025-01-31",
+	}},
+	"channel_tree": {NewChannelTreeResource, map[string]interface{}{
+		"id":           "dev-sles15-sp6-pool-x86_64",
+		"source_label": "sles15-sp6-pool-x86_64",
+		"prefix":       "dev-",
+	}},
+	"clm_environment": {NewCLMEnvironmentResource, map[string]interface{}{
+		"id":            "sles15:dev",
+		"project_label": "sles15",
+		"label":         "dev",
+	}},
+	"clm_filter": {NewCLMFilterResource, map[string]interface{}{
+		"id":        "1",
+		"filter_id": int64(1),
+	}},
+	"clm_project": {NewCLMProjectResource, map[string]interface{}{
+		"id":    "sles15",
+		"label": "sles15",
+	}},
+	"clm_source": {NewCLMSourceResource, map[string]interface{}{
+		"id":            "sles15:sles15-sp6-pool-x86_64",
+		"project_label": "sles15",
+		"channel_label": "sles15-sp6-pool-x86_64",
+	}},
+	"config_channel": {NewConfigChannelResource, map[string]interface{}{
+		"id":    "hardening",
+		"label": "hardening",
+	}},
+	"config_file": {NewConfigFileResource, map[string]interface{}{
+		"id":      "hardening:/init.sls",
+		"channel": "hardening",
+		"path":    "/init.sls",
+	}},
+	"custom_values_policy": {NewCustomValuesPolicyResource, map[string]interface{}{
+		"id":         "web",
+		"group_name": "web",
+		"values":     map[string]string{"owner": "web"},
+	}},
+	"api_call": {NewAPICallResource, map[string]interface{}{
+		"id": "web",
+		"read": &apiCallModel{
+			Path:   types.StringValue("systemgroup/getDetails?systemGroupName={id}"),
+			Method: types.StringNull(),
+			Body:   types.StringNull(),
+		},
+		"delete": &apiCallModel{
+			Path:   types.StringValue("systemgroup/delete"),
+			Method: types.StringValue(http.MethodPost),
+			Body:   types.StringValue(`{"systemGroupName": "{id}"}`),
+		},
+	}},
+	"custom_repo_ssl_bundle": {NewCustomRepoSSLBundleResource, map[string]interface{}{
+		"id":      "rhel-cdn",
+		"name":    "rhel-cdn",
+		"ca_cert": "-----BEGIN CERTIFICATE-----",
+	}},
+	"errata_clone": {NewErrataCloneResource, map[string]interface{}{
+		"id":                   "dev-sles15-sp6-pool-x86_64",
+		"parent_channel_label": "dev-sles15-sp6-pool-x86_64",
+	}},
+	"group_config_channels": {NewGroupConfigChannelsResource, map[string]interface{}{
+		"id":         "web",
+		"group_name": "web",
+	}},
+	"organization": {NewOrganizationResource, map[string]interface{}{
+		"id":     "2",
+		"org_id": int64(2),
+	}},
+	"org_trust": {NewOrgTrustResource, map[string]interface{}{
+		"id":             "2:3",
+		"org_id":         int64(2),
+		"trusted_org_id": int64(3),
+	}},
+	"prometheus_exporters": {NewPrometheusExportersResource, map[string]interface{}{
+		"id":        "1000010000",
+		"system_id": int64(1000010000),
+	}},
+	"recurring_highstate": {NewRecurringHighstateResource, map[string]interface{}{
+		"id":           "nightly",
+		"name":         "nightly",
+		"schedule_ids": map[string]int64{"1000010000": 12},
+	}},
+	"server_settings": {NewServerSettingsResource, map[string]interface{}{
+		"id":     "1",
+		"org_id": int64(1),
+	}},
+	"repository": {NewRepositoryResource, map[string]interface{}{
+		"id":    "sles15-sp6-updates",
+		"label": "sles15-sp6-updates",
+	}},
+	"software_channel": {NewSoftwareChannelResource, map[string]interface{}{
+		"id":    "dev-sles15-sp6-pool-x86_64",
+		"label": "dev-sles15-sp6-pool-x86_64",
+	}},
+	"system_custom_values": {NewSystemCustomValuesResource, map[string]interface{}{
+		"id":        "1000010000",
+		"system_id": int64(1000010000),
+	}},
+	"system_group": {NewSystemGroupResource, map[string]interface{}{
+		"id":   "web",
+		"name": "web",
+	}},
+	"system_snapshot_tag": {NewSystemSnapshotTagResource, map[string]interface{}{
+		"id":          "1000010000:baseline",
+		"system_id":   int64(1000010000),
+		"name":        "baseline",
+		"snapshot_id": int64(7),
+	}},
+}
+
+func TestReadRemovesVanishedObjects(t *testing.T) {
+	for name, tc := range vanishedObjects {
+		t.Run(name, func(t *testing.T) {
+			client := testAPIClient(t, func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusInternalServerError)
+				_, _ = w.Write([]byte(`{"success": false, "message": "No such object"}`))
+			})
+
+			resp := testRead(t, tc.resource(), client, tc.state)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+			if !resp.State.Raw.IsNull() {
+				t.Error("expected the resource to be removed from state")
+			}
+		})
+	}
+}
+
+func TestReadKeepsStateOnTransportErrors(t *testing.T) {
+	for name, tc := range vanishedObjects {
+		t.Run(name, func(t *testing.T) {
+			client := testAPIClient(t, func(w http.ResponseWriter, r *http.Request) {
+				// Drop the connection without answering.
+				conn, _, _ := w.(http.Hijacker).Hijack()
+				conn.Close()
+			})
+
+			resp := testRead(t, tc.resource(), client, tc.state)
+			if !resp.Diagnostics.HasError() {
+				t.Error("expected the transport error to be reported")
+			}
+			if resp.State.Raw.IsNull() {
+				t.Error("expected the resource to stay in state")
+			}
+		})
+	}
+}
+
+// testRead calls Read of r with a state holding the given attributes.
+func testRead(t *testing.T, r resource.Resource, client *uyuniClient, attributes map[string]interface{}) *resource.ReadResponse {
+	ctx := context.Background()
+
+	testConfigure(t, r, client)
+	state := testState(t, r, attributes)
+
+	resp := &resource.ReadResponse{State: state}
+	r.Read(ctx, resource.ReadRequest{State: state}, resp)
+	return resp
+}
+
+// testConfigure hands client to r like the provider does.
+func testConfigure(t *testing.T, r resource.Resource, client *uyuniClient) {
+	var resp resource.ConfigureResponse
+	r.(resource.ResourceWithConfigure).Configure(context.Background(), resource.ConfigureRequest{ProviderData: client}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("could not configure resource: %v", resp.Diagnostics)
+	}
+}
+
+// testState returns a state of r holding the given attributes, all others null.
+func testState(t *testing.T, r resource.Resource, attributes map[string]interface{}) tfsdk.State {
+	ctx := context.Background()
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	state := tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}
+	for name, value := range attributes {
+		if diags := state.SetAttribute(ctx, path.Root(name), value); diags.HasError() {
+			t.Fatalf("could not set %s: %v", name, diags)
+		}
+	}
+	return state
+}
+
+func TestDeleteIgnoresVanishedObjects(t *testing.T) {
+	for name, tc := range vanishedObjects {
+		t.Run(name, func(t *testing.T) {
+			r := tc.resource()
+			testConfigure(t, r, testAPIClient(t, func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusInternalServerError)
+				_, _ = w.Write([]byte(`{"success": false, "message": "No such object"}`))
+			}))
+
+			state := testState(t, r, tc.state)
+			resp := &resource.DeleteResponse{State: state}
+			r.Delete(context.Background(), resource.DeleteRequest{State: state}, resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+		})
+	}
+}
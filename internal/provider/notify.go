@@ -0,0 +1,169 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"text/template"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	// notifySendTimeout bounds sending a notification.
+	notifySendTimeout = 10 * time.Second
+
+	// notifyDefaultTemplate is a message understood by Slack, Mattermost
+	// and Rocket.Chat incoming webhooks.
+	notifyDefaultTemplate = `{"text": {{ json .Text }}}`
+)
+
+// notifyModel maps the notify block of resources scheduling actions.
+type notifyModel struct {
+	URL      types.String `tfsdk:"url"`
+	Template types.String `tfsdk:"template"`
+	Headers  types.Map    `tfsdk:"headers"`
+}
+
+// notification is the data of the template of the notify block.
+type notification struct {
+	// Resource is the type of the resource, e.g. uyuni_scheduled_action.
+	Resource  string
+	ID        string
+	Status    string
+	ActionIDs []int64
+	SystemIDs []int64
+	// Text summarizes the other fields.
+	Text string
+}
+
+// notifyFuncs are the functions of notify templates.
+var notifyFuncs = template.FuncMap{
+	"json": func(v interface{}) (string, error) {
+		data, err := json.Marshal(v)
+		return string(data), err
+	},
+}
+
+// notifyBlock is the schema of the notify block.
+func notifyBlock() schema.SingleNestedBlock {
+	return schema.SingleNestedBlock{
+		Description: "Post a message to a webhook, e.g. of a chat channel, once the actions completed. Only sent when " +
+			"the resource waits for the actions and they completed on all systems. Failing to send it is a warning.",
+		Attributes: map[string]schema.Attribute{
+			"url": schema.StringAttribute{
+				Description: "URL the message is posted to.",
+				Required:    true,
+			},
+			"template": schema.StringAttribute{
+				Description: "Go template of the message body, with the fields `.Resource`, `.ID`, `.Status`, `.ActionIDs`, " +
+					"`.SystemIDs` and `.Text`, a summary of the others, and the function `json` to encode values. " +
+					"Defaults to `{\"text\": {{ json .Text }}}`, understood by Slack and Mattermost.",
+				Optional: true,
+				Validators: []validator.String{
+					notifyTemplateValidator{},
+				},
+			},
+			"headers": schema.MapAttribute{
+				Description: "HTTP headers sent with the message, e.g. for authentication.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Sensitive:   true,
+			},
+		},
+	}
+}
+
+// notifyTemplateValidator ensures that the template of the notify block
+// parses.
+type notifyTemplateValidator struct{}
+
+// Description implements validator.Describer.
+func (v notifyTemplateValidator) Description(_ context.Context) string {
+	return "must be a Go template"
+}
+
+// MarkdownDescription implements validator.Describer.
+func (v notifyTemplateValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateString implements validator.String.
+func (v notifyTemplateValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	if _, err := template.New("notify").Funcs(notifyFuncs).Parse(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid Notification Template", err.Error())
+	}
+}
+
+// send posts the notification to the webhook if the actions completed and
+// diags has no errors. It is a no-op on a nil block, so resources without one
+// need no checks.
+func (n *notifyModel) send(ctx context.Context, event notification, diags *diag.Diagnostics) {
+	if n == nil || event.Status != actionStatusCompleted || diags.HasError() {
+		return
+	}
+	if event.ActionIDs == nil {
+		event.ActionIDs = []int64{}
+	}
+	if event.SystemIDs == nil {
+		event.SystemIDs = []int64{}
+	}
+	event.Text = fmt.Sprintf("%s %s %s on %d systems", event.Resource, event.ID, event.Status, len(event.SystemIDs))
+	if err := n.post(ctx, event); err != nil {
+		diags.AddWarning("Unable to Send Notification", fmt.Sprintf("Could not notify %s: %s", n.URL.ValueString(), err))
+		return
+	}
+	tflog.Info(ctx, "Sent notification to "+n.URL.ValueString())
+}
+
+// post renders the template and posts the result.
+func (n *notifyModel) post(ctx context.Context, event notification) error {
+	text := notifyDefaultTemplate
+	if !n.Template.IsNull() {
+		text = n.Template.ValueString()
+	}
+	tmpl, err := template.New("notify").Funcs(notifyFuncs).Parse(text)
+	if err != nil {
+		return err
+	}
+	var body bytes.Buffer
+	if err := tmpl.Execute(&body, event); err != nil {
+		return err
+	}
+	headers := map[string]string{}
+	if !n.Headers.IsNull() {
+		if err := n.Headers.ElementsAs(ctx, &headers, false); err.HasError() {
+			return fmt.Errorf("invalid headers: %v", err)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, notifySendTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.URL.ValueString(), &body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("webhook returned HTTP %d", res.StatusCode)
+	}
+	return nil
+}
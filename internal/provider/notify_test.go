@@ -0,0 +1,105 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestNotifySendsCompletedActions(t *testing.T) {
+	ctx := context.Background()
+	var bodies []string
+	hook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" || r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("unexpected headers %v", r.Header)
+		}
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		if len(bodies) == 3 {
+			w.WriteHeader(http.StatusForbidden)
+		}
+	}))
+	defer hook.Close()
+
+	notify := &notifyModel{
+		URL:      types.StringValue(hook.URL),
+		Template: types.StringNull(),
+		Headers:  types.MapValueMust(types.StringType, map[string]attr.Value{"Authorization": types.StringValue("Bearer token")}),
+	}
+	event := notification{Resource: "uyuni_scheduled_action", ID: "4711", Status: actionStatusCompleted, ActionIDs: []int64{4711}, SystemIDs: []int64{1, 2}}
+
+	var diags diag.Diagnostics
+	notify.send(ctx, event, &diags)
+	notify.Template = types.StringValue(`{{ .Resource }} {{ json .ActionIDs }} {{ json .SystemIDs }}`)
+	notify.send(ctx, event, &diags)
+	if diags.HasError() || diags.WarningsCount() > 0 {
+		t.Fatal(diags)
+	}
+	var message map[string]string
+	if len(bodies) != 2 || json.Unmarshal([]byte(bodies[0]), &message) != nil || message["text"] != "uyuni_scheduled_action 4711 completed on 2 systems" {
+		t.Fatalf("expected the default message, got %v", bodies)
+	}
+	if bodies[1] != "uyuni_scheduled_action [4711] [1,2]" {
+		t.Errorf("expected the custom template, got %q", bodies[1])
+	}
+
+	// Pending actions are not notified, failing webhooks are a warning.
+	notify.send(ctx, notification{Status: actionStatusPending}, &diags)
+	if len(bodies) != 2 {
+		t.Errorf("expected no notification of pending actions, got %v", bodies)
+	}
+	notify.send(ctx, event, &diags)
+	if diags.HasError() || diags.WarningsCount() != 1 {
+		t.Errorf("expected a warning, got %v", diags)
+	}
+}
+
+func TestSystemReprovisionNotifies(t *testing.T) {
+	ctx := context.Background()
+	var message map[string]string
+	hook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&message)
+	}))
+	defer hook.Close()
+
+	r := NewSystemReprovisionResource()
+	testConfigure(t, r, testAPIClient(t, func(w http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/system/provisionSystem":
+			_, _ = w.Write([]byte(`{"success": true, "result": 4711}`))
+		case "/schedule/listCompletedSystems":
+			_, _ = w.Write([]byte(`{"success": true, "result": [{"server_id": 1000010001}]}`))
+		default:
+			_, _ = w.Write([]byte(`{"success": true, "result": []}`))
+		}
+	}))
+	planned := testState(t, r, map[string]interface{}{
+		"system_id":                   1000010001,
+		"profile_name":                "sles15-sp6-web",
+		"respect_maintenance_windows": false,
+		"wait":                        true,
+		"cancel_on_destroy":           true,
+		"notify": &notifyModel{
+			URL:      types.StringValue(hook.URL),
+			Template: types.StringNull(),
+			Headers:  types.MapNull(types.StringType),
+		},
+	})
+	resp := &resource.CreateResponse{State: planned}
+	r.Create(ctx, resource.CreateRequest{Plan: tfsdk.Plan{Schema: planned.Schema, Raw: planned.Raw}}, resp)
+	if resp.Diagnostics.HasError() || resp.Diagnostics.WarningsCount() > 0 {
+		t.Fatal(resp.Diagnostics)
+	}
+	if message["text"] != "uyuni_system_reprovision 4711 completed on 1 systems" {
+		t.Errorf("unexpected notification %v", message)
+	}
+}
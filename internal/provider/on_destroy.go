@@ -0,0 +1,40 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Values of the on_destroy attribute.
+const (
+	// onDestroyDelete deletes the object on destroy.
+	onDestroyDelete = "delete"
+	// onDestroyOrphan leaves the object on the server and only removes it
+	// from the state.
+	onDestroyOrphan = "orphan"
+)
+
+// onDestroyAttribute is the schema of the on_destroy attribute of resources
+// managing channels or channel content, where destroying a test workspace
+// should not have to wipe what other systems still consume.
+// The deletion describes what delete mode does, e.g. "deletes the channel".
+func onDestroyAttribute(deletion string) schema.StringAttribute {
+	return schema.StringAttribute{
+		Description: "What destroying the resource does: `delete` " + deletion + ", `orphan` keeps everything on the server " +
+			"and only removes the resource from Terraform management. Defaults to `delete`.",
+		Optional: true,
+		Computed: true,
+		Default:  stringdefault.StaticString(onDestroyDelete),
+		Validators: []validator.String{
+			stringvalidator.OneOf(onDestroyDelete, onDestroyOrphan),
+		},
+	}
+}
+
+// orphaned returns true if the object is to be kept on destroy.
+func orphaned(onDestroy types.String) bool {
+	return onDestroy.ValueString() == onDestroyOrphan
+}
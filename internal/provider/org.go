@@ -0,0 +1,94 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// orgModel maps the org block of resources, which manages the object in the
+// organization of another user than the provider user. API sessions belong
+// to a user, so the block takes the credentials of an administrator of that
+// organization rather than its id.
+type orgModel struct {
+	Username types.String `tfsdk:"username"`
+	Password types.String `tfsdk:"password"`
+}
+
+// orgBlock is the schema of the org block.
+func orgBlock() schema.SingleNestedBlock {
+	return schema.SingleNestedBlock{
+		Description: "Manage the object as another user, e.g. an administrator of another organization. " +
+			"Defaults to the provider user. Objects managed as another user cannot be imported.",
+		Attributes: map[string]schema.Attribute{
+			"username": schema.StringAttribute{
+				Description: "Login of the user.",
+				Required:    true,
+			},
+			"password": schema.StringAttribute{
+				Description: "Password of the user.",
+				Required:    true,
+				Sensitive:   true,
+			},
+		},
+	}
+}
+
+// forOrg returns the client acting as the user of org, the client itself if
+// org is nil. The clients of other users are logged in once and shared by all
+// resources using the same credentials.
+func (c *uyuniClient) forOrg(ctx context.Context, org *orgModel) (*uyuniClient, error) {
+	if org == nil {
+		return c, nil
+	}
+	username, password := org.Username.ValueString(), org.Password.ValueString()
+
+	c.orgsMu.Lock()
+	defer c.orgsMu.Unlock()
+
+	if client, ok := c.orgs[username]; ok && client.password == password {
+		return client, nil
+	}
+	client := &uyuniClient{
+		baseURL:    c.baseURL,
+		httpClient: c.httpClient,
+		username:   username,
+		password:   password,
+		cache:      newReadCache(readCacheTTL),
+		pacer:      c.pacer,
+		version:    c.version,
+		debug:      c.debug,
+		retries:    c.retries,
+		tracer:     c.tracer,
+		audit:      c.audit,
+
+		defaultCustomValues: c.defaultCustomValues,
+	}
+	if err := client.login(ctx, nil); err != nil {
+		return nil, fmt.Errorf("could not log in as %s: %w", username, err)
+	}
+	if c.orgs == nil {
+		c.orgs = map[string]*uyuniClient{}
+	}
+	c.orgs[username] = client
+	return client, nil
+}
+
+// orgClient returns the client for the org block of a resource. It reports an
+// error and returns nil if the user of the block cannot log in.
+func orgClient(ctx context.Context, client *uyuniClient, org *orgModel, diags *diag.Diagnostics) *uyuniClient {
+	client, err := client.forOrg(ctx, org)
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("org"),
+			"Unable to Log In",
+			"The org block selects another user than the provider user, but the provider "+err.Error()+".",
+		)
+		return nil
+	}
+	return client
+}
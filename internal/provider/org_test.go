@@ -0,0 +1,71 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func testOrg(username, password string) *orgModel {
+	return &orgModel{Username: types.StringValue(username), Password: types.StringValue(password)}
+}
+
+func TestForOrgPoolsClients(t *testing.T) {
+	ctx := context.Background()
+	server := newTestSessionServer(t)
+	client := server.client(t)
+
+	if c, err := client.forOrg(ctx, nil); err != nil || c != client {
+		t.Fatalf("expected the provider client without org, got %p, %v", c, err)
+	}
+
+	first, err := client.forOrg(ctx, testOrg("tenant-admin", "secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first == client || first.username != "tenant-admin" || first.cookie() == nil {
+		t.Fatalf("expected a logged in client for tenant-admin, got %+v", first)
+	}
+	second, err := client.forOrg(ctx, testOrg("tenant-admin", "secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if second != first {
+		t.Error("expected resources with the same credentials to share a client")
+	}
+	if logins := server.logins.Load(); logins != 2 {
+		t.Errorf("expected one login for the provider and one for the org, got %d", logins)
+	}
+
+	changed, err := client.forOrg(ctx, testOrg("tenant-admin", "rotated"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if changed == first || changed.password != "rotated" {
+		t.Error("expected a new client after the password changed")
+	}
+}
+
+func TestOrgClientLoginFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"success": false, "messages": "Either the password or username is incorrect."}`))
+	}))
+	defer server.Close()
+
+	client := &uyuniClient{baseURL: server.URL, httpClient: server.Client()}
+	var diags diag.Diagnostics
+	if c := orgClient(context.Background(), client, testOrg("tenant-admin", "wrong"), &diags); c != nil {
+		t.Fatal("expected no client")
+	}
+	if !diags.HasError() || !strings.Contains(diags[0].Detail(), "could not log in as tenant-admin: Either the password or username is incorrect.") {
+		t.Errorf("got %v", diags)
+	}
+	if len(client.orgs) != 0 {
+		t.Error("failed logins must not be pooled")
+	}
+}
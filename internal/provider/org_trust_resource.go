@@ -0,0 +1,248 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"terraform-provider-uyuni/internal/uyuni"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &orgTrustResource{}
+	_ resource.ResourceWithConfigure   = &orgTrustResource{}
+	_ resource.ResourceWithImportState = &orgTrustResource{}
+)
+
+// NewOrgTrustResource is a helper function to simplify the provider implementation.
+func NewOrgTrustResource() resource.Resource {
+	return &orgTrustResource{}
+}
+
+// orgTrustResource is the resource implementation.
+type orgTrustResource struct {
+	client *uyuniClient
+}
+
+// orgTrustResourceModel maps the resource schema data.
+type orgTrustResourceModel struct {
+	ID           types.String `tfsdk:"id"`
+	OrgID        types.Int64  `tfsdk:"org_id"`
+	TrustedOrgID types.Int64  `tfsdk:"trusted_org_id"`
+	ServerAlias  types.String `tfsdk:"server_alias"`
+}
+
+// Metadata returns the resource type name.
+func (r *orgTrustResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_org_trust"
+}
+
+// Schema defines the schema for the resource.
+func (r *orgTrustResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Establishes trust between two organizations, which lets them share channels and migrate systems " +
+			"to each other. Trust is mutual, so manage each pair once. Requires the satellite_admin role.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Both organization IDs, separated by a colon.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"org_id": schema.Int64Attribute{
+				Description: "ID of one organization.",
+				Required:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"trusted_org_id": schema.Int64Attribute{
+				Description: "ID of the other organization.",
+				Required:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"server_alias": serverAliasAttribute(),
+		},
+	}
+}
+
+// Create a new resource.
+func (r *orgTrustResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan orgTrustResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := resourceClient(ctx, r.client, plan.ServerAlias, nil, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	org, trusted := plan.OrgID.ValueInt64(), plan.TrustedOrgID.ValueInt64()
+	tflog.Info(ctx, fmt.Sprintf("About to establish trust between organizations %d and %d", org, trusted))
+
+	_, err := apiPost[int](ctx, client, "org/trusts/addTrust", map[string]interface{}{
+		"orgId":      org,
+		"trustOrgId": trusted,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error establishing organization trust",
+			fmt.Sprintf("Could not establish trust between organizations %d and %d: %s", org, trusted, err),
+		)
+		return
+	}
+	plan.ID = types.StringValue(fmt.Sprintf("%d%s%d", org, importIDSeparator, trusted))
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read resource information.
+func (r *orgTrustResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Get current state
+	var state orgTrustResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := resourceClient(ctx, r.client, state.ServerAlias, nil, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	org, trusted := state.OrgID.ValueInt64(), state.TrustedOrgID.ValueInt64()
+	trusts, err := apiGet[[]uyuni.OrgTrust](ctx, client, fmt.Sprintf("org/trusts/listTrusts?orgId=%d", org))
+	if err != nil {
+		if handleNotFound(ctx, resp, err, fmt.Sprintf("Organization %d", org)) {
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Reading Uyuni organization trust",
+			fmt.Sprintf("Could not list the trusts of organization %d: %s", org, err),
+		)
+		return
+	}
+	found := false
+	for _, trust := range trusts.Result {
+		if int64(trust.OrgID) == trusted && trust.TrustEnabled {
+			found = true
+		}
+	}
+	if !found {
+		tflog.Warn(ctx, fmt.Sprintf("Organization %d no longer trusts organization %d, removing the trust from state", org, trusted))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	state.ID = types.StringValue(fmt.Sprintf("%d%s%d", org, importIDSeparator, trusted))
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update only stores the plan, the organizations require replacement.
+func (r *orgTrustResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan orgTrustResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete removes the trust. The server refuses while systems migrated
+// between the organizations or shared channels depend on it.
+func (r *orgTrustResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Retrieve values from state
+	var state orgTrustResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := resourceClient(ctx, r.client, state.ServerAlias, nil, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	org, trusted := state.OrgID.ValueInt64(), state.TrustedOrgID.ValueInt64()
+	_, err := apiPost[int](ctx, client, "org/trusts/removeTrust", map[string]interface{}{
+		"orgId":      org,
+		"trustOrgId": trusted,
+	})
+	if err != nil && !isNotFoundError(err) {
+		resp.Diagnostics.AddError(
+			"Error Deleting Uyuni organization trust",
+			fmt.Sprintf("Could not remove the trust between organizations %d and %d: %s", org, trusted, err),
+		)
+		return
+	}
+}
+
+// ImportState imports a trust by both organization IDs, e.g. "2:3".
+func (r *orgTrustResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts, err := parseImportID(req.ID, "org_id", "trusted_org_id")
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Import ID", err.Error())
+		return
+	}
+	for i, name := range []string{"org_id", "trusted_org_id"} {
+		id, err := parseImportInt64(name, parts[i])
+		if err != nil {
+			resp.Diagnostics.AddError("Invalid Import ID", err.Error())
+			return
+		}
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root(name), id)...)
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *orgTrustResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*uyuniClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *uyuniClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
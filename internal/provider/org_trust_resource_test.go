@@ -0,0 +1,75 @@
+package provider
+
+import (
+	"net/http"
+	"testing"
+
+	acctest "github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestOrgTrustReadRemovesRevokedTrust(t *testing.T) {
+	client := testAPIClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("orgId") != "1" {
+			t.Errorf("unexpected query %s", r.URL.RawQuery)
+		}
+		_, _ = w.Write([]byte(`{"success": true, "result": [
+			{"orgId": 2, "orgName": "Tenant A", "trustEnabled": true},
+			{"orgId": 3, "orgName": "Tenant B", "trustEnabled": false}]}`))
+	})
+
+	for trusted, exists := range map[int64]bool{2: true, 3: false, 4: false} {
+		resp := testRead(t, NewOrgTrustResource(), client, map[string]interface{}{"org_id": int64(1), "trusted_org_id": trusted})
+		if resp.Diagnostics.HasError() {
+			t.Fatal(resp.Diagnostics)
+		}
+		if resp.State.Raw.IsNull() == exists {
+			t.Errorf("expected trust in organization %d to exist: %t", trusted, exists)
+		}
+	}
+}
+
+func TestAccOrgTrustResource(t *testing.T) {
+	acctest.Test(t, acctest.TestCase{
+		PreCheck:                 func() { testAccRealServerPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []acctest.TestStep{
+			// Create and Read testing
+			{
+				Config: `
+resource "uyuni_organization" "a" {
+  name                = "tfacc-trust-a"
+  admin_login         = "tfacc-trust-a-admin"
+  admin_password      = "tfacc-Secret-123"
+  admin_first_name    = "Terraform"
+  admin_last_name     = "Acceptance"
+  admin_email         = "tfacc-trust-a-admin@example.com"
+  deletion_protection = false
+}
+
+resource "uyuni_organization" "b" {
+  name                = "tfacc-trust-b"
+  admin_login         = "tfacc-trust-b-admin"
+  admin_password      = "tfacc-Secret-123"
+  admin_first_name    = "Terraform"
+  admin_last_name     = "Acceptance"
+  admin_email         = "tfacc-trust-b-admin@example.com"
+  deletion_protection = false
+}
+
+resource "uyuni_org_trust" "test" {
+  org_id         = uyuni_organization.a.org_id
+  trusted_org_id = uyuni_organization.b.org_id
+}
+`,
+				Check: acctest.TestCheckResourceAttrPair("uyuni_org_trust.test", "trusted_org_id", "uyuni_organization.b", "org_id"),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "uyuni_org_trust.test",
+				ImportState:       true,
+				ImportStateIdFunc: testAccAttribute("uyuni_org_trust.test", "id"),
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
@@ -0,0 +1,479 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"terraform-provider-uyuni/internal/uyuni"
+	"terraform-provider-uyuni/internal/validators"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                     = &organizationResource{}
+	_ resource.ResourceWithConfigure        = &organizationResource{}
+	_ resource.ResourceWithImportState      = &organizationResource{}
+	_ resource.ResourceWithConfigValidators = &organizationResource{}
+)
+
+// NewOrganizationResource is a helper function to simplify the provider implementation.
+func NewOrganizationResource() resource.Resource {
+	return &organizationResource{}
+}
+
+// organizationResource is the resource implementation.
+type organizationResource struct {
+	client *uyuniClient
+}
+
+// organizationResourceModel maps the resource schema data.
+type organizationResourceModel struct {
+	ID                 types.String   `tfsdk:"id"`
+	Name               types.String   `tfsdk:"name"`
+	AdminLogin         types.String   `tfsdk:"admin_login"`
+	AdminPassword      types.String   `tfsdk:"admin_password"`
+	AdminPrefix        types.String   `tfsdk:"admin_prefix"`
+	AdminFirstName     types.String   `tfsdk:"admin_first_name"`
+	AdminLastName      types.String   `tfsdk:"admin_last_name"`
+	AdminEmail         types.String   `tfsdk:"admin_email"`
+	AdminUsePAM        types.Bool     `tfsdk:"admin_use_pam"`
+	AdminHandover      types.Bool     `tfsdk:"admin_handover"`
+	SystemEntitlements types.Map      `tfsdk:"system_entitlements"`
+	OrgID              types.Int64    `tfsdk:"org_id"`
+	DeletionProtection types.Bool     `tfsdk:"deletion_protection"`
+	ServerAlias        types.String   `tfsdk:"server_alias"`
+	Timeouts           timeouts.Value `tfsdk:"timeouts"`
+}
+
+// Metadata returns the resource type name.
+func (r *organizationResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_organization"
+}
+
+// Schema defines the schema for the resource.
+func (r *organizationResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	// The administrator is only created with the organization and managed
+	// by uyuni_user afterwards, so changes to it are ignored.
+	adminDescription := " Only used when creating the organization, manage the administrator with uyuni_user afterwards."
+	resp.Schema = schema.Schema{
+		Description: "Manages an organization of a multi-tenant server, which requires the satellite_admin role. " +
+			"Manage the objects of the organization by passing the credentials of its administrator to the org block " +
+			"of their resources.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "ID of the organization.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "Name of the organization.",
+				Required:    true,
+			},
+			"admin_login": schema.StringAttribute{
+				Description: "Login of the first administrator of the organization." + adminDescription,
+				Required:    true,
+			},
+			// A write-only attribute (terraform-plugin-framework v1.14) or an
+			// ephemeral resource (v1.13) would keep the password out of the
+			// state, but the provider is built with v1.12, so
+			// admin_handover invalidates it instead.
+			"admin_password": schema.StringAttribute{
+				Description: "Password of the first administrator, required unless admin_use_pam is true. " +
+					"It is kept in the state, set admin_handover to invalidate it once the organization is created. " +
+					"Keeping it out of the state would need write-only attributes or ephemeral resources, which need " +
+					"terraform-plugin-framework v1.14 and v1.13, while the provider is still built with v1.12." + adminDescription,
+				Optional:  true,
+				Sensitive: true,
+			},
+			"admin_prefix": schema.StringAttribute{
+				Description: "Prefix of the name of the first administrator, e.g. `Mr.` or `Ms.`. Defaults to `Mr.`." + adminDescription,
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString("Mr."),
+			},
+			"admin_first_name": schema.StringAttribute{
+				Description: "First name of the first administrator." + adminDescription,
+				Required:    true,
+			},
+			"admin_last_name": schema.StringAttribute{
+				Description: "Last name of the first administrator." + adminDescription,
+				Required:    true,
+			},
+			"admin_email": schema.StringAttribute{
+				Description: "Email address of the first administrator." + adminDescription,
+				Required:    true,
+			},
+			"admin_use_pam": schema.BoolAttribute{
+				Description: "Authenticate the first administrator through PAM instead of a password. Defaults to false." + adminDescription,
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"admin_handover": schema.BoolAttribute{
+				Description: "Replace the password of the first administrator with a random one nobody knows right after " +
+					"creating the organization, so that admin_password in the state no longer works and the administrator " +
+					"has to set their own through the password reset of the login page. Defaults to false." + adminDescription,
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"system_entitlements": schema.MapAttribute{
+				Description: "Number of systems of the organization which may use each system entitlement, by label, " +
+					"e.g. `{ monitoring_entitled = 10 }`. Entitlements which are not listed are left alone.",
+				ElementType: types.Int64Type,
+				Optional:    true,
+				Validators: []validator.Map{
+					mapvalidator.ValueInt64sAre(int64validator.AtLeast(0)),
+				},
+			},
+			"org_id": schema.Int64Attribute{
+				Description: "ID of the organization.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"deletion_protection": deletionProtectionAttribute(true),
+			"server_alias":        serverAliasAttribute(),
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+// ConfigValidators returns the validators checking attributes against each other.
+func (r *organizationResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		validators.ConflictsWhenTrue("admin_use_pam", "admin_password"),
+		validators.RequiredUnlessTrue("admin_use_pam", "admin_password"),
+		validators.ConflictsWhenTrue("admin_use_pam", "admin_handover"),
+	}
+}
+
+// setSystemEntitlements allocates the entitlements of the model to the
+// organization.
+func (m *organizationResourceModel) setSystemEntitlements(ctx context.Context, client *uyuniClient) error {
+	if m.SystemEntitlements.IsNull() {
+		return nil
+	}
+	allocations := map[string]int64{}
+	if diags := m.SystemEntitlements.ElementsAs(ctx, &allocations, false); diags.HasError() {
+		return fmt.Errorf("could not read system_entitlements: %v", diags)
+	}
+	labels := make([]string, 0, len(allocations))
+	for label := range allocations {
+		labels = append(labels, label)
+	}
+	sort.Strings(labels)
+	for _, label := range labels {
+		if _, err := apiPost[int](ctx, client, "org/setSystemEntitlements", map[string]interface{}{
+			"orgId":      m.OrgID.ValueInt64(),
+			"label":      label,
+			"allocation": allocations[label],
+		}); err != nil {
+			return fmt.Errorf("could not allocate %s: %w", label, err)
+		}
+	}
+	return nil
+}
+
+// resetAdminPassword replaces the password of the first administrator with a
+// random one, logged in as the administrator since only the users of an
+// organization may change their passwords.
+func (m *organizationResourceModel) resetAdminPassword(ctx context.Context, client *uyuniClient) error {
+	admin, err := client.forOrg(ctx, &orgModel{Username: m.AdminLogin, Password: m.AdminPassword})
+	if err != nil {
+		return err
+	}
+	_, err = apiPost[int](ctx, admin, "user/setDetails", map[string]interface{}{
+		"login": m.AdminLogin.ValueString(),
+		"details": map[string]interface{}{
+			"password": randomHex(16),
+		},
+	})
+	return err
+}
+
+// Create a new resource.
+func (r *organizationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan organizationResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	client := resourceClient(ctx, r.client, plan.ServerAlias, nil, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	name := plan.Name.ValueString()
+	tflog.Info(ctx, "About to create organization "+name+" with administrator "+plan.AdminLogin.ValueString())
+
+	org, err := apiPost[uyuni.Org](ctx, client, "org/create", map[string]interface{}{
+		"orgName":       name,
+		"adminLogin":    plan.AdminLogin.ValueString(),
+		"adminPassword": plan.AdminPassword.ValueString(),
+		"prefix":        plan.AdminPrefix.ValueString(),
+		"firstName":     plan.AdminFirstName.ValueString(),
+		"lastName":      plan.AdminLastName.ValueString(),
+		"email":         plan.AdminEmail.ValueString(),
+		"usePamAuth":    plan.AdminUsePAM.ValueBool(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating organization",
+			"Could not create organization "+name+": "+err.Error(),
+		)
+		return
+	}
+	plan.ID = types.StringValue(strconv.Itoa(org.Result.ID))
+	plan.OrgID = types.Int64Value(int64(org.Result.ID))
+
+	if err := plan.setSystemEntitlements(ctx, client); err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating organization",
+			"Could not allocate system entitlements to organization "+name+": "+err.Error(),
+		)
+		// Fall through to track the organization, which Terraform taints.
+	}
+	if plan.AdminHandover.ValueBool() {
+		if err := plan.resetAdminPassword(ctx, client); err != nil {
+			resp.Diagnostics.AddError(
+				"Error creating organization",
+				"Could not reset the password of the administrator of organization "+name+": "+err.Error(),
+			)
+		}
+	}
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read resource information. The attributes of the administrator are kept
+// as they are, the administrator may have changed since.
+func (r *organizationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Get current state
+	var state organizationResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := resourceClient(ctx, r.client, state.ServerAlias, nil, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	id := state.OrgID.ValueInt64()
+	org, err := apiGet[uyuni.Org](ctx, client, fmt.Sprintf("org/getDetails?orgId=%d", id))
+	if err != nil {
+		if handleNotFound(ctx, resp, err, fmt.Sprintf("Organization %d", id)) {
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Reading Uyuni organization",
+			fmt.Sprintf("Could not read organization %d: %s", id, err),
+		)
+		return
+	}
+	state.ID = types.StringValue(strconv.Itoa(org.Result.ID))
+	state.Name = types.StringValue(org.Result.Name)
+
+	// Entitlements are only refreshed where they are managed.
+	if !state.SystemEntitlements.IsNull() {
+		usage, err := apiGet[[]uyuni.EntitlementUsage](ctx, client, fmt.Sprintf("org/listSystemEntitlementsForOrg?orgId=%d", id))
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Reading Uyuni organization",
+				fmt.Sprintf("Could not list the system entitlements of organization %d: %s", id, err),
+			)
+			return
+		}
+		managed := map[string]int64{}
+		resp.Diagnostics.Append(state.SystemEntitlements.ElementsAs(ctx, &managed, false)...)
+		allocations := map[string]int64{}
+		for _, entitlement := range usage.Result {
+			if _, ok := managed[entitlement.Label]; ok {
+				allocations[entitlement.Label] = int64(entitlement.Allocated)
+			}
+		}
+		state.SystemEntitlements, diags = types.MapValueFrom(ctx, types.Int64Type, allocations)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update renames the organization and allocates its entitlements. Changes to
+// the administrator are only stored.
+func (r *organizationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Retrieve values from plan and state
+	var plan, state organizationResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	client := resourceClient(ctx, r.client, plan.ServerAlias, nil, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	id := plan.OrgID.ValueInt64()
+	var err error
+	if !plan.Name.Equal(state.Name) {
+		_, err = apiPost[uyuni.Org](ctx, client, "org/updateName", map[string]interface{}{
+			"orgId": id,
+			"name":  plan.Name.ValueString(),
+		})
+	}
+	if err == nil && !plan.SystemEntitlements.Equal(state.SystemEntitlements) {
+		err = plan.setSystemEntitlements(ctx, client)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating organization",
+			fmt.Sprintf("Could not update organization %d: %s", id, err),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete removes the organization with all its users, systems and other
+// objects.
+func (r *organizationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Retrieve values from state
+	var state organizationResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	id := state.OrgID.ValueInt64()
+	if deletionProtected(state.DeletionProtection, fmt.Sprintf("Organization %d", id), &resp.Diagnostics) {
+		return
+	}
+
+	client := resourceClient(ctx, r.client, state.ServerAlias, nil, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	_, err := apiPost[int](ctx, client, "org/delete", map[string]interface{}{
+		"orgId": id,
+	})
+	if err != nil && !isNotFoundError(err) {
+		resp.Diagnostics.AddError(
+			"Error Deleting Uyuni organization",
+			fmt.Sprintf("Could not delete organization %d: %s", id, err),
+		)
+		return
+	}
+}
+
+// ImportState imports an organization by its ID. Its administrator stays
+// unknown and its entitlements unmanaged until they are configured, and it
+// is protected against deletion.
+func (r *organizationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id, err := parseImportInt64("org_id", req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Import ID", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("org_id"), id)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("deletion_protection"), true)...)
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *organizationResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*uyuniClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *uyuniClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
@@ -0,0 +1,152 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	acctest "github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestOrganizationCreateAllocatesEntitlements(t *testing.T) {
+	ctx := context.Background()
+	var calls []string
+	r := NewOrganizationResource()
+	testConfigure(t, r, testAPIClient(t, func(w http.ResponseWriter, req *http.Request) {
+		var body map[string]interface{}
+		_ = json.NewDecoder(req.Body).Decode(&body)
+		switch req.URL.Path {
+		case "/org/create":
+			calls = append(calls, fmt.Sprint(req.URL.Path, " ", body["orgName"], " ", body["adminLogin"], " ", body["usePamAuth"]))
+			_, _ = w.Write([]byte(`{"success": true, "result": {"id": 4, "name": "Tenant C"}}`))
+		default:
+			calls = append(calls, fmt.Sprint(req.URL.Path, " ", body["orgId"], " ", body["label"], " ", body["allocation"]))
+			_, _ = w.Write([]byte(`{"success": true, "result": 1}`))
+		}
+	}))
+
+	planned := testState(t, r, map[string]interface{}{
+		"name":                "Tenant C",
+		"admin_login":         "tenant-c-admin",
+		"admin_password":      "secret",
+		"admin_prefix":        "Ms.",
+		"admin_first_name":    "Tenant",
+		"admin_last_name":     "Admin",
+		"admin_email":         "admin@tenant-c.example.com",
+		"admin_use_pam":       false,
+		"system_entitlements": map[string]int64{"monitoring_entitled": 10, "enterprise_entitled": 50},
+	})
+	resp := &resource.CreateResponse{State: planned}
+	r.Create(ctx, resource.CreateRequest{Plan: tfsdk.Plan{Schema: planned.Schema, Raw: planned.Raw}}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+	want := "[/org/create Tenant C tenant-c-admin false /org/setSystemEntitlements 4 enterprise_entitled 50 " +
+		"/org/setSystemEntitlements 4 monitoring_entitled 10]"
+	if fmt.Sprint(calls) != want {
+		t.Errorf("expected %s, got %v", want, calls)
+	}
+
+	var state organizationResourceModel
+	resp.State.Get(ctx, &state)
+	if state.ID.ValueString() != "4" || state.OrgID.ValueInt64() != 4 {
+		t.Errorf("unexpected state %v", state)
+	}
+}
+
+func TestOrganizationCreateResetsAdminPassword(t *testing.T) {
+	ctx := context.Background()
+	var calls []string
+	var password string
+	r := NewOrganizationResource()
+	testConfigure(t, r, testAPIClient(t, func(w http.ResponseWriter, req *http.Request) {
+		var body map[string]interface{}
+		_ = json.NewDecoder(req.Body).Decode(&body)
+		switch req.URL.Path {
+		case "/auth/login":
+			calls = append(calls, fmt.Sprint(req.URL.Path, " ", body["login"], " ", body["password"]))
+			http.SetCookie(w, &http.Cookie{Name: sessionCookieName, Value: "tenant-c-admin", MaxAge: 3600})
+			_, _ = w.Write([]byte(`{"success": true}`))
+		case "/org/create":
+			calls = append(calls, fmt.Sprint(req.URL.Path, " ", body["adminLogin"]))
+			_, _ = w.Write([]byte(`{"success": true, "result": {"id": 4, "name": "Tenant C"}}`))
+		default:
+			cookie, _ := req.Cookie(sessionCookieName)
+			calls = append(calls, fmt.Sprint(req.URL.Path, " ", body["login"], " as ", cookie.Value))
+			password, _ = body["details"].(map[string]interface{})["password"].(string)
+			_, _ = w.Write([]byte(`{"success": true, "result": 1}`))
+		}
+	}))
+
+	planned := testState(t, r, map[string]interface{}{
+		"name":           "Tenant C",
+		"admin_login":    "tenant-c-admin",
+		"admin_password": "secret",
+		"admin_handover": true,
+	})
+	resp := &resource.CreateResponse{State: planned}
+	r.Create(ctx, resource.CreateRequest{Plan: tfsdk.Plan{Schema: planned.Schema, Raw: planned.Raw}}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+	want := "[/org/create tenant-c-admin /auth/login tenant-c-admin secret /user/setDetails tenant-c-admin as tenant-c-admin]"
+	if fmt.Sprint(calls) != want {
+		t.Errorf("expected %s, got %v", want, calls)
+	}
+	if len(password) != 32 || password == "secret" {
+		t.Errorf("expected a random password, got %q", password)
+	}
+}
+
+func TestAccOrganizationResource(t *testing.T) {
+	acctest.Test(t, acctest.TestCase{
+		PreCheck:                 func() { testAccRealServerPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []acctest.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccOrganizationResourceConfig("tfacc-org"),
+				Check: acctest.ComposeAggregateTestCheckFunc(
+					acctest.TestCheckResourceAttr("uyuni_organization.test", "name", "tfacc-org"),
+					acctest.TestCheckResourceAttrSet("uyuni_organization.test", "org_id"),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: testAccOrganizationResourceConfig("tfacc-org renamed"),
+				Check:  acctest.TestCheckResourceAttr("uyuni_organization.test", "name", "tfacc-org renamed"),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "uyuni_organization.test",
+				ImportState:       true,
+				ImportStateIdFunc: testAccAttribute("uyuni_organization.test", "org_id"),
+				ImportStateVerify: true,
+				// The administrator is only set on creation and imported
+				// organizations are protected against deletion.
+				ImportStateVerifyIgnore: []string{
+					"admin_login", "admin_password", "admin_first_name", "admin_last_name", "admin_email",
+					"admin_prefix", "admin_use_pam", "deletion_protection",
+				},
+			},
+		},
+	})
+}
+
+func testAccOrganizationResourceConfig(name string) string {
+	return fmt.Sprintf(`
+resource "uyuni_organization" "test" {
+  name                = %q
+  admin_login         = "tfacc-org-admin"
+  admin_password      = "tfacc-Secret-123"
+  admin_first_name    = "Terraform"
+  admin_last_name     = "Acceptance"
+  admin_email         = "tfacc-org-admin@example.com"
+  deletion_protection = false
+}
+`, name)
+}
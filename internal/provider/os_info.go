@@ -0,0 +1,209 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"terraform-provider-uyuni/internal/uyuni"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// osDistributions maps prefixes of the lowercase names of base products to
+// the OS family, named like the os_family grain of Salt in lowercase, and
+// the distribution. Longer prefixes come first.
+var osDistributions = []struct {
+	prefix string
+	family string
+	name   string
+}{
+	{"sles_sap", "suse", "sles_sap"},
+	{"sles_es", "redhat", "res"},
+	{"sles", "suse", "sles"},
+	{"sled", "suse", "sled"},
+	{"sle-micro", "suse", "sle_micro"},
+	{"sl-micro", "suse", "sl_micro"},
+	{"suse-microos", "suse", "sle_micro"},
+	{"leap-micro", "suse", "opensuse_leap_micro"},
+	{"leap", "suse", "opensuse_leap"},
+	{"opensuse", "suse", "opensuse_leap"},
+	{"sll", "redhat", "sll"},
+	{"res", "redhat", "res"},
+	{"rhel", "redhat", "rhel"},
+	{"el-base", "redhat", "rhel"},
+	{"almalinux", "redhat", "almalinux"},
+	{"rockylinux", "redhat", "rocky"},
+	{"oraclelinux", "redhat", "oracle"},
+	{"centos", "redhat", "centos"},
+	{"amazonlinux", "redhat", "amazon"},
+	{"ubuntu", "debian", "ubuntu"},
+	{"debian", "debian", "debian"},
+}
+
+// osArchitectures maps architectures of packages and products to the names
+// reported by uname.
+var osArchitectures = map[string]string{
+	"amd64":   "x86_64",
+	"arm64":   "aarch64",
+	"i386":    "i686",
+	"i486":    "i686",
+	"i586":    "i686",
+	"ppc64el": "ppc64le",
+}
+
+// osVersionPattern matches versions like "15.6", "15 SP6", "15-SP6" and
+// "22.04".
+var osVersionPattern = regexp.MustCompile(`(?i)^(\d+)(?:(?:\.|[ -]?SP)(\d+))?`)
+
+// osInfo is the normalized operating system of a system. Fields are empty
+// if they could not be derived.
+type osInfo struct {
+	family       string
+	name         string
+	version      string
+	majorVersion int64
+	arch         string
+	product      string
+}
+
+// normalizeOS derives the operating system from the base product of the
+// installed products.
+func normalizeOS(products []uyuni.InstalledProduct) osInfo {
+	var info osInfo
+	for _, product := range products {
+		if !product.IsBaseProduct {
+			continue
+		}
+		info.product = product.FriendlyName
+
+		productName := strings.ToLower(product.Name)
+		for _, distribution := range osDistributions {
+			if strings.HasPrefix(productName, distribution.prefix) {
+				info.family, info.name = distribution.family, distribution.name
+				break
+			}
+		}
+
+		if match := osVersionPattern.FindStringSubmatch(strings.TrimSpace(product.Version)); match != nil {
+			info.version = match[1]
+			if match[2] != "" {
+				info.version += "." + match[2]
+			}
+			info.majorVersion, _ = strconv.ParseInt(match[1], 10, 64)
+		}
+
+		arch := strings.TrimSuffix(strings.ToLower(product.Arch), "-deb")
+		if normalized, ok := osArchitectures[arch]; ok {
+			arch = normalized
+		}
+		info.arch = arch
+		break
+	}
+	return info
+}
+
+// systemOSInfo returns the normalized operating system of the system.
+func systemOSInfo(ctx context.Context, client *uyuniClient, sid int64) (osInfo, error) {
+	products, err := apiGet[[]uyuni.InstalledProduct](ctx, client, fmt.Sprintf("system/getInstalledProducts?sid=%d", sid))
+	if err != nil {
+		return osInfo{}, err
+	}
+	return normalizeOS(products.Result), nil
+}
+
+// osInfoBySystem returns the normalized operating system of each system,
+// reading limit systems at a time.
+func osInfoBySystem(ctx context.Context, client *uyuniClient, sids []int64, limit int) (map[int64]osInfo, error) {
+	keys := make([]string, 0, len(sids))
+	for _, sid := range sids {
+		keys = append(keys, strconv.FormatInt(sid, 10))
+	}
+	infos := map[int64]osInfo{}
+	var mu sync.Mutex
+	errs := runBatchLimit(keys, limit, func(key string) error {
+		sid, _ := strconv.ParseInt(key, 10, 64)
+		info, err := systemOSInfo(ctx, client, sid)
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		infos[sid] = info
+		return nil
+	})
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("could not list installed products: %w", batchError(errs))
+	}
+	return infos, nil
+}
+
+// osInfoModel maps the normalized operating system attributes of systems.
+type osInfoModel struct {
+	OSFamily       types.String `tfsdk:"os_family"`
+	OSName         types.String `tfsdk:"os_name"`
+	OSVersion      types.String `tfsdk:"os_version"`
+	OSMajorVersion types.Int64  `tfsdk:"os_major_version"`
+	OSArch         types.String `tfsdk:"os_arch"`
+}
+
+// model returns the attributes of the operating system, null if it is
+// unknown.
+func (i *osInfo) model() osInfoModel {
+	if i == nil {
+		return osInfoModel{
+			OSFamily:       types.StringNull(),
+			OSName:         types.StringNull(),
+			OSVersion:      types.StringNull(),
+			OSMajorVersion: types.Int64Null(),
+			OSArch:         types.StringNull(),
+		}
+	}
+	majorVersion := types.Int64Null()
+	if i.version != "" {
+		majorVersion = types.Int64Value(i.majorVersion)
+	}
+	return osInfoModel{
+		OSFamily:       nonEmptyString(i.family),
+		OSName:         nonEmptyString(i.name),
+		OSVersion:      nonEmptyString(i.version),
+		OSMajorVersion: majorVersion,
+		OSArch:         nonEmptyString(i.arch),
+	}
+}
+
+// withOSInfoAttributes adds the schema of the normalized operating system
+// attributes to the attributes of a system and returns them.
+func withOSInfoAttributes(attributes map[string]schema.Attribute) map[string]schema.Attribute {
+	for name, attribute := range map[string]schema.Attribute{
+		"os_family": schema.StringAttribute{
+			Description: "Family of the operating system, named like the os_family grain of Salt in lowercase: " +
+				"`suse`, `redhat` or `debian`. Null if unknown.",
+			Computed: true,
+		},
+		"os_name": schema.StringAttribute{
+			Description: "Distribution, e.g. `sles`, `sles_sap`, `sle_micro`, `opensuse_leap`, `res`, `rhel`, `almalinux`, " +
+				"`rocky`, `oracle`, `ubuntu` or `debian`. Null if unknown.",
+			Computed: true,
+		},
+		"os_version": schema.StringAttribute{
+			Description: "Version of the distribution as major and minor version, e.g. `15.6` for SLES 15 SP6 or `22.04`.",
+			Computed:    true,
+		},
+		"os_major_version": schema.Int64Attribute{
+			Description: "Major version of the distribution, e.g. `15`.",
+			Computed:    true,
+		},
+		"os_arch": schema.StringAttribute{
+			Description: "Architecture as reported by uname, e.g. `x86_64` or `aarch64`, also for Debian based systems.",
+			Computed:    true,
+		},
+	} {
+		attributes[name] = attribute
+	}
+	return attributes
+}
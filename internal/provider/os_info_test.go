@@ -0,0 +1,39 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"terraform-provider-uyuni/internal/uyuni"
+)
+
+func TestNormalizeOS(t *testing.T) {
+	for _, tc := range []struct {
+		base uyuni.InstalledProduct
+		want string
+	}{
+		{uyuni.InstalledProduct{Name: "SLES", Version: "15.6", Arch: "x86_64"}, "suse sles 15.6 15 x86_64"},
+		{uyuni.InstalledProduct{Name: "SLES_SAP", Version: "15 SP5", Arch: "ppc64le"}, "suse sles_sap 15.5 15 ppc64le"},
+		{uyuni.InstalledProduct{Name: "SLE-Micro", Version: "5.5", Arch: "aarch64"}, "suse sle_micro 5.5 5 aarch64"},
+		{uyuni.InstalledProduct{Name: "Leap", Version: "15.5", Arch: "x86_64"}, "suse opensuse_leap 15.5 15 x86_64"},
+		{uyuni.InstalledProduct{Name: "RES", Version: "8", Arch: "x86_64"}, "redhat res 8 8 x86_64"},
+		{uyuni.InstalledProduct{Name: "rockylinux", Version: "9", Arch: "x86_64"}, "redhat rocky 9 9 x86_64"},
+		{uyuni.InstalledProduct{Name: "ubuntu-client", Version: "22.04", Arch: "amd64-deb"}, "debian ubuntu 22.04 22 x86_64"},
+		{uyuni.InstalledProduct{Name: "debian-client", Version: "12", Arch: "arm64-deb"}, "debian debian 12 12 aarch64"},
+		{uyuni.InstalledProduct{Name: "unknown", Version: "", Arch: "i586"}, "   0 i686"},
+	} {
+		tc.base.IsBaseProduct = true
+		info := normalizeOS([]uyuni.InstalledProduct{
+			{Name: "sle-module-basesystem", Version: "15.6", Arch: "x86_64"},
+			tc.base,
+		})
+		got := fmt.Sprintf("%s %s %s %d %s", info.family, info.name, info.version, info.majorVersion, info.arch)
+		if got != tc.want {
+			t.Errorf("%s %s: expected %q, got %q", tc.base.Name, tc.base.Version, tc.want, got)
+		}
+	}
+
+	if info := normalizeOS(nil); info != (osInfo{}) {
+		t.Errorf("expected nothing without a base product, got %+v", info)
+	}
+}
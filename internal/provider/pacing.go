@@ -0,0 +1,238 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// pacingMaxConcurrency is the number of API calls a server gets at the same
+// time while it answers promptly, unless the provider sets
+// max_concurrent_requests. Terraform and batches rarely exceed it.
+const pacingMaxConcurrency = 32
+
+// pacingSlowFactor is how much slower than usual an endpoint has to answer
+// for the server to be considered overloaded, and pacingSlowMinLatency the
+// latency below which no call is considered slow.
+const (
+	pacingSlowFactor     = 4
+	pacingSlowMinLatency = time.Second
+)
+
+// pacingMinDelay is the delay before each call once a single call at a time
+// still overloads the server. It doubles with every further overload up to
+// pacingMaxDelay. Both are variables so that tests do not have to wait.
+var (
+	pacingMinDelay = 500 * time.Millisecond
+	pacingMaxDelay = 30 * time.Second
+)
+
+// pacer adapts the number of concurrent API calls to how the server copes
+// with them. The API of Uyuni slows down dramatically when hundreds of
+// objects are created at once, so the pacer halves the calls it lets through
+// whenever responses get much slower than usual or a proxy reports the server
+// unavailable, delays calls exponentially once it is down to one, and lets more calls
+// through again one at a time while the server answers promptly. Calls can
+// further be spaced to a fixed rate.
+type pacer struct {
+	mu    sync.Mutex
+	limit int
+	// maxLimit is the limit of a server answering promptly.
+	maxLimit int
+	active   int
+	// released is closed and replaced whenever a call finishes, to wake up
+	// the calls waiting for their turn.
+	released chan struct{}
+	delay    time.Duration
+	// healthy counts the healthy calls since the pace last changed.
+	healthy int
+	// inFlight counts the calls still running which were sent before the
+	// pace last slowed down, so that it slows down once per overload.
+	inFlight int
+	// latencies are the usual latencies of healthy calls by endpoint.
+	latencies map[string]time.Duration
+	// interval is the minimum time between the starts of two calls, zero
+	// if the rate is not limited, and next the earliest start of the next
+	// call.
+	interval time.Duration
+	next     time.Time
+}
+
+func newPacer() *pacer {
+	return &pacer{
+		limit:     pacingMaxConcurrency,
+		maxLimit:  pacingMaxConcurrency,
+		released:  make(chan struct{}),
+		latencies: map[string]time.Duration{},
+	}
+}
+
+// configure limits the concurrent calls to maxConcurrency and spaces the
+// starts of the calls by at least interval. A maxConcurrency of zero keeps
+// the default, an interval of zero does not limit the rate.
+func (p *pacer) configure(maxConcurrency int, interval time.Duration) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if maxConcurrency > 0 {
+		p.maxLimit = maxConcurrency
+		p.limit = maxConcurrency
+	}
+	p.interval = interval
+}
+
+// acquire waits until a call may be sent, or ctx is done.
+func (p *pacer) acquire(ctx context.Context) error {
+	if p == nil {
+		return nil
+	}
+	for {
+		p.mu.Lock()
+		if p.active < p.limit {
+			p.active++
+			delay := p.delay
+			if p.interval > 0 {
+				// Each call reserves the next start, so that the
+				// calls waiting concurrently keep their spacing.
+				now := time.Now()
+				start := now
+				if p.next.After(now) {
+					start = p.next
+				}
+				p.next = start.Add(p.interval)
+				delay = max(delay, start.Sub(now))
+			}
+			p.mu.Unlock()
+			if delay == 0 {
+				return nil
+			}
+			select {
+			case <-ctx.Done():
+				p.release(ctx, "", 0, 0)
+				return ctx.Err()
+			case <-time.After(delay):
+				return nil
+			}
+		}
+		released := p.released
+		p.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-released:
+		}
+	}
+}
+
+// release finishes a call to the endpoint which took latency and returned
+// the HTTP status, and adapts the pace to it. Calls which were not sent, with
+// an empty endpoint, or received no response, with status zero, leave the
+// pace as it is.
+func (p *pacer) release(ctx context.Context, endpoint string, status int, latency time.Duration) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.active--
+	close(p.released)
+	p.released = make(chan struct{})
+	if endpoint == "" {
+		return
+	}
+	if status == 0 {
+		p.inFlight = max(p.inFlight-1, 0)
+		return
+	}
+
+	usual, known := p.latencies[endpoint]
+	overloaded := overloadStatus(status) ||
+		(known && latency > pacingSlowMinLatency && latency > pacingSlowFactor*usual)
+	if !overloaded {
+		// The usual latency follows healthy calls, weighting the latest
+		// one by an eighth.
+		if known {
+			p.latencies[endpoint] = usual - usual/8 + latency/8
+		} else {
+			p.latencies[endpoint] = latency
+		}
+	}
+	if p.inFlight > 0 {
+		// Calls sent before the pace last slowed down tell nothing about
+		// the new pace.
+		p.inFlight--
+		if overloaded {
+			return
+		}
+	}
+
+	ctx = withLogSubsystem(ctx, logSubsystemClient)
+	if overloaded {
+		p.healthy = 0
+		p.inFlight = p.active
+		if p.limit > 1 {
+			p.limit /= 2
+			tflog.SubsystemWarn(ctx, logSubsystemClient, "Uyuni server overloaded, reducing concurrent API calls", map[string]interface{}{
+				"endpoint":   endpoint,
+				"status":     status,
+				"latency_ms": latency.Milliseconds(),
+				"limit":      p.limit,
+			})
+			return
+		}
+		p.delay = min(max(2*p.delay, pacingMinDelay), pacingMaxDelay)
+		tflog.SubsystemWarn(ctx, logSubsystemClient, "Uyuni server overloaded, delaying API calls", map[string]interface{}{
+			"endpoint":   endpoint,
+			"status":     status,
+			"latency_ms": latency.Milliseconds(),
+			"delay":      p.delay.String(),
+		})
+		return
+	}
+
+	// A round of healthy calls speeds the pace up by a step.
+	p.healthy++
+	if p.healthy < p.limit {
+		return
+	}
+	p.healthy = 0
+	switch {
+	case p.delay > 0:
+		p.delay /= 2
+		if p.delay < pacingMinDelay {
+			p.delay = 0
+		}
+	case p.limit < p.maxLimit:
+		p.limit++
+		tflog.SubsystemDebug(ctx, logSubsystemClient, "Uyuni server recovering, allowing more concurrent API calls", map[string]interface{}{
+			"limit": p.limit,
+		})
+	}
+}
+
+// overloadStatus reports whether the HTTP status of a response means that the
+// server is overloaded. Faults of the API itself have status 500, e.g. for
+// objects that do not exist, and say nothing about the load of the server.
+func overloadStatus(status int) bool {
+	for _, transient := range transientStatuses {
+		if status == transient {
+			return true
+		}
+	}
+	return false
+}
+
+// pacingEndpoint returns the endpoint the latency of a call is compared
+// with, the method and path without query.
+func pacingEndpoint(method, path string) string {
+	endpoint, _, _ := strings.Cut(path, "?")
+	return method + " " + endpoint
+}
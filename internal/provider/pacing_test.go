@@ -0,0 +1,156 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestPacerHalvesConcurrencyOncePerOverload(t *testing.T) {
+	ctx := context.Background()
+	p := newPacer()
+	for i := 0; i < 4; i++ {
+		if err := p.acquire(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// All calls running during an overload fail, which slows down once.
+	for i := 0; i < 4; i++ {
+		p.release(ctx, "POST user/create", http.StatusServiceUnavailable, time.Second)
+	}
+	if p.limit != pacingMaxConcurrency/2 {
+		t.Fatalf("expected limit %d, got %d", pacingMaxConcurrency/2, p.limit)
+	}
+
+	_ = p.acquire(ctx)
+	p.release(ctx, "POST user/create", http.StatusGatewayTimeout, time.Second)
+	if p.limit != pacingMaxConcurrency/4 {
+		t.Fatalf("expected limit %d, got %d", pacingMaxConcurrency/4, p.limit)
+	}
+
+	// A round of healthy calls lets one more call through.
+	for i := 0; i < pacingMaxConcurrency/4; i++ {
+		_ = p.acquire(ctx)
+		p.release(ctx, "POST user/create", http.StatusOK, time.Second)
+	}
+	if p.limit != pacingMaxConcurrency/4+1 {
+		t.Errorf("expected limit %d, got %d", pacingMaxConcurrency/4+1, p.limit)
+	}
+}
+
+func TestPacerIgnoresAPIFaults(t *testing.T) {
+	ctx := context.Background()
+	client := testAPIClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(`{"success": false, "message": "User already exists"}`))
+	})
+	client.pacer = newPacer()
+	for i := 0; i < 8; i++ {
+		if _, err := apiPost[int](ctx, client, "user/create", map[string]interface{}{"login": "jdoe"}); err == nil {
+			t.Fatal("expected an error")
+		}
+	}
+	if client.pacer.limit != pacingMaxConcurrency || client.pacer.delay != 0 {
+		t.Errorf("expected faults to leave the pace alone, got limit %d and delay %s", client.pacer.limit, client.pacer.delay)
+	}
+}
+
+func TestPacerSlowsDownOnRisingLatency(t *testing.T) {
+	ctx := context.Background()
+	p := newPacer()
+	for _, latency := range []time.Duration{400 * time.Millisecond, 500 * time.Millisecond, 3 * time.Second} {
+		_ = p.acquire(ctx)
+		p.release(ctx, "POST system/createSystemRecord", http.StatusOK, latency)
+	}
+	if p.limit != pacingMaxConcurrency/2 {
+		t.Fatalf("expected limit %d, got %d", pacingMaxConcurrency/2, p.limit)
+	}
+
+	// Slow calls to another endpoint are compared with its own latency.
+	_ = p.acquire(ctx)
+	p.release(ctx, "GET system/listSystems", http.StatusOK, 3*time.Second)
+	if p.limit != pacingMaxConcurrency/2 {
+		t.Errorf("expected limit %d, got %d", pacingMaxConcurrency/2, p.limit)
+	}
+}
+
+func TestPacerDelaysCallsExponentially(t *testing.T) {
+	defer func(interval time.Duration) { pacingMinDelay = interval }(pacingMinDelay)
+	pacingMinDelay = time.Millisecond
+
+	ctx := context.Background()
+	client := testAPIClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_, _ = w.Write([]byte(`{"success": false, "message": "Service Unavailable"}`))
+	})
+	client.pacer = newPacer()
+	client.pacer.limit = 1
+	for i := 0; i < 3; i++ {
+		if _, err := apiGet[int](ctx, client, "user/listUsers"); err == nil {
+			t.Fatal("expected an error")
+		}
+	}
+	if client.pacer.delay != 4*time.Millisecond {
+		t.Errorf("expected delay %s, got %s", 4*time.Millisecond, client.pacer.delay)
+	}
+}
+
+func TestPacerWaitsForItsTurn(t *testing.T) {
+	p := newPacer()
+	p.limit = 1
+	_ = p.acquire(context.Background())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := p.acquire(ctx); err == nil {
+		t.Fatal("expected the call to wait beyond the limit")
+	}
+
+	done := make(chan error)
+	go func() { done <- p.acquire(context.Background()) }()
+	p.release(context.Background(), "GET user/listUsers", http.StatusOK, time.Millisecond)
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestPacerConfigureCapsConcurrency(t *testing.T) {
+	ctx := context.Background()
+	p := newPacer()
+	p.configure(2, 0)
+	_ = p.acquire(ctx)
+	_ = p.acquire(ctx)
+
+	waitCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	if err := p.acquire(waitCtx); err == nil {
+		t.Fatal("expected the call to wait beyond the configured limit")
+	}
+
+	// Healthy calls do not raise the limit beyond the configured one.
+	for i := 0; i < 4; i++ {
+		p.release(ctx, "GET user/listUsers", http.StatusOK, time.Millisecond)
+		_ = p.acquire(ctx)
+	}
+	if p.limit != 2 {
+		t.Errorf("expected limit 2, got %d", p.limit)
+	}
+}
+
+func TestPacerLimitsRate(t *testing.T) {
+	ctx := context.Background()
+	p := newPacer()
+	p.configure(0, 20*time.Millisecond)
+
+	start := time.Now()
+	for i := 0; i < 4; i++ {
+		if err := p.acquire(ctx); err != nil {
+			t.Fatal(err)
+		}
+		p.release(ctx, "GET user/listUsers", http.StatusOK, time.Millisecond)
+	}
+	if elapsed := time.Since(start); elapsed < 60*time.Millisecond {
+		t.Errorf("expected the calls to be spaced, took %s", elapsed)
+	}
+}
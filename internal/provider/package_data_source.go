@@ -0,0 +1,169 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+
+	"terraform-provider-uyuni/internal/uyuni"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &PackageDataSource{}
+	_ datasource.DataSourceWithConfigure = &PackageDataSource{}
+)
+
+// PackageDataSourceModel maps the data source schema data.
+type PackageDataSourceModel struct {
+	Name    types.String `tfsdk:"name"`
+	Version types.String `tfsdk:"version"`
+	Release types.String `tfsdk:"release"`
+	Epoch   types.String `tfsdk:"epoch"`
+	Arch    types.String `tfsdk:"arch"`
+	ID      types.Int64  `tfsdk:"id"`
+	IDs     types.Set    `tfsdk:"ids"`
+	NEVRA   types.String `tfsdk:"nevra"`
+}
+
+// NewPackageDataSource is a helper function to simplify the provider implementation.
+func NewPackageDataSource() datasource.DataSource {
+	return &PackageDataSource{}
+}
+
+// PackageDataSource is the data source implementation.
+type PackageDataSource struct {
+	client *uyuniClient
+}
+
+// Metadata returns the data source type name.
+func (d *PackageDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_package"
+}
+
+// Schema defines the schema for the data source.
+func (d *PackageDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Looks up a package by name, epoch, version, release and architecture, e.g. to add it to a channel with uyuni_channel_packages.",
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Description: "Name of the package.",
+				Required:    true,
+			},
+			"version": schema.StringAttribute{
+				Description: "Version of the package.",
+				Required:    true,
+			},
+			"release": schema.StringAttribute{
+				Description: "Release of the package.",
+				Required:    true,
+			},
+			"epoch": schema.StringAttribute{
+				Description: "Epoch of the package, empty for packages without epoch.",
+				Optional:    true,
+			},
+			"arch": schema.StringAttribute{
+				Description: "Architecture label of the package, e.g. x86_64 or noarch.",
+				Required:    true,
+			},
+			"id": schema.Int64Attribute{
+				Description: "ID of the package. If several packages match, e.g. builds of different vendors, the lowest of their ids.",
+				Computed:    true,
+			},
+			"ids": schema.SetAttribute{
+				Description: "IDs of all matching packages.",
+				ElementType: types.Int64Type,
+				Computed:    true,
+			},
+			"nevra": schema.StringAttribute{
+				Description: "Name-[epoch:]version-release.arch of the package.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *PackageDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state PackageDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	query := url.Values{
+		"name":      {state.Name.ValueString()},
+		"version":   {state.Version.ValueString()},
+		"release":   {state.Release.ValueString()},
+		"epoch":     {state.Epoch.ValueString()},
+		"archLabel": {state.Arch.ValueString()},
+	}
+	packages, err := apiGet[[]uyuni.Package](ctx, d.client, "packages/findByNvrea?"+query.Encode())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Uyuni package",
+			err.Error(),
+		)
+		return
+	}
+	if len(packages.Result) == 0 {
+		pkg := uyuni.Package{
+			Name:      state.Name.ValueString(),
+			Version:   state.Version.ValueString(),
+			Release:   state.Release.ValueString(),
+			Epoch:     state.Epoch.ValueString(),
+			ArchLabel: state.Arch.ValueString(),
+		}
+		resp.Diagnostics.AddError(
+			"Package Not Found",
+			"No package "+pkg.NEVRA()+" is available to the provider user.",
+		)
+		return
+	}
+
+	sort.Slice(packages.Result, func(i, j int) bool {
+		return packages.Result[i].ID < packages.Result[j].ID
+	})
+	ids := make([]int64, 0, len(packages.Result))
+	for _, pkg := range packages.Result {
+		ids = append(ids, int64(pkg.ID))
+	}
+	state.ID = types.Int64Value(ids[0])
+	state.IDs, diags = types.SetValueFrom(ctx, types.Int64Type, ids)
+	resp.Diagnostics.Append(diags...)
+	state.NEVRA = types.StringValue(packages.Result[0].NEVRA())
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *PackageDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*uyuniClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *uyuniClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
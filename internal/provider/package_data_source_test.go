@@ -0,0 +1,102 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func testReadPackage(t *testing.T, result string) *datasource.ReadResponse {
+	ctx := context.Background()
+	client := testAPIClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/packages/findByNvrea" || r.URL.Query().Get("archLabel") != "x86_64" {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		_, _ = w.Write([]byte(`{"success": true, "result": ` + result + `}`))
+	})
+
+	d := NewPackageDataSource()
+	d.(datasource.DataSourceWithConfigure).Configure(ctx, datasource.ConfigureRequest{ProviderData: client}, &datasource.ConfigureResponse{})
+	var schemaResp datasource.SchemaResponse
+	d.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+
+	config := tfsdk.Config{
+		Schema: schemaResp.Schema,
+		Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), map[string]tftypes.Value{
+			"name":    tftypes.NewValue(tftypes.String, "openssl-3"),
+			"version": tftypes.NewValue(tftypes.String, "3.1.4"),
+			"release": tftypes.NewValue(tftypes.String, "150600.5.10.1"),
+			"epoch":   tftypes.NewValue(tftypes.String, nil),
+			"arch":    tftypes.NewValue(tftypes.String, "x86_64"),
+			"id":      tftypes.NewValue(tftypes.Number, nil),
+			"ids":     tftypes.NewValue(tftypes.Set{ElementType: tftypes.Number}, nil),
+			"nevra":   tftypes.NewValue(tftypes.String, nil),
+		}),
+	}
+	resp := &datasource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: config.Raw}}
+	d.Read(ctx, datasource.ReadRequest{Config: config}, resp)
+	return resp
+}
+
+func TestPackageDataSourcePicksLowestID(t *testing.T) {
+	resp := testReadPackage(t, `[
+		{"id": 12002, "name": "openssl-3", "version": "3.1.4", "release": "150600.5.10.1", "epoch": "", "arch_label": "x86_64"},
+		{"id": 12001, "name": "openssl-3", "version": "3.1.4", "release": "150600.5.10.1", "epoch": "", "arch_label": "x86_64"}
+	]`)
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+
+	var state PackageDataSourceModel
+	resp.State.Get(context.Background(), &state)
+	if state.ID.ValueInt64() != 12001 || len(state.IDs.Elements()) != 2 {
+		t.Errorf("got id %s and ids %s", state.ID, state.IDs)
+	}
+	if state.NEVRA.ValueString() != "openssl-3-3.1.4-150600.5.10.1.x86_64" {
+		t.Errorf("got nevra %s", state.NEVRA)
+	}
+}
+
+func TestPackageDataSourceNotFound(t *testing.T) {
+	resp := testReadPackage(t, `[]`)
+	if !resp.Diagnostics.HasError() || !strings.Contains(resp.Diagnostics[0].Detail(), "openssl-3-3.1.4-150600.5.10.1.x86_64") {
+		t.Errorf("expected an error naming the package, got %v", resp.Diagnostics)
+	}
+}
+
+func TestAccPackageDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccRealServerPreCheck(t)
+			testAccSkipUnlessEnv(t, "UYUNI_TEST_SOURCE_CHANNEL", "a synchronized base channel")
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "uyuni_package_search" "test" {
+  query = "name:zypper AND arch:x86_64"
+}
+
+data "uyuni_package" "test" {
+  name    = data.uyuni_package_search.test.packages[0].name
+  epoch   = data.uyuni_package_search.test.packages[0].epoch
+  version = data.uyuni_package_search.test.packages[0].version
+  release = data.uyuni_package_search.test.packages[0].release
+  arch    = data.uyuni_package_search.test.packages[0].arch
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.uyuni_package.test", "id", "data.uyuni_package_search.test", "packages.0.id"),
+					resource.TestCheckResourceAttrPair("data.uyuni_package.test", "nevra", "data.uyuni_package_search.test", "packages.0.nevra"),
+				),
+			},
+		},
+	})
+}
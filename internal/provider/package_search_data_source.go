@@ -0,0 +1,241 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"terraform-provider-uyuni/internal/uyuni"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &PackageSearchDataSource{}
+	_ datasource.DataSourceWithConfigure = &PackageSearchDataSource{}
+)
+
+// PackageSearchDataSourceModel maps the data source schema data.
+type PackageSearchDataSourceModel struct {
+	Query    types.String        `tfsdk:"query"`
+	IDs      types.Set           `tfsdk:"ids"`
+	Packages []foundPackageModel `tfsdk:"packages"`
+}
+
+// foundPackageModel maps package search result schema data.
+type foundPackageModel struct {
+	ID       types.Int64  `tfsdk:"id"`
+	Name     types.String `tfsdk:"name"`
+	Epoch    types.String `tfsdk:"epoch"`
+	Version  types.String `tfsdk:"version"`
+	Release  types.String `tfsdk:"release"`
+	Arch     types.String `tfsdk:"arch"`
+	NEVRA    types.String `tfsdk:"nevra"`
+	Summary  types.String `tfsdk:"summary"`
+	Channels types.Set    `tfsdk:"channels"`
+}
+
+// NewPackageSearchDataSource is a helper function to simplify the provider implementation.
+func NewPackageSearchDataSource() datasource.DataSource {
+	return &PackageSearchDataSource{}
+}
+
+// PackageSearchDataSource is the data source implementation.
+type PackageSearchDataSource struct {
+	client *uyuniClient
+}
+
+// Metadata returns the data source type name.
+func (d *PackageSearchDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_package_search"
+}
+
+// Schema defines the schema for the data source.
+func (d *PackageSearchDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Searches packages with a Lucene query, e.g. to add them to a channel with uyuni_channel_packages. " +
+			"Requires the search server of Uyuni to be running.",
+		Attributes: map[string]schema.Attribute{
+			"query": schema.StringAttribute{
+				Description: "Lucene query over the fields name, epoch, version, release, arch, description and summary, " +
+					"e.g. `name:openssl* AND arch:x86_64`.",
+				Required: true,
+			},
+			"ids": schema.SetAttribute{
+				Description: "IDs of all found packages.",
+				ElementType: types.Int64Type,
+				Computed:    true,
+			},
+			"packages": schema.ListNestedAttribute{
+				Description: "Found packages, ordered by ID.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.Int64Attribute{
+							Description: "ID of the package.",
+							Computed:    true,
+						},
+						"name": schema.StringAttribute{
+							Description: "Name of the package.",
+							Computed:    true,
+						},
+						"epoch": schema.StringAttribute{
+							Description: "Epoch of the package, empty for packages without epoch.",
+							Computed:    true,
+						},
+						"version": schema.StringAttribute{
+							Description: "Version of the package.",
+							Computed:    true,
+						},
+						"release": schema.StringAttribute{
+							Description: "Release of the package.",
+							Computed:    true,
+						},
+						"arch": schema.StringAttribute{
+							Description: "Architecture label of the package.",
+							Computed:    true,
+						},
+						"nevra": schema.StringAttribute{
+							Description: "Name-[epoch:]version-release.arch of the package.",
+							Computed:    true,
+						},
+						"summary": schema.StringAttribute{
+							Description: "Summary of the package.",
+							Computed:    true,
+						},
+						"channels": schema.SetAttribute{
+							Description: "Labels of the channels providing the package.",
+							ElementType: types.StringType,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// listProvidingChannels returns the labels of the channels providing each of
+// the packages, by package id.
+func listProvidingChannels(ctx context.Context, client *uyuniClient, ids []int) (map[int][]string, error) {
+	keys := make([]string, 0, len(ids))
+	for _, id := range ids {
+		keys = append(keys, strconv.Itoa(id))
+	}
+
+	var mu sync.Mutex
+	channels := map[int][]string{}
+	errs := runBatch(keys, func(key string) error {
+		result, err := apiGet[[]uyuni.ProvidingChannel](ctx, client, "packages/listProvidingChannels?pid="+key)
+		if err != nil {
+			return err
+		}
+		labels := make([]string, 0, len(result.Result))
+		for _, channel := range result.Result {
+			labels = append(labels, channel.Label)
+		}
+		id, _ := strconv.Atoi(key)
+		mu.Lock()
+		channels[id] = labels
+		mu.Unlock()
+		return nil
+	})
+	if len(errs) > 0 {
+		failed := make([]string, 0, len(errs))
+		for key, err := range errs {
+			failed = append(failed, fmt.Sprintf("package %s: %s", key, err))
+		}
+		sort.Strings(failed)
+		return nil, fmt.Errorf("could not list providing channels of %s", strings.Join(failed, "; "))
+	}
+	return channels, nil
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *PackageSearchDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state PackageSearchDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	packages, err := apiGet[[]uyuni.PackageOverview](ctx, d.client, "packages/search/advanced?luceneQuery="+url.QueryEscape(state.Query.ValueString()))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Search Uyuni packages",
+			err.Error(),
+		)
+		return
+	}
+	sort.Slice(packages.Result, func(i, j int) bool {
+		return packages.Result[i].ID < packages.Result[j].ID
+	})
+
+	ids := make([]int, 0, len(packages.Result))
+	for _, pkg := range packages.Result {
+		ids = append(ids, pkg.ID)
+	}
+	channels, err := listProvidingChannels(ctx, d.client, ids)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Search Uyuni packages",
+			err.Error(),
+		)
+		return
+	}
+
+	state.Packages = make([]foundPackageModel, 0, len(packages.Result))
+	for _, pkg := range packages.Result {
+		labels, diags := types.SetValueFrom(ctx, types.StringType, channels[pkg.ID])
+		resp.Diagnostics.Append(diags...)
+		state.Packages = append(state.Packages, foundPackageModel{
+			ID:       types.Int64Value(int64(pkg.ID)),
+			Name:     types.StringValue(pkg.Name),
+			Epoch:    types.StringValue(pkg.Epoch),
+			Version:  types.StringValue(pkg.Version),
+			Release:  types.StringValue(pkg.Release),
+			Arch:     types.StringValue(pkg.Arch),
+			NEVRA:    types.StringValue(pkg.NEVRA()),
+			Summary:  types.StringValue(pkg.Summary),
+			Channels: labels,
+		})
+	}
+	state.IDs, diags = types.SetValueFrom(ctx, types.Int64Type, ids)
+	resp.Diagnostics.Append(diags...)
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *PackageSearchDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*uyuniClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *uyuniClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
@@ -0,0 +1,101 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func testSearchPackages(t *testing.T, handler http.HandlerFunc) *datasource.ReadResponse {
+	ctx := context.Background()
+	d := NewPackageSearchDataSource()
+	d.(datasource.DataSourceWithConfigure).Configure(ctx, datasource.ConfigureRequest{ProviderData: testAPIClient(t, handler)}, &datasource.ConfigureResponse{})
+	var schemaResp datasource.SchemaResponse
+	d.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	config := tfsdk.Config{
+		Schema: schemaResp.Schema,
+		Raw: tftypes.NewValue(objectType, map[string]tftypes.Value{
+			"query":    tftypes.NewValue(tftypes.String, "name:openssl*"),
+			"ids":      tftypes.NewValue(objectType.AttributeTypes["ids"], nil),
+			"packages": tftypes.NewValue(objectType.AttributeTypes["packages"], nil),
+		}),
+	}
+	resp := &datasource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: config.Raw}}
+	d.Read(ctx, datasource.ReadRequest{Config: config}, resp)
+	return resp
+}
+
+func TestPackageSearchDataSource(t *testing.T) {
+	resp := testSearchPackages(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/packages/search/advanced" && r.URL.Query().Get("luceneQuery") == "name:openssl*":
+			_, _ = w.Write([]byte(`{"success": true, "result": [
+				{"id": 12002, "name": "openssl-3", "epoch": "", "version": "3.1.4", "release": "150600.5.10.1", "arch": "aarch64", "summary": "TLS", "description": ""},
+				{"id": 12001, "name": "openssl-3", "epoch": "", "version": "3.1.4", "release": "150600.5.10.1", "arch": "x86_64", "summary": "TLS", "description": ""}
+			]}`))
+		case r.URL.Path == "/packages/listProvidingChannels" && r.URL.Query().Get("pid") == "12001":
+			_, _ = w.Write([]byte(`{"success": true, "result": [{"label": "updates-x86_64", "parent_label": "pool-x86_64", "name": "Updates"}]}`))
+		case r.URL.Path == "/packages/listProvidingChannels":
+			_, _ = w.Write([]byte(`{"success": true, "result": []}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL)
+		}
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+
+	var state PackageSearchDataSourceModel
+	resp.State.Get(context.Background(), &state)
+	if len(state.Packages) != 2 || len(state.IDs.Elements()) != 2 {
+		t.Fatalf("expected two packages, got %v", state.Packages)
+	}
+	first := state.Packages[0]
+	if first.ID.ValueInt64() != 12001 || first.NEVRA.ValueString() != "openssl-3-3.1.4-150600.5.10.1.x86_64" {
+		t.Errorf("expected the packages ordered by id, got %v", first)
+	}
+	if first.Channels.String() != `["updates-x86_64"]` || len(state.Packages[1].Channels.Elements()) != 0 {
+		t.Errorf("got channels %s and %s", first.Channels, state.Packages[1].Channels)
+	}
+}
+
+func TestPackageSearchDataSourceReportsChannelErrors(t *testing.T) {
+	resp := testSearchPackages(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/packages/search/advanced" {
+			_, _ = w.Write([]byte(`{"success": true, "result": [{"id": 12001, "name": "openssl-3", "epoch": "", "version": "3.1.4", "release": "1", "arch": "x86_64", "summary": "", "description": ""}]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"success": false, "message": "No such package: 12001"}`))
+	})
+	if !resp.Diagnostics.HasError() || !strings.Contains(resp.Diagnostics[0].Detail(), "package 12001: ") {
+		t.Errorf("expected an error naming the package, got %v", resp.Diagnostics)
+	}
+}
+
+func TestAccPackageSearchDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccRealServerPreCheck(t)
+			testAccSkipUnlessEnv(t, "UYUNI_TEST_SOURCE_CHANNEL", "a synchronized base channel")
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `data "uyuni_package_search" "test" { query = "name:zypper AND arch:x86_64" }`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.uyuni_package_search.test", "packages.0.name", "zypper"),
+					resource.TestCheckResourceAttr("data.uyuni_package_search.test", "packages.0.arch", "x86_64"),
+					resource.TestCheckResourceAttrPair("data.uyuni_package_search.test", "ids.#", "data.uyuni_package_search.test", "packages.#"),
+				),
+			},
+		},
+	})
+}
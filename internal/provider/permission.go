@@ -0,0 +1,78 @@
+package provider
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"terraform-provider-uyuni/internal/uyuni"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Roles of users which list endpoints require.
+const (
+	roleOrgAdmin       = "org_admin"
+	roleSatelliteAdmin = "satellite_admin"
+)
+
+// permissionMessages are the fault messages the API uses for calls the user
+// lacks the role for, like "You do not have permissions to perform this
+// action" or "The user does not have the necessary role(s): org_admin".
+var permissionMessages = []string{
+	"permission",
+	"necessary role",
+	"not authorized",
+	"access denied",
+}
+
+// isPermissionError reports whether an API error means the user lacks the
+// role for the call.
+func isPermissionError(err error) bool {
+	var fault *uyuni.Fault
+	if !errors.As(err, &fault) {
+		return false
+	}
+	if fault.StatusCode == http.StatusForbidden {
+		return true
+	}
+	msg := strings.ToLower(fault.Message)
+	for _, denied := range permissionMessages {
+		if strings.Contains(msg, denied) {
+			return true
+		}
+	}
+	return false
+}
+
+// ignorePermissionErrorsAttribute is the schema of the
+// ignore_permission_errors attribute of data sources listing objects only
+// some roles may list.
+func ignorePermissionErrorsAttribute(role string) schema.BoolAttribute {
+	return schema.BoolAttribute{
+		Description: "Return an empty list with a warning instead of failing when the provider user lacks the " +
+			"`" + role + "` role, e.g. for partially privileged accounts. Defaults to false.",
+		Optional: true,
+	}
+}
+
+// handleListError reports the error of a list call of a data source and
+// returns whether the data source carries on with an empty list, which it
+// does for permission faults if ignore is true. Permission faults name the
+// role the call requires instead of the fault of the server.
+func handleListError(diags *diag.Diagnostics, summary, endpoint string, err error, role string, ignore types.Bool) bool {
+	if !isPermissionError(err) {
+		diags.AddError(summary, err.Error())
+		return false
+	}
+	detail := "The provider user lacks the " + role + " role, which " + endpoint + " requires: " + err.Error()
+	if ignore.ValueBool() {
+		diags.AddAttributeWarning(path.Root("ignore_permission_errors"), "Insufficient Role", detail+". The list is empty.")
+		return true
+	}
+	diags.AddError("Insufficient Role", detail+". Grant the role or set ignore_permission_errors.")
+	return false
+}
@@ -0,0 +1,57 @@
+package provider
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"terraform-provider-uyuni/internal/uyuni"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestIsPermissionError(t *testing.T) {
+	for name, tc := range map[string]struct {
+		err  error
+		want bool
+	}{
+		"permissions": {&uyuni.Fault{Message: "You do not have permissions to perform this action."}, true},
+		"role":        {&uyuni.Fault{Message: "The user does not have the necessary role(s): org_admin"}, true},
+		"HTTP 403":    {&uyuni.Fault{StatusCode: http.StatusForbidden}, true},
+		"other fault": {&uyuni.Fault{Message: "No such user: jdoe"}, false},
+		"transport":   {errors.New("connection refused"), false},
+	} {
+		if got := isPermissionError(tc.err); got != tc.want {
+			t.Errorf("%s: isPermissionError(%q) = %v, want %v", name, tc.err, got, tc.want)
+		}
+	}
+}
+
+func TestListDataSourcesReportInsufficientRole(t *testing.T) {
+	client := testAPIClient(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"success": false, "message": "You do not have permissions to perform this action."}`))
+	})
+
+	for name, tc := range map[string]struct {
+		dataSource func() datasource.DataSource
+		role       string
+	}{
+		"users":             {NewUsersDataSource, roleOrgAdmin},
+		"entitlement_usage": {NewEntitlementUsageDataSource, roleSatelliteAdmin},
+	} {
+		resp := testDataSourceRead(t, tc.dataSource(), client, nil)
+		errs := resp.Diagnostics.Errors()
+		if len(errs) != 1 || errs[0].Summary() != "Insufficient Role" || !strings.Contains(errs[0].Detail(), tc.role) {
+			t.Errorf("%s: expected an error naming the %s role, got %v", name, tc.role, resp.Diagnostics)
+		}
+
+		resp = testDataSourceRead(t, tc.dataSource(), client, map[string]tftypes.Value{
+			"ignore_permission_errors": tftypes.NewValue(tftypes.Bool, true),
+		})
+		if resp.Diagnostics.HasError() || resp.Diagnostics.WarningsCount() != 1 {
+			t.Errorf("%s: expected a warning, got %v", name, resp.Diagnostics)
+		}
+	}
+}
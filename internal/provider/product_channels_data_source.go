@@ -0,0 +1,249 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"terraform-provider-uyuni/internal/uyuni"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// productStatusInstalled is the status of products and channels added to the
+// server.
+const productStatusInstalled = "INSTALLED"
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &ProductChannelsDataSource{}
+	_ datasource.DataSourceWithConfigure = &ProductChannelsDataSource{}
+)
+
+// ProductChannelsDataSourceModel maps the data source schema data.
+type ProductChannelsDataSourceModel struct {
+	Name                 types.String `tfsdk:"name"`
+	Version              types.String `tfsdk:"version"`
+	Arch                 types.String `tfsdk:"arch"`
+	IncludeRecommended   types.Bool   `tfsdk:"include_recommended"`
+	Product              types.String `tfsdk:"product"`
+	Synced               types.Bool   `tfsdk:"synced"`
+	BaseChannelLabel     types.String `tfsdk:"base_channel_label"`
+	ChildChannelLabels   types.Set    `tfsdk:"child_channel_labels"`
+	MissingChannelLabels types.Set    `tfsdk:"missing_channel_labels"`
+}
+
+// NewProductChannelsDataSource is a helper function to simplify the provider implementation.
+func NewProductChannelsDataSource() datasource.DataSource {
+	return &ProductChannelsDataSource{}
+}
+
+// ProductChannelsDataSource is the data source implementation.
+type ProductChannelsDataSource struct {
+	client *uyuniClient
+}
+
+// Metadata returns the data source type name.
+func (d *ProductChannelsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_product_channels"
+}
+
+// Schema defines the schema for the data source.
+func (d *ProductChannelsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Resolves the base channel and the mandatory child channels of an operating system from the product " +
+			"tree of the server, e.g. for the channels of activation keys and bootstrap repositories, so that channel " +
+			"labels need not be hardcoded.",
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Description: "Name of the product as shown on the products page, e.g. `SUSE Linux Enterprise Server` or `openSUSE Leap`.",
+				Required:    true,
+			},
+			"version": schema.StringAttribute{
+				Description: "Version of the product, e.g. `15 SP6` or `15.6`.",
+				Required:    true,
+			},
+			"arch": schema.StringAttribute{
+				Description: "Architecture of the product, e.g. `x86_64` or `aarch64`.",
+				Required:    true,
+			},
+			"include_recommended": schema.BoolAttribute{
+				Description: "Whether to include the mandatory channels of the recommended extensions, e.g. of the modules " +
+					"of SUSE Linux Enterprise 15. Defaults to true.",
+				Optional: true,
+			},
+			"product": schema.StringAttribute{
+				Description: "Name of the product as shown on the products page, e.g. `SUSE Linux Enterprise Server 15 SP6 x86_64`.",
+				Computed:    true,
+			},
+			"synced": schema.BoolAttribute{
+				Description: "Whether the product was added to the server.",
+				Computed:    true,
+			},
+			"base_channel_label": schema.StringAttribute{
+				Description: "Label of the base channel of the product.",
+				Computed:    true,
+			},
+			"child_channel_labels": schema.SetAttribute{
+				Description: "Labels of the mandatory child channels of the product and, with include_recommended, of its " +
+					"recommended extensions.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"missing_channel_labels": schema.SetAttribute{
+				Description: "Labels of the base and child channels which were not added to the server yet, " +
+					"e.g. to check that all of them are synced before bootstrapping.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// findProduct returns the base product with the name, version and
+// architecture, nil if there is none. Friendly names are the name and version,
+// mostly followed by the architecture.
+func findProduct(products []uyuni.Product, name, version, arch string) *uyuni.Product {
+	want := name + " " + version
+	for i, product := range products {
+		if product.Arch != arch {
+			continue
+		}
+		friendly := strings.TrimSuffix(product.FriendlyName, " "+arch)
+		if strings.EqualFold(friendly, want) {
+			return &products[i]
+		}
+	}
+	return nil
+}
+
+// productBaseChannel returns the channel of the product without a parent
+// among its channels, nil if there is none.
+func productBaseChannel(product *uyuni.Product) *uyuni.ProductChannel {
+	labels := map[string]bool{}
+	for _, channel := range product.Channels {
+		labels[channel.Label] = true
+	}
+	for i, channel := range product.Channels {
+		if !labels[channel.Parent] {
+			return &product.Channels[i]
+		}
+	}
+	return nil
+}
+
+// mandatoryChannels returns the mandatory channels of the product and, if
+// recommended is set, of its recommended extensions.
+func mandatoryChannels(product *uyuni.Product, recommended bool) []uyuni.ProductChannel {
+	var channels []uyuni.ProductChannel
+	for _, channel := range product.Channels {
+		if !channel.Optional {
+			channels = append(channels, channel)
+		}
+	}
+	if recommended {
+		for i, extension := range product.Extensions {
+			if extension.Recommended {
+				channels = append(channels, mandatoryChannels(&product.Extensions[i], recommended)...)
+			}
+		}
+	}
+	return channels
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *ProductChannelsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state ProductChannelsDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	products, err := apiGet[[]uyuni.Product](ctx, d.client, "sync/content/listProducts")
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Uyuni products",
+			"Could not list products: "+err.Error(),
+		)
+		return
+	}
+	name, version, arch := state.Name.ValueString(), state.Version.ValueString(), state.Arch.ValueString()
+	product := findProduct(products.Result, name, version, arch)
+	if product == nil {
+		resp.Diagnostics.AddError(
+			"Uyuni product not found",
+			fmt.Sprintf("The product tree of the server has no product %s %s for %s. "+
+				"Check the products page for the names and versions, and refresh the product tree if the product is new.", name, version, arch),
+		)
+		return
+	}
+	base := productBaseChannel(product)
+	if base == nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Uyuni products",
+			fmt.Sprintf("Product %s has no base channel.", product.FriendlyName),
+		)
+		return
+	}
+
+	recommended := state.IncludeRecommended.IsNull() || state.IncludeRecommended.ValueBool()
+	children, missing := []string{}, []string{}
+	seen := map[string]bool{}
+	for _, channel := range mandatoryChannels(product, recommended) {
+		if seen[channel.Label] {
+			continue
+		}
+		seen[channel.Label] = true
+		if channel.Label != base.Label {
+			children = append(children, channel.Label)
+		}
+		if !strings.EqualFold(channel.Status, productStatusInstalled) {
+			missing = append(missing, channel.Label)
+		}
+	}
+	sort.Strings(children)
+	sort.Strings(missing)
+
+	state.Product = types.StringValue(product.FriendlyName)
+	state.Synced = types.BoolValue(strings.EqualFold(product.Status, productStatusInstalled))
+	state.BaseChannelLabel = types.StringValue(base.Label)
+	state.ChildChannelLabels, diags = types.SetValueFrom(ctx, types.StringType, children)
+	resp.Diagnostics.Append(diags...)
+	state.MissingChannelLabels, diags = types.SetValueFrom(ctx, types.StringType, missing)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *ProductChannelsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*uyuniClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *uyuniClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
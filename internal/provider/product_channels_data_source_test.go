@@ -0,0 +1,115 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+const testProducts = `[
+	{"friendly_name": "SUSE Linux Enterprise Server 15 SP6 x86_64", "arch": "x86_64", "status": "INSTALLED",
+	 "channels": [
+		{"label": "sle-product-sles15-sp6-updates-x86_64", "parent": "sle-product-sles15-sp6-pool-x86_64", "status": "INSTALLED"},
+		{"label": "sle-product-sles15-sp6-pool-x86_64", "parent": "BASE", "status": "INSTALLED"},
+		{"label": "sle-product-sles15-sp6-debuginfo-updates-x86_64", "parent": "sle-product-sles15-sp6-pool-x86_64", "optional": true}],
+	 "extensions": [
+		{"friendly_name": "Basesystem Module 15 SP6 x86_64", "arch": "x86_64", "recommended": true,
+		 "channels": [{"label": "sle-module-basesystem15-sp6-pool-x86_64", "parent": "sle-product-sles15-sp6-pool-x86_64", "status": "INSTALLED"}],
+		 "extensions": [
+			{"friendly_name": "Server Applications Module 15 SP6 x86_64", "arch": "x86_64", "recommended": true,
+			 "channels": [{"label": "sle-module-server-applications15-sp6-pool-x86_64", "parent": "sle-product-sles15-sp6-pool-x86_64", "status": "AVAILABLE"}]}]},
+		{"friendly_name": "Python 3 Module 15 SP6 x86_64", "arch": "x86_64",
+		 "channels": [{"label": "sle-module-python3-15-sp6-pool-x86_64", "parent": "sle-product-sles15-sp6-pool-x86_64"}]}]},
+	{"friendly_name": "SUSE Linux Enterprise Server 15 SP6 aarch64", "arch": "aarch64",
+	 "channels": [{"label": "sle-product-sles15-sp6-pool-aarch64", "parent": "BASE"}]}
+]`
+
+func testProductsClient(t *testing.T) *uyuniClient {
+	return testAPIClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/sync/content/listProducts" {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		_, _ = w.Write([]byte(`{"success": true, "result": ` + testProducts + `}`))
+	})
+}
+
+func productChannelsConfig(version string, recommended bool) map[string]tftypes.Value {
+	return map[string]tftypes.Value{
+		"name":                tftypes.NewValue(tftypes.String, "suse linux enterprise server"),
+		"version":             tftypes.NewValue(tftypes.String, version),
+		"arch":                tftypes.NewValue(tftypes.String, "x86_64"),
+		"include_recommended": tftypes.NewValue(tftypes.Bool, recommended),
+	}
+}
+
+func TestProductChannelsDataSource(t *testing.T) {
+	resp := testDataSourceRead(t, NewProductChannelsDataSource(), testProductsClient(t), productChannelsConfig("15 SP6", true))
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+	var model ProductChannelsDataSourceModel
+	resp.State.Get(context.Background(), &model)
+	if model.Product.ValueString() != "SUSE Linux Enterprise Server 15 SP6 x86_64" || !model.Synced.ValueBool() {
+		t.Errorf("unexpected product %v", model)
+	}
+	if model.BaseChannelLabel.ValueString() != "sle-product-sles15-sp6-pool-x86_64" {
+		t.Errorf("expected the pool as base channel, got %s", model.BaseChannelLabel)
+	}
+	var children, missing []string
+	model.ChildChannelLabels.ElementsAs(context.Background(), &children, false)
+	model.MissingChannelLabels.ElementsAs(context.Background(), &missing, false)
+	if strings.Join(children, ",") != "sle-module-basesystem15-sp6-pool-x86_64,sle-module-server-applications15-sp6-pool-x86_64,sle-product-sles15-sp6-updates-x86_64" {
+		t.Errorf("expected the mandatory channels of the product and the recommended extensions, got %v", children)
+	}
+	if strings.Join(missing, ",") != "sle-module-server-applications15-sp6-pool-x86_64" {
+		t.Errorf("expected the channel not added yet, got %v", missing)
+	}
+}
+
+func TestProductChannelsDataSourceWithoutRecommended(t *testing.T) {
+	resp := testDataSourceRead(t, NewProductChannelsDataSource(), testProductsClient(t), productChannelsConfig("15 sp6", false))
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+	var model ProductChannelsDataSourceModel
+	resp.State.Get(context.Background(), &model)
+	var children []string
+	model.ChildChannelLabels.ElementsAs(context.Background(), &children, false)
+	if strings.Join(children, ",") != "sle-product-sles15-sp6-updates-x86_64" || len(model.MissingChannelLabels.Elements()) != 0 {
+		t.Errorf("expected only the channels of the product, got %v", model)
+	}
+}
+
+func TestProductChannelsDataSourceNotFound(t *testing.T) {
+	resp := testDataSourceRead(t, NewProductChannelsDataSource(), testProductsClient(t), productChannelsConfig("15 SP7", true))
+	if !resp.Diagnostics.HasError() || resp.Diagnostics[0].Summary() != "Uyuni product not found" {
+		t.Fatalf("expected the unknown product to fail the read, got %v", resp.Diagnostics)
+	}
+}
+
+func TestAccProductChannelsDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccRealServerPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				// Uyuni lists the openSUSE products without SUSE Customer Center credentials.
+				Config: `
+data "uyuni_product_channels" "test" {
+  name    = "openSUSE Leap"
+  version = "15.6"
+  arch    = "x86_64"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.uyuni_product_channels.test", "product", "openSUSE Leap 15.6 x86_64"),
+					resource.TestCheckResourceAttrSet("data.uyuni_product_channels.test", "base_channel_label"),
+				),
+			},
+		},
+	})
+}
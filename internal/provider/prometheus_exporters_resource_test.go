@@ -0,0 +1,193 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	acctest "github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestPrometheusExportersPillarRoundTrip(t *testing.T) {
+	model := prometheusExportersResourceModel{
+		NodeExporter: &exporterModel{Port: types.Int64Value(9100), Args: types.StringValue("--collector.systemd")},
+		PostgresExporter: &postgresExporterModel{
+			Port:           types.Int64Value(9188),
+			Args:           types.StringValue(""),
+			DataSourceName: types.StringValue("postgresql://exporter@localhost/postgres"),
+		},
+	}
+	pillar := model.pillar()
+
+	exporters := pillar["exporters"].(map[string]interface{})
+	if exporters["apache_exporter"].(map[string]interface{})["enabled"] != false {
+		t.Errorf("expected the apache exporter to be disabled, got %v", exporters)
+	}
+	if exporters["postgres_exporter"].(map[string]interface{})["address"] != ":9188" {
+		t.Errorf("got postgres exporter %v", exporters["postgres_exporter"])
+	}
+
+	// The server returns the data as decoded JSON.
+	data, _ := json.Marshal(pillar)
+	var decoded map[string]interface{}
+	_ = json.Unmarshal(data, &decoded)
+	var read prometheusExportersResourceModel
+	read.setPillar(decoded)
+	if !reflect.DeepEqual(read.NodeExporter, model.NodeExporter) || read.ApacheExporter != nil || !reflect.DeepEqual(read.PostgresExporter, model.PostgresExporter) {
+		t.Errorf("got %+v, %+v, %+v", read.NodeExporter, read.ApacheExporter, read.PostgresExporter)
+	}
+}
+
+// testExportersServer serves the group "web" with two systems and records the
+// formulas and formula data set for it.
+type testExportersServer struct {
+	formulas []string
+	data     map[string]interface{}
+}
+
+func (s *testExportersServer) handler(w http.ResponseWriter, r *http.Request) {
+	var body map[string]interface{}
+	_ = json.NewDecoder(r.Body).Decode(&body)
+	switch r.URL.Path {
+	case "/systemgroup/getDetails":
+		_, _ = w.Write([]byte(`{"success": true, "result": {"id": 7, "name": "web", "description": "", "org_id": 1, "system_count": 2}}`))
+	case "/systemgroup/listSystemsMinimal":
+		_, _ = w.Write([]byte(`{"success": true, "result": [
+			{"id": 1000010001, "name": "web02", "last_checkin": "", "created": ""},
+			{"id": 1000010000, "name": "web01", "last_checkin": "", "created": ""}
+		]}`))
+	case "/system/getNetwork":
+		hostname := "web01.example.com"
+		if r.URL.Query().Get("sid") == "1000010001" {
+			hostname = ""
+		}
+		_, _ = w.Write([]byte(`{"success": true, "result": {"ip": "192.168.10.22", "ip6": "", "hostname": "` + hostname + `"}}`))
+	case "/formula/getFormulasByGroupId":
+		formulas, _ := json.Marshal(append([]string{}, s.formulas...))
+		_, _ = w.Write([]byte(`{"success": true, "result": ` + string(formulas) + `}`))
+	case "/formula/setFormulasOfGroup":
+		s.formulas = nil
+		for _, formula := range body["formulas"].([]interface{}) {
+			s.formulas = append(s.formulas, formula.(string))
+		}
+		_, _ = w.Write([]byte(`{"success": true, "result": 1}`))
+	case "/formula/getGroupFormulaData":
+		data, _ := json.Marshal(s.data)
+		_, _ = w.Write([]byte(`{"success": true, "result": ` + string(data) + `}`))
+	case "/formula/setGroupFormulaData":
+		s.data = body["content"].(map[string]interface{})
+		_, _ = w.Write([]byte(`{"success": true, "result": 1}`))
+	default:
+		_, _ = w.Write([]byte(`{"success": false, "message": "unexpected request ` + r.URL.String() + `"}`))
+	}
+}
+
+func TestPrometheusExportersCreateForGroup(t *testing.T) {
+	ctx := context.Background()
+	server := &testExportersServer{formulas: []string{"branch-network"}}
+	r := NewPrometheusExportersResource()
+	testConfigure(t, r, testAPIClient(t, server.handler))
+
+	planned := testState(t, r, map[string]interface{}{
+		"group_name":    "web",
+		"node_exporter": &exporterModel{Port: types.Int64Value(9100), Args: types.StringValue("")},
+	})
+	resp := &resource.CreateResponse{State: planned}
+	r.Create(ctx, resource.CreateRequest{Plan: tfsdk.Plan{Schema: planned.Schema, Raw: planned.Raw}}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+	if strings.Join(server.formulas, ",") != "branch-network,prometheus-exporters" {
+		t.Errorf("expected the formula to be added to the group formulas, got %v", server.formulas)
+	}
+
+	var state prometheusExportersResourceModel
+	resp.State.Get(ctx, &state)
+	if state.ID.ValueString() != "group:web" {
+		t.Errorf("got id %s", state.ID)
+	}
+	if got := state.ScrapeTargets.String(); got != `{"node":["192.168.10.22:9100","web01.example.com:9100"]}` {
+		t.Errorf("got scrape targets %s", got)
+	}
+}
+
+func TestPrometheusExportersReadWithoutFormula(t *testing.T) {
+	server := &testExportersServer{formulas: []string{"branch-network"}}
+
+	resp := testRead(t, NewPrometheusExportersResource(), testAPIClient(t, server.handler), map[string]interface{}{
+		"id":         "group:web",
+		"group_name": "web",
+	})
+	if resp.Diagnostics.HasError() || !resp.State.Raw.IsNull() {
+		t.Errorf("expected the resource to be removed from state, got %v", resp.Diagnostics)
+	}
+}
+
+func TestPrometheusExportersImportState(t *testing.T) {
+	ctx := context.Background()
+	r := NewPrometheusExportersResource().(resource.ResourceWithImportState)
+
+	for id, valid := range map[string]bool{
+		"system:1000010000": true,
+		"group:web":         true,
+		"system:web":        false,
+		"web":               false,
+		"group:":            false,
+	} {
+		empty := testState(t, r, map[string]interface{}{})
+		resp := &resource.ImportStateResponse{State: empty}
+		r.ImportState(ctx, resource.ImportStateRequest{ID: id}, resp)
+		if resp.Diagnostics.HasError() == valid {
+			t.Errorf("%s: got %v", id, resp.Diagnostics)
+		}
+	}
+}
+
+func TestAccPrometheusExportersResource(t *testing.T) {
+	acctest.Test(t, acctest.TestCase{
+		PreCheck: func() {
+			testAccRealServerPreCheck(t)
+			testAccSeedSystemGroup(t, "tfacc-exporters")
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []acctest.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccPrometheusExportersResourceConfig(""),
+				Check:  acctest.TestCheckResourceAttr("uyuni_prometheus_exporters.test", "id", "group:tfacc-exporters"),
+			},
+			// Update and Read testing
+			{
+				Config: testAccPrometheusExportersResourceConfig(`
+  apache_exporter = {
+    port = 9117
+  }
+`),
+				Check: acctest.TestCheckResourceAttr("uyuni_prometheus_exporters.test", "apache_exporter.port", "9117"),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "uyuni_prometheus_exporters.test",
+				ImportState:       true,
+				ImportStateId:     "group:tfacc-exporters",
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccPrometheusExportersResourceConfig(exporters string) string {
+	return fmt.Sprintf(`
+resource "uyuni_prometheus_exporters" "test" {
+  group_name    = "tfacc-exporters"
+  node_exporter = {}
+%s}
+`, exporters)
+}
@@ -3,12 +3,17 @@ package provider
 import (
 	"context"
 	"os"
+	"strconv"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/uyuni-project/uyuni-tools/shared/api"
@@ -32,6 +37,25 @@ type uyuniProviderModel struct {
 	Host     types.String `tfsdk:"host"`
 	Username types.String `tfsdk:"username"`
 	Password types.String `tfsdk:"password"`
+	CACert   types.String `tfsdk:"ca_cert"`
+	Insecure types.Bool   `tfsdk:"insecure"`
+
+	MgrctlConfig     types.Bool   `tfsdk:"mgrctl_config"`
+	MgrctlConfigFile types.String `tfsdk:"mgrctl_config_file"`
+
+	DefaultCustomValues types.Map    `tfsdk:"default_custom_values"`
+	ScriptPolicy        types.Object `tfsdk:"script_policy"`
+
+	Servers types.Map `tfsdk:"servers"`
+
+	MaxRetries            types.Int64   `tfsdk:"max_retries"`
+	MaxConcurrentRequests types.Int64   `tfsdk:"max_concurrent_requests"`
+	RequestsPerSecond     types.Float64 `tfsdk:"requests_per_second"`
+
+	Debug        types.Bool   `tfsdk:"debug"`
+	OTLPEndpoint types.String `tfsdk:"otlp_endpoint"`
+	OTLPHeaders  types.Map    `tfsdk:"otlp_headers"`
+	AuditLog     types.Object `tfsdk:"audit_log"`
 }
 
 // New is a helper function to simplify provider server and testing implementation.
@@ -63,6 +87,110 @@ func (p *uyuniProvider) Schema(_ context.Context, _ provider.SchemaRequest, resp
 				Optional:  true,
 				Sensitive: true,
 			},
+			"ca_cert": schema.StringAttribute{
+				Description: "CA certificate verifying the certificate of the server, e.g. of an internal CA, as PEM or as the path " +
+					"of a PEM file. It is trusted in addition to the CAs of the system. Can also be set with the UYUNI_CACERT environment variable.",
+				Optional: true,
+			},
+			"insecure": schema.BoolAttribute{
+				Description: "Whether to skip the verification of the certificate of the server, e.g. for test servers with " +
+					"self-signed certificates. Not recommended. Can also be set with the UYUNI_INSECURE environment variable. Defaults to false.",
+				Optional: true,
+			},
+			"mgrctl_config": schema.BoolAttribute{
+				Description: "Whether to read the server, user and password from the configuration of mgrctl: " +
+					"`/etc/uyuni/uyuni-tools.yaml`, `$XDG_CONFIG_HOME/uyuni-tools/config.yaml` and the `UYUNI_API_*` environment variables. " +
+					"The host, username, password, ca_cert and insecure attributes and their environment variables take precedence.",
+				Optional: true,
+			},
+			"mgrctl_config_file": schema.StringAttribute{
+				Description: "Path of a uyuni-tools configuration file read after the mgrctl ones, e.g. `~/.config/uyuni/credentials`. " +
+					"Setting it implies mgrctl_config.",
+				Optional: true,
+			},
+			"default_custom_values": schema.MapAttribute{
+				Description: "Custom values applied to every system managed with uyuni_system_custom_values, e.g. the owner. " +
+					"Values set on the resource override them.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"script_policy": scriptPolicyAttribute(),
+			"max_retries": schema.Int64Attribute{
+				Description: "How often an API call failing transiently is retried, with exponential backoff starting at a second. " +
+					"Reads are retried after network errors and HTTP 429, 502, 503 and 504, writes only when the server could not be " +
+					"reached, as it may have processed them otherwise. Set to 0 to disable retries. Defaults to 3.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"max_concurrent_requests": schema.Int64Attribute{
+				Description: "Maximum number of API calls sent to a server at the same time. The provider sends fewer while the server " +
+					"is overloaded. Defaults to 32.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"requests_per_second": schema.Float64Attribute{
+				Description: "Maximum number of API calls sent to a server per second, e.g. `0.5` for one call every two seconds. " +
+					"Not limited when not set.",
+				Optional: true,
+				Validators: []validator.Float64{
+					float64validator.AtLeast(0.01),
+				},
+			},
+			"debug": schema.BoolAttribute{
+				Description: "Whether to log every API call with its duration, HTTP status and the sizes of its request and response, " +
+					"e.g. to find the calls slowing down applies against large servers. Payloads are never logged. " +
+					"The calls are logged at debug level to the user, channel, system and client subsystems by API namespace, " +
+					"whose levels can be set separately with TF_LOG_PROVIDER_UYUNI_USER and the like.",
+				Optional: true,
+			},
+			"otlp_endpoint": schema.StringAttribute{
+				Description: "URL of an OpenTelemetry collector receiving a span per API call over OTLP/HTTP, " +
+					"e.g. `http://localhost:4318/v1/traces`. The spans are named after the API endpoint and carry the HTTP status; " +
+					"the spans of an apply share one trace. Tracing is disabled when not set.",
+				Optional: true,
+			},
+			"otlp_headers": schema.MapAttribute{
+				Description: "HTTP headers sent to the OpenTelemetry collector, e.g. for authentication.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Sensitive:   true,
+			},
+			"audit_log": auditLogAttribute(),
+			"servers": schema.MapNestedAttribute{
+				Description: "Further servers by alias, e.g. the peripheral servers of a hub, which resources select " +
+					"with their server_alias attribute. The provider logs in to a server when a resource first uses it.",
+				Optional: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"host": schema.StringAttribute{
+							Description: "Host of the server.",
+							Required:    true,
+						},
+						"username": schema.StringAttribute{
+							Description: "Login of the user on the server.",
+							Required:    true,
+						},
+						"password": schema.StringAttribute{
+							Description: "Password of the user on the server.",
+							Required:    true,
+							Sensitive:   true,
+						},
+						"ca_cert": schema.StringAttribute{
+							Description: "CA certificate verifying the certificate of the server, as PEM or as the path of a PEM file. " +
+								"Defaults to the ca_cert of the provider.",
+							Optional: true,
+						},
+						"insecure": schema.BoolAttribute{
+							Description: "Whether to skip the verification of the certificate of the server. Defaults to the insecure setting of the provider.",
+							Optional:    true,
+						},
+					},
+				},
+			},
 		},
 	}
 }
@@ -109,16 +237,121 @@ func (p *uyuniProvider) Configure(ctx context.Context, req provider.ConfigureReq
 		)
 	}
 
+	if config.CACert.IsUnknown() || config.Insecure.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("ca_cert"),
+			"Unknown Uyuni TLS Configuration",
+			"The provider cannot verify the server as the CA certificate or whether to skip the verification is unknown. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the UYUNI_CACERT and UYUNI_INSECURE environment variables.",
+		)
+	}
+
+	if config.MgrctlConfig.IsUnknown() || config.MgrctlConfigFile.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("mgrctl_config_file"),
+			"Unknown mgrctl Configuration",
+			"The provider cannot read the mgrctl configuration as whether or which file to read is unknown. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+
+	if config.DefaultCustomValues.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("default_custom_values"),
+			"Unknown Uyuni Default Custom Values",
+			"The provider cannot apply default custom values as they are unknown. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+
+	if config.ScriptPolicy.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("script_policy"),
+			"Unknown Uyuni Script Policy",
+			"The provider cannot enforce the script policy as it is unknown. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+
+	if config.OTLPEndpoint.IsUnknown() || config.OTLPHeaders.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("otlp_endpoint"),
+			"Unknown OpenTelemetry Collector",
+			"The provider cannot send traces as the collector or its headers are unknown. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+
+	if config.AuditLog.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("audit_log"),
+			"Unknown Audit Log",
+			"The provider cannot record API calls as the audit log is unknown. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+
+	if config.Servers.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("servers"),
+			"Unknown Uyuni Servers",
+			"The provider cannot connect to further servers as they are unknown. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// Default values to environment variables, but override
-	// with Terraform configuration value if set.
+	// Default values to the mgrctl configuration if requested, override
+	// them with environment variables and with Terraform configuration
+	// values if set.
+
+	var host, username, password, caCert string
+	var insecure bool
+	if config.MgrctlConfig.ValueBool() || config.MgrctlConfigFile.ValueString() != "" {
+		conn, err := readMgrctlConfig(expandHome(config.MgrctlConfigFile.ValueString()))
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("mgrctl_config_file"),
+				"Unable to Read mgrctl Configuration",
+				"The provider cannot read the server and credentials from the mgrctl configuration: "+err.Error(),
+			)
+			return
+		}
+		host, username, password = conn.Server, conn.User, conn.Password
+		caCert, insecure = conn.CAcert, conn.Insecure
+	}
+
+	if value := os.Getenv("UYUNI_HOST"); value != "" {
+		host = value
+	}
+
+	if value := os.Getenv("UYUNI_USERNAME"); value != "" {
+		username = value
+	}
+
+	if value := os.Getenv("UYUNI_PASSWORD"); value != "" {
+		password = value
+	}
 
-	host := os.Getenv("UYUNI_HOST")
-	username := os.Getenv("UYUNI_USERNAME")
-	password := os.Getenv("UYUNI_PASSWORD")
+	if value := os.Getenv("UYUNI_CACERT"); value != "" {
+		caCert = value
+	}
+
+	if value := os.Getenv("UYUNI_INSECURE"); value != "" {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("insecure"),
+				"Invalid UYUNI_INSECURE Environment Variable",
+				"The UYUNI_INSECURE environment variable must be true or false, got "+strconv.Quote(value)+".",
+			)
+			return
+		}
+		insecure = parsed
+	}
 
 	if !config.Host.IsNull() {
 		host = config.Host.ValueString()
@@ -132,6 +365,14 @@ func (p *uyuniProvider) Configure(ctx context.Context, req provider.ConfigureReq
 		password = config.Password.ValueString()
 	}
 
+	if !config.CACert.IsNull() {
+		caCert = config.CACert.ValueString()
+	}
+
+	if !config.Insecure.IsNull() {
+		insecure = config.Insecure.ValueBool()
+	}
+
 	// If any of the expected configurations are missing, return
 	// errors with provider-specific guidance.
 
@@ -165,6 +406,18 @@ func (p *uyuniProvider) Configure(ctx context.Context, req provider.ConfigureReq
 		)
 	}
 
+	// Report unusable certificates here rather than as login failures.
+	if caCert != "" {
+		if _, err := loadCACert(caCert); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("ca_cert"),
+				"Invalid Uyuni CA Certificate",
+				"The provider cannot verify the Uyuni server with the CA certificate set in the configuration or "+
+					"the UYUNI_CACERT environment variable: "+err.Error(),
+			)
+		}
+	}
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -181,10 +434,10 @@ func (p *uyuniProvider) Configure(ctx context.Context, req provider.ConfigureReq
 		Server:   host,
 		User:     username,
 		Password: password,
-		CAcert:   "",
-		Insecure: true,
+		CAcert:   caCert,
+		Insecure: insecure,
 	}
-	client, err := api.Init(&_conn)
+	client, err := newUyuniClient(ctx, &_conn)
 
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -195,6 +448,100 @@ func (p *uyuniProvider) Configure(ctx context.Context, req provider.ConfigureReq
 		)
 		return
 	}
+	client.debug = config.Debug.ValueBool()
+	if !config.MaxRetries.IsNull() && !config.MaxRetries.IsUnknown() {
+		client.retries = int(config.MaxRetries.ValueInt64())
+	}
+	var interval time.Duration
+	if config.RequestsPerSecond.ValueFloat64() > 0 {
+		interval = time.Duration(float64(time.Second) / config.RequestsPerSecond.ValueFloat64())
+	}
+	client.pacer.configure(int(config.MaxConcurrentRequests.ValueInt64()), interval)
+	if !config.OTLPEndpoint.IsNull() {
+		headers := map[string]string{}
+		if !config.OTLPHeaders.IsNull() {
+			resp.Diagnostics.Append(config.OTLPHeaders.ElementsAs(ctx, &headers, false)...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+		}
+		client.tracer, err = newOTLPExporter(config.OTLPEndpoint.ValueString(), headers, p.version)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("otlp_endpoint"),
+				"Invalid OpenTelemetry Collector",
+				"The provider cannot send traces to the collector: "+err.Error(),
+			)
+			return
+		}
+	}
+	client.audit, diags = newAuditLog(ctx, config.AuditLog)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	client.detectVersion(ctx)
+
+	client.defaultCustomValues = map[string]string{}
+	if !config.DefaultCustomValues.IsNull() {
+		resp.Diagnostics.Append(config.DefaultCustomValues.ElementsAs(ctx, &client.defaultCustomValues, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	client.scriptPolicy, diags = newScriptPolicy(ctx, config.ScriptPolicy)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !config.Servers.IsNull() {
+		var servers map[string]serverModel
+		resp.Diagnostics.Append(config.Servers.ElementsAs(ctx, &servers, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		conns := map[string]api.ConnectionDetails{}
+		for alias, server := range servers {
+			if server.Host.IsUnknown() || server.Username.IsUnknown() || server.Password.IsUnknown() ||
+				server.CACert.IsUnknown() || server.Insecure.IsUnknown() {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("servers").AtMapKey(alias),
+					"Unknown Uyuni Server",
+					"The provider cannot connect to the server "+alias+" as its host or credentials are unknown. "+
+						"Either target apply the source of the value first or set the value statically in the configuration.",
+				)
+				continue
+			}
+			conn := api.ConnectionDetails{
+				Server:   server.Host.ValueString(),
+				User:     server.Username.ValueString(),
+				Password: server.Password.ValueString(),
+				CAcert:   caCert,
+				Insecure: insecure,
+			}
+			if !server.CACert.IsNull() {
+				conn.CAcert = server.CACert.ValueString()
+				if _, err := loadCACert(conn.CAcert); err != nil {
+					resp.Diagnostics.AddAttributeError(
+						path.Root("servers").AtMapKey(alias).AtName("ca_cert"),
+						"Invalid Uyuni CA Certificate",
+						"The provider cannot verify the server "+alias+" with the CA certificate: "+err.Error(),
+					)
+					continue
+				}
+			}
+			if !server.Insecure.IsNull() {
+				conn.Insecure = server.Insecure.ValueBool()
+			}
+			conns[alias] = conn
+		}
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		client.servers = newServerRegistry(conns)
+	}
 
 	// Make the Uyuni client available during DataSource and Resource
 	// type Configure methods.
@@ -208,6 +555,44 @@ func (p *uyuniProvider) Configure(ctx context.Context, req provider.ConfigureReq
 func (p *uyuniProvider) DataSources(_ context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		NewUsersDataSource,
+		NewGroupPatchStatusDataSource,
+		NewCryptoKeysDataSource,
+		NewBootstrapScriptDataSource,
+		NewPackageDataSource,
+		NewPackageSearchDataSource,
+		NewErratumDataSource,
+		NewCustomStatesDataSource,
+		NewSystemSnapshotsDataSource,
+		NewSSHPushKeysDataSource,
+		NewKickstartFileDataSource,
+		NewAPINamespacesDataSource,
+		NewEntitlementUsageDataSource,
+		NewContactMethodsDataSource,
+		NewEntitlementsDataSource,
+		NewUserPermissionsDataSource,
+		NewConfigFileContentDataSource,
+		NewChannelSubscribersDataSource,
+		NewBuiltImagesDataSource,
+		NewMinionPillarDataSource,
+		NewGroupErrataComplianceDataSource,
+		NewChannelErrataDataSource,
+		NewChannelAdvisoryDiffDataSource,
+		NewRecentRegistrationsDataSource,
+		NewSystemCountByChannelDataSource,
+		NewFormulaCatalogDataSource,
+		NewSystemsDataSource,
+		NewActivationKeyDataSource,
+		NewChannelsDataSource,
+		NewSystemGroupsDataSource,
+		NewConfigChannelExportDataSource,
+		NewActionResultDataSource,
+		NewHubSystemsDataSource,
+		NewProductChannelsDataSource,
+		NewSystemOSInfoDataSource,
+		NewChannelFamilyUsageDataSource,
+		NewKickstartSessionStatusDataSource,
+		NewVirtualSystemsDataSource,
+		NewAPICallDataSource,
 	}
 }
 
@@ -215,5 +600,54 @@ func (p *uyuniProvider) DataSources(_ context.Context) []func() datasource.DataS
 func (p *uyuniProvider) Resources(_ context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewUserResource,
+		NewUsersResource,
+		NewSystemCocoAttestationResource,
+		NewProxyConfigResource,
+		NewRetailBranchResource,
+		NewHubPeripheralChannelsResource,
+		NewChannelPackagesResource,
+		NewChannelSyncResource,
+		NewErrataCloneResource,
+		NewSystemOrgMigrationResource,
+		NewSystemSnapshotTagResource,
+		NewSystemSnapshotRollbackResource,
+		NewSystemRefreshResource,
+		NewRecurringHighstateResource,
+		NewActivationKeyResource,
+		NewAutoinstallProfileResource,
+		NewChannelTreeResource,
+		NewGPGKeyResource,
+		NewScheduledActionResource,
+		NewPrometheusExportersResource,
+		NewSystemCustomValuesResource,
+		NewAutoErrataUpdateResource,
+		NewGroupConfigChannelsResource,
+		NewServerSettingsResource,
+		NewSystemsRefreshResource,
+		NewChannelSettingsResource,
+		NewSystemsChannelChangeResource,
+		NewSystemsRebootResource,
+		NewSystemGroupResource,
+		NewSoftwareChannelResource,
+		NewRepositoryResource,
+		NewCLMProjectResource,
+		NewCLMEnvironmentResource,
+		NewCLMSourceResource,
+		NewCLMFilterResource,
+		NewCLMBuildResource,
+		NewOrganizationResource,
+		NewOrgTrustResource,
+		NewConfigChannelResource,
+		NewConfigFileResource,
+		NewBootstrapHostResource,
+		NewCustomRepoSSLBundleResource,
+		NewActionChainResource,
+		NewSCCCredentialsResource,
+		NewInactiveSystemsCleanupResource,
+		NewSystemReprovisionResource,
+		NewProxyCertificateRotationResource,
+		NewSupportDataResource,
+		NewCustomValuesPolicyResource,
+		NewAPICallResource,
 	}
 }
@@ -0,0 +1,349 @@
+package provider
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"time"
+
+	"terraform-provider-uyuni/internal/uyuni"
+	"terraform-provider-uyuni/internal/validators"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource              = &proxyCertificateRotationResource{}
+	_ resource.ResourceWithConfigure = &proxyCertificateRotationResource{}
+)
+
+// NewProxyCertificateRotationResource is a helper function to simplify the provider implementation.
+func NewProxyCertificateRotationResource() resource.Resource {
+	return &proxyCertificateRotationResource{}
+}
+
+// proxyCertificateRotationResource is the resource implementation.
+type proxyCertificateRotationResource struct {
+	client *uyuniClient
+}
+
+// proxyCertificateRotationResourceModel maps the resource schema data.
+type proxyCertificateRotationResourceModel struct {
+	ID               types.String   `tfsdk:"id"`
+	ProxyName        types.String   `tfsdk:"proxy_name"`
+	ProxyPort        types.Int64    `tfsdk:"proxy_port"`
+	Server           types.String   `tfsdk:"server"`
+	MaxCache         types.Int64    `tfsdk:"max_cache"`
+	Email            types.String   `tfsdk:"email"`
+	CACert           types.String   `tfsdk:"ca_cert"`
+	CAKey            types.String   `tfsdk:"ca_key"`
+	CAPassword       types.String   `tfsdk:"ca_password"`
+	CNames           types.List     `tfsdk:"cnames"`
+	Country          types.String   `tfsdk:"country"`
+	State            types.String   `tfsdk:"state"`
+	City             types.String   `tfsdk:"city"`
+	Org              types.String   `tfsdk:"org"`
+	OrgUnit          types.String   `tfsdk:"org_unit"`
+	SSLEmail         types.String   `tfsdk:"ssl_email"`
+	RotationTriggers types.Map      `tfsdk:"rotation_triggers"`
+	Rotated          types.String   `tfsdk:"rotated"`
+	Config           types.String   `tfsdk:"config"`
+	ServerAlias      types.String   `tfsdk:"server_alias"`
+	Timeouts         timeouts.Value `tfsdk:"timeouts"`
+}
+
+// Metadata returns the resource type name.
+func (r *proxyCertificateRotationResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_proxy_certificate_rotation"
+}
+
+// optionalSubjectAttribute is the schema of an optional field of the subject
+// of the generated certificate.
+func optionalSubjectAttribute(description string) schema.StringAttribute {
+	return schema.StringAttribute{
+		Description: description,
+		Optional:    true,
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.RequiresReplace(),
+		},
+	}
+}
+
+// Schema defines the schema for the resource.
+func (r *proxyCertificateRotationResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Generates a new TLS certificate for a containerized proxy, signed by the given CA, together " +
+			"with the configuration bundle of the proxy. Change rotation_triggers, e.g. from a time_rotating resource, " +
+			"to generate a new certificate and bundle ahead of the expiry of the current one. The bundle only exists " +
+			"in Terraform state and has to be deployed to the proxy, e.g. with mgrpxy. Use uyuni_proxy_config with a " +
+			"certificate issued elsewhere.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "FQDN of the proxy.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"proxy_name": schema.StringAttribute{
+				Description: "FQDN of the proxy, the common name of the certificate.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"proxy_port": schema.Int64Attribute{
+				Description: "SSH port the proxy listens on.",
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(22),
+				Validators: []validator.Int64{
+					int64validator.Between(1, 65535),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"server": schema.StringAttribute{
+				Description: "FQDN of the parent server or proxy.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"max_cache": schema.Int64Attribute{
+				Description: "Maximum size of the proxy cache in MB.",
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(102400),
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"email": schema.StringAttribute{
+				Description: "Email address of the proxy administrator.",
+				Required:    true,
+				Validators: []validator.String{
+					validators.Email(),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"ca_cert": schema.StringAttribute{
+				Description: "PEM encoded certificate of the CA signing the proxy certificate, usually the CA of the server.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"ca_key": schema.StringAttribute{
+				Description: "PEM encoded private key of the CA.",
+				Required:    true,
+				Sensitive:   true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"ca_password": schema.StringAttribute{
+				Description: "Password of the private key of the CA.",
+				Required:    true,
+				Sensitive:   true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"cnames": schema.ListAttribute{
+				Description: "Alternative names of the proxy added to the certificate.",
+				ElementType: types.StringType,
+				Optional:    true,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.RequiresReplace(),
+				},
+			},
+			"country": schema.StringAttribute{
+				Description: "Two letter country code of the certificate subject.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(2, 2),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"state":     optionalSubjectAttribute("State of the certificate subject."),
+			"city":      optionalSubjectAttribute("City of the certificate subject."),
+			"org":       optionalSubjectAttribute("Organization of the certificate subject."),
+			"org_unit":  optionalSubjectAttribute("Organizational unit of the certificate subject."),
+			"ssl_email": optionalSubjectAttribute("Email address of the certificate subject."),
+			"rotation_triggers": schema.MapAttribute{
+				Description: "Arbitrary values which generate a new certificate and bundle when they change.",
+				ElementType: types.StringType,
+				Optional:    true,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"rotated": schema.StringAttribute{
+				Description: "Date the certificate was generated at, in RFC 3339 format.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"config": schema.StringAttribute{
+				Description: "Base64 encoded tar.gz archive with the generated proxy configuration and certificate.",
+				Computed:    true,
+				Sensitive:   true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"server_alias": serverAliasAttribute(),
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+			}),
+		},
+	}
+}
+
+// Create generates the certificate and the configuration.
+func (r *proxyCertificateRotationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan proxyCertificateRotationResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := resourceClient(ctx, r.client, plan.ServerAlias, nil, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	cnames := []string{}
+	if !plan.CNames.IsNull() {
+		resp.Diagnostics.Append(plan.CNames.ElementsAs(ctx, &cnames, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// The server generates the certificate when given the CA instead of a
+	// proxy certificate.
+	data := map[string]interface{}{
+		"proxyName":  plan.ProxyName.ValueString(),
+		"proxyPort":  plan.ProxyPort.ValueInt64(),
+		"server":     plan.Server.ValueString(),
+		"maxCache":   plan.MaxCache.ValueInt64(),
+		"email":      plan.Email.ValueString(),
+		"caCrt":      plan.CACert.ValueString(),
+		"caKey":      plan.CAKey.ValueString(),
+		"caPassword": plan.CAPassword.ValueString(),
+		"cnames":     cnames,
+		"country":    plan.Country.ValueString(),
+		"state":      plan.State.ValueString(),
+		"city":       plan.City.ValueString(),
+		"org":        plan.Org.ValueString(),
+		"orgUnit":    plan.OrgUnit.ValueString(),
+		"sslEmail":   plan.SSLEmail.ValueString(),
+	}
+
+	tflog.Info(ctx, "About to generate a proxy certificate and configuration for "+plan.ProxyName.ValueString())
+
+	config, err := apiPost[uyuni.Bytes](ctx, client, "proxy/containerConfig", data)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error generating proxy certificate",
+			"Could not generate proxy certificate and configuration: "+err.Error(),
+		)
+		return
+	}
+
+	plan.ID = plan.ProxyName
+	plan.Rotated = types.StringValue(apiDate(time.Now()))
+	plan.Config = types.StringValue(base64.StdEncoding.EncodeToString(config.Result))
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read keeps the generated configuration, the server does not store it.
+func (r *proxyCertificateRotationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state proxyCertificateRotationResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update only changes timeouts, all other changes generate a new
+// certificate.
+func (r *proxyCertificateRotationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan proxyCertificateRotationResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete only removes the certificate and configuration from state. The
+// proxy keeps using the deployed ones.
+func (r *proxyCertificateRotationResource) Delete(ctx context.Context, _ resource.DeleteRequest, _ *resource.DeleteResponse) {
+	tflog.Info(ctx, "Removing proxy certificate from state, the proxy is not changed")
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *proxyCertificateRotationResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*uyuniClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *uyuniClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
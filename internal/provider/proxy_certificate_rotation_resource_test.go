@@ -0,0 +1,99 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	acctest "github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestProxyCertificateRotationGeneratesCertificate(t *testing.T) {
+	ctx := context.Background()
+	var sent map[string]interface{}
+	r := NewProxyCertificateRotationResource()
+	testConfigure(t, r, testAPIClient(t, func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/proxy/containerConfig" {
+			t.Errorf("unexpected request %s", req.URL)
+		}
+		_ = json.NewDecoder(req.Body).Decode(&sent)
+		// "bundle" encoded in base64.
+		_, _ = w.Write([]byte(`{"success": true, "result": "YnVuZGxl"}`))
+	}))
+
+	planned := testState(t, r, map[string]interface{}{
+		"proxy_name":  "proxy.example.com",
+		"proxy_port":  22,
+		"server":      "uyuni.example.com",
+		"max_cache":   102400,
+		"email":       "admin@example.com",
+		"ca_cert":     "CA CERT",
+		"ca_key":      "CA KEY",
+		"ca_password": "secret",
+		"country":     "DE",
+	})
+	resp := &resource.CreateResponse{State: planned}
+	r.Create(ctx, resource.CreateRequest{Plan: tfsdk.Plan{Schema: planned.Schema, Raw: planned.Raw}}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+	if sent["caCrt"] != "CA CERT" || sent["caKey"] != "CA KEY" || sent["caPassword"] != "secret" || sent["country"] != "DE" ||
+		sent["state"] != "" || len(sent["cnames"].([]interface{})) != 0 || sent["proxyCrt"] != nil {
+		t.Errorf("expected the server to generate the certificate from the CA, sent %v", sent)
+	}
+
+	var state proxyCertificateRotationResourceModel
+	resp.State.Get(ctx, &state)
+	if state.ID.ValueString() != "proxy.example.com" || state.Config.ValueString() != "YnVuZGxl" || state.Rotated.IsNull() {
+		t.Errorf("unexpected state %v", state)
+	}
+}
+
+func TestAccProxyCertificateRotationResource(t *testing.T) {
+	dir := os.Getenv("UYUNI_TEST_CA_DIR")
+	acctest.Test(t, acctest.TestCase{
+		PreCheck: func() {
+			testAccRealServerPreCheck(t)
+			testAccSkipUnlessEnv(t, "UYUNI_TEST_CA_DIR", "a directory holding the certificate root-ca.pem and the encrypted key root-ca.key of the server CA")
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []acctest.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccProxyCertificateRotationResourceConfig(dir, "first"),
+				Check: acctest.ComposeAggregateTestCheckFunc(
+					acctest.TestCheckResourceAttrSet("uyuni_proxy_certificate_rotation.test", "config"),
+					acctest.TestCheckResourceAttrSet("uyuni_proxy_certificate_rotation.test", "rotated"),
+				),
+			},
+			// A changed trigger rotates the certificate.
+			{
+				Config: testAccProxyCertificateRotationResourceConfig(dir, "second"),
+				Check:  acctest.TestCheckResourceAttr("uyuni_proxy_certificate_rotation.test", "rotation_triggers.rotation", "second"),
+			},
+		},
+	})
+}
+
+func testAccProxyCertificateRotationResourceConfig(dir, rotation string) string {
+	return fmt.Sprintf(`
+resource "uyuni_proxy_certificate_rotation" "test" {
+  proxy_name  = "tfacc-proxy.example.com"
+  server      = %[1]q
+  email       = "tfacc@example.com"
+  ca_cert     = file("%[2]s/root-ca.pem")
+  ca_key      = file("%[2]s/root-ca.key")
+  ca_password = %[3]q
+  country     = "DE"
+
+  rotation_triggers = {
+    rotation = %[4]q
+  }
+}
+`, os.Getenv("UYUNI_HOST"), dir, testAccEnvOr("UYUNI_TEST_CA_PASSWORD", testAccDefaultPassword), rotation)
+}
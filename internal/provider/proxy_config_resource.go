@@ -0,0 +1,311 @@
+package provider
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+
+	"terraform-provider-uyuni/internal/uyuni"
+	"terraform-provider-uyuni/internal/validators"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                 = &proxyConfigResource{}
+	_ resource.ResourceWithConfigure    = &proxyConfigResource{}
+	_ resource.ResourceWithImportState  = &proxyConfigResource{}
+	_ resource.ResourceWithUpgradeState = &proxyConfigResource{}
+)
+
+// NewProxyConfigResource is a helper function to simplify the provider implementation.
+func NewProxyConfigResource() resource.Resource {
+	return &proxyConfigResource{}
+}
+
+// proxyConfigResource is the resource implementation.
+type proxyConfigResource struct {
+	client *uyuniClient
+}
+
+// proxyConfigResourceModel maps the resource schema data.
+type proxyConfigResourceModel struct {
+	ID              types.String   `tfsdk:"id"`
+	ProxyName       types.String   `tfsdk:"proxy_name"`
+	ProxyPort       types.Int64    `tfsdk:"proxy_port"`
+	Server          types.String   `tfsdk:"server"`
+	MaxCache        types.Int64    `tfsdk:"max_cache"`
+	Email           types.String   `tfsdk:"email"`
+	RootCA          types.String   `tfsdk:"root_ca"`
+	IntermediateCAs types.List     `tfsdk:"intermediate_cas"`
+	ProxyCert       types.String   `tfsdk:"proxy_cert"`
+	ProxyKey        types.String   `tfsdk:"proxy_key"`
+	Config          types.String   `tfsdk:"config"`
+	ServerAlias     types.String   `tfsdk:"server_alias"`
+	Timeouts        timeouts.Value `tfsdk:"timeouts"`
+}
+
+// proxyConfigStateMigrations upgrade states of prior schema versions.
+var proxyConfigStateMigrations = stateMigrations{
+	// Version 0 had no id attribute.
+	migrateAddID("proxy_name"),
+}
+
+// Metadata returns the resource type name.
+func (r *proxyConfigResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_proxy_config"
+}
+
+// Schema defines the schema for the resource.
+func (r *proxyConfigResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Version: proxyConfigStateMigrations.version(),
+		Description: "Generates the configuration bundle of a containerized proxy. " +
+			"The bundle only exists in Terraform state; changing any argument generates a new one.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "FQDN of the proxy.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"proxy_name": schema.StringAttribute{
+				Description: "FQDN of the proxy.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"proxy_port": schema.Int64Attribute{
+				Description: "SSH port the proxy listens on.",
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(22),
+				Validators: []validator.Int64{
+					int64validator.Between(1, 65535),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"server": schema.StringAttribute{
+				Description: "FQDN of the parent server or proxy.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"max_cache": schema.Int64Attribute{
+				Description: "Maximum size of the proxy cache in MB.",
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(102400),
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"email": schema.StringAttribute{
+				Description: "Email address of the proxy administrator.",
+				Required:    true,
+				Validators: []validator.String{
+					validators.Email(),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"root_ca": schema.StringAttribute{
+				Description: "PEM encoded root CA certificate.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"intermediate_cas": schema.ListAttribute{
+				Description: "PEM encoded intermediate CA certificates.",
+				ElementType: types.StringType,
+				Optional:    true,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.RequiresReplace(),
+				},
+			},
+			"proxy_cert": schema.StringAttribute{
+				Description: "PEM encoded proxy certificate.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"proxy_key": schema.StringAttribute{
+				Description: "PEM encoded private key of the proxy certificate.",
+				Required:    true,
+				Sensitive:   true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"config": schema.StringAttribute{
+				Description: "Base64 encoded tar.gz archive with the generated proxy configuration.",
+				Computed:    true,
+				Sensitive:   true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"server_alias": serverAliasAttribute(),
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+			}),
+		},
+	}
+}
+
+// UpgradeState upgrades states of prior schema versions.
+func (r *proxyConfigResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return proxyConfigStateMigrations.upgraders()
+}
+
+// Create a new resource.
+func (r *proxyConfigResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan proxyConfigResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := resourceClient(ctx, r.client, plan.ServerAlias, nil, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	intermediateCAs := []string{}
+	if !plan.IntermediateCAs.IsNull() {
+		resp.Diagnostics.Append(plan.IntermediateCAs.ElementsAs(ctx, &intermediateCAs, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	data := map[string]interface{}{
+		"proxyName":       plan.ProxyName.ValueString(),
+		"proxyPort":       plan.ProxyPort.ValueInt64(),
+		"server":          plan.Server.ValueString(),
+		"maxCache":        plan.MaxCache.ValueInt64(),
+		"email":           plan.Email.ValueString(),
+		"rootCA":          plan.RootCA.ValueString(),
+		"intermediateCAs": intermediateCAs,
+		"proxyCrt":        plan.ProxyCert.ValueString(),
+		"proxyKey":        plan.ProxyKey.ValueString(),
+	}
+
+	tflog.Info(ctx, "About to generate proxy configuration for "+plan.ProxyName.ValueString())
+
+	config, err := apiPost[uyuni.Bytes](ctx, client, "proxy/containerConfig", data)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error generating proxy configuration",
+			"Could not generate proxy configuration: "+err.Error(),
+		)
+		return
+	}
+
+	plan.Config = types.StringValue(base64.StdEncoding.EncodeToString(config.Result))
+
+	plan.ID = plan.ProxyName
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read keeps the generated configuration, the server does not store it.
+func (r *proxyConfigResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state proxyConfigResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state.ID = state.ProxyName
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update is never called as every argument requires replacement.
+func (r *proxyConfigResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan proxyConfigResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete only removes the configuration from state.
+func (r *proxyConfigResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+}
+
+// ImportState always fails: the server does not keep generated configurations,
+// so there is nothing to import.
+func (r *proxyConfigResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resp.Diagnostics.AddError(
+		"Import Not Supported",
+		"uyuni_proxy_config cannot be imported because the server does not store generated proxy configurations. "+
+			"Create the resource instead, which generates a new configuration.",
+	)
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *proxyConfigResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*uyuniClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *uyuniClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
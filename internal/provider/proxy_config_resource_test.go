@@ -0,0 +1,98 @@
+package provider
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccProxyConfigResource(t *testing.T) {
+	rootCA, proxyCert, proxyKey := testAccProxyCertificates(t, "tfacc-proxy.example.com")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccRealServerPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "uyuni_proxy_config" "test" {
+  proxy_name = "tfacc-proxy.example.com"
+  server     = %q
+  email      = "tfacc@example.com"
+  root_ca    = %q
+  proxy_cert = %q
+  proxy_key  = %q
+}
+`, testAccEnvOr("UYUNI_HOST", "uyuni.example.com"), rootCA, proxyCert, proxyKey),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("uyuni_proxy_config.test", "id", "tfacc-proxy.example.com"),
+					resource.TestCheckResourceAttrSet("uyuni_proxy_config.test", "config"),
+				),
+			},
+			{
+				ResourceName:  "uyuni_proxy_config.test",
+				ImportState:   true,
+				ImportStateId: "tfacc-proxy.example.com",
+				ExpectError:   regexp.MustCompile("Import Not Supported"),
+			},
+		},
+	})
+}
+
+// testAccProxyCertificates generates a CA and a certificate for the proxy
+// signed by it, all PEM encoded.
+func testAccProxyCertificates(t *testing.T, fqdn string) (rootCA, cert, key string) {
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Terraform Acceptance CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	proxyKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	proxyTemplate := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: fqdn},
+		DNSNames:     []string{fqdn},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(24 * time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	proxyDER, err := x509.CreateCertificate(rand.Reader, proxyTemplate, caTemplate, &proxyKey.PublicKey, caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalPKCS8PrivateKey(proxyKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caDER})),
+		string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: proxyDER})),
+		string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER}))
+}
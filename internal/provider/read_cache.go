@@ -0,0 +1,79 @@
+package provider
+
+import (
+	"sync"
+	"time"
+)
+
+// readCacheTTL bounds how long a response is reused. Terraform runs every
+// command in a new provider process, so a plan or refresh reads each object
+// once, while long applies still see changes made outside of Terraform.
+const readCacheTTL = time.Minute
+
+// readCache keeps the responses of GET requests, so that data sources and
+// Reads asking for the same list within one operation cost one request. Any
+// write may change what reads return, so every write empties the cache.
+type readCache struct {
+	mu         sync.Mutex
+	ttl        time.Duration
+	now        func() time.Time
+	generation uint64
+	entries    map[string]readCacheEntry
+}
+
+type readCacheEntry struct {
+	body    []byte
+	expires time.Time
+}
+
+func newReadCache(ttl time.Duration) *readCache {
+	return &readCache{
+		ttl:     ttl,
+		now:     time.Now,
+		entries: map[string]readCacheEntry{},
+	}
+}
+
+// get returns the cached response for key. It also returns the generation
+// to store a response fetched after a miss with.
+func (c *readCache) get(key string) ([]byte, uint64, bool) {
+	if c == nil {
+		return nil, 0, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if ok && c.now().Before(entry.expires) {
+		return entry.body, c.generation, true
+	}
+	delete(c.entries, key)
+	return nil, c.generation, false
+}
+
+// put stores a response, unless a write invalidated the cache since the
+// request was sent, in which case the response may already be outdated.
+func (c *readCache) put(key string, body []byte, generation uint64) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if generation != c.generation {
+		return
+	}
+	c.entries[key] = readCacheEntry{body: body, expires: c.now().Add(c.ttl)}
+}
+
+// invalidate drops all responses.
+func (c *readCache) invalidate() {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.generation++
+	c.entries = map[string]readCacheEntry{}
+}
@@ -0,0 +1,87 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// testCachingClient returns a client with a read cache answering every
+// request with 1 and counting the requests reaching the server.
+func testCachingClient(t *testing.T) (*uyuniClient, *atomic.Int32) {
+	var requests atomic.Int32
+	client := testAPIClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		_, _ = w.Write([]byte(`{"success": true, "result": 1}`))
+	})
+	client.cache = newReadCache(readCacheTTL)
+	return client, &requests
+}
+
+func TestReadCacheServesRepeatedReads(t *testing.T) {
+	client, requests := testCachingClient(t)
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		if _, err := apiGet[int](ctx, client, "user/listUsers"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := apiGet[int](ctx, client, "user/getDetails?login=jdoe"); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := requests.Load(); got != 2 {
+		t.Errorf("expected one request per distinct path, got %d", got)
+	}
+}
+
+func TestReadCacheInvalidatedByWrites(t *testing.T) {
+	client, requests := testCachingClient(t)
+	ctx := context.Background()
+
+	if _, err := apiGet[int](ctx, client, "user/listUsers"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := apiPost[int](ctx, client, "user/create", map[string]interface{}{"login": "jdoe"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := apiGet[int](ctx, client, "user/listUsers"); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := requests.Load(); got != 3 {
+		t.Errorf("expected the write to invalidate the cached read, got %d requests", got)
+	}
+}
+
+func TestReadCacheExpires(t *testing.T) {
+	cache := newReadCache(time.Minute)
+	now := time.Now()
+	cache.now = func() time.Time { return now }
+
+	_, generation, _ := cache.get("user/listUsers")
+	cache.put("user/listUsers", []byte("1"), generation)
+	if _, _, ok := cache.get("user/listUsers"); !ok {
+		t.Fatal("expected a cached response")
+	}
+
+	now = now.Add(2 * time.Minute)
+	if _, _, ok := cache.get("user/listUsers"); ok {
+		t.Error("expected the response to expire")
+	}
+}
+
+func TestReadCacheDropsReadsOverlappingWrites(t *testing.T) {
+	cache := newReadCache(time.Minute)
+
+	_, generation, _ := cache.get("user/listUsers")
+	cache.invalidate()
+	cache.put("user/listUsers", []byte("1"), generation)
+
+	if _, _, ok := cache.get("user/listUsers"); ok {
+		t.Error("expected a response read before a write not to be cached")
+	}
+}
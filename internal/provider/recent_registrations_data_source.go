@@ -0,0 +1,186 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"terraform-provider-uyuni/internal/uyuni"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &RecentRegistrationsDataSource{}
+	_ datasource.DataSourceWithConfigure = &RecentRegistrationsDataSource{}
+)
+
+// RecentRegistrationsDataSourceModel maps the data source schema data.
+type RecentRegistrationsDataSourceModel struct {
+	MaxAgeHours types.Int64               `tfsdk:"max_age_hours"`
+	SystemIDs   types.Set                 `tfsdk:"system_ids"`
+	Systems     []recentRegistrationModel `tfsdk:"systems"`
+}
+
+// recentRegistrationModel maps a recently registered system.
+type recentRegistrationModel struct {
+	ID          types.Int64  `tfsdk:"id"`
+	Name        types.String `tfsdk:"name"`
+	Created     types.String `tfsdk:"created"`
+	LastCheckin types.String `tfsdk:"last_checkin"`
+}
+
+// NewRecentRegistrationsDataSource is a helper function to simplify the provider implementation.
+func NewRecentRegistrationsDataSource() datasource.DataSource {
+	return &RecentRegistrationsDataSource{}
+}
+
+// RecentRegistrationsDataSource is the data source implementation.
+type RecentRegistrationsDataSource struct {
+	client *uyuniClient
+}
+
+// Metadata returns the data source type name.
+func (d *RecentRegistrationsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_recent_registrations"
+}
+
+// Schema defines the schema for the data source.
+func (d *RecentRegistrationsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the systems registered recently, e.g. to add new machines to groups, " +
+			"schedule their onboarding or alert on unexpected registrations. Only systems the provider user can see are listed.",
+		Attributes: map[string]schema.Attribute{
+			"max_age_hours": schema.Int64Attribute{
+				Description: "Number of hours to look back for registrations.",
+				Required:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"system_ids": schema.SetAttribute{
+				Description: "IDs of the recently registered systems.",
+				ElementType: types.Int64Type,
+				Computed:    true,
+			},
+			"systems": schema.ListNestedAttribute{
+				Description: "Recently registered systems, the newest last.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.Int64Attribute{
+							Description: "ID of the system.",
+							Computed:    true,
+						},
+						"name": schema.StringAttribute{
+							Description: "Name of the system.",
+							Computed:    true,
+						},
+						"created": schema.StringAttribute{
+							Description: "Date the system was registered, in RFC 3339 format.",
+							Computed:    true,
+						},
+						"last_checkin": schema.StringAttribute{
+							Description: "Date the system last checked in, in RFC 3339 format.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *RecentRegistrationsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state RecentRegistrationsDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	systems, err := apiGet[[]uyuni.SystemSummary](ctx, d.client, "system/listSystems")
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Uyuni recent registrations",
+			"Could not list systems: "+err.Error(),
+		)
+		return
+	}
+
+	type registration struct {
+		system  uyuni.SystemSummary
+		created time.Time
+	}
+	cutoff := time.Now().Add(-time.Duration(state.MaxAgeHours.ValueInt64()) * time.Hour)
+	var recent []registration
+	for _, system := range systems.Result {
+		created, err := uyuni.ParseDate(system.Created)
+		if err != nil {
+			tflog.Warn(ctx, fmt.Sprintf("Ignoring system %d with an unknown registration date", system.ID), map[string]interface{}{"error": err.Error()})
+			continue
+		}
+		if created.After(cutoff) {
+			recent = append(recent, registration{system, created})
+		}
+	}
+	sort.Slice(recent, func(i, j int) bool {
+		if !recent[i].created.Equal(recent[j].created) {
+			return recent[i].created.Before(recent[j].created)
+		}
+		return recent[i].system.ID < recent[j].system.ID
+	})
+
+	ids := make([]int64, 0, len(recent))
+	state.Systems = make([]recentRegistrationModel, 0, len(recent))
+	for _, r := range recent {
+		ids = append(ids, int64(r.system.ID))
+		state.Systems = append(state.Systems, recentRegistrationModel{
+			ID:          types.Int64Value(int64(r.system.ID)),
+			Name:        types.StringValue(r.system.Name),
+			Created:     types.StringValue(r.created.UTC().Format(time.RFC3339)),
+			LastCheckin: timestampValue(ctx, r.system.LastCheckin),
+		})
+	}
+	state.SystemIDs, diags = types.SetValueFrom(ctx, types.Int64Type, ids)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *RecentRegistrationsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*uyuniClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *uyuniClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
@@ -0,0 +1,77 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestRecentRegistrationsDataSource(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	client := testAPIClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/system/listSystems" {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		_, _ = w.Write([]byte(`{"success": true, "result": [
+			{"id": 1000010000, "name": "web01.example.com", "created": "2024-01-20T09:30:00Z", "last_checkin": "2024-09-02T10:00:00Z"},
+			{"id": 1000010006, "name": "build02.example.com", "created": "` + now.Add(-time.Hour).Format(time.RFC3339) + `", "last_checkin": ""},
+			{"id": 1000010005, "name": "build01.example.com", "created": "` + now.Add(-2*time.Hour).Format(time.RFC3339) + `", "last_checkin": ""}
+		]}`))
+	})
+
+	d := NewRecentRegistrationsDataSource()
+	d.(datasource.DataSourceWithConfigure).Configure(ctx, datasource.ConfigureRequest{ProviderData: client}, &datasource.ConfigureResponse{})
+	var schemaResp datasource.SchemaResponse
+	d.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	values := map[string]tftypes.Value{}
+	for name, attributeType := range objectType.AttributeTypes {
+		values[name] = tftypes.NewValue(attributeType, nil)
+	}
+	values["max_age_hours"] = tftypes.NewValue(tftypes.Number, 24)
+	config := tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, values)}
+	resp := &datasource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: config.Raw}}
+	d.Read(ctx, datasource.ReadRequest{Config: config}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+
+	var state RecentRegistrationsDataSourceModel
+	resp.State.Get(ctx, &state)
+	if len(state.SystemIDs.Elements()) != 2 {
+		t.Errorf("expected 2 system ids, got %v", state.SystemIDs)
+	}
+	if len(state.Systems) != 2 || state.Systems[0].ID.ValueInt64() != 1000010005 || state.Systems[1].Name.ValueString() != "build02.example.com" {
+		t.Errorf("expected the recent systems, the newest last, got %v", state.Systems)
+	}
+	if !state.Systems[0].LastCheckin.IsNull() {
+		t.Errorf("expected no last checkin, got %v", state.Systems[0].LastCheckin)
+	}
+}
+
+func TestAccRecentRegistrationsDataSource(t *testing.T) {
+	minion := os.Getenv("UYUNI_TEST_MINION_ID")
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccRealServerPreCheck(t)
+			testAccSkipUnlessEnv(t, "UYUNI_TEST_MINION_ID", "a registered Salt minion")
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				// Ten years cover every registration of the test server.
+				Config: `data "uyuni_recent_registrations" "test" { max_age_hours = 87600 }`,
+				Check:  resource.TestCheckTypeSetElemAttr("data.uyuni_recent_registrations.test", "system_ids.*", minion),
+			},
+		},
+	})
+}
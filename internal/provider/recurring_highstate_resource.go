@@ -0,0 +1,567 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"terraform-provider-uyuni/internal/uyuni"
+	"terraform-provider-uyuni/internal/validators"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                     = &recurringHighstateResource{}
+	_ resource.ResourceWithConfigure        = &recurringHighstateResource{}
+	_ resource.ResourceWithConfigValidators = &recurringHighstateResource{}
+)
+
+// Entity types of recurring action schedules.
+const (
+	recurringEntityMinion = "minion"
+	recurringEntityGroup  = "group"
+)
+
+// NewRecurringHighstateResource is a helper function to simplify the provider implementation.
+func NewRecurringHighstateResource() resource.Resource {
+	return &recurringHighstateResource{}
+}
+
+// recurringHighstateResource is the resource implementation.
+type recurringHighstateResource struct {
+	client *uyuniClient
+}
+
+// recurringHighstateResourceModel maps the resource schema data.
+type recurringHighstateResourceModel struct {
+	ID             types.String `tfsdk:"id"`
+	Name           types.String `tfsdk:"name"`
+	Cron           types.String `tfsdk:"cron"`
+	Test           types.Bool   `tfsdk:"test"`
+	Active         types.Bool   `tfsdk:"active"`
+	SystemIDs      types.Set    `tfsdk:"system_ids"`
+	GroupName      types.String `tfsdk:"group_name"`
+	ExcludeSystems types.Set    `tfsdk:"exclude_systems"`
+	Target         *targetModel `tfsdk:"target"`
+	ScheduleIDs    types.Map    `tfsdk:"schedule_ids"`
+	InSync         types.Bool   `tfsdk:"in_sync"`
+	ServerAlias    types.String `tfsdk:"server_alias"`
+}
+
+// Metadata returns the resource type name.
+func (r *recurringHighstateResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_recurring_highstate"
+}
+
+// Schema defines the schema for the resource.
+func (r *recurringHighstateResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Applies the highstate to systems on a recurring schedule. " +
+			"A group without exclusions gets a single schedule, which covers the members of the group at the time it runs. " +
+			"With exclude_systems, each member of the group but the excluded systems gets its own schedule instead, " +
+			"resolved on apply: when the members change, in_sync turns false and the next apply updates the schedules. " +
+			"The systems a target block selects get their own schedules the same way.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Name of the schedules.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "Name of the schedules, unique per system or group.",
+				Required:    true,
+			},
+			"cron": schema.StringAttribute{
+				Description: "Quartz cron expression of the schedule, e.g. `0 0 2 ? * *` for 2 AM daily.",
+				Required:    true,
+				Validators: []validator.String{
+					validators.Cron(),
+				},
+			},
+			"test": schema.BoolAttribute{
+				Description: "Apply the highstate in test mode, which only reports the changes. Defaults to false.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"active": schema.BoolAttribute{
+				Description: "Whether the schedules run. Defaults to true.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+			},
+			"system_ids": schema.SetAttribute{
+				Description: "IDs of the systems to apply the highstate to.",
+				ElementType: types.Int64Type,
+				Optional:    true,
+			},
+			"group_name": schema.StringAttribute{
+				Description: "Name of the group to apply the highstate to.",
+				Optional:    true,
+			},
+			"exclude_systems": schema.SetAttribute{
+				Description: "IDs of members of the group to leave out, e.g. canary systems.",
+				ElementType: types.Int64Type,
+				Optional:    true,
+			},
+			"schedule_ids": schema.MapAttribute{
+				Description: "ID of each schedule by its target, `group:<id>` or `minion:<id>`.",
+				ElementType: types.Int64Type,
+				Computed:    true,
+			},
+			"in_sync": schema.BoolAttribute{
+				Description: "Whether the schedules target the current members of the group but the excluded systems, " +
+					"or the systems the target block currently selects. " +
+					"It is false when they changed after the last apply, in which case the next apply updates the schedules.",
+				Computed: true,
+				Default:  booldefault.StaticBool(true),
+			},
+			"server_alias": serverAliasAttribute(),
+		},
+		Blocks: map[string]schema.Block{
+			"target": targetBlock("Systems to apply the highstate to, instead of system_ids or group_name."),
+		},
+	}
+}
+
+// ConfigValidators returns the validators checking attributes against each other.
+func (r *recurringHighstateResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.ExactlyOneOf(
+			path.MatchRoot("system_ids"),
+			path.MatchRoot("group_name"),
+			path.MatchRoot("target"),
+		),
+		validators.AlsoRequires("group_name", "exclude_systems"),
+	}
+}
+
+// recurringEntityKey returns the key of a schedule target in schedule_ids.
+func recurringEntityKey(entityType string, id int64) string {
+	return fmt.Sprintf("%s%s%d", entityType, importIDSeparator, id)
+}
+
+// parseRecurringEntityKey splits a key of schedule_ids into the entity type
+// and id.
+func parseRecurringEntityKey(key string) (string, int64, error) {
+	entityType, id, _ := strings.Cut(key, importIDSeparator)
+	entityID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return "", 0, fmt.Errorf("invalid schedule target %q", key)
+	}
+	return entityType, entityID, nil
+}
+
+// int64Set returns the elements of a set of int64.
+func int64Set(ctx context.Context, set types.Set) ([]int64, error) {
+	values := []int64{}
+	if set.IsNull() || set.IsUnknown() {
+		return values, nil
+	}
+	if diags := set.ElementsAs(ctx, &values, false); diags.HasError() {
+		return nil, fmt.Errorf("invalid set of integers")
+	}
+	return values, nil
+}
+
+// stringSet returns the elements of a set of strings.
+func stringSet(ctx context.Context, set types.Set) ([]string, error) {
+	values := []string{}
+	if set.IsNull() || set.IsUnknown() {
+		return values, nil
+	}
+	if diags := set.ElementsAs(ctx, &values, false); diags.HasError() {
+		return nil, fmt.Errorf("invalid set of strings")
+	}
+	return values, nil
+}
+
+// targets resolves the schedule targets of the model into keys of
+// schedule_ids.
+func (m *recurringHighstateResourceModel) targets(ctx context.Context, client *uyuniClient) ([]string, error) {
+	keys := []string{}
+	if m.Target != nil {
+		sids, err := m.Target.resolve(ctx, client)
+		if err != nil {
+			return nil, err
+		}
+		for _, sid := range sids {
+			keys = append(keys, recurringEntityKey(recurringEntityMinion, sid))
+		}
+		sort.Strings(keys)
+		return keys, nil
+	}
+	if m.GroupName.IsNull() {
+		sids, err := int64Set(ctx, m.SystemIDs)
+		if err != nil {
+			return nil, err
+		}
+		for _, sid := range sids {
+			keys = append(keys, recurringEntityKey(recurringEntityMinion, sid))
+		}
+		sort.Strings(keys)
+		return keys, nil
+	}
+
+	group := m.GroupName.ValueString()
+	excluded, err := int64Set(ctx, m.ExcludeSystems)
+	if err != nil {
+		return nil, err
+	}
+	if len(excluded) == 0 {
+		details, err := apiGet[uyuni.SystemGroup](ctx, client, "systemgroup/getDetails?systemGroupName="+url.QueryEscape(group))
+		if err != nil {
+			return nil, fmt.Errorf("could not read group %s: %w", group, err)
+		}
+		return []string{recurringEntityKey(recurringEntityGroup, int64(details.Result.ID))}, nil
+	}
+
+	systems, err := apiGet[[]uyuni.ShortSystem](ctx, client, "systemgroup/listSystemsMinimal?systemGroupName="+url.QueryEscape(group))
+	if err != nil {
+		return nil, fmt.Errorf("could not list systems of group %s: %w", group, err)
+	}
+	skip := map[int64]bool{}
+	for _, sid := range excluded {
+		skip[sid] = true
+	}
+	for _, system := range systems.Result {
+		if !skip[int64(system.ID)] {
+			keys = append(keys, recurringEntityKey(recurringEntityMinion, int64(system.ID)))
+		}
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+// scheduleIDs returns the schedule ids of the state by target.
+func (m *recurringHighstateResourceModel) scheduleIDs(ctx context.Context) (map[string]int64, error) {
+	ids := map[string]int64{}
+	if m.ScheduleIDs.IsNull() || m.ScheduleIDs.IsUnknown() {
+		return ids, nil
+	}
+	if diags := m.ScheduleIDs.ElementsAs(ctx, &ids, false); diags.HasError() {
+		return nil, fmt.Errorf("invalid schedule ids")
+	}
+	return ids, nil
+}
+
+// batchError joins the errors of a batch into one, nil if there are none.
+func batchError(errs map[string]error) error {
+	if len(errs) == 0 {
+		return nil
+	}
+	failed := make([]string, 0, len(errs))
+	for key, err := range errs {
+		failed = append(failed, fmt.Sprintf("%s: %s", key, err))
+	}
+	sort.Strings(failed)
+	return fmt.Errorf("%s", strings.Join(failed, "; "))
+}
+
+// applySchedules creates the schedules of the targets missing in ids, updates
+// those of the other targets and deletes the schedules of targets no longer
+// wanted. ids is updated as schedules are created and deleted, so that it
+// holds the existing schedules even if some calls fail.
+func (m *recurringHighstateResourceModel) applySchedules(ctx context.Context, client *uyuniClient, targets []string, ids map[string]int64) error {
+	wanted := map[string]bool{}
+	for _, key := range targets {
+		wanted[key] = true
+	}
+	keys := append([]string{}, targets...)
+	for key := range ids {
+		if !wanted[key] {
+			keys = append(keys, key)
+		}
+	}
+
+	var mu sync.Mutex
+	errs := runBatch(keys, func(key string) error {
+		mu.Lock()
+		id, exists := ids[key]
+		mu.Unlock()
+
+		switch {
+		case !wanted[key]:
+			_, err := apiPost[int](ctx, client, "recurring/delete", map[string]interface{}{"scheduleId": id})
+			if err != nil && !isNotFoundError(err) {
+				return err
+			}
+			mu.Lock()
+			delete(ids, key)
+			mu.Unlock()
+		case exists:
+			_, err := apiPost[int](ctx, client, "recurring/highstate/update", map[string]interface{}{
+				"scheduleDetails": map[string]interface{}{
+					"id":        id,
+					"name":      m.Name.ValueString(),
+					"cron_expr": m.Cron.ValueString(),
+					"test":      m.Test.ValueBool(),
+					"active":    m.Active.ValueBool(),
+				},
+			})
+			return err
+		default:
+			entityType, entityID, err := parseRecurringEntityKey(key)
+			if err != nil {
+				return err
+			}
+			created, err := apiPost[int64](ctx, client, "recurring/highstate/create", map[string]interface{}{
+				"scheduleDetails": map[string]interface{}{
+					"entity_type": entityType,
+					"entity_id":   entityID,
+					"name":        m.Name.ValueString(),
+					"cron_expr":   m.Cron.ValueString(),
+					"test":        m.Test.ValueBool(),
+				},
+			})
+			if err != nil {
+				return err
+			}
+			if !m.Active.ValueBool() {
+				// Schedules are created active.
+				_, err = apiPost[int](ctx, client, "recurring/highstate/update", map[string]interface{}{
+					"scheduleDetails": map[string]interface{}{"id": created.Result, "active": false},
+				})
+			}
+			mu.Lock()
+			ids[key] = created.Result
+			mu.Unlock()
+			return err
+		}
+		return nil
+	})
+	return batchError(errs)
+}
+
+// setSchedules applies the schedules of the plan and stores their ids in it.
+// The ids are stored even if applying fails, so that the state tracks all
+// schedules which exist.
+func (m *recurringHighstateResourceModel) setSchedules(ctx context.Context, client *uyuniClient, ids map[string]int64) error {
+	targets, err := m.targets(ctx, client)
+	if err != nil {
+		return err
+	}
+	applyErr := m.applySchedules(ctx, client, targets, ids)
+
+	m.ID = m.Name
+	m.InSync = types.BoolValue(true)
+	scheduleIDs, diags := types.MapValueFrom(ctx, types.Int64Type, ids)
+	if diags.HasError() {
+		return fmt.Errorf("could not set schedule ids")
+	}
+	m.ScheduleIDs = scheduleIDs
+	return applyErr
+}
+
+// Create a new resource.
+func (r *recurringHighstateResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan recurringHighstateResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := resourceClient(ctx, r.client, plan.ServerAlias, nil, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	if err := plan.setSchedules(ctx, client, map[string]int64{}); err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating recurring highstate",
+			"Could not schedule "+plan.Name.ValueString()+": "+err.Error(),
+		)
+		if plan.ScheduleIDs.IsUnknown() {
+			return
+		}
+	}
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read resource information.
+func (r *recurringHighstateResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Get current state
+	var state recurringHighstateResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := resourceClient(ctx, r.client, state.ServerAlias, nil, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	ids, err := state.scheduleIDs(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Reading Uyuni recurring highstate", err.Error())
+		return
+	}
+	for key, id := range ids {
+		schedule, err := apiGet[uyuni.RecurringAction](ctx, client, fmt.Sprintf("recurring/lookupById?id=%d", id))
+		if err != nil {
+			if isNotFoundError(err) {
+				tflog.Warn(ctx, fmt.Sprintf("Schedule %d of %s no longer exists", id, key))
+				delete(ids, key)
+				continue
+			}
+			resp.Diagnostics.AddError(
+				"Error Reading Uyuni recurring highstate",
+				fmt.Sprintf("Could not read schedule %d: %s", id, err),
+			)
+			return
+		}
+		// Any schedule differing from the configuration shows as a change.
+		if schedule.Result.Name != state.Name.ValueString() {
+			state.Name = types.StringValue(schedule.Result.Name)
+		}
+		if schedule.Result.Cron != state.Cron.ValueString() {
+			state.Cron = types.StringValue(schedule.Result.Cron)
+		}
+		if schedule.Result.Test != state.Test.ValueBool() {
+			state.Test = types.BoolValue(schedule.Result.Test)
+		}
+		if schedule.Result.Active != state.Active.ValueBool() {
+			state.Active = types.BoolValue(schedule.Result.Active)
+		}
+	}
+	if len(ids) == 0 {
+		tflog.Warn(ctx, "Recurring highstate "+state.Name.ValueString()+" no longer exists, removing it from state")
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	targets, err := state.targets(ctx, client)
+	if err != nil && !isNotFoundError(err) && !errors.Is(err, errTargetEmpty) {
+		resp.Diagnostics.AddError("Error Reading Uyuni recurring highstate", err.Error())
+		return
+	}
+	inSync := err == nil && len(targets) == len(ids)
+	for _, key := range targets {
+		if _, ok := ids[key]; !ok {
+			inSync = false
+		}
+	}
+	state.InSync = types.BoolValue(inSync)
+	state.ScheduleIDs, diags = types.MapValueFrom(ctx, types.Int64Type, ids)
+	resp.Diagnostics.Append(diags...)
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update the schedules and their targets.
+func (r *recurringHighstateResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Retrieve values from plan and state
+	var plan, state recurringHighstateResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := resourceClient(ctx, r.client, plan.ServerAlias, nil, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	ids, err := state.scheduleIDs(ctx)
+	if err == nil {
+		err = plan.setSchedules(ctx, client, ids)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating recurring highstate",
+			"Could not update "+plan.Name.ValueString()+": "+err.Error(),
+		)
+		if plan.ScheduleIDs.IsUnknown() {
+			return
+		}
+	}
+
+	diags := resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete the schedules.
+func (r *recurringHighstateResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Retrieve values from state
+	var state recurringHighstateResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := resourceClient(ctx, r.client, state.ServerAlias, nil, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	ids, err := state.scheduleIDs(ctx)
+	if err == nil {
+		err = state.applySchedules(ctx, client, nil, ids)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Deleting Uyuni recurring highstate",
+			"Could not delete "+state.Name.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *recurringHighstateResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*uyuniClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *uyuniClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
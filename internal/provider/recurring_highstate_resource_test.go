@@ -0,0 +1,201 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	acctest "github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+// testRecurringServer serves the group web with the given members and the
+// systems found by any search, and records the schedules created and deleted.
+type testRecurringServer struct {
+	mu      sync.Mutex
+	members string
+	found   string
+	created []string
+	deleted []int64
+}
+
+func (s *testRecurringServer) handler(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var body struct {
+		ScheduleID      int64                  `json:"scheduleId"`
+		ScheduleDetails map[string]interface{} `json:"scheduleDetails"`
+	}
+	_ = json.NewDecoder(r.Body).Decode(&body)
+	switch r.URL.Path {
+	case "/systemgroup/getDetails":
+		_, _ = w.Write([]byte(`{"success": true, "result": {"id": 7, "name": "web"}}`))
+	case "/systemgroup/listSystemsMinimal":
+		_, _ = w.Write([]byte(`{"success": true, "result": [` + s.members + `]}`))
+	case "/system/search/ip":
+		_, _ = w.Write([]byte(`{"success": true, "result": [` + s.found + `]}`))
+	case "/recurring/highstate/create":
+		s.created = append(s.created, fmt.Sprintf("%v:%v", body.ScheduleDetails["entity_type"], body.ScheduleDetails["entity_id"]))
+		_, _ = w.Write([]byte(fmt.Sprintf(`{"success": true, "result": %d}`, 100+len(s.created))))
+	case "/recurring/lookupById":
+		_, _ = w.Write([]byte(`{"success": true, "result": {"id": 101, "name": "nightly", "cron": "0 0 2 ? * *", "test": false, "active": true}}`))
+	case "/recurring/delete":
+		s.deleted = append(s.deleted, body.ScheduleID)
+		_, _ = w.Write([]byte(`{"success": true, "result": 1}`))
+	default:
+		_, _ = w.Write([]byte(`{"success": false, "message": "unexpected request ` + r.URL.String() + `"}`))
+	}
+}
+
+func testRecurringHighstateAttributes() map[string]interface{} {
+	return map[string]interface{}{
+		"name":            "nightly",
+		"cron":            "0 0 2 ? * *",
+		"test":            false,
+		"active":          true,
+		"group_name":      "web",
+		"exclude_systems": []int64{2},
+		"in_sync":         true,
+	}
+}
+
+func TestRecurringHighstateExcludesSystems(t *testing.T) {
+	ctx := context.Background()
+	server := &testRecurringServer{members: `{"id": 1}, {"id": 2}, {"id": 3}`}
+	r := NewRecurringHighstateResource()
+	client := testAPIClient(t, server.handler)
+	testConfigure(t, r, client)
+
+	planned := testState(t, r, testRecurringHighstateAttributes())
+	createResp := &resource.CreateResponse{State: planned}
+	r.Create(ctx, resource.CreateRequest{Plan: tfsdk.Plan{Schema: planned.Schema, Raw: planned.Raw}}, createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatal(createResp.Diagnostics)
+	}
+	sort.Strings(server.created)
+	if want := []string{"minion:1", "minion:3"}; !reflect.DeepEqual(server.created, want) {
+		t.Errorf("expected schedules for %v, got %v", want, server.created)
+	}
+
+	// A new member puts the schedules out of sync.
+	server.members += `, {"id": 4}`
+	readResp := &resource.ReadResponse{State: createResp.State}
+	r.Read(ctx, resource.ReadRequest{State: createResp.State}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatal(readResp.Diagnostics)
+	}
+	var state recurringHighstateResourceModel
+	readResp.State.Get(ctx, &state)
+	if state.InSync.ValueBool() {
+		t.Error("expected the schedules to be out of sync")
+	}
+
+	r.Delete(ctx, resource.DeleteRequest{State: readResp.State}, &resource.DeleteResponse{})
+	if len(server.deleted) != 2 {
+		t.Errorf("expected both schedules to be deleted, got %v", server.deleted)
+	}
+}
+
+func TestRecurringHighstateTargetsGroupWithoutExclusions(t *testing.T) {
+	ctx := context.Background()
+	server := &testRecurringServer{}
+	r := NewRecurringHighstateResource()
+	testConfigure(t, r, testAPIClient(t, server.handler))
+
+	attributes := testRecurringHighstateAttributes()
+	delete(attributes, "exclude_systems")
+	planned := testState(t, r, attributes)
+	resp := &resource.CreateResponse{State: planned}
+	r.Create(ctx, resource.CreateRequest{Plan: tfsdk.Plan{Schema: planned.Schema, Raw: planned.Raw}}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+	if want := []string{"group:7"}; !reflect.DeepEqual(server.created, want) {
+		t.Errorf("expected schedules for %v, got %v", want, server.created)
+	}
+}
+
+func TestRecurringHighstateResolvesTargetSearch(t *testing.T) {
+	ctx := context.Background()
+	server := &testRecurringServer{found: `{"id": 3}, {"id": 1}`}
+	r := NewRecurringHighstateResource()
+	testConfigure(t, r, testAPIClient(t, server.handler))
+
+	attributes := testRecurringHighstateAttributes()
+	delete(attributes, "group_name")
+	delete(attributes, "exclude_systems")
+	attributes["target"] = &targetModel{
+		SystemIDs:  types.SetNull(types.Int64Type),
+		GroupNames: types.SetNull(types.StringType),
+		Search:     types.StringValue("10.0.1."),
+		SearchBy:   types.StringValue("ip"),
+	}
+	planned := testState(t, r, attributes)
+	createResp := &resource.CreateResponse{State: planned}
+	r.Create(ctx, resource.CreateRequest{Plan: tfsdk.Plan{Schema: planned.Schema, Raw: planned.Raw}}, createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatal(createResp.Diagnostics)
+	}
+	sort.Strings(server.created)
+	if want := []string{"minion:1", "minion:3"}; !reflect.DeepEqual(server.created, want) {
+		t.Errorf("expected schedules for %v, got %v", want, server.created)
+	}
+
+	// A search finding nothing puts the schedules out of sync rather than
+	// failing the refresh.
+	server.found = ""
+	readResp := &resource.ReadResponse{State: createResp.State}
+	r.Read(ctx, resource.ReadRequest{State: createResp.State}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatal(readResp.Diagnostics)
+	}
+	var state recurringHighstateResourceModel
+	readResp.State.Get(ctx, &state)
+	if state.InSync.ValueBool() {
+		t.Error("expected the schedules to be out of sync")
+	}
+}
+
+func TestAccRecurringHighstateResource(t *testing.T) {
+	acctest.Test(t, acctest.TestCase{
+		PreCheck: func() {
+			testAccRealServerPreCheck(t)
+			testAccSeedSystemGroup(t, "tfacc-highstate")
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []acctest.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccRecurringHighstateResourceConfig("0 0 2 ? * *"),
+				Check: acctest.ComposeAggregateTestCheckFunc(
+					acctest.TestCheckResourceAttr("uyuni_recurring_highstate.test", "in_sync", "true"),
+					acctest.TestCheckResourceAttr("uyuni_recurring_highstate.test", "schedule_ids.%", "1"),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: testAccRecurringHighstateResourceConfig("0 0 3 ? * SUN"),
+				Check:  acctest.TestCheckResourceAttr("uyuni_recurring_highstate.test", "cron", "0 0 3 ? * SUN"),
+			},
+		},
+	})
+}
+
+func testAccRecurringHighstateResourceConfig(cron string) string {
+	return fmt.Sprintf(`
+resource "uyuni_recurring_highstate" "test" {
+  name       = "tfacc-highstate"
+  cron       = %q
+  group_name = "tfacc-highstate"
+  test       = true
+}
+`, cron)
+}
@@ -0,0 +1,370 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"terraform-provider-uyuni/internal/uyuni"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &repositoryResource{}
+	_ resource.ResourceWithConfigure   = &repositoryResource{}
+	_ resource.ResourceWithImportState = &repositoryResource{}
+)
+
+// NewRepositoryResource is a helper function to simplify the provider implementation.
+func NewRepositoryResource() resource.Resource {
+	return &repositoryResource{}
+}
+
+// repositoryResource is the resource implementation.
+type repositoryResource struct {
+	client *uyuniClient
+}
+
+// repositoryResourceModel maps the resource schema data.
+type repositoryResourceModel struct {
+	ID                types.String   `tfsdk:"id"`
+	Label             types.String   `tfsdk:"label"`
+	URL               types.String   `tfsdk:"url"`
+	Type              types.String   `tfsdk:"type"`
+	HasSignedMetadata types.Bool     `tfsdk:"has_signed_metadata"`
+	SSLCACert         types.String   `tfsdk:"ssl_ca_cert_name"`
+	SSLClientCert     types.String   `tfsdk:"ssl_client_cert_name"`
+	SSLClientKey      types.String   `tfsdk:"ssl_client_key_name"`
+	RepositoryID      types.Int64    `tfsdk:"repository_id"`
+	ServerAlias       types.String   `tfsdk:"server_alias"`
+	Org               *orgModel      `tfsdk:"org"`
+	Timeouts          timeouts.Value `tfsdk:"timeouts"`
+}
+
+// Metadata returns the resource type name.
+func (r *repositoryResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_repository"
+}
+
+// Schema defines the schema for the resource.
+func (r *repositoryResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a repository custom channels sync from. Associate it with channels through " +
+			"the repository_labels of uyuni_software_channel. Replacing the repository drops its associations, " +
+			"which the channels restore with the next apply.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Label of the repository.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"label": schema.StringAttribute{
+				Description: "Label of the repository.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"url": schema.StringAttribute{
+				Description: "URL of the repository.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"type": schema.StringAttribute{
+				Description: "Type of the repository: `yum`, `deb` or `uln`. Defaults to `yum`. Changing it replaces the repository.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString("yum"),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf("yum", "deb", "uln"),
+				},
+			},
+			"has_signed_metadata": schema.BoolAttribute{
+				Description: "Whether the metadata of the repository is GPG signed and its signature checked on sync, " +
+					"for `deb` repositories. Defaults to false. Changing it replaces the repository.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"ssl_ca_cert_name": schema.StringAttribute{
+				Description: "Description of the SSL crypto key holding the CA certificate of the repository, " +
+					"see uyuni_custom_repo_ssl_bundle and uyuni_crypto_keys.",
+				Optional: true,
+			},
+			"ssl_client_cert_name": schema.StringAttribute{
+				Description: "Description of the SSL crypto key holding the client certificate for the repository.",
+				Optional:    true,
+			},
+			"ssl_client_key_name": schema.StringAttribute{
+				Description: "Description of the SSL crypto key holding the client key for the repository.",
+				Optional:    true,
+			},
+			"repository_id": schema.Int64Attribute{
+				Description: "ID of the repository.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"server_alias": serverAliasAttribute(),
+		},
+		Blocks: map[string]schema.Block{
+			"org": orgBlock(),
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+// setFromRepository sets the attributes from the details of the repository.
+func (m *repositoryResourceModel) setFromRepository(repo *uyuni.Repository) {
+	m.ID = m.Label
+	m.RepositoryID = types.Int64Value(int64(repo.ID))
+	m.URL = types.StringValue(repo.SourceURL)
+	m.Type = types.StringValue(repo.Type)
+	m.HasSignedMetadata = types.BoolValue(repo.HasSignedMetadata)
+	m.SSLCACert, m.SSLClientCert, m.SSLClientKey = types.StringNull(), types.StringNull(), types.StringNull()
+	if len(repo.SSLContentSources) > 0 {
+		ssl := repo.SSLContentSources[0]
+		m.SSLCACert = nonEmptyString(ssl.SSLCaDesc)
+		m.SSLClientCert = nonEmptyString(ssl.SSLCertDesc)
+		m.SSLClientKey = nonEmptyString(ssl.SSLKeyDesc)
+	}
+}
+
+// Create a new resource.
+func (r *repositoryResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan repositoryResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	client := resourceClient(ctx, r.client, plan.ServerAlias, plan.Org, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	label := plan.Label.ValueString()
+	tflog.Info(ctx, "About to create repository "+label)
+
+	repo, err := apiPost[uyuni.Repository](ctx, client, "channel/software/createRepo", map[string]interface{}{
+		"label":             label,
+		"type":              plan.Type.ValueString(),
+		"url":               plan.URL.ValueString(),
+		"sslCaCert":         plan.SSLCACert.ValueString(),
+		"sslCliCert":        plan.SSLClientCert.ValueString(),
+		"sslCliKey":         plan.SSLClientKey.ValueString(),
+		"hasSignedMetadata": plan.HasSignedMetadata.ValueBool(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating repository",
+			"Could not create repository "+label+": "+err.Error(),
+		)
+		return
+	}
+	plan.ID = plan.Label
+	plan.RepositoryID = types.Int64Value(int64(repo.Result.ID))
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read resource information.
+func (r *repositoryResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Get current state
+	var state repositoryResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := resourceClient(ctx, r.client, state.ServerAlias, state.Org, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	label := state.Label.ValueString()
+	repo, err := apiGet[uyuni.Repository](ctx, client, "channel/software/getRepoDetails?repoLabel="+url.QueryEscape(label))
+	if err != nil {
+		if handleNotFound(ctx, resp, err, "Repository "+label) {
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Reading Uyuni repository",
+			"Could not read repository "+label+": "+err.Error(),
+		)
+		return
+	}
+	state.setFromRepository(&repo.Result)
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *repositoryResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Retrieve values from plan and state
+	var plan, state repositoryResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	client := resourceClient(ctx, r.client, plan.ServerAlias, plan.Org, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	label := plan.Label.ValueString()
+	var err error
+	if !plan.URL.Equal(state.URL) {
+		_, err = apiPost[uyuni.Repository](ctx, client, "channel/software/updateRepoUrl", map[string]interface{}{
+			"label": label,
+			"url":   plan.URL.ValueString(),
+		})
+	}
+	if err == nil && (!plan.SSLCACert.Equal(state.SSLCACert) || !plan.SSLClientCert.Equal(state.SSLClientCert) || !plan.SSLClientKey.Equal(state.SSLClientKey)) {
+		_, err = apiPost[uyuni.Repository](ctx, client, "channel/software/updateRepoSsl", map[string]interface{}{
+			"label":      label,
+			"sslCaCert":  plan.SSLCACert.ValueString(),
+			"sslCliCert": plan.SSLClientCert.ValueString(),
+			"sslCliKey":  plan.SSLClientKey.ValueString(),
+		})
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating repository",
+			"Could not update repository "+label+": "+err.Error(),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete removes the repository, which disassociates it from all channels.
+func (r *repositoryResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Retrieve values from state
+	var state repositoryResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	client := resourceClient(ctx, r.client, state.ServerAlias, state.Org, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	_, err := apiPost[int](ctx, client, "channel/software/removeRepo", map[string]interface{}{
+		"label": state.Label.ValueString(),
+	})
+	if err != nil && !isNotFoundError(err) {
+		resp.Diagnostics.AddError(
+			"Error Deleting Uyuni repository",
+			"Could not delete repository "+state.Label.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+}
+
+// ImportState imports a repository by its label.
+func (r *repositoryResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("label"), req, resp)
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *repositoryResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*uyuniClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *uyuniClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
@@ -0,0 +1,83 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	acctest "github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestRepositoryUpdateChangesOnlyChangedSettings(t *testing.T) {
+	ctx := context.Background()
+	var changes []string
+	r := NewRepositoryResource()
+	testConfigure(t, r, testAPIClient(t, func(w http.ResponseWriter, req *http.Request) {
+		var body map[string]interface{}
+		_ = json.NewDecoder(req.Body).Decode(&body)
+		changes = append(changes, fmt.Sprint(req.URL.Path, " ", body))
+		_, _ = w.Write([]byte(`{"success": true, "result": {"id": 12, "label": "tools", "sourceUrl": "", "type": "yum"}}`))
+	}))
+
+	attributes := map[string]interface{}{
+		"label":            "tools",
+		"url":              "https://repo.example.com/tools/",
+		"type":             "yum",
+		"ssl_ca_cert_name": "internal-ca",
+	}
+	state := testState(t, r, attributes)
+	attributes["url"] = "https://mirror.example.com/tools/"
+	planned := testState(t, r, attributes)
+	resp := &resource.UpdateResponse{State: planned}
+	r.Update(ctx, resource.UpdateRequest{Plan: tfsdk.Plan{Schema: planned.Schema, Raw: planned.Raw}, State: state}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+	if want := "[/channel/software/updateRepoUrl map[label:tools url:https://mirror.example.com/tools/]]"; fmt.Sprint(changes) != want {
+		t.Errorf("expected %s, got %v", want, changes)
+	}
+}
+
+func TestAccRepositoryResource(t *testing.T) {
+	acctest.Test(t, acctest.TestCase{
+		PreCheck:                 func() { testAccRealServerPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckGone(t, "channel/software/getRepoDetails?repoLabel=tfacc-repo"),
+		Steps: []acctest.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccRepositoryResourceConfig("https://repo.example.com/tfacc/"),
+				Check: acctest.ComposeAggregateTestCheckFunc(
+					acctest.TestCheckResourceAttr("uyuni_repository.test", "type", "yum"),
+					acctest.TestCheckResourceAttrSet("uyuni_repository.test", "repository_id"),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: testAccRepositoryResourceConfig("https://repo.example.com/tfacc/updated/"),
+				Check:  acctest.TestCheckResourceAttr("uyuni_repository.test", "url", "https://repo.example.com/tfacc/updated/"),
+			},
+			// ImportState testing
+			{
+				ResourceName:                         "uyuni_repository.test",
+				ImportState:                          true,
+				ImportStateId:                        "tfacc-repo",
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "label",
+			},
+		},
+	})
+}
+
+func testAccRepositoryResourceConfig(url string) string {
+	return fmt.Sprintf(`
+resource "uyuni_repository" "test" {
+  label = "tfacc-repo"
+  url   = %q
+}
+`, url)
+}
@@ -0,0 +1,599 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+
+	"terraform-provider-uyuni/internal/uyuni"
+	"terraform-provider-uyuni/internal/validators"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                     = &retailBranchResource{}
+	_ resource.ResourceWithConfigure        = &retailBranchResource{}
+	_ resource.ResourceWithImportState      = &retailBranchResource{}
+	_ resource.ResourceWithUpgradeState     = &retailBranchResource{}
+	_ resource.ResourceWithConfigValidators = &retailBranchResource{}
+)
+
+// Formulas used by the retail tooling.
+const (
+	saltbootGroupFormula = "saltboot-group"
+	pxeFormula           = "pxe"
+)
+
+// NewRetailBranchResource is a helper function to simplify the provider implementation.
+func NewRetailBranchResource() resource.Resource {
+	return &retailBranchResource{}
+}
+
+// retailBranchResource is the resource implementation.
+type retailBranchResource struct {
+	client *uyuniClient
+}
+
+// retailBranchResourceModel maps the resource schema data.
+type retailBranchResourceModel struct {
+	ID                      types.String   `tfsdk:"id"`
+	BranchID                types.String   `tfsdk:"branch_id"`
+	Description             types.String   `tfsdk:"description"`
+	GroupID                 types.Int64    `tfsdk:"group_id"`
+	BranchServerID          types.Int64    `tfsdk:"branch_server_id"`
+	MinionIDNaming          types.String   `tfsdk:"minion_id_naming"`
+	DisableIDPrefix         types.Bool     `tfsdk:"disable_id_prefix"`
+	DisableUniqueSuffix     types.Bool     `tfsdk:"disable_unique_suffix"`
+	DownloadServer          types.String   `tfsdk:"download_server"`
+	DefaultBootImage        types.String   `tfsdk:"default_boot_image"`
+	DefaultBootImageVersion types.String   `tfsdk:"default_boot_image_version"`
+	SaltbootPillar          types.String   `tfsdk:"saltboot_pillar"`
+	DeletionProtection      types.Bool     `tfsdk:"deletion_protection"`
+	ServerAlias             types.String   `tfsdk:"server_alias"`
+	Org                     *orgModel      `tfsdk:"org"`
+	Timeouts                timeouts.Value `tfsdk:"timeouts"`
+}
+
+// retailBranchStateMigrations upgrade states of prior schema versions.
+var retailBranchStateMigrations = stateMigrations{
+	// Version 0 had no id attribute.
+	migrateAddID("branch_id"),
+	// Version 1 had no deletion protection.
+	migrateSetAttribute("deletion_protection", false),
+}
+
+// saltbootPillar renders the saltboot group formula data of the branch.
+func (m retailBranchResourceModel) saltbootPillar() map[string]interface{} {
+	saltboot := map[string]interface{}{}
+	if !m.DownloadServer.IsNull() {
+		saltboot["download_server"] = m.DownloadServer.ValueString()
+	}
+	if !m.DefaultBootImage.IsNull() {
+		saltboot["default_boot_image"] = m.DefaultBootImage.ValueString()
+	}
+	if !m.DefaultBootImageVersion.IsNull() {
+		saltboot["default_boot_image_version"] = m.DefaultBootImageVersion.ValueString()
+	}
+	return map[string]interface{}{"saltboot": saltboot}
+}
+
+// pxePillar renders the pxe formula data of the branch server, which controls terminal naming.
+func (m retailBranchResourceModel) pxePillar() map[string]interface{} {
+	return map[string]interface{}{
+		"pxe": map[string]interface{}{
+			"branch_id":             m.BranchID.ValueString(),
+			"minion_id_naming":      m.MinionIDNaming.ValueString(),
+			"disable_id_prefix":     m.DisableIDPrefix.ValueBool(),
+			"disable_unique_suffix": m.DisableUniqueSuffix.ValueBool(),
+		},
+	}
+}
+
+// Metadata returns the resource type name.
+func (r *retailBranchResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_retail_branch"
+}
+
+// Schema defines the schema for the resource.
+func (r *retailBranchResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Version: retailBranchStateMigrations.version(),
+		Description: "Manages a retail branch: the branch system group with its saltboot formula " +
+			"and, optionally, the terminal naming settings of the branch server.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "ID of the branch.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"branch_id": schema.StringAttribute{
+				Description: "Branch identifier, also used as the name of the branch system group.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"description": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(""),
+			},
+			"group_id": schema.Int64Attribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"branch_server_id": schema.Int64Attribute{
+				Description: "System ID of the branch server. It is added to the branch group and gets the pxe formula assigned.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"minion_id_naming": schema.StringAttribute{
+				Description: "How terminal minion IDs are built: Hostname, FQDN or HWAddress.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString("Hostname"),
+				Validators: []validator.String{
+					stringvalidator.OneOf("Hostname", "FQDN", "HWAddress"),
+				},
+			},
+			"disable_id_prefix": schema.BoolAttribute{
+				Description: "Do not prefix terminal minion IDs with the branch ID.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"disable_unique_suffix": schema.BoolAttribute{
+				Description: "Do not append a unique suffix to terminal minion IDs.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"download_server": schema.StringAttribute{
+				Description: "Server terminals download boot images from.",
+				Optional:    true,
+			},
+			"default_boot_image": schema.StringAttribute{
+				Optional: true,
+			},
+			"default_boot_image_version": schema.StringAttribute{
+				Optional: true,
+			},
+			"saltboot_pillar": schema.StringAttribute{
+				Description: "JSON encoded saltboot formula data as stored on the server.",
+				Computed:    true,
+			},
+			"deletion_protection": deletionProtectionAttribute(false),
+			"server_alias":        serverAliasAttribute(),
+		},
+		Blocks: map[string]schema.Block{
+			"org": orgBlock(),
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+// ConfigValidators returns the validators checking attributes against each other.
+func (r *retailBranchResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		// Terminal naming is configured through the pxe formula of the branch server.
+		validators.AlsoRequires("branch_server_id", "minion_id_naming", "disable_id_prefix", "disable_unique_suffix"),
+	}
+}
+
+// UpgradeState upgrades states of prior schema versions.
+func (r *retailBranchResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return retailBranchStateMigrations.upgraders()
+}
+
+// applyFormulas writes the saltboot group formula and, with a branch server, its pxe formula.
+func (r *retailBranchResource) applyFormulas(ctx context.Context, client *uyuniClient, plan *retailBranchResourceModel) error {
+	groupID := plan.GroupID.ValueInt64()
+
+	_, err := apiPost[int](ctx, client, "formula/setFormulasOfGroup", map[string]interface{}{
+		"systemGroupId": groupID,
+		"formulas":      []string{saltbootGroupFormula},
+	})
+	if err != nil {
+		return fmt.Errorf("could not assign %s formula: %w", saltbootGroupFormula, err)
+	}
+
+	saltboot := plan.saltbootPillar()
+	_, err = apiPost[int](ctx, client, "formula/setGroupFormulaData", map[string]interface{}{
+		"systemGroupId": groupID,
+		"formulaName":   saltbootGroupFormula,
+		"content":       saltboot,
+	})
+	if err != nil {
+		return fmt.Errorf("could not set %s formula data: %w", saltbootGroupFormula, err)
+	}
+
+	pillar, err := json.Marshal(saltboot)
+	if err != nil {
+		return err
+	}
+	plan.SaltbootPillar = types.StringValue(string(pillar))
+
+	if plan.BranchServerID.IsNull() {
+		return nil
+	}
+	serverID := plan.BranchServerID.ValueInt64()
+
+	// Keep formulas assigned to the branch server outside of this resource.
+	formulas, err := apiGet[[]string](ctx, client, fmt.Sprintf("formula/getFormulasByServerId?sid=%d", serverID))
+	if err != nil {
+		return fmt.Errorf("could not read formulas of branch server %d: %w", serverID, err)
+	}
+	assigned := formulas.Result
+	if !slices.Contains(assigned, pxeFormula) {
+		assigned = append(assigned, pxeFormula)
+		_, err = apiPost[int](ctx, client, "formula/setFormulasOfServer", map[string]interface{}{
+			"sid":      serverID,
+			"formulas": assigned,
+		})
+		if err != nil {
+			return fmt.Errorf("could not assign %s formula to branch server %d: %w", pxeFormula, serverID, err)
+		}
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Setting terminal naming on branch server %d", serverID))
+	_, err = apiPost[int](ctx, client, "formula/setSystemFormulaData", map[string]interface{}{
+		"systemId":    serverID,
+		"formulaName": pxeFormula,
+		"content":     plan.pxePillar(),
+	})
+	if err != nil {
+		return fmt.Errorf("could not set %s formula data of branch server %d: %w", pxeFormula, serverID, err)
+	}
+	return nil
+}
+
+// Create a new resource.
+func (r *retailBranchResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan retailBranchResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	client := resourceClient(ctx, r.client, plan.ServerAlias, plan.Org, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	tflog.Info(ctx, "About to create retail branch "+plan.BranchID.ValueString())
+
+	group, err := apiPost[uyuni.SystemGroup](ctx, client, "systemgroup/create", map[string]interface{}{
+		"name":        plan.BranchID.ValueString(),
+		"description": plan.Description.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating retail branch",
+			"Could not create branch group: "+err.Error(),
+		)
+		return
+	}
+	plan.GroupID = types.Int64Value(int64(group.Result.ID))
+	plan.ID = plan.BranchID
+
+	if !plan.BranchServerID.IsNull() {
+		_, err = apiPost[int](ctx, client, "systemgroup/addOrRemoveSystems", map[string]interface{}{
+			"systemGroupName": plan.BranchID.ValueString(),
+			"serverIds":       []int64{plan.BranchServerID.ValueInt64()},
+			"add":             true,
+		})
+		if err != nil {
+			err = fmt.Errorf("could not add branch server to branch group: %w", err)
+		}
+	}
+	if err == nil {
+		err = r.applyFormulas(ctx, client, &plan)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating retail branch",
+			"Could not configure branch "+plan.BranchID.ValueString()+": "+err.Error(),
+		)
+		// Fall through to track the branch group, which Terraform taints.
+		if plan.SaltbootPillar.IsUnknown() {
+			plan.SaltbootPillar = types.StringNull()
+		}
+	}
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read resource information.
+func (r *retailBranchResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Get current state
+	var state retailBranchResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := resourceClient(ctx, r.client, state.ServerAlias, state.Org, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	group, err := apiGet[uyuni.SystemGroup](ctx, client, "systemgroup/getDetails?systemGroupName="+url.QueryEscape(state.BranchID.ValueString()))
+	if err != nil {
+		if handleNotFound(ctx, resp, err, "Retail branch "+state.BranchID.ValueString()) {
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Reading Uyuni retail branch",
+			"Could not read branch group "+state.BranchID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+	state.GroupID = types.Int64Value(int64(group.Result.ID))
+	state.Description = types.StringValue(group.Result.Description)
+
+	formulaData, err := apiGet[map[string]interface{}](ctx, client, fmt.Sprintf("formula/getGroupFormulaData?groupId=%d&formulaName=%s", group.Result.ID, saltbootGroupFormula))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Uyuni retail branch",
+			"Could not read saltboot formula data of "+state.BranchID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+	pillar, err := json.Marshal(formulaData.Result)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Reading Uyuni retail branch", err.Error())
+		return
+	}
+	state.SaltbootPillar = types.StringValue(string(pillar))
+	if saltboot, ok := formulaData.Result["saltboot"].(map[string]interface{}); ok {
+		state.DownloadServer = optionalString(saltboot["download_server"])
+		state.DefaultBootImage = optionalString(saltboot["default_boot_image"])
+		state.DefaultBootImageVersion = optionalString(saltboot["default_boot_image_version"])
+	}
+
+	if !state.BranchServerID.IsNull() {
+		pxeData, err := apiGet[map[string]interface{}](ctx, client, fmt.Sprintf("formula/getSystemFormulaData?systemId=%d&formulaName=%s", state.BranchServerID.ValueInt64(), pxeFormula))
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Reading Uyuni retail branch",
+				fmt.Sprintf("Could not read pxe formula data of branch server %d: %s", state.BranchServerID.ValueInt64(), err.Error()),
+			)
+			return
+		}
+		if pxe, ok := pxeData.Result["pxe"].(map[string]interface{}); ok {
+			if naming, ok := pxe["minion_id_naming"].(string); ok {
+				state.MinionIDNaming = types.StringValue(naming)
+			}
+			if disable, ok := pxe["disable_id_prefix"].(bool); ok {
+				state.DisableIDPrefix = types.BoolValue(disable)
+			}
+			if disable, ok := pxe["disable_unique_suffix"].(bool); ok {
+				state.DisableUniqueSuffix = types.BoolValue(disable)
+			}
+		}
+	}
+
+	state.ID = state.BranchID
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *retailBranchResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Retrieve values from plan
+	var plan retailBranchResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	client := resourceClient(ctx, r.client, plan.ServerAlias, plan.Org, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	_, err := apiPost[uyuni.SystemGroup](ctx, client, "systemgroup/update", map[string]interface{}{
+		"systemGroupName": plan.BranchID.ValueString(),
+		"description":     plan.Description.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating retail branch",
+			"Could not update branch group: "+err.Error(),
+		)
+		return
+	}
+
+	if err := r.applyFormulas(ctx, client, &plan); err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating retail branch",
+			"Could not configure branch formulas: "+err.Error(),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete removes the branch group and the pxe formula of the branch server.
+func (r *retailBranchResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Retrieve values from state
+	var state retailBranchResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if deletionProtected(state.DeletionProtection, "Retail branch "+state.BranchID.ValueString(), &resp.Diagnostics) {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	client := resourceClient(ctx, r.client, state.ServerAlias, state.Org, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	if !state.BranchServerID.IsNull() {
+		serverID := state.BranchServerID.ValueInt64()
+		formulas, err := apiGet[[]string](ctx, client, fmt.Sprintf("formula/getFormulasByServerId?sid=%d", serverID))
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Deleting Uyuni retail branch",
+				fmt.Sprintf("Could not read formulas of branch server %d: %s", serverID, err.Error()),
+			)
+			return
+		}
+		remaining := []string{}
+		for _, formula := range formulas.Result {
+			if formula != pxeFormula {
+				remaining = append(remaining, formula)
+			}
+		}
+		_, err = apiPost[int](ctx, client, "formula/setFormulasOfServer", map[string]interface{}{
+			"sid":      serverID,
+			"formulas": remaining,
+		})
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Deleting Uyuni retail branch",
+				fmt.Sprintf("Could not remove %s formula from branch server %d: %s", pxeFormula, serverID, err.Error()),
+			)
+			return
+		}
+	}
+
+	_, err := apiPost[int](ctx, client, "systemgroup/delete", map[string]interface{}{
+		"systemGroupName": state.BranchID.ValueString(),
+	})
+	if err != nil && !isNotFoundError(err) {
+		resp.Diagnostics.AddError(
+			"Error Deleting Uyuni retail branch",
+			"Could not delete branch group: "+err.Error(),
+		)
+		return
+	}
+}
+
+// ImportState imports a branch by "branch_id", or by "branch_id:branch_server_id"
+// to also manage the terminal naming of the branch server.
+func (r *retailBranchResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("deletion_protection"), false)...)
+	if !strings.Contains(req.ID, importIDSeparator) {
+		resource.ImportStatePassthroughID(ctx, path.Root("branch_id"), req, resp)
+		return
+	}
+
+	parts, err := parseImportID(req.ID, "branch_id", "branch_server_id")
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Import ID", err.Error())
+		return
+	}
+	serverID, err := parseImportInt64("branch_server_id", parts[1])
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Import ID", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("branch_id"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("branch_server_id"), serverID)...)
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *retailBranchResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*uyuniClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *uyuniClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// optionalString converts a decoded JSON value into a string attribute, null when absent.
+func optionalString(value interface{}) types.String {
+	if s, ok := value.(string); ok {
+		return types.StringValue(s)
+	}
+	return types.StringNull()
+}
@@ -0,0 +1,122 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	acctest "github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccRetailBranchResource(t *testing.T) {
+	serverID := os.Getenv("UYUNI_TEST_BRANCH_SERVER_ID")
+	acctest.Test(t, acctest.TestCase{
+		PreCheck: func() {
+			testAccRealServerPreCheck(t)
+			testAccSkipUnlessEnv(t, "UYUNI_TEST_BRANCH_SERVER_ID", "a registered branch server")
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckGone(t, "systemgroup/getDetails?systemGroupName=TFACC"),
+		Steps: []acctest.TestStep{
+			{
+				Config: testAccRetailBranchResourceConfig(serverID, "POS_Image_JeOS7"),
+				Check: acctest.ComposeAggregateTestCheckFunc(
+					acctest.TestCheckResourceAttr("uyuni_retail_branch.test", "id", "TFACC"),
+					acctest.TestCheckResourceAttrSet("uyuni_retail_branch.test", "group_id"),
+					acctest.TestCheckResourceAttr("uyuni_retail_branch.test", "default_boot_image", "POS_Image_JeOS7"),
+				),
+			},
+			{
+				Config: testAccRetailBranchResourceConfig(serverID, "POS_Image_JeOS8"),
+				Check:  acctest.TestCheckResourceAttr("uyuni_retail_branch.test", "default_boot_image", "POS_Image_JeOS8"),
+			},
+			{
+				ResourceName:      "uyuni_retail_branch.test",
+				ImportState:       true,
+				ImportStateIdFunc: func(*terraform.State) (string, error) { return "TFACC:" + serverID, nil },
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccRetailBranchResourceConfig(serverID, bootImage string) string {
+	return fmt.Sprintf(`
+resource "uyuni_retail_branch" "test" {
+  branch_id          = "TFACC"
+  branch_server_id   = %s
+  default_boot_image = %q
+}
+`, serverID, bootImage)
+}
+
+func TestRetailBranchPillars(t *testing.T) {
+	branch := retailBranchResourceModel{
+		BranchID:                types.StringValue("B042"),
+		MinionIDNaming:          types.StringValue("HWAddress"),
+		DisableIDPrefix:         types.BoolValue(true),
+		DisableUniqueSuffix:     types.BoolValue(false),
+		DownloadServer:          types.StringValue("branchserver.b042.example.com"),
+		DefaultBootImage:        types.StringNull(),
+		DefaultBootImageVersion: types.StringNull(),
+	}
+
+	wantSaltboot := map[string]interface{}{
+		"saltboot": map[string]interface{}{
+			"download_server": "branchserver.b042.example.com",
+		},
+	}
+	if got := branch.saltbootPillar(); !reflect.DeepEqual(got, wantSaltboot) {
+		t.Errorf("saltboot pillar: got %v, want %v", got, wantSaltboot)
+	}
+
+	wantPxe := map[string]interface{}{
+		"pxe": map[string]interface{}{
+			"branch_id":             "B042",
+			"minion_id_naming":      "HWAddress",
+			"disable_id_prefix":     true,
+			"disable_unique_suffix": false,
+		},
+	}
+	if got := branch.pxePillar(); !reflect.DeepEqual(got, wantPxe) {
+		t.Errorf("pxe pillar: got %v, want %v", got, wantPxe)
+	}
+}
+
+func TestRetailBranchCreateTracksGroupOnFailure(t *testing.T) {
+	ctx := context.Background()
+	client := testAPIClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/systemgroup/create" {
+			_, _ = w.Write([]byte(`{"success": true, "result": {"id": 42, "name": "B042"}}`))
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(`{"success": false, "message": "Formula saltboot-group not found"}`))
+	})
+	r := NewRetailBranchResource()
+	testConfigure(t, r, client)
+
+	planned := testState(t, r, map[string]interface{}{
+		"branch_id":       "B042",
+		"description":     "",
+		"download_server": "branchserver.b042.example.com",
+	})
+	resp := &resource.CreateResponse{State: planned}
+	r.Create(ctx, resource.CreateRequest{Plan: tfsdk.Plan{Schema: planned.Schema, Raw: planned.Raw}}, resp)
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected an error")
+	}
+
+	var state retailBranchResourceModel
+	resp.State.Get(ctx, &state)
+	if state.ID.ValueString() != "B042" || state.GroupID.ValueInt64() != 42 {
+		t.Errorf("expected the branch group to be tracked, got %v", state)
+	}
+}
@@ -0,0 +1,282 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"terraform-provider-uyuni/internal/uyuni"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &sccCredentialsResource{}
+	_ resource.ResourceWithConfigure   = &sccCredentialsResource{}
+	_ resource.ResourceWithImportState = &sccCredentialsResource{}
+)
+
+// NewSCCCredentialsResource is a helper function to simplify the provider implementation.
+func NewSCCCredentialsResource() resource.Resource {
+	return &sccCredentialsResource{}
+}
+
+// sccCredentialsResource is the resource implementation.
+type sccCredentialsResource struct {
+	client *uyuniClient
+}
+
+// sccCredentialsResourceModel maps the resource schema data.
+type sccCredentialsResourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	Username    types.String `tfsdk:"username"`
+	Password    types.String `tfsdk:"password"`
+	Primary     types.Bool   `tfsdk:"primary"`
+	ServerAlias types.String `tfsdk:"server_alias"`
+}
+
+// Metadata returns the resource type name.
+func (r *sccCredentialsResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_scc_credentials"
+}
+
+// Schema defines the schema for the resource.
+func (r *sccCredentialsResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages organization credentials of the SUSE Customer Center or of a mirror, which the server " +
+			"synchronizes products and channels with. Changing the password or primary stores the credentials again. " +
+			"Requires the satellite_admin role.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Username of the credentials.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"username": schema.StringAttribute{
+				Description: "Username of the organization credentials, e.g. `SCC_0123456789abcdef`.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"password": schema.StringAttribute{
+				Description: "Password of the organization credentials. It cannot be read back, so changes made outside " +
+					"Terraform are not detected.",
+				Required:  true,
+				Sensitive: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"primary": schema.BoolAttribute{
+				Description: "Make these the primary credentials, which the server uses for the product tree. " +
+					"Only one set of credentials is primary. Defaults to false.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"server_alias": serverAliasAttribute(),
+		},
+	}
+}
+
+// addSCCCredentials stores the credentials of the model.
+func addSCCCredentials(ctx context.Context, client *uyuniClient, m *sccCredentialsResourceModel) error {
+	_, err := apiPost[int](ctx, client, "sync/content/addCredentials", map[string]interface{}{
+		"username": m.Username.ValueString(),
+		"password": m.Password.ValueString(),
+		"primary":  m.Primary.ValueBool(),
+	})
+	return err
+}
+
+// deleteSCCCredentials deletes the credentials with the username.
+func deleteSCCCredentials(ctx context.Context, client *uyuniClient, username string) error {
+	_, err := apiPost[int](ctx, client, "sync/content/deleteCredentials", map[string]interface{}{
+		"username": username,
+	})
+	return err
+}
+
+// Create a new resource.
+func (r *sccCredentialsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan sccCredentialsResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := resourceClient(ctx, r.client, plan.ServerAlias, nil, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	username := plan.Username.ValueString()
+	tflog.Info(ctx, "About to add SCC credentials "+username)
+
+	if err := addSCCCredentials(ctx, client, &plan); err != nil {
+		resp.Diagnostics.AddError(
+			"Error adding SCC credentials",
+			fmt.Sprintf("Could not add SCC credentials %s: %s", username, err),
+		)
+		return
+	}
+	plan.ID = plan.Username
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read refreshes whether the credentials are primary. The password is kept.
+func (r *sccCredentialsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Get current state
+	var state sccCredentialsResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := resourceClient(ctx, r.client, state.ServerAlias, nil, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	username := state.Username.ValueString()
+	credentials, err := apiGet[[]uyuni.SCCCredentials](ctx, client, "sync/content/listCredentials")
+	if err != nil {
+		if handleNotFound(ctx, resp, err, "SCC credentials "+username) {
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Reading Uyuni SCC credentials",
+			"Could not list SCC credentials: "+err.Error(),
+		)
+		return
+	}
+	var found *uyuni.SCCCredentials
+	for i, c := range credentials.Result {
+		if c.User == username {
+			found = &credentials.Result[i]
+		}
+	}
+	if found == nil {
+		tflog.Warn(ctx, fmt.Sprintf("SCC credentials %s no longer exist, removing them from state", username))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	state.ID = state.Username
+	state.Primary = types.BoolValue(found.IsPrimary)
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update stores the credentials again, the API cannot change them in place.
+func (r *sccCredentialsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan sccCredentialsResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := resourceClient(ctx, r.client, plan.ServerAlias, nil, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	username := plan.Username.ValueString()
+	if err := deleteSCCCredentials(ctx, client, username); err != nil && !isNotFoundError(err) {
+		resp.Diagnostics.AddError(
+			"Error updating SCC credentials",
+			fmt.Sprintf("Could not replace SCC credentials %s: %s", username, err),
+		)
+		return
+	}
+	if err := addSCCCredentials(ctx, client, &plan); err != nil {
+		// The old credentials are gone, let the next apply create them.
+		resp.State.RemoveResource(ctx)
+		resp.Diagnostics.AddError(
+			"Error updating SCC credentials",
+			fmt.Sprintf("Could not add SCC credentials %s again: %s", username, err),
+		)
+		return
+	}
+	plan.ID = plan.Username
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete removes the credentials. Products and channels synchronized with
+// them are kept.
+func (r *sccCredentialsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Retrieve values from state
+	var state sccCredentialsResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := resourceClient(ctx, r.client, state.ServerAlias, nil, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	username := state.Username.ValueString()
+	if err := deleteSCCCredentials(ctx, client, username); err != nil && !isNotFoundError(err) {
+		resp.Diagnostics.AddError(
+			"Error Deleting Uyuni SCC credentials",
+			fmt.Sprintf("Could not delete SCC credentials %s: %s", username, err),
+		)
+		return
+	}
+}
+
+// ImportState imports credentials by username. The password cannot be read,
+// the first apply after the import stores the configured one again.
+func (r *sccCredentialsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("username"), req, resp)
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *sccCredentialsResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*uyuniClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *uyuniClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
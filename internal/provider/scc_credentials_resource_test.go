@@ -0,0 +1,92 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	acctest "github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestSCCCredentialsReadRemovesMissingCredentials(t *testing.T) {
+	client := testAPIClient(t, func(w http.ResponseWriter, req *http.Request) {
+		_, _ = w.Write([]byte(`{"success": true, "result": [{"user": "mirror", "isPrimary": true}]}`))
+	})
+
+	resp := testRead(t, NewSCCCredentialsResource(), client, map[string]interface{}{
+		"username": "SCC_0123456789abcdef",
+		"password": "secret",
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+	if !resp.State.Raw.IsNull() {
+		t.Error("expected the credentials to be removed from state")
+	}
+}
+
+func TestSCCCredentialsUpdateStoresCredentialsAgain(t *testing.T) {
+	ctx := context.Background()
+	var requests []string
+	r := NewSCCCredentialsResource()
+	testConfigure(t, r, testAPIClient(t, func(w http.ResponseWriter, req *http.Request) {
+		var body map[string]interface{}
+		_ = json.NewDecoder(req.Body).Decode(&body)
+		requests = append(requests, fmt.Sprintf("%s %v %v", req.URL.Path, body["password"], body["primary"]))
+		_, _ = w.Write([]byte(`{"success": true, "result": 1}`))
+	}))
+
+	planned := testState(t, r, map[string]interface{}{
+		"username": "SCC_0123456789abcdef",
+		"password": "rotated",
+		"primary":  true,
+	})
+	resp := &resource.UpdateResponse{State: planned}
+	r.Update(ctx, resource.UpdateRequest{Plan: tfsdk.Plan{Schema: planned.Schema, Raw: planned.Raw}}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+	expected := "[/sync/content/deleteCredentials <nil> <nil> /sync/content/addCredentials rotated true]"
+	if fmt.Sprint(requests) != expected {
+		t.Errorf("expected the credentials to be deleted and added, got %v", requests)
+	}
+}
+
+func TestAccSCCCredentialsResource(t *testing.T) {
+	username := os.Getenv("UYUNI_TEST_SCC_USERNAME")
+	acctest.Test(t, acctest.TestCase{
+		PreCheck: func() {
+			testAccRealServerPreCheck(t)
+			testAccSkipUnlessEnv(t, "UYUNI_TEST_SCC_USERNAME", "organization credentials of the SUSE Customer Center")
+			testAccSkipUnlessEnv(t, "UYUNI_TEST_SCC_PASSWORD", "the password of UYUNI_TEST_SCC_USERNAME")
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []acctest.TestStep{
+			// Create and Read testing
+			{
+				Config: fmt.Sprintf(`
+resource "uyuni_scc_credentials" "test" {
+  username = %q
+  password = %q
+}
+`, username, os.Getenv("UYUNI_TEST_SCC_PASSWORD")),
+				Check: acctest.TestCheckResourceAttr("uyuni_scc_credentials.test", "username", username),
+			},
+			// ImportState testing
+			{
+				ResourceName:                         "uyuni_scc_credentials.test",
+				ImportState:                          true,
+				ImportStateId:                        username,
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "username",
+				// The password cannot be read back from the server.
+				ImportStateVerifyIgnore: []string{"password"},
+			},
+		},
+	})
+}
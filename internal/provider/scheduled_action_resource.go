@@ -0,0 +1,717 @@
+package provider
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"terraform-provider-uyuni/internal/uyuni"
+	"terraform-provider-uyuni/internal/validators"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &scheduledActionResource{}
+	_ resource.ResourceWithConfigure      = &scheduledActionResource{}
+	_ resource.ResourceWithModifyPlan     = &scheduledActionResource{}
+	_ resource.ResourceWithValidateConfig = &scheduledActionResource{}
+)
+
+// Types of the actions scheduled by uyuni_scheduled_action.
+const (
+	scheduledActionScript        = "script"
+	scheduledActionHighstate     = "highstate"
+	scheduledActionPackageUpdate = "package_update"
+	scheduledActionErrata        = "errata"
+)
+
+// NewScheduledActionResource is a helper function to simplify the provider implementation.
+func NewScheduledActionResource() resource.Resource {
+	return &scheduledActionResource{}
+}
+
+// scheduledActionResource is the resource implementation.
+type scheduledActionResource struct {
+	client *uyuniClient
+}
+
+// scheduledActionResourceModel maps the resource schema data.
+type scheduledActionResourceModel struct {
+	ID                        types.String        `tfsdk:"id"`
+	Type                      types.String        `tfsdk:"type"`
+	Script                    types.String        `tfsdk:"script"`
+	Interpreter               types.String        `tfsdk:"interpreter"`
+	Username                  types.String        `tfsdk:"username"`
+	Groupname                 types.String        `tfsdk:"groupname"`
+	Timeout                   types.Int64         `tfsdk:"timeout"`
+	Advisories                types.Set           `tfsdk:"advisories"`
+	Target                    *targetModel        `tfsdk:"target"`
+	Wait                      types.Bool          `tfsdk:"wait"`
+	Triggers                  types.Map           `tfsdk:"triggers"`
+	ActionID                  types.Int64         `tfsdk:"action_id"`
+	ActionIDs                 types.Set           `tfsdk:"action_ids"`
+	Status                    types.String        `tfsdk:"status"`
+	CancelOnDestroy           types.Bool          `tfsdk:"cancel_on_destroy"`
+	RespectMaintenanceWindows types.Bool          `tfsdk:"respect_maintenance_windows"`
+	NotBefore                 types.String        `tfsdk:"not_before"`
+	EarliestOccurrence        types.String        `tfsdk:"earliest_occurrence"`
+	Results                   []scriptResultModel `tfsdk:"results"`
+	Notify                    *notifyModel        `tfsdk:"notify"`
+	ServerAlias               types.String        `tfsdk:"server_alias"`
+	Timeouts                  timeouts.Value      `tfsdk:"timeouts"`
+}
+
+// scriptResultModel maps the result of the script on a system.
+type scriptResultModel struct {
+	SystemID   types.Int64  `tfsdk:"system_id"`
+	ReturnCode types.Int64  `tfsdk:"return_code"`
+	Output     types.String `tfsdk:"output"`
+}
+
+// Metadata returns the resource type name.
+func (r *scheduledActionResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_scheduled_action"
+}
+
+// Schema defines the schema for the resource.
+func (r *scheduledActionResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Schedules an action on systems when created: a script for last-mile tweaks that do not warrant a Salt state, " +
+			"a highstate, an update of all packages or the relevant errata. Change triggers to run it again. " +
+			"Destroying the resource does not undo what the action did. " +
+			"Scripts outside the script_policy of the provider are refused when planning.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "ID of the action, `0` if no errata were relevant.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"type": schema.StringAttribute{
+				Description: "Type of the action: `script`, `highstate`, `package_update` or `errata`. Defaults to `script`.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(scheduledActionScript),
+				Validators: []validator.String{
+					stringvalidator.OneOf(scheduledActionScript, scheduledActionHighstate, scheduledActionPackageUpdate, scheduledActionErrata),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"script": schema.StringAttribute{
+				Description: "Body of the script, without the interpreter line. Required for scripts.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"interpreter": schema.StringAttribute{
+				Description: "Absolute path of the interpreter running the script. Defaults to `/bin/sh`.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString("/bin/sh"),
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^/\S+$`), "must be an absolute path"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"username": schema.StringAttribute{
+				Description: "User running the script. Defaults to `root`.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString("root"),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"groupname": schema.StringAttribute{
+				Description: "Group running the script. Defaults to `root`.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString("root"),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"timeout": schema.Int64Attribute{
+				Description: "Number of seconds the script may run. Defaults to 600.",
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(600),
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"advisories": schema.SetAttribute{
+				Description: "Advisory names of the errata to apply, e.g. `SUSE-SU-2024:1234-1`, of those relevant to each system. " +
+					"Only for errata, all relevant errata are applied if not set.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.RequiresReplace(),
+				},
+			},
+			"wait": schema.BoolAttribute{
+				Description: "Wait until the action finished on all systems and capture the results of scripts. Defaults to true.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"triggers": schema.MapAttribute{
+				Description: "Arbitrary values which run the script again when they change.",
+				ElementType: types.StringType,
+				Optional:    true,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"action_id": schema.Int64Attribute{
+				Description: "ID of the action, the first one for errata. Null if no errata were relevant.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"action_ids": schema.SetAttribute{
+				Description: "IDs of all actions scheduled, Uyuni may schedule an action by erratum.",
+				ElementType: types.Int64Type,
+				Computed:    true,
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"status": schema.StringAttribute{
+				Description: "Status of the action over all systems: `failed` if it failed on any, " +
+					"`pending` while it is queued or running on any, and `completed` otherwise. Pending statuses are refreshed.",
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"cancel_on_destroy": cancelOnDestroyAttribute(),
+			"respect_maintenance_windows": schema.BoolAttribute{
+				Description: "Schedule the action for the next maintenance window of the systems from not_before on, " +
+					"unless a window is open. All targeted systems having a maintenance schedule must share it. " +
+					"With wait, the create timeout must last until the window. Defaults to false.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"not_before": schema.StringAttribute{
+				Description: "Earliest date the action may run at, in RFC 3339 format, e.g. `2030-01-01T02:00:00Z`. " +
+					"Defaults to the time of apply.",
+				Optional: true,
+				Validators: []validator.String{
+					validators.Timestamp(),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"earliest_occurrence": schema.StringAttribute{
+				Description: "Date the action was scheduled for, in RFC 3339 format.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"results": schema.ListNestedAttribute{
+				Description: "Results of the script by system, ordered by system ID. Null without wait and for other actions.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"system_id": schema.Int64Attribute{
+							Description: "ID of the system.",
+							Computed:    true,
+						},
+						"return_code": schema.Int64Attribute{
+							Description: "Exit code of the script.",
+							Computed:    true,
+						},
+						"output": schema.StringAttribute{
+							Description: "Output of the script.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"server_alias": serverAliasAttribute(),
+		},
+		Blocks: map[string]schema.Block{
+			"target": requiredTargetBlock("Systems running the action."),
+			"notify": notifyBlock(),
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+			}),
+		},
+	}
+}
+
+// scriptResults returns the results of the script action by system.
+func scriptResults(ctx context.Context, client *uyuniClient, actionID int64) ([]scriptResultModel, error) {
+	results, err := apiGet[[]uyuni.ScriptResult](ctx, client, fmt.Sprintf("system/getScriptResults?actionId=%d", actionID))
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(results.Result, func(i, j int) bool {
+		return results.Result[i].ServerID < results.Result[j].ServerID
+	})
+	models := make([]scriptResultModel, 0, len(results.Result))
+	for _, result := range results.Result {
+		output := result.Output
+		if result.OutputEnc64 {
+			decoded, err := base64.StdEncoding.DecodeString(result.Output)
+			if err != nil {
+				return nil, fmt.Errorf("could not decode the output of system %d: %w", result.ServerID, err)
+			}
+			output = string(decoded)
+		}
+		models = append(models, scriptResultModel{
+			SystemID:   types.Int64Value(int64(result.ServerID)),
+			ReturnCode: types.Int64Value(int64(result.ReturnCode)),
+			Output:     types.StringValue(output),
+		})
+	}
+	return models, nil
+}
+
+// isScript reports whether the action runs a script. Actions created before
+// the type attribute existed are scripts.
+func (m *scheduledActionResourceModel) isScript() bool {
+	return m.Type.IsNull() || m.Type.ValueString() == scheduledActionScript
+}
+
+// noun names the action in messages.
+func (m *scheduledActionResourceModel) noun() string {
+	switch m.Type.ValueString() {
+	case scheduledActionHighstate:
+		return "highstate"
+	case scheduledActionPackageUpdate:
+		return "package update"
+	case scheduledActionErrata:
+		return "errata"
+	default:
+		return "script"
+	}
+}
+
+// schedule schedules the action on the systems and returns the IDs of the
+// actions scheduled in ascending order, none if no errata are relevant.
+func (m *scheduledActionResourceModel) schedule(ctx context.Context, client *uyuniClient, sids []int64, earliest time.Time) ([]int64, error) {
+	switch m.Type.ValueString() {
+	case scheduledActionHighstate:
+		actionID, err := apiPost[int64](ctx, client, "system/scheduleApplyHighstate", map[string]interface{}{
+			"sids":               sids,
+			"earliestOccurrence": apiDate(earliest),
+			"test":               false,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return []int64{actionID.Result}, nil
+	case scheduledActionPackageUpdate:
+		actionID, err := apiPost[int64](ctx, client, "system/schedulePackageUpdate", map[string]interface{}{
+			"sids":               sids,
+			"earliestOccurrence": apiDate(earliest),
+		})
+		if err != nil {
+			return nil, err
+		}
+		return []int64{actionID.Result}, nil
+	case scheduledActionErrata:
+		var advisories []string
+		if !m.Advisories.IsNull() {
+			var err error
+			if advisories, err = stringSet(ctx, m.Advisories); err != nil {
+				return nil, err
+			}
+		}
+		relevant := map[int64]bool{}
+		for _, sid := range sids {
+			ids, err := relevantErrata(ctx, client, sid, advisories)
+			if err != nil {
+				return nil, err
+			}
+			for _, id := range ids {
+				relevant[id] = true
+			}
+		}
+		if len(relevant) == 0 {
+			return nil, nil
+		}
+		errataIDs := make([]int64, 0, len(relevant))
+		for id := range relevant {
+			errataIDs = append(errataIDs, id)
+		}
+		sort.Slice(errataIDs, func(i, j int) bool { return errataIDs[i] < errataIDs[j] })
+		actionIDs, err := apiPost[[]int64](ctx, client, "system/scheduleApplyErrata", map[string]interface{}{
+			"sids":               sids,
+			"errataIds":          errataIDs,
+			"earliestOccurrence": apiDate(earliest),
+		})
+		if err != nil {
+			return nil, err
+		}
+		sort.Slice(actionIDs.Result, func(i, j int) bool { return actionIDs.Result[i] < actionIDs.Result[j] })
+		return actionIDs.Result, nil
+	default:
+		actionID, err := scheduleScriptRun(ctx, client, sids, scriptRun{
+			username:  m.Username.ValueString(),
+			groupname: m.Groupname.ValueString(),
+			timeout:   m.Timeout.ValueInt64(),
+			script:    "#!" + m.Interpreter.ValueString() + "\n" + m.Script.ValueString(),
+			earliest:  earliest,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return []int64{actionID}, nil
+	}
+}
+
+// ValidateConfig requires a script for scripts and refuses the attributes of
+// scripts and errata for other actions.
+func (r *scheduledActionResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config scheduledActionResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() || config.Type.IsUnknown() {
+		return
+	}
+
+	if config.isScript() && config.Script.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("script"),
+			"Missing Attribute Configuration",
+			"Attribute script must be set for scripts.",
+		)
+	}
+	for _, attribute := range []struct {
+		name    string
+		value   attr.Value
+		allowed bool
+	}{
+		{"script", config.Script, config.isScript()},
+		{"interpreter", config.Interpreter, config.isScript()},
+		{"username", config.Username, config.isScript()},
+		{"groupname", config.Groupname, config.isScript()},
+		{"timeout", config.Timeout, config.isScript()},
+		{"advisories", config.Advisories, config.Type.ValueString() == scheduledActionErrata},
+	} {
+		if attribute.allowed || attribute.value.IsNull() {
+			continue
+		}
+		resp.Diagnostics.AddAttributeError(
+			path.Root(attribute.name),
+			"Conflicting Attribute Configuration",
+			fmt.Sprintf("Attribute %s cannot be set for the action type %q.", attribute.name, config.Type.ValueString()),
+		)
+	}
+}
+
+// ModifyPlan refuses scripts outside the script_policy of the provider.
+func (r *scheduledActionResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to plan on destroy or before the provider is configured.
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	var plan scheduledActionResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if plan.isScript() {
+		r.client.scriptPolicy.check(&plan, &resp.Diagnostics)
+	}
+}
+
+// Create schedules the action and waits for it.
+func (r *scheduledActionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan scheduledActionResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Values unknown when planning are only known now.
+	if plan.isScript() {
+		r.client.scriptPolicy.check(&plan, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	client := resourceClient(ctx, r.client, plan.ServerAlias, nil, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	noun := plan.noun()
+	sids, err := plan.Target.resolve(ctx, client)
+	if err != nil {
+		resp.Diagnostics.AddError("Error scheduling "+noun, fmt.Sprintf("Could not schedule the %s: %s", noun, err))
+		return
+	}
+
+	earliest := time.Now()
+	if !plan.NotBefore.IsNull() {
+		if earliest, err = time.Parse(time.RFC3339, plan.NotBefore.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("not_before"), "Error scheduling "+noun, err.Error())
+			return
+		}
+	}
+	if plan.RespectMaintenanceWindows.ValueBool() {
+		earliest, err = maintenanceWindowStart(ctx, client, sids, earliest)
+		if err != nil {
+			resp.Diagnostics.AddError("Error scheduling "+noun, "Could not find the next maintenance window: "+err.Error())
+			return
+		}
+		tflog.Info(ctx, "Scheduling "+noun+" for the maintenance window", map[string]interface{}{"earliest": earliest.Format(time.RFC3339)})
+	}
+
+	actionIDs, err := plan.schedule(ctx, client, sids, earliest)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error scheduling "+noun,
+			fmt.Sprintf("Could not schedule the %s on %d systems: %s", noun, len(sids), err),
+		)
+		return
+	}
+	plan.ActionIDs, diags = types.SetValueFrom(ctx, types.Int64Type, actionIDs)
+	resp.Diagnostics.Append(diags...)
+	plan.EarliestOccurrence = types.StringValue(apiDate(earliest))
+	plan.Results = nil
+	if len(actionIDs) == 0 {
+		tflog.Info(ctx, fmt.Sprintf("None of the %d systems has relevant errata", len(sids)))
+		plan.ID = types.StringValue("0")
+		plan.ActionID = types.Int64Null()
+		plan.Status = types.StringValue(actionStatusCompleted)
+		diags = resp.State.Set(ctx, plan)
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+	actionID := actionIDs[0]
+	plan.ID = types.StringValue(strconv.FormatInt(actionID, 10))
+	plan.ActionID = types.Int64Value(actionID)
+	plan.Status = types.StringValue(actionStatusPending)
+
+	switch {
+	case !plan.Wait.ValueBool():
+	case plan.isScript():
+		// Capture the results of failed runs as well, they tell why.
+		waitErr := waitForSystems(ctx, client, actionID, sids)
+		results, err := scriptResults(ctx, client, actionID)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error running script",
+				fmt.Sprintf("Could not read the results of action %d: %s", actionID, err),
+			)
+		}
+		plan.Results = results
+		plan.Status = types.StringValue(waitedActionStatus(waitErr))
+		if waitErr != nil {
+			resp.Diagnostics.AddError(
+				"Error running script",
+				fmt.Sprintf("Could not run the script: %s", waitErr),
+			)
+		} else {
+			tflog.Info(ctx, fmt.Sprintf("Ran script action %d on %d systems", actionID, len(sids)))
+		}
+	default:
+		status, err := waitForActions(ctx, client, actionIDs)
+		plan.Status = types.StringValue(status)
+		switch {
+		case err != nil:
+			resp.Diagnostics.AddError("Error running "+noun, fmt.Sprintf("Could not wait for the %s: %s", noun, err))
+		case status == actionStatusFailed:
+			resp.Diagnostics.AddError("Error running "+noun, fmt.Sprintf("Actions %v failed on at least one system.", actionIDs))
+		default:
+			tflog.Info(ctx, fmt.Sprintf("Ran %s actions %v on %d systems", noun, actionIDs, len(sids)))
+		}
+	}
+	plan.Notify.send(ctx, notification{
+		Resource:  "uyuni_scheduled_action",
+		ID:        plan.ID.ValueString(),
+		Status:    plan.Status.ValueString(),
+		ActionIDs: actionIDs,
+		SystemIDs: sids,
+	}, &resp.Diagnostics)
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read keeps the state, the action is history once it ran. Only a pending
+// status is refreshed.
+func (r *scheduledActionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state scheduledActionResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := resourceClient(ctx, r.client, state.ServerAlias, nil, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	// Actions scheduled before the type and action_ids attributes existed
+	// are single scripts, fill them in instead of replacing the action.
+	if state.Type.IsNull() {
+		state.Type = types.StringValue(scheduledActionScript)
+	}
+	if state.ActionIDs.IsNull() {
+		actionIDs := []int64{}
+		if !state.ActionID.IsNull() {
+			actionIDs = append(actionIDs, state.ActionID.ValueInt64())
+		}
+		state.ActionIDs, diags = types.SetValueFrom(ctx, types.Int64Type, actionIDs)
+		resp.Diagnostics.Append(diags...)
+	}
+
+	if state.Status.ValueString() == actionStatusPending {
+		actionIDs, err := int64Set(ctx, state.ActionIDs)
+		if err == nil && len(actionIDs) == 1 {
+			state.Status, err = refreshActionStatus(ctx, client, types.Int64Value(actionIDs[0]), state.Status)
+		} else if err == nil {
+			var status string
+			if status, err = actionsStatus(ctx, client, actionIDs); err == nil {
+				state.Status = types.StringValue(status)
+			}
+		}
+		if err != nil {
+			resp.Diagnostics.AddError("Error Reading Uyuni scheduled action", err.Error())
+			return
+		}
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update only changes cancel_on_destroy and timeouts, all other changes
+// schedule the action again.
+func (r *scheduledActionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state scheduledActionResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	// Actions scheduled before the attribute existed have no date.
+	plan.EarliestOccurrence = state.EarliestOccurrence
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete cancels the actions still pending, unless cancel_on_destroy is
+// false. What the action did on the systems is not undone.
+func (r *scheduledActionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state scheduledActionResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := resourceClient(ctx, r.client, state.ServerAlias, nil, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	if state.CancelOnDestroy.ValueBool() && state.Status.ValueString() == actionStatusPending {
+		actionIDs, err := int64Set(ctx, state.ActionIDs)
+		if err == nil && len(actionIDs) == 0 && !state.ActionID.IsNull() {
+			actionIDs = []int64{state.ActionID.ValueInt64()}
+		}
+		if err == nil {
+			err = cancelPendingActions(ctx, client, actionIDs)
+		}
+		if err != nil {
+			resp.Diagnostics.AddError("Error Deleting Uyuni scheduled action", err.Error())
+			return
+		}
+	}
+	tflog.Info(ctx, "Removing scheduled action from state, the systems are not changed")
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *scheduledActionResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*uyuniClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *uyuniClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
@@ -0,0 +1,356 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	acctest "github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestScheduledActionRunsScriptOnTarget(t *testing.T) {
+	ctx := context.Background()
+	actionPollInterval = time.Millisecond
+	t.Cleanup(func() { actionPollInterval = 10 * time.Second })
+
+	var scheduled map[string]interface{}
+	r := NewScheduledActionResource()
+	testConfigure(t, r, testAPIClient(t, func(w http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/systemgroup/listSystemsMinimal":
+			_, _ = w.Write([]byte(`{"success": true, "result": [{"id": 1000010002}, {"id": 1000010001}]}`))
+		case "/system/search/hostname":
+			if req.URL.Query().Get("searchTerm") != "db" {
+				t.Errorf("unexpected request %s", req.URL)
+			}
+			_, _ = w.Write([]byte(`{"success": true, "result": [{"id": 1000010001}, {"id": 1000010003}]}`))
+		case "/system/scheduleScriptRun":
+			_ = json.NewDecoder(req.Body).Decode(&scheduled)
+			_, _ = w.Write([]byte(`{"success": true, "result": 701}`))
+		case "/schedule/listCompletedSystems":
+			_, _ = w.Write([]byte(`{"success": true, "result": [{"server_id": 1000010001}, {"server_id": 1000010002}, {"server_id": 1000010003}]}`))
+		case "/system/getScriptResults":
+			if req.URL.Query().Get("actionId") != "701" {
+				t.Errorf("unexpected request %s", req.URL)
+			}
+			_, _ = w.Write([]byte(`{"success": true, "result": [
+				{"serverId": 1000010002, "returnCode": 0, "output": "c3NoZDogYWN0aXZlCg==", "output_enc64": true},
+				{"serverId": 1000010001, "returnCode": 0, "output": "sshd: active\n"}
+			]}`))
+		default:
+			_, _ = w.Write([]byte(`{"success": true, "result": []}`))
+		}
+	}))
+
+	groups, _ := types.SetValueFrom(ctx, types.StringType, []string{"web"})
+	planned := testState(t, r, map[string]interface{}{
+		"script":      "systemctl is-active sshd",
+		"interpreter": "/bin/bash",
+		"username":    "root",
+		"groupname":   "root",
+		"timeout":     int64(60),
+		"target": &targetModel{
+			GroupNames: groups,
+			Search:     types.StringValue("db"),
+			SystemIDs:  types.SetNull(types.Int64Type),
+			SearchBy:   types.StringNull(),
+		},
+		"wait": true,
+	})
+	resp := &resource.CreateResponse{State: planned}
+	r.Create(ctx, resource.CreateRequest{Plan: tfsdk.Plan{Schema: planned.Schema, Raw: planned.Raw}}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+	if script, _ := scheduled["script"].(string); !strings.HasPrefix(script, "#!/bin/bash\n") {
+		t.Errorf("expected the interpreter line, got %q", script)
+	}
+	if fmt.Sprint(scheduled["sids"]) != "[1.000010001e+09 1.000010002e+09 1.000010003e+09]" {
+		t.Errorf("expected the members of the group and the systems found, got %v", scheduled["sids"])
+	}
+
+	var state scheduledActionResourceModel
+	resp.State.Get(ctx, &state)
+	if state.ActionID.ValueInt64() != 701 || state.Status.ValueString() != actionStatusCompleted || len(state.Results) != 2 {
+		t.Fatalf("unexpected action %s with results %v", state.ActionID, state.Results)
+	}
+	for _, result := range state.Results {
+		if result.Output.ValueString() != "sshd: active\n" {
+			t.Errorf("unexpected output %q of system %s", result.Output.ValueString(), result.SystemID)
+		}
+	}
+	if state.Results[0].SystemID.ValueInt64() != 1000010001 {
+		t.Errorf("expected results ordered by system, got %v", state.Results)
+	}
+}
+
+func TestScheduledActionKeepsPendingActionWithoutCancelOnDestroy(t *testing.T) {
+	ctx := context.Background()
+	var requests []string
+	r := NewScheduledActionResource()
+	testConfigure(t, r, testAPIClient(t, func(w http.ResponseWriter, req *http.Request) {
+		requests = append(requests, req.URL.Path)
+		_, _ = w.Write([]byte(`{"success": true, "result": 702}`))
+	}))
+
+	sids, _ := types.SetValueFrom(ctx, types.Int64Type, []int64{1000010001})
+	planned := testState(t, r, map[string]interface{}{
+		"script":      "zypper -n up",
+		"interpreter": "/bin/sh",
+		"username":    "root",
+		"groupname":   "root",
+		"timeout":     int64(600),
+		"target": &targetModel{
+			SystemIDs:  sids,
+			GroupNames: types.SetNull(types.StringType),
+			Search:     types.StringNull(),
+			SearchBy:   types.StringNull(),
+		},
+		"wait":              false,
+		"cancel_on_destroy": false,
+	})
+	createResp := &resource.CreateResponse{State: planned}
+	r.Create(ctx, resource.CreateRequest{Plan: tfsdk.Plan{Schema: planned.Schema, Raw: planned.Raw}}, createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatal(createResp.Diagnostics)
+	}
+
+	deleteResp := &resource.DeleteResponse{}
+	r.Delete(ctx, resource.DeleteRequest{State: createResp.State}, deleteResp)
+	if deleteResp.Diagnostics.HasError() {
+		t.Fatal(deleteResp.Diagnostics)
+	}
+	if len(requests) != 1 || requests[0] != "/system/scheduleScriptRun" {
+		t.Errorf("expected the pending action to be kept, got requests %v", requests)
+	}
+}
+
+func TestScheduledActionRespectsMaintenanceWindows(t *testing.T) {
+	ctx := context.Background()
+	var scheduled map[string]interface{}
+	r := NewScheduledActionResource()
+	testConfigure(t, r, testAPIClient(t, func(w http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/maintenance/listScheduleNames":
+			_, _ = w.Write([]byte(`{"success": true, "result": ["nightly"]}`))
+		case "/maintenance/listSystemsWithSchedule":
+			_, _ = w.Write([]byte(`{"success": true, "result": [1000010001]}`))
+		case "/maintenance/getScheduleDetails":
+			_, _ = w.Write([]byte(`{"success": true, "result": {"id": 1, "name": "nightly", "type": "single", "calendar": "nightly"}}`))
+		case "/maintenance/getCalendarDetails":
+			_, _ = w.Write([]byte(`{"success": true, "result": {"id": 1, "label": "nightly", "ical": "BEGIN:VCALENDAR\r\n` +
+				`BEGIN:VEVENT\r\nDTSTART:20300101T020000Z\r\nDTEND:20300101T040000Z\r\nEND:VEVENT\r\nEND:VCALENDAR\r\n"}}`))
+		case "/system/scheduleScriptRun":
+			_ = json.NewDecoder(req.Body).Decode(&scheduled)
+			_, _ = w.Write([]byte(`{"success": true, "result": 703}`))
+		default:
+			t.Errorf("unexpected request %s", req.URL)
+		}
+	}))
+
+	sids, _ := types.SetValueFrom(ctx, types.Int64Type, []int64{1000010001})
+	planned := testState(t, r, map[string]interface{}{
+		"script":      "zypper -n up",
+		"interpreter": "/bin/sh",
+		"username":    "root",
+		"groupname":   "root",
+		"timeout":     int64(600),
+		"target": &targetModel{
+			SystemIDs:  sids,
+			GroupNames: types.SetNull(types.StringType),
+			Search:     types.StringNull(),
+			SearchBy:   types.StringNull(),
+		},
+		"wait":                        false,
+		"respect_maintenance_windows": true,
+	})
+	resp := &resource.CreateResponse{State: planned}
+	r.Create(ctx, resource.CreateRequest{Plan: tfsdk.Plan{Schema: planned.Schema, Raw: planned.Raw}}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+	if scheduled["earliestOccurrence"] != "2030-01-01T02:00:00Z" {
+		t.Errorf("expected the script to be scheduled for the window, got %v", scheduled["earliestOccurrence"])
+	}
+
+	var state scheduledActionResourceModel
+	resp.State.Get(ctx, &state)
+	if state.EarliestOccurrence.ValueString() != "2030-01-01T02:00:00Z" {
+		t.Errorf("unexpected earliest_occurrence %s", state.EarliestOccurrence)
+	}
+}
+
+func TestScheduledActionRefusesScriptsOutsidePolicy(t *testing.T) {
+	ctx := context.Background()
+	client := testAPIClient(t, func(w http.ResponseWriter, req *http.Request) {
+		t.Errorf("unexpected request %s", req.URL)
+	})
+	client.scriptPolicy = &scriptPolicy{users: []string{"remediation"}, maxTimeout: 300}
+	r := NewScheduledActionResource()
+	testConfigure(t, r, client)
+
+	for _, tc := range []struct {
+		username string
+		timeout  int64
+		refused  []string
+	}{
+		{"remediation", 300, nil},
+		{"root", 300, []string{"username"}},
+		{"remediation", 600, []string{"timeout"}},
+		{"root", 3600, []string{"username", "timeout"}},
+	} {
+		planned := testState(t, r, map[string]interface{}{
+			"script":      "systemctl restart chronyd",
+			"interpreter": "/bin/sh",
+			"username":    tc.username,
+			"groupname":   "root",
+			"timeout":     tc.timeout,
+		})
+		resp := &resource.ModifyPlanResponse{Plan: tfsdk.Plan{Schema: planned.Schema, Raw: planned.Raw}}
+		r.(resource.ResourceWithModifyPlan).ModifyPlan(ctx, resource.ModifyPlanRequest{
+			Plan: tfsdk.Plan{Schema: planned.Schema, Raw: planned.Raw},
+		}, resp)
+		var refused []string
+		for _, d := range resp.Diagnostics.Errors() {
+			refused = append(refused, d.(interface{ Path() path.Path }).Path().String())
+		}
+		if fmt.Sprint(refused) != fmt.Sprint(tc.refused) {
+			t.Errorf("%s for %ds: expected %v refused, got %v", tc.username, tc.timeout, tc.refused, resp.Diagnostics)
+		}
+	}
+}
+
+func TestScheduledActionAppliesRelevantErrata(t *testing.T) {
+	ctx := context.Background()
+	actionPollInterval = time.Millisecond
+	t.Cleanup(func() { actionPollInterval = 10 * time.Second })
+
+	var (
+		scheduled map[string]interface{}
+		polls     int
+	)
+	r := NewScheduledActionResource()
+	testConfigure(t, r, testAPIClient(t, func(w http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/system/getRelevantErrata":
+			_, _ = w.Write([]byte(`{"success": true, "result": [
+				{"id": 12, "advisory_name": "SUSE-SU-2024:0002-1"},
+				{"id": 11, "advisory_name": "SUSE-SU-2024:0001-1"}
+			]}`))
+		case "/system/scheduleApplyErrata":
+			_ = json.NewDecoder(req.Body).Decode(&scheduled)
+			_, _ = w.Write([]byte(`{"success": true, "result": [705, 704]}`))
+		case "/schedule/listInProgressSystems":
+			polls++
+			if polls <= 2 {
+				_, _ = w.Write([]byte(`{"success": true, "result": [{"server_id": 1000010001}]}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"success": true, "result": []}`))
+		default:
+			_, _ = w.Write([]byte(`{"success": true, "result": []}`))
+		}
+	}))
+
+	sids, _ := types.SetValueFrom(ctx, types.Int64Type, []int64{1000010001})
+	advisories, _ := types.SetValueFrom(ctx, types.StringType, []string{"SUSE-SU-2024:0002-1"})
+	planned := testState(t, r, map[string]interface{}{
+		"type":       scheduledActionErrata,
+		"advisories": advisories,
+		"target": &targetModel{
+			SystemIDs:  sids,
+			GroupNames: types.SetNull(types.StringType),
+			Search:     types.StringNull(),
+			SearchBy:   types.StringNull(),
+		},
+		"wait": true,
+	})
+	resp := &resource.CreateResponse{State: planned}
+	r.Create(ctx, resource.CreateRequest{Plan: tfsdk.Plan{Schema: planned.Schema, Raw: planned.Raw}}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+	if fmt.Sprint(scheduled["errataIds"]) != "[12]" {
+		t.Errorf("expected only the listed advisory, got %v", scheduled["errataIds"])
+	}
+
+	var state scheduledActionResourceModel
+	resp.State.Get(ctx, &state)
+	if state.ActionID.ValueInt64() != 704 || len(state.ActionIDs.Elements()) != 2 || state.Status.ValueString() != actionStatusCompleted {
+		t.Errorf("unexpected actions %s %s with status %s", state.ActionID, state.ActionIDs, state.Status)
+	}
+	if state.Results != nil {
+		t.Errorf("expected no script results, got %v", state.Results)
+	}
+}
+
+func TestScheduledActionValidatesAttributesOfType(t *testing.T) {
+	ctx := context.Background()
+	r := NewScheduledActionResource()
+	for _, tc := range []struct {
+		attributes map[string]interface{}
+		refused    []string
+	}{
+		{map[string]interface{}{"script": "uptime"}, nil},
+		{map[string]interface{}{"type": scheduledActionScript}, []string{"script"}},
+		{map[string]interface{}{"type": scheduledActionHighstate}, nil},
+		{map[string]interface{}{"type": scheduledActionHighstate, "script": "uptime", "timeout": int64(60)}, []string{"script", "timeout"}},
+		{map[string]interface{}{"type": scheduledActionPackageUpdate, "advisories": []string{"SUSE-SU-2024:0001-1"}}, []string{"advisories"}},
+		{map[string]interface{}{"type": scheduledActionErrata, "advisories": []string{"SUSE-SU-2024:0001-1"}}, nil},
+	} {
+		if advisories, ok := tc.attributes["advisories"].([]string); ok {
+			tc.attributes["advisories"], _ = types.SetValueFrom(ctx, types.StringType, advisories)
+		}
+		config := testState(t, r, tc.attributes)
+		resp := &resource.ValidateConfigResponse{}
+		r.(resource.ResourceWithValidateConfig).ValidateConfig(ctx, resource.ValidateConfigRequest{
+			Config: tfsdk.Config{Schema: config.Schema, Raw: config.Raw},
+		}, resp)
+		var refused []string
+		for _, d := range resp.Diagnostics.Errors() {
+			refused = append(refused, d.(interface{ Path() path.Path }).Path().String())
+		}
+		if fmt.Sprint(refused) != fmt.Sprint(tc.refused) {
+			t.Errorf("%v: expected %v refused, got %v", tc.attributes, tc.refused, resp.Diagnostics)
+		}
+	}
+}
+
+func TestAccScheduledActionResource(t *testing.T) {
+	minion := os.Getenv("UYUNI_TEST_MINION_ID")
+	acctest.Test(t, acctest.TestCase{
+		PreCheck: func() {
+			testAccRealServerPreCheck(t)
+			testAccSkipUnlessEnv(t, "UYUNI_TEST_MINION_ID", "a registered Salt minion")
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []acctest.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "uyuni_scheduled_action" "test" {
+  target {
+    system_ids = [%s]
+  }
+
+  script = "echo tfacc"
+  wait   = true
+}
+`, minion),
+				Check: acctest.ComposeAggregateTestCheckFunc(
+					acctest.TestCheckResourceAttr("uyuni_scheduled_action.test", "status", "completed"),
+					acctest.TestCheckResourceAttr("uyuni_scheduled_action.test", "results.#", "1"),
+					acctest.TestCheckResourceAttr("uyuni_scheduled_action.test", "results.0.system_id", minion),
+				),
+			},
+		},
+	})
+}
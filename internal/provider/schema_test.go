@@ -0,0 +1,58 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+)
+
+// TestComputedNestedAttributes checks that the attributes of computed nested
+// attributes are computed too. A Required or Optional attribute inside an
+// object only the provider sets cannot be configured, and the data source
+// fails once it is read.
+func TestComputedNestedAttributes(t *testing.T) {
+	ctx := context.Background()
+	p := &uyuniProvider{}
+
+	for _, newDataSource := range p.DataSources(ctx) {
+		d := newDataSource()
+
+		var metadata datasource.MetadataResponse
+		d.Metadata(ctx, datasource.MetadataRequest{ProviderTypeName: "uyuni"}, &metadata)
+		var resp datasource.SchemaResponse
+		d.Schema(ctx, datasource.SchemaRequest{}, &resp)
+
+		for name, attribute := range resp.Schema.Attributes {
+			if attribute.IsComputed() && !attribute.IsOptional() && !attribute.IsRequired() {
+				checkComputedAttributes(t, metadata.TypeName+"."+name, nestedAttributes(attribute))
+			}
+		}
+	}
+}
+
+func checkComputedAttributes(t *testing.T, parent string, attributes map[string]schema.Attribute) {
+	for name, attribute := range attributes {
+		if attribute.IsRequired() || attribute.IsOptional() || !attribute.IsComputed() {
+			t.Errorf("%s.%s is part of a computed attribute but not computed only", parent, name)
+		}
+		checkComputedAttributes(t, parent+"."+name, nestedAttributes(attribute))
+	}
+}
+
+// nestedAttributes returns the attributes of a nested attribute, nil for
+// other attributes.
+func nestedAttributes(attribute schema.Attribute) map[string]schema.Attribute {
+	switch a := attribute.(type) {
+	case schema.ListNestedAttribute:
+		return a.NestedObject.Attributes
+	case schema.SetNestedAttribute:
+		return a.NestedObject.Attributes
+	case schema.MapNestedAttribute:
+		return a.NestedObject.Attributes
+	case schema.SingleNestedAttribute:
+		return a.Attributes
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+// scriptPolicyModel maps the script_policy attribute of the provider.
+type scriptPolicyModel struct {
+	AllowedUsers        types.Set   `tfsdk:"allowed_users"`
+	AllowedGroups       types.Set   `tfsdk:"allowed_groups"`
+	AllowedInterpreters types.Set   `tfsdk:"allowed_interpreters"`
+	MaxTimeout          types.Int64 `tfsdk:"max_timeout"`
+}
+
+// scriptPolicy restricts the scripts uyuni_scheduled_action runs. Empty
+// lists and a zero maxTimeout allow everything.
+type scriptPolicy struct {
+	users        []string
+	groups       []string
+	interpreters []string
+	maxTimeout   int64
+}
+
+// scriptPolicyAttribute is the schema of the script_policy attribute of the
+// provider.
+func scriptPolicyAttribute() schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		Description: "Guardrails for the scripts uyuni_scheduled_action runs, e.g. to keep remediation scripts " +
+			"from running as root or with interpreters the confinement of the systems does not allow. Scripts " +
+			"outside the policy are refused when planning. Unset lists allow any value.",
+		Optional: true,
+		Attributes: map[string]schema.Attribute{
+			"allowed_users": schema.SetAttribute{
+				Description: "Users scripts may run as.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"allowed_groups": schema.SetAttribute{
+				Description: "Groups scripts may run as.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"allowed_interpreters": schema.SetAttribute{
+				Description: "Absolute paths of the interpreters scripts may use, e.g. `/bin/sh`.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"max_timeout": schema.Int64Attribute{
+				Description: "Maximum number of seconds scripts may run.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+		},
+	}
+}
+
+// newScriptPolicy returns the policy of the script_policy attribute, nil if
+// it is not set.
+func newScriptPolicy(ctx context.Context, value types.Object) (*scriptPolicy, diag.Diagnostics) {
+	if value.IsNull() {
+		return nil, nil
+	}
+	var model scriptPolicyModel
+	diags := value.As(ctx, &model, basetypes.ObjectAsOptions{})
+	if diags.HasError() {
+		return nil, diags
+	}
+	policy := &scriptPolicy{maxTimeout: model.MaxTimeout.ValueInt64()}
+	var err error
+	if policy.users, err = stringSet(ctx, model.AllowedUsers); err == nil {
+		if policy.groups, err = stringSet(ctx, model.AllowedGroups); err == nil {
+			policy.interpreters, err = stringSet(ctx, model.AllowedInterpreters)
+		}
+	}
+	if err != nil {
+		diags.AddAttributeError(path.Root("script_policy"), "Invalid Script Policy", err.Error())
+		return nil, diags
+	}
+	sort.Strings(policy.users)
+	sort.Strings(policy.groups)
+	sort.Strings(policy.interpreters)
+	return policy, diags
+}
+
+// check reports the attributes of a script outside the policy. Unknown
+// values are not checked.
+func (p *scriptPolicy) check(m *scheduledActionResourceModel, diags *diag.Diagnostics) {
+	if p == nil {
+		return
+	}
+	for _, rule := range []struct {
+		attribute string
+		value     types.String
+		allowed   []string
+	}{
+		{"username", m.Username, p.users},
+		{"groupname", m.Groupname, p.groups},
+		{"interpreter", m.Interpreter, p.interpreters},
+	} {
+		if len(rule.allowed) == 0 || rule.value.IsUnknown() || slices.Contains(rule.allowed, rule.value.ValueString()) {
+			continue
+		}
+		diags.AddAttributeError(
+			path.Root(rule.attribute),
+			"Script Policy Violation",
+			fmt.Sprintf("The script_policy of the provider does not allow the %s %q, allowed are %s.",
+				rule.attribute, rule.value.ValueString(), strings.Join(rule.allowed, ", ")),
+		)
+	}
+	if p.maxTimeout > 0 && !m.Timeout.IsUnknown() && m.Timeout.ValueInt64() > p.maxTimeout {
+		diags.AddAttributeError(
+			path.Root("timeout"),
+			"Script Policy Violation",
+			fmt.Sprintf("The script_policy of the provider allows scripts to run for at most %d seconds, got %d.",
+				p.maxTimeout, m.Timeout.ValueInt64()),
+		)
+	}
+}
@@ -0,0 +1,89 @@
+package provider
+
+import (
+	"context"
+	"reflect"
+	"regexp"
+	"sort"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+)
+
+// secretAttribute matches the names of attributes holding secret material.
+// The framework version the provider is built with has no write-only
+// arguments, so secrets are kept in state and must at least be sensitive.
+var secretAttribute = regexp.MustCompile(`password|passphrase|secret|token|private|_key$|headers$`)
+
+// publicAttribute matches the names of attributes secretAttribute matches
+// which hold public material, such as public keys and the activation keys
+// printed in bootstrap scripts.
+var publicAttribute = regexp.MustCompile(`public_key$|authorized_key$|activation_key$`)
+
+// checkSecretsSensitive reports the attributes of a schema, nested attribute
+// or block, and of everything nested in it, which hold a secret but are not
+// sensitive. The schema packages of providers, resources and data sources
+// share no types, so their fields are walked by name.
+func checkSecretsSensitive(t *testing.T, path string, node interface{}) {
+	t.Helper()
+	v := reflect.ValueOf(node)
+	if v.Kind() == reflect.Pointer {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return
+	}
+	for _, field := range []string{"Attributes", "Blocks"} {
+		children := v.FieldByName(field)
+		if !children.IsValid() || children.Kind() != reflect.Map {
+			continue
+		}
+		names := make([]string, 0, children.Len())
+		for _, key := range children.MapKeys() {
+			names = append(names, key.String())
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			child := children.MapIndex(reflect.ValueOf(name)).Interface()
+			if attribute, ok := child.(interface{ IsSensitive() bool }); ok && field == "Attributes" &&
+				secretAttribute.MatchString(name) && !publicAttribute.MatchString(name) && !attribute.IsSensitive() {
+				t.Errorf("%s.%s holds a secret but is not sensitive", path, name)
+			}
+			checkSecretsSensitive(t, path+"."+name, child)
+		}
+	}
+	if nested := v.FieldByName("NestedObject"); nested.IsValid() {
+		checkSecretsSensitive(t, path, nested.Interface())
+	}
+}
+
+func TestSecretAttributesAreSensitive(t *testing.T) {
+	ctx := context.Background()
+	p := &uyuniProvider{}
+
+	var providerSchema provider.SchemaResponse
+	p.Schema(ctx, provider.SchemaRequest{}, &providerSchema)
+	checkSecretsSensitive(t, "provider", providerSchema.Schema)
+
+	for _, newResource := range p.Resources(ctx) {
+		r := newResource()
+
+		var metadata resource.MetadataResponse
+		r.Metadata(ctx, resource.MetadataRequest{ProviderTypeName: "uyuni"}, &metadata)
+		var schema resource.SchemaResponse
+		r.Schema(ctx, resource.SchemaRequest{}, &schema)
+		checkSecretsSensitive(t, metadata.TypeName, schema.Schema)
+	}
+
+	for _, newDataSource := range p.DataSources(ctx) {
+		d := newDataSource()
+
+		var metadata datasource.MetadataResponse
+		d.Metadata(ctx, datasource.MetadataRequest{ProviderTypeName: "uyuni"}, &metadata)
+		var schema datasource.SchemaResponse
+		d.Schema(ctx, datasource.SchemaRequest{}, &schema)
+		checkSecretsSensitive(t, "data."+metadata.TypeName, schema.Schema)
+	}
+}
@@ -0,0 +1,298 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &serverSettingsResource{}
+	_ resource.ResourceWithConfigure   = &serverSettingsResource{}
+	_ resource.ResourceWithImportState = &serverSettingsResource{}
+)
+
+// NewServerSettingsResource is a helper function to simplify the provider implementation.
+func NewServerSettingsResource() resource.Resource {
+	return &serverSettingsResource{}
+}
+
+// serverSettingsResource is the resource implementation.
+type serverSettingsResource struct {
+	client *uyuniClient
+}
+
+// serverSettingsResourceModel maps the resource schema data.
+type serverSettingsResourceModel struct {
+	ID                       types.String `tfsdk:"id"`
+	OrgID                    types.Int64  `tfsdk:"org_id"`
+	ContentStagingEnabled    types.Bool   `tfsdk:"content_staging_enabled"`
+	ErrataEmailNotifications types.Bool   `tfsdk:"errata_email_notifications"`
+	OrgAdminsManageConfig    types.Bool   `tfsdk:"org_admins_manage_config"`
+	ServerAlias              types.String `tfsdk:"server_alias"`
+}
+
+// serverSetting is a boolean setting read and written by a pair of API
+// methods taking the organization ID.
+type serverSetting struct {
+	value *types.Bool
+	get   string
+	set   string
+}
+
+// settings returns the settings of the model.
+func (m *serverSettingsResourceModel) settings() []serverSetting {
+	return []serverSetting{
+		{&m.ContentStagingEnabled, "org/isContentStagingEnabled", "org/setContentStaging"},
+		{&m.ErrataEmailNotifications, "org/isErrataEmailNotifsForOrg", "org/setErrataEmailNotifsForOrg"},
+		{&m.OrgAdminsManageConfig, "org/isOrgConfigManagedByOrgAdmin", "org/setOrgConfigManagedByOrgAdmin"},
+	}
+}
+
+// Metadata returns the resource type name.
+func (r *serverSettingsResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_server_settings"
+}
+
+// Schema defines the schema for the resource.
+func (r *serverSettingsResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	setting := func(description string) schema.BoolAttribute {
+		return schema.BoolAttribute{
+			Description: description + " Left as it is when not set.",
+			Optional:    true,
+			Computed:    true,
+			PlanModifiers: []planmodifier.Bool{
+				boolplanmodifier.UseStateForUnknown(),
+			},
+		}
+	}
+
+	resp.Schema = schema.Schema{
+		Description: "Manages the settings the API exposes for an organization, as one resource per organization. " +
+			"Only the settings set in the configuration are changed, the others are read. Destroying the resource " +
+			"leaves the settings as they are. Settings kept in rhn.conf, e.g. the content staging window or the " +
+			"minimum password length, cannot be managed through the API and apply to all organizations alike. " +
+			"The provider user has to be a server administrator.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "ID of the organization.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"org_id": schema.Int64Attribute{
+				Description: "ID of the organization.",
+				Required:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"content_staging_enabled": setting("Whether Salt minions download packages of scheduled updates ahead of time, " +
+				"within the staging window which java.salt_content_staging_advance and java.salt_content_staging_window " +
+				"of rhn.conf set for the whole server."),
+			"errata_email_notifications": setting("Whether users of the organization are notified of new errata by email."),
+			"org_admins_manage_config": setting("Whether organization administrators can manage configuration channels " +
+				"and files of all systems, like configuration administrators."),
+			"server_alias": serverAliasAttribute(),
+		},
+	}
+}
+
+// apply writes the settings which are set and differ from the prior model,
+// nil if the settings were not read yet.
+func (m *serverSettingsResourceModel) apply(ctx context.Context, client *uyuniClient, prior *serverSettingsResourceModel) error {
+	orgID := m.OrgID.ValueInt64()
+	var priorSettings []serverSetting
+	if prior != nil {
+		priorSettings = prior.settings()
+	}
+	for i, setting := range m.settings() {
+		if setting.value.IsNull() || setting.value.IsUnknown() {
+			continue
+		}
+		if priorSettings != nil && setting.value.Equal(*priorSettings[i].value) {
+			continue
+		}
+		tflog.Info(ctx, fmt.Sprintf("Organization %d: %s to %s", orgID, setting.set, setting.value))
+		_, err := apiPost[int](ctx, client, setting.set, map[string]interface{}{
+			"orgId":  orgID,
+			"enable": setting.value.ValueBool(),
+		})
+		if err != nil {
+			return fmt.Errorf("%s: %w", setting.set, err)
+		}
+	}
+	return nil
+}
+
+// read sets all settings of the model from the server.
+func (m *serverSettingsResourceModel) read(ctx context.Context, client *uyuniClient) error {
+	orgID := m.OrgID.ValueInt64()
+	for _, setting := range m.settings() {
+		value, err := apiGet[bool](ctx, client, fmt.Sprintf("%s?orgId=%d", setting.get, orgID))
+		if err != nil {
+			return fmt.Errorf("%s: %w", setting.get, err)
+		}
+		*setting.value = types.BoolValue(value.Result)
+	}
+	m.ID = types.StringValue(strconv.FormatInt(orgID, 10))
+	return nil
+}
+
+// Create applies the configured settings.
+func (r *serverSettingsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan serverSettingsResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := resourceClient(ctx, r.client, plan.ServerAlias, nil, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	if err := plan.apply(ctx, client, nil); err != nil {
+		resp.Diagnostics.AddError(
+			"Error applying server settings",
+			fmt.Sprintf("Could not apply the settings of organization %d: %s", plan.OrgID.ValueInt64(), err),
+		)
+		return
+	}
+	if err := plan.read(ctx, client); err != nil {
+		resp.Diagnostics.AddError(
+			"Error applying server settings",
+			fmt.Sprintf("Could not read the settings of organization %d: %s", plan.OrgID.ValueInt64(), err),
+		)
+		return
+	}
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read resource information.
+func (r *serverSettingsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Get current state
+	var state serverSettingsResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := resourceClient(ctx, r.client, state.ServerAlias, nil, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	if err := state.read(ctx, client); err != nil {
+		if handleNotFound(ctx, resp, err, fmt.Sprintf("Organization %d", state.OrgID.ValueInt64())) {
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Reading Uyuni server settings",
+			fmt.Sprintf("Could not read the settings of organization %d: %s", state.OrgID.ValueInt64(), err),
+		)
+		return
+	}
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *serverSettingsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Retrieve values from plan and state
+	var plan, state serverSettingsResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := resourceClient(ctx, r.client, plan.ServerAlias, nil, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	if err := plan.apply(ctx, client, &state); err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating server settings",
+			fmt.Sprintf("Could not apply the settings of organization %d: %s", plan.OrgID.ValueInt64(), err),
+		)
+		return
+	}
+	if err := plan.read(ctx, client); err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating server settings",
+			fmt.Sprintf("Could not read the settings of organization %d: %s", plan.OrgID.ValueInt64(), err),
+		)
+		return
+	}
+
+	diags := resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete removes the resource from the state, the settings have no state to
+// go back to.
+func (r *serverSettingsResource) Delete(ctx context.Context, _ resource.DeleteRequest, _ *resource.DeleteResponse) {
+	tflog.Info(ctx, "Removing server settings from state, the settings stay as they are")
+}
+
+// ImportState imports the settings of an organization by its ID.
+func (r *serverSettingsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	orgID, err := parseImportInt64("org_id", req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Import ID", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("org_id"), orgID)...)
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *serverSettingsResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*uyuniClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *uyuniClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
@@ -0,0 +1,90 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	acctest "github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestServerSettingsCreateOnlyWritesConfiguredSettings(t *testing.T) {
+	ctx := context.Background()
+	var changes []string
+	r := NewServerSettingsResource()
+	testConfigure(t, r, testAPIClient(t, func(w http.ResponseWriter, req *http.Request) {
+		if req.Method == http.MethodPost {
+			var body map[string]interface{}
+			_ = json.NewDecoder(req.Body).Decode(&body)
+			changes = append(changes, fmt.Sprint(req.URL.Path, " ", body["orgId"], " ", body["enable"]))
+			_, _ = w.Write([]byte(`{"success": true, "result": 1}`))
+			return
+		}
+		if req.URL.Query().Get("orgId") != "2" {
+			t.Errorf("unexpected request %s", req.URL)
+		}
+		_, _ = w.Write([]byte(`{"success": true, "result": true}`))
+	}))
+
+	planned := testState(t, r, map[string]interface{}{
+		"org_id":                  int64(2),
+		"content_staging_enabled": true,
+	})
+	planned.SetAttribute(ctx, path.Root("errata_email_notifications"), types.BoolUnknown())
+	planned.SetAttribute(ctx, path.Root("org_admins_manage_config"), types.BoolUnknown())
+	resp := &resource.CreateResponse{State: planned}
+	r.Create(ctx, resource.CreateRequest{Plan: tfsdk.Plan{Schema: planned.Schema, Raw: planned.Raw}}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+	if want := "[/org/setContentStaging 2 true]"; fmt.Sprint(changes) != want {
+		t.Errorf("expected %s, got %v", want, changes)
+	}
+
+	var state serverSettingsResourceModel
+	resp.State.Get(ctx, &state)
+	if state.ID.ValueString() != "2" || !state.ErrataEmailNotifications.ValueBool() || !state.OrgAdminsManageConfig.ValueBool() {
+		t.Errorf("unexpected state %v", state)
+	}
+}
+
+func TestAccServerSettingsResource(t *testing.T) {
+	acctest.Test(t, acctest.TestCase{
+		PreCheck:                 func() { testAccRealServerPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []acctest.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccServerSettingsResourceConfig(true),
+				Check:  acctest.TestCheckResourceAttr("uyuni_server_settings.test", "content_staging_enabled", "true"),
+			},
+			// Update and Read testing
+			{
+				Config: testAccServerSettingsResourceConfig(false),
+				Check:  acctest.TestCheckResourceAttr("uyuni_server_settings.test", "content_staging_enabled", "false"),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "uyuni_server_settings.test",
+				ImportState:       true,
+				ImportStateId:     "1",
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccServerSettingsResourceConfig(staging bool) string {
+	return fmt.Sprintf(`
+resource "uyuni_server_settings" "test" {
+  org_id                  = 1
+  content_staging_enabled = %t
+}
+`, staging)
+}
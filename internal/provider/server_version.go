@@ -0,0 +1,137 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// serverVersion is the product version reported by api.systemVersion. Uyuni
+// has calendar versions such as 2024.08, SUSE Manager releases such as 5.0.2.
+type serverVersion struct {
+	raw   string
+	uyuni bool
+	parts []int
+}
+
+func parseServerVersion(s string) (*serverVersion, error) {
+	fields := strings.Split(strings.TrimSpace(s), ".")
+	parts := make([]int, 0, len(fields))
+	for _, field := range fields {
+		part, err := strconv.Atoi(field)
+		if err != nil || part < 0 {
+			return nil, fmt.Errorf("unexpected server version %q", s)
+		}
+		parts = append(parts, part)
+	}
+	return &serverVersion{raw: strings.TrimSpace(s), uyuni: parts[0] >= 2000, parts: parts}, nil
+}
+
+// atLeast reports whether the version is not older than the minimum version
+// of the same product.
+func (v *serverVersion) atLeast(minimum *serverVersion) bool {
+	for i, part := range minimum.parts {
+		if i >= len(v.parts) || v.parts[i] < part {
+			return false
+		}
+		if v.parts[i] > part {
+			return true
+		}
+	}
+	return true
+}
+
+func (v *serverVersion) String() string {
+	if v.uyuni {
+		return "Uyuni " + v.raw
+	}
+	return "SUSE Manager " + v.raw
+}
+
+// apiFeature is a group of API calls that was added in a later release. Older
+// servers answer them with a generic 404, so calls matching one of the path
+// prefixes are refused up front when the server is known to be too old.
+type apiFeature struct {
+	name        string
+	prefixes    []string
+	uyuni       string
+	suseManager string
+}
+
+var apiFeatures = []apiFeature{
+	{
+		name:        "Hub peripheral channel synchronization",
+		prefixes:    []string{"sync/hub/"},
+		uyuni:       "2025.05",
+		suseManager: "5.1",
+	},
+	{
+		name:        "Confidential computing attestation",
+		prefixes:    []string{"system/getCoCoAttestationConfig", "system/setCoCoAttestationConfig"},
+		uyuni:       "2024.05",
+		suseManager: "5.0",
+	},
+	{
+		name:        "Support data upload",
+		prefixes:    []string{"system/scheduleSupportDataUpload"},
+		uyuni:       "2024.10",
+		suseManager: "5.0.2",
+	},
+}
+
+// minimum returns the first release of the product of v offering the feature.
+func (f apiFeature) minimum(v *serverVersion) *serverVersion {
+	minimum := f.suseManager
+	if v.uyuni {
+		minimum = f.uyuni
+	}
+	version, err := parseServerVersion(minimum)
+	if err != nil {
+		panic(err)
+	}
+	return version
+}
+
+// unsupportedFeatureError is returned for calls the server does not offer.
+type unsupportedFeatureError struct {
+	feature apiFeature
+	version *serverVersion
+}
+
+func (e *unsupportedFeatureError) Error() string {
+	return fmt.Sprintf("%s requires Uyuni %s or SUSE Manager %s, but the server runs %s",
+		e.feature.name, e.feature.uyuni, e.feature.suseManager, e.version)
+}
+
+// checkFeature returns an error if path belongs to a feature the server is
+// too old for. Nothing is refused while the server version is unknown.
+func (c *uyuniClient) checkFeature(path string) error {
+	if c.version == nil {
+		return nil
+	}
+	for _, feature := range apiFeatures {
+		for _, prefix := range feature.prefixes {
+			if strings.HasPrefix(path, prefix) && !c.version.atLeast(feature.minimum(c.version)) {
+				return &unsupportedFeatureError{feature: feature, version: c.version}
+			}
+		}
+	}
+	return nil
+}
+
+// detectVersion asks the server for its version. Failing to detect it only
+// disables the feature checks, so errors are logged rather than returned.
+func (c *uyuniClient) detectVersion(ctx context.Context) {
+	response, err := apiGet[string](ctx, c, "api/systemVersion")
+	if err == nil {
+		c.version, err = parseServerVersion(response.Result)
+	}
+	if err != nil {
+		tflog.Warn(ctx, "Unable to detect the Uyuni server version", map[string]interface{}{"error": err.Error()})
+		return
+	}
+	tflog.Debug(ctx, "Detected Uyuni server version", map[string]interface{}{"version": c.version.String()})
+}
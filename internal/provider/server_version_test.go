@@ -0,0 +1,118 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestParseServerVersion(t *testing.T) {
+	tests := map[string]string{
+		"2024.08":   "Uyuni 2024.08",
+		"5.0.2":     "SUSE Manager 5.0.2",
+		" 4.3.14\n": "SUSE Manager 4.3.14",
+	}
+	for input, want := range tests {
+		version, err := parseServerVersion(input)
+		if err != nil {
+			t.Errorf("%q: %v", input, err)
+			continue
+		}
+		if version.String() != want {
+			t.Errorf("%q: got %s, want %s", input, version, want)
+		}
+	}
+
+	for _, input := range []string{"", "5.0-beta", "latest"} {
+		if _, err := parseServerVersion(input); err == nil {
+			t.Errorf("%q: expected an error", input)
+		}
+	}
+}
+
+func TestServerVersionAtLeast(t *testing.T) {
+	tests := []struct {
+		version, minimum string
+		want             bool
+	}{
+		{"5.0.2", "5.0", true},
+		{"5.0", "5.0.1", false},
+		{"4.3.14", "5.0", false},
+		{"5.1", "5.0.3", true},
+		{"2025.05", "2025.05", true},
+		{"2024.12", "2025.05", false},
+	}
+	for _, test := range tests {
+		version, _ := parseServerVersion(test.version)
+		minimum, _ := parseServerVersion(test.minimum)
+		if got := version.atLeast(minimum); got != test.want {
+			t.Errorf("%s at least %s: got %v", test.version, test.minimum, got)
+		}
+	}
+}
+
+func TestCallRefusesUnsupportedFeatures(t *testing.T) {
+	requests := 0
+	client := testAPIClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusNotFound)
+	})
+	client.version, _ = parseServerVersion("4.3.14")
+
+	_, err := apiGet[[]string](context.Background(), client, "sync/hub/listPeripheralChannelsToSync?fqdn=peripheral.example.com")
+	var unsupported *unsupportedFeatureError
+	if !errors.As(err, &unsupported) {
+		t.Fatalf("expected an unsupported feature error, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "requires Uyuni 2025.05 or SUSE Manager 5.1, but the server runs SUSE Manager 4.3.14") {
+		t.Errorf("got %v", err)
+	}
+	if isNotFoundError(err) {
+		t.Error("unsupported features must not be mistaken for vanished objects")
+	}
+	if requests != 0 {
+		t.Errorf("expected no request, got %d", requests)
+	}
+}
+
+func TestCallAllowsSupportedFeatures(t *testing.T) {
+	for _, version := range []string{"", "5.1.0", "2025.07"} {
+		client := testAPIClient(t, func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"success": true, "result": []}`))
+		})
+		if version != "" {
+			client.version, _ = parseServerVersion(version)
+		}
+
+		if _, err := apiGet[[]string](context.Background(), client, "sync/hub/listPeripheralChannelsToSync?fqdn=peripheral.example.com"); err != nil {
+			t.Errorf("%q: %v", version, err)
+		}
+	}
+}
+
+func TestDetectVersion(t *testing.T) {
+	client := testAPIClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/systemVersion" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		_, _ = w.Write([]byte(`{"success": true, "result": "2024.08"}`))
+	})
+
+	client.detectVersion(context.Background())
+	if client.version == nil || client.version.String() != "Uyuni 2024.08" {
+		t.Errorf("got %v", client.version)
+	}
+}
+
+func TestDetectVersionFailure(t *testing.T) {
+	client := testAPIClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	client.detectVersion(context.Background())
+	if client.version != nil {
+		t.Errorf("expected an unknown version, got %v", client.version)
+	}
+}
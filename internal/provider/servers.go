@@ -0,0 +1,120 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/uyuni-project/uyuni-tools/shared/api"
+)
+
+// serverModel maps an entry of the servers attribute of the provider.
+type serverModel struct {
+	Host     types.String `tfsdk:"host"`
+	Username types.String `tfsdk:"username"`
+	Password types.String `tfsdk:"password"`
+	CACert   types.String `tfsdk:"ca_cert"`
+	Insecure types.Bool   `tfsdk:"insecure"`
+}
+
+// serverRegistry holds the connections of the servers attribute by alias,
+// e.g. the peripheral servers of a hub. Their clients log in on first use,
+// so that servers no resource uses are never contacted.
+type serverRegistry struct {
+	conns map[string]api.ConnectionDetails
+
+	mu      sync.Mutex
+	clients map[string]*uyuniClient
+}
+
+// newServerRegistry creates a registry of the connections by alias.
+func newServerRegistry(conns map[string]api.ConnectionDetails) *serverRegistry {
+	return &serverRegistry{conns: conns, clients: map[string]*uyuniClient{}}
+}
+
+// aliases returns the sorted aliases of the registry.
+func (s *serverRegistry) aliases() []string {
+	aliases := make([]string, 0, len(s.conns))
+	for alias := range s.conns {
+		aliases = append(aliases, alias)
+	}
+	sort.Strings(aliases)
+	return aliases
+}
+
+// forServer returns the client of the server with the alias, the client
+// itself if the alias is empty. The clients of the servers share the
+// provider wide settings of c.
+func (c *uyuniClient) forServer(ctx context.Context, alias string) (*uyuniClient, error) {
+	if alias == "" {
+		return c, nil
+	}
+	if c.servers == nil {
+		return nil, fmt.Errorf("no server has the alias %s, the provider configures none", alias)
+	}
+	conn, ok := c.servers.conns[alias]
+	if !ok {
+		return nil, fmt.Errorf("no server has the alias %s, known aliases are %s", alias, strings.Join(c.servers.aliases(), ", "))
+	}
+
+	c.servers.mu.Lock()
+	defer c.servers.mu.Unlock()
+
+	if client, ok := c.servers.clients[alias]; ok {
+		return client, nil
+	}
+	client, err := newUyuniClient(ctx, &conn)
+	if err != nil {
+		return nil, fmt.Errorf("could not log in to %s: %w", conn.Server, err)
+	}
+	client.debug = c.debug
+	client.retries = c.retries
+	if c.pacer != nil {
+		client.pacer.configure(c.pacer.maxLimit, c.pacer.interval)
+	}
+	client.tracer = c.tracer
+	client.audit = c.audit
+	client.detectVersion(ctx)
+	client.defaultCustomValues = c.defaultCustomValues
+	c.servers.clients[alias] = client
+	return client, nil
+}
+
+// serverAliasAttribute is the schema of the server_alias attribute, which
+// manages the object on another server than the one of the provider.
+func serverAliasAttribute() schema.StringAttribute {
+	return schema.StringAttribute{
+		Description: "Alias of the server in the servers attribute of the provider to manage the object on, " +
+			"e.g. a peripheral server of a hub. Defaults to the server of the provider. " +
+			"Objects managed on another server cannot be imported.",
+		Optional: true,
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.RequiresReplace(),
+		},
+	}
+}
+
+// resourceClient returns the client for the server_alias attribute and the
+// org block of a resource, org may be nil for resources without the block.
+// It reports an error and returns nil if the server is unknown or a user
+// cannot log in.
+func resourceClient(ctx context.Context, client *uyuniClient, alias types.String, org *orgModel, diags *diag.Diagnostics) *uyuniClient {
+	client, err := client.forServer(ctx, alias.ValueString())
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("server_alias"),
+			"Unable to Connect to Uyuni Server",
+			"The server_alias attribute selects another server than the one of the provider, but "+err.Error()+".",
+		)
+		return nil
+	}
+	return orgClient(ctx, client, org, diags)
+}
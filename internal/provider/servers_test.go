@@ -0,0 +1,69 @@
+package provider
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/uyuni-project/uyuni-tools/shared/api"
+)
+
+func TestForServerPoolsClients(t *testing.T) {
+	ctx := context.Background()
+	hub := newTestSessionServer(t)
+	peripheral := newTestSessionServer(t)
+	client := hub.client(t)
+	client.defaultCustomValues = map[string]string{"owner": "ops"}
+	host, err := url.Parse(peripheral.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.servers = newServerRegistry(map[string]api.ConnectionDetails{
+		"peripheral": {Server: host.Host, User: "admin", Password: "secret", Insecure: true},
+	})
+
+	if c, err := client.forServer(ctx, ""); err != nil || c != client {
+		t.Fatalf("expected the provider client without alias, got %p, %v", c, err)
+	}
+
+	first, err := client.forServer(ctx, "peripheral")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first == client || first.cookie() == nil || first.defaultCustomValues["owner"] != "ops" {
+		t.Fatalf("expected a logged in client for the peripheral, got %+v", first)
+	}
+	second, err := client.forServer(ctx, "peripheral")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if second != first {
+		t.Error("expected resources with the same alias to share a client")
+	}
+	if logins := peripheral.logins.Load(); logins != 1 {
+		t.Errorf("expected one login to the peripheral, got %d", logins)
+	}
+}
+
+func TestResourceClientUnknownAlias(t *testing.T) {
+	client := &uyuniClient{servers: newServerRegistry(map[string]api.ConnectionDetails{
+		"peripheral-1": {},
+		"peripheral-2": {},
+	})}
+
+	var diags diag.Diagnostics
+	if c := resourceClient(context.Background(), client, types.StringValue("peripheral-3"), nil, &diags); c != nil {
+		t.Fatal("expected no client")
+	}
+	if !diags.HasError() || !strings.Contains(diags[0].Detail(), "no server has the alias peripheral-3, known aliases are peripheral-1, peripheral-2") {
+		t.Errorf("got %v", diags)
+	}
+
+	diags = nil
+	if c := resourceClient(context.Background(), &uyuniClient{}, types.StringValue("peripheral-1"), nil, &diags); c != nil || !diags.HasError() {
+		t.Errorf("expected an error without servers, got %v", diags)
+	}
+}
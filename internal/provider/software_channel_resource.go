@@ -0,0 +1,533 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+
+	"terraform-provider-uyuni/internal/uyuni"
+	"terraform-provider-uyuni/internal/validators"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &softwareChannelResource{}
+	_ resource.ResourceWithConfigure   = &softwareChannelResource{}
+	_ resource.ResourceWithImportState = &softwareChannelResource{}
+	_ resource.ResourceWithModifyPlan  = &softwareChannelResource{}
+)
+
+// NewSoftwareChannelResource is a helper function to simplify the provider implementation.
+func NewSoftwareChannelResource() resource.Resource {
+	return &softwareChannelResource{}
+}
+
+// softwareChannelResource is the resource implementation.
+type softwareChannelResource struct {
+	client *uyuniClient
+}
+
+// softwareChannelResourceModel maps the resource schema data.
+type softwareChannelResourceModel struct {
+	ID                 types.String   `tfsdk:"id"`
+	Label              types.String   `tfsdk:"label"`
+	Name               types.String   `tfsdk:"name"`
+	Summary            types.String   `tfsdk:"summary"`
+	Description        types.String   `tfsdk:"description"`
+	ArchLabel          types.String   `tfsdk:"arch_label"`
+	ParentChannelLabel types.String   `tfsdk:"parent_channel_label"`
+	ChecksumType       types.String   `tfsdk:"checksum_type"`
+	GPGKeyURL          types.String   `tfsdk:"gpg_key_url"`
+	GPGKeyID           types.String   `tfsdk:"gpg_key_id"`
+	GPGKeyFingerprint  types.String   `tfsdk:"gpg_key_fingerprint"`
+	GPGCheck           types.Bool     `tfsdk:"gpg_check"`
+	RepositoryLabels   types.Set      `tfsdk:"repository_labels"`
+	DeletionProtection types.Bool     `tfsdk:"deletion_protection"`
+	ServerAlias        types.String   `tfsdk:"server_alias"`
+	Org                *orgModel      `tfsdk:"org"`
+	Timeouts           timeouts.Value `tfsdk:"timeouts"`
+}
+
+// Metadata returns the resource type name.
+func (r *softwareChannelResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_software_channel"
+}
+
+// Schema defines the schema for the resource.
+func (r *softwareChannelResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	checksumTypes := make([]string, 0, len(channelChecksumTypes))
+	for checksumType := range channelChecksumTypes {
+		checksumTypes = append(checksumTypes, checksumType)
+	}
+	sort.Strings(checksumTypes)
+	resp.Schema = schema.Schema{
+		Description: "Manages a custom software channel and the repositories it syncs from. " +
+			"Create the repositories with uyuni_repository and sync the channel with uyuni_channel_sync.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Label of the channel.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"label": schema.StringAttribute{
+				Description: "Label of the channel.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validators.ChannelLabel(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "Name of the channel.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(6),
+				},
+			},
+			"summary": schema.StringAttribute{
+				Description: "Summary of the channel.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"description": schema.StringAttribute{
+				Description: "Description of the channel.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(""),
+			},
+			"arch_label": schema.StringAttribute{
+				Description: "Architecture of the channel, e.g. `channel-x86_64` or `channel-amd64-deb`. Changing it replaces the channel.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"parent_channel_label": schema.StringAttribute{
+				Description: "Label of the base channel of this child channel. Unset for base channels. Changing it replaces the channel.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validators.ChannelLabel(),
+				},
+			},
+			"checksum_type": schema.StringAttribute{
+				Description: "Checksum type of the repository metadata: `sha1`, `sha256`, or since Uyuni 2024.08 " +
+					"and SUSE Manager 5.0 `sha384` and `sha512`. Defaults to `sha256`.",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString("sha256"),
+				Validators: []validator.String{
+					stringvalidator.OneOf(checksumTypes...),
+				},
+			},
+			"gpg_key_url": schema.StringAttribute{
+				Description: "URL of the GPG key the packages of the channel are signed with.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(""),
+			},
+			"gpg_key_id": schema.StringAttribute{
+				Description: "ID of the GPG key, e.g. `39DB7C82`.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(""),
+			},
+			"gpg_key_fingerprint": schema.StringAttribute{
+				Description: "Fingerprint of the GPG key.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(""),
+			},
+			"gpg_check": schema.BoolAttribute{
+				Description: "Whether clients check the signatures of the packages. Defaults to true.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+			},
+			"repository_labels": schema.SetAttribute{
+				Description: "Labels of the repositories the channel syncs from, other repositories are disassociated. " +
+					"Unset leaves the repositories alone.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"deletion_protection": deletionProtectionAttribute(false),
+			"server_alias":        serverAliasAttribute(),
+		},
+		Blocks: map[string]schema.Block{
+			"org": orgBlock(),
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+// ModifyPlan refuses checksum types the server does not offer yet.
+func (r *softwareChannelResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to plan on destroy or before the provider is configured.
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	var plan softwareChannelResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() || plan.ChecksumType.IsUnknown() || plan.ServerAlias.IsUnknown() {
+		return
+	}
+
+	// Unknown aliases are reported by the apply.
+	client, err := r.client.forServer(ctx, plan.ServerAlias.ValueString())
+	if err != nil {
+		return
+	}
+	if err := checkChecksumType(client, plan.ChecksumType.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("checksum_type"), "Unsupported Checksum Type", err.Error())
+	}
+}
+
+// details returns the details of the channel set by channel.software.setDetails.
+func (m *softwareChannelResourceModel) details() map[string]interface{} {
+	return map[string]interface{}{
+		"name":           m.Name.ValueString(),
+		"summary":        m.Summary.ValueString(),
+		"description":    m.Description.ValueString(),
+		"checksum_label": m.ChecksumType.ValueString(),
+		"gpg_key_url":    m.GPGKeyURL.ValueString(),
+		"gpg_key_id":     m.GPGKeyID.ValueString(),
+		"gpg_key_fp":     m.GPGKeyFingerprint.ValueString(),
+		"gpg_check":      m.GPGCheck.ValueBool(),
+	}
+}
+
+// setFromChannel sets the attributes from the details of the channel.
+func (m *softwareChannelResourceModel) setFromChannel(ctx context.Context, channel *uyuni.Channel) diag.Diagnostics {
+	m.ID = m.Label
+	m.Name = types.StringValue(channel.Name)
+	m.Summary = types.StringValue(channel.Summary)
+	m.Description = types.StringValue(channel.Description)
+	m.ArchLabel = types.StringValue(channel.ArchLabel)
+	m.ParentChannelLabel = nonEmptyString(channel.ParentChannelLabel)
+	m.ChecksumType = types.StringValue(channel.ChecksumLabel)
+	m.GPGKeyURL = types.StringValue(channel.GPGKeyURL)
+	m.GPGKeyID = types.StringValue(channel.GPGKeyID)
+	m.GPGKeyFingerprint = types.StringValue(channel.GPGKeyFP)
+	m.GPGCheck = types.BoolValue(channel.GPGCheck)
+
+	// Repositories are only refreshed where they are managed.
+	if m.RepositoryLabels.IsNull() {
+		return nil
+	}
+	var diags diag.Diagnostics
+	m.RepositoryLabels, diags = types.SetValueFrom(ctx, types.StringType, repositoryLabels(channel))
+	return diags
+}
+
+// repositoryLabels returns the labels of the repositories of the channel.
+func repositoryLabels(channel *uyuni.Channel) []string {
+	labels := make([]string, 0, len(channel.ContentSources))
+	for _, source := range channel.ContentSources {
+		labels = append(labels, source.Label)
+	}
+	return labels
+}
+
+// changeRepositories associates the repositories of the plan with the
+// channel and disassociates the others, if the repositories are managed.
+func (m *softwareChannelResourceModel) changeRepositories(ctx context.Context, client *uyuniClient) error {
+	if m.RepositoryLabels.IsNull() {
+		return nil
+	}
+	label := m.Label.ValueString()
+	wanted, err := stringSet(ctx, m.RepositoryLabels)
+	if err != nil {
+		return err
+	}
+	channel, err := apiGet[uyuni.Channel](ctx, client, "channel/software/getDetails?channelLabel="+url.QueryEscape(label))
+	if err != nil {
+		return fmt.Errorf("could not read channel %s: %w", label, err)
+	}
+	add, remove := setDiff(repositoryLabels(&channel.Result), wanted)
+	sort.Strings(add)
+	sort.Strings(remove)
+	tflog.Info(ctx, fmt.Sprintf("Channel %s: associating %d and disassociating %d repositories", label, len(add), len(remove)))
+	for _, repo := range add {
+		if _, err := apiPost[uyuni.Channel](ctx, client, "channel/software/associateRepo", map[string]interface{}{
+			"channelLabel": label,
+			"repoLabel":    repo,
+		}); err != nil {
+			return fmt.Errorf("could not associate repository %s: %w", repo, err)
+		}
+	}
+	for _, repo := range remove {
+		if _, err := apiPost[uyuni.Channel](ctx, client, "channel/software/disassociateRepo", map[string]interface{}{
+			"channelLabel": label,
+			"repoLabel":    repo,
+		}); err != nil {
+			return fmt.Errorf("could not disassociate repository %s: %w", repo, err)
+		}
+	}
+	return nil
+}
+
+// Create a new resource.
+func (r *softwareChannelResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan softwareChannelResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	client := resourceClient(ctx, r.client, plan.ServerAlias, plan.Org, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	label := plan.Label.ValueString()
+	tflog.Info(ctx, "About to create software channel "+label)
+
+	_, err := apiPost[int](ctx, client, "channel/software/create", map[string]interface{}{
+		"label":        label,
+		"name":         plan.Name.ValueString(),
+		"summary":      plan.Summary.ValueString(),
+		"archLabel":    plan.ArchLabel.ValueString(),
+		"parentLabel":  plan.ParentChannelLabel.ValueString(),
+		"checksumType": plan.ChecksumType.ValueString(),
+		"gpgKey": map[string]interface{}{
+			"url":         plan.GPGKeyURL.ValueString(),
+			"id":          plan.GPGKeyID.ValueString(),
+			"fingerprint": plan.GPGKeyFingerprint.ValueString(),
+		},
+		"gpgCheck": plan.GPGCheck.ValueBool(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating software channel",
+			"Could not create software channel "+label+": "+err.Error(),
+		)
+		return
+	}
+	plan.ID = plan.Label
+
+	// The description cannot be set on creation.
+	if plan.Description.ValueString() != "" {
+		_, err = apiPost[int](ctx, client, "channel/software/setDetails", map[string]interface{}{
+			"channelLabel": label,
+			"details":      map[string]interface{}{"description": plan.Description.ValueString()},
+		})
+	}
+	if err == nil {
+		err = plan.changeRepositories(ctx, client)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating software channel",
+			"Could not configure software channel "+label+": "+err.Error(),
+		)
+		// Fall through to track the channel, which Terraform taints.
+	}
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read resource information.
+func (r *softwareChannelResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Get current state
+	var state softwareChannelResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := resourceClient(ctx, r.client, state.ServerAlias, state.Org, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	label := state.Label.ValueString()
+	channel, err := apiGet[uyuni.Channel](ctx, client, "channel/software/getDetails?channelLabel="+url.QueryEscape(label))
+	if err != nil {
+		if handleNotFound(ctx, resp, err, "Software channel "+label) {
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Reading Uyuni software channel",
+			"Could not read software channel "+label+": "+err.Error(),
+		)
+		return
+	}
+	resp.Diagnostics.Append(state.setFromChannel(ctx, &channel.Result)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *softwareChannelResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Retrieve values from plan and state
+	var plan, state softwareChannelResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	client := resourceClient(ctx, r.client, plan.ServerAlias, plan.Org, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	label := plan.Label.ValueString()
+	_, err := apiPost[int](ctx, client, "channel/software/setDetails", map[string]interface{}{
+		"channelLabel": label,
+		"details":      plan.details(),
+	})
+	// Clients cannot use metadata of the old checksum type after the switch.
+	if err == nil && !plan.ChecksumType.Equal(state.ChecksumType) {
+		tflog.Info(ctx, fmt.Sprintf("Regenerating metadata of channel %s with checksum type %s", label, plan.ChecksumType.ValueString()))
+		_, err = apiPost[int](ctx, client, "channel/software/regenerateYumCache", map[string]interface{}{
+			"channelLabel": label,
+			"force":        true,
+		})
+	}
+	if err == nil {
+		err = plan.changeRepositories(ctx, client)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating software channel",
+			"Could not update software channel "+label+": "+err.Error(),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete deletes the channel.
+func (r *softwareChannelResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Retrieve values from state
+	var state softwareChannelResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	label := state.Label.ValueString()
+	if deletionProtected(state.DeletionProtection, "Software channel "+label, &resp.Diagnostics) {
+		return
+	}
+
+	client := resourceClient(ctx, r.client, state.ServerAlias, state.Org, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	_, err := apiPost[int](ctx, client, "channel/software/delete", map[string]interface{}{
+		"channelLabel": label,
+	})
+	if err != nil && !isNotFoundError(err) {
+		resp.Diagnostics.AddError(
+			"Error Deleting Uyuni software channel",
+			"Could not delete software channel "+label+": "+err.Error(),
+		)
+		return
+	}
+}
+
+// ImportState imports a channel by its label. Its repositories stay
+// unmanaged until they are configured.
+func (r *softwareChannelResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("label"), req, resp)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("deletion_protection"), false)...)
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *softwareChannelResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*uyuniClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *uyuniClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
@@ -0,0 +1,143 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	acctest "github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+// testSoftwareChannelServer serves the channel tools syncing from the
+// repository old-tools and records the changes.
+func testSoftwareChannelServer(changes *[]string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		switch r.URL.Path {
+		case "/channel/software/getDetails":
+			_, _ = w.Write([]byte(`{"success": true, "result": {"id": 300, "label": "tools", "name": "Internal tools",
+				"arch_label": "channel-x86_64", "summary": "Tools", "checksum_label": "sha256", "gpg_check": true,
+				"contentSources": [{"id": 11, "label": "old-tools", "sourceUrl": "https://repo.example.com/old/", "type": "yum"}]}}`))
+			return
+		case "/channel/software/create":
+			*changes = append(*changes, fmt.Sprint(r.URL.Path, " ", body["label"], " ", body["parentLabel"], " ", body["gpgKey"]))
+		case "/channel/software/setDetails":
+			*changes = append(*changes, fmt.Sprint(r.URL.Path, " ", body["details"]))
+		case "/channel/software/associateRepo", "/channel/software/disassociateRepo":
+			*changes = append(*changes, fmt.Sprint(r.URL.Path, " ", body["repoLabel"]))
+			_, _ = w.Write([]byte(`{"success": true, "result": {"id": 300, "label": "tools"}}`))
+			return
+		default:
+			*changes = append(*changes, r.URL.Path)
+		}
+		_, _ = w.Write([]byte(`{"success": true, "result": 1}`))
+	}
+}
+
+func TestSoftwareChannelCreateAssociatesRepositories(t *testing.T) {
+	ctx := context.Background()
+	var changes []string
+	r := NewSoftwareChannelResource()
+	testConfigure(t, r, testAPIClient(t, testSoftwareChannelServer(&changes)))
+
+	planned := testState(t, r, map[string]interface{}{
+		"label":               "tools",
+		"name":                "Internal tools",
+		"summary":             "Tools",
+		"description":         "Tools of the ops team",
+		"arch_label":          "channel-x86_64",
+		"checksum_type":       "sha256",
+		"gpg_key_url":         "https://repo.example.com/key.asc",
+		"gpg_key_id":          "",
+		"gpg_key_fingerprint": "",
+		"gpg_check":           true,
+		"repository_labels":   []string{"tools"},
+	})
+	resp := &resource.CreateResponse{State: planned}
+	r.Create(ctx, resource.CreateRequest{Plan: tfsdk.Plan{Schema: planned.Schema, Raw: planned.Raw}}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+	want := "[/channel/software/create tools  map[fingerprint: id: url:https://repo.example.com/key.asc]" +
+		" /channel/software/setDetails map[description:Tools of the ops team]" +
+		" /channel/software/associateRepo tools" +
+		" /channel/software/disassociateRepo old-tools]"
+	if fmt.Sprint(changes) != want {
+		t.Errorf("expected %s, got %v", want, changes)
+	}
+}
+
+func TestSoftwareChannelUpdateRegeneratesMetadataOnChecksumChange(t *testing.T) {
+	ctx := context.Background()
+	r := NewSoftwareChannelResource()
+	for checksum, regenerated := range map[string]bool{"sha256": false, "sha512": true} {
+		var changes []string
+		testConfigure(t, r, testAPIClient(t, testSoftwareChannelServer(&changes)))
+		attributes := map[string]interface{}{
+			"label":         "tools",
+			"name":          "Internal tools",
+			"summary":       "Tools",
+			"arch_label":    "channel-x86_64",
+			"checksum_type": "sha256",
+		}
+		state := testState(t, r, attributes)
+		attributes["checksum_type"] = checksum
+		planned := testState(t, r, attributes)
+		resp := &resource.UpdateResponse{State: planned}
+		r.Update(ctx, resource.UpdateRequest{Plan: tfsdk.Plan{Schema: planned.Schema, Raw: planned.Raw}, State: state}, resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatal(resp.Diagnostics)
+		}
+		if got := slices.Contains(changes, "/channel/software/regenerateYumCache"); got != regenerated {
+			t.Errorf("%s: expected regenerated to be %t, got %v", checksum, regenerated, changes)
+		}
+	}
+}
+
+func TestAccSoftwareChannelResource(t *testing.T) {
+	acctest.Test(t, acctest.TestCase{
+		PreCheck:                 func() { testAccUyuniPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckGone(t, "channel/software/getDetails?channelLabel=tfacc-channel"),
+		Steps: []acctest.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccSoftwareChannelResourceConfig("Created by the acceptance tests"),
+				Check: acctest.ComposeAggregateTestCheckFunc(
+					acctest.TestCheckResourceAttr("uyuni_software_channel.test", "id", "tfacc-channel"),
+					acctest.TestCheckResourceAttr("uyuni_software_channel.test", "arch_label", "channel-x86_64"),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: testAccSoftwareChannelResourceConfig("Updated by the acceptance tests"),
+				Check:  acctest.TestCheckResourceAttr("uyuni_software_channel.test", "summary", "Updated by the acceptance tests"),
+			},
+			// ImportState testing
+			{
+				ResourceName:                         "uyuni_software_channel.test",
+				ImportState:                          true,
+				ImportStateId:                        "tfacc-channel",
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "label",
+			},
+		},
+	})
+}
+
+func testAccSoftwareChannelResourceConfig(summary string) string {
+	return fmt.Sprintf(`
+resource "uyuni_software_channel" "test" {
+  label      = "tfacc-channel"
+  name       = "tfacc-channel"
+  summary    = %q
+  arch_label = "channel-x86_64"
+}
+`, summary)
+}
@@ -0,0 +1,190 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"golang.org/x/crypto/ssh"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &SSHPushKeysDataSource{}
+	_ datasource.DataSourceWithConfigure = &SSHPushKeysDataSource{}
+)
+
+const (
+	// serverSSHKeyPath is where the server publishes the key salt-ssh
+	// connects with, for proxies to authorize it.
+	serverSSHKeyPath = "/rhn/manager/download/saltssh/pubkey"
+	// proxySSHKeyPath is where mgr-proxy-ssh-push-init publishes the key a
+	// proxy connects to systems with.
+	proxySSHKeyPath = "/pub/id_susemanager_ssh_push.pub"
+)
+
+// SSHPushKeysDataSourceModel maps the data source schema data.
+type SSHPushKeysDataSourceModel struct {
+	Proxy             types.String `tfsdk:"proxy"`
+	ServerPublicKey   types.String `tfsdk:"server_public_key"`
+	ServerFingerprint types.String `tfsdk:"server_fingerprint"`
+	ProxyPublicKey    types.String `tfsdk:"proxy_public_key"`
+	ProxyFingerprint  types.String `tfsdk:"proxy_fingerprint"`
+	AuthorizedKey     types.String `tfsdk:"authorized_key"`
+}
+
+// NewSSHPushKeysDataSource is a helper function to simplify the provider implementation.
+func NewSSHPushKeysDataSource() datasource.DataSource {
+	return &SSHPushKeysDataSource{}
+}
+
+// SSHPushKeysDataSource is the data source implementation.
+type SSHPushKeysDataSource struct {
+	client *uyuniClient
+}
+
+// Metadata returns the data source type name.
+func (d *SSHPushKeysDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_ssh_push_keys"
+}
+
+// Schema defines the schema for the data source.
+func (d *SSHPushKeysDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Downloads the public SSH keys used to manage systems with ssh-push, e.g. to authorize them in bastion hosts or images. " +
+			"Through a proxy, the server connects to the proxy with its key and the proxy to the systems with the key of the proxy.",
+		Attributes: map[string]schema.Attribute{
+			"proxy": schema.StringAttribute{
+				Description: "FQDN of the proxy systems are reached through. Omit it for systems the server connects to directly.",
+				Optional:    true,
+			},
+			"server_public_key": schema.StringAttribute{
+				Description: "Public key of the server in authorized_keys format.",
+				Computed:    true,
+			},
+			"server_fingerprint": schema.StringAttribute{
+				Description: "SHA256 fingerprint of the server key.",
+				Computed:    true,
+			},
+			"proxy_public_key": schema.StringAttribute{
+				Description: "Public key of the proxy in authorized_keys format, null without proxy.",
+				Computed:    true,
+			},
+			"proxy_fingerprint": schema.StringAttribute{
+				Description: "SHA256 fingerprint of the proxy key, null without proxy.",
+				Computed:    true,
+			},
+			"authorized_key": schema.StringAttribute{
+				Description: "Key to authorize on the systems: the proxy key with a proxy, the server key otherwise.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// downloadPublicKey downloads an SSH public key served as a static file and
+// returns it in authorized_keys format with its fingerprint.
+func downloadPublicKey(ctx context.Context, client *uyuniClient, keyURL string) (key, fingerprint string, err error) {
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, keyURL, nil)
+	if err != nil {
+		return "", "", err
+	}
+	httpResp, err := client.httpClient.Do(httpReq)
+	if err != nil {
+		return "", "", fmt.Errorf("could not download %s: %w", keyURL, err)
+	}
+	defer httpResp.Body.Close()
+	if httpResp.StatusCode != http.StatusOK {
+		return "", "", fmt.Errorf("downloading %s returned HTTP %d", keyURL, httpResp.StatusCode)
+	}
+	body, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return "", "", err
+	}
+
+	publicKey, comment, _, _, err := ssh.ParseAuthorizedKey(body)
+	if err != nil {
+		return "", "", fmt.Errorf("%s is not an SSH public key: %w", keyURL, err)
+	}
+	key = strings.TrimSpace(string(ssh.MarshalAuthorizedKey(publicKey)))
+	if comment != "" {
+		key += " " + comment
+	}
+	return key, ssh.FingerprintSHA256(publicKey), nil
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *SSHPushKeysDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state SSHPushKeysDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	server, err := url.Parse(d.client.baseURL)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to determine Uyuni server", err.Error())
+		return
+	}
+
+	// The keys are static files, they are not served through the API.
+	key, fingerprint, err := downloadPublicKey(ctx, d.client, fmt.Sprintf("%s://%s%s", server.Scheme, server.Host, serverSSHKeyPath))
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to Read server SSH key", err.Error())
+		return
+	}
+	state.ServerPublicKey = types.StringValue(key)
+	state.ServerFingerprint = types.StringValue(fingerprint)
+	state.AuthorizedKey = state.ServerPublicKey
+	state.ProxyPublicKey = types.StringNull()
+	state.ProxyFingerprint = types.StringNull()
+
+	if !state.Proxy.IsNull() {
+		key, fingerprint, err := downloadPublicKey(ctx, d.client, fmt.Sprintf("%s://%s%s", server.Scheme, state.Proxy.ValueString(), proxySSHKeyPath))
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Read proxy SSH key",
+				err.Error()+". Check that ssh-push was set up on the proxy with mgr-proxy-ssh-push-init.",
+			)
+			return
+		}
+		state.ProxyPublicKey = types.StringValue(key)
+		state.ProxyFingerprint = types.StringValue(fingerprint)
+		state.AuthorizedKey = state.ProxyPublicKey
+	}
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *SSHPushKeysDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*uyuniClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *uyuniClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
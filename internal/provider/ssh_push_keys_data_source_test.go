@@ -0,0 +1,128 @@
+package provider
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"golang.org/x/crypto/ssh"
+)
+
+func testSSHPublicKey(t *testing.T, comment string) (string, ssh.PublicKey) {
+	public, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, err := ssh.NewPublicKey(public)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return strings.TrimSpace(string(ssh.MarshalAuthorizedKey(key))) + " " + comment, key
+}
+
+func testReadSSHPushKeys(t *testing.T, client *uyuniClient, proxy *string) *datasource.ReadResponse {
+	ctx := context.Background()
+	d := NewSSHPushKeysDataSource()
+	d.(datasource.DataSourceWithConfigure).Configure(ctx, datasource.ConfigureRequest{ProviderData: client}, &datasource.ConfigureResponse{})
+	var schemaResp datasource.SchemaResponse
+	d.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+
+	var proxyValue interface{}
+	if proxy != nil {
+		proxyValue = *proxy
+	}
+	values := map[string]tftypes.Value{"proxy": tftypes.NewValue(tftypes.String, proxyValue)}
+	for _, name := range []string{"server_public_key", "server_fingerprint", "proxy_public_key", "proxy_fingerprint", "authorized_key"} {
+		values[name] = tftypes.NewValue(tftypes.String, nil)
+	}
+	config := tfsdk.Config{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), values),
+	}
+	resp := &datasource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: config.Raw}}
+	d.Read(ctx, datasource.ReadRequest{Config: config}, resp)
+	return resp
+}
+
+func TestSSHPushKeysDataSource(t *testing.T) {
+	serverKey, serverPublic := testSSHPublicKey(t, "mgr_ssh_id")
+	proxyKey, proxyPublic := testSSHPublicKey(t, "root@proxy")
+	client := testAPIClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case serverSSHKeyPath:
+			_, _ = w.Write([]byte(serverKey + "\n"))
+		case proxySSHKeyPath:
+			_, _ = w.Write([]byte(proxyKey + "\n"))
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	server, _ := url.Parse(client.baseURL)
+
+	resp := testReadSSHPushKeys(t, client, nil)
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+	var state SSHPushKeysDataSourceModel
+	resp.State.Get(context.Background(), &state)
+	if state.AuthorizedKey.ValueString() != serverKey || state.ServerFingerprint.ValueString() != ssh.FingerprintSHA256(serverPublic) {
+		t.Errorf("expected the server key, got %s (%s)", state.AuthorizedKey, state.ServerFingerprint)
+	}
+	if !state.ProxyPublicKey.IsNull() {
+		t.Errorf("expected no proxy key, got %s", state.ProxyPublicKey)
+	}
+
+	resp = testReadSSHPushKeys(t, client, &server.Host)
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+	resp.State.Get(context.Background(), &state)
+	if state.AuthorizedKey.ValueString() != proxyKey || state.ProxyFingerprint.ValueString() != ssh.FingerprintSHA256(proxyPublic) {
+		t.Errorf("expected the proxy key, got %s (%s)", state.AuthorizedKey, state.ProxyFingerprint)
+	}
+	if state.ServerPublicKey.ValueString() != serverKey {
+		t.Errorf("expected the server key, got %s", state.ServerPublicKey)
+	}
+}
+
+func TestSSHPushKeysDataSourceProxyWithoutSSHPush(t *testing.T) {
+	serverKey, _ := testSSHPublicKey(t, "mgr_ssh_id")
+	client := testAPIClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == serverSSHKeyPath {
+			_, _ = w.Write([]byte(serverKey))
+			return
+		}
+		_, _ = w.Write([]byte("<html>Not Found</html>"))
+	})
+	server, _ := url.Parse(client.baseURL)
+
+	resp := testReadSSHPushKeys(t, client, &server.Host)
+	if !resp.Diagnostics.HasError() || !strings.Contains(resp.Diagnostics[0].Detail(), "is not an SSH public key") {
+		t.Errorf("expected an error about the proxy key, got %v", resp.Diagnostics)
+	}
+}
+
+func TestAccSSHPushKeysDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccRealServerPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `data "uyuni_ssh_push_keys" "server" {}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.uyuni_ssh_push_keys.server", "server_public_key"),
+					resource.TestCheckResourceAttrSet("data.uyuni_ssh_push_keys.server", "server_fingerprint"),
+					resource.TestCheckResourceAttrPair("data.uyuni_ssh_push_keys.server", "authorized_key", "data.uyuni_ssh_push_keys.server", "server_public_key"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,117 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+)
+
+// stateMigration upgrades a JSON decoded state by one schema version. Numbers
+// are decoded as json.Number.
+type stateMigration func(state map[string]interface{}) error
+
+// stateMigrations lists the migrations of a resource, where the migration at
+// index i upgrades version i states to version i+1. Changing a schema in an
+// incompatible way only needs a new migration appended to the list.
+type stateMigrations []stateMigration
+
+// version returns the current schema version of the resource.
+func (m stateMigrations) version() int64 {
+	return int64(len(m))
+}
+
+// upgraders returns the state upgraders of every prior version. The framework
+// expects each of them to upgrade directly to the current version, so they
+// apply all migrations following their version in order.
+func (m stateMigrations) upgraders() map[int64]resource.StateUpgrader {
+	upgraders := map[int64]resource.StateUpgrader{}
+	for version := range m {
+		pending := m[version:]
+		upgraders[int64(version)] = resource.StateUpgrader{
+			StateUpgrader: func(_ context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				if req.RawState == nil || req.RawState.JSON == nil {
+					resp.Diagnostics.AddError(
+						"Unable to Upgrade Resource State",
+						"The prior state could not be read because it is not stored as JSON. Please report this issue to the provider developers.",
+					)
+					return
+				}
+
+				state, err := pending.apply(req.RawState.JSON)
+				if err != nil {
+					resp.Diagnostics.AddError(
+						"Unable to Upgrade Resource State",
+						fmt.Sprintf("Could not upgrade the state from schema version %d: %s", version, err.Error()),
+					)
+					return
+				}
+				resp.DynamicValue = &tfprotov6.DynamicValue{JSON: state}
+			},
+		}
+	}
+	return upgraders
+}
+
+// apply runs the migrations on a JSON encoded state.
+func (m stateMigrations) apply(raw []byte) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	// Keep large numeric IDs like system IDs intact.
+	decoder.UseNumber()
+
+	var state map[string]interface{}
+	if err := decoder.Decode(&state); err != nil {
+		return nil, err
+	}
+	for _, migrate := range m {
+		if err := migrate(state); err != nil {
+			return nil, err
+		}
+	}
+	return json.Marshal(state)
+}
+
+// migrateAddID sets the id attribute, which resources did not have in schema
+// version 0, to the attribute holding the natural key of the resource.
+func migrateAddID(keyAttribute string) stateMigration {
+	return func(state map[string]interface{}) error {
+		key, ok := state[keyAttribute]
+		if !ok || key == nil {
+			return fmt.Errorf("the state has no value for %s", keyAttribute)
+		}
+		state["id"] = fmt.Sprint(key)
+		return nil
+	}
+}
+
+// migrateRenameAttribute moves the value of an attribute to a new name.
+func migrateRenameAttribute(from, to string) stateMigration {
+	return func(state map[string]interface{}) error {
+		if value, ok := state[from]; ok {
+			state[to] = value
+			delete(state, from)
+		}
+		return nil
+	}
+}
+
+// migrateRemoveAttribute drops an attribute which no longer exists.
+func migrateRemoveAttribute(name string) stateMigration {
+	return func(state map[string]interface{}) error {
+		delete(state, name)
+		return nil
+	}
+}
+
+// migrateSetAttribute sets a new attribute to the value it has for existing objects.
+func migrateSetAttribute(name string, value interface{}) stateMigration {
+	return func(state map[string]interface{}) error {
+		if _, ok := state[name]; !ok {
+			state[name] = value
+		}
+		return nil
+	}
+}
@@ -0,0 +1,66 @@
+package provider
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestStateMigrationsApply(t *testing.T) {
+	migrations := stateMigrations{
+		migrateAddID("system_id"),
+		migrateRenameAttribute("enabled", "active"),
+		migrateRemoveAttribute("legacy"),
+	}
+	if migrations.version() != 3 {
+		t.Errorf("got version %d", migrations.version())
+	}
+
+	for version, raw := range map[int]string{
+		0: `{"system_id": 1000010000, "enabled": true, "legacy": "x"}`,
+		1: `{"id": "1000010000", "system_id": 1000010000, "enabled": true, "legacy": "x"}`,
+		2: `{"id": "1000010000", "system_id": 1000010000, "active": true, "legacy": "x"}`,
+	} {
+		upgraded, err := migrations[version:].apply([]byte(raw))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var state map[string]interface{}
+		if err := json.Unmarshal(upgraded, &state); err != nil {
+			t.Fatal(err)
+		}
+		want := map[string]interface{}{"id": "1000010000", "system_id": float64(1000010000), "active": true}
+		if !reflect.DeepEqual(state, want) {
+			t.Errorf("version %d: got %v, want %v", version, state, want)
+		}
+	}
+
+	if len(migrations.upgraders()) != 3 {
+		t.Error("expected an upgrader for every prior version")
+	}
+}
+
+func TestMigrateAddIDRequiresKey(t *testing.T) {
+	if err := migrateAddID("login")(map[string]interface{}{"login": nil}); err == nil {
+		t.Error("expected a state without the key attribute to be rejected")
+	}
+}
+
+func TestMigrateSetAttributeKeepsValues(t *testing.T) {
+	state := map[string]interface{}{"deletion_protection": true}
+	if err := migrateSetAttribute("deletion_protection", false)(state); err != nil {
+		t.Fatal(err)
+	}
+	if state["deletion_protection"] != true {
+		t.Error("expected an existing value to be kept")
+	}
+
+	state = map[string]interface{}{}
+	if err := migrateSetAttribute("deletion_protection", false)(state); err != nil {
+		t.Fatal(err)
+	}
+	if state["deletion_protection"] != false {
+		t.Error("expected the attribute to be added")
+	}
+}
@@ -0,0 +1,346 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"terraform-provider-uyuni/internal/validators"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource              = &supportDataResource{}
+	_ resource.ResourceWithConfigure = &supportDataResource{}
+)
+
+// NewSupportDataResource is a helper function to simplify the provider implementation.
+func NewSupportDataResource() resource.Resource {
+	return &supportDataResource{}
+}
+
+// supportDataResource is the resource implementation.
+type supportDataResource struct {
+	client *uyuniClient
+}
+
+// supportDataResourceModel maps the resource schema data.
+type supportDataResourceModel struct {
+	ID                 types.String   `tfsdk:"id"`
+	SystemID           types.Int64    `tfsdk:"system_id"`
+	CaseNumber         types.String   `tfsdk:"case_number"`
+	Parameters         types.String   `tfsdk:"parameters"`
+	UploadGeo          types.String   `tfsdk:"upload_geo"`
+	NotBefore          types.String   `tfsdk:"not_before"`
+	Wait               types.Bool     `tfsdk:"wait"`
+	Triggers           types.Map      `tfsdk:"triggers"`
+	EarliestOccurrence types.String   `tfsdk:"earliest_occurrence"`
+	ActionID           types.Int64    `tfsdk:"action_id"`
+	Status             types.String   `tfsdk:"status"`
+	CancelOnDestroy    types.Bool     `tfsdk:"cancel_on_destroy"`
+	Notify             *notifyModel   `tfsdk:"notify"`
+	ServerAlias        types.String   `tfsdk:"server_alias"`
+	Timeouts           timeouts.Value `tfsdk:"timeouts"`
+}
+
+// Metadata returns the resource type name.
+func (r *supportDataResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_supportdata"
+}
+
+// Schema defines the schema for the resource.
+func (r *supportDataResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Collects the support data of a system with supportconfig when created and uploads it to a " +
+			"support case at SUSE Customer Center. Change triggers, e.g. to an incident number, to collect it again. " +
+			"Destroying the resource does not remove uploaded data. The API cannot collect the support data of the " +
+			"server itself, run `mgradm support config` on it instead. Requires Uyuni 2024.10 or SUSE Manager 5.0.2.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "ID of the action.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"system_id": schema.Int64Attribute{
+				Description: "ID of the system to collect the support data of.",
+				Required:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"case_number": schema.StringAttribute{
+				Description: "Number of the support case at SUSE Customer Center the data is uploaded to.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"parameters": schema.StringAttribute{
+				Description: "Command line options of supportconfig, e.g. `-m` for a minimal collection. Defaults to none.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(""),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"upload_geo": schema.StringAttribute{
+				Description: "Region of SUSE Customer Center the data is uploaded to, `EU` or `US`. Defaults to `EU`.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString("EU"),
+				Validators: []validator.String{
+					stringvalidator.OneOf("EU", "US"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"not_before": schema.StringAttribute{
+				Description: "Earliest date the collection may start at, in RFC 3339 format, e.g. `2030-01-01T02:00:00Z`. " +
+					"Defaults to the time of apply.",
+				Optional: true,
+				Validators: []validator.String{
+					validators.Timestamp(),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"wait": schema.BoolAttribute{
+				Description: "Wait until the data was collected and uploaded. Defaults to true.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"triggers": schema.MapAttribute{
+				Description: "Arbitrary values which collect the support data again when they change.",
+				ElementType: types.StringType,
+				Optional:    true,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"cancel_on_destroy": cancelOnDestroyAttribute(),
+			"earliest_occurrence": schema.StringAttribute{
+				Description: "Date the collection was scheduled for, in RFC 3339 format.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"action_id": schema.Int64Attribute{
+				Description: "ID of the action.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"status": schema.StringAttribute{
+				Description: "Status of the action: `pending`, `completed` or `failed`. Pending statuses are refreshed.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"server_alias": serverAliasAttribute(),
+		},
+		Blocks: map[string]schema.Block{
+			"notify": notifyBlock(),
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+			}),
+		},
+	}
+}
+
+// Create schedules the collection and waits for it.
+func (r *supportDataResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan supportDataResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := resourceClient(ctx, r.client, plan.ServerAlias, nil, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	sid, caseNumber := plan.SystemID.ValueInt64(), plan.CaseNumber.ValueString()
+	earliest := time.Now()
+	if !plan.NotBefore.IsNull() {
+		var err error
+		if earliest, err = time.Parse(time.RFC3339, plan.NotBefore.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("not_before"), "Error collecting support data", err.Error())
+			return
+		}
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Collecting the support data of system %d for case %s", sid, caseNumber), map[string]interface{}{
+		"earliest": earliest.Format(time.RFC3339),
+	})
+	actionID, err := apiPost[int64](ctx, client, "system/scheduleSupportDataUpload", map[string]interface{}{
+		"sid":                sid,
+		"caseNumber":         caseNumber,
+		"parameter":          plan.Parameters.ValueString(),
+		"uploadGeo":          plan.UploadGeo.ValueString(),
+		"earliestOccurrence": apiDate(earliest),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error collecting support data",
+			fmt.Sprintf("Could not schedule the support data upload of system %d for case %s: %s", sid, caseNumber, err),
+		)
+		return
+	}
+
+	plan.ID = types.StringValue(strconv.FormatInt(actionID.Result, 10))
+	plan.ActionID = types.Int64Value(actionID.Result)
+	plan.EarliestOccurrence = types.StringValue(apiDate(earliest))
+	plan.Status = types.StringValue(actionStatusPending)
+
+	if plan.Wait.ValueBool() {
+		err := waitForAction(ctx, client, actionID.Result, sid)
+		plan.Status = types.StringValue(waitedActionStatus(err))
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error collecting support data",
+				fmt.Sprintf("Could not upload the support data of system %d: %s", sid, err),
+			)
+		} else {
+			tflog.Info(ctx, fmt.Sprintf("Uploaded the support data of system %d for case %s", sid, caseNumber))
+		}
+	}
+	plan.Notify.send(ctx, notification{
+		Resource:  "uyuni_supportdata",
+		ID:        plan.ID.ValueString(),
+		Status:    plan.Status.ValueString(),
+		ActionIDs: []int64{actionID.Result},
+		SystemIDs: []int64{sid},
+	}, &resp.Diagnostics)
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read keeps the state, the action is history once it ran. Only a pending
+// status is refreshed.
+func (r *supportDataResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state supportDataResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := resourceClient(ctx, r.client, state.ServerAlias, nil, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	status, err := refreshActionStatus(ctx, client, state.ActionID, state.Status)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Reading Uyuni support data collection", err.Error())
+		return
+	}
+	state.Status = status
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update only changes cancel_on_destroy, notify and timeouts, all other
+// changes collect the support data again.
+func (r *supportDataResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan supportDataResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete cancels the collection if it is still pending, unless
+// cancel_on_destroy is false. Uploaded data is not removed.
+func (r *supportDataResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state supportDataResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := resourceClient(ctx, r.client, state.ServerAlias, nil, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	if state.CancelOnDestroy.ValueBool() && state.Status.ValueString() == actionStatusPending {
+		if err := cancelPendingActions(ctx, client, []int64{state.ActionID.ValueInt64()}); err != nil {
+			resp.Diagnostics.AddError("Error Deleting Uyuni support data collection", err.Error())
+			return
+		}
+	}
+	tflog.Info(ctx, "Removing support data collection from state, uploaded data is not changed")
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *supportDataResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*uyuniClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *uyuniClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
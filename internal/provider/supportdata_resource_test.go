@@ -0,0 +1,108 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	acctest "github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestSupportDataSchedulesUpload(t *testing.T) {
+	ctx := context.Background()
+	actionPollInterval = time.Millisecond
+	t.Cleanup(func() { actionPollInterval = 10 * time.Second })
+
+	for name, tc := range map[string]struct {
+		version string
+		wait    bool
+		status  string
+		err     string
+	}{
+		"waits":         {version: "2024.10", wait: true, status: actionStatusCompleted},
+		"does not wait": {version: "5.0.2", status: actionStatusPending},
+		"too old":       {version: "5.0.1", wait: true, err: "requires Uyuni 2024.10 or SUSE Manager 5.0.2"},
+	} {
+		var scheduled map[string]interface{}
+		r := NewSupportDataResource()
+		client := testAPIClient(t, func(w http.ResponseWriter, req *http.Request) {
+			switch req.URL.Path {
+			case "/system/scheduleSupportDataUpload":
+				_ = json.NewDecoder(req.Body).Decode(&scheduled)
+				_, _ = w.Write([]byte(`{"success": true, "result": 4711}`))
+			case "/schedule/listCompletedSystems":
+				_, _ = w.Write([]byte(`{"success": true, "result": [{"server_id": 1000010001}]}`))
+			case "/schedule/listFailedSystems":
+				_, _ = w.Write([]byte(`{"success": true, "result": []}`))
+			default:
+				t.Errorf("%s: unexpected request %s", name, req.URL)
+			}
+		})
+		client.version, _ = parseServerVersion(tc.version)
+		testConfigure(t, r, client)
+
+		planned := testState(t, r, map[string]interface{}{
+			"system_id":         1000010001,
+			"case_number":       "01234567",
+			"parameters":        "-m",
+			"upload_geo":        "US",
+			"not_before":        "2030-01-01T02:00:00Z",
+			"wait":              tc.wait,
+			"cancel_on_destroy": true,
+		})
+		resp := &resource.CreateResponse{State: planned}
+		r.Create(ctx, resource.CreateRequest{Plan: tfsdk.Plan{Schema: planned.Schema, Raw: planned.Raw}}, resp)
+		if tc.err != "" {
+			if !resp.Diagnostics.HasError() || !strings.Contains(resp.Diagnostics.Errors()[0].Detail(), tc.err) || scheduled != nil {
+				t.Errorf("%s: expected %q without a request, got %v", name, tc.err, resp.Diagnostics)
+			}
+			continue
+		}
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("%s: %v", name, resp.Diagnostics)
+		}
+		if scheduled["sid"] != float64(1000010001) || scheduled["caseNumber"] != "01234567" || scheduled["parameter"] != "-m" ||
+			scheduled["uploadGeo"] != "US" || scheduled["earliestOccurrence"] != "2030-01-01T02:00:00Z" {
+			t.Errorf("%s: unexpected request body %v", name, scheduled)
+		}
+
+		var state supportDataResourceModel
+		resp.State.Get(ctx, &state)
+		if state.ActionID.ValueInt64() != 4711 || state.Status.ValueString() != tc.status {
+			t.Errorf("%s: unexpected state %v", name, state)
+		}
+	}
+}
+
+func TestAccSupportdataResource(t *testing.T) {
+	minion := os.Getenv("UYUNI_TEST_MINION_ID")
+	acctest.Test(t, acctest.TestCase{
+		PreCheck: func() {
+			testAccRealServerPreCheck(t)
+			testAccSkipUnlessEnv(t, "UYUNI_TEST_MINION_ID", "a registered Salt minion")
+			testAccSkipUnlessEnv(t, "UYUNI_TEST_SUPPORT_CASE", "a SUSE support case the support data may be uploaded to")
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []acctest.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "uyuni_supportdata" "test" {
+  system_id   = %s
+  case_number = %q
+}
+`, minion, os.Getenv("UYUNI_TEST_SUPPORT_CASE")),
+				Check: acctest.ComposeAggregateTestCheckFunc(
+					acctest.TestCheckResourceAttrSet("uyuni_supportdata.test", "action_id"),
+					acctest.TestCheckResourceAttr("uyuni_supportdata.test", "status", "completed"),
+				),
+			},
+		},
+	})
+}
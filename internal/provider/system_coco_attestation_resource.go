@@ -0,0 +1,322 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"terraform-provider-uyuni/internal/uyuni"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                 = &systemCocoAttestationResource{}
+	_ resource.ResourceWithConfigure    = &systemCocoAttestationResource{}
+	_ resource.ResourceWithImportState  = &systemCocoAttestationResource{}
+	_ resource.ResourceWithUpgradeState = &systemCocoAttestationResource{}
+)
+
+// NewSystemCocoAttestationResource is a helper function to simplify the provider implementation.
+func NewSystemCocoAttestationResource() resource.Resource {
+	return &systemCocoAttestationResource{}
+}
+
+// systemCocoAttestationResource is the resource implementation.
+type systemCocoAttestationResource struct {
+	client *uyuniClient
+}
+
+// systemCocoAttestationResourceModel maps the resource schema data.
+type systemCocoAttestationResourceModel struct {
+	ID                         types.String   `tfsdk:"id"`
+	SystemID                   types.Int64    `tfsdk:"system_id"`
+	Enabled                    types.Bool     `tfsdk:"enabled"`
+	EnvironmentType            types.String   `tfsdk:"environment_type"`
+	AttestOnBoot               types.Bool     `tfsdk:"attest_on_boot"`
+	DestroyInMaintenanceWindow types.Bool     `tfsdk:"destroy_in_maintenance_window"`
+	ServerAlias                types.String   `tfsdk:"server_alias"`
+	Org                        *orgModel      `tfsdk:"org"`
+	Timeouts                   timeouts.Value `tfsdk:"timeouts"`
+}
+
+// systemCocoAttestationStateMigrations upgrade states of prior schema versions.
+var systemCocoAttestationStateMigrations = stateMigrations{
+	// Version 0 had no id attribute.
+	migrateAddID("system_id"),
+}
+
+// Metadata returns the resource type name.
+func (r *systemCocoAttestationResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_system_coco_attestation"
+}
+
+// Schema defines the schema for the resource.
+func (r *systemCocoAttestationResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Version: systemCocoAttestationStateMigrations.version(),
+		Description: "Manages the confidential computing attestation settings of a system. " +
+			"Destroying the resource disables attestation for the system.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "ID of the system.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"system_id": schema.Int64Attribute{
+				Required: true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"enabled": schema.BoolAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(true),
+			},
+			"environment_type": schema.StringAttribute{
+				Description: "Attestation environment type, e.g. KVM_AMD_EPYC_MILAN or KVM_AMD_EPYC_GENOA.",
+				Required:    true,
+			},
+			"attest_on_boot": schema.BoolAttribute{
+				Description: "Schedule an attestation every time the system boots.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"destroy_in_maintenance_window": destroyInMaintenanceWindowAttribute(),
+			"server_alias":                  serverAliasAttribute(),
+		},
+		Blocks: map[string]schema.Block{
+			"org": orgBlock(),
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+// UpgradeState upgrades states of prior schema versions.
+func (r *systemCocoAttestationResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return systemCocoAttestationStateMigrations.upgraders()
+}
+
+// setConfig pushes the attestation settings of the given model to Uyuni.
+func (r *systemCocoAttestationResource) setConfig(ctx context.Context, client *uyuniClient, plan systemCocoAttestationResourceModel) error {
+	data := map[string]interface{}{
+		"sid":             plan.SystemID.ValueInt64(),
+		"enabled":         plan.Enabled.ValueBool(),
+		"environmentType": plan.EnvironmentType.ValueString(),
+		"attestOnBoot":    plan.AttestOnBoot.ValueBool(),
+	}
+	_, err := apiPost[int](ctx, client, "system/setCoCoAttestationConfig", data)
+	return err
+}
+
+// Create a new resource.
+func (r *systemCocoAttestationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan systemCocoAttestationResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	client := resourceClient(ctx, r.client, plan.ServerAlias, plan.Org, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("About to configure attestation for system %d", plan.SystemID.ValueInt64()))
+
+	if err := r.setConfig(ctx, client, plan); err != nil {
+		resp.Diagnostics.AddError(
+			"Error configuring attestation",
+			"Could not configure attestation: "+err.Error(),
+		)
+		return
+	}
+
+	plan.ID = types.StringValue(strconv.FormatInt(plan.SystemID.ValueInt64(), 10))
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read resource information.
+func (r *systemCocoAttestationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Get current state
+	var state systemCocoAttestationResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := resourceClient(ctx, r.client, state.ServerAlias, state.Org, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	config, err := apiGet[uyuni.CocoAttestationConfig](ctx, client, fmt.Sprintf("system/getCoCoAttestationConfig?sid=%d", state.SystemID.ValueInt64()))
+	if err != nil {
+		if handleNotFound(ctx, resp, err, fmt.Sprintf("System %d", state.SystemID.ValueInt64())) {
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Reading Uyuni attestation configuration",
+			fmt.Sprintf("Could not read attestation configuration of system %d: %s", state.SystemID.ValueInt64(), err.Error()),
+		)
+		return
+	}
+
+	state.Enabled = types.BoolValue(config.Result.Enabled)
+	state.EnvironmentType = types.StringValue(config.Result.EnvironmentType())
+	state.AttestOnBoot = types.BoolValue(config.Result.AttestOnBoot())
+
+	state.ID = types.StringValue(strconv.FormatInt(state.SystemID.ValueInt64(), 10))
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *systemCocoAttestationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Retrieve values from plan
+	var plan systemCocoAttestationResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	client := resourceClient(ctx, r.client, plan.ServerAlias, plan.Org, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	if err := r.setConfig(ctx, client, plan); err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating attestation",
+			"Could not update attestation configuration: "+err.Error(),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete disables attestation for the system.
+func (r *systemCocoAttestationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Retrieve values from state
+	var state systemCocoAttestationResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	client := resourceClient(ctx, r.client, state.ServerAlias, state.Org, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	sid := state.SystemID.ValueInt64()
+	if outsideMaintenanceWindow(ctx, client, state.DestroyInMaintenanceWindow, []int64{sid}, fmt.Sprintf("Attestation of system %d", sid), &resp.Diagnostics) {
+		return
+	}
+
+	state.Enabled = types.BoolValue(false)
+	state.AttestOnBoot = types.BoolValue(false)
+	if err := r.setConfig(ctx, client, state); err != nil && !isNotFoundError(err) {
+		resp.Diagnostics.AddError(
+			"Error disabling attestation",
+			"Could not disable attestation: "+err.Error(),
+		)
+		return
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *systemCocoAttestationResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*uyuniClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *uyuniClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// ImportState imports the attestation configuration of an existing system by its system ID.
+func (r *systemCocoAttestationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	systemID, err := parseImportInt64("system_id", req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Import ID", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("system_id"), systemID)...)
+}
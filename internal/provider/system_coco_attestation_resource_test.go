@@ -0,0 +1,52 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	acctest "github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccSystemCocoAttestationResource(t *testing.T) {
+	systemID := os.Getenv("UYUNI_TEST_SYSTEM_ID")
+	acctest.Test(t, acctest.TestCase{
+		PreCheck: func() {
+			testAccRealServerPreCheck(t)
+			testAccSkipUnlessEnv(t, "UYUNI_TEST_SYSTEM_ID", "a registered confidential computing guest")
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []acctest.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "uyuni_system_coco_attestation" "test" {
+  system_id        = %s
+  environment_type = "KVM_AMD_EPYC_GENOA"
+}
+`, systemID),
+				Check: acctest.TestCheckResourceAttr("uyuni_system_coco_attestation.test", "enabled", "true"),
+			},
+			{
+				ResourceName:                         "uyuni_system_coco_attestation.test",
+				ImportState:                          true,
+				ImportStateId:                        systemID,
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "system_id",
+			},
+		},
+	})
+}
+
+func TestSystemCocoAttestationResourceSchema(t *testing.T) {
+	var resp resource.SchemaResponse
+	NewSystemCocoAttestationResource().Schema(context.Background(), resource.SchemaRequest{}, &resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected schema diagnostics: %v", resp.Diagnostics)
+	}
+	if diags := resp.Schema.ValidateImplementation(context.Background()); diags.HasError() {
+		t.Fatalf("invalid schema implementation: %v", diags)
+	}
+}
@@ -0,0 +1,196 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"terraform-provider-uyuni/internal/uyuni"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &SystemCountByChannelDataSource{}
+	_ datasource.DataSourceWithConfigure = &SystemCountByChannelDataSource{}
+)
+
+// SystemCountByChannelDataSourceModel maps the data source schema data.
+type SystemCountByChannelDataSourceModel struct {
+	IncludeChildChannels types.Bool          `tfsdk:"include_child_channels"`
+	Counts               types.Map           `tfsdk:"counts"`
+	TotalSystems         types.Int64         `tfsdk:"total_systems"`
+	Channels             []channelCountModel `tfsdk:"channels"`
+}
+
+// channelCountModel maps the system count of a channel.
+type channelCountModel struct {
+	Label       types.String `tfsdk:"label"`
+	Name        types.String `tfsdk:"name"`
+	ParentLabel types.String `tfsdk:"parent_label"`
+	Arch        types.String `tfsdk:"arch"`
+	Systems     types.Int64  `tfsdk:"systems"`
+}
+
+// NewSystemCountByChannelDataSource is a helper function to simplify the provider implementation.
+func NewSystemCountByChannelDataSource() datasource.DataSource {
+	return &SystemCountByChannelDataSource{}
+}
+
+// SystemCountByChannelDataSource is the data source implementation.
+type SystemCountByChannelDataSource struct {
+	client *uyuniClient
+}
+
+// Metadata returns the data source type name.
+func (d *SystemCountByChannelDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_system_count_by_channel"
+}
+
+// Schema defines the schema for the data source.
+func (d *SystemCountByChannelDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Counts the systems subscribed to each base channel, i.e. to each product, e.g. for capacity and " +
+			"licensing dashboards, without listing the systems. Only channels and systems the provider user can see are counted.",
+		Attributes: map[string]schema.Attribute{
+			"include_child_channels": schema.BoolAttribute{
+				Description: "Count the systems of child channels too. As systems subscribe to child channels in addition " +
+					"to their base channel, total_systems then counts systems more than once. Defaults to false.",
+				Optional: true,
+			},
+			"counts": schema.MapAttribute{
+				Description: "Number of subscribed systems by channel label.",
+				ElementType: types.Int64Type,
+				Computed:    true,
+			},
+			"total_systems": schema.Int64Attribute{
+				Description: "Sum of the counts. Without child channels, the number of systems with a base channel.",
+				Computed:    true,
+			},
+			"channels": schema.ListNestedAttribute{
+				Description: "Counted channels, ordered by label.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"label": schema.StringAttribute{
+							Description: "Label of the channel.",
+							Computed:    true,
+						},
+						"name": schema.StringAttribute{
+							Description: "Name of the channel.",
+							Computed:    true,
+						},
+						"parent_label": schema.StringAttribute{
+							Description: "Label of the base channel of a child channel, null for base channels.",
+							Computed:    true,
+						},
+						"arch": schema.StringAttribute{
+							Description: "Architecture of the channel, e.g. `x86_64`.",
+							Computed:    true,
+						},
+						"systems": schema.Int64Attribute{
+							Description: "Number of systems subscribed to the channel.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *SystemCountByChannelDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state SystemCountByChannelDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// channel.listAllChannels has the counts, channel.listSoftwareChannels
+	// the parents.
+	counts, err := apiGet[[]uyuni.OrgChannel](ctx, d.client, "channel/listAllChannels")
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Uyuni system counts",
+			"Could not list channels: "+err.Error(),
+		)
+		return
+	}
+	channels, err := apiGet[[]uyuni.SoftwareChannel](ctx, d.client, "channel/listSoftwareChannels")
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Uyuni system counts",
+			"Could not list software channels: "+err.Error(),
+		)
+		return
+	}
+	parents := map[string]string{}
+	for _, channel := range channels.Result {
+		parents[channel.Label] = channel.ParentLabel
+	}
+
+	sort.Slice(counts.Result, func(i, j int) bool {
+		return counts.Result[i].Label < counts.Result[j].Label
+	})
+	byLabel := map[string]int64{}
+	total := int64(0)
+	state.Channels = make([]channelCountModel, 0, len(counts.Result))
+	for _, channel := range counts.Result {
+		parent := parents[channel.Label]
+		if parent != "" && !state.IncludeChildChannels.ValueBool() {
+			continue
+		}
+		parentLabel := types.StringNull()
+		if parent != "" {
+			parentLabel = types.StringValue(parent)
+		}
+		byLabel[channel.Label] = int64(channel.Systems)
+		total += int64(channel.Systems)
+		state.Channels = append(state.Channels, channelCountModel{
+			Label:       types.StringValue(channel.Label),
+			Name:        types.StringValue(channel.Name),
+			ParentLabel: parentLabel,
+			Arch:        types.StringValue(channel.ArchName),
+			Systems:     types.Int64Value(int64(channel.Systems)),
+		})
+	}
+	state.TotalSystems = types.Int64Value(total)
+	state.Counts, diags = types.MapValueFrom(ctx, types.Int64Type, byLabel)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *SystemCountByChannelDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*uyuniClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *uyuniClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
@@ -0,0 +1,84 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestSystemCountByChannelDataSource(t *testing.T) {
+	ctx := context.Background()
+	client := testAPIClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/channel/listAllChannels":
+			_, _ = w.Write([]byte(`{"success": true, "result": [
+				{"label": "sles15-sp6-pool", "name": "SLES 15 SP6 Pool", "systems": 12, "arch_name": "x86_64"},
+				{"label": "sles15-sp6-updates", "name": "SLES 15 SP6 Updates", "systems": 12, "arch_name": "x86_64"},
+				{"label": "rhel9-pool", "name": "RHEL 9 Pool", "systems": 3, "arch_name": "x86_64"}
+			]}`))
+		case "/channel/listSoftwareChannels":
+			_, _ = w.Write([]byte(`{"success": true, "result": [
+				{"label": "sles15-sp6-pool", "parent_label": ""},
+				{"label": "sles15-sp6-updates", "parent_label": "sles15-sp6-pool"},
+				{"label": "rhel9-pool", "parent_label": ""}
+			]}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL)
+		}
+	})
+
+	d := NewSystemCountByChannelDataSource()
+	d.(datasource.DataSourceWithConfigure).Configure(ctx, datasource.ConfigureRequest{ProviderData: client}, &datasource.ConfigureResponse{})
+	var schemaResp datasource.SchemaResponse
+	d.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	values := map[string]tftypes.Value{}
+	for name, attributeType := range objectType.AttributeTypes {
+		values[name] = tftypes.NewValue(attributeType, nil)
+	}
+	config := tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, values)}
+	resp := &datasource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: config.Raw}}
+	d.Read(ctx, datasource.ReadRequest{Config: config}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+
+	var state SystemCountByChannelDataSourceModel
+	resp.State.Get(ctx, &state)
+	if state.TotalSystems.ValueInt64() != 15 {
+		t.Errorf("expected 15 systems, got %s", state.TotalSystems)
+	}
+	if len(state.Channels) != 2 || state.Channels[0].Label.ValueString() != "rhel9-pool" || !state.Channels[1].ParentLabel.IsNull() {
+		t.Errorf("expected the base channels by label, got %v", state.Channels)
+	}
+	if len(state.Counts.Elements()) != 2 {
+		t.Errorf("expected 2 counts, got %s", state.Counts)
+	}
+}
+
+func TestAccSystemCountByChannelDataSource(t *testing.T) {
+	channel := os.Getenv("UYUNI_TEST_MINION_BASE_CHANNEL")
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccRealServerPreCheck(t)
+			testAccSkipUnlessEnv(t, "UYUNI_TEST_MINION_BASE_CHANNEL", "the base channel of a registered Salt minion")
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `data "uyuni_system_count_by_channel" "all" {}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.uyuni_system_count_by_channel.all", "counts."+channel),
+					resource.TestCheckResourceAttrSet("data.uyuni_system_count_by_channel.all", "total_systems"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,382 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &systemCustomValuesResource{}
+	_ resource.ResourceWithConfigure   = &systemCustomValuesResource{}
+	_ resource.ResourceWithImportState = &systemCustomValuesResource{}
+	_ resource.ResourceWithModifyPlan  = &systemCustomValuesResource{}
+)
+
+// NewSystemCustomValuesResource is a helper function to simplify the provider implementation.
+func NewSystemCustomValuesResource() resource.Resource {
+	return &systemCustomValuesResource{}
+}
+
+// systemCustomValuesResource is the resource implementation.
+type systemCustomValuesResource struct {
+	client *uyuniClient
+}
+
+// systemCustomValuesResourceModel maps the resource schema data.
+type systemCustomValuesResourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	SystemID    types.Int64  `tfsdk:"system_id"`
+	Values      types.Map    `tfsdk:"values"`
+	AllValues   types.Map    `tfsdk:"all_values"`
+	ServerAlias types.String `tfsdk:"server_alias"`
+}
+
+// Metadata returns the resource type name.
+func (r *systemCustomValuesResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_system_custom_values"
+}
+
+// Schema defines the schema for the resource.
+func (r *systemCustomValuesResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Sets custom values of a system, merged with the default_custom_values of the provider. " +
+			"The keys must exist on the server. Custom values of other keys are left alone.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "ID of the system.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"system_id": schema.Int64Attribute{
+				Description: "ID of the system.",
+				Required:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"values": schema.MapAttribute{
+				Description: "Custom values by key. They override the default_custom_values of the provider.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"all_values": schema.MapAttribute{
+				Description: "Custom values set on the system: the default_custom_values of the provider merged with values.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"server_alias": serverAliasAttribute(),
+		},
+	}
+}
+
+// stringMap returns the elements of a map of strings.
+func stringMap(ctx context.Context, m types.Map) (map[string]string, error) {
+	values := map[string]string{}
+	if m.IsNull() || m.IsUnknown() {
+		return values, nil
+	}
+	if diags := m.ElementsAs(ctx, &values, false); diags.HasError() {
+		return nil, fmt.Errorf("invalid map of strings")
+	}
+	return values, nil
+}
+
+// mergedValues returns the default custom values of the client overridden by
+// the values of the model.
+func (m *systemCustomValuesResourceModel) mergedValues(ctx context.Context, client *uyuniClient) (map[string]string, error) {
+	values, err := stringMap(ctx, m.Values)
+	if err != nil {
+		return nil, err
+	}
+	merged := map[string]string{}
+	for key, value := range client.defaultCustomValues {
+		merged[key] = value
+	}
+	for key, value := range values {
+		merged[key] = value
+	}
+	return merged, nil
+}
+
+// ModifyPlan plans all_values from the default custom values of the provider,
+// so that changing them shows as a change of every system.
+func (r *systemCustomValuesResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to plan on destroy or before the provider is configured.
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	var plan systemCustomValuesResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() || plan.Values.IsUnknown() {
+		return
+	}
+
+	merged, err := plan.mergedValues(ctx, r.client)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("values"), "Invalid custom values", err.Error())
+		return
+	}
+	allValues, diags := types.MapValueFrom(ctx, types.StringType, merged)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("all_values"), allValues)...)
+}
+
+// setCustomValues sets the values on the system and deletes the custom values
+// of the keys in remove.
+func setCustomValues(ctx context.Context, client *uyuniClient, sid int64, values map[string]string, remove []string) error {
+	if len(remove) > 0 {
+		sort.Strings(remove)
+		_, err := apiPost[int](ctx, client, "system/deleteCustomValues", map[string]interface{}{
+			"sid":  sid,
+			"keys": remove,
+		})
+		if err != nil {
+			return fmt.Errorf("could not delete custom values %v: %w", remove, err)
+		}
+	}
+	if len(values) > 0 {
+		_, err := apiPost[int](ctx, client, "system/setCustomValues", map[string]interface{}{
+			"sid":    sid,
+			"values": values,
+		})
+		if err != nil {
+			return fmt.Errorf("could not set custom values: %w", err)
+		}
+	}
+	return nil
+}
+
+// Create a new resource.
+func (r *systemCustomValuesResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan systemCustomValuesResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := resourceClient(ctx, r.client, plan.ServerAlias, nil, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	sid := plan.SystemID.ValueInt64()
+	merged, err := plan.mergedValues(ctx, client)
+	if err == nil {
+		err = setCustomValues(ctx, client, sid, merged, nil)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error setting custom values",
+			fmt.Sprintf("Could not set custom values of system %d: %s", sid, err),
+		)
+		return
+	}
+	plan.ID = types.StringValue(strconv.FormatInt(sid, 10))
+	plan.AllValues, diags = types.MapValueFrom(ctx, types.StringType, merged)
+	resp.Diagnostics.Append(diags...)
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read resource information. Only the keys in all_values are read, all keys
+// of the system when imported.
+func (r *systemCustomValuesResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Get current state
+	var state systemCustomValuesResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := resourceClient(ctx, r.client, state.ServerAlias, nil, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	sid := state.SystemID.ValueInt64()
+	current, err := apiGet[map[string]string](ctx, client, fmt.Sprintf("system/getCustomValues?sid=%d", sid))
+	if err != nil {
+		if handleNotFound(ctx, resp, err, fmt.Sprintf("System %d", sid)) {
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Reading Uyuni custom values",
+			fmt.Sprintf("Could not read custom values of system %d: %s", sid, err),
+		)
+		return
+	}
+
+	imported := state.AllValues.IsNull()
+	managed, err := stringMap(ctx, state.AllValues)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Reading Uyuni custom values", err.Error())
+		return
+	}
+	configured, err := stringMap(ctx, state.Values)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Reading Uyuni custom values", err.Error())
+		return
+	}
+	if imported {
+		// All custom values of an imported system are managed, those equal
+		// to the defaults of the provider are left out of values.
+		managed = current.Result
+		configured = map[string]string{}
+		for key, value := range current.Result {
+			if defaultValue, ok := client.defaultCustomValues[key]; !ok || value != defaultValue {
+				configured[key] = value
+			}
+		}
+	}
+
+	// Values changed or deleted on the server show as a change.
+	read := func(keys map[string]string) map[string]string {
+		values := map[string]string{}
+		for key := range keys {
+			if value, ok := current.Result[key]; ok {
+				values[key] = value
+			}
+		}
+		return values
+	}
+	state.ID = types.StringValue(strconv.FormatInt(sid, 10))
+	state.AllValues, diags = types.MapValueFrom(ctx, types.StringType, read(managed))
+	resp.Diagnostics.Append(diags...)
+	if values := read(configured); !state.Values.IsNull() || (imported && len(values) > 0) {
+		state.Values, diags = types.MapValueFrom(ctx, types.StringType, values)
+		resp.Diagnostics.Append(diags...)
+	}
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *systemCustomValuesResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Retrieve values from plan and state
+	var plan, state systemCustomValuesResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := resourceClient(ctx, r.client, plan.ServerAlias, nil, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	sid := state.SystemID.ValueInt64()
+	merged, err := plan.mergedValues(ctx, client)
+	var current map[string]string
+	if err == nil {
+		current, err = stringMap(ctx, state.AllValues)
+	}
+	if err == nil {
+		remove := []string{}
+		for key := range current {
+			if _, ok := merged[key]; !ok {
+				remove = append(remove, key)
+			}
+		}
+		err = setCustomValues(ctx, client, sid, merged, remove)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating custom values",
+			fmt.Sprintf("Could not update custom values of system %d: %s", sid, err),
+		)
+		return
+	}
+	plan.ID = state.ID
+	allValues, diags := types.MapValueFrom(ctx, types.StringType, merged)
+	resp.Diagnostics.Append(diags...)
+	plan.AllValues = allValues
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete deletes the custom values of all_values from the system.
+func (r *systemCustomValuesResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Retrieve values from state
+	var state systemCustomValuesResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := resourceClient(ctx, r.client, state.ServerAlias, nil, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	sid := state.SystemID.ValueInt64()
+	current, err := stringMap(ctx, state.AllValues)
+	if err == nil {
+		keys := make([]string, 0, len(current))
+		for key := range current {
+			keys = append(keys, key)
+		}
+		err = setCustomValues(ctx, client, sid, nil, keys)
+	}
+	if err != nil && !isNotFoundError(err) {
+		resp.Diagnostics.AddError(
+			"Error Deleting Uyuni custom values",
+			fmt.Sprintf("Could not delete custom values of system %d: %s", sid, err),
+		)
+		return
+	}
+}
+
+// ImportState imports all custom values of a system by its ID.
+func (r *systemCustomValuesResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	sid, err := parseImportInt64("system_id", req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Import ID", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("system_id"), sid)...)
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *systemCustomValuesResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*uyuniClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *uyuniClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
@@ -0,0 +1,143 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	acctest "github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestSystemCustomValuesMergesProviderDefaults(t *testing.T) {
+	ctx := context.Background()
+	var set []map[string]string
+	var deleted []string
+	client := testAPIClient(t, func(w http.ResponseWriter, req *http.Request) {
+		var body struct {
+			Values map[string]string `json:"values"`
+			Keys   []string          `json:"keys"`
+		}
+		_ = json.NewDecoder(req.Body).Decode(&body)
+		switch req.URL.Path {
+		case "/system/setCustomValues":
+			set = append(set, body.Values)
+		case "/system/deleteCustomValues":
+			deleted = append(deleted, body.Keys...)
+		default:
+			t.Errorf("unexpected request %s", req.URL)
+		}
+		_, _ = w.Write([]byte(`{"success": true, "result": 1}`))
+	})
+	client.defaultCustomValues = map[string]string{"owner": "platform", "env": "prod"}
+	r := NewSystemCustomValuesResource()
+	testConfigure(t, r, client)
+
+	values, _ := types.MapValueFrom(ctx, types.StringType, map[string]string{"env": "dev"})
+	planned := testState(t, r, map[string]interface{}{
+		"system_id": int64(1000010001),
+		"values":    values,
+	})
+	createResp := &resource.CreateResponse{State: planned}
+	r.Create(ctx, resource.CreateRequest{Plan: tfsdk.Plan{Schema: planned.Schema, Raw: planned.Raw}}, createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatal(createResp.Diagnostics)
+	}
+	if want := []map[string]string{{"owner": "platform", "env": "dev"}}; !reflect.DeepEqual(set, want) {
+		t.Errorf("expected %v to be set, got %v", want, set)
+	}
+
+	// Dropping the override restores the default, dropping a default deletes
+	// its value.
+	delete(client.defaultCustomValues, "owner")
+	set = nil
+	plan := testState(t, r, map[string]interface{}{
+		"system_id": int64(1000010001),
+		"values":    types.MapNull(types.StringType),
+	})
+	updateResp := &resource.UpdateResponse{State: plan}
+	r.Update(ctx, resource.UpdateRequest{Plan: tfsdk.Plan{Schema: plan.Schema, Raw: plan.Raw}, State: createResp.State}, updateResp)
+	if updateResp.Diagnostics.HasError() {
+		t.Fatal(updateResp.Diagnostics)
+	}
+	if fmt.Sprint(deleted) != "[owner]" || !reflect.DeepEqual(set, []map[string]string{{"env": "prod"}}) {
+		t.Errorf("unexpected changes: deleted %v, set %v", deleted, set)
+	}
+}
+
+func TestSystemCustomValuesImportLeavesDefaultsOutOfValues(t *testing.T) {
+	ctx := context.Background()
+	client := testAPIClient(t, func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/system/getCustomValues" || req.URL.Query().Get("sid") != "1000010001" {
+			t.Errorf("unexpected request %s", req.URL)
+		}
+		_, _ = w.Write([]byte(`{"success": true, "result": {"owner": "platform", "env": "dev"}}`))
+	})
+	client.defaultCustomValues = map[string]string{"owner": "platform", "env": "prod"}
+
+	resp := testRead(t, NewSystemCustomValuesResource(), client, map[string]interface{}{
+		"system_id": int64(1000010001),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+	var state systemCustomValuesResourceModel
+	resp.State.Get(ctx, &state)
+	values, _ := stringMap(ctx, state.Values)
+	allValues, _ := stringMap(ctx, state.AllValues)
+	if !reflect.DeepEqual(values, map[string]string{"env": "dev"}) || len(allValues) != 2 {
+		t.Errorf("unexpected values %v of %v", values, allValues)
+	}
+}
+
+func TestAccSystemCustomValuesResource(t *testing.T) {
+	minion := os.Getenv("UYUNI_TEST_MINION_ID")
+	acctest.Test(t, acctest.TestCase{
+		PreCheck: func() {
+			testAccRealServerPreCheck(t)
+			testAccSkipUnlessEnv(t, "UYUNI_TEST_MINION_ID", "a registered Salt minion")
+			testAccSeed(t, "system/custominfo/createKey", map[string]interface{}{
+				"keyLabel":       "tfacc_role",
+				"keyDescription": "Key of the acceptance tests",
+			}, "system/custominfo/deleteKey", map[string]interface{}{"keyLabel": "tfacc_role"})
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []acctest.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccSystemCustomValuesResourceConfig(minion, "web"),
+				Check:  acctest.TestCheckResourceAttr("uyuni_system_custom_values.test", "all_values.tfacc_role", "web"),
+			},
+			// Update and Read testing
+			{
+				Config: testAccSystemCustomValuesResourceConfig(minion, "db"),
+				Check:  acctest.TestCheckResourceAttr("uyuni_system_custom_values.test", "values.tfacc_role", "db"),
+			},
+			// ImportState testing
+			{
+				ResourceName:                         "uyuni_system_custom_values.test",
+				ImportState:                          true,
+				ImportStateId:                        minion,
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "system_id",
+			},
+		},
+	})
+}
+
+func testAccSystemCustomValuesResourceConfig(systemID, role string) string {
+	return fmt.Sprintf(`
+resource "uyuni_system_custom_values" "test" {
+  system_id = %s
+  values = {
+    tfacc_role = %q
+  }
+}
+`, systemID, role)
+}
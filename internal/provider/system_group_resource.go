@@ -0,0 +1,562 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+
+	"terraform-provider-uyuni/internal/uyuni"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &systemGroupResource{}
+	_ resource.ResourceWithConfigure   = &systemGroupResource{}
+	_ resource.ResourceWithImportState = &systemGroupResource{}
+)
+
+// NewSystemGroupResource is a helper function to simplify the provider implementation.
+func NewSystemGroupResource() resource.Resource {
+	return &systemGroupResource{}
+}
+
+// systemGroupResource is the resource implementation.
+type systemGroupResource struct {
+	client *uyuniClient
+}
+
+// systemGroupResourceModel maps the resource schema data.
+type systemGroupResourceModel struct {
+	ID             types.String   `tfsdk:"id"`
+	Name           types.String   `tfsdk:"name"`
+	Description    types.String   `tfsdk:"description"`
+	Metadata       types.Map      `tfsdk:"metadata"`
+	GroupID        types.Int64    `tfsdk:"group_id"`
+	SystemCount    types.Int64    `tfsdk:"system_count"`
+	Administrators types.Set      `tfsdk:"administrators"`
+	SystemIDs      types.Set      `tfsdk:"system_ids"`
+	ServerAlias    types.String   `tfsdk:"server_alias"`
+	Org            *orgModel      `tfsdk:"org"`
+	Timeouts       timeouts.Value `tfsdk:"timeouts"`
+}
+
+// Metadata returns the resource type name.
+func (r *systemGroupResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_system_group"
+}
+
+// Schema defines the schema for the resource.
+func (r *systemGroupResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a system group with its administrators and, optionally, its systems. " +
+			"Deleting the group leaves its systems registered.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Name of the group.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "Name of the group. The API cannot rename groups, changing it replaces the group.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"description": schema.StringAttribute{
+				Description: "Description of the group.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(""),
+			},
+			"metadata": schema.MapAttribute{
+				Description: "Metadata of the group, e.g. its owner or cost center for dashboards. The API has no " +
+					"attributes for it, so it is kept in a block starting with `" + groupMetadataHeader + "` at the " +
+					"end of the description on the server, a line `key = value` per entry, where other tools can read it.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators: []validator.Map{
+					mapvalidator.KeysAre(stringvalidator.RegexMatches(regexp.MustCompile(`^[^\s=]+$`), "must not contain whitespace or =")),
+					mapvalidator.ValueStringsAre(stringvalidator.RegexMatches(regexp.MustCompile(`^[^\n]*$`), "must be a single line")),
+				},
+			},
+			"group_id": schema.Int64Attribute{
+				Description: "ID of the group.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"system_count": schema.Int64Attribute{
+				Description: "Number of systems in the group, including those added outside of Terraform.",
+				Computed:    true,
+			},
+			"administrators": schema.SetAttribute{
+				Description: "Logins of the users administering the group, other administrators are removed. " +
+					"Organization administrators administer all groups whether listed or not. " +
+					"Unset leaves the administrators alone.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"system_ids": schema.SetAttribute{
+				Description: "IDs of the systems in the group, other systems are removed from it. " +
+					"Unset leaves the systems alone, e.g. when activation keys add them.",
+				ElementType: types.Int64Type,
+				Optional:    true,
+			},
+			"server_alias": serverAliasAttribute(),
+		},
+		Blocks: map[string]schema.Block{
+			"org": orgBlock(),
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+// groupMetadataHeader starts the block at the end of the description of a
+// group holding its metadata.
+const groupMetadataHeader = "[metadata]"
+
+// storedDescription returns the description of the model as stored on the
+// server, with the metadata block appended.
+func (m *systemGroupResourceModel) storedDescription(ctx context.Context) (string, error) {
+	description := m.Description.ValueString()
+	if m.Metadata.IsNull() || len(m.Metadata.Elements()) == 0 {
+		return description, nil
+	}
+	var metadata map[string]string
+	if diags := m.Metadata.ElementsAs(ctx, &metadata, false); diags.HasError() {
+		return "", fmt.Errorf("invalid metadata: %v", diags)
+	}
+	keys := make([]string, 0, len(metadata))
+	for key := range metadata {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	lines := []string{groupMetadataHeader}
+	for _, key := range keys {
+		lines = append(lines, key+" = "+metadata[key])
+	}
+	block := strings.Join(lines, "\n")
+	if description == "" {
+		return block, nil
+	}
+	return description + "\n\n" + block, nil
+}
+
+// splitGroupDescription splits a description stored on the server into the
+// description and the metadata, which is nil if there is no metadata block.
+func splitGroupDescription(stored string) (string, map[string]string) {
+	description, block := "", ""
+	if strings.HasPrefix(stored, groupMetadataHeader+"\n") {
+		block = stored
+	} else if i := strings.LastIndex(stored, "\n\n"+groupMetadataHeader+"\n"); i >= 0 {
+		description, block = stored[:i], stored[i+2:]
+	} else {
+		return stored, nil
+	}
+	metadata := map[string]string{}
+	for _, line := range strings.Split(block, "\n")[1:] {
+		key, value, ok := strings.Cut(line, " = ")
+		if !ok {
+			// Not written by the provider, so it is part of the description.
+			return stored, nil
+		}
+		metadata[key] = value
+	}
+	return description, metadata
+}
+
+// setDescription sets the description and, if it is managed or on the
+// server, the metadata from the description stored on the server.
+func (m *systemGroupResourceModel) setDescription(ctx context.Context, stored string) diag.Diagnostics {
+	description, metadata := splitGroupDescription(stored)
+	m.Description = types.StringValue(description)
+	if metadata == nil && m.Metadata.IsNull() {
+		return nil
+	}
+	if metadata == nil {
+		metadata = map[string]string{}
+	}
+	var diags diag.Diagnostics
+	m.Metadata, diags = types.MapValueFrom(ctx, types.StringType, metadata)
+	return diags
+}
+
+// listGroupAdministrators returns the logins of the administrators of the
+// group.
+func listGroupAdministrators(ctx context.Context, client *uyuniClient, group string) ([]string, error) {
+	users, err := apiGet[[]uyuni.User](ctx, client, "systemgroup/listAdministrators?systemGroupName="+url.QueryEscape(group))
+	if err != nil {
+		return nil, err
+	}
+	logins := make([]string, 0, len(users.Result))
+	for _, user := range users.Result {
+		logins = append(logins, user.Login)
+	}
+	return logins, nil
+}
+
+// listGroupSystems returns the IDs of the systems of the group.
+func listGroupSystems(ctx context.Context, client *uyuniClient, group string) ([]int64, error) {
+	systems, err := apiGet[[]uyuni.ShortSystem](ctx, client, "systemgroup/listSystemsMinimal?systemGroupName="+url.QueryEscape(group))
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]int64, 0, len(systems.Result))
+	for _, system := range systems.Result {
+		ids = append(ids, int64(system.ID))
+	}
+	return ids, nil
+}
+
+// changeMembers brings the administrators and systems of the group that are
+// set in the model in line with the server.
+func (m *systemGroupResourceModel) changeMembers(ctx context.Context, client *uyuniClient) error {
+	group := m.Name.ValueString()
+	if !m.Administrators.IsNull() {
+		wanted, err := stringSet(ctx, m.Administrators)
+		if err != nil {
+			return err
+		}
+		current, err := listGroupAdministrators(ctx, client, group)
+		if err != nil {
+			return fmt.Errorf("could not list administrators: %w", err)
+		}
+		add, remove := setDiff(current, wanted)
+		for _, change := range []struct {
+			logins []string
+			add    int
+		}{{add, 1}, {remove, 0}} {
+			if len(change.logins) == 0 {
+				continue
+			}
+			sort.Strings(change.logins)
+			_, err := apiPost[int](ctx, client, "systemgroup/addOrRemoveAdmins", map[string]interface{}{
+				"systemGroupName": group,
+				"loginName":       change.logins,
+				"add":             change.add,
+			})
+			if err != nil {
+				return fmt.Errorf("could not change administrators %v: %w", change.logins, err)
+			}
+		}
+	}
+	if !m.SystemIDs.IsNull() {
+		wanted, err := int64Set(ctx, m.SystemIDs)
+		if err != nil {
+			return err
+		}
+		current, err := listGroupSystems(ctx, client, group)
+		if err != nil {
+			return fmt.Errorf("could not list systems: %w", err)
+		}
+		add, remove := setDiff(current, wanted)
+		tflog.Info(ctx, fmt.Sprintf("Group %s: adding %d and removing %d systems", group, len(add), len(remove)))
+		for _, change := range []struct {
+			ids []int64
+			add bool
+		}{{add, true}, {remove, false}} {
+			if len(change.ids) == 0 {
+				continue
+			}
+			sort.Slice(change.ids, func(i, j int) bool { return change.ids[i] < change.ids[j] })
+			_, err := apiPost[int](ctx, client, "systemgroup/addOrRemoveSystems", map[string]interface{}{
+				"systemGroupName": group,
+				"serverIds":       change.ids,
+				"add":             change.add,
+			})
+			if err != nil {
+				return fmt.Errorf("could not change systems %v: %w", change.ids, err)
+			}
+		}
+	}
+	return nil
+}
+
+// countSystems sets the number of systems in the group after its members
+// changed.
+func (m *systemGroupResourceModel) countSystems(ctx context.Context, client *uyuniClient) error {
+	group, err := apiGet[uyuni.SystemGroup](ctx, client, "systemgroup/getDetails?systemGroupName="+url.QueryEscape(m.Name.ValueString()))
+	if err != nil {
+		return err
+	}
+	m.SystemCount = types.Int64Value(int64(group.Result.SystemCount))
+	return nil
+}
+
+// Create a new resource.
+func (r *systemGroupResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan systemGroupResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	client := resourceClient(ctx, r.client, plan.ServerAlias, plan.Org, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	tflog.Info(ctx, "About to create system group "+plan.Name.ValueString())
+
+	description, err := plan.storedDescription(ctx)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("metadata"), "Invalid Metadata", err.Error())
+		return
+	}
+	group, err := apiPost[uyuni.SystemGroup](ctx, client, "systemgroup/create", map[string]interface{}{
+		"name":        plan.Name.ValueString(),
+		"description": description,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating system group",
+			"Could not create system group: "+err.Error(),
+		)
+		return
+	}
+	plan.GroupID = types.Int64Value(int64(group.Result.ID))
+	plan.ID = plan.Name
+	plan.SystemCount = types.Int64Value(int64(group.Result.SystemCount))
+
+	if err := plan.changeMembers(ctx, client); err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating system group",
+			"Could not set the members of system group "+plan.Name.ValueString()+": "+err.Error(),
+		)
+		// Fall through to track the group, which Terraform taints.
+	} else if err := plan.countSystems(ctx, client); err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating system group",
+			"Could not count the systems of system group "+plan.Name.ValueString()+": "+err.Error(),
+		)
+	}
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read resource information.
+func (r *systemGroupResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Get current state
+	var state systemGroupResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := resourceClient(ctx, r.client, state.ServerAlias, state.Org, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	name := state.Name.ValueString()
+	group, err := apiGet[uyuni.SystemGroup](ctx, client, "systemgroup/getDetails?systemGroupName="+url.QueryEscape(name))
+	if err != nil {
+		if handleNotFound(ctx, resp, err, "System group "+name) {
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Reading Uyuni system group",
+			"Could not read system group "+name+": "+err.Error(),
+		)
+		return
+	}
+	state.ID = state.Name
+	state.GroupID = types.Int64Value(int64(group.Result.ID))
+	state.SystemCount = types.Int64Value(int64(group.Result.SystemCount))
+	resp.Diagnostics.Append(state.setDescription(ctx, group.Result.Description)...)
+
+	// Members are only refreshed where they are managed.
+	if !state.Administrators.IsNull() {
+		logins, err := listGroupAdministrators(ctx, client, name)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Reading Uyuni system group",
+				"Could not list administrators of system group "+name+": "+err.Error(),
+			)
+			return
+		}
+		state.Administrators, diags = types.SetValueFrom(ctx, types.StringType, logins)
+		resp.Diagnostics.Append(diags...)
+	}
+	if !state.SystemIDs.IsNull() {
+		ids, err := listGroupSystems(ctx, client, name)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Reading Uyuni system group",
+				"Could not list systems of system group "+name+": "+err.Error(),
+			)
+			return
+		}
+		state.SystemIDs, diags = types.SetValueFrom(ctx, types.Int64Type, ids)
+		resp.Diagnostics.Append(diags...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *systemGroupResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Retrieve values from plan
+	var plan systemGroupResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	client := resourceClient(ctx, r.client, plan.ServerAlias, plan.Org, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	name := plan.Name.ValueString()
+	description, err := plan.storedDescription(ctx)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("metadata"), "Invalid Metadata", err.Error())
+		return
+	}
+	_, err = apiPost[uyuni.SystemGroup](ctx, client, "systemgroup/update", map[string]interface{}{
+		"systemGroupName": name,
+		"description":     description,
+	})
+	if err == nil {
+		err = plan.changeMembers(ctx, client)
+	}
+	if err == nil {
+		err = plan.countSystems(ctx, client)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating system group",
+			"Could not update system group "+name+": "+err.Error(),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete removes the group, its systems stay registered.
+func (r *systemGroupResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Retrieve values from state
+	var state systemGroupResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	client := resourceClient(ctx, r.client, state.ServerAlias, state.Org, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	_, err := apiPost[int](ctx, client, "systemgroup/delete", map[string]interface{}{
+		"systemGroupName": state.Name.ValueString(),
+	})
+	if err != nil && !isNotFoundError(err) {
+		resp.Diagnostics.AddError(
+			"Error Deleting Uyuni system group",
+			"Could not delete system group "+state.Name.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+}
+
+// ImportState imports a group by its name. Its administrators and systems
+// stay unmanaged until they are configured.
+func (r *systemGroupResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("name"), req, resp)
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *systemGroupResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*uyuniClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *uyuniClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
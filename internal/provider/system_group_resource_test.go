@@ -0,0 +1,187 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	acctest "github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+// testSystemGroupServer serves the group stores administered by admin with
+// system 1001 and records the changes.
+func testSystemGroupServer(changes *[]string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/systemgroup/getDetails":
+			_, _ = w.Write([]byte(`{"success": true, "result": {"id": 7, "name": "stores", "description": "Stores", "org_id": 1, "system_count": 1}}`))
+		case "/systemgroup/listAdministrators":
+			_, _ = w.Write([]byte(`{"success": true, "result": [{"id": 1, "login": "admin", "login_uc": "ADMIN", "enabled": true}]}`))
+		case "/systemgroup/listSystemsMinimal":
+			_, _ = w.Write([]byte(`{"success": true, "result": [{"id": 1001, "name": "web01", "last_checkin": "", "created": ""}]}`))
+		default:
+			var body map[string]interface{}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			delete(body, "systemGroupName")
+			*changes = append(*changes, fmt.Sprint(r.URL.Path, " ", body))
+			if r.URL.Path == "/systemgroup/create" || r.URL.Path == "/systemgroup/update" {
+				_, _ = w.Write([]byte(`{"success": true, "result": {"id": 7, "name": "stores", "description": "Stores", "org_id": 1, "system_count": 0}}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"success": true, "result": 1}`))
+		}
+	}
+}
+
+func TestSystemGroupCreateSetsMembers(t *testing.T) {
+	ctx := context.Background()
+	var changes []string
+	r := NewSystemGroupResource()
+	testConfigure(t, r, testAPIClient(t, testSystemGroupServer(&changes)))
+
+	planned := testState(t, r, map[string]interface{}{
+		"name":           "stores",
+		"description":    "Stores",
+		"administrators": []string{"store-ops"},
+		"system_ids":     []int64{1002},
+	})
+	resp := &resource.CreateResponse{State: planned}
+	r.Create(ctx, resource.CreateRequest{Plan: tfsdk.Plan{Schema: planned.Schema, Raw: planned.Raw}}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+	want := "[/systemgroup/create map[description:Stores name:stores]" +
+		" /systemgroup/addOrRemoveAdmins map[add:1 loginName:[store-ops]]" +
+		" /systemgroup/addOrRemoveAdmins map[add:0 loginName:[admin]]" +
+		" /systemgroup/addOrRemoveSystems map[add:true serverIds:[1002]]" +
+		" /systemgroup/addOrRemoveSystems map[add:false serverIds:[1001]]]"
+	if fmt.Sprint(changes) != want {
+		t.Errorf("expected %s, got %v", want, changes)
+	}
+	var state systemGroupResourceModel
+	resp.State.Get(ctx, &state)
+	if state.ID.ValueString() != "stores" || state.GroupID.ValueInt64() != 7 || state.SystemCount.ValueInt64() != 1 {
+		t.Errorf("unexpected state %v", state)
+	}
+}
+
+func TestSystemGroupStoresMetadataInDescription(t *testing.T) {
+	ctx := context.Background()
+	var changes []string
+	r := NewSystemGroupResource()
+	testConfigure(t, r, testAPIClient(t, testSystemGroupServer(&changes)))
+
+	planned := testState(t, r, map[string]interface{}{
+		"name":        "stores",
+		"description": "Stores",
+		"metadata":    map[string]string{"owner": "store-ops", "cost_center": "4711"},
+	})
+	resp := &resource.CreateResponse{State: planned}
+	r.Create(ctx, resource.CreateRequest{Plan: tfsdk.Plan{Schema: planned.Schema, Raw: planned.Raw}}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+	want := "[/systemgroup/create map[description:Stores\n\n[metadata]\ncost_center = 4711\nowner = store-ops name:stores]]"
+	if fmt.Sprint(changes) != want {
+		t.Errorf("expected %q, got %q", want, changes)
+	}
+}
+
+func TestSplitGroupDescription(t *testing.T) {
+	for stored, want := range map[string]struct {
+		description string
+		metadata    map[string]string
+	}{
+		"Stores": {"Stores", nil},
+		"Stores\n\n[metadata]\nowner = store-ops":  {"Stores", map[string]string{"owner": "store-ops"}},
+		"[metadata]\nowner = store-ops":            {"", map[string]string{"owner": "store-ops"}},
+		"Stores\n\n[metadata]\nowned by store-ops": {"Stores\n\n[metadata]\nowned by store-ops", nil},
+	} {
+		description, metadata := splitGroupDescription(stored)
+		if description != want.description || fmt.Sprint(metadata) != fmt.Sprint(want.metadata) || (metadata == nil) != (want.metadata == nil) {
+			t.Errorf("%q: expected %q and %v, got %q and %v", stored, want.description, want.metadata, description, metadata)
+		}
+	}
+}
+
+func TestSystemGroupLeavesUnmanagedMembersAlone(t *testing.T) {
+	ctx := context.Background()
+	var changes []string
+	client := testAPIClient(t, testSystemGroupServer(&changes))
+	r := NewSystemGroupResource()
+	testConfigure(t, r, client)
+
+	planned := testState(t, r, map[string]interface{}{
+		"name":        "stores",
+		"description": "Stores of the north",
+	})
+	resp := &resource.UpdateResponse{State: planned}
+	r.Update(ctx, resource.UpdateRequest{Plan: tfsdk.Plan{Schema: planned.Schema, Raw: planned.Raw}, State: planned}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+	if want := "[/systemgroup/update map[description:Stores of the north]]"; fmt.Sprint(changes) != want {
+		t.Errorf("expected %s, got %v", want, changes)
+	}
+
+	readResp := testRead(t, r, client, map[string]interface{}{"name": "stores", "system_ids": []int64{}})
+	if readResp.Diagnostics.HasError() {
+		t.Fatal(readResp.Diagnostics)
+	}
+	var state systemGroupResourceModel
+	readResp.State.Get(ctx, &state)
+	if !state.Administrators.IsNull() || len(state.SystemIDs.Elements()) != 1 || state.Description.ValueString() != "Stores" {
+		t.Errorf("unexpected state %v", state)
+	}
+}
+
+func TestAccSystemGroupResource(t *testing.T) {
+	acctest.Test(t, acctest.TestCase{
+		PreCheck:                 func() { testAccUyuniPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckGone(t, "systemgroup/getDetails?systemGroupName=tfacc-group"),
+		Steps: []acctest.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccSystemGroupResourceConfig("Created by the acceptance tests"),
+				Check: acctest.ComposeAggregateTestCheckFunc(
+					acctest.TestCheckResourceAttr("uyuni_system_group.test", "name", "tfacc-group"),
+					acctest.TestCheckResourceAttrSet("uyuni_system_group.test", "group_id"),
+					acctest.TestCheckResourceAttr("uyuni_system_group.test", "metadata.owner", "tfacc"),
+					acctest.TestCheckResourceAttr("uyuni_system_group.test", "system_count", "0"),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: testAccSystemGroupResourceConfig("Updated by the acceptance tests"),
+				Check:  acctest.TestCheckResourceAttr("uyuni_system_group.test", "description", "Updated by the acceptance tests"),
+			},
+			// ImportState testing
+			{
+				ResourceName:                         "uyuni_system_group.test",
+				ImportState:                          true,
+				ImportStateId:                        "tfacc-group",
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "name",
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccSystemGroupResourceConfig(description string) string {
+	return fmt.Sprintf(`
+resource "uyuni_system_group" "test" {
+  name        = "tfacc-group"
+  description = %q
+
+  metadata = {
+    owner = "tfacc"
+  }
+}
+`, description)
+}
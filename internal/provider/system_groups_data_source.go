@@ -0,0 +1,173 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"terraform-provider-uyuni/internal/uyuni"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &SystemGroupsDataSource{}
+	_ datasource.DataSourceWithConfigure = &SystemGroupsDataSource{}
+)
+
+// SystemGroupsDataSourceModel maps the data source schema data.
+type SystemGroupsDataSourceModel struct {
+	NameRegex types.String       `tfsdk:"name_regex"`
+	Names     types.Set          `tfsdk:"names"`
+	Groups    []systemGroupModel `tfsdk:"groups"`
+}
+
+// systemGroupModel maps a system group.
+type systemGroupModel struct {
+	ID          types.Int64  `tfsdk:"id"`
+	Name        types.String `tfsdk:"name"`
+	Description types.String `tfsdk:"description"`
+	OrgID       types.Int64  `tfsdk:"org_id"`
+	SystemCount types.Int64  `tfsdk:"system_count"`
+}
+
+// NewSystemGroupsDataSource is a helper function to simplify the provider implementation.
+func NewSystemGroupsDataSource() datasource.DataSource {
+	return &SystemGroupsDataSource{}
+}
+
+// SystemGroupsDataSource is the data source implementation.
+type SystemGroupsDataSource struct {
+	client *uyuniClient
+}
+
+// Metadata returns the data source type name.
+func (d *SystemGroupsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_system_groups"
+}
+
+// Schema defines the schema for the data source.
+func (d *SystemGroupsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the system groups of the organization of the provider user, e.g. to reference groups " +
+			"managed outside of Terraform without hardcoding their names.",
+		Attributes: map[string]schema.Attribute{
+			"name_regex": schema.StringAttribute{
+				Description: "Only list groups whose name matches this regular expression, in RE2 syntax.",
+				Optional:    true,
+			},
+			"names": schema.SetAttribute{
+				Description: "Names of the groups.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"groups": schema.ListNestedAttribute{
+				Description: "Groups, ordered by name.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.Int64Attribute{
+							Description: "ID of the group.",
+							Computed:    true,
+						},
+						"name": schema.StringAttribute{
+							Description: "Name of the group.",
+							Computed:    true,
+						},
+						"description": schema.StringAttribute{
+							Description: "Description of the group.",
+							Computed:    true,
+						},
+						"org_id": schema.Int64Attribute{
+							Description: "ID of the organization of the group.",
+							Computed:    true,
+						},
+						"system_count": schema.Int64Attribute{
+							Description: "Number of systems in the group.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *SystemGroupsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state SystemGroupsDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	nameRegex, ok := compileRegexFilter(state.NameRegex, "name_regex", &resp.Diagnostics)
+	if !ok {
+		return
+	}
+
+	groups, err := apiGet[[]uyuni.SystemGroup](ctx, d.client, "systemgroup/listAllGroups")
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Uyuni system groups",
+			"Could not list system groups: "+err.Error(),
+		)
+		return
+	}
+
+	sort.Slice(groups.Result, func(i, j int) bool {
+		return groups.Result[i].Name < groups.Result[j].Name
+	})
+	names := []string{}
+	state.Groups = []systemGroupModel{}
+	for _, group := range groups.Result {
+		if nameRegex != nil && !nameRegex.MatchString(group.Name) {
+			continue
+		}
+		names = append(names, group.Name)
+		state.Groups = append(state.Groups, systemGroupModel{
+			ID:          types.Int64Value(int64(group.ID)),
+			Name:        types.StringValue(group.Name),
+			Description: types.StringValue(group.Description),
+			OrgID:       types.Int64Value(int64(group.OrgID)),
+			SystemCount: types.Int64Value(int64(group.SystemCount)),
+		})
+	}
+	state.Names, diags = types.SetValueFrom(ctx, types.StringType, names)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *SystemGroupsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*uyuniClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *uyuniClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
@@ -0,0 +1,44 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestSystemGroupsDataSourceFiltersByName(t *testing.T) {
+	resp := testDataSourceRead(t, NewSystemGroupsDataSource(), fixtureClient(t, "2024.08"), map[string]tftypes.Value{
+		"name_regex": tftypes.NewValue(tftypes.String, `^B\d+$`),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+	var state SystemGroupsDataSourceModel
+	resp.State.Get(context.Background(), &state)
+	if len(state.Groups) != 1 || state.Groups[0].Name.ValueString() != "B042" || state.Groups[0].ID.ValueInt64() != 6 {
+		t.Errorf("expected group B042, got %v", state.Groups)
+	}
+}
+
+func TestAccSystemGroupsDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccUyuniPreCheck(t)
+			testAccSeedSystemGroup(t, "tfacc-ds-groups")
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `data "uyuni_system_groups" "test" { name_regex = "^tfacc-ds-groups$" }`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.uyuni_system_groups.test", "names.#", "1"),
+					resource.TestCheckResourceAttr("data.uyuni_system_groups.test", "groups.0.name", "tfacc-ds-groups"),
+					resource.TestCheckResourceAttr("data.uyuni_system_groups.test", "groups.0.description", "Group of the acceptance tests"),
+					resource.TestCheckResourceAttr("data.uyuni_system_groups.test", "groups.0.system_count", "0"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,274 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"terraform-provider-uyuni/internal/uyuni"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource              = &systemOrgMigrationResource{}
+	_ resource.ResourceWithConfigure = &systemOrgMigrationResource{}
+)
+
+// NewSystemOrgMigrationResource is a helper function to simplify the provider implementation.
+func NewSystemOrgMigrationResource() resource.Resource {
+	return &systemOrgMigrationResource{}
+}
+
+// systemOrgMigrationResource is the resource implementation.
+type systemOrgMigrationResource struct {
+	client *uyuniClient
+}
+
+// systemOrgMigrationResourceModel maps the resource schema data.
+type systemOrgMigrationResourceModel struct {
+	ID          types.String   `tfsdk:"id"`
+	FromOrgID   types.Int64    `tfsdk:"from_org_id"`
+	ToOrgID     types.Int64    `tfsdk:"to_org_id"`
+	SystemIDs   types.Set      `tfsdk:"system_ids"`
+	ServerAlias types.String   `tfsdk:"server_alias"`
+	Timeouts    timeouts.Value `tfsdk:"timeouts"`
+}
+
+// Metadata returns the resource type name.
+func (r *systemOrgMigrationResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_system_org_migration"
+}
+
+// Schema defines the schema for the resource.
+func (r *systemOrgMigrationResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Migrates systems from one organization to another. " +
+			"The source organization must trust the destination organization, which is checked before any system is migrated. " +
+			"Systems added to the resource later are migrated on the next apply; " +
+			"removing systems or destroying the resource leaves the systems in the destination organization. " +
+			"Requires the provider user to administer the server or the source organization.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Source and destination organization IDs, separated by a colon.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"from_org_id": schema.Int64Attribute{
+				Description: "ID of the organization the systems currently belong to.",
+				Required:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"to_org_id": schema.Int64Attribute{
+				Description: "ID of the organization to migrate the systems to.",
+				Required:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"system_ids": schema.SetAttribute{
+				Description: "IDs of the systems to migrate.",
+				ElementType: types.Int64Type,
+				Required:    true,
+			},
+			"server_alias": serverAliasAttribute(),
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+			}),
+		},
+	}
+}
+
+// checkOrgTrust returns an error unless the organization from trusts the
+// organization to, which org/migrateSystems requires.
+func checkOrgTrust(ctx context.Context, client *uyuniClient, from, to int64) error {
+	trusts, err := apiGet[[]uyuni.OrgTrust](ctx, client, fmt.Sprintf("org/trusts/listTrusts?orgId=%d", from))
+	if err != nil {
+		return fmt.Errorf("could not list the trusts of organization %d: %w", from, err)
+	}
+	for _, trust := range trusts.Result {
+		if int64(trust.OrgID) == to {
+			if !trust.TrustEnabled {
+				return fmt.Errorf("organization %d does not trust organization %d (%s)", from, to, trust.OrgName)
+			}
+			return nil
+		}
+	}
+	return fmt.Errorf("organization %d does not exist or is not visible to the provider user", to)
+}
+
+// migrateSystems moves the systems to the organization to, after checking
+// that the organization from trusts it.
+func migrateSystems(ctx context.Context, client *uyuniClient, from, to int64, ids []int64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	if err := checkOrgTrust(ctx, client, from, to); err != nil {
+		return err
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Migrating %d systems from organization %d to %d", len(ids), from, to))
+	migrated, err := apiPost[[]int64](ctx, client, "org/migrateSystems", map[string]interface{}{
+		"toOrgId": to,
+		"sids":    ids,
+	})
+	if err != nil {
+		return err
+	}
+	if _, failed := setDiff(migrated.Result, ids); len(failed) > 0 {
+		return fmt.Errorf("the server did not migrate the systems %v", failed)
+	}
+	return nil
+}
+
+// Create a new resource.
+func (r *systemOrgMigrationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan systemOrgMigrationResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := resourceClient(ctx, r.client, plan.ServerAlias, nil, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	var ids []int64
+	resp.Diagnostics.Append(plan.SystemIDs.ElementsAs(ctx, &ids, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	from, to := plan.FromOrgID.ValueInt64(), plan.ToOrgID.ValueInt64()
+	if err := migrateSystems(ctx, client, from, to, ids); err != nil {
+		resp.Diagnostics.AddError(
+			"Error migrating systems",
+			fmt.Sprintf("Could not migrate systems from organization %d to %d: %s", from, to, err),
+		)
+		return
+	}
+
+	plan.ID = types.StringValue(fmt.Sprintf("%d%s%d", from, importIDSeparator, to))
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read keeps the state, as migrated systems belong to the destination
+// organization and are managed there.
+func (r *systemOrgMigrationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state systemOrgMigrationResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update migrates the systems added to the resource.
+func (r *systemOrgMigrationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Retrieve values from plan and state
+	var plan, state systemOrgMigrationResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := resourceClient(ctx, r.client, plan.ServerAlias, nil, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	var wanted, previous []int64
+	resp.Diagnostics.Append(plan.SystemIDs.ElementsAs(ctx, &wanted, false)...)
+	resp.Diagnostics.Append(state.SystemIDs.ElementsAs(ctx, &previous, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	from, to := plan.FromOrgID.ValueInt64(), plan.ToOrgID.ValueInt64()
+	added, _ := setDiff(previous, wanted)
+	if err := migrateSystems(ctx, client, from, to, added); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("system_ids"),
+			"Error migrating systems",
+			fmt.Sprintf("Could not migrate systems from organization %d to %d: %s", from, to, err),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete removes the resource from state. The systems stay in the
+// destination organization.
+func (r *systemOrgMigrationResource) Delete(ctx context.Context, _ resource.DeleteRequest, _ *resource.DeleteResponse) {
+	tflog.Info(ctx, "Removing system migration from state, the systems stay in the destination organization")
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *systemOrgMigrationResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*uyuniClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *uyuniClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
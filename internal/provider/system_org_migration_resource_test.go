@@ -0,0 +1,147 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	acctest "github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+// testOrgMigrationServer serves organization 1, which trusts organization 2
+// but not organization 3, and records the migrated systems.
+type testOrgMigrationServer struct {
+	migrated []int64
+}
+
+func (s *testOrgMigrationServer) handler(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case "/org/trusts/listTrusts":
+		_, _ = w.Write([]byte(`{"success": true, "result": [
+			{"orgId": 2, "orgName": "Tenant A", "trustEnabled": true},
+			{"orgId": 3, "orgName": "Tenant B", "trustEnabled": false}
+		]}`))
+	case "/org/migrateSystems":
+		var body struct {
+			SIDs []int64 `json:"sids"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		s.migrated = append(s.migrated, body.SIDs...)
+		result, _ := json.Marshal(body.SIDs)
+		_, _ = w.Write([]byte(`{"success": true, "result": ` + string(result) + `}`))
+	default:
+		_, _ = w.Write([]byte(`{"success": false, "message": "unexpected request ` + r.URL.String() + `"}`))
+	}
+}
+
+func testOrgMigrationState(t *testing.T, r resource.Resource, to int64, ids ...int64) tfsdk.State {
+	systemIDs, _ := types.SetValueFrom(context.Background(), types.Int64Type, ids)
+	return testState(t, r, map[string]interface{}{
+		"from_org_id": int64(1),
+		"to_org_id":   to,
+		"system_ids":  systemIDs,
+	})
+}
+
+func TestSystemOrgMigrationCreate(t *testing.T) {
+	ctx := context.Background()
+	server := &testOrgMigrationServer{}
+	r := NewSystemOrgMigrationResource()
+	testConfigure(t, r, testAPIClient(t, server.handler))
+
+	planned := testOrgMigrationState(t, r, 2, 1000010001)
+	resp := &resource.CreateResponse{State: planned}
+	r.Create(ctx, resource.CreateRequest{Plan: tfsdk.Plan{Schema: planned.Schema, Raw: planned.Raw}}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+	if fmt.Sprint(server.migrated) != "[1000010001]" {
+		t.Errorf("got migrated systems %v", server.migrated)
+	}
+
+	var state systemOrgMigrationResourceModel
+	resp.State.Get(ctx, &state)
+	if state.ID.ValueString() != "1:2" {
+		t.Errorf("got id %s", state.ID)
+	}
+}
+
+func TestSystemOrgMigrationRequiresTrust(t *testing.T) {
+	ctx := context.Background()
+	for to, want := range map[int64]string{
+		3: "organization 1 does not trust organization 3 (Tenant B)",
+		4: "organization 4 does not exist",
+	} {
+		server := &testOrgMigrationServer{}
+		r := NewSystemOrgMigrationResource()
+		testConfigure(t, r, testAPIClient(t, server.handler))
+
+		planned := testOrgMigrationState(t, r, to, 1000010001)
+		resp := &resource.CreateResponse{State: planned}
+		r.Create(ctx, resource.CreateRequest{Plan: tfsdk.Plan{Schema: planned.Schema, Raw: planned.Raw}}, resp)
+		if !resp.Diagnostics.HasError() || !strings.Contains(resp.Diagnostics[0].Detail(), want) {
+			t.Errorf("org %d: expected %q, got %v", to, want, resp.Diagnostics)
+		}
+		if server.migrated != nil {
+			t.Errorf("org %d: no system must be migrated without trust, got %v", to, server.migrated)
+		}
+	}
+}
+
+func TestSystemOrgMigrationUpdateMigratesAddedSystems(t *testing.T) {
+	ctx := context.Background()
+	server := &testOrgMigrationServer{}
+	r := NewSystemOrgMigrationResource()
+	testConfigure(t, r, testAPIClient(t, server.handler))
+
+	prior := testOrgMigrationState(t, r, 2, 1000010001, 1000010002)
+	planned := testOrgMigrationState(t, r, 2, 1000010002, 1000010003)
+	resp := &resource.UpdateResponse{State: prior}
+	r.Update(ctx, resource.UpdateRequest{Plan: tfsdk.Plan{Schema: planned.Schema, Raw: planned.Raw}, State: prior}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+	if fmt.Sprint(server.migrated) != "[1000010003]" {
+		t.Errorf("expected only the added system to be migrated, got %v", server.migrated)
+	}
+}
+
+func TestAccSystemOrgMigrationResource(t *testing.T) {
+	system := os.Getenv("UYUNI_TEST_MIGRATION_SYSTEM_ID")
+	acctest.Test(t, acctest.TestCase{
+		PreCheck: func() {
+			testAccRealServerPreCheck(t)
+			testAccSkipUnlessEnv(t, "UYUNI_TEST_MIGRATION_SYSTEM_ID", "a registered system of organization 1 that may be moved to another organization")
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []acctest.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "uyuni_organization" "test" {
+  name                = "tfacc-migration"
+  admin_login         = "tfacc-migration-admin"
+  admin_password      = "tfacc-Secret-123"
+  admin_first_name    = "Terraform"
+  admin_last_name     = "Acceptance"
+  admin_email         = "tfacc-migration-admin@example.com"
+  deletion_protection = false
+}
+
+resource "uyuni_system_org_migration" "test" {
+  from_org_id = 1
+  to_org_id   = uyuni_organization.test.org_id
+  system_ids  = [%s]
+}
+`, system),
+				Check: acctest.TestCheckTypeSetElemAttr("uyuni_system_org_migration.test", "system_ids.*", system),
+			},
+		},
+	})
+}
@@ -0,0 +1,105 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &SystemOSInfoDataSource{}
+	_ datasource.DataSourceWithConfigure = &SystemOSInfoDataSource{}
+)
+
+// SystemOSInfoDataSourceModel maps the data source schema data.
+type SystemOSInfoDataSourceModel struct {
+	SystemID types.Int64  `tfsdk:"system_id"`
+	Product  types.String `tfsdk:"product"`
+	osInfoModel
+}
+
+// NewSystemOSInfoDataSource is a helper function to simplify the provider implementation.
+func NewSystemOSInfoDataSource() datasource.DataSource {
+	return &SystemOSInfoDataSource{}
+}
+
+// SystemOSInfoDataSource is the data source implementation.
+type SystemOSInfoDataSource struct {
+	client *uyuniClient
+}
+
+// Metadata returns the data source type name.
+func (d *SystemOSInfoDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_system_os_info"
+}
+
+// Schema defines the schema for the data source.
+func (d *SystemOSInfoDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Reads the operating system of a system, normalized from its base product so that modules can " +
+			"branch on the family, version and architecture without parsing product names of each distribution. " +
+			"uyuni_systems and uyuni_hub_systems offer the same attributes with include_os_info.",
+		Attributes: withOSInfoAttributes(map[string]schema.Attribute{
+			"system_id": schema.Int64Attribute{
+				Description: "ID of the system.",
+				Required:    true,
+			},
+			"product": schema.StringAttribute{
+				Description: "Name of the base product as reported by Uyuni, e.g. `SUSE Linux Enterprise Server 15 SP6 x86_64`. " +
+					"Null if the system reports none.",
+				Computed: true,
+			},
+		}),
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *SystemOSInfoDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state SystemOSInfoDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	sid := state.SystemID.ValueInt64()
+	info, err := systemOSInfo(ctx, d.client, sid)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Uyuni system OS info",
+			fmt.Sprintf("Could not list the installed products of system %d: %s", sid, err),
+		)
+		return
+	}
+	state.Product = nonEmptyString(info.product)
+	state.osInfoModel = info.model()
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *SystemOSInfoDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*uyuniClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *uyuniClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
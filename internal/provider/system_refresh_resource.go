@@ -0,0 +1,365 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource              = &systemRefreshResource{}
+	_ resource.ResourceWithConfigure = &systemRefreshResource{}
+)
+
+// NewSystemRefreshResource is a helper function to simplify the provider implementation.
+func NewSystemRefreshResource() resource.Resource {
+	return &systemRefreshResource{}
+}
+
+// systemRefreshResource is the resource implementation.
+type systemRefreshResource struct {
+	client *uyuniClient
+}
+
+// systemRefreshResourceModel maps the resource schema data.
+type systemRefreshResourceModel struct {
+	ID                      types.String   `tfsdk:"id"`
+	SystemID                types.Int64    `tfsdk:"system_id"`
+	PackageRefresh          types.Bool     `tfsdk:"package_refresh"`
+	HardwareRefresh         types.Bool     `tfsdk:"hardware_refresh"`
+	Wait                    types.Bool     `tfsdk:"wait"`
+	Triggers                types.Map      `tfsdk:"triggers"`
+	PackageRefreshActionID  types.Int64    `tfsdk:"package_refresh_action_id"`
+	HardwareRefreshActionID types.Int64    `tfsdk:"hardware_refresh_action_id"`
+	PackageRefreshStatus    types.String   `tfsdk:"package_refresh_status"`
+	HardwareRefreshStatus   types.String   `tfsdk:"hardware_refresh_status"`
+	CancelOnDestroy         types.Bool     `tfsdk:"cancel_on_destroy"`
+	Notify                  *notifyModel   `tfsdk:"notify"`
+	ServerAlias             types.String   `tfsdk:"server_alias"`
+	Timeouts                timeouts.Value `tfsdk:"timeouts"`
+}
+
+// Metadata returns the resource type name.
+func (r *systemRefreshResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_system_refresh"
+}
+
+// Schema defines the schema for the resource.
+func (r *systemRefreshResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Refreshes the package profile and hardware of a system when created, e.g. right after it was bootstrapped, " +
+			"and waits for the refresh so that data sources depending on it read the current packages and errata in the same apply. " +
+			"Change triggers to refresh again.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "ID of the system.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"system_id": schema.Int64Attribute{
+				Description: "ID of the system.",
+				Required:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"package_refresh": schema.BoolAttribute{
+				Description: "Refresh the list of installed packages. Defaults to true.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"hardware_refresh": schema.BoolAttribute{
+				Description: "Refresh the hardware profile. Defaults to true.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"wait": schema.BoolAttribute{
+				Description: "Wait until the refresh finished on the system. Defaults to true.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"triggers": schema.MapAttribute{
+				Description: "Arbitrary values which refresh the system again when they change.",
+				ElementType: types.StringType,
+				Optional:    true,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"cancel_on_destroy": cancelOnDestroyAttribute(),
+			"package_refresh_action_id": schema.Int64Attribute{
+				Description: "ID of the package refresh action, null without package refresh.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"hardware_refresh_action_id": schema.Int64Attribute{
+				Description: "ID of the hardware refresh action, null without hardware refresh.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"package_refresh_status": schema.StringAttribute{
+				Description: "Status of the package refresh action, `pending`, `completed` or `failed`, null without package refresh. " +
+					"Pending statuses are refreshed.",
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"hardware_refresh_status": schema.StringAttribute{
+				Description: "Status of the hardware refresh action, `pending`, `completed` or `failed`, null without hardware refresh. " +
+					"Pending statuses are refreshed.",
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"server_alias": serverAliasAttribute(),
+		},
+		Blocks: map[string]schema.Block{
+			"notify": notifyBlock(),
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+			}),
+		},
+	}
+}
+
+// scheduleRefresh schedules the refresh action of the endpoint on the system
+// to run immediately and returns the action id.
+func scheduleRefresh(ctx context.Context, client *uyuniClient, endpoint string, sid int64) (int64, error) {
+	actionID, err := apiPost[int64](ctx, client, endpoint, map[string]interface{}{
+		"sid":                sid,
+		"earliestOccurrence": apiDate(time.Now()),
+	})
+	if err != nil {
+		return 0, err
+	}
+	return actionID.Result, nil
+}
+
+// Create schedules the refreshes and waits for them.
+func (r *systemRefreshResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan systemRefreshResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := resourceClient(ctx, r.client, plan.ServerAlias, nil, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	sid := plan.SystemID.ValueInt64()
+	plan.PackageRefreshActionID = types.Int64Null()
+	plan.HardwareRefreshActionID = types.Int64Null()
+	plan.PackageRefreshStatus = types.StringNull()
+	plan.HardwareRefreshStatus = types.StringNull()
+	if plan.PackageRefresh.ValueBool() {
+		actionID, err := scheduleRefresh(ctx, client, "system/schedulePackageRefresh", sid)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error refreshing system",
+				fmt.Sprintf("Could not schedule a package refresh of system %d: %s", sid, err),
+			)
+			return
+		}
+		plan.PackageRefreshActionID = types.Int64Value(actionID)
+		plan.PackageRefreshStatus = types.StringValue(actionStatusPending)
+	}
+	if plan.HardwareRefresh.ValueBool() {
+		actionID, err := scheduleRefresh(ctx, client, "system/scheduleHardwareRefresh", sid)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error refreshing system",
+				fmt.Sprintf("Could not schedule a hardware refresh of system %d: %s", sid, err),
+			)
+			return
+		}
+		plan.HardwareRefreshActionID = types.Int64Value(actionID)
+		plan.HardwareRefreshStatus = types.StringValue(actionStatusPending)
+	}
+
+	if plan.Wait.ValueBool() {
+		for _, refresh := range []struct {
+			actionID types.Int64
+			status   *types.String
+		}{
+			{plan.PackageRefreshActionID, &plan.PackageRefreshStatus},
+			{plan.HardwareRefreshActionID, &plan.HardwareRefreshStatus},
+		} {
+			if refresh.actionID.IsNull() {
+				continue
+			}
+			if err := waitForAction(ctx, client, refresh.actionID.ValueInt64(), sid); err != nil {
+				resp.Diagnostics.AddError(
+					"Error refreshing system",
+					fmt.Sprintf("Could not refresh system %d: %s", sid, err),
+				)
+				return
+			}
+			*refresh.status = types.StringValue(actionStatusCompleted)
+		}
+		tflog.Info(ctx, fmt.Sprintf("Refreshed system %d", sid))
+	}
+
+	plan.ID = types.StringValue(fmt.Sprint(sid))
+	if plan.Wait.ValueBool() {
+		var actionIDs []int64
+		for _, actionID := range []types.Int64{plan.PackageRefreshActionID, plan.HardwareRefreshActionID} {
+			if !actionID.IsNull() {
+				actionIDs = append(actionIDs, actionID.ValueInt64())
+			}
+		}
+		plan.Notify.send(ctx, notification{
+			Resource:  "uyuni_system_refresh",
+			ID:        plan.ID.ValueString(),
+			Status:    actionStatusCompleted,
+			ActionIDs: actionIDs,
+			SystemIDs: []int64{sid},
+		}, &resp.Diagnostics)
+	}
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read keeps the state, a refresh has no lasting object on the server. Only
+// the statuses of pending actions are refreshed.
+func (r *systemRefreshResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state systemRefreshResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := resourceClient(ctx, r.client, state.ServerAlias, nil, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	var err error
+	state.PackageRefreshStatus, err = refreshActionStatus(ctx, client, state.PackageRefreshActionID, state.PackageRefreshStatus)
+	if err == nil {
+		state.HardwareRefreshStatus, err = refreshActionStatus(ctx, client, state.HardwareRefreshActionID, state.HardwareRefreshStatus)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Error Reading Uyuni system refresh", err.Error())
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update only changes cancel_on_destroy and timeouts, all other changes
+// refresh the system again.
+func (r *systemRefreshResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan systemRefreshResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete cancels refreshes which are still pending, unless cancel_on_destroy
+// is false, and removes the refresh from state. The system is not changed.
+func (r *systemRefreshResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state systemRefreshResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := resourceClient(ctx, r.client, state.ServerAlias, nil, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	if state.CancelOnDestroy.ValueBool() {
+		var pending []int64
+		if state.PackageRefreshStatus.ValueString() == actionStatusPending {
+			pending = append(pending, state.PackageRefreshActionID.ValueInt64())
+		}
+		if state.HardwareRefreshStatus.ValueString() == actionStatusPending {
+			pending = append(pending, state.HardwareRefreshActionID.ValueInt64())
+		}
+		if err := cancelPendingActions(ctx, client, pending); err != nil {
+			resp.Diagnostics.AddError("Error Deleting Uyuni system refresh", err.Error())
+			return
+		}
+	}
+	tflog.Info(ctx, "Removing system refresh from state, the system is not changed")
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *systemRefreshResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*uyuniClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *uyuniClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
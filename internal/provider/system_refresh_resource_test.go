@@ -0,0 +1,154 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	acctest "github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestSystemRefreshCreateSchedulesAndWaits(t *testing.T) {
+	ctx := context.Background()
+	actionPollInterval = time.Millisecond
+	t.Cleanup(func() { actionPollInterval = 10 * time.Second })
+
+	var scheduled []string
+	waited := map[string]bool{}
+	r := NewSystemRefreshResource()
+	testConfigure(t, r, testAPIClient(t, func(w http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/system/schedulePackageRefresh", "/system/scheduleHardwareRefresh":
+			var body map[string]interface{}
+			_ = json.NewDecoder(req.Body).Decode(&body)
+			if body["sid"] != float64(1000010001) || body["earliestOccurrence"] == nil {
+				t.Errorf("unexpected body %v", body)
+			}
+			scheduled = append(scheduled, req.URL.Path)
+			_, _ = w.Write([]byte(`{"success": true, "result": ` + map[string]string{
+				"/system/schedulePackageRefresh":  "501",
+				"/system/scheduleHardwareRefresh": "502",
+			}[req.URL.Path] + `}`))
+		case "/schedule/listCompletedSystems":
+			waited[req.URL.Query().Get("actionId")] = true
+			_, _ = w.Write([]byte(`{"success": true, "result": [{"server_id": 1000010001}]}`))
+		default:
+			_, _ = w.Write([]byte(`{"success": true, "result": []}`))
+		}
+	}))
+
+	planned := testState(t, r, map[string]interface{}{
+		"system_id":        int64(1000010001),
+		"package_refresh":  true,
+		"hardware_refresh": true,
+		"wait":             true,
+	})
+	resp := &resource.CreateResponse{State: planned}
+	r.Create(ctx, resource.CreateRequest{Plan: tfsdk.Plan{Schema: planned.Schema, Raw: planned.Raw}}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+	if len(scheduled) != 2 || !waited["501"] || !waited["502"] {
+		t.Errorf("expected both refreshes to be scheduled and waited for, got %v and %v", scheduled, waited)
+	}
+
+	var state systemRefreshResourceModel
+	resp.State.Get(ctx, &state)
+	if state.PackageRefreshActionID.ValueInt64() != 501 || state.HardwareRefreshActionID.ValueInt64() != 502 {
+		t.Errorf("unexpected action ids %s and %s", state.PackageRefreshActionID, state.HardwareRefreshActionID)
+	}
+}
+
+func TestSystemRefreshPendingActions(t *testing.T) {
+	ctx := context.Background()
+	var canceled []interface{}
+	r := NewSystemRefreshResource()
+	testConfigure(t, r, testAPIClient(t, func(w http.ResponseWriter, req *http.Request) {
+		switch {
+		case req.URL.Path == "/schedule/cancelActions":
+			var body struct {
+				ActionIDs []interface{} `json:"actionIds"`
+			}
+			_ = json.NewDecoder(req.Body).Decode(&body)
+			canceled = append(canceled, body.ActionIDs...)
+			_, _ = w.Write([]byte(`{"success": true, "result": 1}`))
+		case req.URL.Path == "/system/schedulePackageRefresh":
+			_, _ = w.Write([]byte(`{"success": true, "result": 501}`))
+		case req.URL.Path == "/system/scheduleHardwareRefresh":
+			_, _ = w.Write([]byte(`{"success": true, "result": 502}`))
+		case req.URL.Path == "/schedule/listInProgressSystems" && req.URL.Query().Get("actionId") == "502":
+			_, _ = w.Write([]byte(`{"success": true, "result": [{"server_id": 1000010001}]}`))
+		default:
+			_, _ = w.Write([]byte(`{"success": true, "result": []}`))
+		}
+	}))
+
+	planned := testState(t, r, map[string]interface{}{
+		"system_id":         int64(1000010001),
+		"package_refresh":   true,
+		"hardware_refresh":  true,
+		"wait":              false,
+		"cancel_on_destroy": true,
+	})
+	createResp := &resource.CreateResponse{State: planned}
+	r.Create(ctx, resource.CreateRequest{Plan: tfsdk.Plan{Schema: planned.Schema, Raw: planned.Raw}}, createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatal(createResp.Diagnostics)
+	}
+	var state systemRefreshResourceModel
+	createResp.State.Get(ctx, &state)
+	if state.PackageRefreshStatus.ValueString() != actionStatusPending || state.HardwareRefreshStatus.ValueString() != actionStatusPending {
+		t.Errorf("expected pending actions, got %s and %s", state.PackageRefreshStatus, state.HardwareRefreshStatus)
+	}
+
+	// The package refresh finished meanwhile, the hardware refresh did not.
+	readResp := &resource.ReadResponse{State: createResp.State}
+	r.Read(ctx, resource.ReadRequest{State: createResp.State}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatal(readResp.Diagnostics)
+	}
+	readResp.State.Get(ctx, &state)
+	if state.PackageRefreshStatus.ValueString() != actionStatusCompleted || state.HardwareRefreshStatus.ValueString() != actionStatusPending {
+		t.Errorf("expected the package refresh to be completed, got %s and %s", state.PackageRefreshStatus, state.HardwareRefreshStatus)
+	}
+
+	// Only the pending hardware refresh is canceled.
+	deleteResp := &resource.DeleteResponse{}
+	r.Delete(ctx, resource.DeleteRequest{State: readResp.State}, deleteResp)
+	if deleteResp.Diagnostics.HasError() {
+		t.Fatal(deleteResp.Diagnostics)
+	}
+	if fmt.Sprint(canceled) != "[502]" {
+		t.Errorf("expected the hardware refresh to be canceled, got %v", canceled)
+	}
+}
+
+func TestAccSystemRefreshResource(t *testing.T) {
+	minion := os.Getenv("UYUNI_TEST_MINION_ID")
+	acctest.Test(t, acctest.TestCase{
+		PreCheck: func() {
+			testAccRealServerPreCheck(t)
+			testAccSkipUnlessEnv(t, "UYUNI_TEST_MINION_ID", "a registered Salt minion")
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []acctest.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "uyuni_system_refresh" "test" {
+  system_id = %s
+}
+`, minion),
+				Check: acctest.ComposeAggregateTestCheckFunc(
+					acctest.TestCheckResourceAttr("uyuni_system_refresh.test", "package_refresh_status", "completed"),
+					acctest.TestCheckResourceAttr("uyuni_system_refresh.test", "hardware_refresh_status", "completed"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,339 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"terraform-provider-uyuni/internal/validators"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource              = &systemReprovisionResource{}
+	_ resource.ResourceWithConfigure = &systemReprovisionResource{}
+)
+
+// NewSystemReprovisionResource is a helper function to simplify the provider implementation.
+func NewSystemReprovisionResource() resource.Resource {
+	return &systemReprovisionResource{}
+}
+
+// systemReprovisionResource is the resource implementation.
+type systemReprovisionResource struct {
+	client *uyuniClient
+}
+
+// systemReprovisionResourceModel maps the resource schema data.
+type systemReprovisionResourceModel struct {
+	ID                        types.String   `tfsdk:"id"`
+	SystemID                  types.Int64    `tfsdk:"system_id"`
+	ProfileName               types.String   `tfsdk:"profile_name"`
+	NotBefore                 types.String   `tfsdk:"not_before"`
+	RespectMaintenanceWindows types.Bool     `tfsdk:"respect_maintenance_windows"`
+	Wait                      types.Bool     `tfsdk:"wait"`
+	Triggers                  types.Map      `tfsdk:"triggers"`
+	EarliestOccurrence        types.String   `tfsdk:"earliest_occurrence"`
+	ActionID                  types.Int64    `tfsdk:"action_id"`
+	Status                    types.String   `tfsdk:"status"`
+	CancelOnDestroy           types.Bool     `tfsdk:"cancel_on_destroy"`
+	Notify                    *notifyModel   `tfsdk:"notify"`
+	ServerAlias               types.String   `tfsdk:"server_alias"`
+	Timeouts                  timeouts.Value `tfsdk:"timeouts"`
+}
+
+// Metadata returns the resource type name.
+func (r *systemReprovisionResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_system_reprovision"
+}
+
+// Schema defines the schema for the resource.
+func (r *systemReprovisionResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Schedules the reinstallation of an existing system with an autoinstall profile when created. " +
+			"The system reboots into the installer and is installed from scratch, all data on it is lost. " +
+			"Change triggers, e.g. to the checksum of the profile, to reinstall it again. " +
+			"Destroying the resource does not undo the reinstallation.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "ID of the action.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"system_id": schema.Int64Attribute{
+				Description: "ID of the system to reinstall.",
+				Required:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"profile_name": schema.StringAttribute{
+				Description: "Label of the autoinstall profile to install the system with, e.g. from uyuni_autoinstall_profile.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"not_before": schema.StringAttribute{
+				Description: "Earliest date the reinstallation may start at, in RFC 3339 format, e.g. `2030-01-01T02:00:00Z`. " +
+					"Defaults to the time of apply.",
+				Optional: true,
+				Validators: []validator.String{
+					validators.Timestamp(),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"respect_maintenance_windows": schema.BoolAttribute{
+				Description: "Schedule the reinstallation for the next maintenance window of the system from not_before on, " +
+					"unless a window is open. With wait, the create timeout must last until the window. Defaults to false.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"wait": schema.BoolAttribute{
+				Description: "Wait until the action finished, which is when the system rebooted into the installer. " +
+					"The installation itself is not waited for. Defaults to true.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(true),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"triggers": schema.MapAttribute{
+				Description: "Arbitrary values which reinstall the system again when they change.",
+				ElementType: types.StringType,
+				Optional:    true,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"cancel_on_destroy": cancelOnDestroyAttribute(),
+			"earliest_occurrence": schema.StringAttribute{
+				Description: "Date the reinstallation was scheduled for, in RFC 3339 format.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"action_id": schema.Int64Attribute{
+				Description: "ID of the action.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"status": schema.StringAttribute{
+				Description: "Status of the action: `pending`, `completed` or `failed`. Pending statuses are refreshed.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"server_alias": serverAliasAttribute(),
+		},
+		Blocks: map[string]schema.Block{
+			"notify": notifyBlock(),
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+			}),
+		},
+	}
+}
+
+// Create schedules the reinstallation and waits for it.
+func (r *systemReprovisionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan systemReprovisionResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := resourceClient(ctx, r.client, plan.ServerAlias, nil, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	sid, profile := plan.SystemID.ValueInt64(), plan.ProfileName.ValueString()
+	earliest := time.Now()
+	var err error
+	if !plan.NotBefore.IsNull() {
+		if earliest, err = time.Parse(time.RFC3339, plan.NotBefore.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("not_before"), "Error reprovisioning system", err.Error())
+			return
+		}
+	}
+	if plan.RespectMaintenanceWindows.ValueBool() {
+		earliest, err = maintenanceWindowStart(ctx, client, []int64{sid}, earliest)
+		if err != nil {
+			resp.Diagnostics.AddError("Error reprovisioning system", "Could not find the next maintenance window: "+err.Error())
+			return
+		}
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Reprovisioning system %d with autoinstall profile %s", sid, profile), map[string]interface{}{
+		"earliest": earliest.Format(time.RFC3339),
+	})
+	actionID, err := apiPost[int64](ctx, client, "system/provisionSystem", map[string]interface{}{
+		"sid":          sid,
+		"profileName":  profile,
+		"earliestDate": apiDate(earliest),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reprovisioning system",
+			fmt.Sprintf("Could not schedule the reinstallation of system %d with autoinstall profile %s: %s", sid, profile, err),
+		)
+		return
+	}
+
+	plan.ID = types.StringValue(strconv.FormatInt(actionID.Result, 10))
+	plan.ActionID = types.Int64Value(actionID.Result)
+	plan.EarliestOccurrence = types.StringValue(apiDate(earliest))
+	plan.Status = types.StringValue(actionStatusPending)
+
+	if plan.Wait.ValueBool() {
+		err := waitForAction(ctx, client, actionID.Result, sid)
+		plan.Status = types.StringValue(waitedActionStatus(err))
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error reprovisioning system",
+				fmt.Sprintf("Could not reinstall system %d: %s", sid, err),
+			)
+		} else {
+			tflog.Info(ctx, fmt.Sprintf("System %d rebooted into the installer", sid))
+		}
+	}
+	plan.Notify.send(ctx, notification{
+		Resource:  "uyuni_system_reprovision",
+		ID:        plan.ID.ValueString(),
+		Status:    plan.Status.ValueString(),
+		ActionIDs: []int64{actionID.Result},
+		SystemIDs: []int64{sid},
+	}, &resp.Diagnostics)
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read keeps the state, the action is history once it ran. Only a pending
+// status is refreshed.
+func (r *systemReprovisionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state systemReprovisionResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := resourceClient(ctx, r.client, state.ServerAlias, nil, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	status, err := refreshActionStatus(ctx, client, state.ActionID, state.Status)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Reading Uyuni system reprovisioning", err.Error())
+		return
+	}
+	state.Status = status
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update only changes cancel_on_destroy and timeouts, all other changes
+// reinstall the system again.
+func (r *systemReprovisionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan systemReprovisionResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete cancels the reinstallation if it is still pending, unless
+// cancel_on_destroy is false. A system already reinstalled is not changed.
+func (r *systemReprovisionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state systemReprovisionResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := resourceClient(ctx, r.client, state.ServerAlias, nil, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	if state.CancelOnDestroy.ValueBool() && state.Status.ValueString() == actionStatusPending {
+		if err := cancelPendingActions(ctx, client, []int64{state.ActionID.ValueInt64()}); err != nil {
+			resp.Diagnostics.AddError("Error Deleting Uyuni system reprovisioning", err.Error())
+			return
+		}
+	}
+	tflog.Info(ctx, "Removing reprovisioning from state, the system is not changed")
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *systemReprovisionResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*uyuniClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *uyuniClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
@@ -0,0 +1,103 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	acctest "github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestSystemReprovisionSchedulesAndWaits(t *testing.T) {
+	ctx := context.Background()
+	actionPollInterval = time.Millisecond
+	t.Cleanup(func() { actionPollInterval = 10 * time.Second })
+
+	for name, tc := range map[string]struct {
+		wait   bool
+		failed bool
+		status string
+	}{
+		"waits":         {wait: true, status: actionStatusCompleted},
+		"fails":         {wait: true, failed: true, status: actionStatusFailed},
+		"does not wait": {status: actionStatusPending},
+	} {
+		var scheduled map[string]interface{}
+		r := NewSystemReprovisionResource()
+		testConfigure(t, r, testAPIClient(t, func(w http.ResponseWriter, req *http.Request) {
+			switch req.URL.Path {
+			case "/system/provisionSystem":
+				_ = json.NewDecoder(req.Body).Decode(&scheduled)
+				_, _ = w.Write([]byte(`{"success": true, "result": 4711}`))
+			case "/schedule/listFailedSystems", "/schedule/listCompletedSystems":
+				if !tc.wait {
+					t.Errorf("%s: unexpected request %s", name, req.URL)
+				}
+				if tc.failed != (req.URL.Path == "/schedule/listFailedSystems") {
+					_, _ = w.Write([]byte(`{"success": true, "result": []}`))
+					return
+				}
+				_, _ = w.Write([]byte(`{"success": true, "result": [{"server_id": 1000010001, "message": "no profile"}]}`))
+			default:
+				t.Errorf("%s: unexpected request %s", name, req.URL)
+			}
+		}))
+
+		planned := testState(t, r, map[string]interface{}{
+			"system_id":                   1000010001,
+			"profile_name":                "sles15-sp6-web",
+			"not_before":                  "2030-01-01T02:00:00Z",
+			"respect_maintenance_windows": false,
+			"wait":                        tc.wait,
+			"cancel_on_destroy":           true,
+		})
+		resp := &resource.CreateResponse{State: planned}
+		r.Create(ctx, resource.CreateRequest{Plan: tfsdk.Plan{Schema: planned.Schema, Raw: planned.Raw}}, resp)
+		if resp.Diagnostics.HasError() != tc.failed {
+			t.Errorf("%s: unexpected diagnostics %v", name, resp.Diagnostics)
+		}
+		if scheduled["sid"] != float64(1000010001) || scheduled["profileName"] != "sles15-sp6-web" ||
+			scheduled["earliestDate"] != "2030-01-01T02:00:00Z" {
+			t.Errorf("%s: unexpected request body %v", name, scheduled)
+		}
+
+		var state systemReprovisionResourceModel
+		resp.State.Get(ctx, &state)
+		if state.ID.ValueString() != "4711" || state.ActionID.ValueInt64() != 4711 || state.Status.ValueString() != tc.status {
+			t.Errorf("%s: unexpected state %v", name, state)
+		}
+	}
+}
+
+func TestAccSystemReprovisionResource(t *testing.T) {
+	system := os.Getenv("UYUNI_TEST_REPROVISION_SYSTEM_ID")
+	acctest.Test(t, acctest.TestCase{
+		PreCheck: func() {
+			testAccRealServerPreCheck(t)
+			testAccSkipUnlessEnv(t, "UYUNI_TEST_REPROVISION_SYSTEM_ID", "a registered system that may be reinstalled")
+			testAccSkipUnlessEnv(t, "UYUNI_TEST_AUTOINSTALL_PROFILE", "an autoinstall profile for UYUNI_TEST_REPROVISION_SYSTEM_ID")
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []acctest.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "uyuni_system_reprovision" "test" {
+  system_id    = %s
+  profile_name = %q
+  wait         = false
+}
+`, system, os.Getenv("UYUNI_TEST_AUTOINSTALL_PROFILE")),
+				Check: acctest.ComposeAggregateTestCheckFunc(
+					acctest.TestCheckResourceAttrSet("uyuni_system_reprovision.test", "action_id"),
+					acctest.TestCheckResourceAttrSet("uyuni_system_reprovision.test", "earliest_occurrence"),
+				),
+			},
+		},
+	})
+}
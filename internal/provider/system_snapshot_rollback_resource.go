@@ -0,0 +1,172 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource              = &systemSnapshotRollbackResource{}
+	_ resource.ResourceWithConfigure = &systemSnapshotRollbackResource{}
+)
+
+// NewSystemSnapshotRollbackResource is a helper function to simplify the provider implementation.
+func NewSystemSnapshotRollbackResource() resource.Resource {
+	return &systemSnapshotRollbackResource{}
+}
+
+// systemSnapshotRollbackResource is the resource implementation.
+type systemSnapshotRollbackResource struct {
+	client *uyuniClient
+}
+
+// systemSnapshotRollbackResourceModel maps the resource schema data.
+type systemSnapshotRollbackResourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	SystemID    types.Int64  `tfsdk:"system_id"`
+	SnapshotID  types.Int64  `tfsdk:"snapshot_id"`
+	Triggers    types.Map    `tfsdk:"triggers"`
+	ServerAlias types.String `tfsdk:"server_alias"`
+}
+
+// Metadata returns the resource type name.
+func (r *systemSnapshotRollbackResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_system_snapshot_rollback"
+}
+
+// Schema defines the schema for the resource.
+func (r *systemSnapshotRollbackResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Rolls a system back to a snapshot when created. " +
+			"The rollback restores the channels, groups, entitlements and configuration channels of the snapshot and schedules the package changes. " +
+			"Change triggers to roll back again; destroying the resource does not undo the rollback.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "System ID and snapshot ID, separated by a colon.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"system_id": schema.Int64Attribute{
+				Description: "ID of the system.",
+				Required:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"snapshot_id": schema.Int64Attribute{
+				Description: "ID of the snapshot to roll back to, e.g. from uyuni_system_snapshot_tag or the uyuni_system_snapshots data source.",
+				Required:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"triggers": schema.MapAttribute{
+				Description: "Arbitrary values which roll the system back again when they change.",
+				ElementType: types.StringType,
+				Optional:    true,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"server_alias": serverAliasAttribute(),
+		},
+	}
+}
+
+// Create rolls the system back.
+func (r *systemSnapshotRollbackResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan systemSnapshotRollbackResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := resourceClient(ctx, r.client, plan.ServerAlias, nil, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	sid, snapID := plan.SystemID.ValueInt64(), plan.SnapshotID.ValueInt64()
+	tflog.Info(ctx, fmt.Sprintf("Rolling system %d back to snapshot %d", sid, snapID))
+	_, err := apiPost[int](ctx, client, "system/provisioning/snapshot/rollbackToSnapshot", map[string]interface{}{
+		"sid":    sid,
+		"snapId": snapID,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error rolling back system",
+			fmt.Sprintf("Could not roll system %d back to snapshot %d: %s", sid, snapID, err),
+		)
+		return
+	}
+
+	plan.ID = types.StringValue(fmt.Sprintf("%d%s%d", sid, importIDSeparator, snapID))
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read keeps the state, a rollback has no lasting object on the server.
+func (r *systemSnapshotRollbackResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state systemSnapshotRollbackResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update is not supported, all changes roll the system back again.
+func (r *systemSnapshotRollbackResource) Update(_ context.Context, _ resource.UpdateRequest, resp *resource.UpdateResponse) {
+	resp.Diagnostics.AddError(
+		"Error updating rollback",
+		"Rollbacks cannot be updated. Please report this issue to the provider developers.",
+	)
+}
+
+// Delete removes the rollback from state. The system is not changed.
+func (r *systemSnapshotRollbackResource) Delete(ctx context.Context, _ resource.DeleteRequest, _ *resource.DeleteResponse) {
+	tflog.Info(ctx, "Removing rollback from state, the system is not changed")
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *systemSnapshotRollbackResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*uyuniClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *uyuniClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
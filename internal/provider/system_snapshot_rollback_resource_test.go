@@ -0,0 +1,68 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	acctest "github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestSystemSnapshotRollbackCreate(t *testing.T) {
+	ctx := context.Background()
+	var rolledBack map[string]int64
+	r := NewSystemSnapshotRollbackResource()
+	testConfigure(t, r, testAPIClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/system/provisioning/snapshot/rollbackToSnapshot" {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		_ = json.NewDecoder(r.Body).Decode(&rolledBack)
+		_, _ = w.Write([]byte(`{"success": true, "result": 1}`))
+	}))
+
+	planned := testState(t, r, map[string]interface{}{
+		"system_id":   int64(1000010001),
+		"snapshot_id": int64(41),
+	})
+	resp := &resource.CreateResponse{State: planned}
+	r.Create(ctx, resource.CreateRequest{Plan: tfsdk.Plan{Schema: planned.Schema, Raw: planned.Raw}}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+	if rolledBack["sid"] != 1000010001 || rolledBack["snapId"] != 41 {
+		t.Errorf("got rollback request %v", rolledBack)
+	}
+
+	var state systemSnapshotRollbackResourceModel
+	resp.State.Get(ctx, &state)
+	if state.ID.ValueString() != "1000010001:41" {
+		t.Errorf("got id %s", state.ID)
+	}
+}
+
+func TestAccSystemSnapshotRollbackResource(t *testing.T) {
+	minion := os.Getenv("UYUNI_TEST_MINION_ID")
+	acctest.Test(t, acctest.TestCase{
+		PreCheck: func() {
+			testAccRealServerPreCheck(t)
+			testAccSkipUnlessEnv(t, "UYUNI_TEST_MINION_ID", "a registered Salt minion with snapshots")
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []acctest.TestStep{
+			{
+				// Rolling back to the latest snapshot leaves the system as it is.
+				Config: testAccSystemSnapshotTagResourceConfig(minion) + `
+resource "uyuni_system_snapshot_rollback" "test" {
+  system_id   = uyuni_system_snapshot_tag.test.system_id
+  snapshot_id = uyuni_system_snapshot_tag.test.snapshot_id
+}
+`,
+				Check: acctest.TestCheckResourceAttrPair("uyuni_system_snapshot_rollback.test", "snapshot_id", "uyuni_system_snapshot_tag.test", "snapshot_id"),
+			},
+		},
+	})
+}
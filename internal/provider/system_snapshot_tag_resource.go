@@ -0,0 +1,296 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"terraform-provider-uyuni/internal/uyuni"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &systemSnapshotTagResource{}
+	_ resource.ResourceWithConfigure   = &systemSnapshotTagResource{}
+	_ resource.ResourceWithImportState = &systemSnapshotTagResource{}
+)
+
+// NewSystemSnapshotTagResource is a helper function to simplify the provider implementation.
+func NewSystemSnapshotTagResource() resource.Resource {
+	return &systemSnapshotTagResource{}
+}
+
+// systemSnapshotTagResource is the resource implementation.
+type systemSnapshotTagResource struct {
+	client *uyuniClient
+}
+
+// systemSnapshotTagResourceModel maps the resource schema data.
+type systemSnapshotTagResourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	SystemID    types.Int64  `tfsdk:"system_id"`
+	Name        types.String `tfsdk:"name"`
+	SnapshotID  types.Int64  `tfsdk:"snapshot_id"`
+	Created     types.String `tfsdk:"created"`
+	ServerAlias types.String `tfsdk:"server_alias"`
+}
+
+// Metadata returns the resource type name.
+func (r *systemSnapshotTagResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_system_snapshot_tag"
+}
+
+// Schema defines the schema for the resource.
+func (r *systemSnapshotTagResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Tags the latest snapshot of a system, e.g. after the system was configured, " +
+			"so that it can be rolled back to that state with uyuni_system_snapshot_rollback. " +
+			"The snapshot is taken by the server whenever the system changes; the tag only names it.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "System ID and tag name, separated by a colon.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"system_id": schema.Int64Attribute{
+				Description: "ID of the system.",
+				Required:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "Name of the tag, unique per system.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"snapshot_id": schema.Int64Attribute{
+				Description: "ID of the tagged snapshot.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"created": schema.StringAttribute{
+				Description: "Date the tagged snapshot was taken, in RFC 3339 format.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"server_alias": serverAliasAttribute(),
+		},
+	}
+}
+
+// listSnapshots returns the snapshots of the system, newest first.
+func listSnapshots(ctx context.Context, client *uyuniClient, sid int64) ([]uyuni.Snapshot, error) {
+	snapshots, err := apiGet[[]uyuni.Snapshot](ctx, client, fmt.Sprintf("system/provisioning/snapshot/listSnapshots?sid=%d", sid))
+	if err != nil {
+		return nil, err
+	}
+	return snapshots.Result, nil
+}
+
+// findSnapshotTag returns the snapshot carrying the tag, nil if no snapshot
+// does.
+func findSnapshotTag(snapshots []uyuni.Snapshot, tag string) *uyuni.Snapshot {
+	for i, snapshot := range snapshots {
+		for _, name := range snapshot.Tags {
+			if name == tag {
+				return &snapshots[i]
+			}
+		}
+	}
+	return nil
+}
+
+// readSnapshotTag sets the computed attributes from the tagged snapshot. It
+// returns false if no snapshot carries the tag.
+func (m *systemSnapshotTagResourceModel) readSnapshotTag(ctx context.Context, client *uyuniClient) (bool, error) {
+	snapshots, err := listSnapshots(ctx, client, m.SystemID.ValueInt64())
+	if err != nil {
+		return false, err
+	}
+	snapshot := findSnapshotTag(snapshots, m.Name.ValueString())
+	if snapshot == nil {
+		return false, nil
+	}
+	m.ID = types.StringValue(fmt.Sprintf("%d%s%s", m.SystemID.ValueInt64(), importIDSeparator, m.Name.ValueString()))
+	m.SnapshotID = types.Int64Value(int64(snapshot.ID))
+	m.Created = timestampValue(ctx, snapshot.Created)
+	return true, nil
+}
+
+// Create a new resource.
+func (r *systemSnapshotTagResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan systemSnapshotTagResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := resourceClient(ctx, r.client, plan.ServerAlias, nil, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	_, err := apiPost[int](ctx, client, "system/tagLatestSnapshot", map[string]interface{}{
+		"sid":     plan.SystemID.ValueInt64(),
+		"tagName": plan.Name.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error tagging snapshot",
+			fmt.Sprintf("Could not tag the latest snapshot of system %d: %s", plan.SystemID.ValueInt64(), err),
+		)
+		return
+	}
+
+	found, err := plan.readSnapshotTag(ctx, client)
+	if err == nil && !found {
+		err = fmt.Errorf("no snapshot carries the tag %s", plan.Name.ValueString())
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error tagging snapshot",
+			fmt.Sprintf("Could not read the tagged snapshot of system %d: %s", plan.SystemID.ValueInt64(), err),
+		)
+		return
+	}
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read resource information.
+func (r *systemSnapshotTagResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Get current state
+	var state systemSnapshotTagResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := resourceClient(ctx, r.client, state.ServerAlias, nil, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	found, err := state.readSnapshotTag(ctx, client)
+	if err != nil {
+		if handleNotFound(ctx, resp, err, fmt.Sprintf("System %d", state.SystemID.ValueInt64())) {
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Reading Uyuni snapshot tag",
+			fmt.Sprintf("Could not list snapshots of system %d: %s", state.SystemID.ValueInt64(), err),
+		)
+		return
+	}
+	if !found {
+		tflog.Warn(ctx, "Snapshot tag "+state.Name.ValueString()+" no longer exists, removing it from state")
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update is not supported, all changes replace the tag.
+func (r *systemSnapshotTagResource) Update(_ context.Context, _ resource.UpdateRequest, resp *resource.UpdateResponse) {
+	resp.Diagnostics.AddError(
+		"Error updating snapshot tag",
+		"Snapshot tags cannot be updated. Please report this issue to the provider developers.",
+	)
+}
+
+// Delete removes the tag. The snapshot stays.
+func (r *systemSnapshotTagResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Retrieve values from state
+	var state systemSnapshotTagResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := resourceClient(ctx, r.client, state.ServerAlias, nil, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	_, err := apiPost[int](ctx, client, "system/deleteTagFromSnapshot", map[string]interface{}{
+		"sid":     state.SystemID.ValueInt64(),
+		"tagName": state.Name.ValueString(),
+	})
+	if err != nil && !isNotFoundError(err) {
+		resp.Diagnostics.AddError(
+			"Error Deleting Uyuni snapshot tag",
+			fmt.Sprintf("Could not delete tag %s of system %d: %s", state.Name.ValueString(), state.SystemID.ValueInt64(), err),
+		)
+		return
+	}
+}
+
+// ImportState imports a tag by "system_id:name".
+func (r *systemSnapshotTagResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts, err := parseImportID(req.ID, "system_id", "name")
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Import ID", err.Error())
+		return
+	}
+	sid, err := parseImportInt64("system_id", parts[0])
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Import ID", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("system_id"), sid)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), parts[1])...)
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *systemSnapshotTagResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*uyuniClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *uyuniClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
@@ -0,0 +1,137 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	acctest "github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+// testSnapshotServer serves the snapshots 42 and 41 of system 1000010001 and
+// moves the tags it is asked to add to the latest snapshot.
+type testSnapshotServer struct {
+	tags []string
+}
+
+func (s *testSnapshotServer) handler(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case "/system/tagLatestSnapshot":
+		var body struct {
+			TagName string `json:"tagName"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		s.tags = append(s.tags, body.TagName)
+		_, _ = w.Write([]byte(`{"success": true, "result": 1}`))
+	case "/system/provisioning/snapshot/listSnapshots":
+		if r.URL.Query().Get("sid") != "1000010001" {
+			_, _ = w.Write([]byte(`{"success": false, "message": "No such system - sid = ` + r.URL.Query().Get("sid") + `"}`))
+			return
+		}
+		tags, _ := json.Marshal(append([]string{}, s.tags...))
+		_, _ = w.Write([]byte(`{"success": true, "result": [
+			{"id": 42, "reason": "Package profile changed", "created": "2024-09-02T10:15:00Z", "modified": "2024-09-02T10:15:00Z",
+			 "channels": ["pool"], "groups": [], "entitlements": [], "config_channels": [], "tags": ` + string(tags) + `},
+			{"id": 41, "reason": "Subscribed to channel", "created": "2024-09-01T10:15:00Z", "modified": "2024-09-01T10:15:00Z",
+			 "channels": ["pool"], "groups": [], "entitlements": [], "config_channels": [], "tags": ["baseline"]}
+		]}`))
+	default:
+		_, _ = w.Write([]byte(`{"success": false, "message": "unexpected request ` + r.URL.String() + `"}`))
+	}
+}
+
+func TestSystemSnapshotTagCreate(t *testing.T) {
+	ctx := context.Background()
+	server := &testSnapshotServer{}
+	r := NewSystemSnapshotTagResource()
+	testConfigure(t, r, testAPIClient(t, server.handler))
+
+	planned := testState(t, r, map[string]interface{}{
+		"system_id": int64(1000010001),
+		"name":      "configured",
+	})
+	resp := &resource.CreateResponse{State: planned}
+	r.Create(ctx, resource.CreateRequest{Plan: tfsdk.Plan{Schema: planned.Schema, Raw: planned.Raw}}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+
+	var state systemSnapshotTagResourceModel
+	resp.State.Get(ctx, &state)
+	if state.ID.ValueString() != "1000010001:configured" || state.SnapshotID.ValueInt64() != 42 {
+		t.Errorf("got id %s and snapshot %s", state.ID, state.SnapshotID)
+	}
+	if state.Created.ValueString() != "2024-09-02T10:15:00Z" {
+		t.Errorf("got created %s", state.Created)
+	}
+}
+
+func TestSystemSnapshotTagRead(t *testing.T) {
+	ctx := context.Background()
+	server := &testSnapshotServer{}
+
+	resp := testRead(t, NewSystemSnapshotTagResource(), testAPIClient(t, server.handler), map[string]interface{}{
+		"system_id": int64(1000010001),
+		"name":      "baseline",
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+	var state systemSnapshotTagResourceModel
+	resp.State.Get(ctx, &state)
+	if state.SnapshotID.ValueInt64() != 41 {
+		t.Errorf("expected the older snapshot carrying the tag, got %s", state.SnapshotID)
+	}
+
+	for _, attributes := range []map[string]interface{}{
+		{"system_id": int64(1000010001), "name": "deleted"},
+		{"system_id": int64(1000010002), "name": "baseline"},
+	} {
+		resp := testRead(t, NewSystemSnapshotTagResource(), testAPIClient(t, server.handler), attributes)
+		if resp.Diagnostics.HasError() || !resp.State.Raw.IsNull() {
+			t.Errorf("%v: expected the tag to be removed from state, got %v", attributes, resp.Diagnostics)
+		}
+	}
+}
+
+func TestAccSystemSnapshotTagResource(t *testing.T) {
+	minion := os.Getenv("UYUNI_TEST_MINION_ID")
+	acctest.Test(t, acctest.TestCase{
+		PreCheck: func() {
+			testAccRealServerPreCheck(t)
+			testAccSkipUnlessEnv(t, "UYUNI_TEST_MINION_ID", "a registered Salt minion with snapshots")
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []acctest.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccSystemSnapshotTagResourceConfig(minion),
+				Check: acctest.ComposeAggregateTestCheckFunc(
+					acctest.TestCheckResourceAttr("uyuni_system_snapshot_tag.test", "id", minion+":tfacc-tag"),
+					acctest.TestCheckResourceAttrSet("uyuni_system_snapshot_tag.test", "snapshot_id"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "uyuni_system_snapshot_tag.test",
+				ImportState:       true,
+				ImportStateId:     minion + ":tfacc-tag",
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccSystemSnapshotTagResourceConfig(systemID string) string {
+	return fmt.Sprintf(`
+resource "uyuni_system_snapshot_tag" "test" {
+  system_id = %s
+  name      = "tfacc-tag"
+}
+`, systemID)
+}
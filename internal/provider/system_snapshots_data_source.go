@@ -0,0 +1,158 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &SystemSnapshotsDataSource{}
+	_ datasource.DataSourceWithConfigure = &SystemSnapshotsDataSource{}
+)
+
+// SystemSnapshotsDataSourceModel maps the data source schema data.
+type SystemSnapshotsDataSourceModel struct {
+	SystemID  types.Int64     `tfsdk:"system_id"`
+	Snapshots []snapshotModel `tfsdk:"snapshots"`
+}
+
+// snapshotModel maps snapshot schema data.
+type snapshotModel struct {
+	ID       types.Int64  `tfsdk:"id"`
+	Reason   types.String `tfsdk:"reason"`
+	Created  types.String `tfsdk:"created"`
+	Tags     types.Set    `tfsdk:"tags"`
+	Channels types.Set    `tfsdk:"channels"`
+	Groups   types.Set    `tfsdk:"groups"`
+}
+
+// NewSystemSnapshotsDataSource is a helper function to simplify the provider implementation.
+func NewSystemSnapshotsDataSource() datasource.DataSource {
+	return &SystemSnapshotsDataSource{}
+}
+
+// SystemSnapshotsDataSource is the data source implementation.
+type SystemSnapshotsDataSource struct {
+	client *uyuniClient
+}
+
+// Metadata returns the data source type name.
+func (d *SystemSnapshotsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_system_snapshots"
+}
+
+// Schema defines the schema for the data source.
+func (d *SystemSnapshotsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the snapshots of a system, e.g. to roll it back with uyuni_system_snapshot_rollback.",
+		Attributes: map[string]schema.Attribute{
+			"system_id": schema.Int64Attribute{
+				Description: "ID of the system.",
+				Required:    true,
+			},
+			"snapshots": schema.ListNestedAttribute{
+				Description: "Snapshots of the system, newest first.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.Int64Attribute{
+							Description: "ID of the snapshot.",
+							Computed:    true,
+						},
+						"reason": schema.StringAttribute{
+							Description: "Change of the system that caused the snapshot.",
+							Computed:    true,
+						},
+						"created": schema.StringAttribute{
+							Description: "Date the snapshot was taken, in RFC 3339 format.",
+							Computed:    true,
+						},
+						"tags": schema.SetAttribute{
+							Description: "Names of the tags of the snapshot.",
+							ElementType: types.StringType,
+							Computed:    true,
+						},
+						"channels": schema.SetAttribute{
+							Description: "Labels of the channels the system was subscribed to.",
+							ElementType: types.StringType,
+							Computed:    true,
+						},
+						"groups": schema.SetAttribute{
+							Description: "Names of the system groups the system was a member of.",
+							ElementType: types.StringType,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *SystemSnapshotsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state SystemSnapshotsDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	snapshots, err := listSnapshots(ctx, d.client, state.SystemID.ValueInt64())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Uyuni system snapshots",
+			err.Error(),
+		)
+		return
+	}
+
+	state.Snapshots = make([]snapshotModel, 0, len(snapshots))
+	for _, snapshot := range snapshots {
+		model := snapshotModel{
+			ID:      types.Int64Value(int64(snapshot.ID)),
+			Reason:  types.StringValue(snapshot.Reason),
+			Created: timestampValue(ctx, snapshot.Created),
+		}
+		model.Tags, diags = types.SetValueFrom(ctx, types.StringType, append([]string{}, snapshot.Tags...))
+		resp.Diagnostics.Append(diags...)
+		model.Channels, diags = types.SetValueFrom(ctx, types.StringType, append([]string{}, snapshot.Channels...))
+		resp.Diagnostics.Append(diags...)
+		model.Groups, diags = types.SetValueFrom(ctx, types.StringType, append([]string{}, snapshot.Groups...))
+		resp.Diagnostics.Append(diags...)
+		state.Snapshots = append(state.Snapshots, model)
+	}
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *SystemSnapshotsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*uyuniClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *uyuniClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
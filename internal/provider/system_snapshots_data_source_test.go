@@ -0,0 +1,70 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestSystemSnapshotsDataSource(t *testing.T) {
+	ctx := context.Background()
+	server := &testSnapshotServer{}
+	d := NewSystemSnapshotsDataSource()
+	d.(datasource.DataSourceWithConfigure).Configure(ctx, datasource.ConfigureRequest{ProviderData: testAPIClient(t, server.handler)}, &datasource.ConfigureResponse{})
+	var schemaResp datasource.SchemaResponse
+	d.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	config := tfsdk.Config{
+		Schema: schemaResp.Schema,
+		Raw: tftypes.NewValue(objectType, map[string]tftypes.Value{
+			"system_id": tftypes.NewValue(tftypes.Number, 1000010001),
+			"snapshots": tftypes.NewValue(objectType.AttributeTypes["snapshots"], nil),
+		}),
+	}
+	resp := &datasource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: config.Raw}}
+	d.Read(ctx, datasource.ReadRequest{Config: config}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+
+	var state SystemSnapshotsDataSourceModel
+	resp.State.Get(ctx, &state)
+	if len(state.Snapshots) != 2 {
+		t.Fatalf("expected two snapshots, got %v", state.Snapshots)
+	}
+	latest, oldest := state.Snapshots[0], state.Snapshots[1]
+	if latest.ID.ValueInt64() != 42 || latest.Created.ValueString() != "2024-09-02T10:15:00Z" || len(latest.Tags.Elements()) != 0 {
+		t.Errorf("got latest snapshot %v", latest)
+	}
+	if oldest.Tags.String() != `["baseline"]` || oldest.Groups.IsNull() {
+		t.Errorf("got oldest snapshot %v", oldest)
+	}
+}
+
+func TestAccSystemSnapshotsDataSource(t *testing.T) {
+	minion := os.Getenv("UYUNI_TEST_MINION_ID")
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccRealServerPreCheck(t)
+			testAccSkipUnlessEnv(t, "UYUNI_TEST_MINION_ID", "a registered Salt minion")
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				// The registration of a system takes its first snapshot.
+				Config: fmt.Sprintf(`data "uyuni_system_snapshots" "test" { system_id = %s }`, minion),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.uyuni_system_snapshots.test", "snapshots.0.id"),
+					resource.TestCheckResourceAttrSet("data.uyuni_system_snapshots.test", "snapshots.0.created"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,415 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"terraform-provider-uyuni/internal/validators"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource              = &systemsChannelChangeResource{}
+	_ resource.ResourceWithConfigure = &systemsChannelChangeResource{}
+)
+
+// NewSystemsChannelChangeResource is a helper function to simplify the provider implementation.
+func NewSystemsChannelChangeResource() resource.Resource {
+	return &systemsChannelChangeResource{}
+}
+
+// systemsChannelChangeResource is the resource implementation.
+type systemsChannelChangeResource struct {
+	client *uyuniClient
+}
+
+// systemsChannelChangeResourceModel maps the resource schema data.
+type systemsChannelChangeResourceModel struct {
+	ID                 types.String   `tfsdk:"id"`
+	Target             *targetModel   `tfsdk:"target"`
+	BaseChannelLabel   types.String   `tfsdk:"base_channel_label"`
+	ChildChannelLabels types.Set      `tfsdk:"child_channel_labels"`
+	Parallelism        types.Int64    `tfsdk:"parallelism"`
+	Wait               types.Bool     `tfsdk:"wait"`
+	Triggers           types.Map      `tfsdk:"triggers"`
+	SystemIDs          types.Set      `tfsdk:"system_ids"`
+	FailedSystemIDs    types.Set      `tfsdk:"failed_system_ids"`
+	ActionIDs          types.Set      `tfsdk:"action_ids"`
+	Status             types.String   `tfsdk:"status"`
+	CancelOnDestroy    types.Bool     `tfsdk:"cancel_on_destroy"`
+	Notify             *notifyModel   `tfsdk:"notify"`
+	ServerAlias        types.String   `tfsdk:"server_alias"`
+	Timeouts           timeouts.Value `tfsdk:"timeouts"`
+}
+
+// Metadata returns the resource type name.
+func (r *systemsChannelChangeResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_systems_channel_change"
+}
+
+// Schema defines the schema for the resource.
+func (r *systemsChannelChangeResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Changes the base and child channels of many systems when created, e.g. to move a migration wave " +
+			"of hundreds of servers to the channels of the next service pack, and waits for the changes. Each system gets its " +
+			"own action. Change triggers to change the channels again. Destroying the resource does not restore the previous channels.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "ID of the first action.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"base_channel_label": schema.StringAttribute{
+				Description: "Label of the base channel to subscribe the systems to.",
+				Required:    true,
+				Validators: []validator.String{
+					validators.ChannelLabel(),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"child_channel_labels": schema.SetAttribute{
+				Description: "Labels of the child channels of the base channel to subscribe the systems to. " +
+					"The systems are unsubscribed from all other child channels.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(validators.ChannelLabel()),
+				},
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.RequiresReplace(),
+				},
+			},
+			"parallelism": schema.Int64Attribute{
+				Description: fmt.Sprintf("Number of systems whose changes are scheduled and awaited at the same time. "+
+					"Defaults to %d.", batchConcurrency),
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, 64),
+				},
+			},
+			"wait": schema.BoolAttribute{
+				Description: "Wait until the channels changed on all systems. Defaults to true.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"triggers": schema.MapAttribute{
+				Description: "Arbitrary values which change the channels again when they change.",
+				ElementType: types.StringType,
+				Optional:    true,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"cancel_on_destroy": cancelOnDestroyAttribute(),
+			"system_ids": schema.SetAttribute{
+				Description: "IDs of the changed systems, as resolved from the target on apply.",
+				ElementType: types.Int64Type,
+				Computed:    true,
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"failed_system_ids": schema.SetAttribute{
+				Description: "IDs of the systems whose change could not be scheduled or failed.",
+				ElementType: types.Int64Type,
+				Computed:    true,
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"action_ids": schema.SetAttribute{
+				Description: "IDs of the channel change actions of all systems.",
+				ElementType: types.Int64Type,
+				Computed:    true,
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"status": schema.StringAttribute{
+				Description: "Status of the changes over all systems: `failed` if one failed on any, " +
+					"`pending` while one is queued or running on any, and `completed` otherwise. Pending statuses are refreshed.",
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"server_alias": serverAliasAttribute(),
+		},
+		Blocks: map[string]schema.Block{
+			"target": requiredTargetBlock("Systems whose channels to change."),
+			"notify": notifyBlock(),
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+			}),
+		},
+	}
+}
+
+// scheduleChannelChange schedules the change of the channels of a system
+// and returns the ID of the action.
+func scheduleChannelChange(ctx context.Context, client *uyuniClient, sid int64, base string, children []string) (int64, error) {
+	actionID, err := apiPost[int64](ctx, client, "system/scheduleChangeChannels", map[string]interface{}{
+		"sid":                sid,
+		"baseChannelLabel":   base,
+		"childLabels":        children,
+		"earliestOccurrence": apiDate(time.Now()),
+	})
+	if err != nil {
+		return 0, err
+	}
+	return actionID.Result, nil
+}
+
+// Create schedules the channel changes of every system and waits for them.
+func (r *systemsChannelChangeResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan systemsChannelChangeResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := resourceClient(ctx, r.client, plan.ServerAlias, nil, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	sids, err := plan.Target.resolve(ctx, client)
+	if err != nil {
+		resp.Diagnostics.AddError("Error changing channels", "Could not resolve the target: "+err.Error())
+		return
+	}
+	children, err := stringSet(ctx, plan.ChildChannelLabels)
+	if err != nil {
+		resp.Diagnostics.AddError("Error changing channels", err.Error())
+		return
+	}
+	sort.Strings(children)
+	parallelism := batchConcurrency
+	if !plan.Parallelism.IsNull() {
+		parallelism = int(plan.Parallelism.ValueInt64())
+	}
+
+	keys := make([]string, 0, len(sids))
+	for _, sid := range sids {
+		keys = append(keys, strconv.FormatInt(sid, 10))
+	}
+	var (
+		mu        sync.Mutex
+		actionIDs []int64
+		statuses  []string
+	)
+	record := func(status string, actionID ...int64) {
+		mu.Lock()
+		defer mu.Unlock()
+		if status != "" {
+			statuses = append(statuses, status)
+		}
+		actionIDs = append(actionIDs, actionID...)
+	}
+	errs := runBatchLimit(keys, parallelism, func(key string) error {
+		sid, _ := strconv.ParseInt(key, 10, 64)
+		actionID, err := scheduleChannelChange(ctx, client, sid, plan.BaseChannelLabel.ValueString(), children)
+		if err != nil {
+			// A change which could not be scheduled failed.
+			record(actionStatusFailed)
+			return fmt.Errorf("could not schedule the change: %w", err)
+		}
+		record("", actionID)
+
+		if !plan.Wait.ValueBool() {
+			record(actionStatusPending)
+			return nil
+		}
+		err = waitForAction(ctx, client, actionID, sid)
+		record(waitedActionStatus(err))
+		return err
+	})
+
+	// Report the failures of a wave in one error rather than one per system.
+	failed := make([]int64, 0, len(errs))
+	for _, sid := range sids {
+		if errs[strconv.FormatInt(sid, 10)] != nil {
+			failed = append(failed, sid)
+		}
+	}
+	if len(failed) > 0 {
+		resp.Diagnostics.AddError(
+			"Error changing channels",
+			fmt.Sprintf("Could not change the channels of %d of %d systems: %s", len(failed), len(sids), batchError(errs)),
+		)
+	}
+	if len(actionIDs) == 0 {
+		return
+	}
+	// Fall through to track the actions scheduled, which Terraform taints on
+	// errors.
+
+	sort.Slice(actionIDs, func(i, j int) bool { return actionIDs[i] < actionIDs[j] })
+	plan.ID = types.StringValue(strconv.FormatInt(actionIDs[0], 10))
+	plan.Status = types.StringValue(combinedActionStatus(statuses))
+	plan.SystemIDs, diags = types.SetValueFrom(ctx, types.Int64Type, sids)
+	resp.Diagnostics.Append(diags...)
+	plan.FailedSystemIDs, diags = types.SetValueFrom(ctx, types.Int64Type, failed)
+	resp.Diagnostics.Append(diags...)
+	plan.ActionIDs, diags = types.SetValueFrom(ctx, types.Int64Type, actionIDs)
+	resp.Diagnostics.Append(diags...)
+	if plan.Wait.ValueBool() && plan.Status.ValueString() == actionStatusCompleted {
+		tflog.Info(ctx, fmt.Sprintf("Changed the channels of %d systems", len(sids)))
+	}
+	plan.Notify.send(ctx, notification{
+		Resource:  "uyuni_systems_channel_change",
+		ID:        plan.ID.ValueString(),
+		Status:    plan.Status.ValueString(),
+		ActionIDs: actionIDs,
+		SystemIDs: sids,
+	}, &resp.Diagnostics)
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read keeps the state, a channel change has no lasting object on the
+// server. Only a pending status is refreshed.
+func (r *systemsChannelChangeResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state systemsChannelChangeResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := resourceClient(ctx, r.client, state.ServerAlias, nil, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	if state.Status.ValueString() == actionStatusPending {
+		actionIDs, err := int64Set(ctx, state.ActionIDs)
+		if err != nil {
+			resp.Diagnostics.AddError("Error Reading Uyuni systems channel change", err.Error())
+			return
+		}
+		statuses := make([]string, 0, len(actionIDs))
+		for _, actionID := range actionIDs {
+			status, err := actionStatus(ctx, client, actionID)
+			if err != nil {
+				// Actions deleted from the history keep their status.
+				if isNotFoundError(err) {
+					continue
+				}
+				resp.Diagnostics.AddError(
+					"Error Reading Uyuni systems channel change",
+					fmt.Sprintf("Could not read the status of action %d: %s", actionID, err),
+				)
+				return
+			}
+			statuses = append(statuses, status)
+		}
+		state.Status = types.StringValue(combinedActionStatus(statuses))
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update only changes parallelism, cancel_on_destroy and timeouts, all other
+// changes change the channels again.
+func (r *systemsChannelChangeResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan systemsChannelChangeResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete cancels changes which are still pending, unless cancel_on_destroy
+// is false, and removes the change from state. Channels already changed are
+// kept.
+func (r *systemsChannelChangeResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state systemsChannelChangeResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := resourceClient(ctx, r.client, state.ServerAlias, nil, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	if state.CancelOnDestroy.ValueBool() && state.Status.ValueString() == actionStatusPending {
+		actionIDs, err := int64Set(ctx, state.ActionIDs)
+		if err == nil {
+			err = cancelPendingActions(ctx, client, actionIDs)
+		}
+		if err != nil {
+			resp.Diagnostics.AddError("Error Deleting Uyuni systems channel change", err.Error())
+			return
+		}
+	}
+	tflog.Info(ctx, "Removing systems channel change from state, the channels of the systems are kept")
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *systemsChannelChangeResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*uyuniClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *uyuniClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
@@ -0,0 +1,109 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	acctest "github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestSystemsChannelChangeCreateAggregatesFailures(t *testing.T) {
+	ctx := context.Background()
+	var mu sync.Mutex
+	scheduled := map[string]interface{}{}
+	actionID := 100
+	r := NewSystemsChannelChangeResource()
+	testConfigure(t, r, testAPIClient(t, func(w http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/systemgroup/listSystemsMinimal":
+			_, _ = w.Write([]byte(`{"success": true, "result": [
+				{"id": 1, "name": "web01", "last_checkin": "2025-01-14T10:02:11Z", "created": "2024-11-02T08:45:37Z"},
+				{"id": 2, "name": "web02", "last_checkin": "2025-01-14T10:02:11Z", "created": "2024-11-02T08:45:37Z"},
+				{"id": 3, "name": "web03", "last_checkin": "2025-01-14T10:02:11Z", "created": "2024-11-02T08:45:37Z"}
+			]}`))
+		case "/system/scheduleChangeChannels":
+			var body map[string]interface{}
+			_ = json.NewDecoder(req.Body).Decode(&body)
+			if body["sid"] == float64(2) {
+				_, _ = w.Write([]byte(`{"success": false, "message": "Channel sles15-sp6-pool-x86_64 is not compatible with system 2"}`))
+				return
+			}
+			mu.Lock()
+			scheduled[fmt.Sprint(body["sid"])] = []interface{}{body["baseChannelLabel"], body["childLabels"]}
+			actionID++
+			_, _ = fmt.Fprintf(w, `{"success": true, "result": %d}`, actionID)
+			mu.Unlock()
+		default:
+			t.Errorf("unexpected request %s", req.URL)
+		}
+	}))
+
+	planned := testState(t, r, map[string]interface{}{
+		"target": &targetModel{
+			GroupNames: types.SetValueMust(types.StringType, []attr.Value{types.StringValue("web")}),
+			SystemIDs:  types.SetNull(types.Int64Type),
+			Search:     types.StringNull(),
+			SearchBy:   types.StringNull(),
+		},
+		"base_channel_label":   "sles15-sp6-pool-x86_64",
+		"child_channel_labels": []string{"sles15-sp6-updates-x86_64"},
+		"parallelism":          2,
+		"wait":                 false,
+	})
+	resp := &resource.CreateResponse{State: planned}
+	r.Create(ctx, resource.CreateRequest{Plan: tfsdk.Plan{Schema: planned.Schema, Raw: planned.Raw}}, resp)
+	errs := resp.Diagnostics.Errors()
+	if len(errs) != 1 || !strings.Contains(errs[0].Detail(), "1 of 3 systems") {
+		t.Errorf("expected one error for the failed system, got %v", resp.Diagnostics)
+	}
+	if fmt.Sprint(scheduled) != "map[1:[sles15-sp6-pool-x86_64 [sles15-sp6-updates-x86_64]] 3:[sles15-sp6-pool-x86_64 [sles15-sp6-updates-x86_64]]]" {
+		t.Errorf("unexpected changes %v", scheduled)
+	}
+
+	var state systemsChannelChangeResourceModel
+	resp.State.Get(ctx, &state)
+	if len(state.SystemIDs.Elements()) != 3 || len(state.ActionIDs.Elements()) != 2 || state.Status.ValueString() != actionStatusFailed ||
+		state.FailedSystemIDs.String() != "[2]" {
+		t.Errorf("unexpected state %v", state)
+	}
+}
+
+func TestAccSystemsChannelChangeResource(t *testing.T) {
+	minion := os.Getenv("UYUNI_TEST_MINION_ID")
+	acctest.Test(t, acctest.TestCase{
+		PreCheck: func() {
+			testAccRealServerPreCheck(t)
+			testAccSkipUnlessEnv(t, "UYUNI_TEST_MINION_ID", "a registered Salt minion")
+			testAccSkipUnlessEnv(t, "UYUNI_TEST_MINION_BASE_CHANNEL", "the base channel of UYUNI_TEST_MINION_ID")
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []acctest.TestStep{
+			{
+				// Changing to the current base channel leaves the system as it is.
+				Config: fmt.Sprintf(`
+resource "uyuni_systems_channel_change" "test" {
+  target {
+    system_ids = [%s]
+  }
+
+  base_channel_label = %q
+}
+`, minion, os.Getenv("UYUNI_TEST_MINION_BASE_CHANNEL")),
+				Check: acctest.ComposeAggregateTestCheckFunc(
+					acctest.TestCheckResourceAttr("uyuni_systems_channel_change.test", "status", "completed"),
+					acctest.TestCheckResourceAttr("uyuni_systems_channel_change.test", "failed_system_ids.#", "0"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,278 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"sync"
+
+	"terraform-provider-uyuni/internal/uyuni"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &SystemsDataSource{}
+	_ datasource.DataSourceWithConfigure = &SystemsDataSource{}
+)
+
+// SystemsDataSourceModel maps the data source schema data.
+type SystemsDataSourceModel struct {
+	NameRegex   types.String  `tfsdk:"name_regex"`
+	GroupName   types.String  `tfsdk:"group_name"`
+	OSInfo      types.Bool    `tfsdk:"include_os_info"`
+	Parallelism types.Int64   `tfsdk:"parallelism"`
+	SystemIDs   types.Set     `tfsdk:"system_ids"`
+	Systems     []systemModel `tfsdk:"systems"`
+}
+
+// systemModel maps a registered system.
+type systemModel struct {
+	ID               types.Int64  `tfsdk:"id"`
+	Name             types.String `tfsdk:"name"`
+	LastCheckin      types.String `tfsdk:"last_checkin"`
+	BaseChannelLabel types.String `tfsdk:"base_channel_label"`
+	osInfoModel
+}
+
+// NewSystemsDataSource is a helper function to simplify the provider implementation.
+func NewSystemsDataSource() datasource.DataSource {
+	return &SystemsDataSource{}
+}
+
+// SystemsDataSource is the data source implementation.
+type SystemsDataSource struct {
+	client *uyuniClient
+}
+
+// Metadata returns the data source type name.
+func (d *SystemsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_systems"
+}
+
+// Schema defines the schema for the data source.
+func (d *SystemsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the registered systems, e.g. to add pre-registered minions to groups with uyuni_system_group. " +
+			"Only systems the provider user can see are listed.",
+		Attributes: map[string]schema.Attribute{
+			"name_regex": schema.StringAttribute{
+				Description: "Only list systems whose name matches this regular expression, in RE2 syntax.",
+				Optional:    true,
+			},
+			"group_name": schema.StringAttribute{
+				Description: "Only list systems of this system group.",
+				Optional:    true,
+			},
+			"include_os_info": schema.BoolAttribute{
+				Description: "Whether to read the normalized operating system of the systems, " +
+					"which takes one call per system. The os_ attributes are null otherwise. Defaults to false.",
+				Optional: true,
+			},
+			"parallelism": parallelismAttribute("base channels and systems"),
+			"system_ids": schema.SetAttribute{
+				Description: "IDs of the systems.",
+				ElementType: types.Int64Type,
+				Computed:    true,
+			},
+			"systems": schema.ListNestedAttribute{
+				Description: "Systems, ordered by name.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: withOSInfoAttributes(map[string]schema.Attribute{
+						"id": schema.Int64Attribute{
+							Description: "ID of the system.",
+							Computed:    true,
+						},
+						"name": schema.StringAttribute{
+							Description: "Name of the system.",
+							Computed:    true,
+						},
+						"last_checkin": schema.StringAttribute{
+							Description: "Date the system last checked in, in RFC 3339 format.",
+							Computed:    true,
+						},
+						"base_channel_label": schema.StringAttribute{
+							Description: "Label of the base channel of the system, null if it has none.",
+							Computed:    true,
+						},
+					}),
+				},
+			},
+		},
+	}
+}
+
+// baseChannelsBySystem returns the label of the base channel of each system
+// subscribed to one. It lists the subscribers of each base channel, which
+// takes far fewer calls than asking for the base channel of each system.
+// It lists limit channels at a time.
+func baseChannelsBySystem(ctx context.Context, client *uyuniClient, limit int) (map[int]string, error) {
+	channels, err := apiGet[[]uyuni.SoftwareChannel](ctx, client, "channel/listSoftwareChannels")
+	if err != nil {
+		return nil, fmt.Errorf("could not list software channels: %w", err)
+	}
+	var labels []string
+	for _, channel := range channels.Result {
+		if channel.ParentLabel == "" {
+			labels = append(labels, channel.Label)
+		}
+	}
+
+	baseChannels := map[int]string{}
+	var mu sync.Mutex
+	errs := runBatchLimit(labels, limit, func(label string) error {
+		systems, err := apiGet[[]uyuni.SubscribedSystem](ctx, client, "channel/software/listSubscribedSystems?channelLabel="+url.QueryEscape(label))
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		for _, system := range systems.Result {
+			baseChannels[system.ID] = label
+		}
+		return nil
+	})
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("could not list subscribers of base channels: %w", batchError(errs))
+	}
+	return baseChannels, nil
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *SystemsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state SystemsDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var nameRegex *regexp.Regexp
+	if !state.NameRegex.IsNull() {
+		var err error
+		nameRegex, err = regexp.Compile(state.NameRegex.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("name_regex"), "Invalid Regular Expression", err.Error())
+			return
+		}
+	}
+
+	systems, err := apiGet[[]uyuni.SystemSummary](ctx, d.client, "system/listSystems")
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Uyuni systems",
+			"Could not list systems: "+err.Error(),
+		)
+		return
+	}
+
+	var inGroup map[int64]bool
+	if !state.GroupName.IsNull() {
+		group := state.GroupName.ValueString()
+		ids, err := listGroupSystems(ctx, d.client, group)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Read Uyuni systems",
+				"Could not list systems of group "+group+": "+err.Error(),
+			)
+			return
+		}
+		inGroup = map[int64]bool{}
+		for _, id := range ids {
+			inGroup[id] = true
+		}
+	}
+
+	baseChannels, err := baseChannelsBySystem(ctx, d.client, batchLimit(state.Parallelism))
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to Read Uyuni systems", err.Error())
+		return
+	}
+
+	sort.Slice(systems.Result, func(i, j int) bool {
+		if systems.Result[i].Name != systems.Result[j].Name {
+			return systems.Result[i].Name < systems.Result[j].Name
+		}
+		return systems.Result[i].ID < systems.Result[j].ID
+	})
+	var listed []uyuni.SystemSummary
+	ids := []int64{}
+	for _, system := range systems.Result {
+		if nameRegex != nil && !nameRegex.MatchString(system.Name) {
+			continue
+		}
+		if inGroup != nil && !inGroup[int64(system.ID)] {
+			continue
+		}
+		listed = append(listed, system)
+		ids = append(ids, int64(system.ID))
+	}
+
+	var osInfos map[int64]osInfo
+	if state.OSInfo.ValueBool() {
+		osInfos, err = osInfoBySystem(ctx, d.client, ids, batchLimit(state.Parallelism))
+		if err != nil {
+			resp.Diagnostics.AddError("Unable to Read Uyuni systems", err.Error())
+			return
+		}
+	}
+
+	state.Systems = []systemModel{}
+	for _, system := range listed {
+		baseChannel := types.StringNull()
+		if label, ok := baseChannels[system.ID]; ok {
+			baseChannel = types.StringValue(label)
+		}
+		var info *osInfo
+		if found, ok := osInfos[int64(system.ID)]; ok {
+			info = &found
+		}
+		state.Systems = append(state.Systems, systemModel{
+			ID:               types.Int64Value(int64(system.ID)),
+			Name:             types.StringValue(system.Name),
+			LastCheckin:      timestampValue(ctx, system.LastCheckin),
+			BaseChannelLabel: baseChannel,
+			osInfoModel:      info.model(),
+		})
+	}
+	state.SystemIDs, diags = types.SetValueFrom(ctx, types.Int64Type, ids)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *SystemsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*uyuniClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *uyuniClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
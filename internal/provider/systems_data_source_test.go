@@ -0,0 +1,122 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestSystemsDataSourceFiltersByNameAndGroup(t *testing.T) {
+	client := testAPIClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/system/listSystems":
+			_, _ = w.Write([]byte(`{"success": true, "result": [
+				{"id": 3, "name": "web02", "last_checkin": "2024-09-02T10:01:00Z", "created": "2024-01-20T09:35:00Z"},
+				{"id": 1, "name": "web01", "last_checkin": "2024-09-02T10:00:00Z", "created": "2024-01-20T09:30:00Z"},
+				{"id": 2, "name": "db01", "last_checkin": "2024-09-02T10:02:00Z", "created": "2024-01-20T09:40:00Z"}
+			]}`))
+		case "/systemgroup/listSystemsMinimal":
+			_, _ = w.Write([]byte(`{"success": true, "result": [
+				{"id": 1, "name": "web01", "last_checkin": "", "created": ""},
+				{"id": 2, "name": "db01", "last_checkin": "", "created": ""}
+			]}`))
+		case "/channel/listSoftwareChannels":
+			_, _ = w.Write([]byte(`{"success": true, "result": [
+				{"label": "sles15-sp6-pool", "name": "Pool", "parent_label": "", "end_of_life": "", "arch": "x86_64"},
+				{"label": "sles15-sp6-updates", "name": "Updates", "parent_label": "sles15-sp6-pool", "end_of_life": "", "arch": "x86_64"}
+			]}`))
+		case "/channel/software/listSubscribedSystems":
+			if label := r.URL.Query().Get("channelLabel"); label != "sles15-sp6-pool" {
+				t.Errorf("unexpected base channel %s", label)
+			}
+			_, _ = w.Write([]byte(`{"success": true, "result": [{"id": 1, "name": "web01"}]}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL)
+		}
+	})
+
+	resp := testDataSourceRead(t, NewSystemsDataSource(), client, map[string]tftypes.Value{
+		"name_regex": tftypes.NewValue(tftypes.String, "^web"),
+		"group_name": tftypes.NewValue(tftypes.String, "stores"),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+	var state SystemsDataSourceModel
+	resp.State.Get(context.Background(), &state)
+	if len(state.Systems) != 1 {
+		t.Fatalf("expected web01, got %v", state.Systems)
+	}
+	system := state.Systems[0]
+	got := fmt.Sprint(system.ID, system.Name, system.LastCheckin, system.BaseChannelLabel)
+	if want := `1 "web01" "2024-09-02T10:00:00Z" "sles15-sp6-pool"`; got != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+}
+
+func TestSystemsDataSourceIncludesOSInfo(t *testing.T) {
+	client := testAPIClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/system/listSystems":
+			_, _ = w.Write([]byte(`{"success": true, "result": [
+				{"id": 1, "name": "web01", "last_checkin": "2024-09-02T10:00:00Z", "created": "2024-01-20T09:30:00Z"},
+				{"id": 2, "name": "db01", "last_checkin": "2024-09-02T10:02:00Z", "created": "2024-01-20T09:40:00Z"}
+			]}`))
+		case "/system/getInstalledProducts":
+			if r.URL.Query().Get("sid") != "1" {
+				t.Errorf("expected only the filtered system to be read, got %s", r.URL)
+			}
+			_, _ = w.Write([]byte(`{"success": true, "result": [
+				{"name": "ubuntu-client", "isBaseProduct": true, "version": "22.04", "arch": "amd64-deb", "release": "0", "friendlyName": "Ubuntu 22.04"}
+			]}`))
+		default:
+			_, _ = w.Write([]byte(`{"success": true, "result": []}`))
+		}
+	})
+
+	resp := testDataSourceRead(t, NewSystemsDataSource(), client, map[string]tftypes.Value{
+		"name_regex":      tftypes.NewValue(tftypes.String, "^web"),
+		"include_os_info": tftypes.NewValue(tftypes.Bool, true),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+	var state SystemsDataSourceModel
+	resp.State.Get(context.Background(), &state)
+	if len(state.Systems) != 1 {
+		t.Fatalf("expected web01, got %v", state.Systems)
+	}
+	system := state.Systems[0]
+	got := fmt.Sprint(system.OSFamily, system.OSName, system.OSVersion, system.OSMajorVersion, system.OSArch)
+	if want := `"debian" "ubuntu" "22.04" 22 "x86_64"`; got != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+}
+
+func TestAccSystemsDataSource(t *testing.T) {
+	minion := os.Getenv("UYUNI_TEST_MINION_ID")
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccRealServerPreCheck(t)
+			testAccSkipUnlessEnv(t, "UYUNI_TEST_MINION_ID", "a registered Salt minion")
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `data "uyuni_systems" "all" {}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckTypeSetElemAttr("data.uyuni_systems.all", "system_ids.*", minion),
+					resource.TestCheckTypeSetElemNestedAttrs("data.uyuni_systems.all", "systems.*", map[string]string{
+						"id":                 minion,
+						"base_channel_label": testAccEnvOr("UYUNI_TEST_MINION_BASE_CHANNEL", ""),
+					}),
+				),
+			},
+		},
+	})
+}
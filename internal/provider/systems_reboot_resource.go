@@ -0,0 +1,555 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"terraform-provider-uyuni/internal/uyuni"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource              = &systemsRebootResource{}
+	_ resource.ResourceWithConfigure = &systemsRebootResource{}
+)
+
+// NewSystemsRebootResource is a helper function to simplify the provider implementation.
+func NewSystemsRebootResource() resource.Resource {
+	return &systemsRebootResource{}
+}
+
+// systemsRebootResource is the resource implementation.
+type systemsRebootResource struct {
+	client *uyuniClient
+}
+
+// systemsRebootResourceModel maps the resource schema data.
+type systemsRebootResourceModel struct {
+	ID                        types.String   `tfsdk:"id"`
+	Target                    *targetModel   `tfsdk:"target"`
+	MaxParallel               types.Int64    `tfsdk:"max_parallel"`
+	RespectMaintenanceWindows types.Bool     `tfsdk:"respect_maintenance_windows"`
+	Triggers                  types.Map      `tfsdk:"triggers"`
+	SystemIDs                 types.Set      `tfsdk:"system_ids"`
+	RebootedSystemIDs         types.Set      `tfsdk:"rebooted_system_ids"`
+	FailedSystemIDs           types.Set      `tfsdk:"failed_system_ids"`
+	ActionIDs                 types.Map      `tfsdk:"action_ids"`
+	Status                    types.String   `tfsdk:"status"`
+	CancelOnDestroy           types.Bool     `tfsdk:"cancel_on_destroy"`
+	Notify                    *notifyModel   `tfsdk:"notify"`
+	ServerAlias               types.String   `tfsdk:"server_alias"`
+	Timeouts                  timeouts.Value `tfsdk:"timeouts"`
+}
+
+// Metadata returns the resource type name.
+func (r *systemsRebootResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_systems_reboot"
+}
+
+// Schema defines the schema for the resource.
+func (r *systemsRebootResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Reboots those of the targeted systems which need a reboot after errata were applied, e.g. after " +
+			"kernel updates, when created. The systems are rebooted group by group, in the order of the group names and then " +
+			"the other targeted systems, at most max_parallel at a time, and each reboot is waited for. Groups after one in " +
+			"which a reboot failed are not rebooted. Change triggers to reboot the systems needing it again.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "ID of the first action, `none` if no system needed a reboot.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"max_parallel": schema.Int64Attribute{
+				Description: "Number of systems rebooting at the same time. Defaults to 1.",
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(1),
+				Validators: []validator.Int64{
+					int64validator.Between(1, 64),
+				},
+			},
+			"respect_maintenance_windows": schema.BoolAttribute{
+				Description: "Reboot each group in the next maintenance window of its systems instead of immediately, " +
+					"unless a window is open. All systems of a group having a maintenance schedule must share it. " +
+					"The create timeout must last until the windows. Defaults to false.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"triggers": schema.MapAttribute{
+				Description: "Arbitrary values which reboot the systems needing it again when they change, " +
+					"e.g. the ID of an errata action.",
+				ElementType: types.StringType,
+				Optional:    true,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"cancel_on_destroy": cancelOnDestroyAttribute(),
+			"system_ids": schema.SetAttribute{
+				Description: "IDs of the targeted systems which needed a reboot on apply.",
+				ElementType: types.Int64Type,
+				Computed:    true,
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"rebooted_system_ids": schema.SetAttribute{
+				Description: "IDs of the systems which finished rebooting. Refreshed while reboots are pending.",
+				ElementType: types.Int64Type,
+				Computed:    true,
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"failed_system_ids": schema.SetAttribute{
+				Description: "IDs of the systems whose reboot could not be scheduled or failed.",
+				ElementType: types.Int64Type,
+				Computed:    true,
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"action_ids": schema.MapAttribute{
+				Description: "IDs of the reboot actions by system ID.",
+				ElementType: types.Int64Type,
+				Computed:    true,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"status": schema.StringAttribute{
+				Description: "Status of the reboots: `failed` if one failed, `pending` while one is queued or running, " +
+					"and `completed` otherwise. Pending statuses are refreshed.",
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"server_alias": serverAliasAttribute(),
+		},
+		Blocks: map[string]schema.Block{
+			"target": requiredTargetBlock("Systems to reboot if they need it."),
+			"notify": notifyBlock(),
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+			}),
+		},
+	}
+}
+
+// rebootBatches returns the systems of sids by group: the members of each
+// group of the target, by group name, and then the other systems. Systems in
+// several groups are rebooted with the first one.
+func rebootBatches(ctx context.Context, client *uyuniClient, target *targetModel, sids []int64) ([][]int64, error) {
+	remaining := map[int64]bool{}
+	for _, sid := range sids {
+		remaining[sid] = true
+	}
+	groups, err := stringSet(ctx, target.GroupNames)
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(groups)
+
+	var batches [][]int64
+	for _, group := range groups {
+		systems, err := apiGet[[]uyuni.ShortSystem](ctx, client, "systemgroup/listSystemsMinimal?systemGroupName="+url.QueryEscape(group))
+		if err != nil {
+			return nil, fmt.Errorf("could not list systems of group %s: %w", group, err)
+		}
+		var batch []int64
+		for _, system := range systems.Result {
+			if sid := int64(system.ID); remaining[sid] {
+				batch = append(batch, sid)
+				delete(remaining, sid)
+			}
+		}
+		if len(batch) > 0 {
+			sort.Slice(batch, func(i, j int) bool { return batch[i] < batch[j] })
+			batches = append(batches, batch)
+		}
+	}
+	var rest []int64
+	for _, sid := range sids {
+		if remaining[sid] {
+			rest = append(rest, sid)
+		}
+	}
+	if len(rest) > 0 {
+		batches = append(batches, rest)
+	}
+	return batches, nil
+}
+
+// scheduleReboot schedules the reboot of a system and returns the ID of the
+// action.
+func scheduleReboot(ctx context.Context, client *uyuniClient, sid int64, earliest time.Time) (int64, error) {
+	actionID, err := apiPost[int64](ctx, client, "system/scheduleReboot", map[string]interface{}{
+		"sid":                sid,
+		"earliestOccurrence": apiDate(earliest),
+	})
+	if err != nil {
+		return 0, err
+	}
+	return actionID.Result, nil
+}
+
+// setProgress sets the system IDs, the action IDs and the status of the
+// model from the statuses of the reboots by system.
+func (m *systemsRebootResourceModel) setProgress(ctx context.Context, sids []int64, actionIDs map[int64]int64, statuses map[int64]string) error {
+	var rebooted, failed []int64
+	combined := make([]string, 0, len(statuses))
+	for _, sid := range sids {
+		status, ok := statuses[sid]
+		if !ok {
+			continue
+		}
+		combined = append(combined, status)
+		switch status {
+		case actionStatusCompleted:
+			rebooted = append(rebooted, sid)
+		case actionStatusFailed:
+			failed = append(failed, sid)
+		}
+	}
+	actions := map[string]int64{}
+	for sid, actionID := range actionIDs {
+		actions[strconv.FormatInt(sid, 10)] = actionID
+	}
+
+	var diags diag.Diagnostics
+	var d diag.Diagnostics
+	m.SystemIDs, d = types.SetValueFrom(ctx, types.Int64Type, sids)
+	diags.Append(d...)
+	m.RebootedSystemIDs, d = types.SetValueFrom(ctx, types.Int64Type, append([]int64{}, rebooted...))
+	diags.Append(d...)
+	m.FailedSystemIDs, d = types.SetValueFrom(ctx, types.Int64Type, append([]int64{}, failed...))
+	diags.Append(d...)
+	m.ActionIDs, d = types.MapValueFrom(ctx, types.Int64Type, actions)
+	diags.Append(d...)
+	if diags.HasError() {
+		return fmt.Errorf("could not set the progress of the reboots")
+	}
+	m.Status = types.StringValue(combinedActionStatus(combined))
+	return nil
+}
+
+// Create reboots the targeted systems needing it, group by group, and waits
+// for the reboots.
+func (r *systemsRebootResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan systemsRebootResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := resourceClient(ctx, r.client, plan.ServerAlias, nil, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	targeted, err := plan.Target.resolve(ctx, client)
+	if err != nil {
+		resp.Diagnostics.AddError("Error rebooting systems", "Could not resolve the target: "+err.Error())
+		return
+	}
+	suggested, err := apiGet[[]uyuni.ShortSystem](ctx, client, "system/listSuggestedReboot")
+	if err != nil {
+		resp.Diagnostics.AddError("Error rebooting systems", "Could not list the systems needing a reboot: "+err.Error())
+		return
+	}
+	needed := map[int64]bool{}
+	for _, system := range suggested.Result {
+		needed[int64(system.ID)] = true
+	}
+	var sids []int64
+	for _, sid := range targeted {
+		if needed[sid] {
+			sids = append(sids, sid)
+		}
+	}
+	batches, err := rebootBatches(ctx, client, plan.Target, sids)
+	if err != nil {
+		resp.Diagnostics.AddError("Error rebooting systems", err.Error())
+		return
+	}
+
+	var (
+		mu        sync.Mutex
+		actionIDs = map[int64]int64{}
+		statuses  = map[int64]string{}
+	)
+	record := func(sid int64, status string, actionID int64) {
+		mu.Lock()
+		defer mu.Unlock()
+		statuses[sid] = status
+		if actionID != 0 {
+			actionIDs[sid] = actionID
+		}
+	}
+	for i, batch := range batches {
+		earliest := time.Now()
+		if plan.RespectMaintenanceWindows.ValueBool() {
+			earliest, err = maintenanceWindowStart(ctx, client, batch, earliest)
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"Error rebooting systems",
+					fmt.Sprintf("Could not find the next maintenance window of systems %v: %s", batch, err),
+				)
+				break
+			}
+		}
+		tflog.Info(ctx, fmt.Sprintf("Rebooting %d systems", len(batch)), map[string]interface{}{
+			"batch":    i + 1,
+			"batches":  len(batches),
+			"earliest": earliest.Format(time.RFC3339),
+		})
+
+		keys := make([]string, 0, len(batch))
+		for _, sid := range batch {
+			keys = append(keys, strconv.FormatInt(sid, 10))
+		}
+		errs := runBatchLimit(keys, int(plan.MaxParallel.ValueInt64()), func(key string) error {
+			sid, _ := strconv.ParseInt(key, 10, 64)
+			actionID, err := scheduleReboot(ctx, client, sid, earliest)
+			if err != nil {
+				// A reboot which could not be scheduled failed.
+				record(sid, actionStatusFailed, 0)
+				return fmt.Errorf("could not schedule the reboot: %w", err)
+			}
+			record(sid, actionStatusPending, actionID)
+			err = waitForAction(ctx, client, actionID, sid)
+			record(sid, waitedActionStatus(err), actionID)
+			return err
+		})
+		if len(errs) > 0 {
+			resp.Diagnostics.AddError(
+				"Error rebooting systems",
+				fmt.Sprintf("Could not reboot %d of %d systems, later groups are not rebooted: %s", len(errs), len(batch), batchError(errs)),
+			)
+			break
+		}
+	}
+
+	plan.ID = types.StringValue("none")
+	if first := sortedActionIDs(actionIDs); len(first) > 0 {
+		plan.ID = types.StringValue(strconv.FormatInt(first[0], 10))
+	}
+	if sids == nil {
+		sids = []int64{}
+	}
+	if err := plan.setProgress(ctx, sids, actionIDs, statuses); err != nil {
+		resp.Diagnostics.AddError("Error rebooting systems", err.Error())
+		return
+	}
+	if len(sids) == 0 {
+		tflog.Info(ctx, "None of the targeted systems needs a reboot")
+	}
+	// Track the reboots scheduled, Terraform taints the resource on errors.
+	plan.Notify.send(ctx, notification{
+		Resource:  "uyuni_systems_reboot",
+		ID:        plan.ID.ValueString(),
+		Status:    plan.Status.ValueString(),
+		ActionIDs: sortedActionIDs(actionIDs),
+		SystemIDs: sids,
+	}, &resp.Diagnostics)
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// sortedActionIDs returns the action IDs in ascending order.
+func sortedActionIDs(actionIDs map[int64]int64) []int64 {
+	ids := make([]int64, 0, len(actionIDs))
+	for _, actionID := range actionIDs {
+		ids = append(ids, actionID)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+// actionIDsBySystem returns the action IDs of the model by system ID.
+func (m *systemsRebootResourceModel) actionIDsBySystem(ctx context.Context) (map[int64]int64, error) {
+	actions := map[string]int64{}
+	if diags := m.ActionIDs.ElementsAs(ctx, &actions, false); diags.HasError() {
+		return nil, fmt.Errorf("invalid action IDs")
+	}
+	actionIDs := make(map[int64]int64, len(actions))
+	for key, actionID := range actions {
+		sid, err := strconv.ParseInt(key, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid system ID %q", key)
+		}
+		actionIDs[sid] = actionID
+	}
+	return actionIDs, nil
+}
+
+// Read keeps the state, a reboot has no lasting object on the server. Only
+// the progress of pending reboots is refreshed.
+func (r *systemsRebootResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state systemsRebootResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := resourceClient(ctx, r.client, state.ServerAlias, nil, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	if state.Status.ValueString() == actionStatusPending {
+		sids, err := int64Set(ctx, state.SystemIDs)
+		var actionIDs map[int64]int64
+		if err == nil {
+			actionIDs, err = state.actionIDsBySystem(ctx)
+		}
+		var failed []int64
+		if err == nil {
+			failed, err = int64Set(ctx, state.FailedSystemIDs)
+		}
+		if err != nil {
+			resp.Diagnostics.AddError("Error Reading Uyuni systems reboot", err.Error())
+			return
+		}
+
+		statuses := map[int64]string{}
+		for _, sid := range failed {
+			statuses[sid] = actionStatusFailed
+		}
+		for sid, actionID := range actionIDs {
+			done, err := actionDone(ctx, client, actionID, sid)
+			switch {
+			case err == nil && done:
+				statuses[sid] = actionStatusCompleted
+			case err == nil:
+				statuses[sid] = actionStatusPending
+			case isNotFoundError(err):
+				// Actions deleted from the history keep their status.
+				if _, ok := statuses[sid]; !ok {
+					statuses[sid] = actionStatusCompleted
+				}
+			default:
+				if status := waitedActionStatus(err); status == actionStatusFailed {
+					statuses[sid] = status
+					continue
+				}
+				resp.Diagnostics.AddError(
+					"Error Reading Uyuni systems reboot",
+					fmt.Sprintf("Could not read the status of action %d: %s", actionID, err),
+				)
+				return
+			}
+		}
+		if err := state.setProgress(ctx, sids, actionIDs, statuses); err != nil {
+			resp.Diagnostics.AddError("Error Reading Uyuni systems reboot", err.Error())
+			return
+		}
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update only changes max_parallel, cancel_on_destroy and timeouts, all other
+// changes reboot the systems needing it again.
+func (r *systemsRebootResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan systemsRebootResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete cancels reboots which are still pending, unless cancel_on_destroy
+// is false, and removes the reboot from state.
+func (r *systemsRebootResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state systemsRebootResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := resourceClient(ctx, r.client, state.ServerAlias, nil, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	if state.CancelOnDestroy.ValueBool() && state.Status.ValueString() == actionStatusPending {
+		actionIDs, err := state.actionIDsBySystem(ctx)
+		if err == nil {
+			err = cancelPendingActions(ctx, client, sortedActionIDs(actionIDs))
+		}
+		if err != nil {
+			resp.Diagnostics.AddError("Error Deleting Uyuni systems reboot", err.Error())
+			return
+		}
+	}
+	tflog.Info(ctx, "Removing systems reboot from state")
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *systemsRebootResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*uyuniClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *uyuniClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
@@ -0,0 +1,114 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"reflect"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	acctest "github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestSystemsRebootCreateRebootsGroupByGroup(t *testing.T) {
+	ctx := context.Background()
+	for name, tc := range map[string]struct {
+		failing  int64
+		rebooted []int64
+		status   string
+	}{
+		"all groups":            {rebooted: []int64{1, 2, 4}, status: actionStatusCompleted},
+		"stops after a failure": {failing: 1, rebooted: []int64{1}, status: actionStatusFailed},
+	} {
+		var mu sync.Mutex
+		var scheduled []int64
+		r := NewSystemsRebootResource()
+		testConfigure(t, r, testAPIClient(t, func(w http.ResponseWriter, req *http.Request) {
+			switch req.URL.Path {
+			case "/systemgroup/listSystemsMinimal":
+				members := map[string]string{"db": `{"id": 1}, {"id": 3}`, "web": `{"id": 1}, {"id": 2}, {"id": 4}`}
+				_, _ = w.Write([]byte(`{"success": true, "result": [` + members[req.URL.Query().Get("systemGroupName")] + `]}`))
+			case "/system/listSuggestedReboot":
+				// System 3 does not need a reboot.
+				_, _ = w.Write([]byte(`{"success": true, "result": [{"id": 1}, {"id": 2}, {"id": 4}]}`))
+			case "/system/scheduleReboot":
+				var body map[string]interface{}
+				_ = json.NewDecoder(req.Body).Decode(&body)
+				sid := int64(body["sid"].(float64))
+				mu.Lock()
+				scheduled = append(scheduled, sid)
+				mu.Unlock()
+				_, _ = fmt.Fprintf(w, `{"success": true, "result": %d}`, sid*10)
+			case "/schedule/listFailedSystems", "/schedule/listCompletedSystems":
+				actionID, _ := strconv.ParseInt(req.URL.Query().Get("actionId"), 10, 64)
+				sid := actionID / 10
+				if (sid == tc.failing) != (req.URL.Path == "/schedule/listFailedSystems") {
+					_, _ = w.Write([]byte(`{"success": true, "result": []}`))
+					return
+				}
+				_, _ = fmt.Fprintf(w, `{"success": true, "result": [{"server_id": %d, "message": "reboot timed out"}]}`, sid)
+			default:
+				t.Errorf("unexpected request %s", req.URL)
+			}
+		}))
+
+		planned := testState(t, r, map[string]interface{}{
+			"target": &targetModel{
+				GroupNames: types.SetValueMust(types.StringType, []attr.Value{types.StringValue("web"), types.StringValue("db")}),
+				SystemIDs:  types.SetNull(types.Int64Type),
+				Search:     types.StringNull(),
+				SearchBy:   types.StringNull(),
+			},
+			"max_parallel":                1,
+			"respect_maintenance_windows": false,
+		})
+		resp := &resource.CreateResponse{State: planned}
+		r.Create(ctx, resource.CreateRequest{Plan: tfsdk.Plan{Schema: planned.Schema, Raw: planned.Raw}}, resp)
+		if resp.Diagnostics.HasError() != (tc.failing != 0) {
+			t.Errorf("%s: unexpected diagnostics %v", name, resp.Diagnostics)
+		}
+		if !reflect.DeepEqual(scheduled, tc.rebooted) {
+			t.Errorf("%s: expected reboots of %v in order, got %v", name, tc.rebooted, scheduled)
+		}
+
+		var state systemsRebootResourceModel
+		resp.State.Get(ctx, &state)
+		if state.SystemIDs.String() != "[1,2,4]" || state.Status.ValueString() != tc.status || state.ID.ValueString() != "10" {
+			t.Errorf("%s: unexpected state %v", name, state)
+		}
+	}
+}
+
+func TestAccSystemsRebootResource(t *testing.T) {
+	system := os.Getenv("UYUNI_TEST_REBOOT_SYSTEM_ID")
+	acctest.Test(t, acctest.TestCase{
+		PreCheck: func() {
+			testAccRealServerPreCheck(t)
+			testAccSkipUnlessEnv(t, "UYUNI_TEST_REBOOT_SYSTEM_ID", "a registered system that may be rebooted")
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []acctest.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "uyuni_systems_reboot" "test" {
+  target {
+    system_ids = [%s]
+  }
+}
+`, system),
+				Check: acctest.ComposeAggregateTestCheckFunc(
+					acctest.TestCheckResourceAttr("uyuni_systems_reboot.test", "status", "completed"),
+					acctest.TestCheckTypeSetElemAttr("uyuni_systems_reboot.test", "rebooted_system_ids.*", system),
+				),
+			},
+		},
+	})
+}
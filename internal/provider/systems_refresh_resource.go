@@ -0,0 +1,389 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource              = &systemsRefreshResource{}
+	_ resource.ResourceWithConfigure = &systemsRefreshResource{}
+)
+
+// NewSystemsRefreshResource is a helper function to simplify the provider implementation.
+func NewSystemsRefreshResource() resource.Resource {
+	return &systemsRefreshResource{}
+}
+
+// systemsRefreshResource is the resource implementation.
+type systemsRefreshResource struct {
+	client *uyuniClient
+}
+
+// systemsRefreshResourceModel maps the resource schema data.
+type systemsRefreshResourceModel struct {
+	ID              types.String   `tfsdk:"id"`
+	Target          *targetModel   `tfsdk:"target"`
+	PackageRefresh  types.Bool     `tfsdk:"package_refresh"`
+	HardwareRefresh types.Bool     `tfsdk:"hardware_refresh"`
+	Wait            types.Bool     `tfsdk:"wait"`
+	Triggers        types.Map      `tfsdk:"triggers"`
+	SystemIDs       types.Set      `tfsdk:"system_ids"`
+	ActionIDs       types.Set      `tfsdk:"action_ids"`
+	Status          types.String   `tfsdk:"status"`
+	CancelOnDestroy types.Bool     `tfsdk:"cancel_on_destroy"`
+	Notify          *notifyModel   `tfsdk:"notify"`
+	ServerAlias     types.String   `tfsdk:"server_alias"`
+	Timeouts        timeouts.Value `tfsdk:"timeouts"`
+}
+
+// Metadata returns the resource type name.
+func (r *systemsRefreshResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_systems_refresh"
+}
+
+// Schema defines the schema for the resource.
+func (r *systemsRefreshResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Refreshes the package profiles and hardware of many systems when created, e.g. of a group " +
+			"before an audit, and waits for the refreshes so that data sources read current inventory data in the same apply. " +
+			"Each system gets its own actions. Change triggers to refresh again. Use uyuni_system_refresh for a single system.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "ID of the first action.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"package_refresh": schema.BoolAttribute{
+				Description: "Refresh the lists of installed packages. Defaults to true.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"hardware_refresh": schema.BoolAttribute{
+				Description: "Refresh the hardware profiles. Defaults to true.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"wait": schema.BoolAttribute{
+				Description: "Wait until the refreshes finished on all systems. Defaults to true.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"triggers": schema.MapAttribute{
+				Description: "Arbitrary values which refresh the systems again when they change.",
+				ElementType: types.StringType,
+				Optional:    true,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"cancel_on_destroy": cancelOnDestroyAttribute(),
+			"system_ids": schema.SetAttribute{
+				Description: "IDs of the refreshed systems, as resolved from the target on apply.",
+				ElementType: types.Int64Type,
+				Computed:    true,
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"action_ids": schema.SetAttribute{
+				Description: "IDs of the refresh actions of all systems.",
+				ElementType: types.Int64Type,
+				Computed:    true,
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"status": schema.StringAttribute{
+				Description: "Status of the refreshes over all systems: `failed` if one failed on any, " +
+					"`pending` while one is queued or running on any, and `completed` otherwise. Pending statuses are refreshed.",
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"server_alias": serverAliasAttribute(),
+		},
+		Blocks: map[string]schema.Block{
+			"target": requiredTargetBlock("Systems to refresh."),
+			"notify": notifyBlock(),
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+			}),
+		},
+	}
+}
+
+// combinedActionStatus returns the status over several actions: failed if
+// any failed, pending while any is pending, and completed otherwise.
+func combinedActionStatus(statuses []string) string {
+	combined := actionStatusCompleted
+	for _, status := range statuses {
+		switch status {
+		case actionStatusFailed:
+			return actionStatusFailed
+		case actionStatusPending:
+			combined = actionStatusPending
+		}
+	}
+	return combined
+}
+
+// Create schedules the refreshes of every system and waits for them.
+func (r *systemsRefreshResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan systemsRefreshResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := resourceClient(ctx, r.client, plan.ServerAlias, nil, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	sids, err := plan.Target.resolve(ctx, client)
+	if err != nil {
+		resp.Diagnostics.AddError("Error refreshing systems", "Could not resolve the target: "+err.Error())
+		return
+	}
+
+	var endpoints []string
+	if plan.PackageRefresh.ValueBool() {
+		endpoints = append(endpoints, "system/schedulePackageRefresh")
+	}
+	if plan.HardwareRefresh.ValueBool() {
+		endpoints = append(endpoints, "system/scheduleHardwareRefresh")
+	}
+	if len(endpoints) == 0 {
+		resp.Diagnostics.AddError("Error refreshing systems", "Neither package_refresh nor hardware_refresh is enabled.")
+		return
+	}
+
+	keys := make([]string, 0, len(sids))
+	for _, sid := range sids {
+		keys = append(keys, strconv.FormatInt(sid, 10))
+	}
+	var (
+		mu        sync.Mutex
+		actionIDs []int64
+		statuses  []string
+	)
+	record := func(status string, actionID ...int64) {
+		mu.Lock()
+		defer mu.Unlock()
+		if status != "" {
+			statuses = append(statuses, status)
+		}
+		actionIDs = append(actionIDs, actionID...)
+	}
+	errs := runBatch(keys, func(key string) error {
+		sid, _ := strconv.ParseInt(key, 10, 64)
+		var scheduled []int64
+		for _, endpoint := range endpoints {
+			actionID, err := scheduleRefresh(ctx, client, endpoint, sid)
+			if err != nil {
+				// A refresh which could not be scheduled failed.
+				record(actionStatusFailed)
+				return fmt.Errorf("could not schedule %s: %w", endpoint, err)
+			}
+			scheduled = append(scheduled, actionID)
+			record("", actionID)
+		}
+
+		if !plan.Wait.ValueBool() {
+			record(actionStatusPending)
+			return nil
+		}
+		var err error
+		for _, actionID := range scheduled {
+			if err = waitForAction(ctx, client, actionID, sid); err != nil {
+				break
+			}
+		}
+		record(waitedActionStatus(err))
+		return err
+	})
+	for _, sid := range sids {
+		if err := errs[strconv.FormatInt(sid, 10)]; err != nil {
+			resp.Diagnostics.AddError(
+				"Error refreshing systems",
+				fmt.Sprintf("Could not refresh system %d: %s", sid, err),
+			)
+		}
+	}
+	if len(actionIDs) == 0 {
+		return
+	}
+	// Fall through to track the actions scheduled, which Terraform taints on
+	// errors.
+
+	sort.Slice(actionIDs, func(i, j int) bool { return actionIDs[i] < actionIDs[j] })
+	plan.ID = types.StringValue(strconv.FormatInt(actionIDs[0], 10))
+	plan.Status = types.StringValue(combinedActionStatus(statuses))
+	plan.SystemIDs, diags = types.SetValueFrom(ctx, types.Int64Type, sids)
+	resp.Diagnostics.Append(diags...)
+	plan.ActionIDs, diags = types.SetValueFrom(ctx, types.Int64Type, actionIDs)
+	resp.Diagnostics.Append(diags...)
+	if plan.Wait.ValueBool() && plan.Status.ValueString() == actionStatusCompleted {
+		tflog.Info(ctx, fmt.Sprintf("Refreshed %d systems", len(sids)))
+	}
+	plan.Notify.send(ctx, notification{
+		Resource:  "uyuni_systems_refresh",
+		ID:        plan.ID.ValueString(),
+		Status:    plan.Status.ValueString(),
+		ActionIDs: actionIDs,
+		SystemIDs: sids,
+	}, &resp.Diagnostics)
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read keeps the state, a refresh has no lasting object on the server. Only
+// a pending status is refreshed.
+func (r *systemsRefreshResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state systemsRefreshResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := resourceClient(ctx, r.client, state.ServerAlias, nil, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	if state.Status.ValueString() == actionStatusPending {
+		actionIDs, err := int64Set(ctx, state.ActionIDs)
+		if err != nil {
+			resp.Diagnostics.AddError("Error Reading Uyuni systems refresh", err.Error())
+			return
+		}
+		statuses := make([]string, 0, len(actionIDs))
+		for _, actionID := range actionIDs {
+			status, err := actionStatus(ctx, client, actionID)
+			if err != nil {
+				// Actions deleted from the history keep their status.
+				if isNotFoundError(err) {
+					continue
+				}
+				resp.Diagnostics.AddError(
+					"Error Reading Uyuni systems refresh",
+					fmt.Sprintf("Could not read the status of action %d: %s", actionID, err),
+				)
+				return
+			}
+			statuses = append(statuses, status)
+		}
+		state.Status = types.StringValue(combinedActionStatus(statuses))
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update only changes cancel_on_destroy and timeouts, all other changes
+// refresh the systems again.
+func (r *systemsRefreshResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan systemsRefreshResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete cancels refreshes which are still pending, unless cancel_on_destroy
+// is false, and removes the refresh from state. The systems are not changed.
+func (r *systemsRefreshResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state systemsRefreshResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := resourceClient(ctx, r.client, state.ServerAlias, nil, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	if state.CancelOnDestroy.ValueBool() && state.Status.ValueString() == actionStatusPending {
+		actionIDs, err := int64Set(ctx, state.ActionIDs)
+		if err == nil {
+			err = cancelPendingActions(ctx, client, actionIDs)
+		}
+		if err != nil {
+			resp.Diagnostics.AddError("Error Deleting Uyuni systems refresh", err.Error())
+			return
+		}
+	}
+	tflog.Info(ctx, "Removing systems refresh from state, the systems are not changed")
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *systemsRefreshResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*uyuniClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *uyuniClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
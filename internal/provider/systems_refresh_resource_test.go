@@ -0,0 +1,96 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	acctest "github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestSystemsRefreshCreateRefreshesGroupMembers(t *testing.T) {
+	ctx := context.Background()
+	var mu sync.Mutex
+	scheduled := map[string]int{}
+	actionID := 100
+	r := NewSystemsRefreshResource()
+	testConfigure(t, r, testAPIClient(t, func(w http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/systemgroup/listSystemsMinimal":
+			_, _ = w.Write([]byte(`{"success": true, "result": [
+				{"id": 1, "name": "web01", "last_checkin": "2025-01-14T10:02:11Z", "created": "2024-11-02T08:45:37Z"},
+				{"id": 2, "name": "web02", "last_checkin": "2025-01-14T10:02:11Z", "created": "2024-11-02T08:45:37Z"}
+			]}`))
+		case "/system/schedulePackageRefresh", "/system/scheduleHardwareRefresh":
+			var body map[string]interface{}
+			_ = json.NewDecoder(req.Body).Decode(&body)
+			mu.Lock()
+			scheduled[fmt.Sprint(body["sid"])]++
+			actionID++
+			_, _ = fmt.Fprintf(w, `{"success": true, "result": %d}`, actionID)
+			mu.Unlock()
+		default:
+			t.Errorf("unexpected request %s", req.URL)
+		}
+	}))
+
+	planned := testState(t, r, map[string]interface{}{
+		"target": &targetModel{
+			GroupNames: types.SetValueMust(types.StringType, []attr.Value{types.StringValue("web")}),
+			SystemIDs:  types.SetNull(types.Int64Type),
+			Search:     types.StringNull(),
+			SearchBy:   types.StringNull(),
+		},
+		"package_refresh":  true,
+		"hardware_refresh": true,
+		"wait":             false,
+	})
+	resp := &resource.CreateResponse{State: planned}
+	r.Create(ctx, resource.CreateRequest{Plan: tfsdk.Plan{Schema: planned.Schema, Raw: planned.Raw}}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+	if fmt.Sprint(scheduled) != "map[1:2 2:2]" {
+		t.Errorf("expected two refreshes per system, got %v", scheduled)
+	}
+
+	var state systemsRefreshResourceModel
+	resp.State.Get(ctx, &state)
+	if len(state.SystemIDs.Elements()) != 2 || len(state.ActionIDs.Elements()) != 4 || state.Status.ValueString() != actionStatusPending {
+		t.Errorf("unexpected state %v", state)
+	}
+}
+
+func TestAccSystemsRefreshResource(t *testing.T) {
+	minion := os.Getenv("UYUNI_TEST_MINION_ID")
+	acctest.Test(t, acctest.TestCase{
+		PreCheck: func() {
+			testAccRealServerPreCheck(t)
+			testAccSkipUnlessEnv(t, "UYUNI_TEST_MINION_ID", "a registered Salt minion")
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []acctest.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "uyuni_systems_refresh" "test" {
+  target {
+    system_ids = [%s]
+  }
+}
+`, minion),
+				Check: acctest.ComposeAggregateTestCheckFunc(
+					acctest.TestCheckResourceAttr("uyuni_systems_refresh.test", "status", "completed"),
+					acctest.TestCheckTypeSetElemAttr("uyuni_systems_refresh.test", "system_ids.*", minion),
+				),
+			},
+		},
+	})
+}
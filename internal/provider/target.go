@@ -0,0 +1,140 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+
+	"terraform-provider-uyuni/internal/uyuni"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/objectvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// errTargetEmpty is returned when a target block selects no systems.
+var errTargetEmpty = errors.New("the target selects no systems")
+
+// searchEndpoints maps the search_by values of target blocks to the methods
+// of the system.search namespace.
+var searchEndpoints = map[string]string{
+	"hostname":             "system/search/hostname",
+	"ip":                   "system/search/ip",
+	"name_and_description": "system/search/nameAndDescription",
+}
+
+// targetModel maps the target block of resources scheduling actions on
+// systems.
+type targetModel struct {
+	SystemIDs  types.Set    `tfsdk:"system_ids"`
+	GroupNames types.Set    `tfsdk:"group_names"`
+	Search     types.String `tfsdk:"search"`
+	SearchBy   types.String `tfsdk:"search_by"`
+}
+
+// targetBlock is the schema of the target block. The systems it selects are
+// resolved on apply.
+func targetBlock(description string) schema.SingleNestedBlock {
+	return schema.SingleNestedBlock{
+		Description: description + " The block selects the union of the listed systems, the members of the groups " +
+			"and the systems found by the search, resolved on apply.",
+		Attributes: map[string]schema.Attribute{
+			"system_ids": schema.SetAttribute{
+				Description: "IDs of systems.",
+				ElementType: types.Int64Type,
+				Optional:    true,
+			},
+			"group_names": schema.SetAttribute{
+				Description: "Names of groups whose members are selected.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"search": schema.StringAttribute{
+				Description: "Search term selecting the systems it matches, e.g. `web`.",
+				Optional:    true,
+			},
+			"search_by": schema.StringAttribute{
+				Description: "What search matches: `hostname`, `ip` or `name_and_description`. Defaults to `hostname`.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("hostname", "ip", "name_and_description"),
+				},
+			},
+		},
+	}
+}
+
+// requiredTargetBlock is the target block of resources running an action
+// once. Changing it runs the action again.
+func requiredTargetBlock(description string) schema.SingleNestedBlock {
+	block := targetBlock(description)
+	block.Validators = []validator.Object{
+		objectvalidator.IsRequired(),
+	}
+	block.PlanModifiers = []planmodifier.Object{
+		objectplanmodifier.RequiresReplace(),
+	}
+	return block
+}
+
+// resolve returns the IDs of the systems the target selects, in ascending
+// order. It returns an error if the target selects nothing.
+func (t *targetModel) resolve(ctx context.Context, client *uyuniClient) ([]int64, error) {
+	if t == nil || (t.SystemIDs.IsNull() && t.GroupNames.IsNull() && t.Search.IsNull()) {
+		return nil, fmt.Errorf("the target sets none of system_ids, group_names and search")
+	}
+
+	selected := map[int64]bool{}
+	sids, err := int64Set(ctx, t.SystemIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, sid := range sids {
+		selected[sid] = true
+	}
+
+	groups, err := stringSet(ctx, t.GroupNames)
+	if err != nil {
+		return nil, err
+	}
+	for _, group := range groups {
+		systems, err := apiGet[[]uyuni.ShortSystem](ctx, client, "systemgroup/listSystemsMinimal?systemGroupName="+url.QueryEscape(group))
+		if err != nil {
+			return nil, fmt.Errorf("could not list systems of group %s: %w", group, err)
+		}
+		for _, system := range systems.Result {
+			selected[int64(system.ID)] = true
+		}
+	}
+
+	if !t.Search.IsNull() {
+		searchBy := "hostname"
+		if !t.SearchBy.IsNull() {
+			searchBy = t.SearchBy.ValueString()
+		}
+		term := t.Search.ValueString()
+		systems, err := apiGet[[]uyuni.SystemSearchResult](ctx, client, searchEndpoints[searchBy]+"?searchTerm="+url.QueryEscape(term))
+		if err != nil {
+			return nil, fmt.Errorf("could not search systems by %s for %q: %w", searchBy, term, err)
+		}
+		for _, system := range systems.Result {
+			selected[int64(system.ID)] = true
+		}
+	}
+
+	if len(selected) == 0 {
+		return nil, errTargetEmpty
+	}
+	resolved := make([]int64, 0, len(selected))
+	for sid := range selected {
+		resolved = append(resolved, sid)
+	}
+	sort.Slice(resolved, func(i, j int) bool { return resolved[i] < resolved[j] })
+	return resolved, nil
+}
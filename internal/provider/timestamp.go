@@ -0,0 +1,27 @@
+package provider
+
+import (
+	"context"
+	"time"
+
+	"terraform-provider-uyuni/internal/uyuni"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// timestampValue converts a date returned by the API into an RFC 3339
+// string, so that computed date attributes do not depend on the server
+// locale. Empty dates, and dates in a format the provider does not know, are
+// null.
+func timestampValue(ctx context.Context, date string) types.String {
+	if date == "" {
+		return types.StringNull()
+	}
+	t, err := uyuni.ParseDate(date)
+	if err != nil {
+		tflog.Warn(ctx, "Ignoring Uyuni API date", map[string]interface{}{"error": err.Error()})
+		return types.StringNull()
+	}
+	return types.StringValue(t.Format(time.RFC3339))
+}
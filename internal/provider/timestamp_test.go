@@ -0,0 +1,22 @@
+package provider
+
+import (
+	"context"
+	"testing"
+)
+
+func TestTimestampValue(t *testing.T) {
+	ctx := context.Background()
+
+	if got := timestampValue(ctx, "Sep 2, 2024, 10:15:00 AM"); got.ValueString() != "2024-09-02T10:15:00Z" {
+		t.Errorf("got %s", got)
+	}
+	if got := timestampValue(ctx, "2024-09-02T12:15:00+02:00"); got.ValueString() != "2024-09-02T12:15:00+02:00" {
+		t.Errorf("got %s", got)
+	}
+	for _, date := range []string{"", "02.09.2024"} {
+		if got := timestampValue(ctx, date); !got.IsNull() {
+			t.Errorf("%q: expected null, got %s", date, got)
+		}
+	}
+}
@@ -0,0 +1,29 @@
+package provider
+
+import (
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// loadCACert returns the system CAs with the CA certificate added, which is
+// either PEM or the path of a PEM file.
+func loadCACert(caCert string) (*x509.CertPool, error) {
+	data := []byte(caCert)
+	if !strings.Contains(caCert, "-----BEGIN") {
+		var err error
+		if data, err = os.ReadFile(expandHome(caCert)); err != nil {
+			return nil, fmt.Errorf("could not read %s: %w", caCert, err)
+		}
+	}
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(data) {
+		return nil, errors.New("no PEM encoded certificate found")
+	}
+	return pool, nil
+}
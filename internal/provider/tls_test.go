@@ -0,0 +1,50 @@
+package provider
+
+import (
+	"context"
+	"encoding/pem"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/uyuni-project/uyuni-tools/shared/api"
+)
+
+func TestClientVerifiesServerWithCACert(t *testing.T) {
+	server := newTestSessionServer(t)
+	host, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	caCert := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+	file := filepath.Join(t.TempDir(), "ca.pem")
+	if err := os.WriteFile(file, []byte(caCert), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	invalid := filepath.Join(t.TempDir(), "invalid.pem")
+	if err := os.WriteFile(invalid, []byte("not a certificate"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, tc := range map[string]struct {
+		caCert  string
+		wantErr bool
+	}{
+		"PEM":            {caCert: caCert},
+		"file":           {caCert: file},
+		"unknown CA":     {wantErr: true},
+		"missing file":   {caCert: filepath.Join(t.TempDir(), "missing.pem"), wantErr: true},
+		"no certificate": {caCert: invalid, wantErr: true},
+	} {
+		_, err := newUyuniClient(context.Background(), &api.ConnectionDetails{
+			Server:   host.Host,
+			User:     "admin",
+			Password: "secret",
+			CAcert:   tc.caCert,
+		})
+		if (err != nil) != tc.wantErr {
+			t.Errorf("%s: expected error %v, got %v", name, tc.wantErr, err)
+		}
+	}
+}
@@ -0,0 +1,269 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	// otlpBatchSize is the number of spans sent at once.
+	otlpBatchSize = 128
+
+	// otlpBatchDelay is how long spans wait for others to be sent with.
+	otlpBatchDelay = time.Second
+
+	// otlpSendTimeout bounds sending a batch to the collector.
+	otlpSendTimeout = 10 * time.Second
+
+	// otlpServiceName is the service.name of the spans.
+	otlpServiceName = "terraform-provider-uyuni"
+)
+
+// otlpExporters are the exporters of all configured providers, which
+// FlushTraces sends the last spans of.
+var (
+	otlpExportersMu sync.Mutex
+	otlpExporters   []*otlpExporter
+)
+
+// otlpExporter sends a span per API call to an OpenTelemetry collector over
+// OTLP/HTTP, encoded as JSON so that no SDK is needed. The spans of a provider
+// share one trace, so the calls of an apply can be found together and be
+// correlated with the load of the server at that time.
+type otlpExporter struct {
+	endpoint   string
+	headers    map[string]string
+	version    string
+	httpClient *http.Client
+	traceID    string
+
+	mu      sync.Mutex
+	pending []otlpSpan
+	timer   *time.Timer
+	sendErr error
+	sending sync.WaitGroup
+}
+
+// otlpSpan is a span of the OTLP JSON encoding.
+type otlpSpan struct {
+	TraceID           string          `json:"traceId"`
+	SpanID            string          `json:"spanId"`
+	Name              string          `json:"name"`
+	Kind              int             `json:"kind"`
+	StartTimeUnixNano string          `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string          `json:"endTimeUnixNano"`
+	Attributes        []otlpAttribute `json:"attributes"`
+	Status            otlpStatus      `json:"status"`
+}
+
+// otlpAttribute is a span or resource attribute of the OTLP JSON encoding.
+type otlpAttribute struct {
+	Key   string `json:"key"`
+	Value struct {
+		StringValue *string `json:"stringValue,omitempty"`
+		IntValue    *string `json:"intValue,omitempty"`
+	} `json:"value"`
+}
+
+// otlpStatus is a span status of the OTLP JSON encoding.
+type otlpStatus struct {
+	Code    int    `json:"code"`
+	Message string `json:"message,omitempty"`
+}
+
+const (
+	otlpSpanKindClient  = 3
+	otlpStatusCodeOK    = 1
+	otlpStatusCodeError = 2
+)
+
+func otlpString(key, value string) otlpAttribute {
+	a := otlpAttribute{Key: key}
+	a.Value.StringValue = &value
+	return a
+}
+
+func otlpInt(key string, value int64) otlpAttribute {
+	a := otlpAttribute{Key: key}
+	s := strconv.FormatInt(value, 10)
+	a.Value.IntValue = &s
+	return a
+}
+
+// randomHex returns n random bytes, hex encoded.
+func randomHex(n int) string {
+	b := make([]byte, n)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// newOTLPExporter creates an exporter sending to the collector endpoint and
+// registers it with FlushTraces.
+func newOTLPExporter(endpoint string, headers map[string]string, version string) (*otlpExporter, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("%q is not an http or https URL", endpoint)
+	}
+	e := &otlpExporter{
+		endpoint:   endpoint,
+		headers:    headers,
+		version:    version,
+		httpClient: &http.Client{Timeout: otlpSendTimeout},
+		traceID:    randomHex(16),
+	}
+	otlpExportersMu.Lock()
+	otlpExporters = append(otlpExporters, e)
+	otlpExportersMu.Unlock()
+	return e, nil
+}
+
+// record queues the span of an API call to the server at baseURL. It is a
+// no-op on a nil exporter, so clients without tracing need no checks.
+func (e *otlpExporter) record(ctx context.Context, baseURL, method, path string, status int, start, end time.Time, callErr error) {
+	if e == nil {
+		return
+	}
+	server := baseURL
+	if u, err := url.Parse(baseURL); err == nil {
+		server = u.Hostname()
+	}
+	endpoint, _, _ := strings.Cut(path, "?")
+	span := otlpSpan{
+		TraceID:           e.traceID,
+		SpanID:            randomHex(8),
+		Name:              endpoint,
+		Kind:              otlpSpanKindClient,
+		StartTimeUnixNano: strconv.FormatInt(start.UnixNano(), 10),
+		EndTimeUnixNano:   strconv.FormatInt(end.UnixNano(), 10),
+		Attributes: []otlpAttribute{
+			otlpString("http.request.method", method),
+			otlpString("server.address", server),
+			otlpString("uyuni.api.endpoint", endpoint),
+		},
+		Status: otlpStatus{Code: otlpStatusCodeOK},
+	}
+	if status != 0 {
+		span.Attributes = append(span.Attributes, otlpInt("http.response.status_code", int64(status)))
+	}
+	if callErr != nil {
+		span.Status = otlpStatus{Code: otlpStatusCodeError, Message: callErr.Error()}
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.sendErr != nil {
+		tflog.Warn(ctx, "Unable to send traces to the OpenTelemetry collector", map[string]interface{}{"error": e.sendErr.Error()})
+		e.sendErr = nil
+	}
+	e.pending = append(e.pending, span)
+	switch {
+	case len(e.pending) >= otlpBatchSize:
+		e.flushLocked()
+	case e.timer == nil:
+		e.timer = time.AfterFunc(otlpBatchDelay, func() {
+			e.mu.Lock()
+			defer e.mu.Unlock()
+			e.flushLocked()
+		})
+	}
+}
+
+// flushLocked sends the pending spans in the background. e.mu must be held.
+func (e *otlpExporter) flushLocked() {
+	if e.timer != nil {
+		e.timer.Stop()
+		e.timer = nil
+	}
+	if len(e.pending) == 0 {
+		return
+	}
+	spans := e.pending
+	e.pending = nil
+	e.sending.Add(1)
+	go func() {
+		defer e.sending.Done()
+		if err := e.send(spans); err != nil {
+			e.mu.Lock()
+			e.sendErr = err
+			e.mu.Unlock()
+		}
+	}()
+}
+
+// send posts spans to the collector as an ExportTraceServiceRequest.
+func (e *otlpExporter) send(spans []otlpSpan) error {
+	request := map[string]interface{}{
+		"resourceSpans": []interface{}{map[string]interface{}{
+			"resource": map[string]interface{}{
+				"attributes": []otlpAttribute{otlpString("service.name", otlpServiceName)},
+			},
+			"scopeSpans": []interface{}{map[string]interface{}{
+				"scope": map[string]interface{}{"name": otlpServiceName, "version": e.version},
+				"spans": spans,
+			}},
+		}},
+	}
+	data, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodPost, e.endpoint, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for name, value := range e.headers {
+		req.Header.Set(name, value)
+	}
+	res, err := e.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("collector returned HTTP %d", res.StatusCode)
+	}
+	return nil
+}
+
+// flush sends the pending spans and waits until all spans are sent or ctx is
+// done.
+func (e *otlpExporter) flush(ctx context.Context) {
+	e.mu.Lock()
+	e.flushLocked()
+	e.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		e.sending.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-ctx.Done():
+	}
+}
+
+// FlushTraces sends the spans not sent yet by the providers of the process.
+// It is called when the provider server stops, as spans are sent in batches.
+func FlushTraces(ctx context.Context) {
+	otlpExportersMu.Lock()
+	exporters := append([]*otlpExporter(nil), otlpExporters...)
+	otlpExportersMu.Unlock()
+
+	for _, e := range exporters {
+		e.flush(ctx)
+	}
+}
@@ -0,0 +1,69 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestOTLPExporter(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		requests []map[string]interface{}
+	)
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/traces" || r.Header.Get("Authorization") != "Bearer token" || r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("unexpected request %s %v", r.URL, r.Header)
+		}
+		var request map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			t.Error(err)
+		}
+		mu.Lock()
+		requests = append(requests, request)
+		mu.Unlock()
+	}))
+	defer collector.Close()
+
+	e, err := newOTLPExporter(collector.URL+"/v1/traces", map[string]string{"Authorization": "Bearer token"}, "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	start := time.Unix(1700000000, 0)
+	e.record(ctx, "https://uyuni.example.com/rhn/manager/api", http.MethodGet, "system/getDetails?sid=1000010001", http.StatusOK, start, start.Add(time.Second), nil)
+	e.record(ctx, "https://uyuni.example.com/rhn/manager/api", http.MethodPost, "user/create", http.StatusBadRequest, start, start.Add(time.Second), errors.New("Login already in use"))
+	FlushTraces(ctx)
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(requests) != 1 {
+		t.Fatalf("expected the spans in one batch, got %d requests", len(requests))
+	}
+	scopeSpans := requests[0]["resourceSpans"].([]interface{})[0].(map[string]interface{})["scopeSpans"].([]interface{})
+	spans := scopeSpans[0].(map[string]interface{})["spans"].([]interface{})
+	if len(spans) != 2 {
+		t.Fatalf("expected 2 spans, got %v", spans)
+	}
+	first, second := spans[0].(map[string]interface{}), spans[1].(map[string]interface{})
+	if first["name"] != "system/getDetails" || first["traceId"] != second["traceId"] || first["spanId"] == second["spanId"] {
+		t.Errorf("unexpected spans %v", spans)
+	}
+	if first["startTimeUnixNano"] != "1700000000000000000" || first["endTimeUnixNano"] != "1700000001000000000" {
+		t.Errorf("unexpected span times %v", first)
+	}
+	if status := second["status"].(map[string]interface{}); status["code"] != float64(otlpStatusCodeError) || status["message"] != "Login already in use" {
+		t.Errorf("unexpected status of the failed call %v", status)
+	}
+}
+
+func TestOTLPExporterInvalidEndpoint(t *testing.T) {
+	if _, err := newOTLPExporter("localhost:4318", nil, "test"); err == nil {
+		t.Error("expected an error for an endpoint without scheme")
+	}
+}
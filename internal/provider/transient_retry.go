@@ -0,0 +1,75 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"time"
+
+	"terraform-provider-uyuni/internal/uyuni"
+)
+
+// defaultRetries is how often a request failing transiently is retried when
+// the provider does not set max_retries.
+const defaultRetries = 3
+
+// transientRetryInterval is the delay before the first retry of a request
+// failing transiently. It doubles with every retry up to
+// transientRetryMaxInterval. Both are variables so that tests do not have to
+// wait.
+var (
+	transientRetryInterval    = time.Second
+	transientRetryMaxInterval = 30 * time.Second
+)
+
+// transientStatuses are the HTTP statuses of a proxy or of the server
+// restarting, which do not mean the request is wrong. Faults of the API
+// itself have status 500 and are never retried.
+var transientStatuses = []int{
+	http.StatusTooManyRequests,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+// isTransientError reports whether a request failed for a reason that is
+// likely gone when it is sent again. Reads are retried after any network
+// error or transient status. Writes are only retried when the connection
+// could not be established, as the server may have processed them otherwise.
+func isTransientError(method string, err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Op == "dial" {
+		return true
+	}
+	if method != http.MethodGet {
+		return false
+	}
+	var fault *uyuni.Fault
+	if errors.As(err, &fault) {
+		for _, status := range transientStatuses {
+			if fault.StatusCode == status {
+				return true
+			}
+		}
+		return false
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+// transientRetryDelay returns the delay before the given retry, counted from
+// zero.
+func transientRetryDelay(retry int) time.Duration {
+	delay := transientRetryInterval
+	for i := 0; i < retry && delay < transientRetryMaxInterval; i++ {
+		delay *= 2
+	}
+	if delay > transientRetryMaxInterval {
+		return transientRetryMaxInterval
+	}
+	return delay
+}
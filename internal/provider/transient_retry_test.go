@@ -0,0 +1,104 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"terraform-provider-uyuni/internal/uyuni"
+)
+
+func testTransientRetry(t *testing.T) {
+	interval, maxInterval := transientRetryInterval, transientRetryMaxInterval
+	transientRetryInterval, transientRetryMaxInterval = time.Millisecond, 4*time.Millisecond
+	t.Cleanup(func() { transientRetryInterval, transientRetryMaxInterval = interval, maxInterval })
+}
+
+func TestIsTransientError(t *testing.T) {
+	dial := &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}
+	reset := &net.OpError{Op: "read", Net: "tcp", Err: errors.New("connection reset by peer")}
+	tests := map[string]struct {
+		method string
+		err    error
+		want   bool
+	}{
+		"read bad gateway":    {http.MethodGet, &apiError{Err: &uyuni.Fault{StatusCode: http.StatusBadGateway}}, true},
+		"read fault":          {http.MethodGet, &uyuni.Fault{StatusCode: http.StatusInternalServerError, Message: "No such system"}, false},
+		"read reset":          {http.MethodGet, reset, true},
+		"read deadline":       {http.MethodGet, context.DeadlineExceeded, false},
+		"write bad gateway":   {http.MethodPost, &uyuni.Fault{StatusCode: http.StatusBadGateway}, false},
+		"write reset":         {http.MethodPost, reset, false},
+		"write dial":          {http.MethodPost, dial, true},
+		"unsupported feature": {http.MethodGet, errors.New("not supported by the server"), false},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := isTransientError(tt.method, tt.err); got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTransientRetryDelay(t *testing.T) {
+	testTransientRetry(t)
+	want := []time.Duration{time.Millisecond, 2 * time.Millisecond, 4 * time.Millisecond, 4 * time.Millisecond}
+	for retry, delay := range want {
+		if got := transientRetryDelay(retry); got != delay {
+			t.Errorf("retry %d: got %s, want %s", retry, got, delay)
+		}
+	}
+}
+
+func TestAPIRequestRetriesTransientReads(t *testing.T) {
+	testTransientRetry(t)
+	calls := 0
+	client := testAPIClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		_, _ = w.Write([]byte(`{"success": true, "result": 1}`))
+	})
+	client.retries = defaultRetries
+
+	result, err := apiGet[int](context.Background(), client, "system/listSystems")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Result != 1 || calls != 3 {
+		t.Errorf("got result %d after %d calls", result.Result, calls)
+	}
+}
+
+func TestAPIRequestGivesUpAfterMaxRetries(t *testing.T) {
+	testTransientRetry(t)
+	tests := map[string]struct {
+		method string
+		want   int
+	}{
+		"read":  {http.MethodGet, 3},
+		"write": {http.MethodPost, 1},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			calls := 0
+			client := testAPIClient(t, func(w http.ResponseWriter, r *http.Request) {
+				calls++
+				w.WriteHeader(http.StatusGatewayTimeout)
+			})
+			client.retries = 2
+
+			if _, err := apiRequest[int](context.Background(), client, tt.method, "system/listSystems", nil); err == nil {
+				t.Fatal("expected an error")
+			}
+			if calls != tt.want {
+				t.Errorf("got %d calls, want %d", calls, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,221 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"sync"
+
+	"terraform-provider-uyuni/internal/uyuni"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &UserPermissionsDataSource{}
+	_ datasource.DataSourceWithConfigure = &UserPermissionsDataSource{}
+)
+
+// UserPermissionsDataSourceModel maps the data source schema data.
+type UserPermissionsDataSourceModel struct {
+	Login              types.String             `tfsdk:"login"`
+	Roles              types.Set                `tfsdk:"roles"`
+	AdministeredGroups types.Set                `tfsdk:"administered_groups"`
+	Channels           []channelPermissionModel `tfsdk:"channels"`
+}
+
+// channelPermissionModel maps the permissions of a user on a software channel.
+type channelPermissionModel struct {
+	Label        types.String `tfsdk:"label"`
+	Manageable   types.Bool   `tfsdk:"manageable"`
+	Subscribable types.Bool   `tfsdk:"subscribable"`
+}
+
+// channelPermission is the permission of a user on a software channel as
+// returned by channel.software.isUserManageable and isUserSubscribable.
+type channelPermission struct {
+	manageable   bool
+	subscribable bool
+}
+
+// NewUserPermissionsDataSource is a helper function to simplify the provider implementation.
+func NewUserPermissionsDataSource() datasource.DataSource {
+	return &UserPermissionsDataSource{}
+}
+
+// UserPermissionsDataSource is the data source implementation.
+type UserPermissionsDataSource struct {
+	client *uyuniClient
+}
+
+// Metadata returns the data source type name.
+func (d *UserPermissionsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_user_permissions"
+}
+
+// Schema defines the schema for the data source.
+func (d *UserPermissionsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Reads the effective permissions of a user, e.g. to export them for access reviews. " +
+			"Channel permissions cover the software channels of the organization of the provider user. " +
+			"Requires an organization administrator.",
+		Attributes: map[string]schema.Attribute{
+			"login": schema.StringAttribute{
+				Description: "Login of the user.",
+				Required:    true,
+			},
+			"roles": schema.SetAttribute{
+				Description: "Roles of the user, e.g. `org_admin` or `channel_admin`.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"administered_groups": schema.SetAttribute{
+				Description: "Names of the system groups the user administers.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"channels": schema.ListNestedAttribute{
+				Description: "Permissions of the user on the software channels of the organization, ordered by label.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"label": schema.StringAttribute{
+							Description: "Label of the channel.",
+							Computed:    true,
+						},
+						"manageable": schema.BoolAttribute{
+							Description: "Whether the user can manage the channel.",
+							Computed:    true,
+						},
+						"subscribable": schema.BoolAttribute{
+							Description: "Whether the user can subscribe systems to the channel.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *UserPermissionsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state UserPermissionsDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	login := state.Login.ValueString()
+
+	roles, err := apiGet[[]string](ctx, d.client, "user/listRoles?login="+url.QueryEscape(login))
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to Read Uyuni user roles", err.Error())
+		return
+	}
+	groups, err := apiGet[[]uyuni.SystemGroup](ctx, d.client, "user/listAssignedSystemGroups?login="+url.QueryEscape(login))
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to Read Uyuni user system groups", err.Error())
+		return
+	}
+	permissions, err := listChannelPermissions(ctx, d.client, login)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to Read Uyuni user channel permissions", err.Error())
+		return
+	}
+
+	state.Roles, diags = types.SetValueFrom(ctx, types.StringType, append([]string{}, roles.Result...))
+	resp.Diagnostics.Append(diags...)
+	groupNames := make([]string, 0, len(groups.Result))
+	for _, group := range groups.Result {
+		groupNames = append(groupNames, group.Name)
+	}
+	state.AdministeredGroups, diags = types.SetValueFrom(ctx, types.StringType, groupNames)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	labels := make([]string, 0, len(permissions))
+	for label := range permissions {
+		labels = append(labels, label)
+	}
+	sort.Strings(labels)
+	state.Channels = make([]channelPermissionModel, 0, len(labels))
+	for _, label := range labels {
+		state.Channels = append(state.Channels, channelPermissionModel{
+			Label:        types.StringValue(label),
+			Manageable:   types.BoolValue(permissions[label].manageable),
+			Subscribable: types.BoolValue(permissions[label].subscribable),
+		})
+	}
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// listChannelPermissions returns the permissions of a user on the software
+// channels of the organization of the client by channel label.
+func listChannelPermissions(ctx context.Context, client *uyuniClient, login string) (map[string]channelPermission, error) {
+	channels, err := apiGet[[]uyuni.OrgChannel](ctx, client, "channel/listMyChannels")
+	if err != nil {
+		return nil, err
+	}
+	labels := make([]string, 0, len(channels.Result))
+	for _, channel := range channels.Result {
+		labels = append(labels, channel.Label)
+	}
+
+	var mu sync.Mutex
+	permissions := map[string]channelPermission{}
+	errs := runBatch(labels, func(label string) error {
+		query := url.Values{"channelLabel": {label}, "login": {login}}
+		manageable, err := apiGet[bool](ctx, client, "channel/software/isUserManageable?"+query.Encode())
+		if err != nil {
+			return err
+		}
+		subscribable, err := apiGet[bool](ctx, client, "channel/software/isUserSubscribable?"+query.Encode())
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		permissions[label] = channelPermission{manageable: manageable.Result, subscribable: subscribable.Result}
+		mu.Unlock()
+		return nil
+	})
+	for _, label := range labels {
+		if err := errs[label]; err != nil {
+			return nil, fmt.Errorf("channel %s: %w", label, err)
+		}
+	}
+	return permissions, nil
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *UserPermissionsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*uyuniClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *uyuniClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
@@ -0,0 +1,117 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestUserPermissionsDataSource(t *testing.T) {
+	ctx := context.Background()
+	client := testAPIClient(t, func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if login := query.Get("login"); r.URL.Path != "/channel/listMyChannels" && login != "jdoe" {
+			t.Errorf("unexpected login %q in %s", login, r.URL)
+		}
+		switch r.URL.Path {
+		case "/user/listRoles":
+			_, _ = w.Write([]byte(`{"success": true, "result": ["channel_admin", "system_group_admin"]}`))
+		case "/user/listAssignedSystemGroups":
+			_, _ = w.Write([]byte(`{"success": true, "result": [{"id": 5, "name": "web", "description": "Web servers", "org_id": 1, "system_count": 2}]}`))
+		case "/channel/listMyChannels":
+			_, _ = w.Write([]byte(`{"success": true, "result": [
+				{"id": 118, "label": "prod-updates", "name": "prod-updates", "provider_name": "Example Org", "packages": 10, "systems": 4, "arch_name": "x86_64"},
+				{"id": 117, "label": "dev-updates", "name": "dev-updates", "provider_name": "Example Org", "packages": 12, "systems": 1, "arch_name": "x86_64"}
+			]}`))
+		case "/channel/software/isUserManageable":
+			_, _ = w.Write([]byte(`{"success": true, "result": ` + strconv.FormatBool(query.Get("channelLabel") == "dev-updates") + `}`))
+		case "/channel/software/isUserSubscribable":
+			_, _ = w.Write([]byte(`{"success": true, "result": true}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL)
+		}
+	})
+
+	d := NewUserPermissionsDataSource()
+	d.(datasource.DataSourceWithConfigure).Configure(ctx, datasource.ConfigureRequest{ProviderData: client}, &datasource.ConfigureResponse{})
+	var schemaResp datasource.SchemaResponse
+	d.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	values := map[string]tftypes.Value{}
+	for name, attributeType := range objectType.AttributeTypes {
+		values[name] = tftypes.NewValue(attributeType, nil)
+	}
+	values["login"] = tftypes.NewValue(tftypes.String, "jdoe")
+	config := tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, values)}
+	resp := &datasource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: config.Raw}}
+	d.Read(ctx, datasource.ReadRequest{Config: config}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+
+	var state UserPermissionsDataSourceModel
+	resp.State.Get(ctx, &state)
+	var roles, groups []string
+	state.Roles.ElementsAs(ctx, &roles, false)
+	state.AdministeredGroups.ElementsAs(ctx, &groups, false)
+	sort.Strings(roles)
+	if want := []string{"channel_admin", "system_group_admin"}; !reflect.DeepEqual(roles, want) {
+		t.Errorf("expected roles %v, got %v", want, roles)
+	}
+	if want := []string{"web"}; !reflect.DeepEqual(groups, want) {
+		t.Errorf("expected groups %v, got %v", want, groups)
+	}
+	want := []channelPermissionModel{
+		{Label: types.StringValue("dev-updates"), Manageable: types.BoolValue(true), Subscribable: types.BoolValue(true)},
+		{Label: types.StringValue("prod-updates"), Manageable: types.BoolValue(false), Subscribable: types.BoolValue(true)},
+	}
+	if !reflect.DeepEqual(state.Channels, want) {
+		t.Errorf("expected channels %v, got %v", want, state.Channels)
+	}
+}
+
+func TestAccUserPermissionsDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccRealServerPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "uyuni_user" "test" {
+  login     = "tfacc-ds-permissions"
+  password  = "Secret-123"
+  firstname = "Acceptance"
+  lastname  = "Tests"
+  email     = "tfacc@example.com"
+  roles     = ["system_group_admin"]
+}
+
+resource "uyuni_system_group" "test" {
+  name           = "tfacc-ds-permissions"
+  description    = "Group of the acceptance tests"
+  administrators = [uyuni_user.test.login]
+}
+
+data "uyuni_user_permissions" "test" {
+  login      = uyuni_user.test.login
+  depends_on = [uyuni_system_group.test]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckTypeSetElemAttr("data.uyuni_user_permissions.test", "roles.*", "system_group_admin"),
+					resource.TestCheckTypeSetElemAttr("data.uyuni_user_permissions.test", "administered_groups.*", "tfacc-ds-permissions"),
+				),
+			},
+		},
+	})
+}
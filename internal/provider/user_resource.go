@@ -3,18 +3,36 @@ package provider
 import (
 	"context"
 	"fmt"
+	"net/url"
+	"slices"
+	"sort"
+	"time"
 
+	"terraform-provider-uyuni/internal/uyuni"
+	"terraform-provider-uyuni/internal/validators"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
-	"github.com/uyuni-project/uyuni-tools/shared/api"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource              = &userResource{}
-	_ resource.ResourceWithConfigure = &userResource{}
+	_ resource.Resource                     = &userResource{}
+	_ resource.ResourceWithConfigure        = &userResource{}
+	_ resource.ResourceWithImportState      = &userResource{}
+	_ resource.ResourceWithUpgradeState     = &userResource{}
+	_ resource.ResourceWithConfigValidators = &userResource{}
+	_ resource.ResourceWithModifyPlan       = &userResource{}
 )
 
 // NewUserResource is a helper function to simplify the provider implementation.
@@ -24,17 +42,96 @@ func NewUserResource() resource.Resource {
 
 // userResource is the resource implementation.
 type userResource struct {
-	client *api.HTTPClient
+	client *uyuniClient
 }
 
 // userResourceModel maps the resource schema data.
 type userResourceModel struct {
-	// ID        types.String `tfsdk:"id"`
-	Login     types.String `tfsdk:"login"`
-	Password  types.String `tfsdk:"password"`
-	FirstName types.String `tfsdk:"firstname"`
-	LastName  types.String `tfsdk:"lastname"`
-	Email     types.String `tfsdk:"email"`
+	ID                        types.String   `tfsdk:"id"`
+	Login                     types.String   `tfsdk:"login"`
+	Password                  types.String   `tfsdk:"password"`
+	FirstName                 types.String   `tfsdk:"firstname"`
+	LastName                  types.String   `tfsdk:"lastname"`
+	Email                     types.String   `tfsdk:"email"`
+	UsePAM                    types.Bool     `tfsdk:"use_pam"`
+	Enabled                   types.Bool     `tfsdk:"enabled"`
+	Roles                     types.Set      `tfsdk:"roles"`
+	CredentialsMaxAgeDays     types.Int64    `tfsdk:"credentials_max_age_days"`
+	CredentialsSetDate        types.String   `tfsdk:"credentials_set_date"`
+	CredentialsExpirationDate types.String   `tfsdk:"credentials_expiration_date"`
+	CreatedDate               types.String   `tfsdk:"created_date"`
+	LastLoginDate             types.String   `tfsdk:"last_login_date"`
+	ErrataNotification        types.Bool     `tfsdk:"errata_notification"`
+	AdoptExisting             types.Bool     `tfsdk:"adopt_existing"`
+	ReassignTo                types.String   `tfsdk:"reassign_to"`
+	DisownOwnedObjects        types.Bool     `tfsdk:"disown_owned_objects"`
+	ServerAlias               types.String   `tfsdk:"server_alias"`
+	Org                       *orgModel      `tfsdk:"org"`
+	Timeouts                  timeouts.Value `tfsdk:"timeouts"`
+}
+
+// userStateMigrations upgrade states of prior schema versions.
+var userStateMigrations = stateMigrations{
+	// Version 0 had no id attribute.
+	migrateAddID("login"),
+	// Version 1 could not adopt existing users.
+	migrateSetAttribute("adopt_existing", false),
+}
+
+// setDetails sets the computed dates and the errata notification setting
+// from the details of the user.
+func (m *userResourceModel) setDetails(ctx context.Context, user *uyuni.UserDetails) {
+	m.CreatedDate = timestampValue(ctx, user.CreatedDate)
+	m.LastLoginDate = timestampValue(ctx, user.LastLoginDate)
+	m.ErrataNotification = types.BoolValue(user.ErrataNotification)
+}
+
+// bulk returns the attributes the server stores, as managed by uyuni_users.
+func (m *userResourceModel) bulk() bulkUserModel {
+	return bulkUserModel{
+		Password:  m.Password,
+		FirstName: m.FirstName,
+		LastName:  m.LastName,
+		Email:     m.Email,
+		UsePAM:    m.UsePAM,
+	}
+}
+
+// passwordSet records that the password of the model was just set.
+func (m *userResourceModel) passwordSet(now time.Time) {
+	m.CredentialsSetDate = types.StringNull()
+	if !m.Password.IsNull() {
+		m.CredentialsSetDate = types.StringValue(now.UTC().Format(time.RFC3339))
+	}
+	m.setPasswordExpiration()
+}
+
+// setPasswordExpiration computes the expiration date of the password, which
+// is null unless both its age limit and the date it was set are known.
+func (m *userResourceModel) setPasswordExpiration() {
+	m.CredentialsExpirationDate = types.StringNull()
+	if m.CredentialsSetDate.IsUnknown() || m.CredentialsMaxAgeDays.IsUnknown() {
+		m.CredentialsExpirationDate = types.StringUnknown()
+		return
+	}
+	if m.CredentialsSetDate.IsNull() || m.CredentialsMaxAgeDays.IsNull() {
+		return
+	}
+	set, err := time.Parse(time.RFC3339, m.CredentialsSetDate.ValueString())
+	if err != nil {
+		return
+	}
+	expiration := set.AddDate(0, 0, int(m.CredentialsMaxAgeDays.ValueInt64()))
+	m.CredentialsExpirationDate = types.StringValue(expiration.Format(time.RFC3339))
+}
+
+// passwordExpired reports whether the password expired at now.
+func (m *userResourceModel) passwordExpired(now time.Time) bool {
+	if m.CredentialsExpirationDate.IsNull() || m.CredentialsExpirationDate.IsUnknown() {
+		return false
+	}
+	expiration, err := time.Parse(time.RFC3339, m.CredentialsExpirationDate.ValueString())
+	return err == nil && !now.Before(expiration)
 }
 
 // Metadata returns the resource type name.
@@ -43,18 +140,30 @@ func (r *userResource) Metadata(_ context.Context, req resource.MetadataRequest,
 }
 
 // Schema defines the schema for the resource.
-func (r *userResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *userResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version: userStateMigrations.version(),
 		Attributes: map[string]schema.Attribute{
-			// "id": schema.StringAttribute{
-			// 	Computed: true,
-			// },
+			"id": schema.StringAttribute{
+				Description: "Login of the user.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
 			"login": schema.StringAttribute{
 				Required: true,
+				Validators: []validator.String{
+					validators.Login(),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"password": schema.StringAttribute{
-				Required:  true,
-				Sensitive: true,
+				Description: "Password of the user, required unless use_pam is true.",
+				Optional:    true,
+				Sensitive:   true,
 			},
 			"firstname": schema.StringAttribute{
 				Required: true,
@@ -64,11 +173,136 @@ func (r *userResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 			},
 			"email": schema.StringAttribute{
 				Required: true,
+				Validators: []validator.String{
+					validators.Email(),
+				},
+			},
+			"use_pam": schema.BoolAttribute{
+				Description: "Authenticate the user through PAM instead of a password.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"enabled": schema.BoolAttribute{
+				Description: "Whether the user may log in. Disabled users keep their roles and objects. Defaults to true.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+			},
+			"roles": schema.SetAttribute{
+				Description: "Roles of the user, e.g. `org_admin` or `channel_admin`. The roles org_admin implies are only " +
+					"tracked if they are set. Unset to leave the roles to the server.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"credentials_max_age_days": schema.Int64Attribute{
+				Description: "Number of days after which the password set by Terraform expires. The server neither expires " +
+					"passwords nor forces users to change them, so an expired password is reported as a warning on refresh " +
+					"until a new one is set, and credentials_expiration_date can be checked by check blocks.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"credentials_set_date": schema.StringAttribute{
+				Description: "Date Terraform last set the password, in RFC 3339 format. The server does not expose it, " +
+					"so it is null for imported users and users authenticating through PAM, and passwords changed " +
+					"outside of Terraform are not noticed.",
+				Computed: true,
+			},
+			"credentials_expiration_date": schema.StringAttribute{
+				Description: "Date the password expires, in RFC 3339 format. Null without credentials_max_age_days or credentials_set_date.",
+				Computed:    true,
+			},
+			"created_date": schema.StringAttribute{
+				Description: "Date the user was created, in RFC 3339 format.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"last_login_date": schema.StringAttribute{
+				Description: "Date the user last logged in, in RFC 3339 format. Null if the user never logged in.",
+				Computed:    true,
+			},
+			"errata_notification": schema.BoolAttribute{
+				Description: "Whether the user gets an email for each advisory relevant to its systems, " +
+					"e.g. false for service accounts syncing channels. Defaults to the setting of the server, " +
+					"which notifies new users.",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
 			},
+			"adopt_existing": adoptExistingAttribute(),
+			"reassign_to": schema.StringAttribute{
+				Description: "Login of the user taking over the autoinstall profiles of the user when it is destroyed, " +
+					"as the server refuses to delete users owning objects. Actions the user scheduled which are still " +
+					"pending cannot change hands and are canceled.",
+				Optional: true,
+				Validators: []validator.String{
+					validators.Login(),
+				},
+			},
+			"disown_owned_objects": schema.BoolAttribute{
+				Description: "Leave the autoinstall profiles of the user to the organization and cancel the pending " +
+					"actions it scheduled when it is destroyed, as the server refuses to delete users owning objects.",
+				Optional: true,
+			},
+			"server_alias": serverAliasAttribute(),
+		},
+		Blocks: map[string]schema.Block{
+			"org": orgBlock(),
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+				Delete: true,
+			}),
 		},
 	}
 }
 
+// ConfigValidators returns the validators checking attributes against each other.
+func (r *userResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		validators.ConflictsWhenTrue("use_pam", "password"),
+		validators.RequiredUnlessTrue("use_pam", "password"),
+		validators.ConflictsWhenTrue("disown_owned_objects", "reassign_to"),
+	}
+}
+
+// ModifyPlan keeps the date the password was set unless the password
+// changes, and plans its expiration date.
+func (r *userResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Creates set the dates, destroys have nothing to plan.
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan, state userResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.CredentialsSetDate = state.CredentialsSetDate
+	if !plan.Password.Equal(state.Password) {
+		plan.CredentialsSetDate = types.StringUnknown()
+		if plan.Password.IsNull() {
+			plan.CredentialsSetDate = types.StringNull()
+		}
+	}
+	plan.setPasswordExpiration()
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
+// UpgradeState upgrades states of prior schema versions.
+func (r *userResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return userStateMigrations.upgraders()
+}
+
 // Create a new resource.
 func (r *userResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	// Retrieve values from plan
@@ -79,6 +313,19 @@ func (r *userResource) Create(ctx context.Context, req resource.CreateRequest, r
 		return
 	}
 
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	client := resourceClient(ctx, r.client, plan.ServerAlias, plan.Org, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
 	// Create new user
 	data := map[string]interface{}{
 		"login":     plan.Login.ValueString(),
@@ -87,21 +334,69 @@ func (r *userResource) Create(ctx context.Context, req resource.CreateRequest, r
 		"lastName":  plan.LastName.ValueString(),
 		"email":     plan.Email.ValueString(),
 	}
+	if plan.UsePAM.ValueBool() {
+		data["usePamAuth"] = 1
+	}
 
 	tflog.Info(ctx, "About to create user")
-	tflog.Info(ctx, ""+plan.Login.String()+" - "+plan.Password.String()+" - "+plan.FirstName.String()+" - "+plan.LastName.String()+" - "+plan.Email.String())
 
-	_, err := api.Post[int](r.client, "user/create", data)
+	_, err := apiPost[int](ctx, client, "user/create", data)
+	if err != nil && plan.AdoptExisting.ValueBool() && isAlreadyExistsError(err) {
+		tflog.Info(ctx, "User "+plan.Login.ValueString()+" already exists, adopting it")
+		err = adoptUser(ctx, client, plan.Login.ValueString(), plan.bulk())
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating user",
-			"Could not create user, unexpected error: "+err.Error(),
+			"Could not create user: "+err.Error(),
 		)
 		return
 	}
 
 	tflog.Info(ctx, "User created")
 
+	plan.ID = plan.Login
+
+	if err := setErrataNotification(ctx, client, plan.Login.ValueString(), plan.ErrataNotification); err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating user",
+			"Could not set the errata notification of user "+plan.Login.ValueString()+": "+err.Error(),
+		)
+	}
+
+	if err := plan.changeRoles(ctx, client); err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating user",
+			"Could not set the roles of user "+plan.Login.ValueString()+": "+err.Error(),
+		)
+	}
+
+	this_user, err := apiGet[uyuni.UserDetails](ctx, client, "user/getDetails?login="+url.QueryEscape(plan.Login.ValueString()))
+	if err == nil && this_user.Result.Enabled != plan.Enabled.ValueBool() {
+		// New users are enabled, adopted ones may not be.
+		if err := setUserEnabled(ctx, client, plan.Login.ValueString(), plan.Enabled.ValueBool()); err != nil {
+			resp.Diagnostics.AddError(
+				"Error creating user",
+				"Could not enable or disable user "+plan.Login.ValueString()+": "+err.Error(),
+			)
+		}
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating user",
+			"Could not read user "+plan.Login.ValueString()+" after creating it: "+err.Error(),
+		)
+		// Fall through to track the user, which Terraform taints.
+		plan.CreatedDate = types.StringNull()
+		plan.LastLoginDate = types.StringNull()
+		if plan.ErrataNotification.IsUnknown() {
+			plan.ErrataNotification = types.BoolNull()
+		}
+	} else {
+		plan.setDetails(ctx, &this_user.Result)
+	}
+	plan.passwordSet(time.Now())
+
 	// Set state to fully populated data
 	diags = resp.State.Set(ctx, plan)
 	tflog.Info(ctx, fmt.Sprintf("Updated state object be like: %v", resp.State))
@@ -111,6 +406,141 @@ func (r *userResource) Create(ctx context.Context, req resource.CreateRequest, r
 	}
 }
 
+// adoptUser updates a user which exists already to the plan, setting its
+// password unless it authenticates through PAM.
+func adoptUser(ctx context.Context, client *uyuniClient, login string, plan bulkUserModel) error {
+	existing, err := apiGet[uyuni.UserDetails](ctx, client, "user/getDetails?login="+url.QueryEscape(login))
+	if err != nil {
+		return err
+	}
+	return updateUser(ctx, client, login, plan, bulkUserModel{UsePAM: types.BoolValue(existing.Result.UsePAM)})
+}
+
+// orgAdminImpliedRoles are the roles the server grants along with org_admin,
+// which it lists as roles of organization administrators.
+var orgAdminImpliedRoles = []string{"activation_key_admin", "channel_admin", "config_admin", "image_admin", "system_group_admin"}
+
+// listUserRoles returns the roles of the user, without the roles org_admin
+// implies unless they are in wanted.
+func listUserRoles(ctx context.Context, client *uyuniClient, login string, wanted []string) ([]string, error) {
+	roles, err := apiGet[[]string](ctx, client, "user/listRoles?login="+url.QueryEscape(login))
+	if err != nil {
+		return nil, err
+	}
+	if !slices.Contains(roles.Result, roleOrgAdmin) {
+		return roles.Result, nil
+	}
+	listed := []string{}
+	for _, role := range roles.Result {
+		if !slices.Contains(orgAdminImpliedRoles, role) || slices.Contains(wanted, role) {
+			listed = append(listed, role)
+		}
+	}
+	return listed, nil
+}
+
+// changeRoles brings the roles of the user in line with the model, unless
+// they are left to the server.
+func (m *userResourceModel) changeRoles(ctx context.Context, client *uyuniClient) error {
+	if m.Roles.IsNull() {
+		return nil
+	}
+	login := m.Login.ValueString()
+	wanted, err := stringSet(ctx, m.Roles)
+	if err != nil {
+		return err
+	}
+	current, err := listUserRoles(ctx, client, login, wanted)
+	if err != nil {
+		return fmt.Errorf("could not list roles: %w", err)
+	}
+	add, remove := setDiff(current, wanted)
+	sort.Strings(add)
+	sort.Strings(remove)
+	for _, change := range []struct {
+		roles    []string
+		endpoint string
+	}{{add, "user/addRole"}, {remove, "user/removeRole"}} {
+		for _, role := range change.roles {
+			if _, err := apiPost[int](ctx, client, change.endpoint, map[string]interface{}{
+				"login": login,
+				"role":  role,
+			}); err != nil {
+				return fmt.Errorf("could not change role %s: %w", role, err)
+			}
+			tflog.Info(ctx, fmt.Sprintf("Called %s with role %s for user %s", change.endpoint, role, login))
+		}
+	}
+	return nil
+}
+
+// setUserEnabled enables or disables the user.
+func setUserEnabled(ctx context.Context, client *uyuniClient, login string, enabled bool) error {
+	endpoint := "user/disable"
+	if enabled {
+		endpoint = "user/enable"
+	}
+	_, err := apiPost[int](ctx, client, endpoint, map[string]interface{}{
+		"login": login,
+	})
+	return err
+}
+
+// setErrataNotification enables or disables the errata emails of the user,
+// unless the setting is unknown, i.e. left to the server.
+func setErrataNotification(ctx context.Context, client *uyuniClient, login string, notify types.Bool) error {
+	if notify.IsUnknown() || notify.IsNull() {
+		return nil
+	}
+	_, err := apiPost[int](ctx, client, "user/setErrataNotifications", map[string]interface{}{
+		"login": login,
+		"value": notify.ValueBool(),
+	})
+	return err
+}
+
+// releaseOwnedObjects hands the autoinstall profiles owned by the user over to
+// the user with the login newOwner, or to the organization if it is empty, and
+// cancels the actions the user scheduled which are still pending, so that the
+// user can be deleted.
+func releaseOwnedObjects(ctx context.Context, client *uyuniClient, login, newOwner string) error {
+	profiles, err := apiGet[[]uyuni.KickstartProfile](ctx, client, "kickstart/listKickstarts")
+	if err != nil {
+		return fmt.Errorf("could not list autoinstall profiles: %w", err)
+	}
+	for _, profile := range profiles.Result {
+		if profile.Owner != login {
+			continue
+		}
+		if _, err := apiPost[int](ctx, client, "kickstart/profile/setOwner", map[string]interface{}{
+			"ksLabel": profile.Label,
+			"login":   newOwner,
+		}); err != nil {
+			return fmt.Errorf("could not hand over autoinstall profile %s: %w", profile.Label, err)
+		}
+		tflog.Info(ctx, fmt.Sprintf("Handed over autoinstall profile %s of user %s", profile.Label, login), map[string]interface{}{"owner": newOwner})
+	}
+
+	actions, err := apiGet[[]uyuni.ScheduledAction](ctx, client, "schedule/listInProgressActions")
+	if err != nil {
+		return fmt.Errorf("could not list pending actions: %w", err)
+	}
+	pending := []int64{}
+	for _, action := range actions.Result {
+		if action.Scheduler == login {
+			pending = append(pending, int64(action.ID))
+		}
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+	if _, err := apiPost[int](ctx, client, "schedule/cancelActions", map[string]interface{}{"actionIds": pending}); err != nil {
+		return fmt.Errorf("could not cancel actions %v: %w", pending, err)
+	}
+	tflog.Info(ctx, fmt.Sprintf("Canceled pending actions %v of user %s", pending, login))
+	return nil
+}
+
 // Read resource information.
 func (r *userResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
 	// Get current state
@@ -121,25 +551,18 @@ func (r *userResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
-	// Get refreshed user value from Uyuni
-	type user_api struct {
-		First_names         string
-		First_name          string
-		Last_name           string
-		Email               string
-		Org_id              int
-		Org_name            string
-		Prefix              string
-		Last_login_date     string
-		Created_date        string
-		Enabled             bool
-		Use_pam             bool
-		Read_only           bool
-		Errata_notification bool
+	client := resourceClient(ctx, r.client, state.ServerAlias, state.Org, &resp.Diagnostics)
+	if client == nil {
+		return
 	}
+
+	// Get refreshed user value from Uyuni
 	tflog.Info(ctx, fmt.Sprintf("About to look for user %s", state.Login.ValueString()))
-	this_user, err := api.Get[user_api](r.client, "user/getDetails?login="+state.Login.ValueString())
+	this_user, err := apiGet[uyuni.UserDetails](ctx, client, "user/getDetails?login="+url.QueryEscape(state.Login.ValueString()))
 	if err != nil {
+		if handleNotFound(ctx, resp, err, "User "+state.Login.ValueString()) {
+			return
+		}
 		resp.Diagnostics.AddError(
 			"Error Reading Uyuuni user",
 			"Could not read User "+state.Login.ValueString()+": "+err.Error(),
@@ -147,11 +570,45 @@ func (r *userResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
-	state.FirstName = types.StringValue(this_user.Result.First_name)
-	state.LastName = types.StringValue(this_user.Result.Last_name)
+	state.FirstName = types.StringValue(this_user.Result.FirstName)
+	state.LastName = types.StringValue(this_user.Result.LastName)
 	state.Email = types.StringValue(this_user.Result.Email)
+	state.UsePAM = types.BoolValue(this_user.Result.UsePAM)
+	state.Enabled = types.BoolValue(this_user.Result.Enabled)
+	state.setDetails(ctx, &this_user.Result)
 	tflog.Info(ctx, fmt.Sprintf("Information returned from API: %v", this_user.Result))
 
+	// Roles are only refreshed where they are managed.
+	if !state.Roles.IsNull() {
+		wanted, err := stringSet(ctx, state.Roles)
+		if err == nil {
+			var roles []string
+			roles, err = listUserRoles(ctx, client, state.Login.ValueString(), wanted)
+			if err == nil {
+				state.Roles, diags = types.SetValueFrom(ctx, types.StringType, roles)
+				resp.Diagnostics.Append(diags...)
+			}
+		}
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Reading Uyuni user",
+				"Could not list roles of user "+state.Login.ValueString()+": "+err.Error(),
+			)
+			return
+		}
+	}
+
+	state.setPasswordExpiration()
+	if state.passwordExpired(time.Now()) {
+		resp.Diagnostics.AddWarning(
+			"Uyuni user password expired",
+			fmt.Sprintf("The password of user %s, set on %s, expired on %s. Set a new password.",
+				state.Login.ValueString(), state.CredentialsSetDate.ValueString(), state.CredentialsExpirationDate.ValueString()),
+		)
+	}
+
+	state.ID = state.Login
+
 	// Set refreshed state
 	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -162,6 +619,79 @@ func (r *userResource) Read(ctx context.Context, req resource.ReadRequest, resp
 
 // Update updates the resource and sets the updated Terraform state on success.
 func (r *userResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state userResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	client := resourceClient(ctx, r.client, plan.ServerAlias, plan.Org, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	if err := updateUser(ctx, client, plan.Login.ValueString(), plan.bulk(), state.bulk()); err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating user",
+			"Could not update user "+plan.Login.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+	if plan.CredentialsSetDate.IsUnknown() {
+		plan.passwordSet(time.Now())
+	}
+	if !plan.ErrataNotification.Equal(state.ErrataNotification) {
+		if err := setErrataNotification(ctx, client, plan.Login.ValueString(), plan.ErrataNotification); err != nil {
+			resp.Diagnostics.AddError(
+				"Error updating user",
+				"Could not set the errata notification of user "+plan.Login.ValueString()+": "+err.Error(),
+			)
+			return
+		}
+	}
+	if !plan.Enabled.Equal(state.Enabled) {
+		if err := setUserEnabled(ctx, client, plan.Login.ValueString(), plan.Enabled.ValueBool()); err != nil {
+			resp.Diagnostics.AddError(
+				"Error updating user",
+				"Could not enable or disable user "+plan.Login.ValueString()+": "+err.Error(),
+			)
+			return
+		}
+	}
+	if !plan.Roles.Equal(state.Roles) {
+		if err := plan.changeRoles(ctx, client); err != nil {
+			resp.Diagnostics.AddError(
+				"Error updating user",
+				"Could not set the roles of user "+plan.Login.ValueString()+": "+err.Error(),
+			)
+			return
+		}
+	}
+
+	this_user, err := apiGet[uyuni.UserDetails](ctx, client, "user/getDetails?login="+url.QueryEscape(plan.Login.ValueString()))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating user",
+			"Could not read user "+plan.Login.ValueString()+" after updating it: "+err.Error(),
+		)
+		return
+	}
+	plan.ID = plan.Login
+	plan.setDetails(ctx, &this_user.Result)
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
 }
 
 func (r *userResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -173,14 +703,35 @@ func (r *userResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 		return
 	}
 
-	// Delete existing user
-	//err := r.client.DeleteOrder(state.ID.ValueString())
-	// this_user, err := api.Get[user_api](r.client, "user/getDetails?login="+state.Login.ValueString())
-	_, err := api.Post[int](r.client, "user/delete?login="+state.Login.ValueString(), map[string]interface{}{})
-	if err != nil {
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	client := resourceClient(ctx, r.client, state.ServerAlias, state.Org, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	if !state.ReassignTo.IsNull() || state.DisownOwnedObjects.ValueBool() {
+		if err := releaseOwnedObjects(ctx, client, state.Login.ValueString(), state.ReassignTo.ValueString()); err != nil {
+			resp.Diagnostics.AddError(
+				"Error Deleting Uyuni user",
+				"Could not release the objects owned by user "+state.Login.ValueString()+": "+err.Error(),
+			)
+			return
+		}
+	}
+
+	// Delete existing user, unless it was deleted already
+	_, err := apiPost[int](ctx, client, "user/delete?login="+url.QueryEscape(state.Login.ValueString()), map[string]interface{}{})
+	if err != nil && !isNotFoundError(err) {
 		resp.Diagnostics.AddError(
 			"Error Deleting Uyuni user",
-			"Could not delete order, unexpected error: "+err.Error(),
+			"Could not delete user "+state.Login.ValueString()+": "+err.Error(),
 		)
 		return
 	}
@@ -194,7 +745,7 @@ func (r *userResource) Configure(_ context.Context, req resource.ConfigureReques
 		return
 	}
 
-	client, ok := req.ProviderData.(*api.HTTPClient)
+	client, ok := req.ProviderData.(*uyuniClient)
 
 	if !ok {
 		resp.Diagnostics.AddError(
@@ -207,3 +758,10 @@ func (r *userResource) Configure(_ context.Context, req resource.ConfigureReques
 
 	r.client = client
 }
+
+// ImportState imports an existing user by login. The password cannot be read
+// back from the server and has to be set in the configuration.
+func (r *userResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("login"), req, resp)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("adopt_existing"), false)...)
+}
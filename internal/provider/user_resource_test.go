@@ -0,0 +1,381 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	acctest "github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccUserResource(t *testing.T) {
+	acctest.Test(t, acctest.TestCase{
+		PreCheck:                 func() { testAccUyuniPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckGone(t, "user/getDetails?login=tfacc-user"),
+		Steps: []acctest.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccUserResourceConfig("tfacc-user"),
+				Check: acctest.ComposeAggregateTestCheckFunc(
+					acctest.TestCheckResourceAttr("uyuni_user.test", "login", "tfacc-user"),
+					acctest.TestCheckResourceAttr("uyuni_user.test", "email", "tfacc-user@example.com"),
+					acctest.TestMatchResourceAttr("uyuni_user.test", "created_date", regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T`)),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:                         "uyuni_user.test",
+				ImportState:                          true,
+				ImportStateId:                        "tfacc-user",
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "login",
+				// The password cannot be read back from the server.
+				ImportStateVerifyIgnore: []string{"password"},
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccUserResourceConfig(login string) string {
+	return fmt.Sprintf(`
+resource "uyuni_user" "test" {
+  login     = %[1]q
+  password  = "tfacc-Secret-123"
+  firstname = "Terraform"
+  lastname  = "Acceptance"
+  email     = "%[1]s@example.com"
+}
+`, login)
+}
+
+func TestUserResourceReportsExpiredPassword(t *testing.T) {
+	resp := testRead(t, NewUserResource(), testAPIClient(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"success": true, "result": {"first_name": "Jane", "last_name": "Doe", "email": "jdoe@example.com", "created_date": "2024-01-01T00:00:00Z"}}`))
+	}), map[string]interface{}{
+		"login":                    "jdoe",
+		"password":                 "initial",
+		"use_pam":                  false,
+		"credentials_max_age_days": int64(30),
+		"credentials_set_date":     "2024-01-01T10:00:00Z",
+	})
+	if resp.Diagnostics.HasError() || resp.Diagnostics.WarningsCount() != 1 {
+		t.Fatalf("expected a warning, got %v", resp.Diagnostics)
+	}
+	var state userResourceModel
+	resp.State.Get(context.Background(), &state)
+	if state.CredentialsExpirationDate.ValueString() != "2024-01-31T10:00:00Z" {
+		t.Errorf("unexpected expiration date %s", state.CredentialsExpirationDate)
+	}
+}
+
+func TestUserResourceUpdateSetsPassword(t *testing.T) {
+	ctx := context.Background()
+	var details map[string]interface{}
+	r := NewUserResource()
+	testConfigure(t, r, testAPIClient(t, func(w http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/user/setDetails":
+			var body map[string]interface{}
+			_ = json.NewDecoder(req.Body).Decode(&body)
+			details, _ = body["details"].(map[string]interface{})
+			_, _ = w.Write([]byte(`{"success": true, "result": 1}`))
+		case "/user/getDetails":
+			_, _ = w.Write([]byte(`{"success": true, "result": {"first_name": "Jane", "last_name": "Doe", "email": "jdoe@example.com", "created_date": "2024-01-01T00:00:00Z"}}`))
+		default:
+			t.Errorf("unexpected request %s", req.URL)
+		}
+	}))
+
+	attributes := map[string]interface{}{
+		"id":                       "jdoe",
+		"login":                    "jdoe",
+		"password":                 "initial",
+		"firstname":                "Jane",
+		"lastname":                 "Doe",
+		"email":                    "jdoe@example.com",
+		"use_pam":                  false,
+		"credentials_max_age_days": int64(30),
+		"credentials_set_date":     "2024-01-01T10:00:00Z",
+	}
+	prior := testState(t, r, attributes)
+	attributes["password"] = "rotated"
+	planned := testState(t, r, attributes)
+
+	planResp := &resource.ModifyPlanResponse{Plan: tfsdk.Plan{Schema: planned.Schema, Raw: planned.Raw}}
+	r.(resource.ResourceWithModifyPlan).ModifyPlan(ctx, resource.ModifyPlanRequest{
+		Plan:  tfsdk.Plan{Schema: planned.Schema, Raw: planned.Raw},
+		State: prior,
+	}, planResp)
+	if planResp.Diagnostics.HasError() {
+		t.Fatal(planResp.Diagnostics)
+	}
+	var plan userResourceModel
+	planResp.Plan.Get(ctx, &plan)
+	if !plan.CredentialsSetDate.IsUnknown() || !plan.CredentialsExpirationDate.IsUnknown() {
+		t.Fatalf("expected the dates to be unknown, got %s and %s", plan.CredentialsSetDate, plan.CredentialsExpirationDate)
+	}
+
+	resp := &resource.UpdateResponse{State: prior}
+	r.Update(ctx, resource.UpdateRequest{Plan: planResp.Plan, State: prior}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+	if details["password"] != "rotated" {
+		t.Errorf("expected the password to be set, got %v", details)
+	}
+	var state userResourceModel
+	resp.State.Get(ctx, &state)
+	set, err := time.Parse(time.RFC3339, state.CredentialsSetDate.ValueString())
+	if err != nil || time.Since(set) > time.Minute {
+		t.Errorf("expected the password to be set now, got %s", state.CredentialsSetDate)
+	}
+	if state.CredentialsExpirationDate.ValueString() != set.AddDate(0, 0, 30).Format(time.RFC3339) {
+		t.Errorf("unexpected expiration date %s", state.CredentialsExpirationDate)
+	}
+}
+
+func TestUserResourceCreateAdoptsExistingUser(t *testing.T) {
+	ctx := context.Background()
+	var requests []string
+	r := NewUserResource()
+	testConfigure(t, r, testAPIClient(t, func(w http.ResponseWriter, req *http.Request) {
+		var body map[string]interface{}
+		_ = json.NewDecoder(req.Body).Decode(&body)
+		switch req.URL.Path {
+		case "/user/create":
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(`{"success": false, "message": "Login already in use"}`))
+			return
+		case "/user/getDetails":
+			_, _ = w.Write([]byte(`{"success": true, "result": {"first_name": "J.", "last_name": "Doe", "email": "jdoe@example.com", "use_pam": true, "created_date": "2024-01-01T00:00:00Z"}}`))
+			return
+		case "/user/setDetails":
+			requests = append(requests, req.URL.Path+" "+fmt.Sprint(body["details"]))
+		default:
+			requests = append(requests, req.URL.Path+" "+fmt.Sprint(body["val"]))
+		}
+		_, _ = w.Write([]byte(`{"success": true, "result": 1}`))
+	}))
+
+	planned := testState(t, r, map[string]interface{}{
+		"login":          "jdoe",
+		"password":       "secret",
+		"firstname":      "Jane",
+		"lastname":       "Doe",
+		"email":          "jdoe@example.com",
+		"use_pam":        false,
+		"adopt_existing": true,
+	})
+	resp := &resource.CreateResponse{State: planned}
+	r.Create(ctx, resource.CreateRequest{Plan: tfsdk.Plan{Schema: planned.Schema, Raw: planned.Raw}}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+	want := "[/user/setDetails map[email:jdoe@example.com first_name:Jane last_name:Doe password:secret] /user/usePamAuthentication 0]"
+	if fmt.Sprint(requests) != want {
+		t.Errorf("expected %s, got %v", want, requests)
+	}
+	var state userResourceModel
+	resp.State.Get(ctx, &state)
+	if state.ID.ValueString() != "jdoe" || state.CreatedDate.ValueString() == "" {
+		t.Errorf("unexpected state %v", state)
+	}
+}
+
+func TestUserResourceDeleteReassignsOwnedObjects(t *testing.T) {
+	ctx := context.Background()
+	var requests []string
+	r := NewUserResource()
+	testConfigure(t, r, testAPIClient(t, func(w http.ResponseWriter, req *http.Request) {
+		var body map[string]interface{}
+		_ = json.NewDecoder(req.Body).Decode(&body)
+		switch req.URL.Path {
+		case "/kickstart/listKickstarts":
+			_, _ = w.Write([]byte(`{"success": true, "result": [{"label": "web", "owner": "jdoe"}, {"label": "base", "owner": "admin"}]}`))
+			return
+		case "/schedule/listInProgressActions":
+			_, _ = w.Write([]byte(`{"success": true, "result": [{"id": 4711, "scheduler": "jdoe"}, {"id": 4712, "scheduler": "admin"}]}`))
+			return
+		case "/kickstart/profile/setOwner":
+			requests = append(requests, req.URL.Path+" "+fmt.Sprint(body["ksLabel"], " ", body["login"]))
+		case "/schedule/cancelActions":
+			requests = append(requests, req.URL.Path+" "+fmt.Sprint(body["actionIds"]))
+		default:
+			requests = append(requests, req.URL.Path+"?"+req.URL.RawQuery)
+		}
+		_, _ = w.Write([]byte(`{"success": true, "result": 1}`))
+	}))
+
+	state := testState(t, r, map[string]interface{}{
+		"id":          "jdoe",
+		"login":       "jdoe",
+		"password":    "secret",
+		"firstname":   "Jane",
+		"lastname":    "Doe",
+		"email":       "jdoe@example.com",
+		"use_pam":     false,
+		"reassign_to": "admin",
+	})
+	resp := &resource.DeleteResponse{State: state}
+	r.Delete(ctx, resource.DeleteRequest{State: state}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+	want := "[/kickstart/profile/setOwner web admin /schedule/cancelActions [4711] /user/delete?login=jdoe]"
+	if fmt.Sprint(requests) != want {
+		t.Errorf("expected %s, got %v", want, requests)
+	}
+}
+
+func TestUserResourceUpdateSetsErrataNotification(t *testing.T) {
+	ctx := context.Background()
+	var requests []string
+	r := NewUserResource()
+	testConfigure(t, r, testAPIClient(t, func(w http.ResponseWriter, req *http.Request) {
+		var body map[string]interface{}
+		_ = json.NewDecoder(req.Body).Decode(&body)
+		switch req.URL.Path {
+		case "/user/getDetails":
+			_, _ = w.Write([]byte(`{"success": true, "result": {"created_date": "2024-01-01T00:00:00Z", "errata_notification": false}}`))
+			return
+		case "/user/setErrataNotifications":
+			requests = append(requests, req.URL.Path+" "+fmt.Sprint(body["login"], " ", body["value"]))
+		}
+		_, _ = w.Write([]byte(`{"success": true, "result": 1}`))
+	}))
+
+	attributes := map[string]interface{}{
+		"id":                  "svc-sync",
+		"login":               "svc-sync",
+		"password":            "secret",
+		"firstname":           "Channel",
+		"lastname":            "Sync",
+		"email":               "ops@example.com",
+		"use_pam":             false,
+		"errata_notification": true,
+	}
+	prior := testState(t, r, attributes)
+	attributes["errata_notification"] = false
+	planned := testState(t, r, attributes)
+
+	resp := &resource.UpdateResponse{State: prior}
+	r.Update(ctx, resource.UpdateRequest{Plan: tfsdk.Plan{Schema: planned.Schema, Raw: planned.Raw}, State: prior}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+	if want := "[/user/setErrataNotifications svc-sync false]"; fmt.Sprint(requests) != want {
+		t.Errorf("expected %s, got %v", want, requests)
+	}
+	var state userResourceModel
+	resp.State.Get(ctx, &state)
+	if state.ErrataNotification.ValueBool() {
+		t.Errorf("expected errata notification to be off, got %s", state.ErrataNotification)
+	}
+}
+
+func TestUserResourceUpdateChangesRolesAndEnabled(t *testing.T) {
+	ctx := context.Background()
+	var requests []string
+	r := NewUserResource()
+	testConfigure(t, r, testAPIClient(t, func(w http.ResponseWriter, req *http.Request) {
+		var body map[string]interface{}
+		_ = json.NewDecoder(req.Body).Decode(&body)
+		switch req.URL.Path {
+		case "/user/listRoles":
+			_, _ = w.Write([]byte(`{"success": true, "result": ["channel_admin", "config_admin"]}`))
+			return
+		case "/user/getDetails":
+			_, _ = w.Write([]byte(`{"success": true, "result": {"first_name": "Jane", "last_name": "Doe", "email": "jdoe@example.com", "created_date": "2024-01-01T00:00:00Z"}}`))
+			return
+		case "/user/setDetails":
+		default:
+			requests = append(requests, strings.TrimSpace(req.URL.Path+" "+fmt.Sprint(body["role"])))
+		}
+		_, _ = w.Write([]byte(`{"success": true, "result": 1}`))
+	}))
+
+	attributes := map[string]interface{}{
+		"id":        "jdoe",
+		"login":     "jdoe",
+		"password":  "secret",
+		"firstname": "Jane",
+		"lastname":  "Doe",
+		"email":     "jdoe@example.com",
+		"use_pam":   false,
+		"enabled":   true,
+		"roles":     []string{"channel_admin", "config_admin"},
+	}
+	prior := testState(t, r, attributes)
+	attributes["enabled"] = false
+	attributes["roles"] = []string{"channel_admin", "system_group_admin"}
+	planned := testState(t, r, attributes)
+	resp := &resource.UpdateResponse{State: prior}
+	r.Update(ctx, resource.UpdateRequest{Plan: tfsdk.Plan{Schema: planned.Schema, Raw: planned.Raw}, State: prior}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+	want := "[/user/disable <nil> /user/addRole system_group_admin /user/removeRole config_admin]"
+	if fmt.Sprint(requests) != want {
+		t.Errorf("expected %s, got %v", want, requests)
+	}
+}
+
+func TestUserResourceReadIgnoresRolesImpliedByOrgAdmin(t *testing.T) {
+	resp := testRead(t, NewUserResource(), testAPIClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/user/listRoles" {
+			_, _ = w.Write([]byte(`{"success": true, "result": ["org_admin", "channel_admin", "image_admin", "system_group_admin"]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"success": true, "result": {"first_name": "Jane", "last_name": "Doe", "email": "jdoe@example.com", "enabled": true, "created_date": "2024-01-01T00:00:00Z"}}`))
+	}), map[string]interface{}{
+		"login":    "jdoe",
+		"password": "secret",
+		"use_pam":  false,
+		"roles":    []string{"org_admin", "image_admin"},
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+	var state userResourceModel
+	resp.State.Get(context.Background(), &state)
+	roles, _ := stringSet(context.Background(), state.Roles)
+	sort.Strings(roles)
+	if fmt.Sprint(roles) != "[image_admin org_admin]" || !state.Enabled.ValueBool() {
+		t.Errorf("unexpected roles %v or enabled %s", roles, state.Enabled)
+	}
+}
+
+func TestUserResourceReadRefreshesChangedUser(t *testing.T) {
+	resp := testRead(t, NewUserResource(), testAPIClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("login") != "j.doe+ops@example.com" {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		_, _ = w.Write([]byte(`{"success": true, "result": {"first_name": "Jane", "last_name": "Roe", "email": "jroe@example.com", "enabled": false, "created_date": "2024-01-01T00:00:00Z"}}`))
+	}), map[string]interface{}{
+		"login":     "j.doe+ops@example.com",
+		"password":  "secret",
+		"firstname": "Jane",
+		"lastname":  "Doe",
+		"email":     "jdoe@example.com",
+		"use_pam":   false,
+		"enabled":   true,
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+	var state userResourceModel
+	resp.State.Get(context.Background(), &state)
+	if state.LastName.ValueString() != "Roe" || state.Email.ValueString() != "jroe@example.com" || state.Enabled.ValueBool() {
+		t.Errorf("expected the changes made on the server, got %s, %s and enabled %s", state.LastName, state.Email, state.Enabled)
+	}
+}
@@ -3,11 +3,14 @@ package provider
 import (
 	"context"
 	"fmt"
+	"net/url"
+	"sync"
+
+	"terraform-provider-uyuni/internal/uyuni"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	"github.com/uyuni-project/uyuni-tools/shared/api"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
@@ -18,20 +21,18 @@ var (
 
 // UsersDataSourceModel maps the data source schema data.
 type UsersDataSourceModel struct {
-	Users []userModel `tfsdk:"user"`
+	IgnorePermissionErrors types.Bool  `tfsdk:"ignore_permission_errors"`
+	IncludeRoles           types.Bool  `tfsdk:"include_roles"`
+	Parallelism            types.Int64 `tfsdk:"parallelism"`
+	Users                  []userModel `tfsdk:"user"`
 }
 
 // userModel maps user schema data.
 type userModel struct {
-	ID    types.Int64  `tfsdk:"id"`
-	Login types.String `tfsdk:"login"`
-}
-
-type user_api struct {
-	Id       int
-	Login    string
-	Login_UC string
-	Enabled  bool
+	ID      types.Int64  `tfsdk:"id"`
+	Login   types.String `tfsdk:"login"`
+	Enabled types.Bool   `tfsdk:"enabled"`
+	Roles   types.Set    `tfsdk:"roles"`
 }
 
 // NewUsersDataSource is a helper function to simplify the provider implementation.
@@ -41,7 +42,7 @@ func NewUsersDataSource() datasource.DataSource {
 
 // UsersDataSource is the data source implementation.
 type UsersDataSource struct {
-	client *api.HTTPClient
+	client *uyuniClient
 }
 
 // Metadata returns the data source type name.
@@ -52,16 +53,36 @@ func (d *UsersDataSource) Metadata(_ context.Context, req datasource.MetadataReq
 // Schema defines the schema for the data source.
 func (d *UsersDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Description: "Lists the users of the organization of the provider user, which requires the `org_admin` role.",
 		Attributes: map[string]schema.Attribute{
-			"user": schema.ListNestedAttribute{
-				Computed: true,
+			"ignore_permission_errors": ignorePermissionErrorsAttribute(roleOrgAdmin),
+			"include_roles": schema.BoolAttribute{
+				Description: "Whether to read the roles of the users, which takes one call per user. " +
+					"The roles are null otherwise. Defaults to false.",
+				Optional: true,
+			},
+			"parallelism": parallelismAttribute("users"),
+			"user": schema.SetNestedAttribute{
+				Description: "Users of the organization.",
+				Computed:    true,
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
 						"id": schema.Int64Attribute{
-							Computed: true,
+							Description: "ID of the user.",
+							Computed:    true,
 						},
 						"login": schema.StringAttribute{
-							Required: true,
+							Description: "Login of the user.",
+							Computed:    true,
+						},
+						"enabled": schema.BoolAttribute{
+							Description: "Whether the user can log in.",
+							Computed:    true,
+						},
+						"roles": schema.SetAttribute{
+							Description: "Roles of the user, e.g. `org_admin`. Null unless include_roles is set.",
+							ElementType: types.StringType,
+							Computed:    true,
 						},
 					},
 				},
@@ -73,35 +94,78 @@ func (d *UsersDataSource) Schema(_ context.Context, _ datasource.SchemaRequest,
 // Read refreshes the Terraform state with the latest data.
 func (d *UsersDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 	var state UsersDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// read users from API
-	users, err := api.Get[[]user_api](d.client, "user/listUsers")
+	users, err := apiGet[[]uyuni.User](ctx, d.client, "user/listUsers")
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Unable to Read Uyuni user",
-			err.Error(),
-		)
-		return
+		if !handleListError(&resp.Diagnostics, "Unable to Read Uyuni user", "user/listUsers", err, roleOrgAdmin, state.IgnorePermissionErrors) {
+			return
+		}
+		users = &uyuni.Response[[]uyuni.User]{}
+	}
+
+	var roles map[string][]string
+	if state.IncludeRoles.ValueBool() {
+		roles, err = rolesByUser(ctx, d.client, users.Result, batchLimit(state.Parallelism))
+		if err != nil {
+			resp.Diagnostics.AddError("Unable to Read Uyuni user", err.Error())
+			return
+		}
 	}
 
 	// Map response body to model
 	for _, this_user := range users.Result {
 		userState := userModel{
-			ID:    types.Int64Value(int64(this_user.Id)),
-			Login: types.StringValue(this_user.Login),
+			ID:      types.Int64Value(int64(this_user.ID)),
+			Login:   types.StringValue(this_user.Login),
+			Enabled: types.BoolValue(this_user.Enabled),
+			Roles:   types.SetNull(types.StringType),
+		}
+		if roles != nil {
+			userState.Roles, diags = types.SetValueFrom(ctx, types.StringType, append([]string{}, roles[this_user.Login]...))
+			resp.Diagnostics.Append(diags...)
 		}
 
 		state.Users = append(state.Users, userState)
 	}
 
 	// Set state
-	diags := resp.State.Set(ctx, &state)
+	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 }
 
+// rolesByUser returns the roles of each user, reading limit users at a time.
+func rolesByUser(ctx context.Context, client *uyuniClient, users []uyuni.User, limit int) (map[string][]string, error) {
+	logins := make([]string, 0, len(users))
+	for _, user := range users {
+		logins = append(logins, user.Login)
+	}
+	roles := map[string][]string{}
+	var mu sync.Mutex
+	errs := runBatchLimit(logins, limit, func(login string) error {
+		listed, err := apiGet[[]string](ctx, client, "user/listRoles?login="+url.QueryEscape(login))
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		roles[login] = listed.Result
+		return nil
+	})
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("could not list roles of users: %w", batchError(errs))
+	}
+	return roles, nil
+}
+
 // Configure adds the provider configured client to the data source.
 func (d *UsersDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
 	// Add a nil check when handling ProviderData because Terraform
@@ -110,11 +174,11 @@ func (d *UsersDataSource) Configure(_ context.Context, req datasource.ConfigureR
 		return
 	}
 
-	client, ok := req.ProviderData.(*api.HTTPClient)
+	client, ok := req.ProviderData.(*uyuniClient)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *api.HTTPClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *uyuniClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
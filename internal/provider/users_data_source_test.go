@@ -0,0 +1,51 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccUsersDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccUyuniPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `data "uyuni_users" "all" {}`,
+				// The administrator created during the installation always exists.
+				Check: resource.TestCheckTypeSetElemNestedAttrs("data.uyuni_users.all", "user.*", map[string]string{
+					"login":   testAccEnvOr("UYUNI_TEST_USERNAME", testAccDefaultUsername),
+					"enabled": "true",
+				}),
+			},
+		},
+	})
+}
+
+func TestUsersDataSourceReadsRoles(t *testing.T) {
+	for _, include := range []bool{false, true} {
+		resp := testDataSourceRead(t, NewUsersDataSource(), fixtureClient(t, "2024.08"), map[string]tftypes.Value{
+			"include_roles": tftypes.NewValue(tftypes.Bool, include),
+			"parallelism":   tftypes.NewValue(tftypes.Number, 2),
+		})
+		if resp.Diagnostics.HasError() {
+			t.Fatal(resp.Diagnostics)
+		}
+		var state UsersDataSourceModel
+		resp.State.Get(context.Background(), &state)
+		if len(state.Users) == 0 {
+			t.Fatal("expected users")
+		}
+		for _, user := range state.Users {
+			if user.Roles.IsNull() == include {
+				t.Errorf("include_roles = %v: unexpected roles %s of %s", include, user.Roles, user.Login)
+			}
+			if include && len(user.Roles.Elements()) == 0 {
+				t.Errorf("expected the roles of %s", user.Login)
+			}
+		}
+	}
+}
@@ -0,0 +1,513 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+
+	"terraform-provider-uyuni/internal/uyuni"
+	"terraform-provider-uyuni/internal/validators"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &usersResource{}
+	_ resource.ResourceWithConfigure      = &usersResource{}
+	_ resource.ResourceWithValidateConfig = &usersResource{}
+	_ resource.ResourceWithImportState    = &usersResource{}
+)
+
+// NewUsersResource is a helper function to simplify the provider implementation.
+func NewUsersResource() resource.Resource {
+	return &usersResource{}
+}
+
+// usersResource manages many users at once. Each user is created, updated
+// and deleted by its own API calls, which run concurrently, and failures are
+// reported per user: users that could not be changed keep their previous
+// state, so that it matches the server.
+type usersResource struct {
+	client *uyuniClient
+}
+
+// usersResourceModel maps the resource schema data.
+type usersResourceModel struct {
+	ID          types.String             `tfsdk:"id"`
+	Users       map[string]bulkUserModel `tfsdk:"users"`
+	ServerAlias types.String             `tfsdk:"server_alias"`
+	Org         *orgModel                `tfsdk:"org"`
+	Timeouts    timeouts.Value           `tfsdk:"timeouts"`
+}
+
+// bulkUserModel maps a user of the users attribute, keyed by login.
+type bulkUserModel struct {
+	Password  types.String `tfsdk:"password"`
+	FirstName types.String `tfsdk:"firstname"`
+	LastName  types.String `tfsdk:"lastname"`
+	Email     types.String `tfsdk:"email"`
+	UsePAM    types.Bool   `tfsdk:"use_pam"`
+}
+
+// equal reports whether the attributes the server stores are the same.
+func (u bulkUserModel) equal(other bulkUserModel) bool {
+	return u.Password.Equal(other.Password) &&
+		u.FirstName.Equal(other.FirstName) &&
+		u.LastName.Equal(other.LastName) &&
+		u.Email.Equal(other.Email) &&
+		u.UsePAM.Equal(other.UsePAM)
+}
+
+// Metadata returns the resource type name.
+func (r *usersResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_users"
+}
+
+// Schema defines the schema for the resource.
+func (r *usersResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages many users at once, e.g. to onboard the accounts of a team. " +
+			"Users that cannot be created, updated or deleted are reported individually, without failing the changes to the other users.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Constant identifier of the resource.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"users": schema.MapNestedAttribute{
+				Description: "Users by login.",
+				Required:    true,
+				Validators: []validator.Map{
+					mapvalidator.KeysAre(validators.Login()),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"password": schema.StringAttribute{
+							Description: "Password of the user, required unless use_pam is true.",
+							Optional:    true,
+							Sensitive:   true,
+						},
+						"firstname": schema.StringAttribute{
+							Required: true,
+						},
+						"lastname": schema.StringAttribute{
+							Required: true,
+						},
+						"email": schema.StringAttribute{
+							Required: true,
+							Validators: []validator.String{
+								validators.Email(),
+							},
+						},
+						"use_pam": schema.BoolAttribute{
+							Description: "Authenticate the user through PAM instead of a password.",
+							Optional:    true,
+							Computed:    true,
+							Default:     booldefault.StaticBool(false),
+						},
+					},
+				},
+			},
+			"server_alias": serverAliasAttribute(),
+		},
+		Blocks: map[string]schema.Block{
+			"org": orgBlock(),
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+// ValidateConfig checks the password of every user against use_pam, which
+// the config validators of the user resource cannot do inside a map.
+func (r *usersResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var users map[string]bulkUserModel
+	diags := req.Config.GetAttribute(ctx, path.Root("users"), &users)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for login, user := range users {
+		if user.UsePAM.IsUnknown() || user.Password.IsUnknown() {
+			continue
+		}
+		switch {
+		case user.UsePAM.ValueBool() && !user.Password.IsNull():
+			resp.Diagnostics.AddAttributeError(
+				path.Root("users").AtMapKey(login).AtName("password"),
+				"Conflicting Attribute Configuration",
+				"Attribute password cannot be set when use_pam is true.",
+			)
+		case !user.UsePAM.ValueBool() && user.Password.IsNull():
+			resp.Diagnostics.AddAttributeError(
+				path.Root("users").AtMapKey(login).AtName("password"),
+				"Missing Attribute Configuration",
+				"Attribute password must be set unless use_pam is true.",
+			)
+		}
+	}
+}
+
+// createUser creates a user.
+func createUser(ctx context.Context, client *uyuniClient, login string, user bulkUserModel) error {
+	data := map[string]interface{}{
+		"login":     login,
+		"password":  user.Password.ValueString(),
+		"firstName": user.FirstName.ValueString(),
+		"lastName":  user.LastName.ValueString(),
+		"email":     user.Email.ValueString(),
+	}
+	if user.UsePAM.ValueBool() {
+		data["usePamAuth"] = 1
+	}
+	_, err := apiPost[int](ctx, client, "user/create", data)
+	return err
+}
+
+// updateUser changes the details of a user that differ from its state.
+func updateUser(ctx context.Context, client *uyuniClient, login string, plan, state bulkUserModel) error {
+	details := map[string]interface{}{
+		"first_name": plan.FirstName.ValueString(),
+		"last_name":  plan.LastName.ValueString(),
+		"email":      plan.Email.ValueString(),
+	}
+	if !plan.Password.IsNull() && !plan.Password.Equal(state.Password) {
+		details["password"] = plan.Password.ValueString()
+	}
+	_, err := apiPost[int](ctx, client, "user/setDetails", map[string]interface{}{
+		"login":   login,
+		"details": details,
+	})
+	if err != nil {
+		return err
+	}
+
+	if !plan.UsePAM.Equal(state.UsePAM) {
+		usePAM := 0
+		if plan.UsePAM.ValueBool() {
+			usePAM = 1
+		}
+		_, err = apiPost[int](ctx, client, "user/usePamAuthentication", map[string]interface{}{
+			"login": login,
+			"val":   usePAM,
+		})
+	}
+	return err
+}
+
+// deleteUser deletes a user. Users deleted already are skipped.
+func deleteUser(ctx context.Context, client *uyuniClient, login string) error {
+	_, err := apiPost[int](ctx, client, "user/delete", map[string]interface{}{
+		"login": login,
+	})
+	if isNotFoundError(err) {
+		tflog.Warn(ctx, "User "+login+" was deleted already")
+		return nil
+	}
+	return err
+}
+
+// addUserErrors reports the errors of a batch by user.
+func addUserErrors(diags *diag.Diagnostics, summary, action string, errs map[string]error) {
+	for login, err := range errs {
+		diags.AddAttributeError(
+			path.Root("users").AtMapKey(login),
+			summary,
+			fmt.Sprintf("Could not %s user %s: %s", action, login, err.Error()),
+		)
+	}
+}
+
+// Create a new resource.
+func (r *usersResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan usersResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	client := resourceClient(ctx, r.client, plan.ServerAlias, plan.Org, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("About to create %d users", len(plan.Users)))
+
+	logins := make([]string, 0, len(plan.Users))
+	for login := range plan.Users {
+		logins = append(logins, login)
+	}
+	errs := runBatch(logins, func(login string) error {
+		return createUser(ctx, client, login, plan.Users[login])
+	})
+	addUserErrors(&resp.Diagnostics, "Error creating user", "create", errs)
+
+	// Only users that exist are stored, the others are created by Update.
+	created := make(map[string]bulkUserModel, len(plan.Users))
+	for login, user := range plan.Users {
+		if errs[login] == nil {
+			created[login] = user
+		}
+	}
+	plan.Users = created
+	plan.ID = types.StringValue("users")
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read resource information.
+func (r *usersResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Get current state
+	var state usersResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := resourceClient(ctx, r.client, state.ServerAlias, state.Org, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	var (
+		mu    sync.Mutex
+		users = make(map[string]bulkUserModel, len(state.Users))
+	)
+	logins := make([]string, 0, len(state.Users))
+	for login := range state.Users {
+		logins = append(logins, login)
+	}
+	errs := runBatch(logins, func(login string) error {
+		details, err := apiGet[uyuni.UserDetails](ctx, client, "user/getDetails?login="+url.QueryEscape(login))
+		if isNotFoundError(err) {
+			tflog.Warn(ctx, "User "+login+" no longer exists, removing it from state")
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		// The password cannot be read back.
+		user := state.Users[login]
+		user.FirstName = types.StringValue(details.Result.FirstName)
+		user.LastName = types.StringValue(details.Result.LastName)
+		user.Email = types.StringValue(details.Result.Email)
+		user.UsePAM = types.BoolValue(details.Result.UsePAM)
+		mu.Lock()
+		users[login] = user
+		mu.Unlock()
+		return nil
+	})
+	if len(errs) > 0 {
+		addUserErrors(&resp.Diagnostics, "Error Reading Uyuni user", "read", errs)
+		return
+	}
+	state.Users = users
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update creates, updates and deletes the users that changed.
+func (r *usersResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Retrieve values from plan
+	var plan, state usersResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	client := resourceClient(ctx, r.client, plan.ServerAlias, plan.Org, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	var created, updated, deleted []string
+	for login, user := range plan.Users {
+		current, ok := state.Users[login]
+		switch {
+		case !ok:
+			created = append(created, login)
+		case !user.equal(current):
+			updated = append(updated, login)
+		}
+	}
+	for login := range state.Users {
+		if _, ok := plan.Users[login]; !ok {
+			deleted = append(deleted, login)
+		}
+	}
+	tflog.Info(ctx, fmt.Sprintf("About to create %d, update %d and delete %d users", len(created), len(updated), len(deleted)))
+
+	createErrs := runBatch(created, func(login string) error {
+		return createUser(ctx, client, login, plan.Users[login])
+	})
+	updateErrs := runBatch(updated, func(login string) error {
+		return updateUser(ctx, client, login, plan.Users[login], state.Users[login])
+	})
+	deleteErrs := runBatch(deleted, func(login string) error {
+		return deleteUser(ctx, client, login)
+	})
+	addUserErrors(&resp.Diagnostics, "Error creating user", "create", createErrs)
+	addUserErrors(&resp.Diagnostics, "Error updating user", "update", updateErrs)
+	addUserErrors(&resp.Diagnostics, "Error deleting user", "delete", deleteErrs)
+
+	// Users that failed keep their previous state.
+	users := make(map[string]bulkUserModel, len(plan.Users))
+	for login, user := range plan.Users {
+		switch {
+		case createErrs[login] != nil:
+		case updateErrs[login] != nil:
+			users[login] = state.Users[login]
+		default:
+			users[login] = user
+		}
+	}
+	for login := range deleteErrs {
+		users[login] = state.Users[login]
+	}
+	plan.Users = users
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete deletes all users.
+func (r *usersResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Retrieve values from state
+	var state usersResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	client := resourceClient(ctx, r.client, state.ServerAlias, state.Org, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	logins := make([]string, 0, len(state.Users))
+	for login := range state.Users {
+		logins = append(logins, login)
+	}
+	errs := runBatch(logins, func(login string) error {
+		return deleteUser(ctx, client, login)
+	})
+	if len(errs) == 0 {
+		return
+	}
+	addUserErrors(&resp.Diagnostics, "Error Deleting Uyuni user", "delete", errs)
+
+	// Keep the users that still exist, so that destroying again retries them.
+	remaining := make(map[string]bulkUserModel, len(errs))
+	for login := range errs {
+		remaining[login] = state.Users[login]
+	}
+	state.Users = remaining
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+// ImportState imports the users of a comma-separated list of logins, e.g.
+// `jdoe,jsmith`, which Read fills in. The passwords cannot be read back from
+// the server, so the next apply sets those of the configuration.
+func (r *usersResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	users := map[string]bulkUserModel{}
+	for _, login := range strings.Split(req.ID, ",") {
+		login = strings.TrimSpace(login)
+		if login == "" {
+			resp.Diagnostics.AddError("Invalid Import ID", fmt.Sprintf("expected import ID in the format \"login,login,...\", got: %q", req.ID))
+			return
+		}
+		users[login] = bulkUserModel{
+			Password:  types.StringNull(),
+			FirstName: types.StringNull(),
+			LastName:  types.StringNull(),
+			Email:     types.StringNull(),
+			UsePAM:    types.BoolNull(),
+		}
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), "users")...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("users"), users)...)
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *usersResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*uyuniClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *uyuniClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
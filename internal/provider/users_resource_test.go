@@ -0,0 +1,276 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	acctest "github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestRunBatch(t *testing.T) {
+	var running, peak atomic.Int32
+	keys := []string{"a", "b", "c", "d", "e", "f", "g", "h", "i", "j", "k", "l"}
+
+	errs := runBatch(keys, func(key string) error {
+		if n := running.Add(1); n > peak.Load() {
+			peak.Store(n)
+		}
+		defer running.Add(-1)
+		if key == "c" || key == "k" {
+			return errors.New("failed " + key)
+		}
+		return nil
+	})
+
+	if len(errs) != 2 || errs["c"] == nil || errs["k"] == nil {
+		t.Errorf("got %v", errs)
+	}
+	if peak.Load() > batchConcurrency {
+		t.Errorf("ran %d calls at once, more than %d", peak.Load(), batchConcurrency)
+	}
+}
+
+// testUsersServer records the user API calls and fails those for the login
+// "broken".
+type testUsersServer struct {
+	mu    sync.Mutex
+	calls []string
+}
+
+func (s *testUsersServer) handler(w http.ResponseWriter, r *http.Request) {
+	var body map[string]interface{}
+	_ = json.NewDecoder(r.Body).Decode(&body)
+	login, _ := body["login"].(string)
+	if login == "" {
+		login = r.URL.Query().Get("login")
+	}
+
+	s.mu.Lock()
+	s.calls = append(s.calls, strings.TrimPrefix(r.URL.Path, "/")+" "+login)
+	s.mu.Unlock()
+
+	switch {
+	case login == "broken":
+		_, _ = w.Write([]byte(`{"success": false, "message": "Internal error"}`))
+	case r.URL.Path == "/user/getDetails" && login == "gone":
+		_, _ = w.Write([]byte(`{"success": false, "message": "No such user: gone"}`))
+	case r.URL.Path == "/user/getDetails":
+		_, _ = w.Write([]byte(`{"success": true, "result": {"first_name": "Server", "last_name": "Side", "email": "` + login + `@example.com", "use_pam": false}}`))
+	default:
+		_, _ = w.Write([]byte(`{"success": true, "result": 1}`))
+	}
+}
+
+func (s *testUsersServer) sortedCalls() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	calls := append([]string(nil), s.calls...)
+	sort.Strings(calls)
+	return calls
+}
+
+func testBulkUser(name string) bulkUserModel {
+	return bulkUserModel{
+		Password:  types.StringValue("secret"),
+		FirstName: types.StringValue(name),
+		LastName:  types.StringValue("Doe"),
+		Email:     types.StringValue(name + "@example.com"),
+		UsePAM:    types.BoolValue(false),
+	}
+}
+
+func testUsersState(t *testing.T, r resource.Resource, users map[string]bulkUserModel) tfsdk.State {
+	return testState(t, r, map[string]interface{}{
+		"id":    "users",
+		"users": users,
+	})
+}
+
+func TestUsersResourceCreateKeepsSuccessfulUsers(t *testing.T) {
+	ctx := context.Background()
+	server := &testUsersServer{}
+	r := NewUsersResource()
+	testConfigure(t, r, testAPIClient(t, server.handler))
+
+	planned := testUsersState(t, r, map[string]bulkUserModel{
+		"jdoe":   testBulkUser("jdoe"),
+		"broken": testBulkUser("broken"),
+	})
+	resp := &resource.CreateResponse{State: planned}
+	r.Create(ctx, resource.CreateRequest{Plan: tfsdk.Plan{Schema: planned.Schema, Raw: planned.Raw}}, resp)
+
+	if len(resp.Diagnostics) != 1 || !strings.Contains(resp.Diagnostics[0].Detail(), "Could not create user broken") {
+		t.Fatalf("expected an error for the broken user only, got %v", resp.Diagnostics)
+	}
+	var state usersResourceModel
+	resp.State.Get(ctx, &state)
+	if _, ok := state.Users["jdoe"]; !ok || len(state.Users) != 1 {
+		t.Errorf("expected only jdoe in state, got %v", state.Users)
+	}
+}
+
+func TestUsersResourceUpdateAppliesChangesPerUser(t *testing.T) {
+	ctx := context.Background()
+	server := &testUsersServer{}
+	r := NewUsersResource()
+	testConfigure(t, r, testAPIClient(t, server.handler))
+
+	renamed := testBulkUser("jdoe")
+	renamed.LastName = types.StringValue("Smith")
+	prior := testUsersState(t, r, map[string]bulkUserModel{
+		"jdoe":    testBulkUser("jdoe"),
+		"asmith":  testBulkUser("asmith"),
+		"leaving": testBulkUser("leaving"),
+		"broken":  testBulkUser("broken"),
+	})
+	planned := testUsersState(t, r, map[string]bulkUserModel{
+		"jdoe":   renamed,
+		"asmith": testBulkUser("asmith"),
+		"new":    testBulkUser("new"),
+	})
+
+	resp := &resource.UpdateResponse{State: prior}
+	r.Update(ctx, resource.UpdateRequest{Plan: tfsdk.Plan{Schema: planned.Schema, Raw: planned.Raw}, State: prior}, resp)
+
+	want := []string{"user/create new", "user/delete broken", "user/delete leaving", "user/setDetails jdoe"}
+	if got := server.sortedCalls(); strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("got calls %v, want %v", got, want)
+	}
+	if len(resp.Diagnostics) != 1 || !strings.Contains(resp.Diagnostics[0].Detail(), "Could not delete user broken") {
+		t.Fatalf("expected an error for the broken user only, got %v", resp.Diagnostics)
+	}
+
+	var state usersResourceModel
+	resp.State.Get(ctx, &state)
+	logins := make([]string, 0, len(state.Users))
+	for login := range state.Users {
+		logins = append(logins, login)
+	}
+	sort.Strings(logins)
+	if strings.Join(logins, ",") != "asmith,broken,jdoe,new" {
+		t.Errorf("got users %v in state", logins)
+	}
+	if state.Users["jdoe"].LastName.ValueString() != "Smith" {
+		t.Errorf("expected the updated user in state, got %v", state.Users["jdoe"])
+	}
+}
+
+func TestUsersResourceReadDropsVanishedUsers(t *testing.T) {
+	ctx := context.Background()
+	server := &testUsersServer{}
+	r := NewUsersResource()
+
+	resp := testRead(t, r, testAPIClient(t, server.handler), map[string]interface{}{
+		"id": "users",
+		"users": map[string]bulkUserModel{
+			"jdoe": testBulkUser("jdoe"),
+			"gone": testBulkUser("gone"),
+		},
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+
+	var state usersResourceModel
+	resp.State.Get(ctx, &state)
+	if len(state.Users) != 1 {
+		t.Fatalf("expected the vanished user to be removed, got %v", state.Users)
+	}
+	jdoe := state.Users["jdoe"]
+	if jdoe.FirstName.ValueString() != "Server" || jdoe.Password.ValueString() != "secret" {
+		t.Errorf("expected server details and the password from state, got %v", jdoe)
+	}
+}
+
+func TestUsersResourceImportsLogins(t *testing.T) {
+	ctx := context.Background()
+	server := &testUsersServer{}
+	r := NewUsersResource()
+	testConfigure(t, r, testAPIClient(t, server.handler))
+
+	state := testState(t, r, nil)
+	importResp := &resource.ImportStateResponse{State: state}
+	r.(resource.ResourceWithImportState).ImportState(ctx, resource.ImportStateRequest{ID: "jdoe, jsmith"}, importResp)
+	if importResp.Diagnostics.HasError() {
+		t.Fatal(importResp.Diagnostics)
+	}
+	resp := &resource.ReadResponse{State: importResp.State}
+	r.Read(ctx, resource.ReadRequest{State: importResp.State}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+
+	var imported usersResourceModel
+	resp.State.Get(ctx, &imported)
+	jsmith := imported.Users["jsmith"]
+	if imported.ID.ValueString() != "users" || len(imported.Users) != 2 || jsmith.Email.ValueString() != "jsmith@example.com" || !jsmith.Password.IsNull() {
+		t.Errorf("unexpected state %v", imported)
+	}
+
+	importResp = &resource.ImportStateResponse{State: state}
+	r.(resource.ResourceWithImportState).ImportState(ctx, resource.ImportStateRequest{ID: "jdoe,,jsmith"}, importResp)
+	if !importResp.Diagnostics.HasError() {
+		t.Error("expected an empty login to be rejected")
+	}
+}
+
+func TestAccUsersResource(t *testing.T) {
+	acctest.Test(t, acctest.TestCase{
+		PreCheck:                 func() { testAccUyuniPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: acctest.ComposeAggregateTestCheckFunc(
+			testAccCheckGone(t, "user/getDetails?login=tfacc-users-a"),
+			testAccCheckGone(t, "user/getDetails?login=tfacc-users-b"),
+		),
+		Steps: []acctest.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccUsersResourceConfig("example.com"),
+				Check: acctest.ComposeAggregateTestCheckFunc(
+					acctest.TestCheckResourceAttr("uyuni_users.test", "users.%", "2"),
+					acctest.TestCheckResourceAttr("uyuni_users.test", "users.tfacc-users-a.email", "tfacc-users-a@example.com"),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: testAccUsersResourceConfig("example.org"),
+				Check:  acctest.TestCheckResourceAttr("uyuni_users.test", "users.tfacc-users-b.email", "tfacc-users-b@example.org"),
+			},
+			// ImportState testing
+			{
+				ResourceName:            "uyuni_users.test",
+				ImportState:             true,
+				ImportStateId:           "tfacc-users-a,tfacc-users-b",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"users.tfacc-users-a.password", "users.tfacc-users-b.password"},
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccUsersResourceConfig(domain string) string {
+	return fmt.Sprintf(`
+resource "uyuni_users" "test" {
+  users = {
+    for login in ["tfacc-users-a", "tfacc-users-b"] : login => {
+      password  = "tfacc-Secret-123"
+      firstname = "Terraform"
+      lastname  = "Acceptance"
+      email     = "${login}@%s"
+    }
+  }
+}
+`, domain)
+}
@@ -0,0 +1,271 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+
+	"terraform-provider-uyuni/internal/uyuni"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &VirtualSystemsDataSource{}
+	_ datasource.DataSourceWithConfigure = &VirtualSystemsDataSource{}
+)
+
+// VirtualSystemsDataSourceModel maps the data source schema data.
+type VirtualSystemsDataSourceModel struct {
+	HostIDs     types.Set           `tfsdk:"host_ids"`
+	Parallelism types.Int64         `tfsdk:"parallelism"`
+	Hosts       []virtualHostModel  `tfsdk:"hosts"`
+	Guests      []virtualGuestModel `tfsdk:"guests"`
+}
+
+// virtualHostModel maps a virtual host.
+type virtualHostModel struct {
+	ID          types.Int64  `tfsdk:"id"`
+	Name        types.String `tfsdk:"name"`
+	LastCheckin types.String `tfsdk:"last_checkin"`
+	GuestCount  types.Int64  `tfsdk:"guest_count"`
+}
+
+// virtualGuestModel maps a virtual guest and its host.
+type virtualGuestModel struct {
+	SystemID    types.Int64  `tfsdk:"system_id"`
+	Name        types.String `tfsdk:"name"`
+	GuestName   types.String `tfsdk:"guest_name"`
+	UUID        types.String `tfsdk:"uuid"`
+	LastCheckin types.String `tfsdk:"last_checkin"`
+	HostID      types.Int64  `tfsdk:"host_id"`
+	HostName    types.String `tfsdk:"host_name"`
+}
+
+// NewVirtualSystemsDataSource is a helper function to simplify the provider implementation.
+func NewVirtualSystemsDataSource() datasource.DataSource {
+	return &VirtualSystemsDataSource{}
+}
+
+// VirtualSystemsDataSource is the data source implementation.
+type VirtualSystemsDataSource struct {
+	client *uyuniClient
+}
+
+// Metadata returns the data source type name.
+func (d *VirtualSystemsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_virtual_systems"
+}
+
+// Schema defines the schema for the data source.
+func (d *VirtualSystemsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the virtual hosts known to the server and their guests, e.g. to spread the members of a " +
+			"cluster across hosts. Hosts are registered virtualization hosts as well as the hypervisors reported by " +
+			"virtual host managers, such as VMware vCenter. Guests need not be registered themselves.",
+		Attributes: map[string]schema.Attribute{
+			"host_ids": schema.SetAttribute{
+				Description: "IDs of the hosts to list. Defaults to all of them.",
+				ElementType: types.Int64Type,
+				Optional:    true,
+			},
+			"parallelism": parallelismAttribute("hosts"),
+			"hosts": schema.ListNestedAttribute{
+				Description: "Virtual hosts, ordered by ID.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.Int64Attribute{
+							Description: "System ID of the host.",
+							Computed:    true,
+						},
+						"name": schema.StringAttribute{
+							Description: "Name of the host.",
+							Computed:    true,
+						},
+						"last_checkin": schema.StringAttribute{
+							Description: "Date the host or its virtual host manager last reported, in RFC 3339 format.",
+							Computed:    true,
+						},
+						"guest_count": schema.Int64Attribute{
+							Description: "Number of guests of the host.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"guests": schema.ListNestedAttribute{
+				Description: "Virtual guests of the hosts, ordered by host ID and guest name.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"system_id": schema.Int64Attribute{
+							Description: "ID of the registered system of the guest. Null if the guest is not registered.",
+							Computed:    true,
+						},
+						"name": schema.StringAttribute{
+							Description: "Name of the registered system of the guest. Null if the guest is not registered.",
+							Computed:    true,
+						},
+						"guest_name": schema.StringAttribute{
+							Description: "Name of the guest on the host, e.g. the name of the libvirt domain or VMware VM.",
+							Computed:    true,
+						},
+						"uuid": schema.StringAttribute{
+							Description: "UUID of the guest.",
+							Computed:    true,
+						},
+						"last_checkin": schema.StringAttribute{
+							Description: "Date the registered system of the guest last checked in, in RFC 3339 format. " +
+								"Null if the guest is not registered.",
+							Computed: true,
+						},
+						"host_id": schema.Int64Attribute{
+							Description: "System ID of the host running the guest.",
+							Computed:    true,
+						},
+						"host_name": schema.StringAttribute{
+							Description: "Name of the host running the guest.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *VirtualSystemsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state VirtualSystemsDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	hosts, err := apiGet[[]uyuni.VirtualHost](ctx, d.client, "system/listVirtualHosts")
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Uyuni virtual systems",
+			"Could not list virtual hosts: "+err.Error(),
+		)
+		return
+	}
+	var selected map[int64]bool
+	if !state.HostIDs.IsNull() {
+		ids, err := int64Set(ctx, state.HostIDs)
+		if err != nil {
+			resp.Diagnostics.AddError("Unable to Read Uyuni virtual systems", err.Error())
+			return
+		}
+		selected = map[int64]bool{}
+		for _, id := range ids {
+			selected[id] = true
+		}
+	}
+	byID := map[string]uyuni.VirtualHost{}
+	for _, host := range hosts.Result {
+		if selected == nil || selected[int64(host.ID)] {
+			byID[strconv.Itoa(host.ID)] = host
+		}
+	}
+	sids := make([]string, 0, len(byID))
+	for sid := range byID {
+		sids = append(sids, sid)
+	}
+
+	var mu sync.Mutex
+	guests := map[string][]uyuni.VirtualGuest{}
+	errs := runBatchLimit(sids, batchLimit(state.Parallelism), func(sid string) error {
+		hostGuests, err := apiGet[[]uyuni.VirtualGuest](ctx, d.client, "system/listVirtualGuests?sid="+sid)
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		guests[sid] = hostGuests.Result
+		mu.Unlock()
+		return nil
+	})
+	if err := batchError(errs); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Uyuni virtual systems",
+			"Could not list the guests of hosts "+err.Error(),
+		)
+		return
+	}
+
+	state.Hosts = make([]virtualHostModel, 0, len(byID))
+	state.Guests = []virtualGuestModel{}
+	for sid, host := range byID {
+		state.Hosts = append(state.Hosts, virtualHostModel{
+			ID:          types.Int64Value(int64(host.ID)),
+			Name:        types.StringValue(host.Name),
+			LastCheckin: timestampValue(ctx, host.LastCheckin),
+			GuestCount:  types.Int64Value(int64(len(guests[sid]))),
+		})
+		for _, guest := range guests[sid] {
+			model := virtualGuestModel{
+				SystemID:    types.Int64Null(),
+				Name:        types.StringNull(),
+				GuestName:   types.StringValue(guest.GuestName),
+				UUID:        types.StringValue(guest.UUID),
+				LastCheckin: types.StringNull(),
+				HostID:      types.Int64Value(int64(host.ID)),
+				HostName:    types.StringValue(host.Name),
+			}
+			if guest.ID != 0 {
+				model.SystemID = types.Int64Value(int64(guest.ID))
+				model.Name = types.StringValue(guest.Name)
+				model.LastCheckin = timestampValue(ctx, guest.LastCheckin)
+			}
+			state.Guests = append(state.Guests, model)
+		}
+	}
+	sort.Slice(state.Hosts, func(i, j int) bool {
+		return state.Hosts[i].ID.ValueInt64() < state.Hosts[j].ID.ValueInt64()
+	})
+	sort.Slice(state.Guests, func(i, j int) bool {
+		a, b := state.Guests[i], state.Guests[j]
+		if a.HostID.ValueInt64() != b.HostID.ValueInt64() {
+			return a.HostID.ValueInt64() < b.HostID.ValueInt64()
+		}
+		if a.GuestName.ValueString() != b.GuestName.ValueString() {
+			return a.GuestName.ValueString() < b.GuestName.ValueString()
+		}
+		return a.UUID.ValueString() < b.UUID.ValueString()
+	})
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *VirtualSystemsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*uyuniClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *uyuniClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
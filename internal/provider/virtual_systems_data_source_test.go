@@ -0,0 +1,91 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestVirtualSystemsDataSource(t *testing.T) {
+	ctx := context.Background()
+	client := testAPIClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/system/listVirtualHosts":
+			_, _ = w.Write([]byte(`{"success": true, "result": [
+				{"id": 1000010021, "name": "esx02.example.com", "last_checkin": "2024-09-02T10:00:00Z"},
+				{"id": 1000010020, "name": "kvm01.example.com", "last_checkin": "2024-09-02T10:15:00Z"},
+				{"id": 1000010022, "name": "esx03.example.com", "last_checkin": "2024-09-02T10:00:00Z"}
+			]}`))
+		case "/system/listVirtualGuests":
+			switch r.URL.Query().Get("sid") {
+			case "1000010020":
+				_, _ = w.Write([]byte(`{"success": true, "result": [
+					{"id": 1000010000, "name": "web01.example.com", "guest_name": "web01", "last_checkin": "2024-09-02T10:12:00Z", "uuid": "5e4c1c37"},
+					{"guest_name": "scratch", "uuid": "0b6f7a3e"}
+				]}`))
+			case "1000010021":
+				_, _ = w.Write([]byte(`{"success": true, "result": [
+					{"id": 1000010001, "name": "db01.example.com", "guest_name": "db01", "last_checkin": "2024-09-02T10:11:00Z", "uuid": "7d1e2f3a"}
+				]}`))
+			default:
+				t.Errorf("unexpected request %s", r.URL)
+			}
+		default:
+			t.Errorf("unexpected request %s", r.URL)
+		}
+	})
+
+	resp := testDataSourceRead(t, NewVirtualSystemsDataSource(), client, map[string]tftypes.Value{
+		"host_ids": tftypes.NewValue(tftypes.Set{ElementType: tftypes.Number}, []tftypes.Value{
+			tftypes.NewValue(tftypes.Number, 1000010020),
+			tftypes.NewValue(tftypes.Number, 1000010021),
+		}),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+
+	var state VirtualSystemsDataSourceModel
+	resp.State.Get(ctx, &state)
+	if len(state.Hosts) != 2 || state.Hosts[0].Name.ValueString() != "kvm01.example.com" || state.Hosts[0].GuestCount.ValueInt64() != 2 {
+		t.Fatalf("expected the selected hosts ordered by ID, got %v", state.Hosts)
+	}
+	if len(state.Guests) != 3 {
+		t.Fatalf("expected 3 guests, got %v", state.Guests)
+	}
+	scratch, web01, db01 := state.Guests[0], state.Guests[1], state.Guests[2]
+	if scratch.GuestName.ValueString() != "scratch" || !scratch.SystemID.IsNull() || !scratch.LastCheckin.IsNull() {
+		t.Errorf("expected an unregistered guest, got %v", scratch)
+	}
+	if web01.SystemID.ValueInt64() != 1000010000 || web01.HostID.ValueInt64() != 1000010020 || web01.LastCheckin.ValueString() != "2024-09-02T10:12:00Z" {
+		t.Errorf("unexpected guest %v", web01)
+	}
+	if db01.HostName.ValueString() != "esx02.example.com" {
+		t.Errorf("expected db01 on esx02, got %v", db01)
+	}
+}
+
+func TestAccVirtualSystemsDataSource(t *testing.T) {
+	host := os.Getenv("UYUNI_TEST_VIRTUAL_HOST_ID")
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccRealServerPreCheck(t)
+			testAccSkipUnlessEnv(t, "UYUNI_TEST_VIRTUAL_HOST_ID", "a registered virtual host")
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`data "uyuni_virtual_systems" "test" { host_ids = [%s] }`, host),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.uyuni_virtual_systems.test", "hosts.#", "1"),
+					resource.TestCheckResourceAttr("data.uyuni_virtual_systems.test", "hosts.0.id", host),
+				),
+			},
+		},
+	})
+}
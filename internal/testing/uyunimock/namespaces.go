@@ -0,0 +1,527 @@
+package uyunimock
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"terraform-provider-uyuni/internal/uyuni"
+)
+
+// orgID and orgName are the organization every object belongs to.
+const (
+	orgID   = 1
+	orgName = "Mock Organization"
+)
+
+// user is a user with the details the API does not return.
+type user struct {
+	id       int
+	password string
+	details  uyuni.UserDetails
+	roles    map[string]bool
+}
+
+// group is a system group with its administrators.
+type group struct {
+	details uyuni.SystemGroup
+	admins  map[string]bool
+}
+
+// configChannel is a configuration channel.
+type configChannel struct {
+	details uyuni.ConfigChannel
+}
+
+// channel is a software channel.
+type channel struct {
+	details uyuni.Channel
+}
+
+// registerNamespaces registers the built-in handlers.
+func (s *Server) registerNamespaces() {
+	for call, handler := range map[string]Handler{
+		"GET api/systemVersion": func(*Request) (interface{}, error) { return s.Version, nil },
+		"GET api/getVersion":    func(*Request) (interface{}, error) { return "25", nil },
+		"POST auth/logout":      func(*Request) (interface{}, error) { return 1, nil },
+
+		// Deleting a user hands its kickstart profiles over and cancels
+		// its actions, the mock has neither.
+		"GET kickstart/listKickstarts":       func(*Request) (interface{}, error) { return []interface{}{}, nil },
+		"GET schedule/listInProgressActions": func(*Request) (interface{}, error) { return []interface{}{}, nil },
+
+		"POST user/create":                   s.createUser,
+		"GET user/getDetails":                s.withUser(func(u *user, _ *Request) (interface{}, error) { return u.details, nil }),
+		"POST user/setDetails":               s.withUser(setUserDetails),
+		"POST user/delete":                   s.deleteUser,
+		"GET user/listUsers":                 s.listUsers,
+		"GET user/listRoles":                 s.withUser(listRoles),
+		"POST user/addRole":                  s.withUser(changeRole(true)),
+		"POST user/removeRole":               s.withUser(changeRole(false)),
+		"POST user/enable":                   s.withUser(setUserFlag(func(d *uyuni.UserDetails, v bool) { d.Enabled = v }, true)),
+		"POST user/disable":                  s.withUser(setUserFlag(func(d *uyuni.UserDetails, v bool) { d.Enabled = v }, false)),
+		"POST user/setErrataNotifications":   s.withUser(setUserValue("value", func(d *uyuni.UserDetails, v bool) { d.ErrataNotification = v })),
+		"POST user/usePamAuthentication":     s.withUser(setUserValue("val", func(d *uyuni.UserDetails, v bool) { d.UsePAM = v })),
+		"POST systemgroup/create":            s.createGroup,
+		"GET systemgroup/getDetails":         s.withGroup(func(g *group, _ *Request) (interface{}, error) { return g.details, nil }),
+		"POST systemgroup/update":            s.withGroup(updateGroup),
+		"POST systemgroup/delete":            s.deleteGroup,
+		"GET systemgroup/listAllGroups":      s.listGroups,
+		"GET systemgroup/listAdministrators": s.withGroup(s.listAdmins),
+		"POST systemgroup/addOrRemoveAdmins": s.withGroup(s.changeAdmins),
+		// The mock has no systems.
+		"GET systemgroup/listSystemsMinimal":       s.withGroup(func(*group, *Request) (interface{}, error) { return []uyuni.ShortSystem{}, nil }),
+		"POST systemgroup/addOrRemoveSystems":      s.withGroup(func(*group, *Request) (interface{}, error) { return nil, NotFound("system") }),
+		"POST configchannel/create":                s.createConfigChannel,
+		"GET configchannel/getDetails":             s.withConfigChannel(func(c *configChannel, _ *Request) (interface{}, error) { return c.details, nil }),
+		"POST configchannel/update":                s.withConfigChannel(updateConfigChannel),
+		"POST configchannel/deleteChannels":        s.deleteConfigChannels,
+		"POST channel/software/create":             s.createChannel,
+		"GET channel/software/getDetails":          s.withChannel(func(c *channel, _ *Request) (interface{}, error) { return c.details, nil }),
+		"POST channel/software/setDetails":         s.withChannel(setChannelDetails),
+		"POST channel/software/regenerateYumCache": s.withChannel(func(*channel, *Request) (interface{}, error) { return 1, nil }),
+		"POST channel/software/delete":             s.deleteChannel,
+		"GET channel/listAllChannels":              s.listChannels,
+	} {
+		s.handlers[call] = handler
+	}
+}
+
+// now returns the current time in the format of the API.
+func now() string {
+	return time.Now().UTC().Format(time.RFC3339)
+}
+
+// flag returns the boolean body parameter name, which the API also accepts
+// as 0 or 1.
+func flag(r *Request, name string) bool {
+	switch value := r.Body[name].(type) {
+	case bool:
+		return value
+	case float64:
+		return value != 0
+	}
+	return false
+}
+
+// stringList returns the string list body parameter name.
+func stringList(r *Request, name string) []string {
+	values, _ := r.Body[name].([]interface{})
+	list := make([]string, 0, len(values))
+	for _, value := range values {
+		if value, ok := value.(string); ok {
+			list = append(list, value)
+		}
+	}
+	return list
+}
+
+func (s *Server) createUser(r *Request) (interface{}, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	login := r.String("login")
+	if login == "" {
+		return nil, Faultf("Invalid login")
+	}
+	if _, ok := s.users[login]; ok {
+		return nil, Faultf("Login already in use: %s", login)
+	}
+	s.users[login] = &user{
+		id:       s.id(),
+		password: r.String("password"),
+		details: uyuni.UserDetails{
+			FirstName:   r.String("firstName"),
+			LastName:    r.String("lastName"),
+			Email:       r.String("email"),
+			OrgID:       orgID,
+			OrgName:     orgName,
+			CreatedDate: now(),
+			Enabled:     true,
+			UsePAM:      flag(r, "usePamAuth"),
+		},
+		roles: map[string]bool{},
+	}
+	return 1, nil
+}
+
+// withUser returns a handler calling fn with the user named by the login
+// parameter, holding the lock.
+func (s *Server) withUser(fn func(u *user, r *Request) (interface{}, error)) Handler {
+	return func(r *Request) (interface{}, error) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		login := r.String("login")
+		u, ok := s.users[login]
+		if !ok {
+			return nil, NotFound("user: " + login)
+		}
+		return fn(u, r)
+	}
+}
+
+func setUserDetails(u *user, r *Request) (interface{}, error) {
+	details, _ := r.Body["details"].(map[string]interface{})
+	for key, value := range details {
+		value, _ := value.(string)
+		switch key {
+		case "first_name":
+			u.details.FirstName = value
+		case "last_name":
+			u.details.LastName = value
+		case "email":
+			u.details.Email = value
+		case "password":
+			u.password = value
+		default:
+			return nil, Faultf("Invalid detail %s", key)
+		}
+	}
+	return 1, nil
+}
+
+func (s *Server) deleteUser(r *Request) (interface{}, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	login := r.String("login")
+	if _, ok := s.users[login]; !ok {
+		return nil, NotFound("user: " + login)
+	}
+	delete(s.users, login)
+	for _, g := range s.groups {
+		delete(g.admins, login)
+	}
+	return 1, nil
+}
+
+func (s *Server) listUsers(*Request) (interface{}, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	users := []uyuni.User{}
+	for login, u := range s.users {
+		users = append(users, uyuni.User{ID: u.id, Login: login, LoginUC: strings.ToUpper(login), Enabled: u.details.Enabled})
+	}
+	sort.Slice(users, func(i, j int) bool { return users[i].Login < users[j].Login })
+	return users, nil
+}
+
+func listRoles(u *user, _ *Request) (interface{}, error) {
+	roles := []string{}
+	for role := range u.roles {
+		roles = append(roles, role)
+	}
+	sort.Strings(roles)
+	return roles, nil
+}
+
+func changeRole(add bool) func(u *user, r *Request) (interface{}, error) {
+	return func(u *user, r *Request) (interface{}, error) {
+		role := r.String("role")
+		if role == "" {
+			return nil, Faultf("Invalid role")
+		}
+		if add {
+			u.roles[role] = true
+		} else {
+			delete(u.roles, role)
+		}
+		return 1, nil
+	}
+}
+
+func setUserFlag(set func(*uyuni.UserDetails, bool), value bool) func(u *user, r *Request) (interface{}, error) {
+	return func(u *user, _ *Request) (interface{}, error) {
+		set(&u.details, value)
+		return 1, nil
+	}
+}
+
+func setUserValue(name string, set func(*uyuni.UserDetails, bool)) func(u *user, r *Request) (interface{}, error) {
+	return func(u *user, r *Request) (interface{}, error) {
+		set(&u.details, flag(r, name))
+		return 1, nil
+	}
+}
+
+func (s *Server) createGroup(r *Request) (interface{}, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	name := r.String("name")
+	if _, ok := s.groups[name]; ok {
+		return nil, Faultf("System group %s already exists", name)
+	}
+	g := &group{
+		details: uyuni.SystemGroup{ID: s.id(), Name: name, Description: r.String("description"), OrgID: orgID},
+		admins:  map[string]bool{},
+	}
+	s.groups[name] = g
+	return g.details, nil
+}
+
+// withGroup returns a handler calling fn with the group named by the
+// systemGroupName parameter, holding the lock.
+func (s *Server) withGroup(fn func(g *group, r *Request) (interface{}, error)) Handler {
+	return func(r *Request) (interface{}, error) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		name := r.String("systemGroupName")
+		g, ok := s.groups[name]
+		if !ok {
+			return nil, Faultf("Unable to locate or access server group: %s does not exist", name)
+		}
+		return fn(g, r)
+	}
+}
+
+func updateGroup(g *group, r *Request) (interface{}, error) {
+	g.details.Description = r.String("description")
+	return g.details, nil
+}
+
+func (s *Server) deleteGroup(r *Request) (interface{}, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	name := r.String("systemGroupName")
+	if _, ok := s.groups[name]; !ok {
+		return nil, Faultf("Unable to locate or access server group: %s does not exist", name)
+	}
+	delete(s.groups, name)
+	return 1, nil
+}
+
+func (s *Server) listGroups(*Request) (interface{}, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	groups := []uyuni.SystemGroup{}
+	for _, g := range s.groups {
+		groups = append(groups, g.details)
+	}
+	sort.Slice(groups, func(i, j int) bool { return groups[i].Name < groups[j].Name })
+	return groups, nil
+}
+
+// listAdmins lists the administrators of the group. The caller holds mu.
+func (s *Server) listAdmins(g *group, _ *Request) (interface{}, error) {
+	admins := []uyuni.User{}
+	for login := range g.admins {
+		if u, ok := s.users[login]; ok {
+			admins = append(admins, uyuni.User{ID: u.id, Login: login, LoginUC: strings.ToUpper(login), Enabled: u.details.Enabled})
+		}
+	}
+	sort.Slice(admins, func(i, j int) bool { return admins[i].Login < admins[j].Login })
+	return admins, nil
+}
+
+// changeAdmins adds or removes administrators. The caller holds mu.
+func (s *Server) changeAdmins(g *group, r *Request) (interface{}, error) {
+	add := flag(r, "add")
+	for _, login := range stringList(r, "loginName") {
+		if _, ok := s.users[login]; !ok {
+			return nil, NotFound("user: " + login)
+		}
+		if add {
+			g.admins[login] = true
+		} else {
+			delete(g.admins, login)
+		}
+	}
+	return 1, nil
+}
+
+// configChannelTypes are the types of configuration channels by label.
+var configChannelTypes = map[string]uyuni.ConfigChannelType{
+	"normal": {ID: 1, Label: "normal", Name: "A normal configuration channel", Priority: 1},
+	"state":  {ID: 4, Label: "state", Name: "A channel of Salt states", Priority: 1},
+}
+
+func (s *Server) createConfigChannel(r *Request) (interface{}, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	label := r.String("channelLabel")
+	if _, ok := s.configChannels[label]; ok {
+		return nil, Faultf("Configuration channel %s already exists", label)
+	}
+	channelType := r.String("channelType")
+	if channelType == "" {
+		channelType = "normal"
+	}
+	typeDetails, ok := configChannelTypes[channelType]
+	if !ok {
+		return nil, Faultf("Invalid configuration channel type %s", channelType)
+	}
+	c := &configChannel{details: uyuni.ConfigChannel{
+		ID:                s.id(),
+		OrgID:             orgID,
+		Label:             label,
+		Name:              r.String("channelName"),
+		Description:       r.String("channelDescription"),
+		ConfigChannelType: typeDetails,
+	}}
+	s.configChannels[label] = c
+	return c.details, nil
+}
+
+// withConfigChannel returns a handler calling fn with the configuration
+// channel named by the label or channelLabel parameter, holding the lock.
+func (s *Server) withConfigChannel(fn func(c *configChannel, r *Request) (interface{}, error)) Handler {
+	return func(r *Request) (interface{}, error) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		label := r.String("label")
+		if label == "" {
+			label = r.String("channelLabel")
+		}
+		c, ok := s.configChannels[label]
+		if !ok {
+			return nil, NotFound("configuration channel: " + label)
+		}
+		return fn(c, r)
+	}
+}
+
+func updateConfigChannel(c *configChannel, r *Request) (interface{}, error) {
+	c.details.Name = r.String("channelName")
+	c.details.Description = r.String("description")
+	return c.details, nil
+}
+
+func (s *Server) deleteConfigChannels(r *Request) (interface{}, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	labels := stringList(r, "labels")
+	for _, label := range labels {
+		if _, ok := s.configChannels[label]; !ok {
+			return nil, NotFound("configuration channel: " + label)
+		}
+	}
+	for _, label := range labels {
+		delete(s.configChannels, label)
+	}
+	return 1, nil
+}
+
+func (s *Server) createChannel(r *Request) (interface{}, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	label := r.String("label")
+	if _, ok := s.channels[label]; ok {
+		return nil, Faultf("Channel %s already exists", label)
+	}
+	parent := r.String("parentLabel")
+	if _, ok := s.channels[parent]; parent != "" && !ok {
+		return nil, NotFound("channel: " + parent)
+	}
+	archLabel := r.String("archLabel")
+	gpgKey, _ := r.Body["gpgKey"].(map[string]interface{})
+	gpgString := func(name string) string {
+		value, _ := gpgKey[name].(string)
+		return value
+	}
+	s.channels[label] = &channel{details: uyuni.Channel{
+		ID:                 s.id(),
+		Name:               r.String("name"),
+		Label:              label,
+		ArchName:           strings.TrimPrefix(archLabel, "channel-"),
+		ArchLabel:          archLabel,
+		Summary:            r.String("summary"),
+		ChecksumLabel:      r.String("checksumType"),
+		LastModified:       now(),
+		GPGKeyURL:          gpgString("url"),
+		GPGKeyID:           gpgString("id"),
+		GPGKeyFP:           gpgString("fingerprint"),
+		GPGCheck:           flag(r, "gpgCheck"),
+		ParentChannelLabel: parent,
+	}}
+	return 1, nil
+}
+
+// withChannel returns a handler calling fn with the software channel named
+// by the channelLabel parameter, holding the lock.
+func (s *Server) withChannel(fn func(c *channel, r *Request) (interface{}, error)) Handler {
+	return func(r *Request) (interface{}, error) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		label := r.String("channelLabel")
+		c, ok := s.channels[label]
+		if !ok {
+			return nil, NotFound("channel: " + label)
+		}
+		return fn(c, r)
+	}
+}
+
+func setChannelDetails(c *channel, r *Request) (interface{}, error) {
+	details, _ := r.Body["details"].(map[string]interface{})
+	for key, value := range details {
+		text, _ := value.(string)
+		switch key {
+		case "name":
+			c.details.Name = text
+		case "summary":
+			c.details.Summary = text
+		case "description":
+			c.details.Description = text
+		case "checksum_label":
+			c.details.ChecksumLabel = text
+		case "gpg_key_url":
+			c.details.GPGKeyURL = text
+		case "gpg_key_id":
+			c.details.GPGKeyID = text
+		case "gpg_key_fp":
+			c.details.GPGKeyFP = text
+		case "gpg_check":
+			c.details.GPGCheck, _ = value.(bool)
+		default:
+			return nil, Faultf("Invalid detail %s", key)
+		}
+	}
+	c.details.LastModified = now()
+	return 1, nil
+}
+
+func (s *Server) deleteChannel(r *Request) (interface{}, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	label := r.String("channelLabel")
+	if _, ok := s.channels[label]; !ok {
+		return nil, NotFound("channel: " + label)
+	}
+	for _, c := range s.channels {
+		if c.details.ParentChannelLabel == label {
+			return nil, Faultf("Channel %s has child channels", label)
+		}
+	}
+	delete(s.channels, label)
+	return 1, nil
+}
+
+func (s *Server) listChannels(*Request) (interface{}, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	channels := []uyuni.OrgChannel{}
+	for _, c := range s.channels {
+		channels = append(channels, uyuni.OrgChannel{
+			ID:           c.details.ID,
+			Label:        c.details.Label,
+			Name:         c.details.Name,
+			ProviderName: orgName,
+			ArchName:     c.details.ArchName,
+		})
+	}
+	sort.Slice(channels, func(i, j int) bool { return channels[i].Label < channels[j].Label })
+	return channels, nil
+}
@@ -0,0 +1,236 @@
+// Package uyunimock contains a fake Uyuni API server for tests. It keeps
+// users, system groups, configuration channels and software channels in
+// memory, so that resources managing them can be exercised end to end,
+// including by Terraform acceptance tests. Any other call can be answered by
+// registering a handler for it.
+package uyunimock
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// APIRoot is the path the API is served below, as on a real server.
+const APIRoot = "/rhn/manager/api"
+
+// sessionCookieName is the cookie holding the API session.
+const sessionCookieName = "pxt-session-cookie"
+
+// Request is an API call as passed to handlers.
+type Request struct {
+	// Method is the HTTP method, GET or POST.
+	Method string
+	// Call is the call relative to the API root, e.g. user/getDetails.
+	Call string
+	// Query holds the query parameters.
+	Query url.Values
+	// Body is the decoded JSON body of POST calls, empty for GET calls.
+	Body map[string]interface{}
+}
+
+// String returns the body parameter name, falling back to the query
+// parameter of the same name.
+func (r *Request) String(name string) string {
+	if value, ok := r.Body[name].(string); ok {
+		return value
+	}
+	return r.Query.Get(name)
+}
+
+// Handler answers an API call. The result is encoded as the result of the
+// response, an error as a fault.
+type Handler func(r *Request) (interface{}, error)
+
+// Fault is an error the API reports with success false. NotFound returns the
+// fault of missing objects.
+type Fault struct {
+	Message string
+}
+
+func (f *Fault) Error() string {
+	return f.Message
+}
+
+// Faultf returns a fault with the formatted message.
+func Faultf(format string, args ...interface{}) *Fault {
+	return &Fault{Message: fmt.Sprintf(format, args...)}
+}
+
+// NotFound returns the fault the API reports for the missing object.
+func NotFound(object string) *Fault {
+	return Faultf("No such %s", object)
+}
+
+// Server is a fake Uyuni API server listening on a local TLS port.
+type Server struct {
+	server   *httptest.Server
+	username string
+	password string
+	// Version is returned by api/systemVersion.
+	Version string
+
+	mu       sync.Mutex
+	sessions map[string]bool
+	handlers map[string]Handler
+	calls    []string
+	nextID   int
+
+	users          map[string]*user
+	groups         map[string]*group
+	configChannels map[string]*configChannel
+	channels       map[string]*channel
+}
+
+// New starts a server accepting the credentials. It is closed with Close.
+func New(username, password string) *Server {
+	s := &Server{
+		username:       username,
+		password:       password,
+		Version:        "2025.02",
+		sessions:       map[string]bool{},
+		handlers:       map[string]Handler{},
+		nextID:         1000,
+		users:          map[string]*user{},
+		groups:         map[string]*group{},
+		configChannels: map[string]*configChannel{},
+		channels:       map[string]*channel{},
+	}
+	s.registerNamespaces()
+	s.server = httptest.NewTLSServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// Host returns the host and port of the server, as passed to the provider.
+// The server uses a self-signed certificate.
+func (s *Server) Host() string {
+	return strings.TrimPrefix(s.server.URL, "https://")
+}
+
+// URL returns the URL of the API root.
+func (s *Server) URL() string {
+	return s.server.URL + APIRoot
+}
+
+// Client returns an HTTP client trusting the certificate of the server.
+func (s *Server) Client() *http.Client {
+	return s.server.Client()
+}
+
+// Close shuts the server down.
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// Handle registers the handler for calls of method to call, e.g. "GET" and
+// "user/getDetails", replacing the built-in one if there is one.
+func (s *Server) Handle(method, call string, handler Handler) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.handlers[method+" "+call] = handler
+}
+
+// Calls returns the calls the server answered so far as method and call,
+// e.g. "POST user/create".
+func (s *Server) Calls() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.calls...)
+}
+
+// id returns a new object ID. The caller holds mu.
+func (s *Server) id() int {
+	s.nextID++
+	return s.nextID
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, req *http.Request) {
+	call, ok := strings.CutPrefix(req.URL.Path, APIRoot+"/")
+	if !ok {
+		http.NotFound(w, req)
+		return
+	}
+
+	r := &Request{Method: req.Method, Call: call, Query: req.URL.Query(), Body: map[string]interface{}{}}
+	if req.Method == http.MethodPost {
+		data, err := io.ReadAll(req.Body)
+		if err == nil && len(data) > 0 {
+			err = json.Unmarshal(data, &r.Body)
+		}
+		if err != nil {
+			writeResponse(w, http.StatusBadRequest, nil, Faultf("Invalid request body: %s", err))
+			return
+		}
+	}
+
+	if call == "auth/login" {
+		s.login(w, r)
+		return
+	}
+	cookie, err := req.Cookie(sessionCookieName)
+	s.mu.Lock()
+	valid := err == nil && s.sessions[cookie.Value]
+	s.mu.Unlock()
+	if !valid {
+		writeResponse(w, http.StatusUnauthorized, nil, Faultf("Could not find session"))
+		return
+	}
+
+	s.mu.Lock()
+	s.calls = append(s.calls, r.Method+" "+call)
+	handler, ok := s.handlers[r.Method+" "+call]
+	s.mu.Unlock()
+	if !ok {
+		writeResponse(w, http.StatusNotImplemented, nil, Faultf("The mock API does not implement %s %s", r.Method, call))
+		return
+	}
+
+	result, err := handler(r)
+	if err != nil {
+		// The API reports every fault with status 500.
+		writeResponse(w, http.StatusInternalServerError, nil, err)
+		return
+	}
+	writeResponse(w, http.StatusOK, result, nil)
+}
+
+// login opens a session for matching credentials.
+func (s *Server) login(w http.ResponseWriter, r *Request) {
+	if r.Body["login"] != s.username || r.Body["password"] != s.password {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnauthorized)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"success": false, "messages": "Either the password or username is incorrect."})
+		return
+	}
+
+	token := make([]byte, 16)
+	_, _ = rand.Read(token)
+	session := hex.EncodeToString(token)
+	s.mu.Lock()
+	s.sessions[session] = true
+	s.mu.Unlock()
+
+	http.SetCookie(w, &http.Cookie{Name: sessionCookieName, Value: session, Path: "/", MaxAge: 3600, Secure: true, HttpOnly: true})
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{"success": true, "messages": ""})
+}
+
+// writeResponse writes the result, or the error as a fault.
+func writeResponse(w http.ResponseWriter, status int, result interface{}, err error) {
+	response := map[string]interface{}{"success": err == nil}
+	if err != nil {
+		response["message"] = err.Error()
+	} else {
+		response["result"] = result
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(response)
+}
@@ -0,0 +1,112 @@
+package uyunimock
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/cookiejar"
+	"strings"
+	"testing"
+)
+
+// testCall sends a call with the cookies of client and returns the status
+// and the decoded response.
+func testCall(t *testing.T, s *Server, client *http.Client, method, call, body string) (int, map[string]interface{}) {
+	req, err := http.NewRequest(method, s.URL()+"/"+call, strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	var response map[string]interface{}
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		t.Fatal(err)
+	}
+	return res.StatusCode, response
+}
+
+// testSession returns a client logged in to s.
+func testSession(t *testing.T, s *Server) *http.Client {
+	client := s.Client()
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.Jar = jar
+	if status, _ := testCall(t, s, client, http.MethodPost, "auth/login", `{"login": "admin", "password": "secret"}`); status != http.StatusOK {
+		t.Fatalf("could not log in: %d", status)
+	}
+	return client
+}
+
+func TestServerRequiresSession(t *testing.T) {
+	s := New("admin", "secret")
+	defer s.Close()
+
+	status, _ := testCall(t, s, s.Client(), http.MethodPost, "auth/login", `{"login": "admin", "password": "wrong"}`)
+	if status != http.StatusUnauthorized {
+		t.Errorf("expected status 401 for wrong credentials, got %d", status)
+	}
+	status, response := testCall(t, s, s.Client(), http.MethodGet, "user/listUsers", "")
+	if status != http.StatusUnauthorized || response["message"] != "Could not find session" {
+		t.Errorf("expected status 401 without session, got %d: %v", status, response)
+	}
+
+	client := testSession(t, s)
+	if status, response := testCall(t, s, client, http.MethodGet, "api/systemVersion", ""); status != http.StatusOK || response["result"] != s.Version {
+		t.Errorf("expected version %s, got %d: %v", s.Version, status, response)
+	}
+}
+
+func TestServerKeepsUsers(t *testing.T) {
+	s := New("admin", "secret")
+	defer s.Close()
+	client := testSession(t, s)
+
+	create := `{"login": "jdoe", "password": "pw", "firstName": "Jane", "lastName": "Doe", "email": "jdoe@example.com"}`
+	if status, response := testCall(t, s, client, http.MethodPost, "user/create", create); status != http.StatusOK {
+		t.Fatalf("could not create user: %v", response)
+	}
+	status, response := testCall(t, s, client, http.MethodPost, "user/create", create)
+	if status != http.StatusInternalServerError || !strings.Contains(response["message"].(string), "already in use") {
+		t.Errorf("expected a fault creating the user twice, got %d: %v", status, response)
+	}
+
+	_, response = testCall(t, s, client, http.MethodGet, "user/getDetails?login=jdoe", "")
+	details := response["result"].(map[string]interface{})
+	if details["email"] != "jdoe@example.com" || details["enabled"] != true {
+		t.Errorf("unexpected details %v", details)
+	}
+
+	testCall(t, s, client, http.MethodPost, "user/delete?login=jdoe", "{}")
+	status, response = testCall(t, s, client, http.MethodGet, "user/getDetails?login=jdoe", "")
+	if status != http.StatusInternalServerError || !strings.HasPrefix(response["message"].(string), "No such user") {
+		t.Errorf("expected a not found fault, got %d: %v", status, response)
+	}
+}
+
+func TestServerAnswersRegisteredCalls(t *testing.T) {
+	s := New("admin", "secret")
+	defer s.Close()
+	client := testSession(t, s)
+
+	if status, _ := testCall(t, s, client, http.MethodGet, "system/listSystems", ""); status != http.StatusNotImplemented {
+		t.Errorf("expected status 501 for an unknown call, got %d", status)
+	}
+
+	s.Handle(http.MethodGet, "system/listSystems", func(r *Request) (interface{}, error) {
+		return []map[string]interface{}{{"id": 1000010000, "name": r.Query.Get("name")}}, nil
+	})
+	_, response := testCall(t, s, client, http.MethodGet, "system/listSystems?name=web", "")
+	systems := response["result"].([]interface{})
+	if len(systems) != 1 || systems[0].(map[string]interface{})["name"] != "web" {
+		t.Errorf("unexpected result %v", response["result"])
+	}
+
+	calls := s.Calls()
+	if len(calls) != 2 || calls[1] != "GET system/listSystems" {
+		t.Errorf("unexpected calls %v", calls)
+	}
+}
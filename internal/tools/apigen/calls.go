@@ -0,0 +1,101 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// apiCall is a method as listed by api.getApiCallList. Parameters only carry
+// their types, the first one being the session key.
+type apiCall struct {
+	Name       string   `json:"name"`
+	Parameters []string `json:"parameters"`
+	Exceptions []string `json:"exceptions"`
+	Return     string   `json:"return"`
+}
+
+// callList maps namespaces to their methods, keyed by signature.
+type callList map[string]map[string]apiCall
+
+func readCallList(file string) (callList, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	// The file may hold the whole API response or only its result.
+	var response struct {
+		Result callList `json:"result"`
+	}
+	if err := json.Unmarshal(data, &response); err == nil && response.Result != nil {
+		return response.Result, nil
+	}
+	var calls callList
+	if err := json.Unmarshal(data, &calls); err != nil {
+		return nil, fmt.Errorf("%s: %w", file, err)
+	}
+	return calls, nil
+}
+
+// signatures lists the methods of a namespace with their parameter types.
+func (c callList) signatures(namespace string) ([]string, error) {
+	methods, ok := c[namespace]
+	if !ok {
+		return nil, fmt.Errorf("unknown namespace %s", namespace)
+	}
+	signatures := []string{}
+	for _, call := range methods {
+		signatures = append(signatures, fmt.Sprintf("%s(%s) %s", call.Name, strings.Join(call.Parameters, ", "), call.Return))
+	}
+	sort.Strings(signatures)
+	return signatures, nil
+}
+
+// check verifies that the API offers every call of the definition with
+// parameters of the expected types.
+func (c callList) check(def *definition) error {
+	operations := def.operations()
+	for _, name := range operationNames {
+		op := operations[name]
+		if op == nil {
+			continue
+		}
+		namespace, method := splitCall(op.Call)
+		want := []string{"string"}
+		for _, param := range op.Params {
+			want = append(want, def.paramType(param))
+		}
+
+		matched := false
+		offered := []string{}
+		for _, call := range c[namespace] {
+			if call.Name != method {
+				continue
+			}
+			if strings.Join(call.Parameters, ",") == strings.Join(want, ",") {
+				matched = true
+				break
+			}
+			offered = append(offered, "("+strings.Join(call.Parameters, ", ")+")")
+		}
+		if matched {
+			continue
+		}
+		if len(offered) == 0 {
+			return fmt.Errorf("the %s call %s does not exist", name, op.Call)
+		}
+		sort.Strings(offered)
+		return fmt.Errorf("the %s call %s takes (%s), the API offers %s",
+			name, op.Call, strings.Join(want, ", "), strings.Join(offered, ", "))
+	}
+	return nil
+}
+
+// splitCall splits a call like kickstart.profile.addScript into its namespace
+// and method.
+func splitCall(call string) (namespace, method string) {
+	i := strings.LastIndex(call, ".")
+	return call[:i], call[i+1:]
+}
@@ -0,0 +1,154 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// definition describes a resource in terms of the API calls managing it.
+type definition struct {
+	// Name is the resource type name without the provider prefix, e.g. activation_key.
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	// Key is the attribute identifying the object, it becomes the id.
+	Key        string      `json:"key"`
+	Attributes []attribute `json:"attributes"`
+	Create     *operation  `json:"create"`
+	Read       *operation  `json:"read"`
+	Update     *operation  `json:"update,omitempty"`
+	Delete     *operation  `json:"delete"`
+}
+
+// attribute is a schema attribute backed by a field of the API object.
+type attribute struct {
+	Name string `json:"name"`
+	// APIName is the name of the field in API calls and results, it defaults to Name.
+	APIName     string `json:"api_name,omitempty"`
+	Type        string `json:"type"`
+	Description string `json:"description,omitempty"`
+	Required    bool   `json:"required,omitempty"`
+	Computed    bool   `json:"computed,omitempty"`
+}
+
+// operation is an API call, e.g. activationkey.create, and the attributes
+// passed as its parameters. A parameter named differently than the API field
+// of the attribute is given as "parameter=attribute". The parameter "details"
+// passes all configurable attributes except the key as one struct, like the
+// setDetails calls expect.
+type operation struct {
+	Call   string   `json:"call"`
+	Params []string `json:"params"`
+}
+
+// detailsParam is the parameter passing the configurable attributes as a struct.
+const detailsParam = "details"
+
+// attributeTypes maps the attribute types to the type names used by the API.
+var attributeTypes = map[string]string{
+	"string": "string",
+	"int":    "int",
+	"bool":   "boolean",
+}
+
+func readDefinition(file string) (*definition, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	var def definition
+	if err := json.Unmarshal(data, &def); err != nil {
+		return nil, fmt.Errorf("%s: %w", file, err)
+	}
+	for i := range def.Attributes {
+		if def.Attributes[i].APIName == "" {
+			def.Attributes[i].APIName = def.Attributes[i].Name
+		}
+	}
+	if err := def.validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", file, err)
+	}
+	return &def, nil
+}
+
+func (d *definition) validate() error {
+	if d.Name == "" {
+		return fmt.Errorf("the resource has no name")
+	}
+	if d.attribute(d.Key) == nil {
+		return fmt.Errorf("the key %q is not an attribute", d.Key)
+	}
+	for _, a := range d.Attributes {
+		if _, ok := attributeTypes[a.Type]; !ok {
+			return fmt.Errorf("attribute %s has the unsupported type %q", a.Name, a.Type)
+		}
+		if a.Name == "id" || a.Name == "timeouts" {
+			return fmt.Errorf("attribute %s is reserved", a.Name)
+		}
+		if a.Required && a.Computed {
+			return fmt.Errorf("attribute %s cannot be both required and computed", a.Name)
+		}
+	}
+	operations := d.operations()
+	for _, name := range operationNames {
+		op := operations[name]
+		if op == nil {
+			if name == "update" {
+				continue
+			}
+			return fmt.Errorf("the %s operation is missing", name)
+		}
+		if !strings.Contains(op.Call, ".") {
+			return fmt.Errorf("the %s call %q is not of the form namespace.method", name, op.Call)
+		}
+		for _, param := range op.Params {
+			if param == detailsParam && name != "read" {
+				continue
+			}
+			if _, attr := splitParam(param); d.attribute(attr) == nil {
+				return fmt.Errorf("the %s call passes %q, which is not an attribute", name, attr)
+			}
+		}
+	}
+	return nil
+}
+
+// operationNames lists the operations in the order they are checked.
+var operationNames = []string{"create", "read", "update", "delete"}
+
+func (d *definition) operations() map[string]*operation {
+	return map[string]*operation{
+		"create": d.Create,
+		"read":   d.Read,
+		"update": d.Update,
+		"delete": d.Delete,
+	}
+}
+
+func (d *definition) attribute(name string) *attribute {
+	for i := range d.Attributes {
+		if d.Attributes[i].Name == name {
+			return &d.Attributes[i]
+		}
+	}
+	return nil
+}
+
+// splitParam returns the attribute passed by an operation parameter and the
+// name of the parameter, if it differs from the API name of the attribute.
+func splitParam(param string) (name, attr string) {
+	if name, attr, ok := strings.Cut(param, "="); ok {
+		return name, attr
+	}
+	return "", param
+}
+
+// paramType returns the API type name of an operation parameter.
+func (d *definition) paramType(param string) string {
+	if param == detailsParam {
+		return "struct"
+	}
+	_, attr := splitParam(param)
+	return attributeTypes[d.attribute(attr).Type]
+}
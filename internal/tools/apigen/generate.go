@@ -0,0 +1,550 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"slices"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+// initialisms are written in upper case in Go identifiers.
+var initialisms = map[string]bool{
+	"api": true, "cpu": true, "dns": true, "fqdn": true, "gpg": true, "id": true, "ip": true,
+	"ram": true, "ssh": true, "ssl": true, "url": true, "uuid": true,
+}
+
+// goName converts a snake case attribute name to an exported Go identifier.
+func goName(name string) string {
+	var b strings.Builder
+	for _, word := range strings.Split(name, "_") {
+		if initialisms[word] {
+			b.WriteString(strings.ToUpper(word))
+		} else if word != "" {
+			b.WriteString(strings.ToUpper(word[:1]) + word[1:])
+		}
+	}
+	return b.String()
+}
+
+// typeInfo holds the framework names of an attribute type.
+type typeInfo struct {
+	GoType, Framework, Schema, Value, PlanModifier, PlanModifierPackage string
+}
+
+var typeInfos = map[string]typeInfo{
+	"string": {"string", "types.String", "schema.StringAttribute", "ValueString", "planmodifier.String", "stringplanmodifier"},
+	"int":    {"int64", "types.Int64", "schema.Int64Attribute", "ValueInt64", "planmodifier.Int64", "int64planmodifier"},
+	"bool":   {"bool", "types.Bool", "schema.BoolAttribute", "ValueBool", "planmodifier.Bool", "boolplanmodifier"},
+}
+
+// attributeData is an attribute prepared for the template.
+type attributeData struct {
+	attribute
+	typeInfo
+	Field           string
+	Key             bool
+	Optional        bool
+	RequiresReplace bool
+}
+
+// Format renders the value of the attribute in model as a query parameter.
+func (a attributeData) Format(model string) string {
+	value := fmt.Sprintf("%s.%s.%s()", model, a.Field, a.Value)
+	switch a.Type {
+	case "int":
+		return "strconv.FormatInt(" + value + ", 10)"
+	case "bool":
+		return "strconv.FormatBool(" + value + ")"
+	}
+	return value
+}
+
+// operationData is an API call prepared for the template.
+type operationData struct {
+	Call string
+	Path string
+	// Params maps API parameter names to Go expressions, in call order.
+	Params [][2]string
+}
+
+type templateData struct {
+	Source string
+	Name   string
+	// Label names the resource in messages.
+	Label       string
+	Description string
+	Type        string
+	Constructor string
+	Key         attributeData
+	Attributes  []attributeData
+	Imports     []string
+	Create      operationData
+	Read        operationData
+	Update      *operationData
+	Delete      operationData
+	Details     bool
+}
+
+// generate renders the resource scaffolding of a definition as Go source.
+func generate(def *definition, file string) ([]byte, error) {
+	data := templateData{
+		Source:      file,
+		Name:        def.Name,
+		Label:       strings.ReplaceAll(def.Name, "_", " "),
+		Description: def.Description,
+		Type:        strings.ToLower(goName(def.Name)[:1]) + goName(def.Name)[1:],
+		Constructor: "New" + goName(def.Name) + "Resource",
+	}
+
+	updatable := map[string]bool{}
+	if def.Update != nil {
+		for _, param := range def.Update.Params {
+			_, attr := splitParam(param)
+			updatable[attr] = true
+			if param == detailsParam {
+				for _, a := range def.Attributes {
+					updatable[a.Name] = a.Name != def.Key && !a.Computed
+				}
+			}
+		}
+	}
+
+	imports := map[string]bool{
+		// The id attribute uses it.
+		"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier": true,
+	}
+	for _, a := range def.Attributes {
+		info := typeInfos[a.Type]
+		ad := attributeData{
+			attribute: a,
+			typeInfo:  info,
+			Field:     goName(a.Name),
+			Key:       a.Name == def.Key,
+			Optional:  !a.Required && !a.Computed,
+		}
+		ad.RequiresReplace = !a.Computed && (ad.Key || !updatable[a.Name])
+		if ad.RequiresReplace || !a.Required {
+			imports["github.com/hashicorp/terraform-plugin-framework/resource/schema/"+info.PlanModifierPackage] = true
+		}
+		if a.Type != "string" && (ad.Key || readsParam(def.Read, a.Name)) {
+			imports["strconv"] = true
+		}
+		if ad.Key {
+			data.Key = ad
+		}
+		data.Attributes = append(data.Attributes, ad)
+	}
+
+	// Query parameters of the read call are formatted as strings.
+	operation := func(op *operation, model string, query bool) operationData {
+		od := operationData{Call: op.Call, Path: strings.ReplaceAll(op.Call, ".", "/")}
+		for _, param := range op.Params {
+			if param == detailsParam {
+				data.Details = true
+				od.Params = append(od.Params, [2]string{detailsParam, model + ".details()"})
+				continue
+			}
+			name, attr := splitParam(param)
+			a := data.attribute(attr)
+			if name == "" {
+				name = a.APIName
+			}
+			value := fmt.Sprintf("%s.%s.%s()", model, a.Field, a.Value)
+			if query {
+				value = a.Format(model)
+			}
+			od.Params = append(od.Params, [2]string{name, value})
+		}
+		return od
+	}
+	data.Create = operation(def.Create, "plan", false)
+	data.Read = operation(def.Read, "model", true)
+	data.Delete = operation(def.Delete, "state", false)
+	if def.Update != nil {
+		update := operation(def.Update, "plan", false)
+		data.Update = &update
+	}
+
+	for path := range imports {
+		data.Imports = append(data.Imports, path)
+	}
+	sort.Strings(data.Imports)
+
+	var buf bytes.Buffer
+	if err := resourceTemplate.Execute(&buf, data); err != nil {
+		return nil, err
+	}
+	source, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("generated invalid code: %w\n%s", err, buf.String())
+	}
+	return source, nil
+}
+
+// readsParam tells whether the read call passes attr as query parameter.
+func readsParam(read *operation, attr string) bool {
+	return slices.ContainsFunc(read.Params, func(param string) bool {
+		_, a := splitParam(param)
+		return a == attr
+	})
+}
+
+func (d templateData) attribute(name string) attributeData {
+	for _, a := range d.Attributes {
+		if a.Name == name {
+			return a
+		}
+	}
+	panic("unknown attribute " + name)
+}
+
+var resourceTemplate = template.Must(template.New("resource").Parse(`// Code generated by apigen from {{.Source}}. Complete the TODOs, then register
+// the resource in provider.go, add an example and regenerate the docs.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+{{- range .Imports}}{{if eq . "strconv"}}
+	"strconv"{{end}}{{end}}
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+{{- range .Imports}}{{if ne . "strconv"}}
+	"{{.}}"{{end}}{{end}}
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                 = &{{.Type}}Resource{}
+	_ resource.ResourceWithConfigure    = &{{.Type}}Resource{}
+	_ resource.ResourceWithImportState  = &{{.Type}}Resource{}
+	_ resource.ResourceWithUpgradeState = &{{.Type}}Resource{}
+)
+
+// {{.Constructor}} is a helper function to simplify the provider implementation.
+func {{.Constructor}}() resource.Resource {
+	return &{{.Type}}Resource{}
+}
+
+// {{.Type}}Resource is the resource implementation.
+type {{.Type}}Resource struct {
+	client *uyuniClient
+}
+
+// {{.Type}}ResourceModel maps the resource schema data.
+type {{.Type}}ResourceModel struct {
+	ID types.String ` + "`tfsdk:\"id\"`" + `
+{{- range .Attributes}}
+	{{.Field}} {{.Framework}} ` + "`tfsdk:\"{{.Name}}\"`" + `
+{{- end}}
+	Timeouts timeouts.Value ` + "`tfsdk:\"timeouts\"`" + `
+}
+
+// {{.Type}}API is the object returned by {{.Read.Call}}.
+// TODO: move it to internal/uyuni with a recorded response as fixture.
+type {{.Type}}API struct {
+{{- range .Attributes}}
+	{{.Field}} {{.GoType}} ` + "`json:\"{{.APIName}}\"`" + `
+{{- end}}
+}
+
+// {{.Type}}StateMigrations upgrade states of prior schema versions.
+var {{.Type}}StateMigrations = stateMigrations{}
+{{if .Details}}
+// details returns the configured attributes passed as details struct.
+func (m {{.Type}}ResourceModel) details() map[string]interface{} {
+	details := map[string]interface{}{}
+{{- range .Attributes}}{{if and (not .Key) (not .Computed)}}
+	if !m.{{.Field}}.IsNull() && !m.{{.Field}}.IsUnknown() {
+		details["{{.APIName}}"] = m.{{.Field}}.{{.Value}}()
+	}
+{{- end}}{{end}}
+	return details
+}
+{{end}}
+// Metadata returns the resource type name.
+func (r *{{.Type}}Resource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_{{.Name}}"
+}
+
+// Schema defines the schema for the resource.
+func (r *{{.Type}}Resource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Version:     {{.Type}}StateMigrations.version(),
+		Description: {{printf "%q" .Description}},
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+{{- range .Attributes}}
+			"{{.Name}}": {{.Schema}}{
+{{- if .Description}}
+				Description: {{printf "%q" .Description}},
+{{- end}}
+{{- if .Required}}
+				Required: true,
+{{- else if .Optional}}
+				Optional: true,
+				Computed: true,
+{{- else}}
+				Computed: true,
+{{- end}}
+{{- if .RequiresReplace}}
+				PlanModifiers: []{{.PlanModifier}}{
+					{{.PlanModifierPackage}}.RequiresReplace(),
+				},
+{{- else if not .Required}}
+				PlanModifiers: []{{.PlanModifier}}{
+					{{.PlanModifierPackage}}.UseStateForUnknown(),
+				},
+{{- end}}
+			},
+{{- end}}
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+{{- if .Update}}
+				Update: true,
+{{- end}}
+				Delete: true,
+			}),
+		},
+	}
+}
+
+// UpgradeState upgrades states of prior schema versions.
+func (r *{{.Type}}Resource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return {{.Type}}StateMigrations.upgraders()
+}
+
+// read refreshes model with the object on the server.
+func (r *{{.Type}}Resource) read(ctx context.Context, model *{{.Type}}ResourceModel) error {
+	query := url.Values{}
+{{- range $param := .Read.Params}}
+	query.Set("{{index $param 0}}", {{index $param 1}})
+{{- end}}
+	object, err := apiGet[{{.Type}}API](ctx, r.client, "{{.Read.Path}}?"+query.Encode())
+	if err != nil {
+		return err
+	}
+{{range .Attributes}}
+	model.{{.Field}} = {{.Framework}}Value(object.Result.{{.Field}})
+{{- end}}
+	model.ID = types.StringValue({{.Key.Format "model"}})
+	return nil
+}
+
+// Create a new resource.
+func (r *{{.Type}}Resource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan {{.Type}}ResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	tflog.Info(ctx, "About to create {{.Label}}")
+
+	{{if .Key.Computed}}created{{else}}_{{end}}, err := apiPost[{{if .Key.Computed}}{{.Key.GoType}}{{else}}interface{}{{end}}](ctx, r.client, "{{.Create.Path}}", map[string]interface{}{
+{{- range .Create.Params}}
+		"{{index . 0}}": {{index . 1}},
+{{- end}}
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating {{.Label}}",
+			"Could not create {{.Label}}: "+err.Error(),
+		)
+		return
+	}
+{{- if .Key.Computed}}
+	plan.{{.Key.Field}} = {{.Key.Framework}}Value(created.Result)
+{{- end}}
+
+	if err := r.read(ctx, &plan); err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating {{.Label}}",
+			"Could not read {{.Label}} after creating it: "+err.Error(),
+		)
+		return
+	}
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read resource information.
+func (r *{{.Type}}Resource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Get current state
+	var state {{.Type}}ResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.read(ctx, &state); err != nil {
+		if handleNotFound(ctx, resp, err, "The {{.Label}} "+{{.Key.Format "state"}}) {
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Reading Uyuni {{.Label}}",
+			"Could not read {{.Label}} "+{{.Key.Format "state"}}+": "+err.Error(),
+		)
+		return
+	}
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *{{.Type}}Resource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+{{- if .Update}}
+	// Retrieve values from plan
+	var plan {{.Type}}ResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	_, err := apiPost[interface{}](ctx, r.client, "{{.Update.Path}}", map[string]interface{}{
+{{- range .Update.Params}}
+		"{{index . 0}}": {{index . 1}},
+{{- end}}
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating {{.Label}}",
+			"Could not update {{.Label}}: "+err.Error(),
+		)
+		return
+	}
+
+	if err := r.read(ctx, &plan); err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating {{.Label}}",
+			"Could not read {{.Label}} after updating it: "+err.Error(),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+{{- else}}
+	// Every attribute requires replacement, so there is nothing to update in place.
+{{- end}}
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *{{.Type}}Resource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Retrieve values from state
+	var state {{.Type}}ResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	_, err := apiPost[interface{}](ctx, r.client, "{{.Delete.Path}}", map[string]interface{}{
+{{- range .Delete.Params}}
+		"{{index . 0}}": {{index . 1}},
+{{- end}}
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Deleting Uyuni {{.Label}}",
+			"Could not delete {{.Label}}: "+err.Error(),
+		)
+		return
+	}
+}
+
+// ImportState imports a {{.Label}} by its {{.Key.Name}}.
+func (r *{{.Type}}Resource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+{{- if eq .Key.Type "string"}}
+	resource.ImportStatePassthroughID(ctx, path.Root("{{.Key.Name}}"), req, resp)
+{{- else}}
+	key, err := parseImportInt64("{{.Key.Name}}", req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Import ID", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("{{.Key.Name}}"), key)...)
+{{- end}}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *{{.Type}}Resource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*uyuniClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *uyuniClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+`))
@@ -0,0 +1,159 @@
+package main
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "update the golden files")
+
+func TestGenerate(t *testing.T) {
+	def, err := readDefinition(filepath.Join("testdata", "system_group.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	calls, err := readCallList(filepath.Join("testdata", "getApiCallList.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := calls.check(def); err != nil {
+		t.Fatal(err)
+	}
+
+	source, err := generate(def, "system_group.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	golden := filepath.Join("testdata", "system_group_resource.go.golden")
+	if *update {
+		if err := os.WriteFile(golden, source, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(source) != string(want) {
+		t.Errorf("generated code differs from %s, run the test with -update and review the diff", golden)
+	}
+}
+
+func TestGenerateIntegerKey(t *testing.T) {
+	def := &definition{
+		Name: "kickstart_profile_script",
+		Key:  "script_id",
+		Attributes: []attribute{
+			{Name: "script_id", APIName: "id", Type: "int", Computed: true},
+			{Name: "contents", APIName: "contents", Type: "string", Required: true},
+			{Name: "chroot", APIName: "chroot", Type: "bool"},
+		},
+		Create: &operation{Call: "kickstart.profile.addScript", Params: []string{"contents", "chroot"}},
+		Read:   &operation{Call: "kickstart.profile.getScript", Params: []string{"scriptId=script_id"}},
+		Delete: &operation{Call: "kickstart.profile.removeScript", Params: []string{"scriptId=script_id"}},
+	}
+	if err := def.validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	source, err := generate(def, "kickstart_profile_script.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		`"kickstart/profile/addScript"`,
+		`created, err := apiPost[int64]`,
+		`plan.ScriptID = types.Int64Value(created.Result)`,
+		`query.Set("scriptId", strconv.FormatInt(model.ScriptID.ValueInt64(), 10))`,
+		`parseImportInt64("script_id", req.ID)`,
+		`boolplanmodifier.RequiresReplace()`,
+		`Every attribute requires replacement`,
+	} {
+		if !strings.Contains(string(source), want) {
+			t.Errorf("generated code lacks %s", want)
+		}
+	}
+}
+
+func TestCheckCalls(t *testing.T) {
+	calls, err := readCallList(filepath.Join("testdata", "getApiCallList.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, tc := range map[string]struct {
+		op   operation
+		want string
+	}{
+		"unknown method": {
+			op:   operation{Call: "systemgroup.remove", Params: []string{"systemGroupName=name"}},
+			want: "the delete call systemgroup.remove does not exist",
+		},
+		"parameter types": {
+			op:   operation{Call: "systemgroup.delete", Params: []string{"group_id"}},
+			want: "the delete call systemgroup.delete takes (string, int), the API offers (string, string)",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			def, err := readDefinition(filepath.Join("testdata", "system_group.json"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			def.Delete = &tc.op
+			if err := calls.check(def); err == nil || err.Error() != tc.want {
+				t.Errorf("got %v, want %s", err, tc.want)
+			}
+		})
+	}
+}
+
+func TestDefinitionValidation(t *testing.T) {
+	for name, tc := range map[string]struct {
+		mutate func(*definition)
+		want   string
+	}{
+		"unknown key": {
+			mutate: func(d *definition) { d.Key = "label" },
+			want:   `the key "label" is not an attribute`,
+		},
+		"unsupported type": {
+			mutate: func(d *definition) { d.Attributes[1].Type = "array" },
+			want:   `attribute description has the unsupported type "array"`,
+		},
+		"missing operation": {
+			mutate: func(d *definition) { d.Delete = nil },
+			want:   "the delete operation is missing",
+		},
+		"unknown parameter": {
+			mutate: func(d *definition) { d.Create.Params = append(d.Create.Params, "org_id") },
+			want:   `the create call passes "org_id", which is not an attribute`,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			def, err := readDefinition(filepath.Join("testdata", "system_group.json"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			tc.mutate(def)
+			if err := def.validate(); err == nil || err.Error() != tc.want {
+				t.Errorf("got %v, want %s", err, tc.want)
+			}
+		})
+	}
+}
+
+func TestGoName(t *testing.T) {
+	for name, want := range map[string]string{
+		"system_group":    "SystemGroup",
+		"peripheral_fqdn": "PeripheralFQDN",
+		"base_url":        "BaseURL",
+		"id":              "ID",
+	} {
+		if got := goName(name); got != want {
+			t.Errorf("goName(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
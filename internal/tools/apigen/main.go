@@ -0,0 +1,93 @@
+// Command apigen generates the scaffolding of a resource from a definition
+// mapping its attributes to Uyuni API calls: the schema, the model, CRUD
+// functions, import and state upgrades in the style of the provider.
+//
+// The calls are checked against the API documentation as served by the
+// server itself, which lists every namespace with the parameter types of
+// its methods:
+//
+//	curl -b cookies https://$UYUNI_HOST/rhn/manager/api/api/getApiCallList > calls.json
+//	go run ./internal/tools/apigen -calls calls.json -namespace activationkey
+//	go run ./internal/tools/apigen -calls calls.json -out internal/provider definition.json
+//
+// The first form lists the methods of a namespace to help writing the
+// definition, the second checks the definition and writes
+// <name>_resource.go to the output directory. Without -calls the definition
+// is not checked against the API.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		fmt.Fprintln(os.Stderr, "apigen:", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string) error {
+	flags := flag.NewFlagSet("apigen", flag.ContinueOnError)
+	callsFile := flags.String("calls", "", "`file` holding the result of api.getApiCallList")
+	namespace := flags.String("namespace", "", "list the methods of `namespace` and exit")
+	out := flags.String("out", ".", "`directory` to write the generated resources to")
+	force := flags.Bool("force", false, "overwrite existing files")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	var calls callList
+	if *callsFile != "" {
+		var err error
+		if calls, err = readCallList(*callsFile); err != nil {
+			return err
+		}
+	}
+
+	if *namespace != "" {
+		if calls == nil {
+			return fmt.Errorf("-namespace needs the call list given by -calls")
+		}
+		signatures, err := calls.signatures(*namespace)
+		if err != nil {
+			return err
+		}
+		for _, signature := range signatures {
+			fmt.Println(signature)
+		}
+		return nil
+	}
+
+	if flags.NArg() == 0 {
+		return fmt.Errorf("no definitions given")
+	}
+	for _, file := range flags.Args() {
+		def, err := readDefinition(file)
+		if err != nil {
+			return err
+		}
+		if calls != nil {
+			if err := calls.check(def); err != nil {
+				return fmt.Errorf("%s: %w", file, err)
+			}
+		}
+
+		source, err := generate(def, filepath.Base(file))
+		if err != nil {
+			return fmt.Errorf("%s: %w", file, err)
+		}
+		target := filepath.Join(*out, def.Name+"_resource.go")
+		if _, err := os.Stat(target); err == nil && !*force {
+			return fmt.Errorf("%s already exists, use -force to overwrite it", target)
+		}
+		if err := os.WriteFile(target, source, 0o644); err != nil {
+			return err
+		}
+		fmt.Println("wrote", target)
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package uyuni
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// dateLayouts are the formats dates are returned in. Depending on the server
+// version and the namespace, the API serializes them as ISO 8601, or in the
+// Java formats of the server locale, which only the US English ones are
+// known for.
+var dateLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05Z0700",
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02",
+	"Jan 2, 2006, 3:04:05 PM",
+	"Jan 2, 2006 3:04:05 PM",
+	"1/2/06, 3:04 PM",
+	"1/2/06 3:04 PM",
+	time.UnixDate,
+}
+
+// ParseDate parses a date returned by the API. Dates without a time zone are
+// in UTC.
+func ParseDate(s string) (time.Time, error) {
+	// Recent Java versions separate the time from AM/PM with a (narrow)
+	// no-break space.
+	normalized := strings.NewReplacer("\u202f", " ", "\u00a0", " ").Replace(strings.TrimSpace(s))
+	for _, layout := range dateLayouts {
+		if t, err := time.Parse(layout, normalized); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unsupported date format %q", s)
+}
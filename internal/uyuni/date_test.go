@@ -0,0 +1,38 @@
+package uyuni
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDate(t *testing.T) {
+	tests := map[string]string{
+		"2024-09-02T10:15:00Z":          "2024-09-02T10:15:00Z",
+		"2024-09-02T12:15:00+02:00":     "2024-09-02T12:15:00+02:00",
+		"2024-09-02T12:15:00+0200":      "2024-09-02T12:15:00+02:00",
+		"2024-09-02T10:15:00":           "2024-09-02T10:15:00Z",
+		"2024-09-02 10:15:00.123":       "2024-09-02T10:15:00.123Z",
+		"2024-08-20":                    "2024-08-20T00:00:00Z",
+		"Sep 2, 2024, 10:15:00 AM":      "2024-09-02T10:15:00Z",
+		"Sep 2, 2024, 10:15:00\u202fPM": "2024-09-02T22:15:00Z",
+		"Sep 2, 2024 10:15:00 AM":       "2024-09-02T10:15:00Z",
+		"9/2/24, 10:15 PM":              "2024-09-02T22:15:00Z",
+		"Mon Sep 02 10:15:00 UTC 2024":  "2024-09-02T10:15:00Z",
+	}
+	for input, want := range tests {
+		got, err := ParseDate(input)
+		if err != nil {
+			t.Errorf("%q: %v", input, err)
+			continue
+		}
+		if got.Format(time.RFC3339Nano) != want {
+			t.Errorf("%q: got %s, want %s", input, got.Format(time.RFC3339Nano), want)
+		}
+	}
+
+	for _, input := range []string{"", "yesterday", "02.09.2024 10:15"} {
+		if _, err := ParseDate(input); err == nil {
+			t.Errorf("%q: expected an error", input)
+		}
+	}
+}
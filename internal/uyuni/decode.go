@@ -0,0 +1,147 @@
+// Package uyuni contains the models of the Uyuni API responses used by the
+// provider and decodes responses strictly against them.
+package uyuni
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Response is the envelope of every Uyuni API response.
+type Response[T interface{}] struct {
+	Success bool   `json:"success"`
+	Message string `json:"message,omitempty"`
+	Result  T      `json:"result"`
+}
+
+// Fault is a failure reported by the server, either in the body of a response
+// or as an HTTP error status.
+type Fault struct {
+	// StatusCode is the HTTP status of the response, zero if the server
+	// reported the failure in a successful response.
+	StatusCode int
+	Message    string
+}
+
+func (f *Fault) Error() string {
+	switch {
+	case f.Message != "":
+		return f.Message
+	case f.StatusCode != 0:
+		return fmt.Sprintf("unknown error: %d", f.StatusCode)
+	}
+	return "the server reported a failure without a message"
+}
+
+// Decode decodes a Uyuni API response into T.
+//
+// A response the server marked as failed is returned as a *Fault. Fields the
+// server sent that T does not declare, and fields of T the server did not
+// send, are returned as warnings: they usually mean that the server runs an
+// API version the models were not written for. Fields tagged with omitempty
+// are optional and never reported as missing.
+func Decode[T interface{}](data []byte) (*Response[T], []string, error) {
+	var raw struct {
+		Success bool            `json:"success"`
+		Message string          `json:"message"`
+		Result  json.RawMessage `json:"result"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, nil, fmt.Errorf("could not decode response: %w", err)
+	}
+	if !raw.Success {
+		return nil, nil, &Fault{Message: raw.Message}
+	}
+
+	response := Response[T]{Success: raw.Success, Message: raw.Message}
+	if len(raw.Result) == 0 {
+		return &response, nil, nil
+	}
+	if err := json.Unmarshal(raw.Result, &response.Result); err != nil {
+		return nil, nil, fmt.Errorf("could not decode result: %w", err)
+	}
+
+	var warnings []string
+	checkFields(raw.Result, reflect.TypeOf(response.Result), "result", &warnings)
+	sort.Strings(warnings)
+	return &response, warnings, nil
+}
+
+// checkFields compares the JSON value data with the type t it was decoded
+// into and collects unexpected and missing struct fields.
+func checkFields(data json.RawMessage, t reflect.Type, path string, warnings *[]string) {
+	if t == nil || string(data) == "null" {
+		return
+	}
+	if _, ok := reflect.New(t).Interface().(json.Unmarshaler); ok {
+		// Types decoding themselves define their own format.
+		return
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		checkFields(data, t.Elem(), path, warnings)
+	case reflect.Slice, reflect.Array:
+		var items []json.RawMessage
+		if json.Unmarshal(data, &items) != nil {
+			return
+		}
+		for i, item := range items {
+			checkFields(item, t.Elem(), fmt.Sprintf("%s[%d]", path, i), warnings)
+		}
+	case reflect.Map:
+		var items map[string]json.RawMessage
+		if json.Unmarshal(data, &items) != nil {
+			return
+		}
+		for key, item := range items {
+			checkFields(item, t.Elem(), fmt.Sprintf("%s[%q]", path, key), warnings)
+		}
+	case reflect.Struct:
+		var fields map[string]json.RawMessage
+		if json.Unmarshal(data, &fields) != nil {
+			return
+		}
+		known := map[string]bool{}
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			name, optional, ok := jsonField(field)
+			if !ok {
+				continue
+			}
+			known[name] = true
+			value, present := fields[name]
+			if !present {
+				if !optional {
+					*warnings = append(*warnings, fmt.Sprintf("missing field %s.%s", path, name))
+				}
+				continue
+			}
+			checkFields(value, field.Type, path+"."+name, warnings)
+		}
+		for name := range fields {
+			if !known[name] {
+				*warnings = append(*warnings, fmt.Sprintf("unexpected field %s.%s", path, name))
+			}
+		}
+	}
+}
+
+// jsonField returns the JSON name of a struct field and whether it is optional.
+func jsonField(field reflect.StructField) (name string, optional, ok bool) {
+	if !field.IsExported() {
+		return "", false, false
+	}
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return "", false, false
+	}
+	name, options, _ := strings.Cut(tag, ",")
+	if name == "" {
+		name = field.Name
+	}
+	return name, strings.Contains(","+options+",", ",omitempty,"), true
+}
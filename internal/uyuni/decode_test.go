@@ -0,0 +1,176 @@
+package uyuni
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// fixtureDecoders decodes the recorded response of each endpoint with its model.
+var fixtureDecoders = map[string]func([]byte) ([]string, error){
+	"api.systemVersion":                          decodeWarnings[string],
+	"user.listUsers":                             decodeWarnings[[]User],
+	"user.getDetails":                            decodeWarnings[UserDetails],
+	"systemgroup.listAllGroups":                  decodeWarnings[[]SystemGroup],
+	"systemgroup.getDetails":                     decodeWarnings[SystemGroup],
+	"systemgroup.listSystemsMinimal":             decodeWarnings[[]ShortSystem],
+	"systemgroup.listAdministrators":             decodeWarnings[[]User],
+	"system.listSystems":                         decodeWarnings[[]SystemSummary],
+	"system.listInactiveSystems":                 decodeWarnings[[]SystemSummary],
+	"system.getId":                               decodeWarnings[[]SystemSummary],
+	"system.listSystemEvents":                    decodeWarnings[[]SystemEvent],
+	"system.listVirtualHosts":                    decodeWarnings[[]VirtualHost],
+	"system.listVirtualGuests":                   decodeWarnings[[]VirtualGuest],
+	"user.listRoles":                             decodeWarnings[[]string],
+	"user.listAssignedSystemGroups":              decodeWarnings[[]SystemGroup],
+	"channel.listMyChannels":                     decodeWarnings[[]OrgChannel],
+	"channel.listAllChannels":                    decodeWarnings[[]OrgChannel],
+	"channel.listSoftwareChannels":               decodeWarnings[[]SoftwareChannel],
+	"system.search.hostname":                     decodeWarnings[[]SystemSearchResult],
+	"api.getApiNamespaces":                       decodeWarnings[map[string]string],
+	"api.getApiCallList":                         decodeWarnings[map[string]map[string]APICall],
+	"system.getNetwork":                          decodeWarnings[NetworkInfo],
+	"system.listGroups":                          decodeWarnings[[]SystemGroupMembership],
+	"formula.getFormulasByServerId":              decodeWarnings[[]string],
+	"formula.listFormulas":                       decodeWarnings[[]string],
+	"formula.getCombinedFormulaDataByServerIds":  decodeWarnings[[]FormulaData],
+	"system.getDetails":                          decodeWarnings[SystemDetails],
+	"system.getCustomValues":                     decodeWarnings[map[string]string],
+	"system.getRelevantErrata":                   decodeWarnings[[]Erratum],
+	"system.getCoCoAttestationConfig":            decodeWarnings[CocoAttestationConfig],
+	"recurring.lookupById":                       decodeWarnings[RecurringAction],
+	"schedule.listCompletedSystems":              decodeWarnings[[]ActionSystem],
+	"schedule.listFailedSystems":                 decodeWarnings[[]ActionSystem],
+	"schedule.listInProgressSystems":             decodeWarnings[[]ActionSystem],
+	"schedule.listInProgressActions":             decodeWarnings[[]ScheduledAction],
+	"system.getScriptResults":                    decodeWarnings[[]ScriptResult],
+	"system.getInstalledProducts":                decodeWarnings[[]InstalledProduct],
+	"configchannel.listGlobals":                  decodeWarnings[[]ConfigChannel],
+	"configchannel.getDetails":                   decodeWarnings[ConfigChannel],
+	"sync.content.listProducts":                  decodeWarnings[[]Product],
+	"sync.content.listCredentials":               decodeWarnings[[]SCCCredentials],
+	"systemgroup.listAssignedConfigChannels":     decodeWarnings[[]ConfigChannel],
+	"configchannel.getFileRevisions":             decodeWarnings[[]ConfigRevision],
+	"configchannel.listFiles":                    decodeWarnings[[]ConfigFile],
+	"errata.getDetails":                          decodeWarnings[ErratumDetails],
+	"errata.listPackages":                        decodeWarnings[[]ErratumPackage],
+	"errata.listCves":                            decodeWarnings[[]string],
+	"errata.applicableToChannels":                decodeWarnings[[]ErratumChannel],
+	"kickstart.keys.listAllKeys":                 decodeWarnings[[]CryptoKey],
+	"kickstart.keys.getDetails":                  decodeWarnings[CryptoKey],
+	"kickstart.listKickstarts":                   decodeWarnings[[]KickstartProfile],
+	"activationkey.getDetails":                   decodeWarnings[ActivationKey],
+	"activationkey.listActivationKeys":           decodeWarnings[[]ActivationKey],
+	"image.profile.getDetails":                   decodeWarnings[ImageProfile],
+	"image.store.getDetails":                     decodeWarnings[ImageStore],
+	"image.listImages":                           decodeWarnings[[]ImageInfo],
+	"channel.software.getDetails":                decodeWarnings[Channel],
+	"channel.software.getRepoDetails":            decodeWarnings[Repository],
+	"channel.software.listSubscribedSystems":     decodeWarnings[[]SubscribedSystem],
+	"channel.software.listChildren":              decodeWarnings[[]Channel],
+	"channel.software.listErrata":                decodeWarnings[[]Erratum],
+	"channel.software.listAllPackages":           decodeWarnings[[]Package],
+	"packages.findByNvrea":                       decodeWarnings[[]Package],
+	"org.listOrgs":                               decodeWarnings[[]Org],
+	"org.getDetails":                             decodeWarnings[Org],
+	"org.listSystemEntitlementsForOrg":           decodeWarnings[[]EntitlementUsage],
+	"org.listSoftwareEntitlementsForOrg":         decodeWarnings[[]EntitlementUsage],
+	"org.listSystemEntitlements":                 decodeWarnings[[]EntitlementUsage],
+	"maintenance.listScheduleNames":              decodeWarnings[[]string],
+	"maintenance.listSystemsWithSchedule":        decodeWarnings[[]int64],
+	"maintenance.getScheduleDetails":             decodeWarnings[MaintenanceSchedule],
+	"maintenance.getCalendarDetails":             decodeWarnings[MaintenanceCalendar],
+	"org.trusts.listTrusts":                      decodeWarnings[[]OrgTrust],
+	"packages.search.advanced":                   decodeWarnings[[]PackageOverview],
+	"packages.listProvidingChannels":             decodeWarnings[[]ProvidingChannel],
+	"system.provisioning.snapshot.listSnapshots": decodeWarnings[[]Snapshot],
+	"contentmanagement.lookupProject":            decodeWarnings[ContentProject],
+	"contentmanagement.lookupEnvironment":        decodeWarnings[ContentEnvironment],
+	"contentmanagement.lookupSource":             decodeWarnings[ContentSource],
+	"contentmanagement.lookupFilterById":         decodeWarnings[ContentFilter],
+	"contentmanagement.listProjectFilters":       decodeWarnings[[]ContentProjectFilter],
+}
+
+func decodeWarnings[T interface{}](data []byte) ([]string, error) {
+	_, warnings, err := Decode[T](data)
+	return warnings, err
+}
+
+func TestFixtures(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join("testdata", "*", "*.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) == 0 {
+		t.Fatal("no fixtures found")
+	}
+
+	for _, file := range files {
+		version := filepath.Base(filepath.Dir(file))
+		endpoint := strings.TrimSuffix(filepath.Base(file), ".json")
+		t.Run(version+"/"+endpoint, func(t *testing.T) {
+			decode, ok := fixtureDecoders[endpoint]
+			if !ok {
+				t.Fatalf("no model registered for %s", endpoint)
+			}
+			data, err := os.ReadFile(file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			warnings, err := decode(data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, warning := range warnings {
+				t.Error(warning)
+			}
+		})
+	}
+}
+
+func TestDecodeReportsFieldMismatches(t *testing.T) {
+	data := []byte(`{"success": true, "result": [{"id": 1, "login": "admin", "enabled": true, "org_id": 1}]}`)
+
+	response, warnings, err := Decode[[]User](data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(response.Result) != 1 || response.Result[0].Login != "admin" {
+		t.Errorf("unexpected result %+v", response.Result)
+	}
+
+	want := []string{
+		"missing field result[0].login_uc",
+		"unexpected field result[0].org_id",
+	}
+	if !reflect.DeepEqual(warnings, want) {
+		t.Errorf("got warnings %q, want %q", warnings, want)
+	}
+}
+
+func TestDecodeIgnoresOptionalFields(t *testing.T) {
+	data := []byte(`{"success": true, "result": {"description": "internal-repo", "type": "GPG"}}`)
+
+	_, warnings, err := Decode[CryptoKey](data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(warnings) != 0 {
+		t.Errorf("unexpected warnings %q", warnings)
+	}
+}
+
+func TestDecodeFailedResponse(t *testing.T) {
+	_, _, err := Decode[int]([]byte(`{"success": false, "message": "No such user: jdoe"}`))
+	var fault *Fault
+	if !errors.As(err, &fault) || fault.Message != "No such user: jdoe" {
+		t.Errorf("got %v", err)
+	}
+
+	if _, _, err := Decode[int]([]byte(`{"success": true, "result": "one"}`)); err == nil {
+		t.Error("expected a type mismatch to be an error")
+	}
+}
@@ -0,0 +1,810 @@
+package uyuni
+
+import (
+	"encoding/base64"
+	"encoding/json"
+)
+
+// User is a user as returned by user.listUsers.
+type User struct {
+	ID      int    `json:"id"`
+	Login   string `json:"login"`
+	LoginUC string `json:"login_uc"`
+	Enabled bool   `json:"enabled"`
+}
+
+// UserDetails are the details of a user as returned by user.getDetails.
+type UserDetails struct {
+	// FirstNames is the deprecated spelling of FirstName.
+	FirstNames         string `json:"first_names,omitempty"`
+	FirstName          string `json:"first_name"`
+	LastName           string `json:"last_name"`
+	Email              string `json:"email"`
+	OrgID              int    `json:"org_id"`
+	OrgName            string `json:"org_name"`
+	Prefix             string `json:"prefix"`
+	LastLoginDate      string `json:"last_login_date,omitempty"`
+	CreatedDate        string `json:"created_date"`
+	Enabled            bool   `json:"enabled"`
+	UsePAM             bool   `json:"use_pam"`
+	ReadOnly           bool   `json:"read_only"`
+	ErrataNotification bool   `json:"errata_notification"`
+}
+
+// SystemGroup is a system group as returned by the systemgroup namespace.
+type SystemGroup struct {
+	ID          int    `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	OrgID       int    `json:"org_id"`
+	SystemCount int    `json:"system_count"`
+}
+
+// SystemGroupMembership is a system group as returned by system.listGroups,
+// which lists all groups of the organization. Subscribed is 1 for the groups
+// the system is a member of.
+type SystemGroupMembership struct {
+	ID              int    `json:"id"`
+	Subscribed      int    `json:"subscribed"`
+	SystemGroupName string `json:"system_group_name"`
+	SGID            int    `json:"sgid"`
+}
+
+// ShortSystem is a system as returned by systemgroup.listSystemsMinimal.
+type ShortSystem struct {
+	ID          int    `json:"id"`
+	Name        string `json:"name"`
+	LastCheckin string `json:"last_checkin"`
+	Created     string `json:"created"`
+	LastBoot    string `json:"last_boot,omitempty"`
+}
+
+// SystemSummary is a system as returned by system.listSystems,
+// system.listInactiveSystems and system.getId.
+type SystemSummary struct {
+	ID               int    `json:"id"`
+	Name             string `json:"name"`
+	LastCheckin      string `json:"last_checkin"`
+	Created          string `json:"created"`
+	LastBoot         string `json:"last_boot,omitempty"`
+	ExtraPkgCount    int    `json:"extra_pkg_count"`
+	OutdatedPkgCount int    `json:"outdated_pkg_count"`
+}
+
+// InstalledProduct is a product installed on a system as returned by
+// system.getInstalledProducts. Systems report one base product, whose name
+// identifies the distribution, e.g. "SLES" or "ubuntu-client".
+type InstalledProduct struct {
+	Name          string `json:"name"`
+	IsBaseProduct bool   `json:"isBaseProduct"`
+	Version       string `json:"version"`
+	Arch          string `json:"arch"`
+	Release       string `json:"release"`
+	FriendlyName  string `json:"friendlyName"`
+}
+
+// SubscribedSystem is a system as returned by
+// channel.software.listSubscribedSystems.
+type SubscribedSystem struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// SystemSearchResult is a system as returned by the system.search
+// namespace. The hardware fields are only set by searches on devices.
+type SystemSearchResult struct {
+	ID            int    `json:"id"`
+	Name          string `json:"name"`
+	LastCheckin   string `json:"last_checkin"`
+	Hostname      string `json:"hostname"`
+	IP            string `json:"ip"`
+	HWDescription string `json:"hw_description,omitempty"`
+	HWDeviceID    string `json:"hw_device_id,omitempty"`
+	HWVendorID    string `json:"hw_vendor_id,omitempty"`
+	HWDriver      string `json:"hw_driver,omitempty"`
+}
+
+// SystemDetails is a system as returned by system.getDetails. AutoUpdate is
+// whether errata are applied automatically, set by system.setDetails as
+// auto_errata_update.
+type SystemDetails struct {
+	ID                int      `json:"id"`
+	ProfileName       string   `json:"profile_name"`
+	MachineID         string   `json:"machine_id,omitempty"`
+	MinionID          string   `json:"minion_id,omitempty"`
+	BaseEntitlement   string   `json:"base_entitlement"`
+	AddonEntitlements []string `json:"addon_entitlements"`
+	AutoUpdate        bool     `json:"auto_update"`
+	Description       string   `json:"description"`
+	Address1          string   `json:"address1,omitempty"`
+	Address2          string   `json:"address2,omitempty"`
+	City              string   `json:"city,omitempty"`
+	State             string   `json:"state,omitempty"`
+	Country           string   `json:"country,omitempty"`
+	Building          string   `json:"building,omitempty"`
+	Room              string   `json:"room,omitempty"`
+	Rack              string   `json:"rack,omitempty"`
+	Hostname          string   `json:"hostname"`
+	LastBoot          string   `json:"last_boot,omitempty"`
+	OSAStatus         string   `json:"osa_status,omitempty"`
+	LockStatus        bool     `json:"lock_status"`
+	Virtualization    string   `json:"virtualization,omitempty"`
+	ContactMethod     string   `json:"contact_method"`
+	Payg              bool     `json:"payg,omitempty"`
+}
+
+// NetworkInfo is the network configuration of a system as returned by
+// system.getNetwork.
+type NetworkInfo struct {
+	IP       string `json:"ip"`
+	IP6      string `json:"ip6"`
+	Hostname string `json:"hostname"`
+}
+
+// Erratum is an advisory as returned by system.getRelevantErrata and
+// channel.software.listErrata.
+type Erratum struct {
+	ID               int    `json:"id"`
+	Date             string `json:"date"`
+	UpdateDate       string `json:"update_date"`
+	AdvisorySynopsis string `json:"advisory_synopsis"`
+	AdvisoryType     string `json:"advisory_type"`
+	AdvisoryStatus   string `json:"advisory_status,omitempty"`
+	AdvisoryName     string `json:"advisory_name"`
+}
+
+// ErratumDetails is an advisory as returned by errata.getDetails. Severity
+// is only set for security advisories which have one.
+type ErratumDetails struct {
+	ID               int    `json:"id"`
+	IssueDate        string `json:"issue_date"`
+	UpdateDate       string `json:"update_date"`
+	LastModifiedDate string `json:"last_modified_date"`
+	Synopsis         string `json:"synopsis"`
+	Release          int    `json:"release"`
+	AdvisoryStatus   string `json:"advisory_status"`
+	VendorAdvisory   string `json:"vendor_advisory"`
+	Type             string `json:"type"`
+	Product          string `json:"product"`
+	ErrataFrom       string `json:"errataFrom"`
+	Topic            string `json:"topic"`
+	Description      string `json:"description"`
+	References       string `json:"references"`
+	Notes            string `json:"notes"`
+	Solution         string `json:"solution"`
+	RebootSuggested  bool   `json:"reboot_suggested"`
+	RestartSuggested bool   `json:"restart_suggested"`
+	Severity         string `json:"severity,omitempty"`
+}
+
+// ErratumPackage is a package as returned by errata.listPackages.
+type ErratumPackage struct {
+	ID                int      `json:"id"`
+	Name              string   `json:"name"`
+	Epoch             string   `json:"epoch"`
+	Version           string   `json:"version"`
+	Release           string   `json:"release"`
+	ArchLabel         string   `json:"arch_label"`
+	ProvidingChannels []string `json:"providing_channels"`
+	BuildHost         string   `json:"build_host"`
+	Description       string   `json:"description"`
+	Checksum          string   `json:"checksum"`
+	ChecksumType      string   `json:"checksum_type"`
+	Vendor            string   `json:"vendor"`
+	Summary           string   `json:"summary"`
+	Cookie            string   `json:"cookie"`
+	License           string   `json:"license"`
+	Path              string   `json:"path"`
+	File              string   `json:"file"`
+	BuildDate         string   `json:"build_date"`
+	LastModifiedDate  string   `json:"last_modified_date"`
+	Size              string   `json:"size"`
+	PayloadSize       string   `json:"payload_size"`
+}
+
+// NEVRA returns the name-[epoch:]version-release.arch of the package.
+func (p ErratumPackage) NEVRA() string {
+	return Package{Name: p.Name, Epoch: p.Epoch, Version: p.Version, Release: p.Release, ArchLabel: p.ArchLabel}.NEVRA()
+}
+
+// ErratumChannel is a channel as returned by errata.applicableToChannels.
+type ErratumChannel struct {
+	ChannelID          int    `json:"channel_id"`
+	Label              string `json:"label"`
+	Name               string `json:"name"`
+	ParentChannelLabel string `json:"parent_channel_label"`
+}
+
+// Package is a package as returned by channel.software.listAllPackages and
+// packages.findByNvrea, which each add their own optional fields.
+type Package struct {
+	ID               int    `json:"id"`
+	Name             string `json:"name"`
+	Version          string `json:"version"`
+	Release          string `json:"release"`
+	Epoch            string `json:"epoch"`
+	ArchLabel        string `json:"arch_label"`
+	Checksum         string `json:"checksum,omitempty"`
+	ChecksumType     string `json:"checksum_type,omitempty"`
+	LastModifiedDate string `json:"last_modified_date,omitempty"`
+	LastModified     string `json:"last_modified,omitempty"`
+	Path             string `json:"path,omitempty"`
+	Provider         string `json:"provider,omitempty"`
+}
+
+// NEVRA returns the name-[epoch:]version-release.arch of the package.
+func (p Package) NEVRA() string {
+	evr := p.Version + "-" + p.Release
+	if p.Epoch != "" && p.Epoch != "0" {
+		evr = p.Epoch + ":" + evr
+	}
+	return p.Name + "-" + evr + "." + p.ArchLabel
+}
+
+// PackageOverview is a package as returned by packages.search.advanced.
+type PackageOverview struct {
+	ID          int    `json:"id"`
+	Name        string `json:"name"`
+	Epoch       string `json:"epoch"`
+	Version     string `json:"version"`
+	Release     string `json:"release"`
+	Arch        string `json:"arch"`
+	Summary     string `json:"summary"`
+	Description string `json:"description"`
+}
+
+// NEVRA returns the name-[epoch:]version-release.arch of the package.
+func (p PackageOverview) NEVRA() string {
+	return Package{Name: p.Name, Epoch: p.Epoch, Version: p.Version, Release: p.Release, ArchLabel: p.Arch}.NEVRA()
+}
+
+// ProvidingChannel is a channel as returned by packages.listProvidingChannels.
+type ProvidingChannel struct {
+	Label       string `json:"label"`
+	ParentLabel string `json:"parent_label"`
+	Name        string `json:"name"`
+}
+
+// Snapshot is a system snapshot as returned by
+// system.provisioning.snapshot.listSnapshots.
+type Snapshot struct {
+	ID             int      `json:"id"`
+	Reason         string   `json:"reason"`
+	Created        string   `json:"created"`
+	Modified       string   `json:"modified"`
+	Channels       []string `json:"channels"`
+	Groups         []string `json:"groups"`
+	Entitlements   []string `json:"entitlements"`
+	ConfigChannels []string `json:"config_channels"`
+	Tags           []string `json:"tags"`
+	InvalidReason  string   `json:"Invalid_reason,omitempty"`
+}
+
+// CryptoKey is a cryptographic key as returned by the kickstart.keys namespace.
+// The content is only returned by kickstart.keys.getDetails.
+type CryptoKey struct {
+	Description string `json:"description"`
+	Type        string `json:"type"`
+	Content     string `json:"content,omitempty"`
+}
+
+// ActivationKey is an activation key as returned by activationkey.getDetails.
+// BaseChannelLabel is "none" for keys using the default base channel of the
+// registering system and UsageLimit is 0 for unlimited keys.
+type ActivationKey struct {
+	Key                string       `json:"key"`
+	Description        string       `json:"description"`
+	UsageLimit         int          `json:"usage_limit"`
+	BaseChannelLabel   string       `json:"base_channel_label"`
+	ChildChannelLabels []string     `json:"child_channel_labels"`
+	Entitlements       []string     `json:"entitlements"`
+	ServerGroupIDs     []int        `json:"server_group_ids"`
+	PackageNames       []string     `json:"package_names"`
+	Packages           []KeyPackage `json:"packages"`
+	UniversalDefault   bool         `json:"universal_default"`
+	Disabled           bool         `json:"disabled"`
+	ContactMethod      string       `json:"contact_method"`
+}
+
+// KeyPackage is a package installed on systems registering with an
+// activation key. Arch is empty for packages of any architecture.
+type KeyPackage struct {
+	Name string `json:"name"`
+	Arch string `json:"arch,omitempty"`
+}
+
+// String returns the name of the package, followed by the architecture if
+// it has one.
+func (p KeyPackage) String() string {
+	if p.Arch == "" {
+		return p.Name
+	}
+	return p.Name + "." + p.Arch
+}
+
+// ScheduledAction is an action as returned by schedule.listInProgressActions.
+// Scheduler is the login of the user who scheduled the action, empty if the
+// user was deleted.
+type ScheduledAction struct {
+	ID                int    `json:"id"`
+	Name              string `json:"name"`
+	Type              string `json:"type"`
+	Scheduler         string `json:"scheduler,omitempty"`
+	Earliest          string `json:"earliest"`
+	Prerequisite      int    `json:"prerequisite"`
+	CompletedSystems  int    `json:"completedSystems"`
+	FailedSystems     int    `json:"failedSystems"`
+	InProgressSystems int    `json:"inProgressSystems"`
+}
+
+// SystemEvent is an action in the history of a system as returned by
+// system.listSystemEvents. The times and the result message are those of the
+// system, the counts are over all systems of the action.
+type SystemEvent struct {
+	ID              int    `json:"id"`
+	Name            string `json:"name"`
+	ActionType      string `json:"action_type"`
+	Created         string `json:"created"`
+	EarliestAction  string `json:"earliest_action"`
+	PickupTime      string `json:"pickup_time,omitempty"`
+	CompletionTime  string `json:"completion_time,omitempty"`
+	SuccessfulCount int    `json:"successful_count"`
+	FailedCount     int    `json:"failed_count"`
+	ResultMsg       string `json:"result_msg,omitempty"`
+}
+
+// VirtualHost is a virtual host as returned by system.listVirtualHosts,
+// including the hosts reported by virtual host managers.
+type VirtualHost struct {
+	ID          int    `json:"id"`
+	Name        string `json:"name"`
+	LastCheckin string `json:"last_checkin"`
+}
+
+// VirtualGuest is a virtual system as returned by system.listVirtualGuests.
+// ID is that of the registered system of the guest, zero if it is not
+// registered.
+type VirtualGuest struct {
+	ID          int    `json:"id,omitempty"`
+	Name        string `json:"name,omitempty"`
+	GuestName   string `json:"guest_name"`
+	LastCheckin string `json:"last_checkin,omitempty"`
+	UUID        string `json:"uuid"`
+}
+
+// KickstartProfile is an autoinstall profile as returned by
+// kickstart.listKickstarts. Owner is the login of the user owning the
+// profile, empty if it belongs to the organization only.
+type KickstartProfile struct {
+	Label        string `json:"label"`
+	Name         string `json:"name"`
+	TreeLabel    string `json:"tree_label"`
+	AdvancedMode bool   `json:"advanced_mode"`
+	OrgDefault   bool   `json:"org_default"`
+	Active       bool   `json:"active"`
+	UpdateType   string `json:"update_type"`
+	Owner        string `json:"owner,omitempty"`
+}
+
+// APICall is a method as returned by api.getApiCallList, which maps each
+// namespace to its methods keyed by signature. Parameters only carry their
+// types, the first one being the session key.
+type APICall struct {
+	Name       string   `json:"name"`
+	Parameters []string `json:"parameters"`
+	Exceptions []string `json:"exceptions"`
+	Return     string   `json:"return"`
+}
+
+// ActionSystem is a system an action was scheduled for, as returned by
+// schedule.listCompletedSystems, schedule.listFailedSystems and
+// schedule.listInProgressSystems.
+type ActionSystem struct {
+	ServerID    int    `json:"server_id"`
+	ServerName  string `json:"server_name"`
+	BaseChannel string `json:"base_channel"`
+	Timestamp   string `json:"timestamp"`
+	Message     string `json:"message"`
+}
+
+// ScriptResult is the result of a script run on a system as returned by
+// system.getScriptResults. Output is base64 encoded if OutputEnc64 is set.
+type ScriptResult struct {
+	ServerID    int    `json:"serverId"`
+	StartDate   string `json:"startDate"`
+	StopDate    string `json:"stopDate"`
+	ReturnCode  int    `json:"returnCode"`
+	Output      string `json:"output"`
+	OutputEnc64 bool   `json:"output_enc64,omitempty"`
+}
+
+// RecurringAction is a recurring action schedule as returned by
+// recurring.lookupById. Type is the entity type the schedule targets, MINION,
+// GROUP or ORG.
+type RecurringAction struct {
+	ID         int    `json:"id"`
+	Name       string `json:"name"`
+	Type       string `json:"type"`
+	EntityID   int    `json:"entity_id"`
+	EntityName string `json:"entity_name"`
+	Cron       string `json:"cron"`
+	Created    string `json:"created"`
+	Creator    string `json:"creator"`
+	Test       bool   `json:"test"`
+	Active     bool   `json:"active"`
+}
+
+// Channel is a software channel as returned by channel.software.getDetails
+// and channel.software.listChildren. CloneOriginal is empty for channels
+// which are not clones.
+type Channel struct {
+	ID                 int    `json:"id"`
+	Name               string `json:"name"`
+	Label              string `json:"label"`
+	ArchName           string `json:"arch_name"`
+	ArchLabel          string `json:"arch_label"`
+	Summary            string `json:"summary"`
+	Description        string `json:"description"`
+	ChecksumLabel      string `json:"checksum_label"`
+	LastModified       string `json:"last_modified"`
+	MaintainerName     string `json:"maintainer_name"`
+	MaintainerEmail    string `json:"maintainer_email"`
+	MaintainerPhone    string `json:"maintainer_phone"`
+	SupportPolicy      string `json:"support_policy"`
+	GPGKeyURL          string `json:"gpg_key_url"`
+	GPGKeyID           string `json:"gpg_key_id"`
+	GPGKeyFP           string `json:"gpg_key_fp"`
+	GPGCheck           bool   `json:"gpg_check"`
+	YumrepoLastSync    string `json:"yumrepo_last_sync,omitempty"`
+	EndOfLife          string `json:"end_of_life"`
+	ParentChannelLabel string `json:"parent_channel_label"`
+	CloneOriginal      string `json:"clone_original"`
+	ContentSources     []struct {
+		ID        int    `json:"id"`
+		Label     string `json:"label"`
+		SourceURL string `json:"sourceUrl"`
+		Type      string `json:"type"`
+	} `json:"contentSources"`
+}
+
+// Repository is a repository as returned by channel.software.getRepoDetails
+// and created by channel.software.createRepo. The SSL certificates are
+// referenced by the descriptions of their crypto keys.
+type Repository struct {
+	ID                int    `json:"id"`
+	Label             string `json:"label"`
+	SourceURL         string `json:"sourceUrl"`
+	Type              string `json:"type"`
+	HasSignedMetadata bool   `json:"hasSignedMetadata"`
+	SSLContentSources []struct {
+		SSLCaDesc   string `json:"sslCaDesc"`
+		SSLCertDesc string `json:"sslCertDesc,omitempty"`
+		SSLKeyDesc  string `json:"sslKeyDesc,omitempty"`
+	} `json:"sslContentSources"`
+}
+
+// ContentProject is a content lifecycle management project as returned by
+// contentmanagement.lookupProject. FirstEnvironment is the label of the
+// first environment, empty while the project has none.
+type ContentProject struct {
+	ID               int    `json:"id"`
+	Label            string `json:"label"`
+	Name             string `json:"name"`
+	Description      string `json:"description"`
+	OrgID            int    `json:"orgId"`
+	LastBuildDate    string `json:"lastBuildDate,omitempty"`
+	FirstEnvironment string `json:"firstEnvironment,omitempty"`
+}
+
+// ContentEnvironment is an environment of a content lifecycle management
+// project as returned by contentmanagement.lookupEnvironment. Version is the
+// build last promoted to the environment and Status the state of its
+// channels, e.g. "built" or "building".
+type ContentEnvironment struct {
+	ID                       int    `json:"id"`
+	Label                    string `json:"label"`
+	Name                     string `json:"name"`
+	Description              string `json:"description"`
+	Version                  int    `json:"version"`
+	Status                   string `json:"status,omitempty"`
+	ContentProjectLabel      string `json:"contentProjectLabel"`
+	PreviousEnvironmentLabel string `json:"previousEnvironmentLabel,omitempty"`
+	NextEnvironmentLabel     string `json:"nextEnvironmentLabel,omitempty"`
+}
+
+// ContentSource is a source of a content lifecycle management project as
+// returned by contentmanagement.lookupSource. State is ATTACHED or DETACHED
+// until the next build, BUILT afterwards.
+type ContentSource struct {
+	ContentProjectLabel string `json:"contentProjectLabel"`
+	Type                string `json:"type"`
+	State               string `json:"state"`
+	ChannelLabel        string `json:"channelLabel,omitempty"`
+}
+
+// ContentFilter is a content lifecycle management filter as returned by
+// contentmanagement.lookupFilterById.
+type ContentFilter struct {
+	ID         int    `json:"id"`
+	Name       string `json:"name"`
+	OrgID      int    `json:"orgId"`
+	EntityType string `json:"entityType"`
+	Rule       string `json:"rule"`
+	Criteria   struct {
+		Matcher string `json:"matcher"`
+		Field   string `json:"field"`
+		Value   string `json:"value"`
+	} `json:"criteria"`
+}
+
+// ContentProjectFilter is a filter attached to a project as returned by
+// contentmanagement.listProjectFilters, with a State like ContentSource.
+type ContentProjectFilter struct {
+	ContentProjectLabel string        `json:"contentProjectLabel"`
+	State               string        `json:"state"`
+	Filter              ContentFilter `json:"filter"`
+}
+
+// OrgChannel is a software channel as returned by channel.listMyChannels,
+// which lists the channels owned by the organization of the caller, and
+// channel.listAllChannels, which lists all channels the caller can see.
+// Systems is the number of systems subscribed to the channel.
+type OrgChannel struct {
+	ID           int    `json:"id"`
+	Label        string `json:"label"`
+	Name         string `json:"name"`
+	ProviderName string `json:"provider_name"`
+	Packages     int    `json:"packages"`
+	Systems      int    `json:"systems"`
+	ArchName     string `json:"arch_name"`
+}
+
+// SoftwareChannel is a software channel as returned by
+// channel.listSoftwareChannels. ParentLabel is empty for base channels.
+type SoftwareChannel struct {
+	Label       string `json:"label"`
+	Name        string `json:"name"`
+	ParentLabel string `json:"parent_label"`
+	EndOfLife   string `json:"end_of_life"`
+	Arch        string `json:"arch"`
+}
+
+// Product is a product of the product tree as returned by
+// sync.content.listProducts, with the extensions that can be added to it.
+// Status is "INSTALLED" once the product was added to the server.
+type Product struct {
+	FriendlyName string           `json:"friendly_name"`
+	Arch         string           `json:"arch"`
+	Status       string           `json:"status"`
+	Recommended  bool             `json:"recommended"`
+	Channels     []ProductChannel `json:"channels"`
+	Extensions   []Product        `json:"extensions"`
+}
+
+// ProductChannel is a channel of a product. Parent is "BASE" for the base
+// channel of a base product.
+type ProductChannel struct {
+	Arch             string `json:"arch"`
+	Family           string `json:"family"`
+	Label            string `json:"label"`
+	Name             string `json:"name"`
+	Optional         bool   `json:"optional"`
+	Parent           string `json:"parent"`
+	ProductName      string `json:"product_name"`
+	ProductVersion   string `json:"product_version"`
+	SourceURL        string `json:"source_url"`
+	Status           string `json:"status"`
+	Summary          string `json:"summary"`
+	UpdateTag        string `json:"update_tag"`
+	InstallerUpdates bool   `json:"installer_updates"`
+}
+
+// SCCCredentials are organization credentials of the SUSE Customer Center or
+// a mirror as returned by sync.content.listCredentials.
+type SCCCredentials struct {
+	User      string `json:"user"`
+	IsPrimary bool   `json:"isPrimary"`
+}
+
+// ConfigChannel is a configuration channel as returned by
+// configchannel.listGlobals and configchannel.getDetails.
+type ConfigChannel struct {
+	ID                int               `json:"id"`
+	OrgID             int               `json:"orgId"`
+	Label             string            `json:"label"`
+	Name              string            `json:"name"`
+	Description       string            `json:"description"`
+	ConfigChannelType ConfigChannelType `json:"configChannelType"`
+}
+
+// ConfigChannelType is the type of a configuration channel. Custom Salt
+// states are channels of type "state".
+type ConfigChannelType struct {
+	ID       int    `json:"id"`
+	Label    string `json:"label"`
+	Name     string `json:"name"`
+	Priority int    `json:"priority"`
+}
+
+// ConfigFile is a file of a configuration channel as returned by
+// configchannel.listFiles, without its contents.
+type ConfigFile struct {
+	Type         string `json:"type"`
+	Path         string `json:"path"`
+	LastModified string `json:"last_modified"`
+}
+
+// ConfigRevision is a revision of a file in a configuration channel as
+// returned by configchannel.getFileRevisions. Ownership and permissions are
+// only returned for files deployed to systems, not for Salt states.
+type ConfigRevision struct {
+	Type            string `json:"type"`
+	Path            string `json:"path"`
+	TargetPath      string `json:"target_path,omitempty"`
+	Channel         string `json:"channel"`
+	Contents        string `json:"contents,omitempty"`
+	ContentsEnc64   bool   `json:"contents_enc64"`
+	Revision        int    `json:"revision"`
+	Creation        string `json:"creation"`
+	Modified        string `json:"modified"`
+	Owner           string `json:"owner,omitempty"`
+	Group           string `json:"group,omitempty"`
+	Permissions     int    `json:"permissions,omitempty"`
+	PermissionsMode string `json:"permissions_mode,omitempty"`
+	SELinuxCtx      string `json:"selinux_ctx,omitempty"`
+	Binary          bool   `json:"binary"`
+	SHA256          string `json:"sha256,omitempty"`
+	MacroStartDelim string `json:"macro-start-delimiter,omitempty"`
+	MacroEndDelim   string `json:"macro-end-delimiter,omitempty"`
+}
+
+// ImageProfile is an image profile as returned by image.profile.getDetails.
+// Path is the Dockerfile or Kiwi source of the profile.
+type ImageProfile struct {
+	Label         string `json:"label"`
+	ImageType     string `json:"imagetype"`
+	ImageStore    string `json:"imagestore"`
+	ActivationKey string `json:"activation_key,omitempty"`
+	Path          string `json:"path"`
+	KiwiOptions   string `json:"kiwi_options,omitempty"`
+}
+
+// ImageStore is an image store as returned by image.store.getDetails.
+type ImageStore struct {
+	Label     string `json:"label"`
+	URI       string `json:"uri"`
+	StoreType string `json:"storetype"`
+}
+
+// ImageInfo is an image as returned by image.listImages. Checksum is the
+// digest of container images, empty until the image was inspected.
+type ImageInfo struct {
+	ID            int    `json:"id"`
+	Name          string `json:"name"`
+	Version       string `json:"version"`
+	Revision      int    `json:"revision"`
+	Arch          string `json:"arch"`
+	External      bool   `json:"external"`
+	StoreLabel    string `json:"storeLabel"`
+	Checksum      string `json:"checksum,omitempty"`
+	Obsolete      bool   `json:"obsolete,omitempty"`
+	BuildStatus   string `json:"buildStatus,omitempty"`
+	InspectStatus string `json:"inspectStatus,omitempty"`
+}
+
+// FormulaData is the data of a formula for a system as returned by
+// formula.getCombinedFormulaDataByServerIds, the group data merged with the
+// system data.
+type FormulaData struct {
+	SystemID      int                    `json:"system_id"`
+	FormulaValues map[string]interface{} `json:"formula_values"`
+}
+
+// Org is an organization as returned by org.listOrgs and org.getDetails.
+type Org struct {
+	ID                    int    `json:"id"`
+	Name                  string `json:"name"`
+	ActiveUsers           int    `json:"active_users"`
+	Systems               int    `json:"systems"`
+	Trusts                int    `json:"trusts"`
+	SystemGroups          int    `json:"system_groups"`
+	ActivationKeys        int    `json:"activation_keys"`
+	KickstartProfiles     int    `json:"kickstart_profiles"`
+	ConfigurationChannels int    `json:"configuration_channels"`
+	StagingContentEnabled bool   `json:"staging_content_enabled"`
+}
+
+// EntitlementUsage is the usage of a system entitlement as returned by
+// org.listSystemEntitlements for the server and by
+// org.listSystemEntitlementsForOrg for an organization, and of a channel
+// family as returned by org.listSoftwareEntitlementsForOrg.
+type EntitlementUsage struct {
+	Label       string `json:"label"`
+	Name        string `json:"name"`
+	Allocated   int    `json:"allocated"`
+	Unallocated int    `json:"unallocated"`
+	Free        int    `json:"free"`
+	Used        int    `json:"used"`
+}
+
+// OrgTrust is an organization as returned by org.trusts.listTrusts, which
+// lists all other organizations and whether they are trusted.
+type OrgTrust struct {
+	OrgID        int    `json:"orgId"`
+	OrgName      string `json:"orgName"`
+	TrustEnabled bool   `json:"trustEnabled"`
+}
+
+// CocoAttestationConfig is the confidential computing attestation configuration
+// of a system. Both the snake_case and camelCase spelling of the keys are
+// accepted; use the accessor methods to read them.
+type CocoAttestationConfig struct {
+	Enabled              bool   `json:"enabled"`
+	EnvironmentTypeSnake string `json:"environment_type,omitempty"`
+	EnvironmentTypeCamel string `json:"environmentType,omitempty"`
+	AttestOnBootSnake    *bool  `json:"attest_on_boot,omitempty"`
+	AttestOnBootCamel    *bool  `json:"attestOnBoot,omitempty"`
+}
+
+// EnvironmentType returns the environment type regardless of the key spelling used by the server.
+func (c CocoAttestationConfig) EnvironmentType() string {
+	if c.EnvironmentTypeCamel != "" {
+		return c.EnvironmentTypeCamel
+	}
+	return c.EnvironmentTypeSnake
+}
+
+// AttestOnBoot returns the boot flag regardless of the key spelling used by the server.
+func (c CocoAttestationConfig) AttestOnBoot() bool {
+	if c.AttestOnBootCamel != nil {
+		return *c.AttestOnBootCamel
+	}
+	return c.AttestOnBootSnake != nil && *c.AttestOnBootSnake
+}
+
+// MaintenanceSchedule is a maintenance schedule as returned by
+// maintenance.getScheduleDetails. Multi schedules share their calendar with
+// others and only use the events named after them.
+type MaintenanceSchedule struct {
+	ID       int    `json:"id"`
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Calendar string `json:"calendar,omitempty"`
+}
+
+// MaintenanceCalendar is a maintenance calendar as returned by
+// maintenance.getCalendarDetails.
+type MaintenanceCalendar struct {
+	ID    int    `json:"id"`
+	Label string `json:"label"`
+	ICal  string `json:"ical"`
+	URL   string `json:"url,omitempty"`
+}
+
+// Bytes decodes binary results, which the server may return either as a
+// base64 string or as an array of byte values.
+type Bytes []byte
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (b *Bytes) UnmarshalJSON(data []byte) error {
+	var encoded string
+	if err := json.Unmarshal(data, &encoded); err == nil {
+		decoded, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return err
+		}
+		*b = decoded
+		return nil
+	}
+
+	var values []int
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+	decoded := make([]byte, len(values))
+	for i, v := range values {
+		decoded[i] = byte(v)
+	}
+	*b = decoded
+	return nil
+}
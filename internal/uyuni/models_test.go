@@ -0,0 +1,65 @@
+package uyuni
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestBytesUnmarshal(t *testing.T) {
+	for name, body := range map[string]string{
+		"base64": `"AQID"`,
+		"array":  `[1, 2, 3]`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			var b Bytes
+			if err := json.Unmarshal([]byte(body), &b); err != nil {
+				t.Fatal(err)
+			}
+			if string(b) != "\x01\x02\x03" {
+				t.Errorf("got %v, want [1 2 3]", []byte(b))
+			}
+		})
+	}
+
+	var b Bytes
+	if err := json.Unmarshal([]byte(`{"foo": 1}`), &b); err == nil {
+		t.Error("expected an error for an object")
+	}
+}
+
+func TestCocoAttestationConfigSpellings(t *testing.T) {
+	for name, body := range map[string]string{
+		"snake_case": `{"enabled": true, "environment_type": "KVM_AMD_EPYC_GENOA", "attest_on_boot": true}`,
+		"camelCase":  `{"enabled": true, "environmentType": "KVM_AMD_EPYC_GENOA", "attestOnBoot": true}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			var config CocoAttestationConfig
+			if err := json.Unmarshal([]byte(body), &config); err != nil {
+				t.Fatal(err)
+			}
+			if !config.Enabled {
+				t.Error("expected enabled to be true")
+			}
+			if got := config.EnvironmentType(); got != "KVM_AMD_EPYC_GENOA" {
+				t.Errorf("environment type: got %q", got)
+			}
+			if !config.AttestOnBoot() {
+				t.Error("expected attest on boot to be true")
+			}
+		})
+	}
+}
+
+func TestPackageNEVRA(t *testing.T) {
+	pkg := Package{Name: "vim", Version: "9.1", Release: "1.1", ArchLabel: "x86_64"}
+	for epoch, want := range map[string]string{
+		"":  "vim-9.1-1.1.x86_64",
+		"0": "vim-9.1-1.1.x86_64",
+		"2": "vim-2:9.1-1.1.x86_64",
+	} {
+		pkg.Epoch = epoch
+		if got := pkg.NEVRA(); got != want {
+			t.Errorf("epoch %q: got %s, want %s", epoch, got, want)
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package validators
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// configValidator validates the configuration of a resource as a whole, for
+// constraints between attributes that the server only enforces during apply.
+type configValidator struct {
+	description string
+	check       func(ctx context.Context, config tfsdk.Config, resp *resource.ValidateConfigResponse)
+}
+
+var _ resource.ConfigValidator = configValidator{}
+
+// Description implements resource.ConfigValidator.
+func (v configValidator) Description(_ context.Context) string {
+	return v.description
+}
+
+// MarkdownDescription implements resource.ConfigValidator.
+func (v configValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateResource implements resource.ConfigValidator.
+func (v configValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	v.check(ctx, req.Config, resp)
+}
+
+// ConflictsWhenTrue returns a validator rejecting the attributes when the
+// bool attribute flag is true, e.g. a password for users authenticated by PAM.
+func ConflictsWhenTrue(flag string, attributes ...string) resource.ConfigValidator {
+	return configValidator{
+		description: fmt.Sprintf("%s cannot be set when %s is true", strings.Join(attributes, ", "), flag),
+		check: func(ctx context.Context, config tfsdk.Config, resp *resource.ValidateConfigResponse) {
+			enabled, known := boolAttribute(ctx, config, flag, resp)
+			if !known || !enabled {
+				return
+			}
+			for _, name := range attributes {
+				if value := configValue(ctx, config, name, resp); value != nil && !value.IsNull() {
+					resp.Diagnostics.AddAttributeError(
+						path.Root(name),
+						"Conflicting Attribute Configuration",
+						fmt.Sprintf("Attribute %s cannot be set when %s is true.", name, flag),
+					)
+				}
+			}
+		},
+	}
+}
+
+// RequiredUnlessTrue returns a validator requiring the attribute unless the
+// bool attribute flag is true. An unset flag counts as false.
+func RequiredUnlessTrue(flag, attribute string) resource.ConfigValidator {
+	return configValidator{
+		description: fmt.Sprintf("%s must be set unless %s is true", attribute, flag),
+		check: func(ctx context.Context, config tfsdk.Config, resp *resource.ValidateConfigResponse) {
+			enabled, known := boolAttribute(ctx, config, flag, resp)
+			if !known || enabled {
+				return
+			}
+			if value := configValue(ctx, config, attribute, resp); value != nil && value.IsNull() {
+				resp.Diagnostics.AddAttributeError(
+					path.Root(attribute),
+					"Missing Attribute Configuration",
+					fmt.Sprintf("Attribute %s must be set unless %s is true.", attribute, flag),
+				)
+			}
+		},
+	}
+}
+
+// AlsoRequires returns a validator requiring the attribute required whenever
+// one of the dependent attributes is set, because they only take effect on
+// the object it refers to.
+func AlsoRequires(required string, dependents ...string) resource.ConfigValidator {
+	return configValidator{
+		description: fmt.Sprintf("%s must be set to use %s", required, strings.Join(dependents, ", ")),
+		check: func(ctx context.Context, config tfsdk.Config, resp *resource.ValidateConfigResponse) {
+			value := configValue(ctx, config, required, resp)
+			if value == nil || !value.IsNull() {
+				return
+			}
+			for _, name := range dependents {
+				if dependent := configValue(ctx, config, name, resp); dependent != nil && !dependent.IsNull() {
+					resp.Diagnostics.AddAttributeError(
+						path.Root(name),
+						"Missing Attribute Configuration",
+						fmt.Sprintf("Attribute %s only takes effect when %s is set.", name, required),
+					)
+				}
+			}
+		},
+	}
+}
+
+// configValue returns the configured value of a root attribute, or nil if it
+// is unknown or could not be read.
+func configValue(ctx context.Context, config tfsdk.Config, name string, resp *resource.ValidateConfigResponse) attr.Value {
+	var value attr.Value
+	diags := config.GetAttribute(ctx, path.Root(name), &value)
+	resp.Diagnostics.Append(diags...)
+	if diags.HasError() || value.IsUnknown() {
+		return nil
+	}
+	return value
+}
+
+// boolAttribute returns the configured value of a bool root attribute and
+// whether it is known. An unset attribute is false.
+func boolAttribute(ctx context.Context, config tfsdk.Config, name string, resp *resource.ValidateConfigResponse) (value, known bool) {
+	var flag types.Bool
+	diags := config.GetAttribute(ctx, path.Root(name), &flag)
+	resp.Diagnostics.Append(diags...)
+	if diags.HasError() || flag.IsUnknown() {
+		return false, false
+	}
+	return flag.ValueBool(), true
+}
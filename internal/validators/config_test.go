@@ -0,0 +1,68 @@
+package validators
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+var testConfigSchema = schema.Schema{
+	Attributes: map[string]schema.Attribute{
+		"use_pam":   schema.BoolAttribute{Optional: true},
+		"password":  schema.StringAttribute{Optional: true},
+		"server_id": schema.Int64Attribute{Optional: true},
+		"naming":    schema.StringAttribute{Optional: true},
+	},
+}
+
+// testConfig returns a configuration of testConfigSchema with the given
+// values, all other attributes being null.
+func testConfig(values map[string]tftypes.Value) tfsdk.Config {
+	objectType := testConfigSchema.Type().TerraformType(context.Background()).(tftypes.Object)
+	attributes := map[string]tftypes.Value{}
+	for name, attributeType := range objectType.AttributeTypes {
+		attributes[name] = tftypes.NewValue(attributeType, nil)
+		if value, ok := values[name]; ok {
+			attributes[name] = value
+		}
+	}
+	return tfsdk.Config{Schema: testConfigSchema, Raw: tftypes.NewValue(objectType, attributes)}
+}
+
+func TestConfigValidators(t *testing.T) {
+	pam := tftypes.NewValue(tftypes.Bool, true)
+	noPAM := tftypes.NewValue(tftypes.Bool, false)
+	unknownPAM := tftypes.NewValue(tftypes.Bool, tftypes.UnknownValue)
+	password := tftypes.NewValue(tftypes.String, "secret")
+	serverID := tftypes.NewValue(tftypes.Number, 1000010000)
+	naming := tftypes.NewValue(tftypes.String, "FQDN")
+
+	for name, tc := range map[string]struct {
+		validator resource.ConfigValidator
+		values    map[string]tftypes.Value
+		wantError bool
+	}{
+		"conflict with flag":       {ConflictsWhenTrue("use_pam", "password"), map[string]tftypes.Value{"use_pam": pam, "password": password}, true},
+		"no conflict without flag": {ConflictsWhenTrue("use_pam", "password"), map[string]tftypes.Value{"use_pam": noPAM, "password": password}, false},
+		"conflict unknown flag":    {ConflictsWhenTrue("use_pam", "password"), map[string]tftypes.Value{"use_pam": unknownPAM, "password": password}, false},
+		"required without flag":    {RequiredUnlessTrue("use_pam", "password"), map[string]tftypes.Value{}, true},
+		"required with flag false": {RequiredUnlessTrue("use_pam", "password"), map[string]tftypes.Value{"use_pam": noPAM}, true},
+		"not required with flag":   {RequiredUnlessTrue("use_pam", "password"), map[string]tftypes.Value{"use_pam": pam}, false},
+		"required and set":         {RequiredUnlessTrue("use_pam", "password"), map[string]tftypes.Value{"password": password}, false},
+		"dependent without target": {AlsoRequires("server_id", "naming"), map[string]tftypes.Value{"naming": naming}, true},
+		"dependent with target":    {AlsoRequires("server_id", "naming"), map[string]tftypes.Value{"naming": naming, "server_id": serverID}, false},
+		"no dependents":            {AlsoRequires("server_id", "naming"), map[string]tftypes.Value{}, false},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var resp resource.ValidateConfigResponse
+			tc.validator.ValidateResource(context.Background(), resource.ValidateConfigRequest{Config: testConfig(tc.values)}, &resp)
+			if resp.Diagnostics.HasError() != tc.wantError {
+				t.Errorf("got diagnostics %v, want error: %v", resp.Diagnostics, tc.wantError)
+			}
+		})
+	}
+}
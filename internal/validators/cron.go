@@ -0,0 +1,144 @@
+package validators
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// cronField describes one field of a Quartz cron expression.
+type cronField struct {
+	name     string
+	min, max int
+	names    []string // names of the values, starting at min
+}
+
+var cronFields = []cronField{
+	{name: "seconds", min: 0, max: 59},
+	{name: "minutes", min: 0, max: 59},
+	{name: "hours", min: 0, max: 23},
+	{name: "day of month", min: 1, max: 31},
+	{name: "month", min: 1, max: 12, names: []string{"JAN", "FEB", "MAR", "APR", "MAY", "JUN", "JUL", "AUG", "SEP", "OCT", "NOV", "DEC"}},
+	{name: "day of week", min: 1, max: 7, names: []string{"SUN", "MON", "TUE", "WED", "THU", "FRI", "SAT"}},
+	{name: "year", min: 1970, max: 2099},
+}
+
+const (
+	cronDayOfMonth = 3
+	cronDayOfWeek  = 5
+)
+
+// Cron returns a validator which ensures that the value is a Quartz cron
+// expression as used by Uyuni schedules, e.g. "0 0 23 ? * MON-FRI".
+func Cron() validator.String {
+	return stringValidator{
+		description: "must be a Quartz cron expression with seconds, minutes, hours, day of month, month, day of week and an optional year",
+		check:       checkCron,
+	}
+}
+
+func checkCron(expression string) error {
+	fields := strings.Fields(expression)
+	if len(fields) != 6 && len(fields) != 7 {
+		return fmt.Errorf("expected 6 or 7 fields, got %d", len(fields))
+	}
+
+	for i, value := range fields {
+		if err := checkCronField(value, i); err != nil {
+			return fmt.Errorf("invalid %s %q: %w", cronFields[i].name, value, err)
+		}
+	}
+
+	if (fields[cronDayOfMonth] == "?") == (fields[cronDayOfWeek] == "?") {
+		return errors.New("exactly one of day of month and day of week must be ?")
+	}
+	return nil
+}
+
+func checkCronField(value string, index int) error {
+	field := cronFields[index]
+	if value == "?" {
+		if index != cronDayOfMonth && index != cronDayOfWeek {
+			return errors.New("? is only allowed for day of month and day of week")
+		}
+		return nil
+	}
+
+	for _, part := range strings.Split(value, ",") {
+		if err := checkCronPart(part, index, field); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func checkCronPart(part string, index int, field cronField) error {
+	switch {
+	case index == cronDayOfMonth && (part == "L" || part == "LW"):
+		return nil
+	case index == cronDayOfMonth && strings.HasPrefix(part, "L-"):
+		_, err := cronValue(strings.TrimPrefix(part, "L-"), cronField{min: 0, max: 30})
+		return err
+	case index == cronDayOfMonth && strings.HasSuffix(part, "W"):
+		_, err := cronValue(strings.TrimSuffix(part, "W"), field)
+		return err
+	case index == cronDayOfWeek && part == "L":
+		return nil
+	case index == cronDayOfWeek && strings.HasSuffix(part, "L"):
+		_, err := cronValue(strings.TrimSuffix(part, "L"), field)
+		return err
+	case index == cronDayOfWeek && strings.Contains(part, "#"):
+		day, nth, _ := strings.Cut(part, "#")
+		if _, err := cronValue(day, field); err != nil {
+			return err
+		}
+		_, err := cronValue(nth, cronField{min: 1, max: 5})
+		return err
+	}
+
+	base, step, hasStep := strings.Cut(part, "/")
+	if hasStep {
+		if _, err := cronValue(step, cronField{min: 1, max: field.max}); err != nil {
+			return fmt.Errorf("invalid step: %w", err)
+		}
+	}
+	if base == "*" {
+		return nil
+	}
+
+	from, to, isRange := strings.Cut(base, "-")
+	start, err := cronValue(from, field)
+	if err != nil {
+		return err
+	}
+	if isRange {
+		end, err := cronValue(to, field)
+		if err != nil {
+			return err
+		}
+		if end < start && index != cronDayOfWeek {
+			return fmt.Errorf("range %s ends before it starts", base)
+		}
+	}
+	return nil
+}
+
+// cronValue parses a single numeric or named value of a field.
+func cronValue(value string, field cronField) (int, error) {
+	for i, name := range field.names {
+		if strings.EqualFold(value, name) {
+			return field.min + i, nil
+		}
+	}
+	number, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a number", value)
+	}
+	if number < field.min || number > field.max {
+		return 0, fmt.Errorf("%d is not between %d and %d", number, field.min, field.max)
+	}
+	return number, nil
+}
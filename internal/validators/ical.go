@@ -0,0 +1,76 @@
+package validators
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// ICal returns a validator which ensures that the value is an iCalendar
+// document with at least one event, as used by maintenance calendars.
+func ICal() validator.String {
+	return stringValidator{
+		description: "must be an iCalendar document containing at least one VEVENT",
+		check:       checkICal,
+	}
+}
+
+func checkICal(document string) error {
+	lines := UnfoldICal(document)
+	if len(lines) == 0 || !strings.EqualFold(lines[0], "BEGIN:VCALENDAR") {
+		return errors.New("the document must start with BEGIN:VCALENDAR")
+	}
+
+	var components []string
+	events := 0
+	for number, line := range lines {
+		name, value, ok := strings.Cut(line, ":")
+		if !ok || name == "" {
+			return fmt.Errorf("line %d is not a content line: %q", number+1, line)
+		}
+		// Parameters follow the property name, separated by semicolons.
+		name, _, _ = strings.Cut(name, ";")
+
+		switch strings.ToUpper(name) {
+		case "BEGIN":
+			components = append(components, strings.ToUpper(value))
+			if strings.EqualFold(value, "VEVENT") {
+				events++
+			}
+		case "END":
+			if len(components) == 0 || components[len(components)-1] != strings.ToUpper(value) {
+				return fmt.Errorf("line %d ends %s, which is not open", number+1, value)
+			}
+			components = components[:len(components)-1]
+			if len(components) == 0 && number != len(lines)-1 {
+				return fmt.Errorf("line %d: content after END:VCALENDAR", number+2)
+			}
+		}
+	}
+
+	if len(components) != 0 {
+		return fmt.Errorf("%s is not closed", components[len(components)-1])
+	}
+	if events == 0 {
+		return errors.New("the calendar does not contain any VEVENT")
+	}
+	return nil
+}
+
+// UnfoldICal splits an iCalendar document into content lines, joining folded
+// lines and dropping empty ones.
+func UnfoldICal(document string) []string {
+	var lines []string
+	for _, line := range strings.Split(strings.ReplaceAll(document, "\r\n", "\n"), "\n") {
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		if strings.TrimSpace(line) != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
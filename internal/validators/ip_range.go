@@ -0,0 +1,51 @@
+package validators
+
+import (
+	"fmt"
+	"net/netip"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// IPRange returns a validator which ensures that the value is an IP address,
+// a CIDR prefix like "10.0.0.0/24" or a range like "10.0.0.10-10.0.0.20".
+func IPRange() validator.String {
+	return stringValidator{
+		description: "must be an IP address, a CIDR prefix or a range of two addresses separated by -",
+		check:       checkIPRange,
+	}
+}
+
+func checkIPRange(value string) error {
+	if strings.Contains(value, "/") {
+		prefix, err := netip.ParsePrefix(value)
+		if err != nil {
+			return err
+		}
+		if prefix.Masked() != prefix {
+			return fmt.Errorf("%s has host bits set, did you mean %s?", value, prefix.Masked())
+		}
+		return nil
+	}
+
+	from, to, isRange := strings.Cut(value, "-")
+	start, err := netip.ParseAddr(strings.TrimSpace(from))
+	if err != nil {
+		return err
+	}
+	if !isRange {
+		return nil
+	}
+	end, err := netip.ParseAddr(strings.TrimSpace(to))
+	if err != nil {
+		return err
+	}
+	if start.Is4() != end.Is4() {
+		return fmt.Errorf("%s and %s are of different address families", start, end)
+	}
+	if end.Less(start) {
+		return fmt.Errorf("the range ends at %s before it starts at %s", end, start)
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+package validators
+
+import (
+	"errors"
+	"fmt"
+	"net/mail"
+	"regexp"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+var (
+	loginPattern        = regexp.MustCompile(`^[[:alnum:]._@+-]+$`)
+	channelLabelPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]*$`)
+	apiEndpointPattern  = regexp.MustCompile(`^[a-z][A-Za-z]*(/[a-z][A-Za-z]*)*/[a-z][A-Za-z0-9]*(\?.*)?$`)
+)
+
+const (
+	maxLoginLength        = 64
+	minChannelLabelLength = 6
+	maxChannelLabelLength = 128
+)
+
+// Email returns a validator which ensures that the value is a plain email
+// address, without display name.
+func Email() validator.String {
+	return stringValidator{
+		description: "must be a valid email address",
+		check: func(value string) error {
+			address, err := mail.ParseAddress(value)
+			if err != nil {
+				return err
+			}
+			if address.Address != value {
+				return errors.New("the address must not contain a display name or angle brackets")
+			}
+			return nil
+		},
+	}
+}
+
+// Login returns a validator which ensures that the value is a valid Uyuni
+// user login.
+func Login() validator.String {
+	return stringValidator{
+		description: fmt.Sprintf("must be at most %d letters, digits or . _ - @ + characters", maxLoginLength),
+		check: func(value string) error {
+			if len(value) > maxLoginLength {
+				return fmt.Errorf("the login is %d characters long", len(value))
+			}
+			if !loginPattern.MatchString(value) {
+				return errors.New("the login contains unsupported characters")
+			}
+			return nil
+		},
+	}
+}
+
+// ChannelLabel returns a validator which ensures that the value is a valid
+// software channel label.
+func ChannelLabel() validator.String {
+	return stringValidator{
+		description: fmt.Sprintf("must be a channel label of %d to %d lowercase letters, digits or . _ - characters, starting with a letter or digit",
+			minChannelLabelLength, maxChannelLabelLength),
+		check: func(value string) error {
+			if len(value) < minChannelLabelLength || len(value) > maxChannelLabelLength {
+				return fmt.Errorf("the label is %d characters long", len(value))
+			}
+			if !channelLabelPattern.MatchString(value) {
+				return errors.New("the label contains unsupported characters")
+			}
+			return nil
+		},
+	}
+}
+
+// APIEndpoint returns a validator which ensures that the value is the path of
+// an API call relative to the API root, e.g. system/getDetails?sid=1000010000.
+func APIEndpoint() validator.String {
+	return stringValidator{
+		description: "must be an API call as namespace/method, optionally followed by a query, e.g. system/getDetails?sid=1000010000",
+		check: func(value string) error {
+			if !apiEndpointPattern.MatchString(value) {
+				return errors.New("the value is not a namespace and method")
+			}
+			return nil
+		},
+	}
+}
+
+// DateLayout is the layout of dates validated by Date.
+const DateLayout = "2006-01-02"
+
+// Date returns a validator which ensures that the value is a calendar date
+// in the format YYYY-MM-DD.
+func Date() validator.String {
+	return stringValidator{
+		description: "must be a date in the format YYYY-MM-DD",
+		check: func(value string) error {
+			_, err := time.Parse(DateLayout, value)
+			return err
+		},
+	}
+}
+
+// Timestamp returns a validator which ensures that the value is a date and
+// time in RFC 3339 format, e.g. 2030-01-01T02:00:00Z.
+func Timestamp() validator.String {
+	return stringValidator{
+		description: "must be a date and time in RFC 3339 format, e.g. 2030-01-01T02:00:00Z",
+		check: func(value string) error {
+			_, err := time.Parse(time.RFC3339, value)
+			return err
+		},
+	}
+}
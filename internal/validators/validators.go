@@ -0,0 +1,43 @@
+// Package validators contains schema validators for values with a format
+// defined by Uyuni, so that invalid input fails at plan time instead of
+// being rejected by the API during apply.
+package validators
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+var _ validator.String = stringValidator{}
+
+// stringValidator validates string values with a check function.
+type stringValidator struct {
+	description string
+	check       func(string) error
+}
+
+// Description implements validator.Describer.
+func (v stringValidator) Description(_ context.Context) string {
+	return v.description
+}
+
+// MarkdownDescription implements validator.Describer.
+func (v stringValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateString implements validator.String.
+func (v stringValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if err := v.check(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Attribute Value",
+			"Attribute "+req.Path.String()+" "+v.Description(ctx)+", got: "+req.ConfigValue.String()+"\n\n"+err.Error(),
+		)
+	}
+}
@@ -0,0 +1,118 @@
+package validators
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func validate(v validator.String, value types.String) bool {
+	resp := &validator.StringResponse{}
+	v.ValidateString(context.Background(), validator.StringRequest{
+		Path:        path.Root("test"),
+		ConfigValue: value,
+	}, resp)
+	return !resp.Diagnostics.HasError()
+}
+
+func TestValidators(t *testing.T) {
+	tests := map[string]struct {
+		validator validator.String
+		valid     []string
+		invalid   []string
+	}{
+		"Email": {
+			validator: Email(),
+			valid:     []string{"admin@example.com", "first.last+uyuni@sub.example.org"},
+			invalid:   []string{"", "admin", "Admin <admin@example.com>", "admin@"},
+		},
+		"Login": {
+			validator: Login(),
+			valid:     []string{"admin", "j.doe", "ci-bot_01", "jdoe@example.com"},
+			invalid:   []string{"", "j doe", "jdoe;", "ädmin", string(make([]byte, 65))},
+		},
+		"ChannelLabel": {
+			validator: ChannelLabel(),
+			valid:     []string{"sles15-sp6-pool-x86_64", "dev-sles15-sp6.updates"},
+			invalid:   []string{"pool", "SLES15-SP6-Pool", "-sles15-sp6", "sles15 sp6 pool"},
+		},
+		"APIEndpoint": {
+			validator: APIEndpoint(),
+			valid:     []string{"system/getDetails?sid=1000010000", "kickstart/profile/system/getPartitioningScheme", "api/systemVersion"},
+			invalid:   []string{"", "getDetails", "/system/getDetails", "system/getDetails/", "https://uyuni.example.com/rhn/manager/api/system/listSystems"},
+		},
+		"Date": {
+			validator: Date(),
+			valid:     []string{"2024-01-01", "2024-02-29"},
+			invalid:   []string{"", "2023-02-29", "2024-1-1", "01.01.2024", "2024-01-01T00:00:00Z"},
+		},
+		"Timestamp": {
+			validator: Timestamp(),
+			valid:     []string{"2030-01-01T02:00:00Z", "2030-01-01T03:00:00+01:00"},
+			invalid:   []string{"", "2030-01-01", "2030-01-01 02:00:00", "2030-01-01T02:00:00"},
+		},
+		"Cron": {
+			validator: Cron(),
+			valid: []string{
+				"0 0 23 ? * *",
+				"0 */15 * * * ?",
+				"0 30 2 ? * MON-FRI",
+				"0 0 4 L * ?",
+				"0 0 4 15W * ?",
+				"0 0 4 ? * 6#3",
+				"0 0 4 ? JAN,JUL 1L 2030",
+			},
+			invalid: []string{
+				"",
+				"0 0 23 * *",
+				"0 0 23 * * *",
+				"0 0 23 ? * ?",
+				"0 60 23 ? * *",
+				"0 0 23 ? FOO *",
+				"0 0 23 ? * 8",
+				"0 0 10-2 ? * *",
+				"0 0/0 * ? * *",
+				"0 0 4 ? * 6#6",
+			},
+		},
+		"ICal": {
+			validator: ICal(),
+			valid: []string{
+				"BEGIN:VCALENDAR\r\nVERSION:2.0\r\nBEGIN:VEVENT\r\nDTSTART;TZID=Europe/Berlin:20240101T220000\r\nSUMMARY:Patch\r\n window\r\nEND:VEVENT\r\nEND:VCALENDAR\r\n",
+			},
+			invalid: []string{
+				"",
+				"BEGIN:VEVENT\nEND:VEVENT\n",
+				"BEGIN:VCALENDAR\nVERSION:2.0\nEND:VCALENDAR\n",
+				"BEGIN:VCALENDAR\nBEGIN:VEVENT\nEND:VCALENDAR\n",
+				"BEGIN:VCALENDAR\nBEGIN:VEVENT\nno colon\nEND:VEVENT\nEND:VCALENDAR\n",
+			},
+		},
+		"IPRange": {
+			validator: IPRange(),
+			valid:     []string{"10.0.0.1", "10.0.0.0/24", "10.0.0.10-10.0.0.20", "2001:db8::/32", "2001:db8::1-2001:db8::ff"},
+			invalid:   []string{"", "10.0.0.1/24", "10.0.0.300", "10.0.0.20-10.0.0.10", "10.0.0.1-2001:db8::1", "example.com"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			for _, value := range test.valid {
+				if !validate(test.validator, types.StringValue(value)) {
+					t.Errorf("expected %q to be valid", value)
+				}
+			}
+			for _, value := range test.invalid {
+				if validate(test.validator, types.StringValue(value)) {
+					t.Errorf("expected %q to be invalid", value)
+				}
+			}
+			if !validate(test.validator, types.StringNull()) || !validate(test.validator, types.StringUnknown()) {
+				t.Error("expected null and unknown values to be skipped")
+			}
+		})
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"context"
 	"flag"
 	"log"
+	"time"
 
 	"terraform-provider-uyuni/internal/provider"
 
@@ -40,6 +41,11 @@ func main() {
 
 	err := providerserver.Serve(context.Background(), provider.New(version), opts)
 
+	// Spans are sent in batches, send the last ones before exiting.
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	provider.FlushTraces(ctx)
+	cancel()
+
 	if err != nil {
 		log.Fatal(err.Error())
 	}